@@ -0,0 +1,256 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"github.com/greenplum-db/gpdb/gpservice/constants"
+	"github.com/greenplum-db/gpdb/gpservice/idl"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/gpservice_config"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/gpservice_mgmt"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/postgres"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+)
+
+var (
+	ActivateStandbyService     = ActivateStandbyServiceFn
+	PromoteStandby             = PromoteStandbyFn
+	CheckOldCoordinatorStopped = CheckOldCoordinatorStoppedFn
+	MoveHubService             = gpservice_mgmt.MoveHubService
+)
+
+var activateStandbyForce bool
+
+// oldCoordinatorDialTimeout bounds how long the old coordinator host is waited on to answer
+const oldCoordinatorDialTimeout = 5 * time.Second
+
+func activateStandbyCmd() *cobra.Command {
+	activateStandbyCmd := &cobra.Command{
+		Use:   "activate-standby",
+		Short: "Activates the standby coordinator as the coordinator of a Greenplum Database system",
+		Long: `Activates the standby coordinator as the coordinator of a Greenplum Database system.
+The command must be run on the standby coordinator host. The standby coordinator is promoted,
+after which the hub service is moved to the standby coordinator host. The standby coordinator
+is not promoted while the old coordinator host is reachable, unless --force is given.`,
+		Example: `To activate the standby coordinator running on the local host
+$ gpctl activate-standby --coordinator-data-directory /data/standby/gpseg-1
+`,
+		RunE: RunActivateStandbyCmd,
+	}
+
+	addCoordinatorDataDirFlag(activateStandbyCmd)
+	activateStandbyCmd.Flags().BoolVar(&activateStandbyForce, "force", false, "Activate the standby coordinator even if the old coordinator host is reachable")
+
+	return activateStandbyCmd
+}
+
+// RunActivateStandbyCmd driving function gets called from cobra on gpctl activate-standby command
+func RunActivateStandbyCmd(cmd *cobra.Command, args []string) error {
+	// The hub might have been running on the failed coordinator host, so it is
+	// only required that the services are configured
+	if !IsConfigured {
+		return utils.NewHelpErr(fmt.Errorf("gpservice is not configured"), "Configure the services using the 'gpservice init' command.")
+	}
+
+	if coordinatorDataDir == "" {
+		return fmt.Errorf("standby coordinator data directory not provided, please set the %s environment variable or use the --coordinator-data-directory flag", constants.CoordinatorDataDirEnv)
+	}
+
+	hostname, err := utils.System.GetHostName()
+	if err != nil {
+		return fmt.Errorf("could not get the hostname: %w", err)
+	}
+
+	if !confirm(fmt.Sprintf("The standby coordinator on host %s will be activated as the coordinator of the cluster. Continue?", hostname)) {
+		gplog.Info("Exiting without activating the standby coordinator")
+		return nil
+	}
+
+	// The standby is promoted through the local agent before the hub is moved, so that the
+	// hub stays where it is when the promotion fails
+	err = PromoteStandby(coordinatorDataDir, activateStandbyForce)
+	if err != nil {
+		return err
+	}
+
+	err = MoveHubToLocalHost(hostname)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(commandContext(cmd))
+	defer cancel()
+	ctrl := NewStreamController()
+
+	SetSignalHandler(ctrl)
+	CancelOnTermination(cancel)
+
+	return ActivateStandbyService(ctx, ctrl, &idl.ActivateStandbyRequest{
+		CoordinatorDataDir: coordinatorDataDir,
+		Hostname:           hostname,
+	})
+}
+
+/*
+MoveHubToLocalHost makes sure the hub is running on the local host, since the hub
+needs to run on the coordinator host. A hub which is still reachable on another
+host is stopped before the hub service is moved to the local host.
+*/
+func MoveHubToLocalHost(hostname string) error {
+	if IsGpserviceRunning && Conf.GetHubHost() == "localhost" {
+		return nil
+	}
+
+	if IsGpserviceRunning {
+		gplog.Info("Stopping the hub service on host %s", Conf.GetHubHost())
+		err := stopRemoteHub()
+		if err != nil {
+			gplog.Warn("failed to stop the hub service on host %s: %v", Conf.GetHubHost(), err)
+		}
+	} else {
+		gplog.Warn("Unable to reach the hub service. If the old coordinator host is reachable, make sure the hub service is stopped on it")
+	}
+
+	gplog.Info("Moving the hub service to host %s", hostname)
+	err := MoveHubService(Conf, ConfigFilePath, hostname)
+	if err != nil {
+		return fmt.Errorf("failed to move the hub service to host %s: %w", hostname, err)
+	}
+
+	return nil
+}
+
+func stopRemoteHub() error {
+	client, err := gpservice_config.ConnectToHub(Conf)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.Stop(context.Background(), &idl.StopHubRequest{})
+	if err != nil {
+		return utils.FormatGrpcError(err)
+	}
+
+	return nil
+}
+
+/*
+PromoteStandbyFn promotes the standby coordinator running on the local host using the
+agent of the local host, unless it has already been promoted by a previous attempt.
+Two coordinators would accept writes for the same cluster, so the standby is only
+promoted once the old coordinator is known to be down or when forced.
+*/
+func PromoteStandbyFn(dataDir string, force bool) error {
+	client, err := gpservice_config.ConnectToAgent(Conf)
+	if err != nil {
+		return err
+	}
+
+	reply, err := client.GetSegmentStatus(context.Background(), &idl.GetSegmentStatusRequest{
+		DataDirs: []string{dataDir},
+	})
+	if err != nil {
+		return utils.FormatGrpcError(err)
+	}
+
+	if len(reply.Statuses) == 0 || !reply.Statuses[0].Running {
+		return fmt.Errorf("the standby coordinator with data directory %s is not running on the local host", dataDir)
+	}
+
+	if reply.Statuses[0].ClusterState == constants.ClusterStateInProduction {
+		gplog.Info("Standby coordinator has already been promoted, skipping the promotion")
+		return nil
+	}
+
+	if !force {
+		err = CheckOldCoordinatorStopped(dataDir)
+		if err != nil {
+			return utils.NewHelpErr(err, "Make sure the old coordinator is stopped, or use --force to activate the standby coordinator regardless.")
+		}
+	} else {
+		gplog.Warn("Skipping the check that the old coordinator is stopped")
+	}
+
+	gplog.Info("Promoting the standby coordinator")
+	_, err = client.PromoteSegment(context.Background(), &idl.PromoteSegmentRequest{
+		DataDir: dataDir,
+		Wait:    true,
+		Timeout: constants.DefaultPromoteTimeout,
+	})
+	if err != nil {
+		return fmt.Errorf("promoting standby coordinator: %w", utils.FormatGrpcError(err))
+	}
+	gplog.Info("Successfully promoted the standby coordinator")
+
+	return nil
+}
+
+/*
+CheckOldCoordinatorStoppedFn returns an error when the old coordinator, which the standby
+coordinator replicates from, is still running or its host still answers on the agent port
+*/
+func CheckOldCoordinatorStoppedFn(dataDir string) error {
+	connInfo, err := postgres.GetPrimaryConnInfo(dataDir)
+	if err != nil {
+		return fmt.Errorf("could not find the old coordinator: %w", err)
+	}
+
+	host, port := connInfo["host"], connInfo["port"]
+	if host == "" || port == "" {
+		return fmt.Errorf("could not find the host and port of the old coordinator in the primary_conninfo of %s", dataDir)
+	}
+
+	if isReachable(host, port) {
+		return fmt.Errorf("the old coordinator is still running on host %s port %s", host, port)
+	}
+
+	if isReachable(host, strconv.Itoa(Conf.AgentPort)) {
+		return fmt.Errorf("the old coordinator host %s is still reachable", host)
+	}
+
+	return nil
+}
+
+func isReachable(host, port string) bool {
+	conn, err := net.DialTimeout("tcp", net.JoinHostPort(host, port), oldCoordinatorDialTimeout)
+	if err != nil {
+		return false
+	}
+	conn.Close()
+
+	return true
+}
+
+/*
+ActivateStandbyServiceFn calls the ActivateStandby RPC on the hub and displays the streamed responses
+*/
+func ActivateStandbyServiceFn(ctx context.Context, ctrl *StreamController, request *idl.ActivateStandbyRequest) error {
+	client, err := gpservice_config.ConnectToHub(Conf)
+	if err != nil {
+		return err
+	}
+
+	stream, err := client.ActivateStandby(ctx, request)
+	if err != nil {
+		return utils.FormatGrpcError(err)
+	}
+
+	err = ParseStreamResponse(stream, ctrl)
+	if err != nil {
+		if TerminationRequested {
+			return &ErrorUserTermination{}
+		}
+
+		return err
+	}
+
+	gplog.Info("Standby coordinator activated successfully")
+	gplog.Info("Set the %s environment variable to %s to manage the cluster from host %s", constants.CoordinatorDataDirEnv, request.CoordinatorDataDir, request.Hostname)
+	return nil
+}
@@ -0,0 +1,398 @@
+package cli_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/greenplum-db/gpdb/gpctl/cli"
+	"github.com/greenplum-db/gpdb/gpservice/constants"
+	"github.com/greenplum-db/gpdb/gpservice/idl"
+	"github.com/greenplum-db/gpdb/gpservice/idl/mock_idl"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/gpservice_config"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+func TestRunActivateStandbyCmd(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	t.Run("returns error when gpservice is not configured", func(t *testing.T) {
+		cli.IsConfigured = false
+		defer func() { cli.IsConfigured = true }()
+
+		testStr := "gpservice is not configured"
+		err := cli.RunActivateStandbyCmd(&cobra.Command{}, nil)
+		if err == nil || !strings.Contains(err.Error(), testStr) {
+			t.Fatalf("got:%v, expected:%s", err, testStr)
+		}
+	})
+}
+
+func TestMoveHubToLocalHost(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	setHostname := func(hostname string) {
+		utils.System.GetHostName = func() (string, error) {
+			return hostname, nil
+		}
+	}
+
+	t.Run("does not move the hub when it is running on the local host", func(t *testing.T) {
+		defer resetCLIVars()
+		defer utils.ResetSystemFunctions()
+		setHostname("scdw")
+
+		cli.Conf.HubHost = "scdw"
+		defer func() { cli.Conf.HubHost = "" }()
+		cli.IsGpserviceRunning = true
+
+		cli.MoveHubService = func(conf *gpservice_config.Config, configFilepath, hostname string) error {
+			t.Fatalf("unexpected call to move the hub service")
+			return nil
+		}
+
+		err := cli.MoveHubToLocalHost("scdw")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("moves the hub when it is not reachable", func(t *testing.T) {
+		defer resetCLIVars()
+		defer utils.ResetSystemFunctions()
+		setHostname("scdw")
+
+		cli.IsGpserviceRunning = false
+
+		var movedTo string
+		cli.MoveHubService = func(conf *gpservice_config.Config, configFilepath, hostname string) error {
+			movedTo = hostname
+			return nil
+		}
+
+		err := cli.MoveHubToLocalHost("scdw")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if movedTo != "scdw" {
+			t.Fatalf("got %q, want %q", movedTo, "scdw")
+		}
+	})
+
+	t.Run("stops the hub running on another host before moving it", func(t *testing.T) {
+		defer resetCLIVars()
+		defer utils.ResetSystemFunctions()
+		setHostname("scdw")
+
+		cli.Conf.HubHost = "cdw"
+		defer func() { cli.Conf.HubHost = "" }()
+		cli.IsGpserviceRunning = true
+
+		var calls []string
+		gpservice_config.ConnectToHub = func(conf *gpservice_config.Config) (idl.HubClient, error) {
+			hubClient := mock_idl.NewMockHubClient(ctrl)
+			hubClient.EXPECT().Stop(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, req *idl.StopHubRequest, opts ...interface{}) (*idl.StopHubReply, error) {
+					calls = append(calls, "stop")
+					return &idl.StopHubReply{}, nil
+				})
+			return hubClient, nil
+		}
+		cli.MoveHubService = func(conf *gpservice_config.Config, configFilepath, hostname string) error {
+			calls = append(calls, "move")
+			return nil
+		}
+
+		err := cli.MoveHubToLocalHost("scdw")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if strings.Join(calls, ",") != "stop,move" {
+			t.Fatalf("got %v, want [stop move]", calls)
+		}
+	})
+
+	t.Run("returns error when moving the hub fails", func(t *testing.T) {
+		defer resetCLIVars()
+		defer utils.ResetSystemFunctions()
+		setHostname("scdw")
+
+		cli.IsGpserviceRunning = false
+
+		expectedErr := errors.New("error")
+		cli.MoveHubService = func(conf *gpservice_config.Config, configFilepath, hostname string) error {
+			return expectedErr
+		}
+
+		err := cli.MoveHubToLocalHost("scdw")
+		if !errors.Is(err, expectedErr) {
+			t.Fatalf("got %v, want %v", err, expectedErr)
+		}
+	})
+}
+
+func TestPromoteStandby(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	dataDir := "/data/standby/gpseg-1"
+
+	t.Run("promotes the standby coordinator using the local agent", func(t *testing.T) {
+		defer resetCLIVars()
+
+		agentClient := mock_idl.NewMockAgentClient(ctrl)
+		agentClient.EXPECT().GetSegmentStatus(gomock.Any(), &idl.GetSegmentStatusRequest{DataDirs: []string{dataDir}}).Return(&idl.GetSegmentStatusReply{
+			Statuses: []*idl.SegmentProcessStatus{{Running: true, ClusterState: "in archive recovery"}},
+		}, nil)
+		agentClient.EXPECT().PromoteSegment(gomock.Any(), &idl.PromoteSegmentRequest{
+			DataDir: dataDir,
+			Wait:    true,
+			Timeout: constants.DefaultPromoteTimeout,
+		}).Return(&idl.PromoteSegmentReply{}, nil)
+		gpservice_config.SetConnectToAgent(agentClient)
+
+		var checkedDataDir string
+		cli.CheckOldCoordinatorStopped = func(dataDir string) error {
+			checkedDataDir = dataDir
+			return nil
+		}
+
+		err := cli.PromoteStandby(dataDir, false)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if checkedDataDir != dataDir {
+			t.Fatalf("got %q, want the old coordinator to be checked for %q", checkedDataDir, dataDir)
+		}
+	})
+
+	t.Run("refuses to promote while the old coordinator is running", func(t *testing.T) {
+		defer resetCLIVars()
+
+		expectedErr := errors.New("the old coordinator is still running on host cdw port 7000")
+		agentClient := mock_idl.NewMockAgentClient(ctrl)
+		agentClient.EXPECT().GetSegmentStatus(gomock.Any(), gomock.Any()).Return(&idl.GetSegmentStatusReply{
+			Statuses: []*idl.SegmentProcessStatus{{Running: true, ClusterState: "in archive recovery"}},
+		}, nil)
+		gpservice_config.SetConnectToAgent(agentClient)
+
+		cli.CheckOldCoordinatorStopped = func(dataDir string) error {
+			return expectedErr
+		}
+
+		err := cli.PromoteStandby(dataDir, false)
+		if err == nil || err.Error() != expectedErr.Error() {
+			t.Fatalf("got %v, want %v", err, expectedErr)
+		}
+	})
+
+	t.Run("promotes without checking the old coordinator when forced", func(t *testing.T) {
+		defer resetCLIVars()
+
+		agentClient := mock_idl.NewMockAgentClient(ctrl)
+		agentClient.EXPECT().GetSegmentStatus(gomock.Any(), gomock.Any()).Return(&idl.GetSegmentStatusReply{
+			Statuses: []*idl.SegmentProcessStatus{{Running: true, ClusterState: "in archive recovery"}},
+		}, nil)
+		agentClient.EXPECT().PromoteSegment(gomock.Any(), gomock.Any()).Return(&idl.PromoteSegmentReply{}, nil)
+		gpservice_config.SetConnectToAgent(agentClient)
+
+		cli.CheckOldCoordinatorStopped = func(dataDir string) error {
+			t.Fatalf("unexpected call to check the old coordinator")
+			return nil
+		}
+
+		err := cli.PromoteStandby(dataDir, true)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("skips the promotion when the standby coordinator has already been promoted", func(t *testing.T) {
+		defer resetCLIVars()
+
+		agentClient := mock_idl.NewMockAgentClient(ctrl)
+		agentClient.EXPECT().GetSegmentStatus(gomock.Any(), gomock.Any()).Return(&idl.GetSegmentStatusReply{
+			Statuses: []*idl.SegmentProcessStatus{{Running: true, ClusterState: constants.ClusterStateInProduction}},
+		}, nil)
+		gpservice_config.SetConnectToAgent(agentClient)
+
+		err := cli.PromoteStandby(dataDir, false)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("returns error when the standby coordinator is not running", func(t *testing.T) {
+		defer resetCLIVars()
+
+		agentClient := mock_idl.NewMockAgentClient(ctrl)
+		agentClient.EXPECT().GetSegmentStatus(gomock.Any(), gomock.Any()).Return(&idl.GetSegmentStatusReply{
+			Statuses: []*idl.SegmentProcessStatus{{Running: false}},
+		}, nil)
+		gpservice_config.SetConnectToAgent(agentClient)
+
+		err := cli.PromoteStandby(dataDir, false)
+		expected := fmt.Sprintf("the standby coordinator with data directory %s is not running on the local host", dataDir)
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+
+	t.Run("returns error when the promotion fails", func(t *testing.T) {
+		defer resetCLIVars()
+
+		expectedErr := errors.New("error")
+		agentClient := mock_idl.NewMockAgentClient(ctrl)
+		agentClient.EXPECT().GetSegmentStatus(gomock.Any(), gomock.Any()).Return(&idl.GetSegmentStatusReply{
+			Statuses: []*idl.SegmentProcessStatus{{Running: true}},
+		}, nil)
+		agentClient.EXPECT().PromoteSegment(gomock.Any(), gomock.Any()).Return(nil, expectedErr)
+		gpservice_config.SetConnectToAgent(agentClient)
+		cli.CheckOldCoordinatorStopped = func(dataDir string) error {
+			return nil
+		}
+
+		err := cli.PromoteStandby(dataDir, false)
+		if !errors.Is(err, expectedErr) {
+			t.Fatalf("got %v, want %v", err, expectedErr)
+		}
+	})
+}
+
+func TestCheckOldCoordinatorStopped(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	listen := func(t *testing.T) (net.Listener, string) {
+		t.Helper()
+
+		listener, err := net.Listen("tcp", "localhost:0")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		_, port, _ := net.SplitHostPort(listener.Addr().String())
+
+		return listener, port
+	}
+
+	writeAutoConf := func(t *testing.T, port string) string {
+		t.Helper()
+
+		dataDir := t.TempDir()
+		content := fmt.Sprintf("primary_conninfo = 'user=gpadmin host=localhost port=%s application_name=gp_walreceiver'\n", port)
+		err := os.WriteFile(filepath.Join(dataDir, "postgresql.auto.conf"), []byte(content), 0600)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		return dataDir
+	}
+
+	// A port which was listened on and closed again has nothing answering on it
+	closedPort := func(t *testing.T) int {
+		t.Helper()
+
+		listener, port := listen(t)
+		listener.Close()
+		result, _ := strconv.Atoi(port)
+
+		return result
+	}
+
+	t.Run("succeeds when the old coordinator host does not answer", func(t *testing.T) {
+		cli.Conf.AgentPort = closedPort(t)
+		dataDir := writeAutoConf(t, strconv.Itoa(closedPort(t)))
+
+		err := cli.CheckOldCoordinatorStopped(dataDir)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("returns error when the old coordinator is running", func(t *testing.T) {
+		cli.Conf.AgentPort = closedPort(t)
+		listener, port := listen(t)
+		defer listener.Close()
+		dataDir := writeAutoConf(t, port)
+
+		err := cli.CheckOldCoordinatorStopped(dataDir)
+		expected := fmt.Sprintf("the old coordinator is still running on host localhost port %s", port)
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+
+	t.Run("returns error when the old coordinator host answers on the agent port", func(t *testing.T) {
+		listener, port := listen(t)
+		defer listener.Close()
+		cli.Conf.AgentPort, _ = strconv.Atoi(port)
+		dataDir := writeAutoConf(t, strconv.Itoa(closedPort(t)))
+
+		err := cli.CheckOldCoordinatorStopped(dataDir)
+		expected := "the old coordinator host localhost is still reachable"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+
+	t.Run("returns error when the primary_conninfo cannot be read", func(t *testing.T) {
+		err := cli.CheckOldCoordinatorStopped(t.TempDir())
+		if !errors.Is(err, os.ErrNotExist) {
+			t.Fatalf("got %v, want %v", err, os.ErrNotExist)
+		}
+	})
+}
+
+func TestActivateStandbyService(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	request := &idl.ActivateStandbyRequest{CoordinatorDataDir: "/data/standby/gpseg-1", Hostname: "scdw"}
+
+	t.Run("returns error if RPC returns error", func(t *testing.T) {
+		testStr := "test-error"
+		defer resetCLIVars()
+		gpservice_config.ConnectToHub = func(conf *gpservice_config.Config) (idl.HubClient, error) {
+			hubClient := mock_idl.NewMockHubClient(ctrl)
+			hubClient.EXPECT().ActivateStandby(gomock.Any(), request).Return(nil, fmt.Errorf(testStr))
+			return hubClient, nil
+		}
+
+		err := cli.ActivateStandbyService(context.Background(), cli.NewStreamController(), request)
+		if err == nil || !strings.Contains(err.Error(), testStr) {
+			t.Fatalf("got %v, want %v", err, testStr)
+		}
+	})
+
+	t.Run("returns error if the stream returns error", func(t *testing.T) {
+		defer resetCLIVars()
+
+		expectedErr := errors.New("error")
+		gpservice_config.ConnectToHub = func(conf *gpservice_config.Config) (idl.HubClient, error) {
+			hubClient := mock_idl.NewMockHubClient(ctrl)
+			hubClient.EXPECT().ActivateStandby(gomock.Any(), request).Return(nil, nil)
+			return hubClient, nil
+		}
+		cli.ParseStreamResponse = func(stream cli.StreamReceiver, ctrl *cli.StreamController) error {
+			return expectedErr
+		}
+
+		err := cli.ActivateStandbyService(context.Background(), cli.NewStreamController(), request)
+		if !errors.Is(err, expectedErr) {
+			t.Fatalf("got %v, want %v", err, expectedErr)
+		}
+	})
+}
@@ -0,0 +1,400 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"github.com/greenplum-db/gpdb/gpservice/constants"
+	"github.com/greenplum-db/gpdb/gpservice/idl"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/gpservice_config"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+)
+
+// MirrorSegment is a mirror segment along with the content of the primary it mirrors
+type MirrorSegment struct {
+	Content int `mapstructure:"content"`
+	Segment `mapstructure:",squash"`
+}
+
+type AddMirrorsConfig struct {
+	Mirrors []MirrorSegment `mapstructure:"mirrors"`
+
+	//Expansion config parameters
+	MirrorBasePort        int      `mapstructure:"mirror-base-port"`
+	MirrorDataDirectories []string `mapstructure:"mirror-data-directories"`
+	MirroringType         string   `mapstructure:"mirroring-type"`
+}
+
+var (
+	AddMirrorsService         = AddMirrorsServiceFn
+	LoadAddMirrorsConfigToIdl = LoadAddMirrorsConfigToIdlFn
+)
+
+var (
+	addMirrorsHbaHostnames bool
+	addMirrorsClean        bool
+)
+
+func addMirrorsCmd() *cobra.Command {
+	addMirrorsCmd := &cobra.Command{
+		Use:   "add-mirrors <config-file>",
+		Short: "Adds mirror segments to a Greenplum Database system which was initialized without mirrors",
+		Args:  cobra.MaximumNArgs(1),
+		Example: `To add the mirrors listed in a configuration file, or expanded from the mirror-data-directories,
+mirror-base-port and mirroring-type parameters of the configuration file
+$ gpctl add-mirrors mirror_config.yaml
+
+To rollback the changes made due to a failed attempt to add mirrors
+$ gpctl add-mirrors --clean
+`,
+		RunE: RunAddMirrorsCmd,
+	}
+
+	addCoordinatorDataDirFlag(addMirrorsCmd)
+	addMirrorsCmd.Flags().BoolVar(&addMirrorsHbaHostnames, "hba-hostnames", false, "Use hostnames instead of IP addresses when adding the mirror entries to pg_hba.conf")
+	addMirrorsCmd.Flags().BoolVar(&addMirrorsClean, "clean", false, "Rollback the changes made due to a failed attempt to add mirrors")
+
+	return addMirrorsCmd
+}
+
+// RunAddMirrorsCmd driving function gets called from cobra on gpctl add-mirrors command
+func RunAddMirrorsCmd(cmd *cobra.Command, args []string) error {
+	err := CheckGpServiceRunning()
+	if err != nil {
+		return err
+	}
+
+	if addMirrorsClean {
+		if len(args) == 1 {
+			return fmt.Errorf("cannot provide config file with --clean")
+		}
+
+		_, err := utils.System.Stat(filepath.Join(Conf.LogDir, constants.CleanFileName))
+		if err != nil {
+			return fmt.Errorf("cluster is clean, no cleanup file present")
+		}
+
+		return RollbackChanges(false, "add-mirrors")
+	}
+
+	if len(args) == 0 {
+		return fmt.Errorf("please provide config file to add the mirrors")
+	}
+
+	if coordinatorDataDir == "" {
+		return fmt.Errorf("coordinator data directory not provided, please set the %s environment variable or use the --coordinator-data-directory flag", constants.CoordinatorDataDirEnv)
+	}
+
+	statuses, err := GetClusterStatus(coordinatorDataDir)
+	if err != nil {
+		return err
+	}
+
+	var primaries []SegmentStatus
+	for _, status := range statuses {
+		if status.Role == constants.RolePrimary && status.Content >= 0 {
+			primaries = append(primaries, status)
+		}
+	}
+
+	mirrors, err := LoadAddMirrorsConfigToIdl(args[0], viper.New(), primaries)
+	if err != nil {
+		return err
+	}
+
+	request := &idl.AddMirrorsRequest{
+		CoordinatorDataDir: coordinatorDataDir,
+		HbaHostnames:       addMirrorsHbaHostnames,
+		Mirrors:            mirrors,
+	}
+
+	ctx, cancel := context.WithCancel(commandContext(cmd))
+	defer cancel()
+	ctrl := NewStreamController()
+
+	SetSignalHandler(ctrl)
+	CancelOnTermination(cancel)
+
+	return AddMirrorsService(ctx, ctrl, request)
+}
+
+/*
+LoadAddMirrorsConfigToIdlFn reads the config file and returns the mirrors to be added for the given
+primary segments. The mirrors are either listed explicitly or expanded from the expansion parameters.
+*/
+func LoadAddMirrorsConfigToIdlFn(configFile string, cliHandler *viper.Viper, primaries []SegmentStatus) ([]*idl.Segment, error) {
+	cliHandler.SetConfigFile(configFile)
+
+	if err := cliHandler.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("while reading config file: %w", err)
+	}
+
+	var config AddMirrorsConfig
+	if err := cliHandler.UnmarshalExact(&config); err != nil {
+		return nil, fmt.Errorf("while unmarshaling config file: %w", err)
+	}
+
+	if len(primaries) == 0 {
+		return nil, fmt.Errorf("no primary segments found in the cluster")
+	}
+
+	if AnyExpansionMirrorConfigPresent(cliHandler) {
+		if cliHandler.IsSet("mirrors") {
+			return nil, fmt.Errorf("cannot specify mirrors and mirror-data-directories together")
+		}
+
+		err := ValidateAddMirrorsExpansionConfigAndSetDefault(&config, cliHandler, primaries)
+		if err != nil {
+			return nil, err
+		}
+
+		return ExpandMirrorsFromPrimaries(config, primaries)
+	}
+
+	if len(config.Mirrors) == 0 {
+		return nil, fmt.Errorf("no mirrors found in the config file %s, please provide either the mirrors or the mirror-data-directories", configFile)
+	}
+
+	return MirrorListToIdl(config.Mirrors, primaries)
+}
+
+// MirrorListToIdl validates the explicitly listed mirrors against the primaries in the cluster
+func MirrorListToIdl(mirrors []MirrorSegment, primaries []SegmentStatus) ([]*idl.Segment, error) {
+	if len(mirrors) != len(primaries) {
+		return nil, fmt.Errorf("number of mirrors %d is not equal to the number of primaries %d present in the cluster", len(mirrors), len(primaries))
+	}
+
+	primaryContents := make(map[int]bool)
+	for _, primary := range primaries {
+		primaryContents[int(primary.Content)] = true
+	}
+
+	seen := make(map[int]bool)
+	var result []*idl.Segment
+	for _, mirror := range mirrors {
+		if !primaryContents[mirror.Content] {
+			return nil, fmt.Errorf("no primary segment with content %d present in the cluster", mirror.Content)
+		}
+
+		if seen[mirror.Content] {
+			return nil, fmt.Errorf("more than one mirror provided for the content %d", mirror.Content)
+		}
+		seen[mirror.Content] = true
+
+		if mirror.Hostname == "" || mirror.DataDirectory == "" || mirror.Port == 0 {
+			return nil, fmt.Errorf("hostname, port and data-directory must be provided for the mirror of content %d", mirror.Content)
+		}
+
+		seg := mirror.Segment
+		if seg.Address == "" {
+			seg.Address = seg.Hostname
+		}
+
+		idlSeg := SegmentToIdl(&seg)
+		idlSeg.Contentid = int32(mirror.Content)
+		result = append(result, idlSeg)
+	}
+
+	return result, nil
+}
+
+// ValidateAddMirrorsExpansionConfigAndSetDefault validates the mirror expansion parameters
+// against the layout of the primary segments in the cluster
+func ValidateAddMirrorsExpansionConfigAndSetDefault(config *AddMirrorsConfig, cliHandle *viper.Viper, primaries []SegmentStatus) error {
+	if !cliHandle.IsSet("mirror-data-directories") || len(config.MirrorDataDirectories) < 1 {
+		return fmt.Errorf("mirror-data-directories not specified. Please specify mirror-data-directories to continue")
+	}
+
+	if !ValidateStringArray(config.MirrorDataDirectories) {
+		return fmt.Errorf("empty mirror-data-directories entry provided, please provide valid directory")
+	}
+
+	hostnames, err := getPrimaryHostnames(primaries, len(config.MirrorDataDirectories))
+	if err != nil {
+		return err
+	}
+
+	if len(hostnames) < 2 {
+		return fmt.Errorf("at least 2 hosts are required to expand the mirrors, the primaries are present only on the host %s", hostnames[0])
+	}
+
+	if !cliHandle.IsSet("mirror-base-port") {
+		minPrimaryPort := primaries[0].Port
+		for _, primary := range primaries {
+			minPrimaryPort = min(minPrimaryPort, primary.Port)
+		}
+
+		defaultMirrorBasePort := int(minPrimaryPort) + 1000
+		gplog.Warn("mirror-base-port value not specified. Setting default to: %d", defaultMirrorBasePort)
+		config.MirrorBasePort = defaultMirrorBasePort
+	}
+
+	if config.MirrorBasePort < 1 {
+		return fmt.Errorf("invalid mirror-base-port value provided: %d", config.MirrorBasePort)
+	}
+
+	if !cliHandle.IsSet("mirroring-type") || config.MirroringType == "" {
+		// Default is group mirroring
+		config.MirroringType = constants.GroupMirroring
+		gplog.Warn("Mirroring type not specified. Setting default as 'group' mirroring")
+	} else {
+		config.MirroringType = strings.ToLower(config.MirroringType)
+
+		if config.MirroringType != constants.SpreadMirroring && config.MirroringType != constants.GroupMirroring {
+			return fmt.Errorf("invalid mirroring-Type: %s. Valid options are 'group' and 'spread'", config.MirroringType)
+		}
+	}
+
+	if config.MirroringType == constants.SpreadMirroring && !(len(config.MirrorDataDirectories) < len(hostnames)) {
+		return fmt.Errorf("To enable spread mirroring, number of hosts should be more than number of primary segments per host. "+
+			"Current number of hosts is: %d and number of primaries per host is:%d", len(hostnames), len(config.MirrorDataDirectories))
+	}
+
+	return nil
+}
+
+/*
+ExpandMirrorsFromPrimaries expands the mirrors for the primaries present in the cluster using the
+same group and spread placement as gpctl init. The mirror of each primary gets its content.
+*/
+func ExpandMirrorsFromPrimaries(config AddMirrorsConfig, primaries []SegmentStatus) ([]*idl.Segment, error) {
+	primaries = slices.Clone(primaries)
+	sort.Slice(primaries, func(i, j int) bool {
+		return primaries[i].Content < primaries[j].Content
+	})
+
+	hostnames, err := getPrimaryHostnames(primaries, len(config.MirrorDataDirectories))
+	if err != nil {
+		return nil, err
+	}
+
+	var segPairList []SegmentPair
+	nameAddressMap := make(map[string][]string)
+	addressNameMap := make(map[string]string)
+	for _, primary := range primaries {
+		segPairList = append(segPairList, SegmentPair{Primary: &Segment{
+			Hostname:      primary.Hostname,
+			Address:       primary.Address,
+			Port:          int(primary.Port),
+			DataDirectory: primary.DataDir,
+		}})
+
+		if !slices.Contains(nameAddressMap[primary.Hostname], primary.Address) {
+			nameAddressMap[primary.Hostname] = append(nameAddressMap[primary.Hostname], primary.Address)
+		}
+		addressNameMap[primary.Address] = primary.Hostname
+	}
+
+	isMultiHome := len(addressNameMap) > len(nameAddressMap)
+	if isMultiHome {
+		for hostname := range nameAddressMap {
+			slices.Sort(nameAddressMap[hostname])
+		}
+
+		if config.MirroringType == constants.GroupMirroring {
+			ExpandMultiHomeGroupMirrorList(&segPairList, config.MirrorBasePort, config.MirrorDataDirectories, hostnames, nameAddressMap)
+		} else {
+			ExpandMultiHomeSpreadMirrorList(&segPairList, config.MirrorBasePort, config.MirrorDataDirectories, hostnames, nameAddressMap)
+		}
+	} else {
+		var hostList []string
+		for _, hostname := range hostnames {
+			hostList = append(hostList, nameAddressMap[hostname][0])
+		}
+
+		if config.MirroringType == constants.GroupMirroring {
+			ExpandNonMultiHomeGroupMirrorList(&segPairList, config.MirrorBasePort, config.MirrorDataDirectories, hostList, addressNameMap)
+		} else {
+			ExpandNonMultiHomeSpreadMirroring(&segPairList, config.MirrorBasePort, config.MirrorDataDirectories, hostList, addressNameMap)
+		}
+	}
+
+	var mirrors []*idl.Segment
+	for idx, pair := range segPairList {
+		mirror := SegmentToIdl(pair.Mirror)
+		mirror.Contentid = primaries[idx].Content
+		mirrors = append(mirrors, mirror)
+	}
+
+	return mirrors, nil
+}
+
+// getPrimaryHostnames returns the hostnames of the primaries in content order. The expansion
+// places the mirrors host by host, so every host must hold the same number of primaries
+// as the number of mirror data directories and with consecutive contents.
+func getPrimaryHostnames(primaries []SegmentStatus, segmentsPerHost int) ([]string, error) {
+	if len(primaries)%segmentsPerHost != 0 {
+		return nil, fmt.Errorf("number of primaries %d is not a multiple of the number of mirror-data-directories %d", len(primaries), segmentsPerHost)
+	}
+
+	sorted := slices.Clone(primaries)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Content < sorted[j].Content
+	})
+
+	var hostnames []string
+	for idx, primary := range sorted {
+		if idx%segmentsPerHost == 0 {
+			if slices.Contains(hostnames, primary.Hostname) {
+				return nil, fmt.Errorf("cannot expand the mirrors, host %s does not have %d primaries with consecutive contents. Please list the mirrors explicitly", primary.Hostname, segmentsPerHost)
+			}
+			hostnames = append(hostnames, primary.Hostname)
+		} else if primary.Hostname != hostnames[len(hostnames)-1] {
+			return nil, fmt.Errorf("cannot expand the mirrors, host %s does not have %d primaries with consecutive contents. Please list the mirrors explicitly", hostnames[len(hostnames)-1], segmentsPerHost)
+		}
+	}
+
+	return hostnames, nil
+}
+
+/*
+AddMirrorsServiceFn calls the AddMirrors RPC on the hub and displays the streamed responses.
+If the mirrors could not be created, the changes are rolled back in the same way as gpctl init.
+*/
+func AddMirrorsServiceFn(ctx context.Context, ctrl *StreamController, request *idl.AddMirrorsRequest) (err error) {
+	defer func() {
+		if err != nil {
+			if TerminationRequested {
+				err = &ErrorUserTermination{}
+			}
+
+			// Call the cleanup routine only if the cleanup file exists
+			fileName := filepath.Join(Conf.LogDir, constants.CleanFileName)
+			_, statErr := utils.System.Stat(fileName)
+
+			if statErr == nil {
+				gplog.Error("failed to add mirrors: %v", err)
+				if cleanErr := RollbackChanges(true, "add-mirrors"); cleanErr != nil {
+					err = errors.Join(err, cleanErr)
+				}
+			}
+		}
+	}()
+
+	client, err := gpservice_config.ConnectToHub(Conf)
+	if err != nil {
+		return err
+	}
+
+	stream, err := client.AddMirrors(ctx, request)
+	if err != nil {
+		return utils.FormatGrpcError(err)
+	}
+
+	err = ParseStreamResponse(stream, ctrl)
+	if err != nil {
+		return err
+	}
+
+	gplog.Info("Mirror segments added successfully")
+	return nil
+}
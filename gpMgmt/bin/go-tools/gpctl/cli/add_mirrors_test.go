@@ -0,0 +1,296 @@
+package cli_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/greenplum-db/gpdb/gpctl/cli"
+	"github.com/greenplum-db/gpdb/gpservice/idl"
+	"github.com/greenplum-db/gpdb/gpservice/idl/mock_idl"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/gpservice_config"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func TestRunAddMirrorsCmd(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	t.Run("returns error when gpservice is not running", func(t *testing.T) {
+		cli.IsConfigured = true
+		cli.IsGpserviceRunning = false
+
+		testStr := "gpservice is not running"
+		err := cli.RunAddMirrorsCmd(&cobra.Command{}, []string{"config.yaml"})
+		if err == nil || !strings.Contains(err.Error(), testStr) {
+			t.Fatalf("got:%v, expected:%s", err, testStr)
+		}
+	})
+}
+
+func TestLoadAddMirrorsConfigToIdl(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	primaries := []cli.SegmentStatus{
+		{Content: 0, Role: "p", Hostname: "sdw1", Address: "sdw1", Port: 7000, DataDir: "/data/primary/gpseg0"},
+		{Content: 1, Role: "p", Hostname: "sdw1", Address: "sdw1", Port: 7001, DataDir: "/data/primary/gpseg1"},
+		{Content: 2, Role: "p", Hostname: "sdw2", Address: "sdw2", Port: 7000, DataDir: "/data/primary/gpseg2"},
+		{Content: 3, Role: "p", Hostname: "sdw2", Address: "sdw2", Port: 7001, DataDir: "/data/primary/gpseg3"},
+		{Content: 4, Role: "p", Hostname: "sdw3", Address: "sdw3", Port: 7000, DataDir: "/data/primary/gpseg4"},
+		{Content: 5, Role: "p", Hostname: "sdw3", Address: "sdw3", Port: 7001, DataDir: "/data/primary/gpseg5"},
+	}
+
+	t.Run("loads the explicitly listed mirrors", func(t *testing.T) {
+		content := `
+mirrors:
+  - content: 1
+    hostname: sdw2
+    port: 8001
+    data-directory: /data/mirror/gpseg1
+  - content: 0
+    hostname: sdw2
+    address: sdw2-1
+    port: 8000
+    data-directory: /data/mirror/gpseg0
+`
+		filePath := createTempFile(t, content, "mirror_config.yaml")
+
+		result, err := cli.LoadAddMirrorsConfigToIdl(filePath, viper.New(), primaries[:2])
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := []*idl.Segment{
+			{HostName: "sdw2", HostAddress: "sdw2", Port: 8001, DataDirectory: "/data/mirror/gpseg1", Contentid: 1},
+			{HostName: "sdw2", HostAddress: "sdw2-1", Port: 8000, DataDirectory: "/data/mirror/gpseg0", Contentid: 0},
+		}
+		if !reflect.DeepEqual(result, expected) {
+			t.Fatalf("got %+v, want %+v", result, expected)
+		}
+	})
+
+	t.Run("expands the mirrors with group mirroring by default", func(t *testing.T) {
+		content := `
+mirror-base-port: 8000
+mirror-data-directories:
+  - /data/mirror1
+  - /data/mirror2
+`
+		filePath := createTempFile(t, content, "mirror_config.yaml")
+
+		result, err := cli.LoadAddMirrorsConfigToIdl(filePath, viper.New(), primaries)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := []*idl.Segment{
+			{HostName: "sdw2", HostAddress: "sdw2", Port: 8000, DataDirectory: "/data/mirror1/gpseg0", Contentid: 0},
+			{HostName: "sdw2", HostAddress: "sdw2", Port: 8001, DataDirectory: "/data/mirror2/gpseg1", Contentid: 1},
+			{HostName: "sdw3", HostAddress: "sdw3", Port: 8000, DataDirectory: "/data/mirror1/gpseg2", Contentid: 2},
+			{HostName: "sdw3", HostAddress: "sdw3", Port: 8001, DataDirectory: "/data/mirror2/gpseg3", Contentid: 3},
+			{HostName: "sdw1", HostAddress: "sdw1", Port: 8000, DataDirectory: "/data/mirror1/gpseg4", Contentid: 4},
+			{HostName: "sdw1", HostAddress: "sdw1", Port: 8001, DataDirectory: "/data/mirror2/gpseg5", Contentid: 5},
+		}
+		if !reflect.DeepEqual(result, expected) {
+			t.Fatalf("got %+v, want %+v", result, expected)
+		}
+	})
+
+	t.Run("expands the mirrors with spread mirroring", func(t *testing.T) {
+		content := `
+mirroring-type: Spread
+mirror-data-directories:
+  - /data/mirror1
+  - /data/mirror2
+`
+		filePath := createTempFile(t, content, "mirror_config.yaml")
+
+		result, err := cli.LoadAddMirrorsConfigToIdl(filePath, viper.New(), primaries)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var hosts []string
+		for _, mirror := range result {
+			hosts = append(hosts, mirror.HostName)
+			if mirror.Port != 8000 && mirror.Port != 8001 {
+				t.Fatalf("got port %d, want the default mirror base port 8000", mirror.Port)
+			}
+		}
+
+		expectedHosts := []string{"sdw2", "sdw3", "sdw3", "sdw1", "sdw1", "sdw2"}
+		if !reflect.DeepEqual(hosts, expectedHosts) {
+			t.Fatalf("got %v, want %v", hosts, expectedHosts)
+		}
+	})
+
+	cases := []struct {
+		name      string
+		content   string
+		primaries []cli.SegmentStatus
+		expected  string
+	}{
+		{
+			name:      "returns error when no mirrors are provided",
+			content:   "mirrors: []\n",
+			primaries: primaries,
+			expected:  "no mirrors found in the config file",
+		},
+		{
+			name:      "returns error when both the mirrors and the expansion parameters are provided",
+			content:   "mirrors:\n  - content: 0\nmirror-data-directories:\n  - /data/mirror\n",
+			primaries: primaries,
+			expected:  "cannot specify mirrors and mirror-data-directories together",
+		},
+		{
+			name:      "returns error when the number of mirrors does not match the primaries",
+			content:   "mirrors:\n  - content: 0\n    hostname: sdw2\n    port: 8000\n    data-directory: /data/mirror/gpseg0\n",
+			primaries: primaries,
+			expected:  "number of mirrors 1 is not equal to the number of primaries 6 present in the cluster",
+		},
+		{
+			name:      "returns error when a mirror is listed for an unknown content",
+			content:   "mirrors:\n  - content: 7\n    hostname: sdw2\n    port: 8000\n    data-directory: /data/mirror/gpseg7\n",
+			primaries: primaries[:1],
+			expected:  "no primary segment with content 7 present in the cluster",
+		},
+		{
+			name:      "returns error when a mirror is incomplete",
+			content:   "mirrors:\n  - content: 0\n    hostname: sdw2\n",
+			primaries: primaries[:1],
+			expected:  "hostname, port and data-directory must be provided for the mirror of content 0",
+		},
+		{
+			name:      "returns error when the primaries per host do not match the mirror data directories",
+			content:   "mirror-data-directories:\n  - /data/mirror1\n  - /data/mirror2\n  - /data/mirror3\n",
+			primaries: primaries,
+			expected:  "cannot expand the mirrors, host sdw1 does not have 3 primaries with consecutive contents",
+		},
+		{
+			name:      "returns error when the primaries are on a single host",
+			content:   "mirror-data-directories:\n  - /data/mirror1\n  - /data/mirror2\n",
+			primaries: primaries[:2],
+			expected:  "at least 2 hosts are required to expand the mirrors",
+		},
+		{
+			name:      "returns error when there are not enough hosts for spread mirroring",
+			content:   "mirroring-type: spread\nmirror-data-directories:\n  - /data/mirror1\n  - /data/mirror2\n",
+			primaries: primaries[:4],
+			expected:  "To enable spread mirroring, number of hosts should be more than number of primary segments per host",
+		},
+		{
+			name:      "returns error when the mirroring type is invalid",
+			content:   "mirroring-type: ring\nmirror-data-directories:\n  - /data/mirror1\n  - /data/mirror2\n",
+			primaries: primaries,
+			expected:  "invalid mirroring-Type: ring",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			filePath := createTempFile(t, tc.content, "mirror_config.yaml")
+
+			_, err := cli.LoadAddMirrorsConfigToIdl(filePath, viper.New(), tc.primaries)
+			if err == nil || !strings.Contains(err.Error(), tc.expected) {
+				t.Fatalf("got %v, want %s", err, tc.expected)
+			}
+		})
+	}
+}
+
+func TestAddMirrorsService(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	request := &idl.AddMirrorsRequest{
+		CoordinatorDataDir: "/data/gpseg-1",
+		Mirrors:            []*idl.Segment{{HostName: "sdw2", Port: 8000, DataDirectory: "/data/mirror/gpseg0"}},
+	}
+
+	t.Run("returns error if RPC returns error", func(t *testing.T) {
+		testStr := "test-error"
+		defer resetCLIVars()
+		gpservice_config.ConnectToHub = func(conf *gpservice_config.Config) (idl.HubClient, error) {
+			hubClient := mock_idl.NewMockHubClient(ctrl)
+			hubClient.EXPECT().AddMirrors(gomock.Any(), request).Return(nil, fmt.Errorf(testStr))
+			return hubClient, nil
+		}
+
+		err := cli.AddMirrorsService(context.Background(), cli.NewStreamController(), request)
+		if err == nil || !strings.Contains(err.Error(), testStr) {
+			t.Fatalf("got %v, want %v", err, testStr)
+		}
+	})
+
+	t.Run("rolls back the changes when adding the mirrors fails", func(t *testing.T) {
+		defer resetCLIVars()
+		defer utils.ResetSystemFunctions()
+
+		expectedErr := errors.New("error")
+		var cleanCalled bool
+		gpservice_config.ConnectToHub = func(conf *gpservice_config.Config) (idl.HubClient, error) {
+			hubClient := mock_idl.NewMockHubClient(ctrl)
+			hubClient.EXPECT().AddMirrors(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+			hubClient.EXPECT().CleanInitCluster(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, req *idl.CleanInitClusterRequest, opts ...interface{}) (*idl.CleanInitClusterReply, error) {
+					cleanCalled = true
+					return &idl.CleanInitClusterReply{}, nil
+				}).AnyTimes()
+			return hubClient, nil
+		}
+		cli.ParseStreamResponse = func(stream cli.StreamReceiver, ctrl *cli.StreamController) error {
+			return expectedErr
+		}
+		utils.System.Stat = func(name string) (os.FileInfo, error) {
+			return nil, nil
+		}
+
+		cli.TerminationRequested = true
+		cli.SigtermReceived = true
+		defer func() {
+			cli.TerminationRequested = false
+			cli.SigtermReceived = false
+		}()
+
+		err := cli.AddMirrorsService(context.Background(), cli.NewStreamController(), request)
+		var expected *cli.ErrorUserTermination
+		if !errors.As(err, &expected) {
+			t.Fatalf("got %v, want %T", err, expected)
+		}
+
+		if !cleanCalled {
+			t.Fatalf("expected the changes to be rolled back")
+		}
+	})
+
+	t.Run("does not roll back when there is no cleanup file", func(t *testing.T) {
+		defer resetCLIVars()
+		defer utils.ResetSystemFunctions()
+
+		expectedErr := errors.New("error")
+		gpservice_config.ConnectToHub = func(conf *gpservice_config.Config) (idl.HubClient, error) {
+			hubClient := mock_idl.NewMockHubClient(ctrl)
+			hubClient.EXPECT().AddMirrors(gomock.Any(), gomock.Any()).Return(nil, nil)
+			return hubClient, nil
+		}
+		cli.ParseStreamResponse = func(stream cli.StreamReceiver, ctrl *cli.StreamController) error {
+			return expectedErr
+		}
+		utils.System.Stat = func(name string) (os.FileInfo, error) {
+			return nil, os.ErrNotExist
+		}
+
+		err := cli.AddMirrorsService(context.Background(), cli.NewStreamController(), request)
+		if !errors.Is(err, expectedErr) {
+			t.Fatalf("got %v, want %v", err, expectedErr)
+		}
+	})
+}
@@ -0,0 +1,289 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"github.com/greenplum-db/gpdb/gpservice/constants"
+	"github.com/greenplum-db/gpdb/gpservice/idl"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/gpservice_config"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+)
+
+type ExpandConfig struct {
+	HbaHostnames bool          `mapstructure:"hba-hostnames"`
+	SegmentArray []SegmentPair `mapstructure:"segment-array"`
+
+	//Expansion config parameters
+	PrimaryBasePort        int      `mapstructure:"primary-base-port"`
+	PrimaryDataDirectories []string `mapstructure:"primary-data-directories"`
+	HostList               []string `mapstructure:"hostlist"`
+	MirrorBasePort         int      `mapstructure:"mirror-base-port"`
+	MirrorDataDirectories  []string `mapstructure:"mirror-data-directories"`
+	MirroringType          string   `mapstructure:"mirroring-type"`
+}
+
+var (
+	ExpandClusterService  = ExpandClusterServiceFn
+	LoadExpandConfigToIdl = LoadExpandConfigToIdlFn
+)
+
+var (
+	expandRedistribute bool
+	expandBatchSize    int
+	expandClean        bool
+)
+
+func expandCmd() *cobra.Command {
+	expandCmd := &cobra.Command{
+		Use:   "expand [<config-file>]",
+		Short: "Adds new segments to a Greenplum Database system and redistributes the tables across them",
+		Args:  cobra.MaximumNArgs(1),
+		Example: `To add the new segments listed in, or expanded from, a configuration file and redistribute the tables
+$ gpctl expand expand_config.yaml
+
+To resume the redistribution of the tables after an interrupted expansion
+$ gpctl expand --redistribute
+
+To rollback the changes made due to a failed attempt to add the new segments
+$ gpctl expand --clean
+`,
+		RunE: RunExpandClusterCmd,
+	}
+
+	addCoordinatorDataDirFlag(expandCmd)
+	expandCmd.Flags().BoolVar(&expandRedistribute, "redistribute", false, "Only redistribute the tables which are not yet distributed across all the segments")
+	expandCmd.Flags().IntVar(&expandBatchSize, "batch-size", constants.DefaultExpandBatchSize, "Number of tables to redistribute in parallel in each batch")
+	expandCmd.Flags().BoolVar(&expandClean, "clean", false, "Rollback the changes made due to a failed attempt to add the new segments")
+
+	return expandCmd
+}
+
+// RunExpandClusterCmd driving function gets called from cobra on gpctl expand command
+func RunExpandClusterCmd(cmd *cobra.Command, args []string) error {
+	err := CheckGpServiceRunning()
+	if err != nil {
+		return err
+	}
+
+	if expandClean {
+		if len(args) == 1 {
+			return fmt.Errorf("cannot provide config file with --clean")
+		}
+
+		_, err := utils.System.Stat(filepath.Join(Conf.LogDir, constants.CleanFileName))
+		if err != nil {
+			return fmt.Errorf("cluster is clean, no cleanup file present")
+		}
+
+		return RollbackChanges(false, "expand")
+	}
+
+	if coordinatorDataDir == "" {
+		return fmt.Errorf("coordinator data directory not provided, please set the %s environment variable or use the --coordinator-data-directory flag", constants.CoordinatorDataDirEnv)
+	}
+
+	if expandRedistribute && len(args) == 1 {
+		return fmt.Errorf("cannot provide config file with --redistribute")
+	}
+
+	if !expandRedistribute && len(args) == 0 {
+		return fmt.Errorf("please provide config file for cluster expansion")
+	}
+
+	if expandBatchSize < 1 {
+		return fmt.Errorf("invalid value %d for --batch-size, the value must be greater than 0", expandBatchSize)
+	}
+
+	ctx, cancel := context.WithCancel(commandContext(cmd))
+	defer cancel()
+
+	request := &idl.ExpandClusterRequest{
+		CoordinatorDataDir: coordinatorDataDir,
+		BatchSize:          int32(expandBatchSize),
+	}
+
+	if !expandRedistribute {
+		statuses, err := GetClusterStatus(coordinatorDataDir)
+		if err != nil {
+			return err
+		}
+
+		HubClient, err = gpservice_config.ConnectToHub(Conf)
+		if err != nil {
+			return err
+		}
+
+		request.HbaHostnames, request.SegmentPairs, err = LoadExpandConfigToIdl(ctx, args[0], viper.New(), statuses)
+		if err != nil {
+			return err
+		}
+	}
+
+	ctrl := NewStreamController()
+
+	SetSignalHandler(ctrl)
+	CancelOnTermination(cancel)
+
+	return ExpandClusterService(ctx, ctrl, request)
+}
+
+/*
+LoadExpandConfigToIdlFn reads the config file and returns the new segments to be added to the
+cluster. The segments are either listed explicitly or expanded in the same way as gpctl init,
+with the contents and data directory names following the existing segments of the cluster.
+*/
+func LoadExpandConfigToIdlFn(ctx context.Context, configFile string, cliHandler *viper.Viper, statuses []SegmentStatus) (bool, []*idl.SegmentPair, error) {
+	cliHandler.SetConfigFile(configFile)
+
+	if err := cliHandler.ReadInConfig(); err != nil {
+		return false, nil, fmt.Errorf("while reading config file: %w", err)
+	}
+
+	var config ExpandConfig
+	if err := cliHandler.UnmarshalExact(&config); err != nil {
+		return false, nil, fmt.Errorf("while unmarshaling config file: %w", err)
+	}
+
+	var numPrimaries, coordinatorPort int
+	var hasMirrors bool
+	for _, status := range statuses {
+		if status.Content < 0 && status.Role == constants.RolePrimary {
+			coordinatorPort = int(status.Port)
+		} else if status.Content >= 0 && status.PreferredRole == constants.RolePrimary {
+			numPrimaries++
+		} else if status.Content >= 0 {
+			hasMirrors = true
+		}
+	}
+
+	segPairs := config.SegmentArray
+	if AnyExpansionConfigPresent(cliHandler) {
+		initConfig := InitConfig{
+			Coordinator:            Segment{Port: coordinatorPort},
+			SegmentArray:           config.SegmentArray,
+			PrimaryBasePort:        config.PrimaryBasePort,
+			PrimaryDataDirectories: config.PrimaryDataDirectories,
+			HostList:               config.HostList,
+			MirrorBasePort:         config.MirrorBasePort,
+			MirrorDataDirectories:  config.MirrorDataDirectories,
+			MirroringType:          config.MirroringType,
+		}
+
+		err := ValidateExpansionConfigAndSetDefault(&initConfig, cliHandler)
+		if err != nil {
+			return false, nil, err
+		}
+
+		if ContainsMirror != hasMirrors {
+			return false, nil, mirrorMismatchError(hasMirrors)
+		}
+
+		isMultiHome, nameAddressMap, addressNameMap, err := IsMultiHome(ctx, initConfig.HostList)
+		if err != nil {
+			return false, nil, err
+		}
+
+		if isMultiHome {
+			isValidMultiHomeConfig, err := ValidateMultiHomeConfig(initConfig, nameAddressMap)
+			if !isValidMultiHomeConfig {
+				return false, nil, err
+			}
+		}
+
+		segPairs = ExpandSegPairArray(initConfig, isMultiHome, nameAddressMap, addressNameMap)
+		RenumberSegmentDataDirectories(segPairs, numPrimaries)
+	}
+
+	if len(segPairs) == 0 {
+		return false, nil, fmt.Errorf("no segments to add found in the config file %s, please provide either the segment-array or the primary-data-directories", configFile)
+	}
+
+	var result []*idl.SegmentPair
+	for _, pair := range segPairs {
+		if pair.Primary == nil || pair.Primary.Hostname == "" || pair.Primary.DataDirectory == "" || pair.Primary.Port == 0 {
+			return false, nil, fmt.Errorf("hostname, port and data-directory must be provided for every primary segment in the segment-array")
+		}
+
+		if (pair.Mirror != nil) != hasMirrors {
+			return false, nil, mirrorMismatchError(hasMirrors)
+		}
+
+		if pair.Mirror != nil && (pair.Mirror.Hostname == "" || pair.Mirror.DataDirectory == "" || pair.Mirror.Port == 0) {
+			return false, nil, fmt.Errorf("hostname, port and data-directory must be provided for every mirror segment in the segment-array")
+		}
+
+		result = append(result, SegmentPairToIdl(&pair))
+	}
+
+	return config.HbaHostnames, result, nil
+}
+
+func mirrorMismatchError(hasMirrors bool) error {
+	if hasMirrors {
+		return fmt.Errorf("the cluster is configured with mirrors, please provide the mirrors for the new segments")
+	}
+
+	return fmt.Errorf("the cluster is not configured with mirrors, cannot add mirrors for the new segments")
+}
+
+// RenumberSegmentDataDirectories renames the data directories created by the expansion
+// so that their numbering starts after the segments which are already part of the cluster
+func RenumberSegmentDataDirectories(segPairs []SegmentPair, offset int) {
+	for idx, pair := range segPairs {
+		for _, seg := range []*Segment{pair.Primary, pair.Mirror} {
+			if seg != nil {
+				seg.DataDirectory = filepath.Join(filepath.Dir(seg.DataDirectory), fmt.Sprintf("%s%d", constants.DefaultSegName, idx+offset))
+			}
+		}
+	}
+}
+
+/*
+ExpandClusterServiceFn calls the ExpandCluster RPC on the hub and displays the streamed responses.
+If the new primary segments could not be added, the changes are rolled back in the same way as gpctl init.
+*/
+func ExpandClusterServiceFn(ctx context.Context, ctrl *StreamController, request *idl.ExpandClusterRequest) (err error) {
+	defer func() {
+		if err != nil {
+			if TerminationRequested {
+				err = &ErrorUserTermination{}
+			}
+
+			// Call the cleanup routine only if the cleanup file exists
+			fileName := filepath.Join(Conf.LogDir, constants.CleanFileName)
+			_, statErr := utils.System.Stat(fileName)
+
+			if statErr == nil {
+				gplog.Error("failed to expand the cluster: %v", err)
+				if cleanErr := RollbackChanges(true, "expand"); cleanErr != nil {
+					err = errors.Join(err, cleanErr)
+				}
+			}
+		}
+	}()
+
+	client, err := gpservice_config.ConnectToHub(Conf)
+	if err != nil {
+		return err
+	}
+
+	stream, err := client.ExpandCluster(ctx, request)
+	if err != nil {
+		return utils.FormatGrpcError(err)
+	}
+
+	err = ParseStreamResponse(stream, ctrl)
+	if err != nil {
+		return err
+	}
+
+	gplog.Info("Cluster expanded successfully")
+	return nil
+}
@@ -0,0 +1,278 @@
+package cli_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/greenplum-db/gpdb/gpctl/cli"
+	"github.com/greenplum-db/gpdb/gpservice/idl"
+	"github.com/greenplum-db/gpdb/gpservice/idl/mock_idl"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/gpservice_config"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func TestRunExpandClusterCmd(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	t.Run("returns error when gpservice is not running", func(t *testing.T) {
+		cli.IsConfigured = true
+		cli.IsGpserviceRunning = false
+
+		testStr := "gpservice is not running"
+		err := cli.RunExpandClusterCmd(&cobra.Command{}, []string{"config.yaml"})
+		if err == nil || !strings.Contains(err.Error(), testStr) {
+			t.Fatalf("got:%v, expected:%s", err, testStr)
+		}
+	})
+}
+
+func TestLoadExpandConfigToIdl(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	mirrorlessCluster := []cli.SegmentStatus{
+		{Content: -1, Role: "p", PreferredRole: "p", Hostname: "cdw", Port: 7000},
+		{Content: 0, Role: "p", PreferredRole: "p", Hostname: "sdw1", Port: 7002},
+		{Content: 1, Role: "p", PreferredRole: "p", Hostname: "sdw2", Port: 7002},
+	}
+	mirroredCluster := append(mirrorlessCluster,
+		cli.SegmentStatus{Content: 0, Role: "m", PreferredRole: "m", Hostname: "sdw2", Port: 8002},
+		cli.SegmentStatus{Content: 1, Role: "m", PreferredRole: "m", Hostname: "sdw1", Port: 8002},
+	)
+
+	mockHostnames := func(t *testing.T, hostList []string) {
+		hostNameMap := make(map[string]string)
+		for _, host := range hostList {
+			hostNameMap[host] = host
+		}
+
+		hubClient := mock_idl.NewMockHubClient(ctrl)
+		hubClient.EXPECT().GetAllHostNames(gomock.Any(), &idl.GetAllHostNamesRequest{HostList: hostList}).Return(&idl.GetAllHostNamesReply{HostNameMap: hostNameMap}, nil)
+		cli.HubClient = hubClient
+	}
+
+	t.Run("expands the new segments numbered after the existing segments", func(t *testing.T) {
+		mockHostnames(t, []string{"sdw3", "sdw4"})
+		defer func() { cli.HubClient = nil }()
+
+		content := `
+hostlist:
+  - sdw3
+  - sdw4
+primary-base-port: 7002
+primary-data-directories:
+  - /data/primary
+mirror-base-port: 8002
+mirror-data-directories:
+  - /data/mirror
+`
+		filePath := createTempFile(t, content, "expand_config.yaml")
+
+		hbaHostnames, result, err := cli.LoadExpandConfigToIdl(context.Background(), filePath, viper.New(), mirroredCluster)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if hbaHostnames {
+			t.Fatalf("got hba-hostnames true, want false")
+		}
+
+		expected := []*idl.SegmentPair{
+			{
+				Primary: &idl.Segment{HostName: "sdw3", HostAddress: "sdw3", Port: 7002, DataDirectory: "/data/primary/gpseg2"},
+				Mirror:  &idl.Segment{HostName: "sdw4", HostAddress: "sdw4", Port: 8002, DataDirectory: "/data/mirror/gpseg2"},
+			},
+			{
+				Primary: &idl.Segment{HostName: "sdw4", HostAddress: "sdw4", Port: 7002, DataDirectory: "/data/primary/gpseg3"},
+				Mirror:  &idl.Segment{HostName: "sdw3", HostAddress: "sdw3", Port: 8002, DataDirectory: "/data/mirror/gpseg3"},
+			},
+		}
+		if !reflect.DeepEqual(result, expected) {
+			t.Fatalf("got %+v, want %+v", result, expected)
+		}
+	})
+
+	t.Run("loads the explicitly listed segments", func(t *testing.T) {
+		content := `
+hba-hostnames: true
+segment-array:
+  - primary:
+      hostname: sdw3
+      address: sdw3
+      port: 7002
+      data-directory: /data/primary/gpseg2
+`
+		filePath := createTempFile(t, content, "expand_config.yaml")
+
+		hbaHostnames, result, err := cli.LoadExpandConfigToIdl(context.Background(), filePath, viper.New(), mirrorlessCluster)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !hbaHostnames {
+			t.Fatalf("got hba-hostnames false, want true")
+		}
+
+		expected := []*idl.SegmentPair{
+			{Primary: &idl.Segment{HostName: "sdw3", HostAddress: "sdw3", Port: 7002, DataDirectory: "/data/primary/gpseg2"}},
+		}
+		if !reflect.DeepEqual(result, expected) {
+			t.Fatalf("got %+v, want %+v", result, expected)
+		}
+	})
+
+	cases := []struct {
+		name     string
+		content  string
+		statuses []cli.SegmentStatus
+		expected string
+	}{
+		{
+			name:     "returns error when no segments are provided",
+			content:  "segment-array: []\n",
+			statuses: mirrorlessCluster,
+			expected: "no segments to add found in the config file",
+		},
+		{
+			name:     "returns error when the mirrors are missing for a cluster with mirrors",
+			content:  "segment-array:\n  - primary:\n      hostname: sdw3\n      port: 7002\n      data-directory: /data/primary/gpseg2\n",
+			statuses: mirroredCluster,
+			expected: "the cluster is configured with mirrors, please provide the mirrors for the new segments",
+		},
+		{
+			name:     "returns error when the mirror expansion is provided for a mirrorless cluster",
+			content:  "hostlist:\n  - sdw3\n  - sdw4\nprimary-data-directories:\n  - /data/primary\nmirror-data-directories:\n  - /data/mirror\n",
+			statuses: mirrorlessCluster,
+			expected: "the cluster is not configured with mirrors, cannot add mirrors for the new segments",
+		},
+		{
+			name:     "returns error when the primary segment is incomplete",
+			content:  "segment-array:\n  - primary:\n      hostname: sdw3\n",
+			statuses: mirrorlessCluster,
+			expected: "hostname, port and data-directory must be provided for every primary segment in the segment-array",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			filePath := createTempFile(t, tc.content, "expand_config.yaml")
+
+			_, _, err := cli.LoadExpandConfigToIdl(context.Background(), filePath, viper.New(), tc.statuses)
+			if err == nil || !strings.Contains(err.Error(), tc.expected) {
+				t.Fatalf("got %v, want %s", err, tc.expected)
+			}
+		})
+	}
+}
+
+func TestRenumberSegmentDataDirectories(t *testing.T) {
+	t.Run("renumbers the primary and mirror data directories with the offset", func(t *testing.T) {
+		segPairs := []cli.SegmentPair{
+			{Primary: &cli.Segment{DataDirectory: "/data/primary/gpseg0"}, Mirror: &cli.Segment{DataDirectory: "/data/mirror/gpseg0"}},
+			{Primary: &cli.Segment{DataDirectory: "/data/primary/gpseg1"}},
+		}
+
+		cli.RenumberSegmentDataDirectories(segPairs, 4)
+
+		expected := []cli.SegmentPair{
+			{Primary: &cli.Segment{DataDirectory: "/data/primary/gpseg4"}, Mirror: &cli.Segment{DataDirectory: "/data/mirror/gpseg4"}},
+			{Primary: &cli.Segment{DataDirectory: "/data/primary/gpseg5"}},
+		}
+		if !reflect.DeepEqual(segPairs, expected) {
+			t.Fatalf("got %+v, want %+v", segPairs, expected)
+		}
+	})
+}
+
+func TestExpandClusterService(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	request := &idl.ExpandClusterRequest{
+		CoordinatorDataDir: "/data/gpseg-1",
+		BatchSize:          4,
+	}
+
+	t.Run("returns error if RPC returns error", func(t *testing.T) {
+		testStr := "test-error"
+		defer resetCLIVars()
+		gpservice_config.ConnectToHub = func(conf *gpservice_config.Config) (idl.HubClient, error) {
+			hubClient := mock_idl.NewMockHubClient(ctrl)
+			hubClient.EXPECT().ExpandCluster(gomock.Any(), request).Return(nil, fmt.Errorf(testStr))
+			return hubClient, nil
+		}
+
+		err := cli.ExpandClusterService(context.Background(), cli.NewStreamController(), request)
+		if err == nil || !strings.Contains(err.Error(), testStr) {
+			t.Fatalf("got %v, want %v", err, testStr)
+		}
+	})
+
+	t.Run("returns error if stream receiver returns error", func(t *testing.T) {
+		testStr := "redistributing tables failed"
+		defer resetCLIVars()
+		gpservice_config.ConnectToHub = func(conf *gpservice_config.Config) (idl.HubClient, error) {
+			hubClient := mock_idl.NewMockHubClient(ctrl)
+			hubClient.EXPECT().ExpandCluster(gomock.Any(), gomock.Any()).Return(nil, nil)
+			return hubClient, nil
+		}
+		cli.ParseStreamResponse = func(stream cli.StreamReceiver, ctrl *cli.StreamController) error {
+			return fmt.Errorf(testStr)
+		}
+
+		err := cli.ExpandClusterService(context.Background(), cli.NewStreamController(), request)
+		if err == nil || !strings.Contains(err.Error(), testStr) {
+			t.Fatalf("got %v, want %v", err, testStr)
+		}
+	})
+
+	t.Run("rolls back the changes when adding the new segments fails", func(t *testing.T) {
+		defer resetCLIVars()
+		defer utils.ResetSystemFunctions()
+
+		expectedErr := errors.New("error")
+		var cleanCalled bool
+		gpservice_config.ConnectToHub = func(conf *gpservice_config.Config) (idl.HubClient, error) {
+			hubClient := mock_idl.NewMockHubClient(ctrl)
+			hubClient.EXPECT().ExpandCluster(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+			hubClient.EXPECT().CleanInitCluster(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, req *idl.CleanInitClusterRequest, opts ...interface{}) (*idl.CleanInitClusterReply, error) {
+					cleanCalled = true
+					return &idl.CleanInitClusterReply{}, nil
+				}).AnyTimes()
+			return hubClient, nil
+		}
+		cli.ParseStreamResponse = func(stream cli.StreamReceiver, ctrl *cli.StreamController) error {
+			return expectedErr
+		}
+		utils.System.Stat = func(name string) (os.FileInfo, error) {
+			return nil, nil
+		}
+
+		cli.TerminationRequested = true
+		cli.SigtermReceived = true
+		defer func() {
+			cli.TerminationRequested = false
+			cli.SigtermReceived = false
+		}()
+
+		err := cli.ExpandClusterService(context.Background(), cli.NewStreamController(), request)
+		var expected *cli.ErrorUserTermination
+		if !errors.As(err, &expected) {
+			t.Fatalf("got %v, want %T", err, expected)
+		}
+
+		if !cleanCalled {
+			t.Fatalf("expected the changes to be rolled back")
+		}
+	})
+}
@@ -6,13 +6,10 @@ import (
 	"errors"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"slices"
 	"strconv"
 	"strings"
-	"sync"
-	"time"
 
 	"github.com/greenplum-db/gpdb/gpservice/pkg/greenplum"
 
@@ -60,6 +57,7 @@ type InitConfig struct {
 	CoordinatorConfig map[string]string `mapstructure:"coordinator-config"`
 	SegmentConfig     map[string]string `mapstructure:"segment-config"`
 	Coordinator       Segment           `mapstructure:"coordinator"`
+	Standby           *Segment          `mapstructure:"standby"`
 	SegmentArray      []SegmentPair     `mapstructure:"segment-array"`
 
 	//Expansion config parameters
@@ -82,12 +80,19 @@ var (
 	IsGpServicesEnabled                  = IsGpServicesEnabledFn
 )
 
+// expansionConfigKeys are the configuration parameters which get expanded into the segment-array
+var expansionConfigKeys = []string{"hostlist", "primary-base-port", "primary-data-directories", "mirroring-type", "mirror-base-port", "mirror-data-directories"}
+
 var (
-	cliForceFlag   bool
-	cliCleanFlag   bool
-	CleanFilePath  string
-	ContainsMirror bool
-	HubClient      idl.HubClient
+	cliForceFlag        bool
+	cliCleanFlag        bool
+	cliDryRunFlag       bool
+	cliResumeFlag       bool
+	cliWriteConfigFile  string
+	cliNoServiceManager bool
+	CleanFilePath       string
+	ContainsMirror      bool
+	HubClient           idl.HubClient
 )
 
 func initCmd() *cobra.Command {
@@ -99,6 +104,10 @@ func initCmd() *cobra.Command {
 
 	initCmd.Flags().BoolVar(&cliForceFlag, "force", false, "Create the database cluster by overwriting the data directories if they are not empty")
 	initCmd.Flags().BoolVar(&cliCleanFlag, "clean", false, "Rollback the changes made due to a failed cluster initialization")
+	initCmd.Flags().BoolVar(&cliResumeFlag, "resume", false, "Resume a failed cluster initialization from the last completed step, using the same configuration file")
+	initCmd.Flags().BoolVar(&cliDryRunFlag, "dry-run", false, "Validate the configuration and the hosts, and display the segment layout without creating the cluster")
+	initCmd.Flags().StringVar(&cliWriteConfigFile, "write-config", "", "Write the configuration with the expanded segment-array to the given file, to be used with --dry-run")
+	initCmd.Flags().BoolVar(&cliNoServiceManager, "no-service-manager", false, "Run hub and agents as supervised processes instead of systemd or launchd services when gpservice is not configured yet, for hosts such as containers")
 
 	return initCmd
 }
@@ -117,6 +126,14 @@ func RunInitClusterCmd(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("cannot use --clean and --force together")
 		}
 
+		if cliDryRunFlag {
+			return fmt.Errorf("cannot use --clean and --dry-run together")
+		}
+
+		if cliResumeFlag {
+			return fmt.Errorf("cannot use --clean and --resume together")
+		}
+
 		_, err := utils.System.Stat(clusterCleanupFile)
 		if err != nil {
 			return fmt.Errorf("cluster is clean, no cleanup file present")
@@ -130,6 +147,25 @@ func RunInitClusterCmd(cmd *cobra.Command, args []string) error {
 		if len(args) > 1 {
 			return fmt.Errorf("more arguments than expected")
 		}
+
+		if cliWriteConfigFile != "" && !cliDryRunFlag {
+			return fmt.Errorf("--write-config can only be used with --dry-run")
+		}
+
+		if cliResumeFlag {
+			if cliDryRunFlag {
+				return fmt.Errorf("cannot use --resume and --dry-run together")
+			}
+
+			_, err := utils.System.Stat(filepath.Join(greenplum.GetDefaultHubLogDir(), constants.InitJournalFileName))
+			if err != nil {
+				return fmt.Errorf("no failed cluster initialization found to resume")
+			}
+		}
+	}
+
+	if IsConfigured && cliNoServiceManager && !Conf.NoServiceManager {
+		gplog.Warn("gpservice is already configured to use a service manager, ignoring --no-service-manager")
 	}
 
 	defer stopAndDeleteService(!IsGpserviceRunning, !IsConfigured)
@@ -156,7 +192,7 @@ func RunInitClusterCmd(cmd *cobra.Command, args []string) error {
 
 		err = gpservice_mgmt.InitialiseGpService(ConfigFilePath, constants.DefaultHubPort, constants.DefaultAgentPort,
 			hostnames, greenplum.GetDefaultHubLogDir(), constants.DefaultServiceName,
-			os.Getenv("GPHOME"), "", "", "", true, true)
+			os.Getenv("GPHOME"), &utils.GpCredentials{}, true, cliNoServiceManager)
 
 		if err != nil {
 			return err
@@ -169,7 +205,7 @@ func RunInitClusterCmd(cmd *cobra.Command, args []string) error {
 	}
 
 	if !IsConfigured || !IsGpserviceRunning {
-		err = gpservice_mgmt.StartServices(Conf)
+		err = gpservice_mgmt.StartServices(Conf, ConfigFilePath)
 		if err != nil {
 			return err
 		}
@@ -179,34 +215,11 @@ func RunInitClusterCmd(cmd *cobra.Command, args []string) error {
 		return InitClean(false)
 	}
 
-	ctx, cancel := context.WithCancel(context.Background())
+	ctx, cancel := context.WithCancel(commandContext(cmd))
 	ctrl := NewStreamController()
 
 	SetSignalHandler(ctrl)
-
-	go func() {
-		ticker := time.NewTicker(constants.CheckInterruptFrequency)
-		defer ticker.Stop()
-
-		for ; true; <-ticker.C {
-			if TerminationRequested {
-				cancel()
-				return
-			}
-		}
-	}()
-
-	lockDir, err := utils.NewLockDir(cmd)
-	if err != nil {
-		return err
-	}
-	defer func() {
-		err := lockDir.Remove()
-		if err != nil {
-			gplog.Error("failed to remove lock directory err %v", err)
-		}
-
-	}()
+	CancelOnTermination(cancel)
 
 	err = InitClusterService(args[0], ctx, ctrl, cliForceFlag, verbose)
 	if err != nil {
@@ -250,12 +263,32 @@ func stopAndDeleteService(toStop, toDelete bool) {
 	}
 }
 
+/*
+InitClean rolls back the changes of a failed cluster initialization. When prompt is set, it
+follows the --on-failure policy, which may ask the user whether to continue with the rollback.
+*/
 func InitClean(prompt bool) error {
+	// A failed cluster initialization can also be resumed, so let the user know before the rollback
+	if prompt && !decideRollback() {
+		gplog.Info("Exiting without rollback")
+		gplog.Info("Please run gpctl init --resume <config-file> to continue the cluster initialization or gpctl init --clean to rollback")
+		return nil
+	}
+
+	return RollbackChanges(false, "init")
+}
+
+/*
+RollbackChanges removes the data directories listed in the cleanup file written by the hub
+when a command which creates segments fails. When prompt is set, it follows the --on-failure
+policy. The command name is used to tell the user how to run the rollback later in case the
+changes are kept.
+*/
+func RollbackChanges(prompt bool, command string) error {
 	if prompt {
-		input := utils.AskUserYesOrNo("Continue with the rollback?")
-		if !input {
+		if !decideRollback() {
 			gplog.Info("Exiting without rollback")
-			gplog.Info("Please run gpctl init --clean to rollback")
+			gplog.Info("Please run gpctl %s --clean to rollback", command)
 			return nil
 
 		}
@@ -289,12 +322,11 @@ func InitClusterServiceFn(inputConfigFile string, ctx context.Context, ctrl *Str
 			fileName := filepath.Join(Conf.LogDir, constants.CleanFileName)
 			_, statErr := utils.System.Stat(fileName)
 
-			// do not display the prompt in case of SIGTERM
-			displayPrompt := !(TerminationRequested && SigtermReceived)
-
 			if statErr == nil {
 				gplog.Error("failed to initialize the cluster: %v", err)
-				err = InitClean(displayPrompt)
+				if cleanErr := InitClean(true); cleanErr != nil {
+					err = errors.Join(err, cleanErr)
+				}
 			}
 		}
 	}()
@@ -323,6 +355,16 @@ func InitClusterServiceFn(inputConfigFile string, ctx context.Context, ctrl *Str
 		return err
 	}
 
+	if cliResumeFlag {
+		clusterReq.Resume = true
+	}
+
+	if cliDryRunFlag {
+		// Display the layout before validating the hosts so that it can be reviewed even if the validation fails
+		DisplaySegmentLayout(os.Stdout, clusterReq.GpArray)
+		clusterReq.DryRun = true
+	}
+
 	// Call RPC on Hub to create the cluster
 	stream, err := HubClient.MakeCluster(ctx, clusterReq)
 	if err != nil {
@@ -334,6 +376,19 @@ func InitClusterServiceFn(inputConfigFile string, ctx context.Context, ctrl *Str
 		return err
 	}
 
+	if cliDryRunFlag {
+		if cliWriteConfigFile != "" {
+			err = WriteExpandedConfig(cliHandler, clusterReq.GpArray, cliWriteConfigFile)
+			if err != nil {
+				return err
+			}
+			gplog.Info("Wrote the configuration with the expanded segment-array to %s", cliWriteConfigFile)
+		}
+
+		gplog.Info("Dry run completed successfully, the cluster has not been created")
+		return nil
+	}
+
 	if TerminationRequested {
 		gplog.Info("Not able to stop the current execution as it has been completed")
 	}
@@ -386,7 +441,6 @@ func LoadInputConfigToIdlFn(ctx context.Context, inputConfigFile string, cliHand
 		//Expand details to config for primary
 		segmentPairArray := ExpandSegPairArray(config, isMultiHome, NameAddressMap, AddressNameMap)
 		config.SegmentArray = segmentPairArray
-		// TODO to print expanded configuration here for user reference and print to file if required
 	}
 
 	return CreateMakeClusterReq(&config, force, verbose), nil
@@ -453,8 +507,7 @@ func IsMultiHome(ctx context.Context, hostlist []string) (isMultiHome bool, Name
 }
 
 func AnyExpansionConfigPresent(cliHandle *viper.Viper) bool {
-	expansionKeys := []string{"hostlist", "primary-base-port", "primary-data-directories", "mirroring-type", "mirror-base-port", "mirror-data-directories"}
-	for _, key := range expansionKeys {
+	for _, key := range expansionConfigKeys {
 		if cliHandle.IsSet(key) {
 			return true
 		}
@@ -769,6 +822,7 @@ func CreateMakeClusterReq(config *InitConfig, forceFlag bool, verbose bool) *idl
 		GpArray: &idl.GpArray{
 			Coordinator:  SegmentToIdl(&config.Coordinator),
 			SegmentArray: segmentPairs,
+			Standby:      SegmentToIdl(config.Standby),
 		},
 		ClusterParams: ClusterParamsToIdl(config),
 		ForceFlag:     forceFlag,
@@ -862,7 +916,11 @@ func ValidateInputConfigAndSetDefaultsFn(request *idl.MakeClusterRequest, cliHan
 	}
 
 	// validate the details of segments
-	for _, seg := range append(request.GetPrimarySegments(), request.GetMirrorSegments()...) {
+	segs := append(request.GetPrimarySegments(), request.GetMirrorSegments()...)
+	if request.GpArray.Standby != nil {
+		segs = append(segs, request.GpArray.Standby)
+	}
+	for _, seg := range segs {
 		err = ValidateSegment(seg)
 		if err != nil {
 			return err
@@ -870,7 +928,7 @@ func ValidateInputConfigAndSetDefaultsFn(request *idl.MakeClusterRequest, cliHan
 	}
 
 	// check for conflicting port and data-dir on a host
-	err = CheckForDuplicatPortAndDataDirectory(append(request.GetPrimarySegments(), request.GetMirrorSegments()...))
+	err = CheckForDuplicatPortAndDataDirectory(append(segs, request.GpArray.Coordinator))
 	if err != nil {
 		return err
 	}
@@ -1006,6 +1064,9 @@ func IsGpServicesEnabledFn(req *idl.MakeClusterRequest) error {
 	for _, seg := range req.GetPrimarySegments() {
 		hostnames = append(hostnames, seg.HostName)
 	}
+	if req.GpArray.Standby != nil {
+		hostnames = append(hostnames, req.GpArray.Standby.HostName)
+	}
 
 	// remove any duplicate entries
 	slices.Sort(hostnames)
@@ -1104,51 +1165,21 @@ func CheckGpServiceIsConfigured() bool {
 	return true
 }
 
-// getHostnameUsingSSH executes the SSH command to run `hostname` on the remote host
-func getHostnameUsingSSH(host string, wg *sync.WaitGroup, results chan<- string, errors chan<- error) {
-	defer wg.Done()
-
-	// Construct the SSH command
-	cmd := exec.Command("ssh", host, "hostname")
-
-	// Capture the output
-	var stdout, stderr bytes.Buffer
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
-
-	if err := cmd.Run(); err != nil {
-		errors <- fmt.Errorf("failed to run command on host %s: %v, stderr: %s", host, err, stderr.String())
-		return
-	}
-
-	hostname := strings.TrimSpace(stdout.String())
-	results <- hostname
-}
-
 // GetHostnamesFromHosts takes a list of hosts, performs SSH to them in parallel, and runs the hostname command
 // returns list of hostname
 func GetHostnamesFromHosts(hosts []string) ([]string, error) {
-	var wg sync.WaitGroup
-	results := make(chan string, len(hosts))
-	errs := make(chan error, len(hosts))
-
-	for _, host := range hosts {
-		wg.Add(1)
-		go getHostnameUsingSSH(host, &wg, results, errs)
-	}
-
-	wg.Wait()
-	close(results)
-	close(errs)
+	results := utils.Remote.Run(hosts, "hostname")
 
 	var hostnames []string
-	for result := range results {
-		hostnames = append(hostnames, result)
+	for _, result := range results {
+		if result.Error() == nil {
+			hostnames = append(hostnames, strings.TrimSpace(result.Stdout))
+		}
 	}
 
-	var err error
-	for e := range errs {
-		err = errors.Join(err, e)
+	err := results.Err()
+	if err != nil {
+		err = fmt.Errorf("failed to get the hostnames: %w", err)
 	}
 
 	return hostnames, err
@@ -1211,6 +1242,11 @@ func GetHostlistFromConfig(filePath string) ([]string, error) {
 		hostnameMap[config.Coordinator.Hostname] = struct{}{}
 	}
 
+	// Add standby hostname
+	if config.Standby != nil && config.Standby.Hostname != "" {
+		hostnameMap[config.Standby.Hostname] = struct{}{}
+	}
+
 	// Add hostnames from segment-array
 	for _, segment := range config.SegmentArray {
 		if segment.Primary.Hostname != "" {
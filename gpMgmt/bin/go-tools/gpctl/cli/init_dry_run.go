@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/spf13/viper"
+
+	"github.com/greenplum-db/gpdb/gpservice/idl"
+)
+
+// LayoutSegment is the user facing representation of a segment of the cluster to be created
+type LayoutSegment struct {
+	Role     string `json:"role"`
+	Content  int    `json:"content"`
+	Hostname string `json:"hostname"`
+	Address  string `json:"address"`
+	Port     int32  `json:"port"`
+	DataDir  string `json:"data_directory"`
+}
+
+/*
+DisplaySegmentLayout writes the fully expanded layout of the cluster to be created to the
+given writer. The content IDs are the ones which get assigned to the segments by the hub.
+With a structured output, the layout is reported as the data of the result of the command.
+*/
+func DisplaySegmentLayout(outfile io.Writer, gparray *idl.GpArray) {
+	var layout []LayoutSegment
+	addSegment := func(role string, content int, seg *idl.Segment) {
+		if seg != nil {
+			layout = append(layout, LayoutSegment{Role: role, Content: content, Hostname: seg.HostName, Address: seg.HostAddress, Port: seg.Port, DataDir: seg.DataDirectory})
+		}
+	}
+
+	addSegment("coordinator", -1, gparray.Coordinator)
+	addSegment("standby", -1, gparray.Standby)
+	for content, pair := range gparray.SegmentArray {
+		addSegment("primary", content, pair.Primary)
+	}
+	for content, pair := range gparray.SegmentArray {
+		addSegment("mirror", content, pair.Mirror)
+	}
+
+	if StructuredOutput() {
+		output.SetData(layout)
+		return
+	}
+
+	w := new(tabwriter.Writer)
+	w.Init(outfile, 10, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ROLE\tCONTENT\tHOST\tADDRESS\tPORT\tDATA DIRECTORY")
+
+	for _, seg := range layout {
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%d\t%s\n", seg.Role, seg.Content, seg.Hostname, seg.Address, seg.Port, seg.DataDir)
+	}
+
+	w.Flush()
+}
+
+/*
+WriteExpandedConfig writes the input configuration to the given file with the expansion
+parameters replaced by an explicit segment-array, so that the exact layout which was
+reviewed can be used to create the cluster. The file format is based on its extension.
+*/
+func WriteExpandedConfig(cliHandler *viper.Viper, gparray *idl.GpArray, outputFile string) error {
+	settings := cliHandler.AllSettings()
+	for _, key := range expansionConfigKeys {
+		delete(settings, key)
+	}
+
+	var segmentArray []map[string]*Segment
+	for _, pair := range gparray.SegmentArray {
+		entry := map[string]*Segment{"primary": segmentFromIdl(pair.Primary)}
+		if pair.Mirror != nil {
+			entry["mirror"] = segmentFromIdl(pair.Mirror)
+		}
+		segmentArray = append(segmentArray, entry)
+	}
+	settings["segment-array"] = segmentArray
+
+	writer := viper.New()
+	err := writer.MergeConfigMap(settings)
+	if err != nil {
+		return fmt.Errorf("could not create the configuration file %s: %w", outputFile, err)
+	}
+
+	err = writer.WriteConfigAs(outputFile)
+	if err != nil {
+		return fmt.Errorf("could not write the configuration file %s: %w", outputFile, err)
+	}
+
+	return nil
+}
+
+func segmentFromIdl(seg *idl.Segment) *Segment {
+	return &Segment{
+		Hostname:      seg.HostName,
+		Address:       seg.HostAddress,
+		Port:          int(seg.Port),
+		DataDirectory: seg.DataDirectory,
+	}
+}
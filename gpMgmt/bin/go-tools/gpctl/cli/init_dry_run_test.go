@@ -0,0 +1,150 @@
+package cli_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/greenplum-db/gpdb/gpctl/cli"
+	"github.com/greenplum-db/gpdb/gpservice/idl"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+	"github.com/greenplum-db/gpdb/gpservice/testutils"
+)
+
+func TestDisplaySegmentLayout(t *testing.T) {
+	gparray := &idl.GpArray{
+		Coordinator: &idl.Segment{HostName: "cdw", HostAddress: "cdw", Port: 7000, DataDirectory: "/data/coordinator/gpseg-1"},
+		SegmentArray: []*idl.SegmentPair{
+			{
+				Primary: &idl.Segment{HostName: "sdw1", HostAddress: "sdw1-1", Port: 7002, DataDirectory: "/data/primary/gpseg0"},
+				Mirror:  &idl.Segment{HostName: "sdw2", HostAddress: "sdw2-1", Port: 8002, DataDirectory: "/data/mirror/gpseg0"},
+			},
+			{
+				Primary: &idl.Segment{HostName: "sdw2", HostAddress: "sdw2-1", Port: 7002, DataDirectory: "/data/primary/gpseg1"},
+				Mirror:  &idl.Segment{HostName: "sdw1", HostAddress: "sdw1-1", Port: 8002, DataDirectory: "/data/mirror/gpseg1"},
+			},
+		},
+	}
+
+	t.Run("displays the layout as a table", func(t *testing.T) {
+		var buf bytes.Buffer
+		cli.DisplaySegmentLayout(&buf, gparray)
+
+		expected := `ROLE         CONTENT   HOST      ADDRESS   PORT      DATA DIRECTORY
+coordinator  -1        cdw       cdw       7000      /data/coordinator/gpseg-1
+primary      0         sdw1      sdw1-1    7002      /data/primary/gpseg0
+primary      1         sdw2      sdw2-1    7002      /data/primary/gpseg1
+mirror       0         sdw2      sdw2-1    8002      /data/mirror/gpseg0
+mirror       1         sdw1      sdw1-1    8002      /data/mirror/gpseg1
+`
+		if buf.String() != expected {
+			t.Fatalf("got:\n%s\nwant:\n%s", buf.String(), expected)
+		}
+	})
+
+	t.Run("reports the layout as the data of the result with a structured output", func(t *testing.T) {
+		buffer, writer, resetStdout := testutils.CaptureStdout(t)
+		defer resetStdout()
+
+		resetOutput := cli.SetOutputFormat(utils.OutputFormatJson)
+		defer resetOutput()
+
+		var buf bytes.Buffer
+		cli.DisplaySegmentLayout(&buf, gparray)
+		cli.WriteResult(&cobra.Command{Use: "init"}, nil)
+		writer.Close()
+		stdout := <-buffer
+
+		if buf.Len() != 0 {
+			t.Fatalf("got %s, want no table", buf.String())
+		}
+
+		var result struct {
+			Data []cli.LayoutSegment `json:"data"`
+		}
+		err := json.Unmarshal([]byte(stdout), &result)
+		if err != nil {
+			t.Fatalf("got %s, want a single json object: %v", stdout, err)
+		}
+
+		expected := cli.LayoutSegment{Role: "mirror", Content: 1, Hostname: "sdw1", Address: "sdw1-1", Port: 8002, DataDir: "/data/mirror/gpseg1"}
+		if len(result.Data) != 5 || result.Data[4] != expected {
+			t.Fatalf("got %+v, want 5 segments ending with %+v", result.Data, expected)
+		}
+	})
+}
+
+func TestWriteExpandedConfig(t *testing.T) {
+	input := `{
+	"encoding": "UTF-8",
+	"coordinator": {"hostname": "cdw", "address": "cdw", "port": 7000, "data-directory": "/data/coordinator/gpseg-1"},
+	"hostlist": ["sdw1"],
+	"primary-base-port": 7002,
+	"primary-data-directories": ["/data/primary"]
+}`
+
+	gparray := &idl.GpArray{
+		SegmentArray: []*idl.SegmentPair{
+			{Primary: &idl.Segment{HostName: "sdw1", HostAddress: "sdw1", Port: 7002, DataDirectory: "/data/primary/gpseg0"}},
+		},
+	}
+
+	for _, ext := range []string{"json", "yaml"} {
+		t.Run("writes the expanded segment-array as "+ext, func(t *testing.T) {
+			cliHandler := viper.New()
+			cliHandler.SetConfigType("json")
+			err := cliHandler.ReadConfig(strings.NewReader(input))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			outputFile := filepath.Join(t.TempDir(), "expanded."+ext)
+			err = cli.WriteExpandedConfig(cliHandler, gparray, outputFile)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			reader := viper.New()
+			reader.SetConfigFile(outputFile)
+			err = reader.ReadInConfig()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var config cli.InitConfig
+			err = reader.UnmarshalExact(&config)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if cli.AnyExpansionConfigPresent(reader) {
+				t.Fatalf("expected the expansion parameters to be removed, got %v", reader.AllSettings())
+			}
+
+			expected := []cli.SegmentPair{
+				{Primary: &cli.Segment{Hostname: "sdw1", Address: "sdw1", Port: 7002, DataDirectory: "/data/primary/gpseg0"}},
+			}
+			if !reflect.DeepEqual(config.SegmentArray, expected) {
+				t.Fatalf("got %+v, want %+v", config.SegmentArray, expected)
+			}
+
+			if config.Encoding != "UTF-8" || config.Coordinator.Port != 7000 {
+				t.Fatalf("expected the remaining parameters to be retained, got %+v", config)
+			}
+		})
+	}
+
+	t.Run("returns error when it fails to write the file", func(t *testing.T) {
+		err := cli.WriteExpandedConfig(viper.New(), gparray, filepath.Join(t.TempDir(), "missing", "expanded.json"))
+		expected := "could not write the configuration file"
+		if err == nil || !strings.HasPrefix(err.Error(), expected) {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+}
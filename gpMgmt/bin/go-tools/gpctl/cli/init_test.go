@@ -19,6 +19,7 @@ import (
 	"github.com/greenplum-db/gpdb/gpservice/idl"
 	"github.com/greenplum-db/gpdb/gpservice/idl/mock_idl"
 	"github.com/greenplum-db/gpdb/gpservice/pkg/gpservice_config"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/gpservice_mgmt"
 	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
 	"github.com/greenplum-db/gpdb/gpservice/testutils"
 	"github.com/greenplum-db/gpdb/gpservice/testutils/exectest"
@@ -65,6 +66,22 @@ func teardownTest() {
 
 func resetCLIVars() {
 	cli.InitClusterService = cli.InitClusterServiceFn
+	cli.StartClusterService = cli.StartClusterServiceFn
+	cli.StopClusterService = cli.StopClusterServiceFn
+	cli.GetClusterStatus = cli.GetClusterStatusFn
+	cli.GetClusterReport = cli.GetClusterReportFn
+	cli.RecoverSegmentsService = cli.RecoverSegmentsServiceFn
+	cli.LoadRecoverConfigToIdl = cli.LoadRecoverConfigToIdlFn
+	cli.AddMirrorsService = cli.AddMirrorsServiceFn
+	cli.LoadAddMirrorsConfigToIdl = cli.LoadAddMirrorsConfigToIdlFn
+	cli.ExpandClusterService = cli.ExpandClusterServiceFn
+	cli.LoadExpandConfigToIdl = cli.LoadExpandConfigToIdlFn
+	cli.AddStandbyService = cli.AddStandbyServiceFn
+	cli.RemoveStandbyService = cli.RemoveStandbyServiceFn
+	cli.ActivateStandbyService = cli.ActivateStandbyServiceFn
+	cli.PromoteStandby = cli.PromoteStandbyFn
+	cli.CheckOldCoordinatorStopped = cli.CheckOldCoordinatorStoppedFn
+	cli.MoveHubService = gpservice_mgmt.MoveHubService
 	cli.LoadInputConfigToIdl = cli.LoadInputConfigToIdlFn
 	cli.ValidateInputConfigAndSetDefaults = cli.ValidateInputConfigAndSetDefaultsFn
 	cli.ParseStreamResponse = cli.ParseStreamResponseFn
@@ -1765,6 +1782,38 @@ func TestValidateInputConfigAndSetDefaults(t *testing.T) {
 			return errors.New(expectedError)
 		}
 
+		err := cli.ValidateInputConfigAndSetDefaults(request, cliHandler)
+		if err == nil || !strings.Contains(err.Error(), expectedError) {
+			t.Fatalf("got %v, want %v", err, expectedError)
+		}
+	})
+	t.Run("fails if the standby has the same data directory as the coordinator on the same host", func(t *testing.T) {
+		defer resetCLIVars()
+		defer initializeRequest()
+		defer resetConfHostnames()
+		cli.Conf.Hostnames = []string{"cdw", "sdw1", "sdw2"}
+		request.GpArray.Standby = &idl.Segment{
+			HostAddress:   "cdw",
+			HostName:      "cdw",
+			Port:          701,
+			DataDirectory: "/tmp/coordinator/",
+		}
+		expectedError := "duplicate data directory entry /tmp/coordinator/ found for host cdw"
+
+		err := cli.ValidateInputConfigAndSetDefaults(request, cliHandler)
+		if err == nil || !strings.Contains(err.Error(), expectedError) {
+			t.Fatalf("got %v, want %v", err, expectedError)
+		}
+	})
+	t.Run("fails if the standby hostname is not provided", func(t *testing.T) {
+		defer resetCLIVars()
+		defer initializeRequest()
+		request.GpArray.Standby = &idl.Segment{
+			Port:          700,
+			DataDirectory: "/tmp/standby/",
+		}
+		expectedError := "hostName has not been provided for the segment with port 700 and data_directory /tmp/standby/"
+
 		err := cli.ValidateInputConfigAndSetDefaults(request, cliHandler)
 		if err == nil || !strings.Contains(err.Error(), expectedError) {
 			t.Fatalf("got %v, want %v", err, expectedError)
@@ -2042,6 +2091,18 @@ func TestIsGpServicesEnabledFn(t *testing.T) {
 			t.Fatalf("got %v, want %v", err, expectedError)
 		}
 	})
+	t.Run("fails if the standby host does not have gp services configured", func(t *testing.T) {
+		defer resetCLIVars()
+		defer resetConfHostnames()
+		cli.Conf.Hostnames = []string{"cdw", "sdw1", "sdw2"}
+		standbyArray := gparray
+		standbyArray.Standby = &idl.Segment{HostName: "scdw"}
+		expectedError := "following hostnames [scdw] do not have gp services configured. Please configure the services"
+		err := cli.IsGpServicesEnabled(&idl.MakeClusterRequest{GpArray: &standbyArray})
+		if err == nil || !strings.Contains(err.Error(), expectedError) {
+			t.Fatalf("got %v, want %v", err, expectedError)
+		}
+	})
 }
 
 func TestInitClean(t *testing.T) {
@@ -2081,6 +2142,91 @@ func TestInitClean(t *testing.T) {
 		testutils.AssertLogMessage(t, logfile, `\[INFO\]:-Successfully cleaned up the changes`)
 	})
 
+	t.Run("does not rollback and suggests resuming when the user declines", func(t *testing.T) {
+		setupTest(t)
+		defer teardownTest()
+		defer resetCLIVars()
+
+		_, _, logfile := testhelper.SetupTestLogger()
+
+		resetStdin := testutils.MockStdin(t, fmt.Sprintln("n"))
+		defer resetStdin()
+
+		gpservice_config.ConnectToHub = func(conf *gpservice_config.Config) (idl.HubClient, error) {
+			t.Fatalf("unexpected call to the hub")
+			return nil, nil
+		}
+
+		err := cli.InitClean(true)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		testutils.AssertLogMessage(t, logfile, `\[INFO\]:-Please run gpctl init --resume <config-file> to continue the cluster initialization or gpctl init --clean to rollback`)
+	})
+
+	t.Run("keeps the changes without prompting when the failure policy is to keep them", func(t *testing.T) {
+		setupTest(t)
+		defer teardownTest()
+		defer resetCLIVars()
+
+		_, _, logfile := testhelper.SetupTestLogger()
+
+		resetPolicies := cli.SetPolicies(true, cli.OnFailureKeep, cli.OnInterruptPrompt)
+		defer resetPolicies()
+
+		gpservice_config.ConnectToHub = func(conf *gpservice_config.Config) (idl.HubClient, error) {
+			t.Fatalf("unexpected call to the hub")
+			return nil, nil
+		}
+
+		err := cli.InitClean(true)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		testutils.AssertLogMessage(t, logfile, `\[INFO\]:-Action on failure: keep, as --on-failure is keep`)
+		testutils.AssertLogMessage(t, logfile, `\[INFO\]:-Exiting without rollback`)
+	})
+
+	t.Run("rolls back without prompting and reports it in the result with --yes", func(t *testing.T) {
+		setupTest(t)
+		defer teardownTest()
+		defer resetCLIVars()
+
+		_, _, logfile := testhelper.SetupTestLogger()
+
+		resetPolicies := cli.SetPolicies(true, cli.OnFailurePrompt, cli.OnInterruptPrompt)
+		defer resetPolicies()
+
+		gpservice_config.ConnectToHub = func(conf *gpservice_config.Config) (idl.HubClient, error) {
+			hubClient := mock_idl.NewMockHubClient(ctrl)
+			hubClient.EXPECT().CleanInitCluster(gomock.Any(), gomock.Any())
+			return hubClient, nil
+		}
+
+		buffer, writer, resetStdout := testutils.CaptureStdout(t)
+		defer resetStdout()
+
+		resetOutput := cli.SetOutputFormat(utils.OutputFormatJson)
+		defer resetOutput()
+
+		err := cli.InitClean(true)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		cli.WriteResult(&cobra.Command{Use: "init"}, nil)
+		writer.Close()
+		stdout := <-buffer
+
+		testutils.AssertLogMessage(t, logfile, `\[INFO\]:-Successfully cleaned up the changes`)
+
+		expected := `"actions":[{"trigger":"failure","action":"rollback","reason":"--yes is set"}]`
+		if !strings.Contains(stdout, expected) {
+			t.Fatalf("got %s, want it to contain %s", stdout, expected)
+		}
+	})
+
 	t.Run("CleanInitCluster RPC fails", func(t *testing.T) {
 		setupTest(t)
 		defer teardownTest()
@@ -0,0 +1,214 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"github.com/greenplum-db/gpdb/gpservice/idl"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/gpservice_config"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+)
+
+// Operation is the user facing representation of a long running operation of the hub
+type Operation struct {
+	ID        string     `json:"id"`
+	Method    string     `json:"method"`
+	State     string     `json:"state"`
+	User      string     `json:"user,omitempty"`
+	StartTime time.Time  `json:"start_time"`
+	EndTime   *time.Time `json:"end_time,omitempty"`
+	Error     string     `json:"error,omitempty"`
+}
+
+func opsCmd() *cobra.Command {
+	opsCmd := &cobra.Command{
+		Use:   "ops",
+		Short: "Lists, follows and cancels the long running operations of the hub",
+		Long: `Lists, follows and cancels the long running operations of the hub, such as creating the
+cluster or adding mirrors. The hub keeps the output of the running operations and of the
+most recently finished ones, which can be replayed and followed from any session.`,
+		Example: `To list the operations of the hub
+$ gpctl ops list
+
+To follow the output of an operation started from another session
+$ gpctl ops attach 3f9a2c1b
+
+To cancel an operation
+$ gpctl ops cancel 3f9a2c1b
+`,
+	}
+
+	opsCmd.AddCommand(
+		opsListCmd(),
+		opsAttachCmd(),
+		opsCancelCmd(),
+	)
+
+	return opsCmd
+}
+
+func opsListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "Lists the running and recently finished operations",
+		Args:  cobra.NoArgs,
+		RunE:  RunOpsListCmd,
+	}
+}
+
+func opsAttachCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "attach <id>",
+		Short: "Replays the output of an operation and follows it until it finishes",
+		Long: `Replays the output of an operation and follows it until it finishes, returning the error
+the operation failed with, if any. Interrupting the command only stops following the
+operation, which can be cancelled using the 'gpctl ops cancel' command.`,
+		Args: cobra.ExactArgs(1),
+		RunE: RunOpsAttachCmd,
+	}
+}
+
+func opsCancelCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "cancel <id>",
+		Short: "Cancels a running operation",
+		Args:  cobra.ExactArgs(1),
+		RunE:  RunOpsCancelCmd,
+	}
+}
+
+func RunOpsListCmd(cmd *cobra.Command, args []string) error {
+	err := CheckGpServiceRunning()
+	if err != nil {
+		return err
+	}
+
+	operations, err := ListOperations(commandContext(cmd))
+	if err != nil {
+		return err
+	}
+
+	DisplayOperations(os.Stdout, operations)
+	return nil
+}
+
+func RunOpsAttachCmd(cmd *cobra.Command, args []string) error {
+	err := CheckGpServiceRunning()
+	if err != nil {
+		return err
+	}
+
+	return AttachOperation(commandContext(cmd), NewStreamController(), args[0])
+}
+
+func RunOpsCancelCmd(cmd *cobra.Command, args []string) error {
+	err := CheckGpServiceRunning()
+	if err != nil {
+		return err
+	}
+
+	return CancelOperation(commandContext(cmd), args[0])
+}
+
+// ListOperations calls the ListOperations RPC on the hub and returns its operations
+func ListOperations(ctx context.Context) ([]Operation, error) {
+	client, err := gpservice_config.ConnectToHub(Conf)
+	if err != nil {
+		return nil, err
+	}
+
+	reply, err := client.ListOperations(ctx, &idl.ListOperationsRequest{})
+	if err != nil {
+		return nil, utils.FormatGrpcError(err)
+	}
+
+	var operations []Operation
+	for _, op := range reply.Operations {
+		operation := Operation{
+			ID:        op.Id,
+			Method:    op.Method,
+			State:     strings.ToLower(op.State.String()),
+			User:      op.User,
+			StartTime: time.Unix(op.StartTime, 0),
+			Error:     op.Error,
+		}
+		if op.EndTime != 0 {
+			endTime := time.Unix(op.EndTime, 0)
+			operation.EndTime = &endTime
+		}
+
+		operations = append(operations, operation)
+	}
+
+	return operations, nil
+}
+
+// AttachOperation calls the AttachOperation RPC on the hub and displays the streamed responses
+func AttachOperation(ctx context.Context, ctrl *StreamController, id string) error {
+	client, err := gpservice_config.ConnectToHub(Conf)
+	if err != nil {
+		return err
+	}
+
+	stream, err := client.AttachOperation(ctx, &idl.AttachOperationRequest{Id: id})
+	if err != nil {
+		return utils.FormatGrpcError(err)
+	}
+
+	err = ParseStreamResponse(stream, ctrl)
+	if err != nil {
+		return err
+	}
+
+	gplog.Info("Operation %s completed successfully", id)
+	return nil
+}
+
+// CancelOperation calls the CancelOperation RPC on the hub
+func CancelOperation(ctx context.Context, id string) error {
+	client, err := gpservice_config.ConnectToHub(Conf)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.CancelOperation(ctx, &idl.CancelOperationRequest{Id: id})
+	if err != nil {
+		return utils.FormatGrpcError(err)
+	}
+
+	gplog.Info("Requested the cancellation of operation %s", id)
+	return nil
+}
+
+/*
+DisplayOperations writes the operations to the given writer as a table. With a structured
+output, the operations are reported as the data of the result of the command instead.
+*/
+func DisplayOperations(outfile io.Writer, operations []Operation) {
+	if StructuredOutput() {
+		output.SetData(operations)
+		return
+	}
+
+	w := new(tabwriter.Writer)
+	w.Init(outfile, 10, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tMETHOD\tSTATE\tUSER\tSTARTED\tDURATION\tERROR")
+
+	for _, op := range operations {
+		end := time.Now()
+		if op.EndTime != nil {
+			end = *op.EndTime
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", op.ID, op.Method, op.State, op.User, op.StartTime.Format(time.DateTime), end.Sub(op.StartTime).Round(time.Second), op.Error)
+	}
+	w.Flush()
+}
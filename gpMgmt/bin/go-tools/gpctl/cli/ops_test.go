@@ -0,0 +1,221 @@
+package cli_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/greenplum-db/gpdb/gpctl/cli"
+	"github.com/greenplum-db/gpdb/gpservice/idl"
+	"github.com/greenplum-db/gpdb/gpservice/idl/mock_idl"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/gpservice_config"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+	"github.com/greenplum-db/gpdb/gpservice/testutils"
+	"github.com/spf13/cobra"
+)
+
+func TestRunOpsCmd(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	for name, run := range map[string]func(*cobra.Command, []string) error{
+		"list":   cli.RunOpsListCmd,
+		"attach": cli.RunOpsAttachCmd,
+		"cancel": cli.RunOpsCancelCmd,
+	} {
+		t.Run(fmt.Sprintf("%s returns error when gpservice is not running", name), func(t *testing.T) {
+			cli.IsConfigured = true
+			cli.IsGpserviceRunning = false
+
+			testStr := "gpservice is not running"
+			err := run(&cobra.Command{}, []string{"3f9a2c1b"})
+			if err == nil || !strings.Contains(err.Error(), testStr) {
+				t.Fatalf("got:%v, expected:%s", err, testStr)
+			}
+		})
+	}
+}
+
+func TestListOperations(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	t.Run("returns error if RPC returns error", func(t *testing.T) {
+		testStr := "test-error"
+		defer resetCLIVars()
+		gpservice_config.ConnectToHub = func(conf *gpservice_config.Config) (idl.HubClient, error) {
+			hubClient := mock_idl.NewMockHubClient(ctrl)
+			hubClient.EXPECT().ListOperations(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf(testStr))
+			return hubClient, nil
+		}
+
+		_, err := cli.ListOperations(context.Background())
+		if err == nil || !strings.Contains(err.Error(), testStr) {
+			t.Fatalf("got %v, want %v", err, testStr)
+		}
+	})
+
+	t.Run("returns the operations of the hub", func(t *testing.T) {
+		defer resetCLIVars()
+		gpservice_config.ConnectToHub = func(conf *gpservice_config.Config) (idl.HubClient, error) {
+			hubClient := mock_idl.NewMockHubClient(ctrl)
+			hubClient.EXPECT().ListOperations(gomock.Any(), &idl.ListOperationsRequest{}).Return(&idl.ListOperationsReply{
+				Operations: []*idl.Operation{
+					{Id: "3f9a2c1b", Method: "MakeCluster", State: idl.OperationState_RUNNING, User: "gpadmin", StartTime: 1000},
+					{Id: "0c4d8e2a", Method: "AddMirrors", State: idl.OperationState_FAILED, StartTime: 1000, EndTime: 1060, Error: "error"},
+				},
+			}, nil)
+			return hubClient, nil
+		}
+
+		operations, err := cli.ListOperations(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		endTime := time.Unix(1060, 0)
+		expected := []cli.Operation{
+			{ID: "3f9a2c1b", Method: "MakeCluster", State: "running", User: "gpadmin", StartTime: time.Unix(1000, 0)},
+			{ID: "0c4d8e2a", Method: "AddMirrors", State: "failed", StartTime: time.Unix(1000, 0), EndTime: &endTime, Error: "error"},
+		}
+		if !reflect.DeepEqual(operations, expected) {
+			t.Fatalf("got %+v, want %+v", operations, expected)
+		}
+	})
+}
+
+func TestAttachOperation(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	t.Run("follows the output of the operation", func(t *testing.T) {
+		defer resetCLIVars()
+		gpservice_config.ConnectToHub = func(conf *gpservice_config.Config) (idl.HubClient, error) {
+			hubClient := mock_idl.NewMockHubClient(ctrl)
+			hubClient.EXPECT().AttachOperation(gomock.Any(), &idl.AttachOperationRequest{Id: "3f9a2c1b"}).Return(nil, nil)
+			return hubClient, nil
+		}
+
+		called := false
+		cli.ParseStreamResponse = func(stream cli.StreamReceiver, ctrl *cli.StreamController) error {
+			called = true
+			return nil
+		}
+
+		err := cli.AttachOperation(context.Background(), cli.NewStreamController(), "3f9a2c1b")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !called {
+			t.Fatalf("expected the output of the operation to be displayed")
+		}
+	})
+
+	t.Run("returns the error the operation failed with", func(t *testing.T) {
+		testStr := "could not create segments"
+		defer resetCLIVars()
+		gpservice_config.ConnectToHub = func(conf *gpservice_config.Config) (idl.HubClient, error) {
+			hubClient := mock_idl.NewMockHubClient(ctrl)
+			hubClient.EXPECT().AttachOperation(gomock.Any(), gomock.Any()).Return(nil, nil)
+			return hubClient, nil
+		}
+		cli.ParseStreamResponse = func(stream cli.StreamReceiver, ctrl *cli.StreamController) error {
+			return fmt.Errorf(testStr)
+		}
+
+		err := cli.AttachOperation(context.Background(), cli.NewStreamController(), "3f9a2c1b")
+		if err == nil || !strings.Contains(err.Error(), testStr) {
+			t.Fatalf("got %v, want %v", err, testStr)
+		}
+	})
+}
+
+func TestCancelOperation(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	t.Run("cancels the operation", func(t *testing.T) {
+		defer resetCLIVars()
+		gpservice_config.ConnectToHub = func(conf *gpservice_config.Config) (idl.HubClient, error) {
+			hubClient := mock_idl.NewMockHubClient(ctrl)
+			hubClient.EXPECT().CancelOperation(gomock.Any(), &idl.CancelOperationRequest{Id: "3f9a2c1b"}).Return(&idl.CancelOperationReply{}, nil)
+			return hubClient, nil
+		}
+
+		err := cli.CancelOperation(context.Background(), "3f9a2c1b")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("returns error if RPC returns error", func(t *testing.T) {
+		testStr := "operation 3f9a2c1b has already finished"
+		defer resetCLIVars()
+		gpservice_config.ConnectToHub = func(conf *gpservice_config.Config) (idl.HubClient, error) {
+			hubClient := mock_idl.NewMockHubClient(ctrl)
+			hubClient.EXPECT().CancelOperation(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf(testStr))
+			return hubClient, nil
+		}
+
+		err := cli.CancelOperation(context.Background(), "3f9a2c1b")
+		if err == nil || !strings.Contains(err.Error(), testStr) {
+			t.Fatalf("got %v, want %v", err, testStr)
+		}
+	})
+}
+
+func TestDisplayOperations(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	endTime := time.Unix(1090, 0)
+	operations := []cli.Operation{
+		{ID: "0c4d8e2a", Method: "AddMirrors", State: "failed", User: "gpadmin", StartTime: time.Unix(1000, 0), EndTime: &endTime, Error: "error"},
+	}
+
+	t.Run("displays the operations as a table", func(t *testing.T) {
+		var buf bytes.Buffer
+		cli.DisplayOperations(&buf, operations)
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		if len(lines) != 2 {
+			t.Fatalf("got %d lines, want 2", len(lines))
+		}
+
+		fields := strings.Fields(lines[1])
+		expected := []string{"0c4d8e2a", "AddMirrors", "failed", "gpadmin"}
+		if !reflect.DeepEqual(fields[:4], expected) || fields[len(fields)-2] != "1m30s" || fields[len(fields)-1] != "error" {
+			t.Fatalf("got %q, want %q followed by the start time, 1m30s and the error", fields, expected)
+		}
+	})
+
+	t.Run("reports the operations as the data of the result with a structured output", func(t *testing.T) {
+		buffer, writer, resetStdout := testutils.CaptureStdout(t)
+		defer resetStdout()
+
+		resetOutput := cli.SetOutputFormat(utils.OutputFormatYaml)
+		defer resetOutput()
+
+		var buf bytes.Buffer
+		cli.DisplayOperations(&buf, operations)
+		cli.WriteResult(&cobra.Command{Use: "list"}, nil)
+		writer.Close()
+		stdout := <-buffer
+
+		if buf.Len() != 0 {
+			t.Fatalf("got %s, want no table", buf.String())
+		}
+
+		for _, expected := range []string{"data:\n    - id: 0c4d8e2a\n", "      state: failed\n", "      end_time: "} {
+			if !strings.Contains(stdout, expected) {
+				t.Fatalf("got %s, want it to contain %q", stdout, expected)
+			}
+		}
+	})
+}
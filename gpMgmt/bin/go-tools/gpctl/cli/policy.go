@@ -0,0 +1,123 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+)
+
+const (
+	OnFailureRollback = "rollback"
+	OnFailureKeep     = "keep"
+	OnFailurePrompt   = "prompt"
+
+	OnInterruptTerminate = "terminate"
+	OnInterruptContinue  = "continue"
+	OnInterruptPrompt    = "prompt"
+)
+
+const (
+	triggerFailure   = "failure"
+	triggerInterrupt = "interrupt"
+)
+
+var (
+	nonInteractive bool
+	onFailure      = OnFailurePrompt
+	onInterrupt    = OnInterruptPrompt
+)
+
+func validatePolicies() error {
+	if onFailure != OnFailureRollback && onFailure != OnFailureKeep && onFailure != OnFailurePrompt {
+		return fmt.Errorf("invalid value %q for --on-failure, valid values are %s, %s and %s", onFailure, OnFailureRollback, OnFailureKeep, OnFailurePrompt)
+	}
+
+	if onInterrupt != OnInterruptTerminate && onInterrupt != OnInterruptContinue && onInterrupt != OnInterruptPrompt {
+		return fmt.Errorf("invalid value %q for --on-interrupt, valid values are %s, %s and %s", onInterrupt, OnInterruptTerminate, OnInterruptContinue, OnInterruptPrompt)
+	}
+
+	return nil
+}
+
+// confirm asks the user to confirm the prompt, which is assumed to be confirmed with --yes
+func confirm(prompt string) bool {
+	if nonInteractive {
+		gplog.Info("%s Continuing as --yes is set", prompt)
+		return true
+	}
+
+	return utils.AskUserYesOrNo(prompt)
+}
+
+/*
+decideRollback tells whether the changes of a failed command are rolled back, following the
+--on-failure policy. With the prompt policy, the changes are rolled back without asking the
+user when the command was terminated or --yes is set.
+*/
+func decideRollback() bool {
+	var rollback bool
+	var reason string
+
+	switch {
+	case onFailure != OnFailurePrompt:
+		rollback, reason = onFailure == OnFailureRollback, fmt.Sprintf("--on-failure is %s", onFailure)
+	case SigtermReceived:
+		rollback, reason = true, "the command was terminated"
+	case nonInteractive:
+		rollback, reason = true, "--yes is set"
+	default:
+		rollback, reason = utils.AskUserYesOrNo("Continue with the rollback?"), "the user answered the prompt"
+	}
+
+	action := OnFailureKeep
+	if rollback {
+		action = OnFailureRollback
+	}
+	reportAction(triggerFailure, action, reason)
+
+	return rollback
+}
+
+/*
+decideTermination tells whether the command is terminated upon an interrupt, following the
+--on-interrupt policy. With the prompt policy, the command is terminated without asking the
+user when --yes is set.
+*/
+func decideTermination() bool {
+	var terminate bool
+	var reason string
+
+	switch {
+	case onInterrupt != OnInterruptPrompt:
+		terminate, reason = onInterrupt == OnInterruptTerminate, fmt.Sprintf("--on-interrupt is %s", onInterrupt)
+	case nonInteractive:
+		terminate, reason = true, "--yes is set"
+	default:
+		terminate, reason = utils.AskUserYesOrNo("Do you want to continue terminating the current execution?"), "the user answered the prompt"
+	}
+
+	action := OnInterruptContinue
+	if terminate {
+		action = OnInterruptTerminate
+	}
+	reportAction(triggerInterrupt, action, reason)
+
+	return terminate
+}
+
+// reportAction logs the action taken upon a failure or an interrupt and adds it to the result of the command
+func reportAction(trigger, action, reason string) {
+	gplog.Info("Action on %s: %s, as %s", trigger, action, reason)
+	output.Action(utils.OutputAction{Trigger: trigger, Action: action, Reason: reason})
+}
+
+// used only for testing
+func SetPolicies(yes bool, failure, interrupt string) func() {
+	oldNonInteractive, oldOnFailure, oldOnInterrupt := nonInteractive, onFailure, onInterrupt
+	nonInteractive, onFailure, onInterrupt = yes, failure, interrupt
+
+	return func() {
+		nonInteractive, onFailure, onInterrupt = oldNonInteractive, oldOnFailure, oldOnInterrupt
+	}
+}
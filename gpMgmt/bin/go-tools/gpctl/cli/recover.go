@@ -0,0 +1,162 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"github.com/greenplum-db/gpdb/gpservice/constants"
+	"github.com/greenplum-db/gpdb/gpservice/idl"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/gpservice_config"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+)
+
+type RecoverSegmentPair struct {
+	Failed *Segment `mapstructure:"failed"`
+	Target *Segment `mapstructure:"target"`
+}
+
+type RecoverConfig struct {
+	Segments []RecoverSegmentPair `mapstructure:"recover"`
+}
+
+var (
+	RecoverSegmentsService = RecoverSegmentsServiceFn
+	LoadRecoverConfigToIdl = LoadRecoverConfigToIdlFn
+)
+
+var (
+	recoverFull         bool
+	recoverHbaHostnames bool
+)
+
+func recoverCmd() *cobra.Command {
+	recoverCmd := &cobra.Command{
+		Use:   "recover [<config-file>]",
+		Short: "Recovers the failed segments of a Greenplum Database system",
+		Args:  cobra.MaximumNArgs(1),
+		Example: `To incrementally recover all the failed segments in place
+$ gpctl recover
+
+To do a full recovery of all the failed segments in place
+$ gpctl recover --full
+
+To recover the failed segments listed in a configuration file, optionally to a different host or data directory
+$ gpctl recover recover_config.yaml
+`,
+		RunE: RunRecoverSegmentsCmd,
+	}
+
+	addCoordinatorDataDirFlag(recoverCmd)
+	recoverCmd.Flags().BoolVar(&recoverFull, "full", false, "Do a full recovery of the segments using pg_basebackup instead of an incremental recovery using pg_rewind")
+	recoverCmd.Flags().BoolVar(&recoverHbaHostnames, "hba-hostnames", false, "Use hostnames instead of IP addresses when adding the entries for relocated segments to pg_hba.conf")
+
+	return recoverCmd
+}
+
+// RunRecoverSegmentsCmd driving function gets called from cobra on gpctl recover command
+func RunRecoverSegmentsCmd(cmd *cobra.Command, args []string) error {
+	err := CheckGpServiceRunning()
+	if err != nil {
+		return err
+	}
+
+	if coordinatorDataDir == "" {
+		return fmt.Errorf("coordinator data directory not provided, please set the %s environment variable or use the --coordinator-data-directory flag", constants.CoordinatorDataDirEnv)
+	}
+
+	request := &idl.RecoverSegmentsRequest{
+		CoordinatorDataDir: coordinatorDataDir,
+		Full:               recoverFull,
+		HbaHostnames:       recoverHbaHostnames,
+	}
+
+	if len(args) == 1 {
+		request.RecoverPairs, err = LoadRecoverConfigToIdl(args[0], viper.New())
+		if err != nil {
+			return err
+		}
+	}
+
+	ctx, cancel := context.WithCancel(commandContext(cmd))
+	defer cancel()
+	ctrl := NewStreamController()
+
+	SetSignalHandler(ctrl)
+	CancelOnTermination(cancel)
+
+	return RecoverSegmentsService(ctx, ctrl, request)
+}
+
+// LoadRecoverConfigToIdlFn reads the recovery configuration file which lists the
+// failed segments to recover along with an optional target location for each of them
+func LoadRecoverConfigToIdlFn(configFile string, cliHandler *viper.Viper) ([]*idl.RecoverSegmentPair, error) {
+	cliHandler.SetConfigFile(configFile)
+
+	if err := cliHandler.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("while reading config file: %w", err)
+	}
+
+	var config RecoverConfig
+	if err := cliHandler.UnmarshalExact(&config); err != nil {
+		return nil, fmt.Errorf("while unmarshaling config file: %w", err)
+	}
+
+	if len(config.Segments) == 0 {
+		return nil, fmt.Errorf("no segments to recover found in the config file %s", configFile)
+	}
+
+	var pairs []*idl.RecoverSegmentPair
+	for _, seg := range config.Segments {
+		if seg.Failed == nil || seg.Failed.Hostname == "" || seg.Failed.DataDirectory == "" {
+			return nil, fmt.Errorf("hostname and data-directory of the failed segment must be provided for every entry in the config file")
+		}
+
+		pair := &idl.RecoverSegmentPair{
+			Failed: SegmentToIdl(seg.Failed),
+		}
+
+		if seg.Target != nil {
+			if seg.Target.Hostname == "" || seg.Target.DataDirectory == "" || seg.Target.Port == 0 {
+				return nil, fmt.Errorf("hostname, port and data-directory of the target segment must be provided for the failed segment %s on host %s",
+					seg.Failed.DataDirectory, seg.Failed.Hostname)
+			}
+
+			pair.Target = SegmentToIdl(seg.Target)
+		}
+
+		pairs = append(pairs, pair)
+	}
+
+	return pairs, nil
+}
+
+/*
+RecoverSegmentsServiceFn calls the RecoverSegments RPC on the hub and displays the streamed responses
+*/
+func RecoverSegmentsServiceFn(ctx context.Context, ctrl *StreamController, request *idl.RecoverSegmentsRequest) error {
+	client, err := gpservice_config.ConnectToHub(Conf)
+	if err != nil {
+		return err
+	}
+
+	stream, err := client.RecoverSegments(ctx, request)
+	if err != nil {
+		return utils.FormatGrpcError(err)
+	}
+
+	err = ParseStreamResponse(stream, ctrl)
+	if err != nil {
+		if TerminationRequested {
+			return &ErrorUserTermination{}
+		}
+
+		return err
+	}
+
+	gplog.Info("Segment recovery completed successfully")
+	return nil
+}
@@ -0,0 +1,192 @@
+package cli_test
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/greenplum-db/gpdb/gpctl/cli"
+	"github.com/greenplum-db/gpdb/gpservice/idl"
+	"github.com/greenplum-db/gpdb/gpservice/idl/mock_idl"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/gpservice_config"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func TestRunRecoverSegmentsCmd(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	t.Run("returns error when gpservice is not configured", func(t *testing.T) {
+		cli.IsConfigured = false
+		defer func() { cli.IsConfigured = true }()
+
+		testStr := "gpservice is not configured"
+		err := cli.RunRecoverSegmentsCmd(&cobra.Command{}, nil)
+		if err == nil || !strings.Contains(err.Error(), testStr) {
+			t.Fatalf("got:%v, expected:%s", err, testStr)
+		}
+	})
+
+	t.Run("returns error when gpservice is not running", func(t *testing.T) {
+		cli.IsConfigured = true
+		cli.IsGpserviceRunning = false
+
+		testStr := "gpservice is not running"
+		err := cli.RunRecoverSegmentsCmd(&cobra.Command{}, nil)
+		if err == nil || !strings.Contains(err.Error(), testStr) {
+			t.Fatalf("got:%v, expected:%s", err, testStr)
+		}
+	})
+}
+
+func TestLoadRecoverConfigToIdl(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	t.Run("loads the failed and target segments from the config file", func(t *testing.T) {
+		content := `
+recover:
+  - failed:
+      hostname: sdw1
+      data-directory: /data/mirror/gpseg0
+  - failed:
+      hostname: sdw2
+      data-directory: /data/mirror/gpseg1
+    target:
+      hostname: sdw3
+      address: sdw3-1
+      port: 8000
+      data-directory: /data/new/gpseg1
+`
+		filePath := createTempFile(t, content, "recover_config.yaml")
+
+		result, err := cli.LoadRecoverConfigToIdl(filePath, viper.New())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := []*idl.RecoverSegmentPair{
+			{
+				Failed: &idl.Segment{HostName: "sdw1", DataDirectory: "/data/mirror/gpseg0"},
+			},
+			{
+				Failed: &idl.Segment{HostName: "sdw2", DataDirectory: "/data/mirror/gpseg1"},
+				Target: &idl.Segment{HostName: "sdw3", HostAddress: "sdw3-1", Port: 8000, DataDirectory: "/data/new/gpseg1"},
+			},
+		}
+		if !reflect.DeepEqual(result, expected) {
+			t.Fatalf("got %+v, want %+v", result, expected)
+		}
+	})
+
+	t.Run("returns error when the config file does not exist", func(t *testing.T) {
+		_, err := cli.LoadRecoverConfigToIdl("/tmp/nonexistent/recover_config.yaml", viper.New())
+		testStr := "while reading config file"
+		if err == nil || !strings.Contains(err.Error(), testStr) {
+			t.Fatalf("got %v, want %s", err, testStr)
+		}
+	})
+
+	t.Run("returns error when the failed segment is incomplete", func(t *testing.T) {
+		content := `
+recover:
+  - failed:
+      hostname: sdw1
+`
+		filePath := createTempFile(t, content, "recover_config.yaml")
+
+		_, err := cli.LoadRecoverConfigToIdl(filePath, viper.New())
+		testStr := "hostname and data-directory of the failed segment must be provided"
+		if err == nil || !strings.Contains(err.Error(), testStr) {
+			t.Fatalf("got %v, want %s", err, testStr)
+		}
+	})
+
+	t.Run("returns error when the target segment is incomplete", func(t *testing.T) {
+		content := `
+recover:
+  - failed:
+      hostname: sdw1
+      data-directory: /data/mirror/gpseg0
+    target:
+      hostname: sdw3
+`
+		filePath := createTempFile(t, content, "recover_config.yaml")
+
+		_, err := cli.LoadRecoverConfigToIdl(filePath, viper.New())
+		testStr := "hostname, port and data-directory of the target segment must be provided"
+		if err == nil || !strings.Contains(err.Error(), testStr) {
+			t.Fatalf("got %v, want %s", err, testStr)
+		}
+	})
+
+	t.Run("returns error when there are no segments to recover", func(t *testing.T) {
+		filePath := createTempFile(t, "recover: []\n", "recover_config.yaml")
+
+		_, err := cli.LoadRecoverConfigToIdl(filePath, viper.New())
+		testStr := "no segments to recover found in the config file"
+		if err == nil || !strings.Contains(err.Error(), testStr) {
+			t.Fatalf("got %v, want %s", err, testStr)
+		}
+	})
+}
+
+func TestRecoverSegmentsService(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	request := &idl.RecoverSegmentsRequest{
+		CoordinatorDataDir: "/data/gpseg-1",
+		Full:               true,
+	}
+
+	t.Run("returns error if connect to hub fails", func(t *testing.T) {
+		testStr := "test-error"
+		defer resetCLIVars()
+		gpservice_config.ConnectToHub = func(conf *gpservice_config.Config) (idl.HubClient, error) {
+			return nil, fmt.Errorf(testStr)
+		}
+
+		err := cli.RecoverSegmentsService(context.Background(), cli.NewStreamController(), request)
+		if err == nil || !strings.Contains(err.Error(), testStr) {
+			t.Fatalf("got %v, want %v", err, testStr)
+		}
+	})
+
+	t.Run("returns error if RPC returns error", func(t *testing.T) {
+		testStr := "test-error"
+		defer resetCLIVars()
+		gpservice_config.ConnectToHub = func(conf *gpservice_config.Config) (idl.HubClient, error) {
+			hubClient := mock_idl.NewMockHubClient(ctrl)
+			hubClient.EXPECT().RecoverSegments(gomock.Any(), request).Return(nil, fmt.Errorf(testStr))
+			return hubClient, nil
+		}
+
+		err := cli.RecoverSegmentsService(context.Background(), cli.NewStreamController(), request)
+		if err == nil || !strings.Contains(err.Error(), testStr) {
+			t.Fatalf("got %v, want %v", err, testStr)
+		}
+	})
+
+	t.Run("returns error if stream receiver returns error", func(t *testing.T) {
+		testStr := "Segment recovery failed"
+		defer resetCLIVars()
+		gpservice_config.ConnectToHub = func(conf *gpservice_config.Config) (idl.HubClient, error) {
+			hubClient := mock_idl.NewMockHubClient(ctrl)
+			hubClient.EXPECT().RecoverSegments(gomock.Any(), gomock.Any()).Return(nil, nil)
+			return hubClient, nil
+		}
+		cli.ParseStreamResponse = func(stream cli.StreamReceiver, ctrl *cli.StreamController) error {
+			return fmt.Errorf(testStr)
+		}
+
+		err := cli.RecoverSegmentsService(context.Background(), cli.NewStreamController(), request)
+		if err == nil || !strings.Contains(err.Error(), testStr) {
+			t.Fatalf("got %v, want %v", err, testStr)
+		}
+	})
+}
@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -8,6 +9,7 @@ import (
 	"github.com/greenplum-db/gp-common-go-libs/gplog"
 	"github.com/greenplum-db/gpdb/gpservice/constants"
 	"github.com/greenplum-db/gpdb/gpservice/pkg/gpservice_config"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -17,6 +19,8 @@ var (
 	IsConfigured       bool
 	IsGpserviceRunning bool
 	Conf               *gpservice_config.Config
+	outputFormat       string
+	output             = utils.NewOutput(os.Stdout, utils.OutputFormatText)
 )
 
 func RootCommand() *cobra.Command {
@@ -24,6 +28,17 @@ func RootCommand() *cobra.Command {
 		Use:  "gpctl",
 		Long: "gpctl is a utility to manage a Greenplum Database System",
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) (err error) {
+			err = utils.ValidateOutputFormat(outputFormat)
+			if err != nil {
+				return err
+			}
+			output = utils.NewOutput(os.Stdout, outputFormat)
+
+			err = validatePolicies()
+			if err != nil {
+				return err
+			}
+
 			IsConfigured = CheckGpServiceIsConfigured()
 			if !IsConfigured {
 				initializeLogger(cmd, "~/gpAdminLogs")
@@ -38,16 +53,31 @@ func RootCommand() *cobra.Command {
 			IsGpserviceRunning = IsGPServiceIsRunning(Conf)
 			initializeLogger(cmd, Conf.LogDir)
 
-			return
+			return startTracing(cmd, Conf)
 		}}
 
 	root.PersistentFlags().StringVar(&ConfigFilePath, "service-config-file", filepath.Join(os.Getenv("GPHOME"), constants.ConfigFileName), `Path to gpservice configuration file`)
 	root.PersistentFlags().BoolVar(&verbose, "verbose", false, `Provide verbose output`)
+	root.PersistentFlags().BoolVar(&nonInteractive, "yes", false, `Run without prompting, assuming yes as the answer to the prompts`)
+	root.PersistentFlags().BoolVar(&nonInteractive, "non-interactive", false, `Same as --yes`)
+	root.PersistentFlags().StringVar(&onFailure, "on-failure", OnFailurePrompt, fmt.Sprintf("Action on the changes of a failed command, one of %s, %s or %s", OnFailureRollback, OnFailureKeep, OnFailurePrompt))
+	root.PersistentFlags().StringVar(&onInterrupt, "on-interrupt", OnInterruptPrompt, fmt.Sprintf("Action on an interrupt signal, one of %s, %s or %s", OnInterruptTerminate, OnInterruptContinue, OnInterruptPrompt))
+	root.PersistentFlags().StringVar(&outputFormat, "output", utils.OutputFormatText, fmt.Sprintf("Output format, one of %s, %s, %s or %s", utils.OutputFormatText, utils.OutputFormatJson, utils.OutputFormatNdjson, utils.OutputFormatYaml))
 
 	root.CompletionOptions.DisableDefaultCmd = true
 
 	root.AddCommand(
 		initCmd(),
+		startCmd(),
+		stopCmd(),
+		statusCmd(),
+		recoverCmd(),
+		addMirrorsCmd(),
+		expandCmd(),
+		addStandbyCmd(),
+		removeStandbyCmd(),
+		activateStandbyCmd(),
+		opsCmd(),
 	)
 
 	return root
@@ -62,4 +92,29 @@ func initializeLogger(cmd *cobra.Command, logdir string) {
 	if verbose {
 		gplog.SetVerbosity(gplog.LOGVERBOSE)
 	}
+
+	// Only the errors are displayed along with the structured output, the log file has the rest
+	if output.Structured() {
+		gplog.SetVerbosity(gplog.LOGERROR)
+	}
+}
+
+// WriteResult writes the result of the command once it returns, when the output is structured
+func WriteResult(cmd *cobra.Command, err error) {
+	output.Result(cmd.CommandPath(), err) // nolint
+}
+
+// StructuredOutput returns true if the output of the command is meant for automation
+func StructuredOutput() bool {
+	return output.Structured()
+}
+
+// used only for testing
+func SetOutputFormat(format string) func() {
+	oldOutput := output
+	output = utils.NewOutput(os.Stdout, format)
+
+	return func() {
+		output = oldOutput
+	}
 }
@@ -1,18 +1,25 @@
 package cli
 
 import (
+	"context"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
+	"time"
 
 	"github.com/greenplum-db/gp-common-go-libs/gplog"
-	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+	"github.com/greenplum-db/gpdb/gpservice/constants"
 )
 
 var (
 	TerminationRequested bool
 	SigtermReceived      bool
 	ch                   = make(chan os.Signal, 1)
+
+	// runningOperation is the ID of the operation the command is following on the hub, if any
+	runningOperation      string
+	runningOperationMutex sync.Mutex
 )
 
 // Specific type of error for interrupt
@@ -33,8 +40,50 @@ func SetSignalHandler(ctrl *StreamController) {
 	}()
 }
 
+// CancelOnTermination cancels the given context once the user
+// has requested to terminate the current execution, along with
+// the operation it started on the hub, which keeps running once
+// its caller has gone away otherwise
+func CancelOnTermination(cancel context.CancelFunc) {
+	go func() {
+		ticker := time.NewTicker(constants.CheckInterruptFrequency)
+		defer ticker.Stop()
+
+		for ; true; <-ticker.C {
+			if TerminationRequested {
+				cancelRunningOperation()
+				cancel()
+				return
+			}
+		}
+	}()
+}
+
+func setRunningOperation(id string) {
+	runningOperationMutex.Lock()
+	defer runningOperationMutex.Unlock()
+
+	runningOperation = id
+}
+
+func cancelRunningOperation() {
+	runningOperationMutex.Lock()
+	id := runningOperation
+	runningOperationMutex.Unlock()
+
+	if id == "" {
+		return
+	}
+
+	err := CancelOperation(context.Background(), id)
+	if err != nil {
+		gplog.Warn("Could not cancel operation %s: %v", id, err)
+	}
+}
+
 // HandleSignal handles the given signal and performs the necessary actions based on the signal received.
-// If the signal is SIGINT, it pauses the hub stream parsing and prompts the user to continue terminating the current execution.
+// If the signal is SIGINT, it pauses the hub stream parsing and decides whether to terminate the current
+// execution following the --on-interrupt policy, which may prompt the user.
 // If the signal is SIGTERM, it sets the TerminationRequested flag to true.
 // For any other signal, it logs the signal received.
 // Note: This function assumes that the TerminationRequested flag is defined and accessible from the current scope.
@@ -43,7 +92,6 @@ func HandleSignal(sig os.Signal, ctrl *StreamController) {
 	case syscall.SIGINT:
 		signal.Ignore(syscall.SIGINT)
 		logMessage := "received an interrupt signal"
-		promptText := "Do you want to continue terminating the current execution?"
 
 		if ctrl != nil && ctrl.State() != streamNotStarted {
 			// pause the hub stream parsing so we could display a prompt
@@ -51,7 +99,7 @@ func HandleSignal(sig os.Signal, ctrl *StreamController) {
 			// wait until the stream is paused
 			ctrl.WaitUntilPaused()
 			gplog.Warn(logMessage)
-			terminate := utils.AskUserYesOrNo(promptText)
+			terminate := decideTermination()
 			if !terminate {
 				signal.Notify(ch, syscall.SIGINT, syscall.SIGTERM)
 				// resume the stream
@@ -62,7 +110,10 @@ func HandleSignal(sig os.Signal, ctrl *StreamController) {
 			}
 		} else {
 			gplog.Warn(logMessage)
-			TerminationRequested = utils.AskUserYesOrNo(promptText)
+			TerminationRequested = decideTermination()
+			if !TerminationRequested {
+				signal.Notify(ch, syscall.SIGINT, syscall.SIGTERM)
+			}
 		}
 
 	case syscall.SIGTERM:
@@ -1,7 +1,9 @@
 package cli_test
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 	"sync"
@@ -9,8 +11,16 @@ import (
 	"testing"
 	"time"
 
+	"github.com/golang/mock/gomock"
 	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
 	"github.com/greenplum-db/gpdb/gpctl/cli"
+	"github.com/greenplum-db/gpdb/gpservice/constants"
+	"github.com/greenplum-db/gpdb/gpservice/idl"
+	"github.com/greenplum-db/gpdb/gpservice/idl/mock_idl"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/gpservice_config"
 	"github.com/greenplum-db/gpdb/gpservice/testutils"
 )
 
@@ -149,6 +159,73 @@ func TestHandleSignal(t *testing.T) {
 		}
 	})
 
+	t.Run("follows the interrupt policy without prompting the user", func(t *testing.T) {
+		defer func() {
+			cli.TerminationRequested = false
+		}()
+
+		buffer, writer, resetStdout := testutils.CaptureStdout(t)
+		defer resetStdout()
+
+		resetPolicies := cli.SetPolicies(false, cli.OnFailurePrompt, cli.OnInterruptContinue)
+		cli.HandleSignal(syscall.SIGINT, nil)
+		resetPolicies()
+
+		if cli.TerminationRequested {
+			t.Fatalf("got %t, want false", cli.TerminationRequested)
+		}
+		testutils.AssertLogMessage(t, logfile, `\[INFO\]:-Action on interrupt: continue, as --on-interrupt is continue`)
+
+		resetPolicies = cli.SetPolicies(true, cli.OnFailurePrompt, cli.OnInterruptPrompt)
+		cli.HandleSignal(syscall.SIGINT, nil)
+		resetPolicies()
+
+		if !cli.TerminationRequested {
+			t.Fatalf("got %t, want true", cli.TerminationRequested)
+		}
+		testutils.AssertLogMessage(t, logfile, `\[INFO\]:-Action on interrupt: terminate, as --yes is set`)
+
+		writer.Close()
+		stdout := <-buffer
+		if strings.Contains(stdout, "Do you want to continue terminating the current execution?") {
+			t.Fatalf("got %s, want no prompt", stdout)
+		}
+	})
+
+	t.Run("discards the paused stream when the interrupt policy is to terminate", func(t *testing.T) {
+		defer func() {
+			cli.TerminationRequested = false
+		}()
+
+		resetPolicies := cli.SetPolicies(false, cli.OnFailurePrompt, cli.OnInterruptTerminate)
+		defer resetPolicies()
+
+		ctrl := cli.NewStreamController()
+		ctrl.SetState(streamRunning)
+
+		done := make(chan struct{})
+		go func() {
+			cli.HandleSignal(syscall.SIGINT, ctrl)
+			close(done)
+		}()
+
+		// the stream is paused in the same way as when the user is prompted
+		time.Sleep(100 * time.Millisecond)
+		if ctrl.State() != streamPaused {
+			t.Fatalf("expected stream to be paused")
+		}
+		ctrl.Paused()
+		<-done
+
+		if ctrl.State() != streamDiscard {
+			t.Fatalf("expected stream to be discarded")
+		}
+
+		if !cli.TerminationRequested {
+			t.Fatalf("got %t, want true", cli.TerminationRequested)
+		}
+	})
+
 	t.Run("when there is a SIGTERM signal", func(t *testing.T) {
 		defer func() {
 			cli.TerminationRequested = false
@@ -215,3 +292,63 @@ func TestSetSignalHandler(t *testing.T) {
 		testutils.AssertLogMessage(t, logfile, `\[WARNING\]:-received a termination signal`)
 	})
 }
+
+// operationStream is a stream of an operation started on the hub, which ends once released
+type operationStream struct {
+	receiving chan struct{}
+	release   chan struct{}
+}
+
+func (s *operationStream) Header() (metadata.MD, error) {
+	return metadata.Pairs(constants.OperationIDHeader, "3f9a2c1b"), nil
+}
+
+func (s *operationStream) Recv() (*idl.HubReply, error) {
+	close(s.receiving)
+	<-s.release
+
+	return nil, io.EOF
+}
+
+func TestCancelOnTermination(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	t.Run("cancels the operation followed on the hub before the context", func(t *testing.T) {
+		defer func() {
+			cli.TerminationRequested = false
+		}()
+		defer gpservice_config.ResetConfigFunctions()
+
+		cancelled := make(chan struct{})
+		hubClient := mock_idl.NewMockHubClient(gomock.NewController(t))
+		hubClient.EXPECT().CancelOperation(gomock.Any(), &idl.CancelOperationRequest{Id: "3f9a2c1b"}).DoAndReturn(func(_ context.Context, _ *idl.CancelOperationRequest, _ ...grpc.CallOption) (*idl.CancelOperationReply, error) {
+			close(cancelled)
+			return &idl.CancelOperationReply{}, nil
+		})
+		gpservice_config.SetConnectToHub(hubClient)
+
+		stream := &operationStream{receiving: make(chan struct{}), release: make(chan struct{})}
+		result := make(chan error, 1)
+		go func() {
+			result <- cli.ParseStreamResponse(stream, cli.NewStreamController())
+		}()
+		<-stream.receiving
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cli.TerminationRequested = true
+		cli.CancelOnTermination(cancel)
+		<-ctx.Done()
+
+		select {
+		case <-cancelled:
+		default:
+			t.Fatalf("expected the operation to be cancelled before the context")
+		}
+
+		close(stream.release)
+		err := <-result
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
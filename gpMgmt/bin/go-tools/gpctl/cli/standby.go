@@ -0,0 +1,267 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"github.com/greenplum-db/gpdb/gpservice/constants"
+	"github.com/greenplum-db/gpdb/gpservice/idl"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/gpservice_config"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+)
+
+var (
+	AddStandbyService    = AddStandbyServiceFn
+	RemoveStandbyService = RemoveStandbyServiceFn
+)
+
+var (
+	standbyHostname      string
+	standbyAddress       string
+	standbyPort          int
+	standbyDataDirectory string
+	standbyHbaHostnames  bool
+	addStandbyClean      bool
+)
+
+func addStandbyCmd() *cobra.Command {
+	addStandbyCmd := &cobra.Command{
+		Use:   "add-standby",
+		Short: "Adds a standby coordinator to a Greenplum Database system",
+		Example: `To add a standby coordinator on a host using the same port and data directory as the coordinator
+$ gpctl add-standby --hostname scdw
+
+To add a standby coordinator with a different port and data directory
+$ gpctl add-standby --hostname scdw --port 7001 --data-directory /data/standby/gpseg-1
+
+To rollback the changes made due to a failed attempt to add the standby coordinator
+$ gpctl add-standby --clean
+`,
+		RunE: RunAddStandbyCmd,
+	}
+
+	addCoordinatorDataDirFlag(addStandbyCmd)
+	addStandbyCmd.Flags().StringVar(&standbyHostname, "hostname", "", "Hostname of the standby coordinator")
+	addStandbyCmd.Flags().StringVar(&standbyAddress, "address", "", "Address of the standby coordinator, defaults to the hostname")
+	addStandbyCmd.Flags().IntVar(&standbyPort, "port", 0, "Port of the standby coordinator, defaults to the coordinator port")
+	addStandbyCmd.Flags().StringVar(&standbyDataDirectory, "data-directory", "", "Data directory of the standby coordinator, defaults to the coordinator data directory")
+	addStandbyCmd.Flags().BoolVar(&standbyHbaHostnames, "hba-hostnames", false, "Use hostnames instead of IP addresses when adding the standby entries to pg_hba.conf")
+	addStandbyCmd.Flags().BoolVar(&addStandbyClean, "clean", false, "Rollback the changes made due to a failed attempt to add the standby coordinator")
+
+	return addStandbyCmd
+}
+
+// RunAddStandbyCmd driving function gets called from cobra on gpctl add-standby command
+func RunAddStandbyCmd(cmd *cobra.Command, args []string) error {
+	err := CheckGpServiceRunning()
+	if err != nil {
+		return err
+	}
+
+	if addStandbyClean {
+		_, err := utils.System.Stat(filepath.Join(Conf.LogDir, constants.CleanFileName))
+		if err != nil {
+			return fmt.Errorf("cluster is clean, no cleanup file present")
+		}
+
+		return RollbackChanges(false, "add-standby")
+	}
+
+	if coordinatorDataDir == "" {
+		return fmt.Errorf("coordinator data directory not provided, please set the %s environment variable or use the --coordinator-data-directory flag", constants.CoordinatorDataDirEnv)
+	}
+
+	if standbyHostname == "" {
+		return fmt.Errorf("please provide the hostname of the standby coordinator using the --hostname flag")
+	}
+
+	statuses, err := GetClusterStatus(coordinatorDataDir)
+	if err != nil {
+		return err
+	}
+
+	standby, err := GetStandbySegment(statuses, standbyHostname, standbyAddress, standbyPort, standbyDataDirectory)
+	if err != nil {
+		return err
+	}
+
+	request := &idl.AddStandbyRequest{
+		CoordinatorDataDir: coordinatorDataDir,
+		HbaHostnames:       standbyHbaHostnames,
+		Standby:            standby,
+	}
+
+	ctx, cancel := context.WithCancel(commandContext(cmd))
+	defer cancel()
+	ctrl := NewStreamController()
+
+	SetSignalHandler(ctrl)
+	CancelOnTermination(cancel)
+
+	return AddStandbyService(ctx, ctrl, request)
+}
+
+/*
+GetStandbySegment returns the standby coordinator to be added to the cluster. The port
+and data directory default to that of the coordinator, in which case the standby
+must be placed on a different host than the coordinator.
+*/
+func GetStandbySegment(statuses []SegmentStatus, hostname, address string, port int, dataDirectory string) (*idl.Segment, error) {
+	var coordinator *SegmentStatus
+	for idx, status := range statuses {
+		if status.Content == -1 {
+			if status.Role == constants.RoleMirror {
+				return nil, fmt.Errorf("the cluster already has a standby coordinator on host %s", status.Hostname)
+			}
+
+			coordinator = &statuses[idx]
+		}
+	}
+
+	if coordinator == nil {
+		return nil, fmt.Errorf("coordinator segment not found in the cluster")
+	}
+
+	if address == "" {
+		address = hostname
+	}
+
+	if port == 0 {
+		port = int(coordinator.Port)
+	}
+
+	if port < 0 {
+		return nil, fmt.Errorf("invalid port %d provided for the standby coordinator", port)
+	}
+
+	if dataDirectory == "" {
+		dataDirectory = coordinator.DataDir
+	}
+
+	if hostname == coordinator.Hostname && (port == int(coordinator.Port) || dataDirectory == coordinator.DataDir) {
+		return nil, fmt.Errorf("the standby coordinator on the coordinator host %s must have a different port and data directory than the coordinator", hostname)
+	}
+
+	return &idl.Segment{
+		HostName:      hostname,
+		HostAddress:   address,
+		Port:          int32(port),
+		DataDirectory: dataDirectory,
+	}, nil
+}
+
+/*
+AddStandbyServiceFn calls the AddStandby RPC on the hub and displays the streamed responses.
+If the standby could not be created, the changes are rolled back in the same way as gpctl init.
+*/
+func AddStandbyServiceFn(ctx context.Context, ctrl *StreamController, request *idl.AddStandbyRequest) (err error) {
+	defer func() {
+		if err != nil {
+			if TerminationRequested {
+				err = &ErrorUserTermination{}
+			}
+
+			// Call the cleanup routine only if the cleanup file exists
+			fileName := filepath.Join(Conf.LogDir, constants.CleanFileName)
+			_, statErr := utils.System.Stat(fileName)
+
+			if statErr == nil {
+				gplog.Error("failed to add the standby coordinator: %v", err)
+				if cleanErr := RollbackChanges(true, "add-standby"); cleanErr != nil {
+					err = errors.Join(err, cleanErr)
+				}
+			}
+		}
+	}()
+
+	client, err := gpservice_config.ConnectToHub(Conf)
+	if err != nil {
+		return err
+	}
+
+	stream, err := client.AddStandby(ctx, request)
+	if err != nil {
+		return utils.FormatGrpcError(err)
+	}
+
+	err = ParseStreamResponse(stream, ctrl)
+	if err != nil {
+		return err
+	}
+
+	gplog.Info("Standby coordinator added successfully")
+	return nil
+}
+
+func removeStandbyCmd() *cobra.Command {
+	removeStandbyCmd := &cobra.Command{
+		Use:   "remove-standby",
+		Short: "Removes the standby coordinator from a Greenplum Database system",
+		Example: `To stop the standby coordinator, remove it from the cluster and delete its data directory
+$ gpctl remove-standby
+`,
+		RunE: RunRemoveStandbyCmd,
+	}
+
+	addCoordinatorDataDirFlag(removeStandbyCmd)
+
+	return removeStandbyCmd
+}
+
+// RunRemoveStandbyCmd driving function gets called from cobra on gpctl remove-standby command
+func RunRemoveStandbyCmd(cmd *cobra.Command, args []string) error {
+	err := CheckGpServiceRunning()
+	if err != nil {
+		return err
+	}
+
+	if coordinatorDataDir == "" {
+		return fmt.Errorf("coordinator data directory not provided, please set the %s environment variable or use the --coordinator-data-directory flag", constants.CoordinatorDataDirEnv)
+	}
+
+	if !confirm("The data directory of the standby coordinator will be deleted. Continue with the removal?") {
+		gplog.Info("Exiting without removing the standby coordinator")
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(commandContext(cmd))
+	defer cancel()
+	ctrl := NewStreamController()
+
+	SetSignalHandler(ctrl)
+	CancelOnTermination(cancel)
+
+	return RemoveStandbyService(ctx, ctrl, &idl.RemoveStandbyRequest{CoordinatorDataDir: coordinatorDataDir})
+}
+
+/*
+RemoveStandbyServiceFn calls the RemoveStandby RPC on the hub and displays the streamed responses
+*/
+func RemoveStandbyServiceFn(ctx context.Context, ctrl *StreamController, request *idl.RemoveStandbyRequest) error {
+	client, err := gpservice_config.ConnectToHub(Conf)
+	if err != nil {
+		return err
+	}
+
+	stream, err := client.RemoveStandby(ctx, request)
+	if err != nil {
+		return utils.FormatGrpcError(err)
+	}
+
+	err = ParseStreamResponse(stream, ctrl)
+	if err != nil {
+		if TerminationRequested {
+			return &ErrorUserTermination{}
+		}
+
+		return err
+	}
+
+	gplog.Info("Standby coordinator removed successfully")
+	return nil
+}
@@ -0,0 +1,226 @@
+package cli_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/greenplum-db/gpdb/gpctl/cli"
+	"github.com/greenplum-db/gpdb/gpservice/idl"
+	"github.com/greenplum-db/gpdb/gpservice/idl/mock_idl"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/gpservice_config"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+func TestRunStandbyCmd(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	t.Run("add-standby returns error when gpservice is not running", func(t *testing.T) {
+		cli.IsConfigured = true
+		cli.IsGpserviceRunning = false
+
+		testStr := "gpservice is not running"
+		err := cli.RunAddStandbyCmd(&cobra.Command{}, nil)
+		if err == nil || !strings.Contains(err.Error(), testStr) {
+			t.Fatalf("got:%v, expected:%s", err, testStr)
+		}
+	})
+
+	t.Run("remove-standby returns error when gpservice is not running", func(t *testing.T) {
+		cli.IsConfigured = true
+		cli.IsGpserviceRunning = false
+
+		testStr := "gpservice is not running"
+		err := cli.RunRemoveStandbyCmd(&cobra.Command{}, nil)
+		if err == nil || !strings.Contains(err.Error(), testStr) {
+			t.Fatalf("got:%v, expected:%s", err, testStr)
+		}
+	})
+}
+
+func TestGetStandbySegment(t *testing.T) {
+	statuses := []cli.SegmentStatus{
+		{Content: -1, Role: "p", PreferredRole: "p", Hostname: "cdw", Port: 7000, DataDir: "/data/coordinator/gpseg-1"},
+		{Content: 0, Role: "p", PreferredRole: "p", Hostname: "sdw1", Port: 7002, DataDir: "/data/primary/gpseg0"},
+	}
+
+	t.Run("defaults the port and data directory to that of the coordinator", func(t *testing.T) {
+		result, err := cli.GetStandbySegment(statuses, "scdw", "", 0, "")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := &idl.Segment{HostName: "scdw", HostAddress: "scdw", Port: 7000, DataDirectory: "/data/coordinator/gpseg-1"}
+		if !reflect.DeepEqual(result, expected) {
+			t.Fatalf("got %+v, want %+v", result, expected)
+		}
+	})
+
+	t.Run("uses the provided values", func(t *testing.T) {
+		result, err := cli.GetStandbySegment(statuses, "cdw", "cdw-1", 7001, "/data/standby/gpseg-1")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := &idl.Segment{HostName: "cdw", HostAddress: "cdw-1", Port: 7001, DataDirectory: "/data/standby/gpseg-1"}
+		if !reflect.DeepEqual(result, expected) {
+			t.Fatalf("got %+v, want %+v", result, expected)
+		}
+	})
+
+	cases := []struct {
+		name     string
+		statuses []cli.SegmentStatus
+		hostname string
+		port     int
+		expected string
+	}{
+		{
+			name:     "returns error when the standby conflicts with the coordinator",
+			statuses: statuses,
+			hostname: "cdw",
+			expected: "the standby coordinator on the coordinator host cdw must have a different port and data directory than the coordinator",
+		},
+		{
+			name:     "returns error when the port is invalid",
+			statuses: statuses,
+			hostname: "scdw",
+			port:     -1,
+			expected: "invalid port -1 provided for the standby coordinator",
+		},
+		{
+			name:     "returns error when the cluster already has a standby",
+			statuses: append(statuses, cli.SegmentStatus{Content: -1, Role: "m", PreferredRole: "m", Hostname: "scdw"}),
+			hostname: "sdw1",
+			expected: "the cluster already has a standby coordinator on host scdw",
+		},
+		{
+			name:     "returns error when the coordinator is not found",
+			statuses: statuses[1:],
+			hostname: "scdw",
+			expected: "coordinator segment not found in the cluster",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := cli.GetStandbySegment(tc.statuses, tc.hostname, "", tc.port, "")
+			if err == nil || err.Error() != tc.expected {
+				t.Fatalf("got %v, want %s", err, tc.expected)
+			}
+		})
+	}
+}
+
+func TestAddStandbyService(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	request := &idl.AddStandbyRequest{
+		CoordinatorDataDir: "/data/gpseg-1",
+		Standby:            &idl.Segment{HostName: "scdw", Port: 7000, DataDirectory: "/data/gpseg-1"},
+	}
+
+	t.Run("returns error if RPC returns error", func(t *testing.T) {
+		testStr := "test-error"
+		defer resetCLIVars()
+		gpservice_config.ConnectToHub = func(conf *gpservice_config.Config) (idl.HubClient, error) {
+			hubClient := mock_idl.NewMockHubClient(ctrl)
+			hubClient.EXPECT().AddStandby(gomock.Any(), request).Return(nil, fmt.Errorf(testStr))
+			return hubClient, nil
+		}
+
+		err := cli.AddStandbyService(context.Background(), cli.NewStreamController(), request)
+		if err == nil || !strings.Contains(err.Error(), testStr) {
+			t.Fatalf("got %v, want %v", err, testStr)
+		}
+	})
+
+	t.Run("rolls back the changes when adding the standby fails", func(t *testing.T) {
+		defer resetCLIVars()
+		defer utils.ResetSystemFunctions()
+
+		expectedErr := errors.New("error")
+		var cleanCalled bool
+		gpservice_config.ConnectToHub = func(conf *gpservice_config.Config) (idl.HubClient, error) {
+			hubClient := mock_idl.NewMockHubClient(ctrl)
+			hubClient.EXPECT().AddStandby(gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+			hubClient.EXPECT().CleanInitCluster(gomock.Any(), gomock.Any()).DoAndReturn(
+				func(ctx context.Context, req *idl.CleanInitClusterRequest, opts ...interface{}) (*idl.CleanInitClusterReply, error) {
+					cleanCalled = true
+					return &idl.CleanInitClusterReply{}, nil
+				}).AnyTimes()
+			return hubClient, nil
+		}
+		cli.ParseStreamResponse = func(stream cli.StreamReceiver, ctrl *cli.StreamController) error {
+			return expectedErr
+		}
+		utils.System.Stat = func(name string) (os.FileInfo, error) {
+			return nil, nil
+		}
+
+		cli.TerminationRequested = true
+		cli.SigtermReceived = true
+		defer func() {
+			cli.TerminationRequested = false
+			cli.SigtermReceived = false
+		}()
+
+		err := cli.AddStandbyService(context.Background(), cli.NewStreamController(), request)
+		var expected *cli.ErrorUserTermination
+		if !errors.As(err, &expected) {
+			t.Fatalf("got %v, want %T", err, expected)
+		}
+
+		if !cleanCalled {
+			t.Fatalf("expected the changes to be rolled back")
+		}
+	})
+}
+
+func TestRemoveStandbyService(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	request := &idl.RemoveStandbyRequest{CoordinatorDataDir: "/data/gpseg-1"}
+
+	t.Run("returns error if RPC returns error", func(t *testing.T) {
+		testStr := "test-error"
+		defer resetCLIVars()
+		gpservice_config.ConnectToHub = func(conf *gpservice_config.Config) (idl.HubClient, error) {
+			hubClient := mock_idl.NewMockHubClient(ctrl)
+			hubClient.EXPECT().RemoveStandby(gomock.Any(), request).Return(nil, fmt.Errorf(testStr))
+			return hubClient, nil
+		}
+
+		err := cli.RemoveStandbyService(context.Background(), cli.NewStreamController(), request)
+		if err == nil || !strings.Contains(err.Error(), testStr) {
+			t.Fatalf("got %v, want %v", err, testStr)
+		}
+	})
+
+	t.Run("returns error if stream receiver returns error", func(t *testing.T) {
+		testStr := "stopping standby coordinator failed"
+		defer resetCLIVars()
+		gpservice_config.ConnectToHub = func(conf *gpservice_config.Config) (idl.HubClient, error) {
+			hubClient := mock_idl.NewMockHubClient(ctrl)
+			hubClient.EXPECT().RemoveStandby(gomock.Any(), gomock.Any()).Return(nil, nil)
+			return hubClient, nil
+		}
+		cli.ParseStreamResponse = func(stream cli.StreamReceiver, ctrl *cli.StreamController) error {
+			return fmt.Errorf(testStr)
+		}
+
+		err := cli.RemoveStandbyService(context.Background(), cli.NewStreamController(), request)
+		if err == nil || !strings.Contains(err.Error(), testStr) {
+			t.Fatalf("got %v, want %v", err, testStr)
+		}
+	})
+}
@@ -0,0 +1,110 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"github.com/greenplum-db/gpdb/gpservice/constants"
+	"github.com/greenplum-db/gpdb/gpservice/idl"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/gpservice_config"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+)
+
+var (
+	StartClusterService = StartClusterServiceFn
+)
+
+var (
+	coordinatorDataDir string
+)
+
+func startCmd() *cobra.Command {
+	startCmd := &cobra.Command{
+		Use:   "start",
+		Short: "Starts a Greenplum Database system",
+		Args:  cobra.NoArgs,
+		Example: `To start the Greenplum Database system
+$ gpctl start
+
+To start the Greenplum Database system with a given coordinator data directory
+$ gpctl start --coordinator-data-directory /data/coordinator/gpseg-1
+`,
+		RunE: RunStartClusterCmd,
+	}
+
+	addCoordinatorDataDirFlag(startCmd)
+
+	return startCmd
+}
+
+// RunStartClusterCmd driving function gets called from cobra on gpctl start command
+func RunStartClusterCmd(cmd *cobra.Command, args []string) error {
+	err := CheckGpServiceRunning()
+	if err != nil {
+		return err
+	}
+
+	if coordinatorDataDir == "" {
+		return fmt.Errorf("coordinator data directory not provided, please set the %s environment variable or use the --coordinator-data-directory flag", constants.CoordinatorDataDirEnv)
+	}
+
+	ctx, cancel := context.WithCancel(commandContext(cmd))
+	defer cancel()
+	ctrl := NewStreamController()
+
+	SetSignalHandler(ctrl)
+	CancelOnTermination(cancel)
+
+	return StartClusterService(ctx, ctrl, coordinatorDataDir)
+}
+
+/*
+StartClusterServiceFn calls the StartCluster RPC on the hub and displays the streamed responses
+*/
+func StartClusterServiceFn(ctx context.Context, ctrl *StreamController, coordinatorDataDir string) error {
+	client, err := gpservice_config.ConnectToHub(Conf)
+	if err != nil {
+		return err
+	}
+
+	stream, err := client.StartCluster(ctx, &idl.StartClusterRequest{
+		CoordinatorDataDir: coordinatorDataDir,
+	})
+	if err != nil {
+		return utils.FormatGrpcError(err)
+	}
+
+	err = ParseStreamResponse(stream, ctrl)
+	if err != nil {
+		if TerminationRequested {
+			return &ErrorUserTermination{}
+		}
+
+		return err
+	}
+
+	gplog.Info("Cluster started successfully")
+	return nil
+}
+
+// CheckGpServiceRunning returns an error if the gpservice is
+// not configured or if the hub and agents are not running
+func CheckGpServiceRunning() error {
+	if !IsConfigured {
+		return utils.NewHelpErr(fmt.Errorf("gpservice is not configured"), "Configure the services using the 'gpservice init' command.")
+	}
+
+	if !IsGpserviceRunning {
+		return utils.NewHelpErr(fmt.Errorf("gpservice is not running"), "Start the services using the 'gpservice start' command.")
+	}
+
+	return nil
+}
+
+func addCoordinatorDataDirFlag(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&coordinatorDataDir, "coordinator-data-directory", os.Getenv(constants.CoordinatorDataDirEnv), "Coordinator data directory, defaults to the value of the COORDINATOR_DATA_DIRECTORY environment variable")
+}
@@ -0,0 +1,110 @@
+package cli_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/greenplum-db/gpdb/gpctl/cli"
+	"github.com/greenplum-db/gpdb/gpservice/idl"
+	"github.com/greenplum-db/gpdb/gpservice/idl/mock_idl"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/gpservice_config"
+	"github.com/spf13/cobra"
+)
+
+func TestRunStartClusterCmd(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	t.Run("returns error when gpservice is not configured", func(t *testing.T) {
+		cli.IsConfigured = false
+		defer func() { cli.IsConfigured = true }()
+
+		testStr := "gpservice is not configured"
+		err := cli.RunStartClusterCmd(&cobra.Command{}, nil)
+		if err == nil || !strings.Contains(err.Error(), testStr) {
+			t.Fatalf("got:%v, expected:%s", err, testStr)
+		}
+	})
+
+	t.Run("returns error when gpservice is not running", func(t *testing.T) {
+		cli.IsConfigured = true
+		cli.IsGpserviceRunning = false
+
+		testStr := "gpservice is not running"
+		err := cli.RunStartClusterCmd(&cobra.Command{}, nil)
+		if err == nil || !strings.Contains(err.Error(), testStr) {
+			t.Fatalf("got:%v, expected:%s", err, testStr)
+		}
+	})
+}
+
+func TestStartClusterService(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	t.Run("returns error if connect to hub fails", func(t *testing.T) {
+		testStr := "test-error"
+		defer resetCLIVars()
+		gpservice_config.ConnectToHub = func(conf *gpservice_config.Config) (idl.HubClient, error) {
+			return nil, fmt.Errorf(testStr)
+		}
+
+		err := cli.StartClusterService(context.Background(), cli.NewStreamController(), "/data/gpseg-1")
+		if err == nil || !strings.Contains(err.Error(), testStr) {
+			t.Fatalf("got %v, want %v", err, testStr)
+		}
+	})
+
+	t.Run("returns error if RPC returns error", func(t *testing.T) {
+		testStr := "test-error"
+		defer resetCLIVars()
+		gpservice_config.ConnectToHub = func(conf *gpservice_config.Config) (idl.HubClient, error) {
+			hubClient := mock_idl.NewMockHubClient(ctrl)
+			hubClient.EXPECT().StartCluster(gomock.Any(), &idl.StartClusterRequest{CoordinatorDataDir: "/data/gpseg-1"}).Return(nil, fmt.Errorf(testStr))
+			return hubClient, nil
+		}
+
+		err := cli.StartClusterService(context.Background(), cli.NewStreamController(), "/data/gpseg-1")
+		if err == nil || !strings.Contains(err.Error(), testStr) {
+			t.Fatalf("got %v, want %v", err, testStr)
+		}
+	})
+
+	t.Run("returns error if stream receiver returns error", func(t *testing.T) {
+		testStr := "Cluster start failed"
+		defer resetCLIVars()
+		gpservice_config.ConnectToHub = func(conf *gpservice_config.Config) (idl.HubClient, error) {
+			hubClient := mock_idl.NewMockHubClient(ctrl)
+			hubClient.EXPECT().StartCluster(gomock.Any(), gomock.Any()).Return(nil, nil)
+			return hubClient, nil
+		}
+		cli.ParseStreamResponse = func(stream cli.StreamReceiver, ctrl *cli.StreamController) error {
+			return fmt.Errorf(testStr)
+		}
+
+		err := cli.StartClusterService(context.Background(), cli.NewStreamController(), "/data/gpseg-1")
+		if err == nil || !strings.Contains(err.Error(), testStr) {
+			t.Fatalf("got %v, want %v", err, testStr)
+		}
+	})
+
+	t.Run("starts the cluster successfully", func(t *testing.T) {
+		defer resetCLIVars()
+		gpservice_config.ConnectToHub = func(conf *gpservice_config.Config) (idl.HubClient, error) {
+			hubClient := mock_idl.NewMockHubClient(ctrl)
+			hubClient.EXPECT().StartCluster(gomock.Any(), gomock.Any()).Return(nil, nil)
+			return hubClient, nil
+		}
+		cli.ParseStreamResponse = func(stream cli.StreamReceiver, ctrl *cli.StreamController) error {
+			return nil
+		}
+
+		err := cli.StartClusterService(context.Background(), cli.NewStreamController(), "/data/gpseg-1")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
@@ -0,0 +1,200 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"github.com/greenplum-db/gpdb/gpservice/constants"
+	"github.com/greenplum-db/gpdb/gpservice/idl"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/gpservice_config"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+)
+
+var (
+	GetClusterStatus = GetClusterStatusFn
+	GetClusterReport = GetClusterReportFn
+)
+
+// SegmentStatus is the user facing representation of the status of a segment
+type SegmentStatus struct {
+	Dbid          int32  `json:"dbid"`
+	Content       int32  `json:"content"`
+	Role          string `json:"role"`
+	PreferredRole string `json:"preferred_role"`
+	Mode          string `json:"mode"`
+	Status        string `json:"status"`
+	Hostname      string `json:"hostname"`
+	Address       string `json:"address"`
+	Port          int32  `json:"port"`
+	DataDir       string `json:"data_directory"`
+	Running       bool   `json:"running"`
+	Pid           int32  `json:"pid"`
+	ClusterState  string `json:"cluster_state"`
+	Error         string `json:"error,omitempty"`
+}
+
+// AgentStatus is the user facing representation of the status of the agent on a host
+type AgentStatus struct {
+	Hostname string `json:"hostname"`
+	Status   string `json:"status"`
+	Pid      uint32 `json:"pid"`
+	Uptime   string `json:"uptime,omitempty"`
+}
+
+// ClusterReport is the status of the segments and of the agents reported by gpctl status.
+// Only the coordinator is reported when the segment configuration cannot be read.
+type ClusterReport struct {
+	Segments     []SegmentStatus `json:"segments"`
+	Agents       []AgentStatus   `json:"agents"`
+	CatalogError string          `json:"catalog_error,omitempty"`
+}
+
+func statusCmd() *cobra.Command {
+	statusCmd := &cobra.Command{
+		Use:   "status",
+		Short: "Displays the status of a Greenplum Database system",
+		Args:  cobra.NoArgs,
+		Example: `To display the status of all the segments in the Greenplum Database system
+$ gpctl status
+
+To display the status in YAML format
+$ gpctl status --output yaml
+`,
+		RunE: RunStatusCmd,
+	}
+
+	addCoordinatorDataDirFlag(statusCmd)
+
+	return statusCmd
+}
+
+// RunStatusCmd driving function gets called from cobra on gpctl status command
+func RunStatusCmd(cmd *cobra.Command, args []string) error {
+	err := CheckGpServiceRunning()
+	if err != nil {
+		return err
+	}
+
+	if coordinatorDataDir == "" {
+		return fmt.Errorf("coordinator data directory not provided, please set the %s environment variable or use the --coordinator-data-directory flag", constants.CoordinatorDataDirEnv)
+	}
+
+	report, err := GetClusterReport(coordinatorDataDir)
+	if err != nil {
+		return err
+	}
+
+	DisplayClusterStatus(os.Stdout, report)
+	return nil
+}
+
+/*
+GetClusterStatusFn calls the ClusterStatus RPC on the hub and returns the status of all the segments.
+It errors out when the segment configuration cannot be read, such as when the coordinator is down.
+*/
+func GetClusterStatusFn(coordinatorDataDir string) ([]SegmentStatus, error) {
+	report, err := GetClusterReportFn(coordinatorDataDir)
+	if err != nil {
+		return nil, err
+	}
+
+	if report.CatalogError != "" {
+		return nil, errors.New(report.CatalogError)
+	}
+
+	return report.Segments, nil
+}
+
+/*
+GetClusterReportFn calls the ClusterStatus RPC on the hub and returns the status of all the
+segments and of the agents, along with the reason the segment configuration could not be read.
+*/
+func GetClusterReportFn(coordinatorDataDir string) (*ClusterReport, error) {
+	client, err := gpservice_config.ConnectToHub(Conf)
+	if err != nil {
+		return nil, err
+	}
+
+	reply, err := client.ClusterStatus(context.Background(), &idl.ClusterStatusRequest{
+		CoordinatorDataDir: coordinatorDataDir,
+	})
+	if err != nil {
+		return nil, utils.FormatGrpcError(err)
+	}
+
+	report := &ClusterReport{CatalogError: reply.CatalogError}
+	for _, seg := range reply.Segments {
+		report.Segments = append(report.Segments, SegmentStatus{
+			Dbid:          seg.Dbid,
+			Content:       seg.Content,
+			Role:          seg.Role,
+			PreferredRole: seg.PreferredRole,
+			Mode:          seg.Mode,
+			Status:        seg.Status,
+			Hostname:      seg.Hostname,
+			Address:       seg.Address,
+			Port:          seg.Port,
+			DataDir:       seg.DataDir,
+			Running:       seg.Running,
+			Pid:           seg.Pid,
+			ClusterState:  seg.ClusterState,
+			Error:         seg.Error,
+		})
+	}
+
+	for _, agent := range reply.Agents {
+		report.Agents = append(report.Agents, AgentStatus{
+			Hostname: agent.Host,
+			Status:   agent.Status,
+			Pid:      agent.Pid,
+			Uptime:   agent.Uptime,
+		})
+	}
+
+	return report, nil
+}
+
+/*
+DisplayClusterStatus writes the segment and agent statuses to the given writer as tables. With a
+structured output, the report is the data of the result of the command instead.
+*/
+func DisplayClusterStatus(outfile io.Writer, report *ClusterReport) {
+	if StructuredOutput() {
+		output.SetData(report)
+		return
+	}
+
+	w := new(tabwriter.Writer)
+	w.Init(outfile, 10, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "DBID\tCONTENT\tROLE\tPREFERRED ROLE\tMODE\tSTATUS\tHOST\tPORT\tDATA DIRECTORY\tRUNNING\tPID\tCLUSTER STATE")
+
+	for _, s := range report.Segments {
+		fmt.Fprintf(w, "%d\t%d\t%s\t%s\t%s\t%s\t%s\t%d\t%s\t%t\t%d\t%s\n", s.Dbid, s.Content, s.Role, s.PreferredRole, s.Mode, s.Status, s.Hostname, s.Port, s.DataDir, s.Running, s.Pid, s.ClusterState)
+	}
+	w.Flush()
+
+	fmt.Fprintln(outfile)
+	w.Init(outfile, 10, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "HOST\tAGENT STATUS\tPID\tUPTIME")
+	for _, a := range report.Agents {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\n", a.Hostname, a.Status, a.Pid, a.Uptime)
+	}
+	w.Flush()
+
+	if report.CatalogError != "" {
+		gplog.Warn(report.CatalogError)
+	}
+
+	for _, s := range report.Segments {
+		if s.Error != "" && s.Error != report.CatalogError {
+			gplog.Warn("dbid %d on host %s: %s", s.Dbid, s.Hostname, s.Error)
+		}
+	}
+}
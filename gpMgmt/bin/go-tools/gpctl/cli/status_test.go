@@ -0,0 +1,214 @@
+package cli_test
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/greenplum-db/gpdb/gpctl/cli"
+	"github.com/greenplum-db/gpdb/gpservice/idl"
+	"github.com/greenplum-db/gpdb/gpservice/idl/mock_idl"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/gpservice_config"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+	"github.com/greenplum-db/gpdb/gpservice/testutils"
+	"github.com/spf13/cobra"
+)
+
+func TestRunStatusCmd(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	t.Run("returns error when gpservice is not configured", func(t *testing.T) {
+		cli.IsConfigured = false
+		defer func() { cli.IsConfigured = true }()
+
+		testStr := "gpservice is not configured"
+		err := cli.RunStatusCmd(&cobra.Command{}, nil)
+		if err == nil || !strings.Contains(err.Error(), testStr) {
+			t.Fatalf("got:%v, expected:%s", err, testStr)
+		}
+	})
+
+	t.Run("returns error when gpservice is not running", func(t *testing.T) {
+		cli.IsConfigured = true
+		cli.IsGpserviceRunning = false
+
+		testStr := "gpservice is not running"
+		err := cli.RunStatusCmd(&cobra.Command{}, nil)
+		if err == nil || !strings.Contains(err.Error(), testStr) {
+			t.Fatalf("got:%v, expected:%s", err, testStr)
+		}
+	})
+}
+
+func TestGetClusterStatus(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	t.Run("returns error if connect to hub fails", func(t *testing.T) {
+		testStr := "test-error"
+		defer resetCLIVars()
+		gpservice_config.ConnectToHub = func(conf *gpservice_config.Config) (idl.HubClient, error) {
+			return nil, fmt.Errorf(testStr)
+		}
+
+		_, err := cli.GetClusterStatus("/data/gpseg-1")
+		if err == nil || !strings.Contains(err.Error(), testStr) {
+			t.Fatalf("got %v, want %v", err, testStr)
+		}
+	})
+
+	t.Run("returns error if RPC returns error", func(t *testing.T) {
+		testStr := "test-error"
+		defer resetCLIVars()
+		gpservice_config.ConnectToHub = func(conf *gpservice_config.Config) (idl.HubClient, error) {
+			hubClient := mock_idl.NewMockHubClient(ctrl)
+			hubClient.EXPECT().ClusterStatus(gomock.Any(), &idl.ClusterStatusRequest{CoordinatorDataDir: "/data/gpseg-1"}).Return(nil, fmt.Errorf(testStr))
+			return hubClient, nil
+		}
+
+		_, err := cli.GetClusterStatus("/data/gpseg-1")
+		if err == nil || !strings.Contains(err.Error(), testStr) {
+			t.Fatalf("got %v, want %v", err, testStr)
+		}
+	})
+
+	t.Run("returns the status of all the segments", func(t *testing.T) {
+		defer resetCLIVars()
+		gpservice_config.ConnectToHub = func(conf *gpservice_config.Config) (idl.HubClient, error) {
+			hubClient := mock_idl.NewMockHubClient(ctrl)
+			hubClient.EXPECT().ClusterStatus(gomock.Any(), gomock.Any()).Return(&idl.ClusterStatusReply{
+				Segments: []*idl.SegmentStatus{
+					{Dbid: 1, Content: -1, Role: "p", Hostname: "cdw", Running: true, Pid: 100},
+					{Dbid: 2, Content: 0, Role: "p", Hostname: "sdw1", Error: "error"},
+				},
+			}, nil)
+			return hubClient, nil
+		}
+
+		statuses, err := cli.GetClusterStatus("/data/gpseg-1")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := []cli.SegmentStatus{
+			{Dbid: 1, Content: -1, Role: "p", Hostname: "cdw", Running: true, Pid: 100},
+			{Dbid: 2, Content: 0, Role: "p", Hostname: "sdw1", Error: "error"},
+		}
+		if len(statuses) != len(expected) || statuses[0] != expected[0] || statuses[1] != expected[1] {
+			t.Fatalf("got %+v, want %+v", statuses, expected)
+		}
+	})
+
+	t.Run("returns error if the segment configuration cannot be read", func(t *testing.T) {
+		defer resetCLIVars()
+		gpservice_config.ConnectToHub = func(conf *gpservice_config.Config) (idl.HubClient, error) {
+			hubClient := mock_idl.NewMockHubClient(ctrl)
+			hubClient.EXPECT().ClusterStatus(gomock.Any(), gomock.Any()).Return(&idl.ClusterStatusReply{
+				Segments:     []*idl.SegmentStatus{{Content: -1, Hostname: "cdw"}},
+				CatalogError: "could not read the segment configuration",
+			}, nil)
+			return hubClient, nil
+		}
+
+		_, err := cli.GetClusterStatus("/data/gpseg-1")
+		expected := "could not read the segment configuration"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+}
+
+func TestGetClusterReport(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	t.Run("returns the status of the coordinator and the agents when the segment configuration cannot be read", func(t *testing.T) {
+		defer resetCLIVars()
+		gpservice_config.ConnectToHub = func(conf *gpservice_config.Config) (idl.HubClient, error) {
+			hubClient := mock_idl.NewMockHubClient(ctrl)
+			hubClient.EXPECT().ClusterStatus(gomock.Any(), gomock.Any()).Return(&idl.ClusterStatusReply{
+				Segments:     []*idl.SegmentStatus{{Content: -1, Hostname: "cdw", DataDir: "/data/gpseg-1", Error: "error"}},
+				Agents:       []*idl.ServiceStatus{{Role: "Agent", Host: "sdw1", Status: "running", Pid: 300, Uptime: "1h"}},
+				CatalogError: "could not read the segment configuration",
+			}, nil)
+			return hubClient, nil
+		}
+
+		report, err := cli.GetClusterReport("/data/gpseg-1")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := &cli.ClusterReport{
+			Segments:     []cli.SegmentStatus{{Content: -1, Hostname: "cdw", DataDir: "/data/gpseg-1", Error: "error"}},
+			Agents:       []cli.AgentStatus{{Hostname: "sdw1", Status: "running", Pid: 300, Uptime: "1h"}},
+			CatalogError: "could not read the segment configuration",
+		}
+		if !reflect.DeepEqual(report, expected) {
+			t.Fatalf("got %+v, want %+v", report, expected)
+		}
+	})
+}
+
+func TestDisplayClusterStatus(t *testing.T) {
+	setupTest(t)
+	defer teardownTest()
+
+	report := &cli.ClusterReport{
+		Segments: []cli.SegmentStatus{
+			{Dbid: 1, Content: -1, Role: "p", PreferredRole: "p", Mode: "n", Status: "u", Hostname: "cdw", Port: 7000, DataDir: "/data/gpseg-1", Running: true, Pid: 100, ClusterState: "in production"},
+		},
+		Agents: []cli.AgentStatus{
+			{Hostname: "sdw1", Status: "unreachable"},
+		},
+	}
+
+	t.Run("displays the status of the segments and the agents as tables", func(t *testing.T) {
+		var buf bytes.Buffer
+		cli.DisplayClusterStatus(&buf, report)
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		if len(lines) != 5 {
+			t.Fatalf("got %d lines, want 5", len(lines))
+		}
+
+		expected := []string{"1", "-1", "p", "p", "n", "u", "cdw", "7000", "/data/gpseg-1", "true", "100", "in", "production"}
+		fields := strings.Fields(lines[1])
+		if strings.Join(fields, " ") != strings.Join(expected, " ") {
+			t.Fatalf("got %q, want %q", fields, expected)
+		}
+
+		expected = []string{"sdw1", "unreachable", "0"}
+		fields = strings.Fields(lines[4])
+		if strings.Join(fields, " ") != strings.Join(expected, " ") {
+			t.Fatalf("got %q, want %q", fields, expected)
+		}
+	})
+
+	t.Run("reports the status as the data of the result with a structured output", func(t *testing.T) {
+		buffer, writer, resetStdout := testutils.CaptureStdout(t)
+		defer resetStdout()
+
+		resetOutput := cli.SetOutputFormat(utils.OutputFormatJson)
+		defer resetOutput()
+
+		var buf bytes.Buffer
+		cli.DisplayClusterStatus(&buf, report)
+		cli.WriteResult(&cobra.Command{Use: "status"}, nil)
+		writer.Close()
+		stdout := <-buffer
+
+		if buf.Len() != 0 {
+			t.Fatalf("got %s, want no table", buf.String())
+		}
+
+		expected := `"data":{"segments":[{"dbid":1,"content":-1,"role":"p","preferred_role":"p"`
+		if !strings.Contains(stdout, expected) || strings.Contains(stdout, `"error"`) {
+			t.Fatalf("got %s, want it to contain %s and no error field", stdout, expected)
+		}
+	})
+}
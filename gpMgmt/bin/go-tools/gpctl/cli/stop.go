@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"github.com/greenplum-db/gpdb/gpservice/constants"
+	"github.com/greenplum-db/gpdb/gpservice/idl"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/gpservice_config"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+)
+
+var (
+	StopClusterService = StopClusterServiceFn
+)
+
+var (
+	stopMode    string
+	stopTimeout int
+)
+
+func stopCmd() *cobra.Command {
+	stopCmd := &cobra.Command{
+		Use:   "stop",
+		Short: "Stops a Greenplum Database system",
+		Args:  cobra.NoArgs,
+		Example: `To stop the Greenplum Database system after all the client sessions have disconnected
+$ gpctl stop
+
+To stop the Greenplum Database system by rolling back all the active transactions
+$ gpctl stop --mode fast
+
+To stop the Greenplum Database system by aborting all the server processes
+$ gpctl stop --mode immediate
+`,
+		RunE: RunStopClusterCmd,
+	}
+
+	addCoordinatorDataDirFlag(stopCmd)
+	stopCmd.Flags().StringVar(&stopMode, "mode", constants.ShutdownModeSmart, fmt.Sprintf("Shutdown mode, one of %s, %s or %s", constants.ShutdownModeSmart, constants.ShutdownModeFast, constants.ShutdownModeImmediate))
+	stopCmd.Flags().IntVar(&stopTimeout, "timeout", constants.DefaultStopTimeout, "Number of seconds to wait for each segment to shut down")
+
+	return stopCmd
+}
+
+// RunStopClusterCmd driving function gets called from cobra on gpctl stop command
+func RunStopClusterCmd(cmd *cobra.Command, args []string) error {
+	err := CheckGpServiceRunning()
+	if err != nil {
+		return err
+	}
+
+	if coordinatorDataDir == "" {
+		return fmt.Errorf("coordinator data directory not provided, please set the %s environment variable or use the --coordinator-data-directory flag", constants.CoordinatorDataDirEnv)
+	}
+
+	if stopMode != constants.ShutdownModeSmart && stopMode != constants.ShutdownModeFast && stopMode != constants.ShutdownModeImmediate {
+		return fmt.Errorf("invalid value %q for --mode, valid values are %s, %s and %s", stopMode,
+			constants.ShutdownModeSmart, constants.ShutdownModeFast, constants.ShutdownModeImmediate)
+	}
+
+	if stopTimeout <= 0 {
+		return fmt.Errorf("invalid value %d for --timeout, value must be greater than 0", stopTimeout)
+	}
+
+	ctx, cancel := context.WithCancel(commandContext(cmd))
+	defer cancel()
+	ctrl := NewStreamController()
+
+	SetSignalHandler(ctrl)
+	CancelOnTermination(cancel)
+
+	return StopClusterService(ctx, ctrl, &idl.StopClusterRequest{
+		CoordinatorDataDir: coordinatorDataDir,
+		Mode:               stopMode,
+		Timeout:            int32(stopTimeout),
+	})
+}
+
+/*
+StopClusterServiceFn calls the StopCluster RPC on the hub and displays the streamed responses
+*/
+func StopClusterServiceFn(ctx context.Context, ctrl *StreamController, request *idl.StopClusterRequest) error {
+	client, err := gpservice_config.ConnectToHub(Conf)
+	if err != nil {
+		return err
+	}
+
+	stream, err := client.StopCluster(ctx, request)
+	if err != nil {
+		return utils.FormatGrpcError(err)
+	}
+
+	err = ParseStreamResponse(stream, ctrl)
+	if err != nil {
+		if TerminationRequested {
+			return &ErrorUserTermination{}
+		}
+
+		return err
+	}
+
+	gplog.Info("Cluster stopped successfully")
+	return nil
+}
@@ -4,11 +4,14 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
 	"sync"
 
 	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"github.com/greenplum-db/gpdb/gpservice/constants"
 	"github.com/greenplum-db/gpdb/gpservice/idl"
 	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+	"google.golang.org/grpc/metadata"
 )
 
 const (
@@ -90,8 +93,11 @@ func ParseStreamResponseFn(stream StreamReceiver, ctrl *StreamController) error
 	errCh := make(chan error)
 
 	ctrl.SetState(streamRunning)
+	defer setRunningOperation("")
 
 	go func() {
+		logOperationID(stream)
+
 		for {
 			resp, err := stream.Recv()
 			if err != nil {
@@ -118,6 +124,14 @@ loop:
 					continue
 				}
 
+				if output.Structured() {
+					err := output.Event(hubReplyEvent(resp))
+					if err != nil {
+						gplog.Error("failed to write the output event: %v", err)
+					}
+					continue
+				}
+
 				msg := resp.Message
 				switch msg.(type) {
 				case *idl.HubReply_LogMsg:
@@ -160,3 +174,48 @@ loop:
 
 	return nil
 }
+
+// logOperationID logs the ID the hub gave to the operation, with which it can be followed
+// from another session in case this one is lost
+func logOperationID(stream StreamReceiver) {
+	headerStream, ok := stream.(interface{ Header() (metadata.MD, error) })
+	if !ok {
+		return
+	}
+
+	header, err := headerStream.Header()
+	if err != nil {
+		return
+	}
+
+	if id := header.Get(constants.OperationIDHeader); len(id) > 0 {
+		setRunningOperation(id[0])
+		gplog.Verbose("Started operation %s, which can be followed using 'gpctl ops attach %s'", id[0], id[0])
+		output.Event(utils.OutputEvent{Type: utils.EventTypeOperation, OperationID: id[0]}) // nolint
+	}
+}
+
+// hubReplyEvent converts a reply streamed by the hub to an event of the structured output
+func hubReplyEvent(resp *idl.HubReply) utils.OutputEvent {
+	switch msg := resp.Message.(type) {
+	case *idl.HubReply_LogMsg:
+		return utils.OutputEvent{
+			Type:    utils.EventTypeLog,
+			Level:   strings.ToLower(msg.LogMsg.Level.String()),
+			Message: msg.LogMsg.Message,
+		}
+
+	case *idl.HubReply_ProgressMsg:
+		return utils.OutputEvent{
+			Type: utils.EventTypeProgress,
+			Progress: &utils.ProgressEvent{
+				Label:   msg.ProgressMsg.Label,
+				Current: msg.ProgressMsg.Current,
+				Total:   msg.ProgressMsg.Total,
+			},
+		}
+
+	default:
+		return utils.OutputEvent{Type: utils.EventTypeStdout, Message: resp.GetStdoutMsg()}
+	}
+}
@@ -1,8 +1,10 @@
 package cli_test
 
 import (
+	"encoding/json"
 	"errors"
 	"io"
+	"reflect"
 	"strings"
 	"sync"
 	"testing"
@@ -11,6 +13,7 @@ import (
 	"github.com/greenplum-db/gp-common-go-libs/testhelper"
 	"github.com/greenplum-db/gpdb/gpctl/cli"
 	"github.com/greenplum-db/gpdb/gpservice/idl"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
 	"github.com/greenplum-db/gpdb/gpservice/testutils"
 )
 
@@ -290,4 +293,47 @@ func TestParseStreamResponse(t *testing.T) {
 		// check that we do not have any additional responses
 		testutils.AssertLogMessageCount(t, logfile, "log message", 2)
 	})
+
+	t.Run("writes an event per stream response with the ndjson output", func(t *testing.T) {
+		testhelper.SetupTestLogger()
+
+		msg := []*idl.HubReply{
+			{Message: &idl.HubReply_LogMsg{LogMsg: &idl.LogMessage{Message: "creating segments", Level: idl.LogLevel_WARNING}}},
+			{Message: &idl.HubReply_StdoutMsg{StdoutMsg: "stdout message"}},
+			{Message: &idl.HubReply_ProgressMsg{ProgressMsg: &idl.ProgressMessage{Label: "progress message", Current: 1, Total: 2}}},
+		}
+
+		buffer, writer, resetStdout := testutils.CaptureStdout(t)
+		defer resetStdout()
+
+		resetOutput := cli.SetOutputFormat(utils.OutputFormatNdjson)
+		defer resetOutput()
+
+		err := cli.ParseStreamResponse(&msgStream{msg: msg}, cli.NewStreamController())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		writer.Close()
+		stdout := <-buffer
+
+		var events []utils.OutputEvent
+		for _, line := range strings.Split(strings.TrimSpace(stdout), "\n") {
+			var event utils.OutputEvent
+			err := json.Unmarshal([]byte(line), &event)
+			if err != nil {
+				t.Fatalf("got %q, want an event per line: %v", stdout, err)
+			}
+			event.Time = time.Time{}
+			events = append(events, event)
+		}
+
+		expected := []utils.OutputEvent{
+			{Type: utils.EventTypeLog, Level: "warning", Message: "creating segments"},
+			{Type: utils.EventTypeStdout, Message: "stdout message"},
+			{Type: utils.EventTypeProgress, Progress: &utils.ProgressEvent{Label: "progress message", Current: 1, Total: 2}},
+		}
+		if !reflect.DeepEqual(events, expected) {
+			t.Fatalf("got %+v, want %+v", events, expected)
+		}
+	})
 }
@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"context"
+
+	"github.com/greenplum-db/gpdb/gpservice/pkg/gpservice_config"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+var endTracing = func(err error) {}
+
+/*
+startTracing starts the trace of the command, which is carried over to the hub and the agents
+through the context of the command. The trace is only exported when the tracing is enabled
+in the gpservice configuration.
+*/
+func startTracing(cmd *cobra.Command, conf *gpservice_config.Config) error {
+	shutdown, err := utils.SetupTracing(conf.Tracing, "gpctl", conf.LogDir)
+	if err != nil {
+		return err
+	}
+
+	ctx, span := utils.StartSpan(commandContext(cmd), cmd.CommandPath())
+	cmd.SetContext(ctx)
+
+	endTracing = func(err error) {
+		utils.EndSpan(span, err)
+		shutdown()
+	}
+
+	return nil
+}
+
+// EndTracing ends the trace of the command with the error it returned, if any, and exports it.
+// It is called once the command returns, as cobra does not run the post run hooks on errors.
+func EndTracing(err error) {
+	endTracing(err)
+}
+
+// commandContext returns the context of the command, which is not set when the command is not
+// run through the root command
+func commandContext(cmd *cobra.Command) context.Context {
+	if cmd.Context() == nil {
+		return context.Background()
+	}
+
+	return cmd.Context()
+}
@@ -1,11 +1,13 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
 	"github.com/greenplum-db/gp-common-go-libs/gplog"
 	"github.com/greenplum-db/gpdb/gpctl/cli"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
 )
 
 func main() {
@@ -13,13 +15,21 @@ func main() {
 	root.SilenceUsage = true
 	root.SilenceErrors = true
 
-	err := root.Execute()
+	cmd, err := root.ExecuteC()
+	cli.EndTracing(err)
+	cli.WriteResult(cmd, err)
 	if err != nil {
 		// gplog is initialised in the PreRun function in cobra and sometimes when the
 		// error is due to the input flags, the cobra pkg would not run the PreRun function.
 		// In those cases directly print to the stdout instead of using gplog
 		if gplog.GetLogger() != nil {
 			gplog.Error(err.Error())
+
+			var helpErr utils.HelpErr
+			if errors.As(err, &helpErr) && !cli.StructuredOutput() {
+				fmt.Println()
+				helpErr.Help()
+			}
 		} else {
 			fmt.Println(err)
 			err = root.Help()
@@ -361,7 +361,7 @@ func TestInitClusterCleanup(t *testing.T) {
 			t.Fatalf("unexpected error: %s, %v", result.OutputMsg, err)
 		}
 
-		expectedOut := "[ERROR]:-failed to initialize the cluster: gpinitsystem has failed previously. Run gpctl init --clean before creating cluster again"
+		expectedOut := "[ERROR]:-failed to initialize the cluster: gpinitsystem has failed previously. Run gpctl init --resume to continue or gpctl init --clean before creating cluster again"
 		if !strings.Contains(result.OutputMsg, expectedOut) {
 			t.Fatalf("got %q, want %q", result.OutputMsg, expectedOut)
 		}
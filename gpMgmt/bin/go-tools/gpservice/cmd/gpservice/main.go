@@ -9,8 +9,10 @@ import (
 func main() {
 	root := cli.RootCommand()
 
-	err := root.Execute()
+	cmd, err := root.ExecuteC()
 	if err != nil {
+		// The commands which do not exit on errors leave the result to be written here
+		cli.WriteResult(cmd, err)
 		os.Exit(1)
 	}
 }
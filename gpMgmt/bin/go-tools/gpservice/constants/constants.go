@@ -11,11 +11,13 @@ const (
 	PlatformDarwin     = "darwin"
 	PlatformLinux      = "linux"
 	DefaultGpCtlName   = "gpctl"
+
+	// OperationIDHeader is the header in which the hub sends the ID of a long running operation
+	OperationIDHeader = "gpservice-operation-id"
 )
 
 const (
 	ShellPath               = "/bin/bash"
-	GpSSH                   = "gpssh"
 	MaxRetries              = 10
 	DefaultQdMaxConnect     = 150
 	QeConnectFactor         = 3
@@ -25,22 +27,43 @@ const (
 	DefaultEncoding         = "UTF-8"
 	EtcHostsFilepath        = "/etc/hosts"
 	CleanFileName           = "ClusterInitCLeanup.txt"
+	InitJournalFileName     = "ClusterInitJournal.json"
 	ReplicationSlotName     = "internal_wal_replication_slot"
 	DefaultStartTimeout     = 600
+	DefaultStopTimeout      = 600
+	DefaultPromoteTimeout   = 600
 	DefaultPostgresLogDir   = "log"
 	GroupMirroring          = "group"
 	SpreadMirroring         = "spread"
 	DefaultSegName          = "gpseg"
 	UserInputWaitDurtion    = 30
 	CheckInterruptFrequency = 500 * time.Millisecond
-	LockDirPath             = "/tmp"
-	LockDirPidFile          = "pid"
+	CoordinatorDataDirEnv   = "COORDINATOR_DATA_DIRECTORY"
+	ExpandStatusFileName    = "gpexpand_status.json"
+	DefaultExpandBatchSize  = 16
 )
 
 // gp_segment_configuration specific constants
 const (
 	RolePrimary = "p"
 	RoleMirror  = "m"
+	StatusUp    = "u"
+	StatusDown  = "d"
+
+	ModeSynchronized    = "s"
+	ModeNotSynchronized = "n"
+)
+
+// pg_ctl stop modes
+const (
+	ShutdownModeSmart     = "smart"
+	ShutdownModeFast      = "fast"
+	ShutdownModeImmediate = "immediate"
+)
+
+// pg_controldata cluster states
+const (
+	ClusterStateInProduction = "in production"
 )
 
 // Catalog tables
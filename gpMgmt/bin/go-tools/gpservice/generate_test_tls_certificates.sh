@@ -9,7 +9,8 @@ openssl req -x509 -sha256 -newkey rsa:4096 -days 365 -nodes \
     -keyout ./certificates/ca-key.pem \
     -out ./certificates/ca-cert.pem \
     -subj "/C=US/ST=California/L=Palo Alto/O=Greenplum/OU=GPDB/CN=$1" \
-    -addext "basicConstraints=CA:TRUE"
+    -addext "basicConstraints=CA:TRUE" \
+    -addext "keyUsage=critical,keyCertSign,cRLSign"
 
 # Generate private key and certificate signing request for the server
 openssl req -newkey rsa:4096 -nodes \
@@ -28,3 +29,19 @@ openssl x509 -req -in ./certificates/server-request.pem -days 365 \
     -out ./certificates/server-cert.pem \
     -extfile ./certificates/extensions.conf \
     -sha256
+
+# Generate private key and certificate signing request for the client
+openssl req -newkey rsa:4096 -nodes \
+    -keyout ./certificates/client-key.pem \
+    -out ./certificates/client-request.pem \
+    -subj "/C=US/ST=California/L=Palo Alto/O=Greenplum/OU=GPDB/CN=gpadmin" -sha256
+
+# Sign the client certificate using the CA
+echo "extendedKeyUsage=clientAuth" > ./certificates/client-extensions.conf
+openssl x509 -req -in ./certificates/client-request.pem -days 365 \
+    -CA ./certificates/ca-cert.pem \
+    -CAkey ./certificates/ca-key.pem \
+    -CAcreateserial \
+    -out ./certificates/client-cert.pem \
+    -extfile ./certificates/client-extensions.conf \
+    -sha256
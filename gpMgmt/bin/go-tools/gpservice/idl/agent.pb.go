@@ -189,6 +189,249 @@ func (m *StartSegmentReply) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_StartSegmentReply proto.InternalMessageInfo
 
+type StopSegmentRequest struct {
+	DataDir              string   `protobuf:"bytes,1,opt,name=dataDir,proto3" json:"dataDir,omitempty"`
+	Wait                 bool     `protobuf:"varint,2,opt,name=wait,proto3" json:"wait,omitempty"`
+	Timeout              int32    `protobuf:"varint,3,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	Mode                 string   `protobuf:"bytes,4,opt,name=mode,proto3" json:"mode,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StopSegmentRequest) Reset()         { *m = StopSegmentRequest{} }
+func (m *StopSegmentRequest) String() string { return proto.CompactTextString(m) }
+func (*StopSegmentRequest) ProtoMessage()    {}
+func (*StopSegmentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56ede974c0020f77, []int{4}
+}
+
+func (m *StopSegmentRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StopSegmentRequest.Unmarshal(m, b)
+}
+func (m *StopSegmentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StopSegmentRequest.Marshal(b, m, deterministic)
+}
+func (m *StopSegmentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StopSegmentRequest.Merge(m, src)
+}
+func (m *StopSegmentRequest) XXX_Size() int {
+	return xxx_messageInfo_StopSegmentRequest.Size(m)
+}
+func (m *StopSegmentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StopSegmentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StopSegmentRequest proto.InternalMessageInfo
+
+func (m *StopSegmentRequest) GetDataDir() string {
+	if m != nil {
+		return m.DataDir
+	}
+	return ""
+}
+
+func (m *StopSegmentRequest) GetWait() bool {
+	if m != nil {
+		return m.Wait
+	}
+	return false
+}
+
+func (m *StopSegmentRequest) GetTimeout() int32 {
+	if m != nil {
+		return m.Timeout
+	}
+	return 0
+}
+
+func (m *StopSegmentRequest) GetMode() string {
+	if m != nil {
+		return m.Mode
+	}
+	return ""
+}
+
+type StopSegmentReply struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StopSegmentReply) Reset()         { *m = StopSegmentReply{} }
+func (m *StopSegmentReply) String() string { return proto.CompactTextString(m) }
+func (*StopSegmentReply) ProtoMessage()    {}
+func (*StopSegmentReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56ede974c0020f77, []int{5}
+}
+
+func (m *StopSegmentReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StopSegmentReply.Unmarshal(m, b)
+}
+func (m *StopSegmentReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StopSegmentReply.Marshal(b, m, deterministic)
+}
+func (m *StopSegmentReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StopSegmentReply.Merge(m, src)
+}
+func (m *StopSegmentReply) XXX_Size() int {
+	return xxx_messageInfo_StopSegmentReply.Size(m)
+}
+func (m *StopSegmentReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_StopSegmentReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StopSegmentReply proto.InternalMessageInfo
+
+type GetSegmentStatusRequest struct {
+	DataDirs             []string `protobuf:"bytes,1,rep,name=dataDirs,proto3" json:"dataDirs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetSegmentStatusRequest) Reset()         { *m = GetSegmentStatusRequest{} }
+func (m *GetSegmentStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*GetSegmentStatusRequest) ProtoMessage()    {}
+func (*GetSegmentStatusRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56ede974c0020f77, []int{6}
+}
+
+func (m *GetSegmentStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetSegmentStatusRequest.Unmarshal(m, b)
+}
+func (m *GetSegmentStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetSegmentStatusRequest.Marshal(b, m, deterministic)
+}
+func (m *GetSegmentStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetSegmentStatusRequest.Merge(m, src)
+}
+func (m *GetSegmentStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_GetSegmentStatusRequest.Size(m)
+}
+func (m *GetSegmentStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetSegmentStatusRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetSegmentStatusRequest proto.InternalMessageInfo
+
+func (m *GetSegmentStatusRequest) GetDataDirs() []string {
+	if m != nil {
+		return m.DataDirs
+	}
+	return nil
+}
+
+type SegmentProcessStatus struct {
+	DataDir              string   `protobuf:"bytes,1,opt,name=dataDir,proto3" json:"dataDir,omitempty"`
+	Running              bool     `protobuf:"varint,2,opt,name=running,proto3" json:"running,omitempty"`
+	Pid                  int32    `protobuf:"varint,3,opt,name=pid,proto3" json:"pid,omitempty"`
+	ClusterState         string   `protobuf:"bytes,4,opt,name=clusterState,proto3" json:"clusterState,omitempty"`
+	Error                string   `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SegmentProcessStatus) Reset()         { *m = SegmentProcessStatus{} }
+func (m *SegmentProcessStatus) String() string { return proto.CompactTextString(m) }
+func (*SegmentProcessStatus) ProtoMessage()    {}
+func (*SegmentProcessStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56ede974c0020f77, []int{7}
+}
+
+func (m *SegmentProcessStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SegmentProcessStatus.Unmarshal(m, b)
+}
+func (m *SegmentProcessStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SegmentProcessStatus.Marshal(b, m, deterministic)
+}
+func (m *SegmentProcessStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SegmentProcessStatus.Merge(m, src)
+}
+func (m *SegmentProcessStatus) XXX_Size() int {
+	return xxx_messageInfo_SegmentProcessStatus.Size(m)
+}
+func (m *SegmentProcessStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_SegmentProcessStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SegmentProcessStatus proto.InternalMessageInfo
+
+func (m *SegmentProcessStatus) GetDataDir() string {
+	if m != nil {
+		return m.DataDir
+	}
+	return ""
+}
+
+func (m *SegmentProcessStatus) GetRunning() bool {
+	if m != nil {
+		return m.Running
+	}
+	return false
+}
+
+func (m *SegmentProcessStatus) GetPid() int32 {
+	if m != nil {
+		return m.Pid
+	}
+	return 0
+}
+
+func (m *SegmentProcessStatus) GetClusterState() string {
+	if m != nil {
+		return m.ClusterState
+	}
+	return ""
+}
+
+func (m *SegmentProcessStatus) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type GetSegmentStatusReply struct {
+	Statuses             []*SegmentProcessStatus `protobuf:"bytes,1,rep,name=statuses,proto3" json:"statuses,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *GetSegmentStatusReply) Reset()         { *m = GetSegmentStatusReply{} }
+func (m *GetSegmentStatusReply) String() string { return proto.CompactTextString(m) }
+func (*GetSegmentStatusReply) ProtoMessage()    {}
+func (*GetSegmentStatusReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56ede974c0020f77, []int{8}
+}
+
+func (m *GetSegmentStatusReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetSegmentStatusReply.Unmarshal(m, b)
+}
+func (m *GetSegmentStatusReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetSegmentStatusReply.Marshal(b, m, deterministic)
+}
+func (m *GetSegmentStatusReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetSegmentStatusReply.Merge(m, src)
+}
+func (m *GetSegmentStatusReply) XXX_Size() int {
+	return xxx_messageInfo_GetSegmentStatusReply.Size(m)
+}
+func (m *GetSegmentStatusReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetSegmentStatusReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetSegmentStatusReply proto.InternalMessageInfo
+
+func (m *GetSegmentStatusReply) GetStatuses() []*SegmentProcessStatus {
+	if m != nil {
+		return m.Statuses
+	}
+	return nil
+}
+
 type StopAgentRequest struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -199,7 +442,7 @@ func (m *StopAgentRequest) Reset()         { *m = StopAgentRequest{} }
 func (m *StopAgentRequest) String() string { return proto.CompactTextString(m) }
 func (*StopAgentRequest) ProtoMessage()    {}
 func (*StopAgentRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_56ede974c0020f77, []int{4}
+	return fileDescriptor_56ede974c0020f77, []int{9}
 }
 
 func (m *StopAgentRequest) XXX_Unmarshal(b []byte) error {
@@ -230,7 +473,7 @@ func (m *StopAgentReply) Reset()         { *m = StopAgentReply{} }
 func (m *StopAgentReply) String() string { return proto.CompactTextString(m) }
 func (*StopAgentReply) ProtoMessage()    {}
 func (*StopAgentReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_56ede974c0020f77, []int{5}
+	return fileDescriptor_56ede974c0020f77, []int{10}
 }
 
 func (m *StopAgentReply) XXX_Unmarshal(b []byte) error {
@@ -261,7 +504,7 @@ func (m *StatusAgentRequest) Reset()         { *m = StatusAgentRequest{} }
 func (m *StatusAgentRequest) String() string { return proto.CompactTextString(m) }
 func (*StatusAgentRequest) ProtoMessage()    {}
 func (*StatusAgentRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_56ede974c0020f77, []int{6}
+	return fileDescriptor_56ede974c0020f77, []int{11}
 }
 
 func (m *StatusAgentRequest) XXX_Unmarshal(b []byte) error {
@@ -295,7 +538,7 @@ func (m *StatusAgentReply) Reset()         { *m = StatusAgentReply{} }
 func (m *StatusAgentReply) String() string { return proto.CompactTextString(m) }
 func (*StatusAgentReply) ProtoMessage()    {}
 func (*StatusAgentReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_56ede974c0020f77, []int{7}
+	return fileDescriptor_56ede974c0020f77, []int{12}
 }
 
 func (m *StatusAgentReply) XXX_Unmarshal(b []byte) error {
@@ -344,6 +587,7 @@ type ValidateHostEnvRequest struct {
 	Locale               *Locale  `protobuf:"bytes,4,opt,name=locale,proto3" json:"locale,omitempty"`
 	GpVersion            string   `protobuf:"bytes,5,opt,name=gpVersion,proto3" json:"gpVersion,omitempty"`
 	Forced               bool     `protobuf:"varint,6,opt,name=forced,proto3" json:"forced,omitempty"`
+	DryRun               bool     `protobuf:"varint,7,opt,name=dryRun,proto3" json:"dryRun,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -353,7 +597,7 @@ func (m *ValidateHostEnvRequest) Reset()         { *m = ValidateHostEnvRequest{}
 func (m *ValidateHostEnvRequest) String() string { return proto.CompactTextString(m) }
 func (*ValidateHostEnvRequest) ProtoMessage()    {}
 func (*ValidateHostEnvRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_56ede974c0020f77, []int{8}
+	return fileDescriptor_56ede974c0020f77, []int{13}
 }
 
 func (m *ValidateHostEnvRequest) XXX_Unmarshal(b []byte) error {
@@ -416,6 +660,13 @@ func (m *ValidateHostEnvRequest) GetForced() bool {
 	return false
 }
 
+func (m *ValidateHostEnvRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
 type ValidateHostEnvReply struct {
 	Messages             []*LogMessage `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
@@ -427,7 +678,7 @@ func (m *ValidateHostEnvReply) Reset()         { *m = ValidateHostEnvReply{} }
 func (m *ValidateHostEnvReply) String() string { return proto.CompactTextString(m) }
 func (*ValidateHostEnvReply) ProtoMessage()    {}
 func (*ValidateHostEnvReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_56ede974c0020f77, []int{9}
+	return fileDescriptor_56ede974c0020f77, []int{14}
 }
 
 func (m *ValidateHostEnvReply) XXX_Unmarshal(b []byte) error {
@@ -463,6 +714,7 @@ type MakeSegmentRequest struct {
 	CoordinatorAddrs     []string          `protobuf:"bytes,5,rep,name=coordinatorAddrs,proto3" json:"coordinatorAddrs,omitempty"`
 	HbaHostNames         bool              `protobuf:"varint,6,opt,name=hbaHostNames,proto3" json:"hbaHostNames,omitempty"`
 	DataChecksums        bool              `protobuf:"varint,7,opt,name=dataChecksums,proto3" json:"dataChecksums,omitempty"`
+	Template             *Segment          `protobuf:"bytes,8,opt,name=template,proto3" json:"template,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
 	XXX_unrecognized     []byte            `json:"-"`
 	XXX_sizecache        int32             `json:"-"`
@@ -472,7 +724,7 @@ func (m *MakeSegmentRequest) Reset()         { *m = MakeSegmentRequest{} }
 func (m *MakeSegmentRequest) String() string { return proto.CompactTextString(m) }
 func (*MakeSegmentRequest) ProtoMessage()    {}
 func (*MakeSegmentRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_56ede974c0020f77, []int{10}
+	return fileDescriptor_56ede974c0020f77, []int{15}
 }
 
 func (m *MakeSegmentRequest) XXX_Unmarshal(b []byte) error {
@@ -542,6 +794,13 @@ func (m *MakeSegmentRequest) GetDataChecksums() bool {
 	return false
 }
 
+func (m *MakeSegmentRequest) GetTemplate() *Segment {
+	if m != nil {
+		return m.Template
+	}
+	return nil
+}
+
 type MakeSegmentReply struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -552,7 +811,7 @@ func (m *MakeSegmentReply) Reset()         { *m = MakeSegmentReply{} }
 func (m *MakeSegmentReply) String() string { return proto.CompactTextString(m) }
 func (*MakeSegmentReply) ProtoMessage()    {}
 func (*MakeSegmentReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_56ede974c0020f77, []int{11}
+	return fileDescriptor_56ede974c0020f77, []int{16}
 }
 
 func (m *MakeSegmentReply) XXX_Unmarshal(b []byte) error {
@@ -583,7 +842,7 @@ func (m *GetInterfaceAddrsRequest) Reset()         { *m = GetInterfaceAddrsReque
 func (m *GetInterfaceAddrsRequest) String() string { return proto.CompactTextString(m) }
 func (*GetInterfaceAddrsRequest) ProtoMessage()    {}
 func (*GetInterfaceAddrsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_56ede974c0020f77, []int{12}
+	return fileDescriptor_56ede974c0020f77, []int{17}
 }
 
 func (m *GetInterfaceAddrsRequest) XXX_Unmarshal(b []byte) error {
@@ -615,7 +874,7 @@ func (m *GetInterfaceAddrsResponse) Reset()         { *m = GetInterfaceAddrsResp
 func (m *GetInterfaceAddrsResponse) String() string { return proto.CompactTextString(m) }
 func (*GetInterfaceAddrsResponse) ProtoMessage()    {}
 func (*GetInterfaceAddrsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_56ede974c0020f77, []int{13}
+	return fileDescriptor_56ede974c0020f77, []int{18}
 }
 
 func (m *GetInterfaceAddrsResponse) XXX_Unmarshal(b []byte) error {
@@ -647,6 +906,7 @@ type UpdatePgHbaConfRequest struct {
 	Pgdata               string   `protobuf:"bytes,1,opt,name=pgdata,proto3" json:"pgdata,omitempty"`
 	Addrs                []string `protobuf:"bytes,2,rep,name=addrs,proto3" json:"addrs,omitempty"`
 	Replication          bool     `protobuf:"varint,3,opt,name=replication,proto3" json:"replication,omitempty"`
+	Remove               bool     `protobuf:"varint,4,opt,name=remove,proto3" json:"remove,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -656,7 +916,7 @@ func (m *UpdatePgHbaConfRequest) Reset()         { *m = UpdatePgHbaConfRequest{}
 func (m *UpdatePgHbaConfRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdatePgHbaConfRequest) ProtoMessage()    {}
 func (*UpdatePgHbaConfRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_56ede974c0020f77, []int{14}
+	return fileDescriptor_56ede974c0020f77, []int{19}
 }
 
 func (m *UpdatePgHbaConfRequest) XXX_Unmarshal(b []byte) error {
@@ -698,6 +958,13 @@ func (m *UpdatePgHbaConfRequest) GetReplication() bool {
 	return false
 }
 
+func (m *UpdatePgHbaConfRequest) GetRemove() bool {
+	if m != nil {
+		return m.Remove
+	}
+	return false
+}
+
 type UpdatePgHbaConfResponse struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -708,7 +975,7 @@ func (m *UpdatePgHbaConfResponse) Reset()         { *m = UpdatePgHbaConfResponse
 func (m *UpdatePgHbaConfResponse) String() string { return proto.CompactTextString(m) }
 func (*UpdatePgHbaConfResponse) ProtoMessage()    {}
 func (*UpdatePgHbaConfResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_56ede974c0020f77, []int{15}
+	return fileDescriptor_56ede974c0020f77, []int{20}
 }
 
 func (m *UpdatePgHbaConfResponse) XXX_Unmarshal(b []byte) error {
@@ -742,7 +1009,7 @@ func (m *UpdatePgConfRequest) Reset()         { *m = UpdatePgConfRequest{} }
 func (m *UpdatePgConfRequest) String() string { return proto.CompactTextString(m) }
 func (*UpdatePgConfRequest) ProtoMessage()    {}
 func (*UpdatePgConfRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_56ede974c0020f77, []int{16}
+	return fileDescriptor_56ede974c0020f77, []int{21}
 }
 
 func (m *UpdatePgConfRequest) XXX_Unmarshal(b []byte) error {
@@ -794,7 +1061,7 @@ func (m *UpdatePgConfRespoonse) Reset()         { *m = UpdatePgConfRespoonse{} }
 func (m *UpdatePgConfRespoonse) String() string { return proto.CompactTextString(m) }
 func (*UpdatePgConfRespoonse) ProtoMessage()    {}
 func (*UpdatePgConfRespoonse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_56ede974c0020f77, []int{17}
+	return fileDescriptor_56ede974c0020f77, []int{22}
 }
 
 func (m *UpdatePgConfRespoonse) XXX_Unmarshal(b []byte) error {
@@ -834,7 +1101,7 @@ func (m *PgBasebackupRequest) Reset()         { *m = PgBasebackupRequest{} }
 func (m *PgBasebackupRequest) String() string { return proto.CompactTextString(m) }
 func (*PgBasebackupRequest) ProtoMessage()    {}
 func (*PgBasebackupRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_56ede974c0020f77, []int{18}
+	return fileDescriptor_56ede974c0020f77, []int{23}
 }
 
 func (m *PgBasebackupRequest) XXX_Unmarshal(b []byte) error {
@@ -928,7 +1195,7 @@ func (m *PgBasebackupResponse) Reset()         { *m = PgBasebackupResponse{} }
 func (m *PgBasebackupResponse) String() string { return proto.CompactTextString(m) }
 func (*PgBasebackupResponse) ProtoMessage()    {}
 func (*PgBasebackupResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_56ede974c0020f77, []int{19}
+	return fileDescriptor_56ede974c0020f77, []int{24}
 }
 
 func (m *PgBasebackupResponse) XXX_Unmarshal(b []byte) error {
@@ -949,6 +1216,100 @@ func (m *PgBasebackupResponse) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_PgBasebackupResponse proto.InternalMessageInfo
 
+type PgRewindRequest struct {
+	TargetDir            string   `protobuf:"bytes,1,opt,name=targetDir,proto3" json:"targetDir,omitempty"`
+	SourceHost           string   `protobuf:"bytes,2,opt,name=sourceHost,proto3" json:"sourceHost,omitempty"`
+	SourcePort           int32    `protobuf:"varint,3,opt,name=sourcePort,proto3" json:"sourcePort,omitempty"`
+	TargetDbid           int32    `protobuf:"varint,4,opt,name=targetDbid,proto3" json:"targetDbid,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PgRewindRequest) Reset()         { *m = PgRewindRequest{} }
+func (m *PgRewindRequest) String() string { return proto.CompactTextString(m) }
+func (*PgRewindRequest) ProtoMessage()    {}
+func (*PgRewindRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56ede974c0020f77, []int{25}
+}
+
+func (m *PgRewindRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PgRewindRequest.Unmarshal(m, b)
+}
+func (m *PgRewindRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PgRewindRequest.Marshal(b, m, deterministic)
+}
+func (m *PgRewindRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PgRewindRequest.Merge(m, src)
+}
+func (m *PgRewindRequest) XXX_Size() int {
+	return xxx_messageInfo_PgRewindRequest.Size(m)
+}
+func (m *PgRewindRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PgRewindRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PgRewindRequest proto.InternalMessageInfo
+
+func (m *PgRewindRequest) GetTargetDir() string {
+	if m != nil {
+		return m.TargetDir
+	}
+	return ""
+}
+
+func (m *PgRewindRequest) GetSourceHost() string {
+	if m != nil {
+		return m.SourceHost
+	}
+	return ""
+}
+
+func (m *PgRewindRequest) GetSourcePort() int32 {
+	if m != nil {
+		return m.SourcePort
+	}
+	return 0
+}
+
+func (m *PgRewindRequest) GetTargetDbid() int32 {
+	if m != nil {
+		return m.TargetDbid
+	}
+	return 0
+}
+
+type PgRewindResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PgRewindResponse) Reset()         { *m = PgRewindResponse{} }
+func (m *PgRewindResponse) String() string { return proto.CompactTextString(m) }
+func (*PgRewindResponse) ProtoMessage()    {}
+func (*PgRewindResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56ede974c0020f77, []int{26}
+}
+
+func (m *PgRewindResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PgRewindResponse.Unmarshal(m, b)
+}
+func (m *PgRewindResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PgRewindResponse.Marshal(b, m, deterministic)
+}
+func (m *PgRewindResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PgRewindResponse.Merge(m, src)
+}
+func (m *PgRewindResponse) XXX_Size() int {
+	return xxx_messageInfo_PgRewindResponse.Size(m)
+}
+func (m *PgRewindResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PgRewindResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PgRewindResponse proto.InternalMessageInfo
+
 type RemoveDirectoryRequest struct {
 	DataDirectory        string   `protobuf:"bytes,1,opt,name=dataDirectory,proto3" json:"dataDirectory,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
@@ -960,7 +1321,7 @@ func (m *RemoveDirectoryRequest) Reset()         { *m = RemoveDirectoryRequest{}
 func (m *RemoveDirectoryRequest) String() string { return proto.CompactTextString(m) }
 func (*RemoveDirectoryRequest) ProtoMessage()    {}
 func (*RemoveDirectoryRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_56ede974c0020f77, []int{20}
+	return fileDescriptor_56ede974c0020f77, []int{27}
 }
 
 func (m *RemoveDirectoryRequest) XXX_Unmarshal(b []byte) error {
@@ -998,7 +1359,7 @@ func (m *RemoveDirectoryReply) Reset()         { *m = RemoveDirectoryReply{} }
 func (m *RemoveDirectoryReply) String() string { return proto.CompactTextString(m) }
 func (*RemoveDirectoryReply) ProtoMessage()    {}
 func (*RemoveDirectoryReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_56ede974c0020f77, []int{21}
+	return fileDescriptor_56ede974c0020f77, []int{28}
 }
 
 func (m *RemoveDirectoryReply) XXX_Unmarshal(b []byte) error {
@@ -1019,106 +1380,634 @@ func (m *RemoveDirectoryReply) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_RemoveDirectoryReply proto.InternalMessageInfo
 
-func init() {
-	proto.RegisterType((*GetHostNameReply)(nil), "idl.GetHostNameReply")
-	proto.RegisterType((*GetHostNameRequest)(nil), "idl.GetHostNameRequest")
-	proto.RegisterType((*StartSegmentRequest)(nil), "idl.StartSegmentRequest")
-	proto.RegisterType((*StartSegmentReply)(nil), "idl.StartSegmentReply")
-	proto.RegisterType((*StopAgentRequest)(nil), "idl.StopAgentRequest")
-	proto.RegisterType((*StopAgentReply)(nil), "idl.StopAgentReply")
-	proto.RegisterType((*StatusAgentRequest)(nil), "idl.StatusAgentRequest")
-	proto.RegisterType((*StatusAgentReply)(nil), "idl.StatusAgentReply")
-	proto.RegisterType((*ValidateHostEnvRequest)(nil), "idl.ValidateHostEnvRequest")
-	proto.RegisterType((*ValidateHostEnvReply)(nil), "idl.ValidateHostEnvReply")
-	proto.RegisterType((*MakeSegmentRequest)(nil), "idl.MakeSegmentRequest")
-	proto.RegisterMapType((map[string]string)(nil), "idl.MakeSegmentRequest.SegConfigEntry")
-	proto.RegisterType((*MakeSegmentReply)(nil), "idl.MakeSegmentReply")
-	proto.RegisterType((*GetInterfaceAddrsRequest)(nil), "idl.GetInterfaceAddrsRequest")
-	proto.RegisterType((*GetInterfaceAddrsResponse)(nil), "idl.GetInterfaceAddrsResponse")
-	proto.RegisterType((*UpdatePgHbaConfRequest)(nil), "idl.UpdatePgHbaConfRequest")
-	proto.RegisterType((*UpdatePgHbaConfResponse)(nil), "idl.UpdatePgHbaConfResponse")
-	proto.RegisterType((*UpdatePgConfRequest)(nil), "idl.UpdatePgConfRequest")
-	proto.RegisterMapType((map[string]string)(nil), "idl.UpdatePgConfRequest.ParamsEntry")
+type PromoteSegmentRequest struct {
+	DataDir              string   `protobuf:"bytes,1,opt,name=dataDir,proto3" json:"dataDir,omitempty"`
+	Wait                 bool     `protobuf:"varint,2,opt,name=wait,proto3" json:"wait,omitempty"`
+	Timeout              int32    `protobuf:"varint,3,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PromoteSegmentRequest) Reset()         { *m = PromoteSegmentRequest{} }
+func (m *PromoteSegmentRequest) String() string { return proto.CompactTextString(m) }
+func (*PromoteSegmentRequest) ProtoMessage()    {}
+func (*PromoteSegmentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56ede974c0020f77, []int{29}
+}
+
+func (m *PromoteSegmentRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PromoteSegmentRequest.Unmarshal(m, b)
+}
+func (m *PromoteSegmentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PromoteSegmentRequest.Marshal(b, m, deterministic)
+}
+func (m *PromoteSegmentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PromoteSegmentRequest.Merge(m, src)
+}
+func (m *PromoteSegmentRequest) XXX_Size() int {
+	return xxx_messageInfo_PromoteSegmentRequest.Size(m)
+}
+func (m *PromoteSegmentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PromoteSegmentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PromoteSegmentRequest proto.InternalMessageInfo
+
+func (m *PromoteSegmentRequest) GetDataDir() string {
+	if m != nil {
+		return m.DataDir
+	}
+	return ""
+}
+
+func (m *PromoteSegmentRequest) GetWait() bool {
+	if m != nil {
+		return m.Wait
+	}
+	return false
+}
+
+func (m *PromoteSegmentRequest) GetTimeout() int32 {
+	if m != nil {
+		return m.Timeout
+	}
+	return 0
+}
+
+type PromoteSegmentReply struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PromoteSegmentReply) Reset()         { *m = PromoteSegmentReply{} }
+func (m *PromoteSegmentReply) String() string { return proto.CompactTextString(m) }
+func (*PromoteSegmentReply) ProtoMessage()    {}
+func (*PromoteSegmentReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56ede974c0020f77, []int{30}
+}
+
+func (m *PromoteSegmentReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PromoteSegmentReply.Unmarshal(m, b)
+}
+func (m *PromoteSegmentReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PromoteSegmentReply.Marshal(b, m, deterministic)
+}
+func (m *PromoteSegmentReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PromoteSegmentReply.Merge(m, src)
+}
+func (m *PromoteSegmentReply) XXX_Size() int {
+	return xxx_messageInfo_PromoteSegmentReply.Size(m)
+}
+func (m *PromoteSegmentReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_PromoteSegmentReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PromoteSegmentReply proto.InternalMessageInfo
+
+type FileHeader struct {
+	Path                 string   `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Mode                 uint32   `protobuf:"varint,2,opt,name=mode,proto3" json:"mode,omitempty"`
+	Size                 int64    `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FileHeader) Reset()         { *m = FileHeader{} }
+func (m *FileHeader) String() string { return proto.CompactTextString(m) }
+func (*FileHeader) ProtoMessage()    {}
+func (*FileHeader) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56ede974c0020f77, []int{31}
+}
+
+func (m *FileHeader) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FileHeader.Unmarshal(m, b)
+}
+func (m *FileHeader) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FileHeader.Marshal(b, m, deterministic)
+}
+func (m *FileHeader) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FileHeader.Merge(m, src)
+}
+func (m *FileHeader) XXX_Size() int {
+	return xxx_messageInfo_FileHeader.Size(m)
+}
+func (m *FileHeader) XXX_DiscardUnknown() {
+	xxx_messageInfo_FileHeader.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FileHeader proto.InternalMessageInfo
+
+func (m *FileHeader) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *FileHeader) GetMode() uint32 {
+	if m != nil {
+		return m.Mode
+	}
+	return 0
+}
+
+func (m *FileHeader) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+type FileChunk struct {
+	Data                 []byte   `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	Checksum             uint32   `protobuf:"varint,2,opt,name=checksum,proto3" json:"checksum,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *FileChunk) Reset()         { *m = FileChunk{} }
+func (m *FileChunk) String() string { return proto.CompactTextString(m) }
+func (*FileChunk) ProtoMessage()    {}
+func (*FileChunk) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56ede974c0020f77, []int{32}
+}
+
+func (m *FileChunk) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FileChunk.Unmarshal(m, b)
+}
+func (m *FileChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FileChunk.Marshal(b, m, deterministic)
+}
+func (m *FileChunk) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FileChunk.Merge(m, src)
+}
+func (m *FileChunk) XXX_Size() int {
+	return xxx_messageInfo_FileChunk.Size(m)
+}
+func (m *FileChunk) XXX_DiscardUnknown() {
+	xxx_messageInfo_FileChunk.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FileChunk proto.InternalMessageInfo
+
+func (m *FileChunk) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *FileChunk) GetChecksum() uint32 {
+	if m != nil {
+		return m.Checksum
+	}
+	return 0
+}
+
+// The first message of the stream carries the header, followed by the chunks
+type PutFileRequest struct {
+	// Types that are valid to be assigned to Content:
+	//	*PutFileRequest_Header
+	//	*PutFileRequest_Chunk
+	Content              isPutFileRequest_Content `protobuf_oneof:"content"`
+	XXX_NoUnkeyedLiteral struct{}                 `json:"-"`
+	XXX_unrecognized     []byte                   `json:"-"`
+	XXX_sizecache        int32                    `json:"-"`
+}
+
+func (m *PutFileRequest) Reset()         { *m = PutFileRequest{} }
+func (m *PutFileRequest) String() string { return proto.CompactTextString(m) }
+func (*PutFileRequest) ProtoMessage()    {}
+func (*PutFileRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56ede974c0020f77, []int{33}
+}
+
+func (m *PutFileRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PutFileRequest.Unmarshal(m, b)
+}
+func (m *PutFileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PutFileRequest.Marshal(b, m, deterministic)
+}
+func (m *PutFileRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PutFileRequest.Merge(m, src)
+}
+func (m *PutFileRequest) XXX_Size() int {
+	return xxx_messageInfo_PutFileRequest.Size(m)
+}
+func (m *PutFileRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PutFileRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PutFileRequest proto.InternalMessageInfo
+
+type isPutFileRequest_Content interface {
+	isPutFileRequest_Content()
+}
+
+type PutFileRequest_Header struct {
+	Header *FileHeader `protobuf:"bytes,1,opt,name=header,proto3,oneof"`
+}
+
+type PutFileRequest_Chunk struct {
+	Chunk *FileChunk `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+func (*PutFileRequest_Header) isPutFileRequest_Content() {}
+
+func (*PutFileRequest_Chunk) isPutFileRequest_Content() {}
+
+func (m *PutFileRequest) GetContent() isPutFileRequest_Content {
+	if m != nil {
+		return m.Content
+	}
+	return nil
+}
+
+func (m *PutFileRequest) GetHeader() *FileHeader {
+	if x, ok := m.GetContent().(*PutFileRequest_Header); ok {
+		return x.Header
+	}
+	return nil
+}
+
+func (m *PutFileRequest) GetChunk() *FileChunk {
+	if x, ok := m.GetContent().(*PutFileRequest_Chunk); ok {
+		return x.Chunk
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*PutFileRequest) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*PutFileRequest_Header)(nil),
+		(*PutFileRequest_Chunk)(nil),
+	}
+}
+
+type PutFileReply struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PutFileReply) Reset()         { *m = PutFileReply{} }
+func (m *PutFileReply) String() string { return proto.CompactTextString(m) }
+func (*PutFileReply) ProtoMessage()    {}
+func (*PutFileReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56ede974c0020f77, []int{34}
+}
+
+func (m *PutFileReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PutFileReply.Unmarshal(m, b)
+}
+func (m *PutFileReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PutFileReply.Marshal(b, m, deterministic)
+}
+func (m *PutFileReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PutFileReply.Merge(m, src)
+}
+func (m *PutFileReply) XXX_Size() int {
+	return xxx_messageInfo_PutFileReply.Size(m)
+}
+func (m *PutFileReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_PutFileReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PutFileReply proto.InternalMessageInfo
+
+type GetFileRequest struct {
+	Path                 string   `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetFileRequest) Reset()         { *m = GetFileRequest{} }
+func (m *GetFileRequest) String() string { return proto.CompactTextString(m) }
+func (*GetFileRequest) ProtoMessage()    {}
+func (*GetFileRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56ede974c0020f77, []int{35}
+}
+
+func (m *GetFileRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetFileRequest.Unmarshal(m, b)
+}
+func (m *GetFileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetFileRequest.Marshal(b, m, deterministic)
+}
+func (m *GetFileRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetFileRequest.Merge(m, src)
+}
+func (m *GetFileRequest) XXX_Size() int {
+	return xxx_messageInfo_GetFileRequest.Size(m)
+}
+func (m *GetFileRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetFileRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetFileRequest proto.InternalMessageInfo
+
+func (m *GetFileRequest) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+// The first message of the stream carries the header, followed by the chunks
+type GetFileReply struct {
+	// Types that are valid to be assigned to Content:
+	//	*GetFileReply_Header
+	//	*GetFileReply_Chunk
+	Content              isGetFileReply_Content `protobuf_oneof:"content"`
+	XXX_NoUnkeyedLiteral struct{}               `json:"-"`
+	XXX_unrecognized     []byte                 `json:"-"`
+	XXX_sizecache        int32                  `json:"-"`
+}
+
+func (m *GetFileReply) Reset()         { *m = GetFileReply{} }
+func (m *GetFileReply) String() string { return proto.CompactTextString(m) }
+func (*GetFileReply) ProtoMessage()    {}
+func (*GetFileReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56ede974c0020f77, []int{36}
+}
+
+func (m *GetFileReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetFileReply.Unmarshal(m, b)
+}
+func (m *GetFileReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetFileReply.Marshal(b, m, deterministic)
+}
+func (m *GetFileReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetFileReply.Merge(m, src)
+}
+func (m *GetFileReply) XXX_Size() int {
+	return xxx_messageInfo_GetFileReply.Size(m)
+}
+func (m *GetFileReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetFileReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetFileReply proto.InternalMessageInfo
+
+type isGetFileReply_Content interface {
+	isGetFileReply_Content()
+}
+
+type GetFileReply_Header struct {
+	Header *FileHeader `protobuf:"bytes,1,opt,name=header,proto3,oneof"`
+}
+
+type GetFileReply_Chunk struct {
+	Chunk *FileChunk `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+func (*GetFileReply_Header) isGetFileReply_Content() {}
+
+func (*GetFileReply_Chunk) isGetFileReply_Content() {}
+
+func (m *GetFileReply) GetContent() isGetFileReply_Content {
+	if m != nil {
+		return m.Content
+	}
+	return nil
+}
+
+func (m *GetFileReply) GetHeader() *FileHeader {
+	if x, ok := m.GetContent().(*GetFileReply_Header); ok {
+		return x.Header
+	}
+	return nil
+}
+
+func (m *GetFileReply) GetChunk() *FileChunk {
+	if x, ok := m.GetContent().(*GetFileReply_Chunk); ok {
+		return x.Chunk
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*GetFileReply) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*GetFileReply_Header)(nil),
+		(*GetFileReply_Chunk)(nil),
+	}
+}
+
+type ReloadAgentCredentialsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReloadAgentCredentialsRequest) Reset()         { *m = ReloadAgentCredentialsRequest{} }
+func (m *ReloadAgentCredentialsRequest) String() string { return proto.CompactTextString(m) }
+func (*ReloadAgentCredentialsRequest) ProtoMessage()    {}
+func (*ReloadAgentCredentialsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56ede974c0020f77, []int{37}
+}
+
+func (m *ReloadAgentCredentialsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReloadAgentCredentialsRequest.Unmarshal(m, b)
+}
+func (m *ReloadAgentCredentialsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReloadAgentCredentialsRequest.Marshal(b, m, deterministic)
+}
+func (m *ReloadAgentCredentialsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReloadAgentCredentialsRequest.Merge(m, src)
+}
+func (m *ReloadAgentCredentialsRequest) XXX_Size() int {
+	return xxx_messageInfo_ReloadAgentCredentialsRequest.Size(m)
+}
+func (m *ReloadAgentCredentialsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReloadAgentCredentialsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReloadAgentCredentialsRequest proto.InternalMessageInfo
+
+type ReloadAgentCredentialsReply struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReloadAgentCredentialsReply) Reset()         { *m = ReloadAgentCredentialsReply{} }
+func (m *ReloadAgentCredentialsReply) String() string { return proto.CompactTextString(m) }
+func (*ReloadAgentCredentialsReply) ProtoMessage()    {}
+func (*ReloadAgentCredentialsReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_56ede974c0020f77, []int{38}
+}
+
+func (m *ReloadAgentCredentialsReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReloadAgentCredentialsReply.Unmarshal(m, b)
+}
+func (m *ReloadAgentCredentialsReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReloadAgentCredentialsReply.Marshal(b, m, deterministic)
+}
+func (m *ReloadAgentCredentialsReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReloadAgentCredentialsReply.Merge(m, src)
+}
+func (m *ReloadAgentCredentialsReply) XXX_Size() int {
+	return xxx_messageInfo_ReloadAgentCredentialsReply.Size(m)
+}
+func (m *ReloadAgentCredentialsReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReloadAgentCredentialsReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReloadAgentCredentialsReply proto.InternalMessageInfo
+
+func init() {
+	proto.RegisterType((*GetHostNameReply)(nil), "idl.GetHostNameReply")
+	proto.RegisterType((*GetHostNameRequest)(nil), "idl.GetHostNameRequest")
+	proto.RegisterType((*StartSegmentRequest)(nil), "idl.StartSegmentRequest")
+	proto.RegisterType((*StartSegmentReply)(nil), "idl.StartSegmentReply")
+	proto.RegisterType((*StopSegmentRequest)(nil), "idl.StopSegmentRequest")
+	proto.RegisterType((*StopSegmentReply)(nil), "idl.StopSegmentReply")
+	proto.RegisterType((*GetSegmentStatusRequest)(nil), "idl.GetSegmentStatusRequest")
+	proto.RegisterType((*SegmentProcessStatus)(nil), "idl.SegmentProcessStatus")
+	proto.RegisterType((*GetSegmentStatusReply)(nil), "idl.GetSegmentStatusReply")
+	proto.RegisterType((*StopAgentRequest)(nil), "idl.StopAgentRequest")
+	proto.RegisterType((*StopAgentReply)(nil), "idl.StopAgentReply")
+	proto.RegisterType((*StatusAgentRequest)(nil), "idl.StatusAgentRequest")
+	proto.RegisterType((*StatusAgentReply)(nil), "idl.StatusAgentReply")
+	proto.RegisterType((*ValidateHostEnvRequest)(nil), "idl.ValidateHostEnvRequest")
+	proto.RegisterType((*ValidateHostEnvReply)(nil), "idl.ValidateHostEnvReply")
+	proto.RegisterType((*MakeSegmentRequest)(nil), "idl.MakeSegmentRequest")
+	proto.RegisterMapType((map[string]string)(nil), "idl.MakeSegmentRequest.SegConfigEntry")
+	proto.RegisterType((*MakeSegmentReply)(nil), "idl.MakeSegmentReply")
+	proto.RegisterType((*GetInterfaceAddrsRequest)(nil), "idl.GetInterfaceAddrsRequest")
+	proto.RegisterType((*GetInterfaceAddrsResponse)(nil), "idl.GetInterfaceAddrsResponse")
+	proto.RegisterType((*UpdatePgHbaConfRequest)(nil), "idl.UpdatePgHbaConfRequest")
+	proto.RegisterType((*UpdatePgHbaConfResponse)(nil), "idl.UpdatePgHbaConfResponse")
+	proto.RegisterType((*UpdatePgConfRequest)(nil), "idl.UpdatePgConfRequest")
+	proto.RegisterMapType((map[string]string)(nil), "idl.UpdatePgConfRequest.ParamsEntry")
 	proto.RegisterType((*UpdatePgConfRespoonse)(nil), "idl.UpdatePgConfRespoonse")
 	proto.RegisterType((*PgBasebackupRequest)(nil), "idl.PgBasebackupRequest")
 	proto.RegisterType((*PgBasebackupResponse)(nil), "idl.PgBasebackupResponse")
+	proto.RegisterType((*PgRewindRequest)(nil), "idl.PgRewindRequest")
+	proto.RegisterType((*PgRewindResponse)(nil), "idl.PgRewindResponse")
 	proto.RegisterType((*RemoveDirectoryRequest)(nil), "idl.RemoveDirectoryRequest")
 	proto.RegisterType((*RemoveDirectoryReply)(nil), "idl.RemoveDirectoryReply")
+	proto.RegisterType((*PromoteSegmentRequest)(nil), "idl.PromoteSegmentRequest")
+	proto.RegisterType((*PromoteSegmentReply)(nil), "idl.PromoteSegmentReply")
+	proto.RegisterType((*FileHeader)(nil), "idl.FileHeader")
+	proto.RegisterType((*FileChunk)(nil), "idl.FileChunk")
+	proto.RegisterType((*PutFileRequest)(nil), "idl.PutFileRequest")
+	proto.RegisterType((*PutFileReply)(nil), "idl.PutFileReply")
+	proto.RegisterType((*GetFileRequest)(nil), "idl.GetFileRequest")
+	proto.RegisterType((*GetFileReply)(nil), "idl.GetFileReply")
+	proto.RegisterType((*ReloadAgentCredentialsRequest)(nil), "idl.ReloadAgentCredentialsRequest")
+	proto.RegisterType((*ReloadAgentCredentialsReply)(nil), "idl.ReloadAgentCredentialsReply")
 }
 
 func init() { proto.RegisterFile("agent.proto", fileDescriptor_56ede974c0020f77) }
 
 var fileDescriptor_56ede974c0020f77 = []byte{
-	// 1090 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x56, 0x6d, 0x6f, 0xe3, 0x44,
-	0x10, 0x6e, 0xd2, 0x26, 0x4d, 0x26, 0xb9, 0x36, 0xdd, 0xa4, 0xa9, 0x6b, 0xca, 0x29, 0x32, 0xa7,
-	0x2a, 0x02, 0x14, 0x20, 0xf0, 0x01, 0x4e, 0x27, 0x50, 0xdf, 0xb8, 0x43, 0xdc, 0x41, 0xe4, 0x1c,
-	0x87, 0xc4, 0xb7, 0x8d, 0xbd, 0x75, 0xac, 0x3a, 0x5e, 0xb3, 0xbb, 0x6e, 0xc9, 0x2f, 0xe1, 0x0f,
-	0xf1, 0x3f, 0xee, 0x67, 0xf0, 0x15, 0xed, 0x7a, 0x9d, 0xf8, 0xad, 0x12, 0xf7, 0xcd, 0xf3, 0xcc,
-	0xec, 0xec, 0xbc, 0x3c, 0x33, 0x6b, 0xe8, 0x60, 0x8f, 0x84, 0x62, 0x12, 0x31, 0x2a, 0x28, 0xda,
-	0xf5, 0xdd, 0xc0, 0x6c, 0x2f, 0xe3, 0x45, 0x22, 0x5b, 0x13, 0xe8, 0xbd, 0x24, 0xe2, 0x15, 0xe5,
-	0xe2, 0x17, 0xbc, 0x22, 0x36, 0x89, 0x82, 0x35, 0x32, 0xa1, 0xb5, 0xa4, 0x5c, 0x84, 0x78, 0x45,
-	0x8c, 0xda, 0xa8, 0x36, 0x6e, 0xdb, 0x1b, 0xd9, 0x1a, 0x00, 0xca, 0xd9, 0xff, 0x19, 0x13, 0x2e,
-	0xac, 0x07, 0xe8, 0xcf, 0x05, 0x66, 0x62, 0x4e, 0xbc, 0x15, 0x09, 0x85, 0x86, 0x91, 0x01, 0xfb,
-	0x2e, 0x16, 0xf8, 0xda, 0x67, 0xda, 0x4f, 0x2a, 0x22, 0x04, 0x7b, 0x0f, 0xd8, 0x17, 0x46, 0x7d,
-	0x54, 0x1b, 0xb7, 0x6c, 0xf5, 0x2d, 0xad, 0x85, 0xbf, 0x22, 0x34, 0x16, 0xc6, 0xde, 0xa8, 0x36,
-	0x6e, 0xd8, 0xa9, 0x28, 0x35, 0x34, 0x12, 0x3e, 0x0d, 0xb9, 0xd1, 0x48, 0xfc, 0x68, 0xd1, 0xea,
-	0xc3, 0x51, 0xfe, 0xe2, 0x28, 0x58, 0x5b, 0x08, 0x7a, 0x73, 0x41, 0xa3, 0x0b, 0x6f, 0x1b, 0x8a,
-	0xd5, 0x83, 0x83, 0x0c, 0x26, 0xad, 0x06, 0x80, 0xe6, 0x02, 0x8b, 0x98, 0xe7, 0xec, 0xde, 0xca,
-	0xb3, 0x19, 0x54, 0xd6, 0x63, 0x08, 0x4d, 0xae, 0x30, 0x9d, 0x85, 0x96, 0x24, 0x1e, 0x47, 0x32,
-	0x46, 0x95, 0x46, 0xdb, 0xd6, 0x12, 0xea, 0xc1, 0x6e, 0xe4, 0xbb, 0xc6, 0xee, 0xa8, 0x36, 0x7e,
-	0x62, 0xcb, 0x4f, 0xeb, 0x7d, 0x0d, 0x86, 0xef, 0x70, 0xe0, 0xbb, 0x58, 0x10, 0x59, 0xbb, 0x9b,
-	0xf0, 0x3e, 0xad, 0xd1, 0x18, 0x0e, 0x65, 0x71, 0x2f, 0x5c, 0x97, 0x11, 0xce, 0x5f, 0xfb, 0x5c,
-	0x18, 0xb5, 0xd1, 0xee, 0xb8, 0x6d, 0x17, 0x61, 0xf4, 0x0c, 0x9e, 0x5c, 0xfb, 0x8c, 0x38, 0x82,
-	0xb2, 0xb5, 0xb2, 0xab, 0x2b, 0xbb, 0x3c, 0x28, 0x9b, 0x17, 0x51, 0x26, 0x94, 0xc1, 0xae, 0x32,
-	0xd8, 0xc8, 0xe8, 0x13, 0x68, 0x06, 0xd4, 0xc1, 0x01, 0x51, 0x05, 0xee, 0x4c, 0x3b, 0x13, 0xdf,
-	0x0d, 0x26, 0xaf, 0x15, 0x64, 0x6b, 0x15, 0x3a, 0x83, 0xb6, 0x17, 0xbd, 0x23, 0x8c, 0xfb, 0x34,
-	0xd4, 0xe5, 0xde, 0x02, 0x32, 0xe7, 0x5b, 0xca, 0x1c, 0xe2, 0x1a, 0x4d, 0xd5, 0x3a, 0x2d, 0x59,
-	0x57, 0x30, 0x28, 0x25, 0x28, 0x6b, 0xf7, 0x19, 0xb4, 0x56, 0x84, 0x73, 0xec, 0x11, 0xae, 0xf2,
-	0xea, 0x4c, 0x0f, 0xf5, 0xa5, 0xde, 0x9b, 0x04, 0xb7, 0x37, 0x06, 0xd6, 0xbf, 0x75, 0x40, 0x6f,
-	0xf0, 0x1d, 0x29, 0xd0, 0xe8, 0x1c, 0xf6, 0x79, 0x82, 0xa8, 0x06, 0x74, 0xa6, 0x5d, 0xe5, 0x22,
-	0xb5, 0x4a, 0x95, 0x99, 0xf4, 0xea, 0x8f, 0xa7, 0x67, 0x42, 0xeb, 0x26, 0x74, 0xa8, 0xeb, 0x87,
-	0x9e, 0xea, 0x50, 0xdb, 0xde, 0xc8, 0xe8, 0x1a, 0xda, 0x73, 0xe2, 0x5d, 0xd1, 0xf0, 0xd6, 0xf7,
-	0x8c, 0x3d, 0x15, 0xed, 0xb9, 0xf2, 0x51, 0x0e, 0x6a, 0xb2, 0x31, 0xbc, 0x09, 0x05, 0x5b, 0xdb,
-	0xdb, 0x83, 0xe8, 0x53, 0xe8, 0x39, 0x94, 0x32, 0xd7, 0x0f, 0xb1, 0xa0, 0x4c, 0x76, 0x50, 0xd2,
-	0x56, 0x76, 0xa2, 0x84, 0x23, 0x0b, 0xba, 0xcb, 0x05, 0x4e, 0xc7, 0x89, 0xeb, 0xa2, 0xe6, 0x30,
-	0xd9, 0x77, 0x39, 0x36, 0x57, 0x4b, 0xe2, 0xdc, 0xf1, 0x78, 0xc5, 0x8d, 0x7d, 0x65, 0x94, 0x07,
-	0xcd, 0x17, 0x70, 0x90, 0x0f, 0x49, 0xd2, 0xf0, 0x8e, 0xac, 0x35, 0x67, 0xe5, 0x27, 0x1a, 0x40,
-	0xe3, 0x1e, 0x07, 0x71, 0xca, 0xd7, 0x44, 0x78, 0x5e, 0xff, 0xb6, 0x26, 0x47, 0x26, 0x97, 0xa3,
-	0x1c, 0x10, 0x13, 0x8c, 0x97, 0x44, 0xfc, 0x14, 0x0a, 0xc2, 0x6e, 0xb1, 0x43, 0x54, 0xc0, 0xe9,
-	0x98, 0x7c, 0x05, 0xa7, 0x15, 0x3a, 0x1e, 0xd1, 0x90, 0x13, 0x79, 0x0d, 0x56, 0x59, 0x27, 0x44,
-	0x4e, 0x04, 0x6b, 0x09, 0xc3, 0xdf, 0x22, 0xc9, 0x8f, 0x99, 0xf7, 0x6a, 0x81, 0x65, 0xa0, 0x69,
-	0x7f, 0x87, 0xd0, 0x8c, 0x3c, 0x99, 0x4d, 0x3a, 0x5f, 0x89, 0xb4, 0xf5, 0x53, 0xcf, 0xf8, 0x41,
-	0x23, 0xe8, 0x30, 0x12, 0x05, 0xbe, 0x83, 0xe5, 0x0a, 0x50, 0x3d, 0x6c, 0xd9, 0x59, 0xc8, 0x3a,
-	0x85, 0x93, 0xd2, 0x4d, 0x49, 0x68, 0xd6, 0x3f, 0x35, 0xe8, 0xa7, 0xba, 0xff, 0x13, 0xc2, 0x0b,
-	0x68, 0x46, 0x98, 0xe1, 0x55, 0x12, 0x43, 0x67, 0xfa, 0x4c, 0xd1, 0xa1, 0xc2, 0xc3, 0x64, 0xa6,
-	0xcc, 0x12, 0x32, 0xe8, 0x33, 0x72, 0x94, 0xe8, 0x3d, 0x61, 0x0f, 0xcc, 0x17, 0x44, 0x07, 0xba,
-	0x05, 0xcc, 0xef, 0xa0, 0x93, 0x39, 0xf4, 0x41, 0xed, 0x3a, 0x81, 0xe3, 0x7c, 0x0c, 0x3c, 0xa2,
-	0x2a, 0xbf, 0xf7, 0x75, 0xe8, 0xcf, 0xbc, 0x4b, 0xcc, 0xc9, 0x02, 0x3b, 0x77, 0x71, 0x94, 0xe6,
-	0x77, 0x06, 0x6d, 0x81, 0x99, 0x47, 0xc4, 0x76, 0x17, 0x6f, 0x01, 0xf4, 0x14, 0x80, 0xd3, 0x98,
-	0x39, 0x6a, 0x74, 0xf5, 0x6d, 0x19, 0x64, 0xab, 0x9f, 0x51, 0x26, 0x54, 0x22, 0x0d, 0x3b, 0x83,
-	0x48, 0xbd, 0xc3, 0x08, 0x16, 0x64, 0x1e, 0xd0, 0x64, 0x79, 0xb7, 0xec, 0x0c, 0x82, 0xce, 0xe1,
-	0x40, 0xad, 0x89, 0x5f, 0x37, 0xc5, 0x68, 0x28, 0x9b, 0x02, 0x2a, 0xfd, 0xe8, 0xa0, 0x16, 0x7e,
-	0xb2, 0x60, 0x1a, 0x76, 0x06, 0x41, 0x9f, 0xc3, 0x91, 0x32, 0xb4, 0x89, 0x23, 0xcb, 0xb8, 0x96,
-	0xb9, 0xeb, 0x69, 0x28, 0x2b, 0xd0, 0x97, 0xd0, 0xcf, 0xb0, 0x42, 0x06, 0x22, 0xe7, 0xc9, 0x68,
-	0xa9, 0xf4, 0xaa, 0x54, 0x72, 0x1a, 0xc9, 0x5f, 0x4e, 0x10, 0xbb, 0x64, 0x86, 0xc5, 0x92, 0x1b,
-	0x6d, 0xc5, 0xbb, 0x1c, 0x66, 0x0d, 0x61, 0x90, 0x2f, 0xb0, 0x66, 0xd6, 0xf7, 0x30, 0xb4, 0xc9,
-	0x8a, 0xde, 0x93, 0xcd, 0x3a, 0x4e, 0x6b, 0xaf, 0xe7, 0x77, 0x83, 0xeb, 0xfa, 0xe7, 0x41, 0xe9,
-	0xb7, 0x74, 0x3e, 0x0a, 0xd6, 0xd3, 0xbf, 0x9b, 0xd0, 0x50, 0x6f, 0x11, 0xfa, 0x06, 0xf6, 0xe4,
-	0x13, 0x86, 0x8e, 0x93, 0xed, 0x57, 0x78, 0xe1, 0xcc, 0x7e, 0x11, 0x96, 0x33, 0xbc, 0x83, 0x9e,
-	0x43, 0x33, 0x79, 0xd0, 0xd0, 0x89, 0x36, 0x28, 0xbe, 0x79, 0xe6, 0x71, 0x59, 0x91, 0x9c, 0xfd,
-	0x01, 0x3a, 0x99, 0xad, 0xa0, 0x1d, 0x94, 0x77, 0xa1, 0x76, 0x50, 0x5a, 0x20, 0x3b, 0xe8, 0x12,
-	0xba, 0xd9, 0xe7, 0x19, 0x19, 0xe9, 0x4d, 0xc5, 0x5f, 0x05, 0x73, 0x58, 0xa1, 0x49, 0x7c, 0xfc,
-	0x0c, 0x87, 0x85, 0x97, 0x05, 0x7d, 0xa4, 0x8c, 0xab, 0x1f, 0x54, 0xf3, 0xb4, 0x5a, 0x99, 0x38,
-	0x7b, 0x0b, 0x47, 0xa5, 0xbd, 0x85, 0x3e, 0x56, 0x27, 0x1e, 0xdb, 0x75, 0xe6, 0xd3, 0xc7, 0xd4,
-	0xba, 0xf3, 0x3b, 0xe8, 0x77, 0x30, 0x0a, 0x0b, 0xe7, 0x22, 0x74, 0x6d, 0x12, 0x50, 0xec, 0xea,
-	0x58, 0xab, 0x37, 0x9f, 0x79, 0x56, 0xad, 0xdc, 0x38, 0xfe, 0x11, 0xba, 0xd9, 0x39, 0xd7, 0xf5,
-	0xab, 0x58, 0x3f, 0xa6, 0x59, 0xa1, 0x49, 0x97, 0xc2, 0x0e, 0xba, 0x81, 0x6e, 0x96, 0xb4, 0xda,
-	0x4f, 0xc5, 0xa2, 0xd0, 0xd5, 0xab, 0x64, 0xb8, 0xe2, 0x43, 0xe6, 0xe7, 0x4f, 0xf3, 0xa1, 0xfc,
-	0x3b, 0xa8, 0xf9, 0x50, 0xfc, 0xaf, 0x4c, 0x7a, 0x59, 0x20, 0xb9, 0xae, 0x4f, 0xf5, 0xe8, 0xe8,
-	0x68, 0xaa, 0xe6, 0xc2, 0xda, 0xb9, 0x6c, 0xfd, 0xd1, 0x9c, 0x4c, 0xbe, 0xf0, 0xdd, 0x60, 0xd1,
-	0x54, 0xff, 0xb2, 0x5f, 0xff, 0x17, 0x00, 0x00, 0xff, 0xff, 0x5b, 0x01, 0xff, 0x55, 0xea, 0x0a,
-	0x00, 0x00,
+	// 1567 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x58, 0xdd, 0x6e, 0xdb, 0x46,
+	0x16, 0xb6, 0x2c, 0x5b, 0x96, 0x8e, 0x14, 0xd9, 0x1e, 0x5b, 0x36, 0xcd, 0x38, 0x59, 0x83, 0x1b,
+	0x18, 0xde, 0x1f, 0x68, 0xb3, 0xde, 0x0d, 0xd0, 0xa4, 0x41, 0x83, 0xd8, 0x71, 0xec, 0xa2, 0x49,
+	0x2a, 0xd0, 0x69, 0x0a, 0xb4, 0x17, 0xc5, 0x98, 0x9c, 0x48, 0x84, 0x29, 0x0e, 0x3b, 0x1c, 0xda,
+	0x55, 0xaf, 0xfa, 0x06, 0xbd, 0xeb, 0x7d, 0xdf, 0xa5, 0xef, 0xd1, 0xc7, 0x68, 0x2f, 0x8b, 0x33,
+	0x33, 0xa4, 0x48, 0x89, 0x0e, 0x5a, 0xa0, 0xe9, 0x1d, 0xcf, 0xcf, 0x9c, 0xf9, 0xce, 0x99, 0xf3,
+	0x33, 0x43, 0x68, 0xd3, 0x21, 0x8b, 0x64, 0x3f, 0x16, 0x5c, 0x72, 0x52, 0x0f, 0xfc, 0xd0, 0x6e,
+	0x8d, 0xd2, 0x0b, 0x4d, 0x3b, 0x7d, 0x58, 0x3b, 0x65, 0xf2, 0x8c, 0x27, 0xf2, 0x15, 0x1d, 0x33,
+	0x97, 0xc5, 0xe1, 0x84, 0xd8, 0xd0, 0x1c, 0xf1, 0x44, 0x46, 0x74, 0xcc, 0xac, 0xda, 0x5e, 0xed,
+	0xa0, 0xe5, 0xe6, 0xb4, 0xb3, 0x09, 0xa4, 0xa4, 0xff, 0x75, 0xca, 0x12, 0xe9, 0x5c, 0xc3, 0xc6,
+	0xb9, 0xa4, 0x42, 0x9e, 0xb3, 0xe1, 0x98, 0x45, 0xd2, 0xb0, 0x89, 0x05, 0x2b, 0x3e, 0x95, 0xf4,
+	0x59, 0x20, 0x8c, 0x9d, 0x8c, 0x24, 0x04, 0x96, 0xae, 0x69, 0x20, 0xad, 0xc5, 0xbd, 0xda, 0x41,
+	0xd3, 0x55, 0xdf, 0xa8, 0x2d, 0x83, 0x31, 0xe3, 0xa9, 0xb4, 0x96, 0xf6, 0x6a, 0x07, 0xcb, 0x6e,
+	0x46, 0xa2, 0x84, 0xc7, 0x32, 0xe0, 0x51, 0x62, 0x2d, 0x6b, 0x3b, 0x86, 0x74, 0x36, 0x60, 0xbd,
+	0xbc, 0x71, 0x1c, 0x4e, 0x9c, 0x18, 0xc8, 0xb9, 0xe4, 0xf1, 0x9f, 0x05, 0xa6, 0x5e, 0x06, 0x43,
+	0x60, 0x69, 0xcc, 0x7d, 0xa6, 0x30, 0xb6, 0x5c, 0xf5, 0xed, 0x10, 0x58, 0x2b, 0xed, 0x88, 0x28,
+	0x1e, 0xc0, 0xf6, 0x29, 0xcb, 0x80, 0x9d, 0x4b, 0x2a, 0xd3, 0x24, 0x83, 0x62, 0x43, 0xd3, 0xec,
+	0x9d, 0x58, 0xb5, 0xbd, 0x3a, 0x06, 0x38, 0xa3, 0x9d, 0x1f, 0x6a, 0xb0, 0x69, 0x16, 0x0d, 0x04,
+	0xf7, 0x58, 0x92, 0xe8, 0xb5, 0xef, 0xc0, 0x6f, 0xc1, 0x8a, 0x48, 0xa3, 0x28, 0x88, 0x86, 0xc6,
+	0x85, 0x8c, 0x24, 0x6b, 0x50, 0x8f, 0x03, 0xdf, 0x78, 0x80, 0x9f, 0xc4, 0x81, 0x8e, 0x17, 0xa6,
+	0x89, 0x64, 0x02, 0xcd, 0x66, 0x5e, 0x94, 0x78, 0x64, 0x13, 0x96, 0x99, 0x10, 0x5c, 0x98, 0x60,
+	0x6b, 0xc2, 0x79, 0x05, 0xbd, 0x79, 0x7f, 0x30, 0x5d, 0x1e, 0x40, 0x33, 0x51, 0x24, 0xd3, 0xde,
+	0xb4, 0x0f, 0x77, 0xfa, 0x81, 0x1f, 0xf6, 0xab, 0xbc, 0x70, 0x73, 0xd5, 0x2c, 0x66, 0x4f, 0x87,
+	0xd3, 0x33, 0x72, 0xd6, 0xa0, 0x5b, 0xe0, 0x61, 0x14, 0x37, 0xf1, 0x2c, 0x71, 0x45, 0x49, 0xef,
+	0x35, 0xac, 0x95, 0xb8, 0x08, 0x63, 0x0b, 0x1a, 0xda, 0xb6, 0x09, 0x8f, 0xa1, 0x90, 0x9f, 0xc6,
+	0x78, 0x78, 0x2a, 0x38, 0x2d, 0xd7, 0x50, 0xc5, 0xd8, 0xdc, 0x52, 0xb1, 0x71, 0x7e, 0xa9, 0xc1,
+	0xd6, 0x1b, 0x1a, 0x06, 0x3e, 0x95, 0x0c, 0x33, 0xfc, 0x24, 0xba, 0xca, 0x4e, 0xec, 0x00, 0x56,
+	0xb1, 0x04, 0x9e, 0xfa, 0xbe, 0x60, 0x49, 0xf2, 0x22, 0x48, 0xa4, 0x39, 0xb8, 0x59, 0x36, 0xb9,
+	0x07, 0xb7, 0x9e, 0x05, 0x82, 0x79, 0x92, 0x8b, 0x89, 0xd2, 0x5b, 0x54, 0x7a, 0x65, 0x26, 0x66,
+	0x40, 0xcc, 0x85, 0x54, 0x0a, 0x75, 0x9d, 0x01, 0x19, 0x4d, 0xfe, 0x0e, 0x8d, 0x90, 0x7b, 0x34,
+	0xd4, 0x87, 0xd3, 0x3e, 0x6c, 0xab, 0x68, 0xbe, 0x50, 0x2c, 0xd7, 0x88, 0xc8, 0x2e, 0xb4, 0x86,
+	0xf1, 0x1b, 0x26, 0x92, 0x80, 0x47, 0xe6, 0x9c, 0xa6, 0x0c, 0xf4, 0xf9, 0x2d, 0x17, 0x1e, 0xf3,
+	0xad, 0x86, 0x4a, 0x08, 0x43, 0x21, 0xdf, 0x17, 0x13, 0x37, 0x8d, 0xac, 0x15, 0xcd, 0xd7, 0x94,
+	0x73, 0x0c, 0x9b, 0x73, 0x8e, 0x63, 0x4c, 0xff, 0x05, 0xcd, 0x31, 0x4b, 0x12, 0x3a, 0xcc, 0x8f,
+	0x76, 0xd5, 0x80, 0x19, 0xbe, 0xd4, 0x7c, 0x37, 0x57, 0x70, 0x7e, 0xac, 0x03, 0x79, 0x49, 0x2f,
+	0xd9, 0x4c, 0xdd, 0xed, 0xc3, 0x4a, 0xa2, 0x39, 0xea, 0x60, 0xda, 0x87, 0x9d, 0x62, 0x76, 0xb8,
+	0x99, 0xb0, 0xe0, 0xf6, 0xe2, 0xcd, 0x6e, 0xdb, 0xd0, 0x3c, 0x89, 0x3c, 0xee, 0x63, 0xae, 0xd7,
+	0x75, 0x6b, 0xca, 0x68, 0xf2, 0x0c, 0x5a, 0xe7, 0x6c, 0x78, 0xcc, 0xa3, 0xb7, 0xc1, 0xd0, 0x5a,
+	0x52, 0x68, 0xf7, 0x95, 0x8d, 0x79, 0x50, 0xfd, 0x5c, 0xf1, 0x24, 0x92, 0x62, 0xe2, 0x4e, 0x17,
+	0x92, 0x7f, 0xc2, 0x9a, 0xc7, 0xb9, 0xf0, 0x83, 0x88, 0x4a, 0x2e, 0xf0, 0x64, 0xb1, 0xe9, 0xe0,
+	0x09, 0xcd, 0xf1, 0xb1, 0x98, 0x46, 0x17, 0x34, 0x6b, 0x86, 0x89, 0x09, 0x76, 0x89, 0x87, 0xf9,
+	0x80, 0x75, 0x7a, 0x3c, 0x62, 0xde, 0x65, 0x92, 0x8e, 0x13, 0x13, 0xf9, 0x32, 0x93, 0x1c, 0x40,
+	0x53, 0xb2, 0x71, 0x1c, 0x62, 0x49, 0x36, 0x2b, 0xa2, 0x94, 0x4b, 0xed, 0xc7, 0xd0, 0x2d, 0x83,
+	0xc7, 0x44, 0xbe, 0x64, 0x13, 0x93, 0xf5, 0xf8, 0x89, 0x05, 0x7c, 0x45, 0xc3, 0x34, 0xcb, 0x78,
+	0x4d, 0x3c, 0x5a, 0xfc, 0xa0, 0x86, 0x45, 0x57, 0x8a, 0x06, 0x96, 0x98, 0x0d, 0xd6, 0x29, 0x93,
+	0x1f, 0x47, 0x92, 0x89, 0xb7, 0xd4, 0x63, 0xca, 0xb5, 0xac, 0xd0, 0xfe, 0x0b, 0x3b, 0x15, 0xb2,
+	0x24, 0xe6, 0x51, 0xa2, 0xfa, 0x04, 0x55, 0xf1, 0xd1, 0xa5, 0xa0, 0x09, 0xe7, 0xbb, 0x1a, 0x6c,
+	0x7d, 0x16, 0x63, 0x2a, 0x0d, 0x86, 0x67, 0x17, 0x14, 0x91, 0x66, 0xa9, 0xb0, 0x05, 0x8d, 0x78,
+	0x88, 0x8e, 0x67, 0x25, 0xaa, 0xa9, 0xa9, 0xa1, 0xc5, 0x82, 0x21, 0xb2, 0x07, 0x6d, 0xc1, 0xe2,
+	0x30, 0xf0, 0x28, 0xf6, 0x7a, 0x75, 0xdc, 0x4d, 0xb7, 0xc8, 0x42, 0x7b, 0x82, 0x8d, 0xf9, 0x95,
+	0xae, 0x94, 0xa6, 0x6b, 0x28, 0x67, 0x07, 0xb6, 0xe7, 0x10, 0x68, 0xcc, 0xce, 0x4f, 0x35, 0xd8,
+	0xc8, 0x64, 0xbf, 0x07, 0xda, 0x63, 0x68, 0xc4, 0x54, 0xd0, 0xb1, 0xc6, 0xd6, 0x3e, 0xbc, 0xa7,
+	0x8e, 0xa5, 0xc2, 0x42, 0x7f, 0xa0, 0xd4, 0x74, 0x3e, 0x99, 0x35, 0x58, 0xa5, 0xfc, 0x8a, 0x89,
+	0x6b, 0x11, 0x48, 0x66, 0x1c, 0x98, 0x32, 0xec, 0x87, 0xd0, 0x2e, 0x2c, 0xfa, 0x43, 0xe7, 0xb8,
+	0x0d, 0xbd, 0x32, 0x86, 0x24, 0xe6, 0xca, 0xbf, 0x9f, 0x17, 0x61, 0x63, 0x30, 0x3c, 0xa2, 0x09,
+	0xbb, 0xa0, 0xde, 0x65, 0x1a, 0x67, 0xfe, 0xed, 0x42, 0x4b, 0x52, 0x31, 0x64, 0x72, 0x3a, 0x3f,
+	0xa6, 0x0c, 0x72, 0x17, 0x20, 0xe1, 0xa9, 0xf0, 0x54, 0xf5, 0x9b, 0xdd, 0x0a, 0x9c, 0xa9, 0x7c,
+	0xc0, 0x45, 0x36, 0x10, 0x0b, 0x1c, 0x94, 0x7b, 0x82, 0x51, 0xc9, 0xce, 0x43, 0x2e, 0xcd, 0x61,
+	0x14, 0x38, 0x64, 0x1f, 0xba, 0xaa, 0x03, 0x7d, 0x9a, 0x07, 0x63, 0x59, 0xe9, 0xcc, 0x70, 0xd1,
+	0x8e, 0x01, 0x75, 0x11, 0xe8, 0xde, 0xb5, 0xec, 0x16, 0x38, 0xe4, 0xdf, 0xb0, 0xae, 0x14, 0x5d,
+	0xe6, 0x61, 0x18, 0x27, 0xe8, 0xbb, 0x29, 0xa8, 0x79, 0x01, 0xb9, 0x0f, 0x1b, 0x85, 0x6c, 0x41,
+	0x20, 0x58, 0x92, 0xaa, 0xbe, 0x5a, 0x6e, 0x95, 0x08, 0x0b, 0x9a, 0x7d, 0xe3, 0x85, 0xa9, 0xcf,
+	0x06, 0x54, 0x8e, 0x12, 0xab, 0xa5, 0xf2, 0xb1, 0xc4, 0x73, 0xb6, 0x60, 0xb3, 0x1c, 0x60, 0x93,
+	0x59, 0xdf, 0xd7, 0x60, 0x75, 0x30, 0x74, 0xd9, 0x75, 0x10, 0xf9, 0x7f, 0x59, 0xd4, 0x0b, 0xd1,
+	0x5a, 0x9a, 0x8d, 0x16, 0x16, 0xfb, 0x14, 0x90, 0x41, 0xf9, 0x11, 0x6c, 0xb9, 0xaa, 0x48, 0xf2,
+	0x79, 0x94, 0x61, 0x35, 0x8d, 0x2a, 0xe7, 0x1b, 0xbc, 0x65, 0x26, 0x7a, 0x3f, 0xb7, 0x1e, 0x9b,
+	0xc8, 0x57, 0xd0, 0x1b, 0x08, 0x3e, 0xe6, 0x92, 0xbd, 0x9f, 0x6b, 0x97, 0xd3, 0x83, 0x8d, 0xd9,
+	0x0d, 0x70, 0xdf, 0x33, 0x80, 0xe7, 0x41, 0xc8, 0xce, 0x18, 0xf5, 0x99, 0x32, 0x19, 0x53, 0x39,
+	0x32, 0x3b, 0xa9, 0xef, 0xfc, 0xbe, 0xb6, 0xa8, 0x06, 0xbd, 0xfa, 0x46, 0x5e, 0x12, 0x7c, 0xab,
+	0x4b, 0xb2, 0xee, 0xaa, 0x6f, 0xe7, 0x43, 0x68, 0xa1, 0xa5, 0xe3, 0x51, 0x1a, 0x5d, 0xa2, 0x42,
+	0xde, 0x0c, 0x3a, 0xae, 0xfa, 0xc6, 0xd9, 0xe3, 0x99, 0x86, 0x6d, 0x8c, 0xe5, 0xb4, 0x13, 0x41,
+	0x77, 0x90, 0x4a, 0x5c, 0x9f, 0xf9, 0xfd, 0x0f, 0x68, 0x8c, 0x14, 0x28, 0x33, 0xf5, 0xf4, 0xe0,
+	0x9c, 0x62, 0x3d, 0x5b, 0x70, 0x8d, 0x02, 0xd9, 0x87, 0x65, 0x0f, 0x77, 0x35, 0x83, 0xaf, 0x9b,
+	0x6b, 0x2a, 0x2c, 0x67, 0x0b, 0xae, 0x16, 0x1f, 0xb5, 0x60, 0xc5, 0xe3, 0x91, 0x64, 0x91, 0x74,
+	0xba, 0xd0, 0xc9, 0xf7, 0xc3, 0x30, 0xdc, 0x83, 0xee, 0x29, 0x2b, 0xed, 0x5f, 0x11, 0x0a, 0x27,
+	0x84, 0x4e, 0xae, 0x85, 0xe3, 0xfd, 0xfd, 0x62, 0xfc, 0x1b, 0xdc, 0x71, 0x59, 0xc8, 0xa9, 0xaf,
+	0x2e, 0x69, 0xc7, 0x82, 0xf9, 0x2c, 0x92, 0x01, 0x0d, 0xf3, 0xe1, 0x72, 0x07, 0x6e, 0xdf, 0xa4,
+	0x10, 0x87, 0x93, 0xc3, 0x5f, 0x5b, 0xb0, 0xac, 0x24, 0xe4, 0xff, 0xb0, 0x84, 0xd7, 0x42, 0xd2,
+	0xd3, 0x33, 0x71, 0xe6, 0xd6, 0x68, 0x6f, 0xcc, 0xb2, 0x31, 0x22, 0x0b, 0xe4, 0x11, 0x34, 0xcc,
+	0xd5, 0x79, 0xdb, 0x28, 0xcc, 0xde, 0x23, 0xed, 0xde, 0xbc, 0x40, 0xaf, 0x7d, 0x02, 0xed, 0xc2,
+	0x9c, 0x34, 0x06, 0xe6, 0xef, 0x11, 0x76, 0x6f, 0x5e, 0xa0, 0x0d, 0x1c, 0x41, 0xa7, 0xf8, 0x30,
+	0x21, 0x56, 0xb6, 0xd3, 0xec, 0x23, 0xc9, 0xde, 0xaa, 0x90, 0xe4, 0x20, 0x0a, 0xaf, 0x8a, 0xdc,
+	0x0b, 0x1e, 0x57, 0x82, 0x98, 0x7b, 0x80, 0x2c, 0x90, 0x57, 0xea, 0x71, 0x57, 0xba, 0xb2, 0x93,
+	0x5d, 0xa5, 0x7c, 0xc3, 0xcb, 0xc4, 0xb6, 0x6f, 0x90, 0x6a, 0x7b, 0x9f, 0xc0, 0xea, 0xcc, 0x35,
+	0x91, 0xdc, 0x56, 0x0b, 0xaa, 0x6f, 0xcd, 0xf6, 0x4e, 0xb5, 0x50, 0x1b, 0x7b, 0x0d, 0xeb, 0x73,
+	0x57, 0x0b, 0x72, 0x27, 0xdb, 0xbf, 0xf2, 0x3a, 0x62, 0xdf, 0xbd, 0x49, 0x6c, 0xba, 0xdb, 0x02,
+	0xf9, 0x1c, 0xac, 0x99, 0xd1, 0xff, 0x34, 0xf2, 0x75, 0x96, 0x19, 0xac, 0xd5, 0x77, 0x13, 0x7b,
+	0xb7, 0x5a, 0x98, 0x1b, 0x7e, 0x0e, 0x9d, 0xe2, 0xc4, 0x35, 0x07, 0x5a, 0x71, 0x11, 0xb0, 0xed,
+	0x0a, 0x49, 0x36, 0x9e, 0x17, 0xc8, 0x09, 0x74, 0x8a, 0xe3, 0xc3, 0xd8, 0xa9, 0x18, 0xd9, 0xf6,
+	0x4e, 0x85, 0x24, 0x87, 0xf3, 0x10, 0x9a, 0x59, 0x6f, 0x27, 0x9b, 0x46, 0xb1, 0x34, 0x7b, 0xec,
+	0xde, 0x0c, 0x37, 0x5f, 0xfa, 0x04, 0xda, 0x85, 0x27, 0xbc, 0x49, 0xab, 0xf9, 0x47, 0xbd, 0xdd,
+	0x9b, 0x17, 0xe4, 0x69, 0x30, 0x33, 0x03, 0x4c, 0x68, 0xab, 0x27, 0x8b, 0xbd, 0x53, 0x2d, 0xd4,
+	0xc6, 0xce, 0xa0, 0x5b, 0xee, 0xeb, 0x44, 0xc7, 0xaf, 0x72, 0x9a, 0xd8, 0x56, 0xa5, 0x4c, 0x5b,
+	0x7a, 0x00, 0x2b, 0xa6, 0x27, 0x12, 0xdd, 0x11, 0xca, 0x1d, 0xd9, 0x5e, 0x2f, 0x33, 0xd5, 0xa2,
+	0x83, 0x1a, 0x2e, 0x3b, 0x65, 0xc5, 0x65, 0xa7, 0xac, 0x62, 0x59, 0xb1, 0x6f, 0x3a, 0x0b, 0xf7,
+	0x6b, 0xe4, 0x4b, 0x58, 0xd7, 0x69, 0x55, 0xe8, 0x5b, 0xc4, 0x31, 0x9e, 0xbe, 0xa3, 0xeb, 0xd9,
+	0x7b, 0xef, 0xd4, 0x51, 0xe6, 0x8f, 0x9a, 0x5f, 0x34, 0xfa, 0xfd, 0xff, 0x04, 0x7e, 0x78, 0xd1,
+	0x50, 0xbf, 0x69, 0xfe, 0xf7, 0xdb, 0x00, 0x68, 0x19, 0x0b, 0x7e, 0xc5, 0x11, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -1137,13 +2026,20 @@ type AgentClient interface {
 	Status(ctx context.Context, in *StatusAgentRequest, opts ...grpc.CallOption) (*StatusAgentReply, error)
 	MakeSegment(ctx context.Context, in *MakeSegmentRequest, opts ...grpc.CallOption) (*MakeSegmentReply, error)
 	StartSegment(ctx context.Context, in *StartSegmentRequest, opts ...grpc.CallOption) (*StartSegmentReply, error)
+	StopSegment(ctx context.Context, in *StopSegmentRequest, opts ...grpc.CallOption) (*StopSegmentReply, error)
+	GetSegmentStatus(ctx context.Context, in *GetSegmentStatusRequest, opts ...grpc.CallOption) (*GetSegmentStatusReply, error)
 	ValidateHostEnv(ctx context.Context, in *ValidateHostEnvRequest, opts ...grpc.CallOption) (*ValidateHostEnvReply, error)
 	GetInterfaceAddrs(ctx context.Context, in *GetInterfaceAddrsRequest, opts ...grpc.CallOption) (*GetInterfaceAddrsResponse, error)
 	UpdatePgHbaConfAndReload(ctx context.Context, in *UpdatePgHbaConfRequest, opts ...grpc.CallOption) (*UpdatePgHbaConfResponse, error)
 	UpdatePgConf(ctx context.Context, in *UpdatePgConfRequest, opts ...grpc.CallOption) (*UpdatePgConfRespoonse, error)
 	PgBasebackup(ctx context.Context, in *PgBasebackupRequest, opts ...grpc.CallOption) (*PgBasebackupResponse, error)
+	PgRewind(ctx context.Context, in *PgRewindRequest, opts ...grpc.CallOption) (*PgRewindResponse, error)
 	GetHostName(ctx context.Context, in *GetHostNameRequest, opts ...grpc.CallOption) (*GetHostNameReply, error)
 	RemoveDirectory(ctx context.Context, in *RemoveDirectoryRequest, opts ...grpc.CallOption) (*RemoveDirectoryReply, error)
+	PromoteSegment(ctx context.Context, in *PromoteSegmentRequest, opts ...grpc.CallOption) (*PromoteSegmentReply, error)
+	PutFile(ctx context.Context, opts ...grpc.CallOption) (Agent_PutFileClient, error)
+	GetFile(ctx context.Context, in *GetFileRequest, opts ...grpc.CallOption) (Agent_GetFileClient, error)
+	ReloadCredentials(ctx context.Context, in *ReloadAgentCredentialsRequest, opts ...grpc.CallOption) (*ReloadAgentCredentialsReply, error)
 }
 
 type agentClient struct {
@@ -1190,6 +2086,24 @@ func (c *agentClient) StartSegment(ctx context.Context, in *StartSegmentRequest,
 	return out, nil
 }
 
+func (c *agentClient) StopSegment(ctx context.Context, in *StopSegmentRequest, opts ...grpc.CallOption) (*StopSegmentReply, error) {
+	out := new(StopSegmentReply)
+	err := c.cc.Invoke(ctx, "/idl.Agent/StopSegment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) GetSegmentStatus(ctx context.Context, in *GetSegmentStatusRequest, opts ...grpc.CallOption) (*GetSegmentStatusReply, error) {
+	out := new(GetSegmentStatusReply)
+	err := c.cc.Invoke(ctx, "/idl.Agent/GetSegmentStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *agentClient) ValidateHostEnv(ctx context.Context, in *ValidateHostEnvRequest, opts ...grpc.CallOption) (*ValidateHostEnvReply, error) {
 	out := new(ValidateHostEnvReply)
 	err := c.cc.Invoke(ctx, "/idl.Agent/ValidateHostEnv", in, out, opts...)
@@ -1235,6 +2149,15 @@ func (c *agentClient) PgBasebackup(ctx context.Context, in *PgBasebackupRequest,
 	return out, nil
 }
 
+func (c *agentClient) PgRewind(ctx context.Context, in *PgRewindRequest, opts ...grpc.CallOption) (*PgRewindResponse, error) {
+	out := new(PgRewindResponse)
+	err := c.cc.Invoke(ctx, "/idl.Agent/PgRewind", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *agentClient) GetHostName(ctx context.Context, in *GetHostNameRequest, opts ...grpc.CallOption) (*GetHostNameReply, error) {
 	out := new(GetHostNameReply)
 	err := c.cc.Invoke(ctx, "/idl.Agent/GetHostName", in, out, opts...)
@@ -1253,19 +2176,110 @@ func (c *agentClient) RemoveDirectory(ctx context.Context, in *RemoveDirectoryRe
 	return out, nil
 }
 
+func (c *agentClient) PromoteSegment(ctx context.Context, in *PromoteSegmentRequest, opts ...grpc.CallOption) (*PromoteSegmentReply, error) {
+	out := new(PromoteSegmentReply)
+	err := c.cc.Invoke(ctx, "/idl.Agent/PromoteSegment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentClient) PutFile(ctx context.Context, opts ...grpc.CallOption) (Agent_PutFileClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Agent_serviceDesc.Streams[0], "/idl.Agent/PutFile", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &agentPutFileClient{stream}
+	return x, nil
+}
+
+type Agent_PutFileClient interface {
+	Send(*PutFileRequest) error
+	CloseAndRecv() (*PutFileReply, error)
+	grpc.ClientStream
+}
+
+type agentPutFileClient struct {
+	grpc.ClientStream
+}
+
+func (x *agentPutFileClient) Send(m *PutFileRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *agentPutFileClient) CloseAndRecv() (*PutFileReply, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(PutFileReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *agentClient) GetFile(ctx context.Context, in *GetFileRequest, opts ...grpc.CallOption) (Agent_GetFileClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Agent_serviceDesc.Streams[1], "/idl.Agent/GetFile", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &agentGetFileClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Agent_GetFileClient interface {
+	Recv() (*GetFileReply, error)
+	grpc.ClientStream
+}
+
+type agentGetFileClient struct {
+	grpc.ClientStream
+}
+
+func (x *agentGetFileClient) Recv() (*GetFileReply, error) {
+	m := new(GetFileReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *agentClient) ReloadCredentials(ctx context.Context, in *ReloadAgentCredentialsRequest, opts ...grpc.CallOption) (*ReloadAgentCredentialsReply, error) {
+	out := new(ReloadAgentCredentialsReply)
+	err := c.cc.Invoke(ctx, "/idl.Agent/ReloadCredentials", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AgentServer is the server API for Agent service.
 type AgentServer interface {
 	Stop(context.Context, *StopAgentRequest) (*StopAgentReply, error)
 	Status(context.Context, *StatusAgentRequest) (*StatusAgentReply, error)
 	MakeSegment(context.Context, *MakeSegmentRequest) (*MakeSegmentReply, error)
 	StartSegment(context.Context, *StartSegmentRequest) (*StartSegmentReply, error)
+	StopSegment(context.Context, *StopSegmentRequest) (*StopSegmentReply, error)
+	GetSegmentStatus(context.Context, *GetSegmentStatusRequest) (*GetSegmentStatusReply, error)
 	ValidateHostEnv(context.Context, *ValidateHostEnvRequest) (*ValidateHostEnvReply, error)
 	GetInterfaceAddrs(context.Context, *GetInterfaceAddrsRequest) (*GetInterfaceAddrsResponse, error)
 	UpdatePgHbaConfAndReload(context.Context, *UpdatePgHbaConfRequest) (*UpdatePgHbaConfResponse, error)
 	UpdatePgConf(context.Context, *UpdatePgConfRequest) (*UpdatePgConfRespoonse, error)
 	PgBasebackup(context.Context, *PgBasebackupRequest) (*PgBasebackupResponse, error)
+	PgRewind(context.Context, *PgRewindRequest) (*PgRewindResponse, error)
 	GetHostName(context.Context, *GetHostNameRequest) (*GetHostNameReply, error)
 	RemoveDirectory(context.Context, *RemoveDirectoryRequest) (*RemoveDirectoryReply, error)
+	PromoteSegment(context.Context, *PromoteSegmentRequest) (*PromoteSegmentReply, error)
+	PutFile(Agent_PutFileServer) error
+	GetFile(*GetFileRequest, Agent_GetFileServer) error
+	ReloadCredentials(context.Context, *ReloadAgentCredentialsRequest) (*ReloadAgentCredentialsReply, error)
 }
 
 // UnimplementedAgentServer can be embedded to have forward compatible implementations.
@@ -1284,6 +2298,12 @@ func (*UnimplementedAgentServer) MakeSegment(ctx context.Context, req *MakeSegme
 func (*UnimplementedAgentServer) StartSegment(ctx context.Context, req *StartSegmentRequest) (*StartSegmentReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method StartSegment not implemented")
 }
+func (*UnimplementedAgentServer) StopSegment(ctx context.Context, req *StopSegmentRequest) (*StopSegmentReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StopSegment not implemented")
+}
+func (*UnimplementedAgentServer) GetSegmentStatus(ctx context.Context, req *GetSegmentStatusRequest) (*GetSegmentStatusReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSegmentStatus not implemented")
+}
 func (*UnimplementedAgentServer) ValidateHostEnv(ctx context.Context, req *ValidateHostEnvRequest) (*ValidateHostEnvReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ValidateHostEnv not implemented")
 }
@@ -1299,12 +2319,27 @@ func (*UnimplementedAgentServer) UpdatePgConf(ctx context.Context, req *UpdatePg
 func (*UnimplementedAgentServer) PgBasebackup(ctx context.Context, req *PgBasebackupRequest) (*PgBasebackupResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PgBasebackup not implemented")
 }
+func (*UnimplementedAgentServer) PgRewind(ctx context.Context, req *PgRewindRequest) (*PgRewindResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PgRewind not implemented")
+}
 func (*UnimplementedAgentServer) GetHostName(ctx context.Context, req *GetHostNameRequest) (*GetHostNameReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetHostName not implemented")
 }
 func (*UnimplementedAgentServer) RemoveDirectory(ctx context.Context, req *RemoveDirectoryRequest) (*RemoveDirectoryReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RemoveDirectory not implemented")
 }
+func (*UnimplementedAgentServer) PromoteSegment(ctx context.Context, req *PromoteSegmentRequest) (*PromoteSegmentReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PromoteSegment not implemented")
+}
+func (*UnimplementedAgentServer) PutFile(srv Agent_PutFileServer) error {
+	return status.Errorf(codes.Unimplemented, "method PutFile not implemented")
+}
+func (*UnimplementedAgentServer) GetFile(req *GetFileRequest, srv Agent_GetFileServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetFile not implemented")
+}
+func (*UnimplementedAgentServer) ReloadCredentials(ctx context.Context, req *ReloadAgentCredentialsRequest) (*ReloadAgentCredentialsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReloadCredentials not implemented")
+}
 
 func RegisterAgentServer(s *grpc.Server, srv AgentServer) {
 	s.RegisterService(&_Agent_serviceDesc, srv)
@@ -1382,6 +2417,42 @@ func _Agent_StartSegment_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Agent_StopSegment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StopSegmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).StopSegment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/idl.Agent/StopSegment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).StopSegment(ctx, req.(*StopSegmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_GetSegmentStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSegmentStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).GetSegmentStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/idl.Agent/GetSegmentStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).GetSegmentStatus(ctx, req.(*GetSegmentStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Agent_ValidateHostEnv_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ValidateHostEnvRequest)
 	if err := dec(in); err != nil {
@@ -1472,6 +2543,24 @@ func _Agent_PgBasebackup_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Agent_PgRewind_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PgRewindRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).PgRewind(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/idl.Agent/PgRewind",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).PgRewind(ctx, req.(*PgRewindRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Agent_GetHostName_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetHostNameRequest)
 	if err := dec(in); err != nil {
@@ -1508,6 +2597,89 @@ func _Agent_RemoveDirectory_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Agent_PromoteSegment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PromoteSegmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).PromoteSegment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/idl.Agent/PromoteSegment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).PromoteSegment(ctx, req.(*PromoteSegmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Agent_PutFile_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(AgentServer).PutFile(&agentPutFileServer{stream})
+}
+
+type Agent_PutFileServer interface {
+	SendAndClose(*PutFileReply) error
+	Recv() (*PutFileRequest, error)
+	grpc.ServerStream
+}
+
+type agentPutFileServer struct {
+	grpc.ServerStream
+}
+
+func (x *agentPutFileServer) SendAndClose(m *PutFileReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *agentPutFileServer) Recv() (*PutFileRequest, error) {
+	m := new(PutFileRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Agent_GetFile_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetFileRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AgentServer).GetFile(m, &agentGetFileServer{stream})
+}
+
+type Agent_GetFileServer interface {
+	Send(*GetFileReply) error
+	grpc.ServerStream
+}
+
+type agentGetFileServer struct {
+	grpc.ServerStream
+}
+
+func (x *agentGetFileServer) Send(m *GetFileReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Agent_ReloadCredentials_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReloadAgentCredentialsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServer).ReloadCredentials(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/idl.Agent/ReloadCredentials",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServer).ReloadCredentials(ctx, req.(*ReloadAgentCredentialsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Agent_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "idl.Agent",
 	HandlerType: (*AgentServer)(nil),
@@ -1528,6 +2700,14 @@ var _Agent_serviceDesc = grpc.ServiceDesc{
 			MethodName: "StartSegment",
 			Handler:    _Agent_StartSegment_Handler,
 		},
+		{
+			MethodName: "StopSegment",
+			Handler:    _Agent_StopSegment_Handler,
+		},
+		{
+			MethodName: "GetSegmentStatus",
+			Handler:    _Agent_GetSegmentStatus_Handler,
+		},
 		{
 			MethodName: "ValidateHostEnv",
 			Handler:    _Agent_ValidateHostEnv_Handler,
@@ -1548,6 +2728,10 @@ var _Agent_serviceDesc = grpc.ServiceDesc{
 			MethodName: "PgBasebackup",
 			Handler:    _Agent_PgBasebackup_Handler,
 		},
+		{
+			MethodName: "PgRewind",
+			Handler:    _Agent_PgRewind_Handler,
+		},
 		{
 			MethodName: "GetHostName",
 			Handler:    _Agent_GetHostName_Handler,
@@ -1556,7 +2740,26 @@ var _Agent_serviceDesc = grpc.ServiceDesc{
 			MethodName: "RemoveDirectory",
 			Handler:    _Agent_RemoveDirectory_Handler,
 		},
+		{
+			MethodName: "PromoteSegment",
+			Handler:    _Agent_PromoteSegment_Handler,
+		},
+		{
+			MethodName: "ReloadCredentials",
+			Handler:    _Agent_ReloadCredentials_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "PutFile",
+			Handler:       _Agent_PutFile_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "GetFile",
+			Handler:       _Agent_GetFile_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "agent.proto",
 }
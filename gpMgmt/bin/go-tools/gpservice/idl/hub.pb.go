@@ -25,6 +25,37 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+type OperationState int32
+
+const (
+	OperationState_RUNNING   OperationState = 0
+	OperationState_SUCCEEDED OperationState = 1
+	OperationState_FAILED    OperationState = 2
+	OperationState_CANCELLED OperationState = 3
+)
+
+var OperationState_name = map[int32]string{
+	0: "RUNNING",
+	1: "SUCCEEDED",
+	2: "FAILED",
+	3: "CANCELLED",
+}
+
+var OperationState_value = map[string]int32{
+	"RUNNING":   0,
+	"SUCCEEDED": 1,
+	"FAILED":    2,
+	"CANCELLED": 3,
+}
+
+func (x OperationState) String() string {
+	return proto.EnumName(OperationState_name, int32(x))
+}
+
+func (OperationState) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{0}
+}
+
 type LogLevel int32
 
 const (
@@ -56,1422 +87,3049 @@ func (x LogLevel) String() string {
 }
 
 func (LogLevel) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_b3103f8d3056b01c, []int{0}
+	return fileDescriptor_b3103f8d3056b01c, []int{1}
 }
 
-type AddMirrorsRequest struct {
-	CoordinatorDataDir   string     `protobuf:"bytes,1,opt,name=CoordinatorDataDir,proto3" json:"CoordinatorDataDir,omitempty"`
-	HbaHostnames         bool       `protobuf:"varint,2,opt,name=HbaHostnames,proto3" json:"HbaHostnames,omitempty"`
-	Mirrors              []*Segment `protobuf:"bytes,3,rep,name=mirrors,proto3" json:"mirrors,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
-	XXX_unrecognized     []byte     `json:"-"`
-	XXX_sizecache        int32      `json:"-"`
+type StartClusterRequest struct {
+	CoordinatorDataDir   string   `protobuf:"bytes,1,opt,name=CoordinatorDataDir,proto3" json:"CoordinatorDataDir,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *AddMirrorsRequest) Reset()         { *m = AddMirrorsRequest{} }
-func (m *AddMirrorsRequest) String() string { return proto.CompactTextString(m) }
-func (*AddMirrorsRequest) ProtoMessage()    {}
-func (*AddMirrorsRequest) Descriptor() ([]byte, []int) {
+func (m *StartClusterRequest) Reset()         { *m = StartClusterRequest{} }
+func (m *StartClusterRequest) String() string { return proto.CompactTextString(m) }
+func (*StartClusterRequest) ProtoMessage()    {}
+func (*StartClusterRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_b3103f8d3056b01c, []int{0}
 }
 
-func (m *AddMirrorsRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_AddMirrorsRequest.Unmarshal(m, b)
+func (m *StartClusterRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StartClusterRequest.Unmarshal(m, b)
 }
-func (m *AddMirrorsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_AddMirrorsRequest.Marshal(b, m, deterministic)
+func (m *StartClusterRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StartClusterRequest.Marshal(b, m, deterministic)
 }
-func (m *AddMirrorsRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_AddMirrorsRequest.Merge(m, src)
+func (m *StartClusterRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StartClusterRequest.Merge(m, src)
 }
-func (m *AddMirrorsRequest) XXX_Size() int {
-	return xxx_messageInfo_AddMirrorsRequest.Size(m)
+func (m *StartClusterRequest) XXX_Size() int {
+	return xxx_messageInfo_StartClusterRequest.Size(m)
 }
-func (m *AddMirrorsRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_AddMirrorsRequest.DiscardUnknown(m)
+func (m *StartClusterRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StartClusterRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_AddMirrorsRequest proto.InternalMessageInfo
+var xxx_messageInfo_StartClusterRequest proto.InternalMessageInfo
 
-func (m *AddMirrorsRequest) GetCoordinatorDataDir() string {
+func (m *StartClusterRequest) GetCoordinatorDataDir() string {
 	if m != nil {
 		return m.CoordinatorDataDir
 	}
 	return ""
 }
 
-func (m *AddMirrorsRequest) GetHbaHostnames() bool {
-	if m != nil {
-		return m.HbaHostnames
-	}
-	return false
-}
-
-func (m *AddMirrorsRequest) GetMirrors() []*Segment {
-	if m != nil {
-		return m.Mirrors
-	}
-	return nil
-}
-
-type GetAllHostNamesRequest struct {
-	HostList             []string `protobuf:"bytes,1,rep,name=hostList,proto3" json:"hostList,omitempty"`
+type StopClusterRequest struct {
+	CoordinatorDataDir   string   `protobuf:"bytes,1,opt,name=CoordinatorDataDir,proto3" json:"CoordinatorDataDir,omitempty"`
+	Mode                 string   `protobuf:"bytes,2,opt,name=Mode,proto3" json:"Mode,omitempty"`
+	Timeout              int32    `protobuf:"varint,3,opt,name=Timeout,proto3" json:"Timeout,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetAllHostNamesRequest) Reset()         { *m = GetAllHostNamesRequest{} }
-func (m *GetAllHostNamesRequest) String() string { return proto.CompactTextString(m) }
-func (*GetAllHostNamesRequest) ProtoMessage()    {}
-func (*GetAllHostNamesRequest) Descriptor() ([]byte, []int) {
+func (m *StopClusterRequest) Reset()         { *m = StopClusterRequest{} }
+func (m *StopClusterRequest) String() string { return proto.CompactTextString(m) }
+func (*StopClusterRequest) ProtoMessage()    {}
+func (*StopClusterRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_b3103f8d3056b01c, []int{1}
 }
 
-func (m *GetAllHostNamesRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetAllHostNamesRequest.Unmarshal(m, b)
+func (m *StopClusterRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StopClusterRequest.Unmarshal(m, b)
 }
-func (m *GetAllHostNamesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetAllHostNamesRequest.Marshal(b, m, deterministic)
+func (m *StopClusterRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StopClusterRequest.Marshal(b, m, deterministic)
 }
-func (m *GetAllHostNamesRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetAllHostNamesRequest.Merge(m, src)
+func (m *StopClusterRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StopClusterRequest.Merge(m, src)
 }
-func (m *GetAllHostNamesRequest) XXX_Size() int {
-	return xxx_messageInfo_GetAllHostNamesRequest.Size(m)
+func (m *StopClusterRequest) XXX_Size() int {
+	return xxx_messageInfo_StopClusterRequest.Size(m)
 }
-func (m *GetAllHostNamesRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetAllHostNamesRequest.DiscardUnknown(m)
+func (m *StopClusterRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StopClusterRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetAllHostNamesRequest proto.InternalMessageInfo
+var xxx_messageInfo_StopClusterRequest proto.InternalMessageInfo
 
-func (m *GetAllHostNamesRequest) GetHostList() []string {
+func (m *StopClusterRequest) GetCoordinatorDataDir() string {
 	if m != nil {
-		return m.HostList
+		return m.CoordinatorDataDir
 	}
-	return nil
+	return ""
 }
 
-type GetAllHostNamesReply struct {
-	HostNameMap          map[string]string `protobuf:"bytes,1,rep,name=hostNameMap,proto3" json:"hostNameMap,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+func (m *StopClusterRequest) GetMode() string {
+	if m != nil {
+		return m.Mode
+	}
+	return ""
 }
 
-func (m *GetAllHostNamesReply) Reset()         { *m = GetAllHostNamesReply{} }
-func (m *GetAllHostNamesReply) String() string { return proto.CompactTextString(m) }
-func (*GetAllHostNamesReply) ProtoMessage()    {}
-func (*GetAllHostNamesReply) Descriptor() ([]byte, []int) {
+func (m *StopClusterRequest) GetTimeout() int32 {
+	if m != nil {
+		return m.Timeout
+	}
+	return 0
+}
+
+type ClusterStatusRequest struct {
+	CoordinatorDataDir   string   `protobuf:"bytes,1,opt,name=CoordinatorDataDir,proto3" json:"CoordinatorDataDir,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ClusterStatusRequest) Reset()         { *m = ClusterStatusRequest{} }
+func (m *ClusterStatusRequest) String() string { return proto.CompactTextString(m) }
+func (*ClusterStatusRequest) ProtoMessage()    {}
+func (*ClusterStatusRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_b3103f8d3056b01c, []int{2}
 }
 
-func (m *GetAllHostNamesReply) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetAllHostNamesReply.Unmarshal(m, b)
+func (m *ClusterStatusRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ClusterStatusRequest.Unmarshal(m, b)
 }
-func (m *GetAllHostNamesReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetAllHostNamesReply.Marshal(b, m, deterministic)
+func (m *ClusterStatusRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ClusterStatusRequest.Marshal(b, m, deterministic)
 }
-func (m *GetAllHostNamesReply) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetAllHostNamesReply.Merge(m, src)
+func (m *ClusterStatusRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ClusterStatusRequest.Merge(m, src)
 }
-func (m *GetAllHostNamesReply) XXX_Size() int {
-	return xxx_messageInfo_GetAllHostNamesReply.Size(m)
+func (m *ClusterStatusRequest) XXX_Size() int {
+	return xxx_messageInfo_ClusterStatusRequest.Size(m)
 }
-func (m *GetAllHostNamesReply) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetAllHostNamesReply.DiscardUnknown(m)
+func (m *ClusterStatusRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ClusterStatusRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetAllHostNamesReply proto.InternalMessageInfo
+var xxx_messageInfo_ClusterStatusRequest proto.InternalMessageInfo
 
-func (m *GetAllHostNamesReply) GetHostNameMap() map[string]string {
+func (m *ClusterStatusRequest) GetCoordinatorDataDir() string {
 	if m != nil {
-		return m.HostNameMap
+		return m.CoordinatorDataDir
 	}
-	return nil
+	return ""
 }
 
-type StopHubRequest struct {
+type SegmentStatus struct {
+	Dbid                 int32    `protobuf:"varint,1,opt,name=dbid,proto3" json:"dbid,omitempty"`
+	Content              int32    `protobuf:"varint,2,opt,name=content,proto3" json:"content,omitempty"`
+	Role                 string   `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
+	PreferredRole        string   `protobuf:"bytes,4,opt,name=preferredRole,proto3" json:"preferredRole,omitempty"`
+	Mode                 string   `protobuf:"bytes,5,opt,name=mode,proto3" json:"mode,omitempty"`
+	Status               string   `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	Hostname             string   `protobuf:"bytes,7,opt,name=hostname,proto3" json:"hostname,omitempty"`
+	Address              string   `protobuf:"bytes,8,opt,name=address,proto3" json:"address,omitempty"`
+	Port                 int32    `protobuf:"varint,9,opt,name=port,proto3" json:"port,omitempty"`
+	DataDir              string   `protobuf:"bytes,10,opt,name=dataDir,proto3" json:"dataDir,omitempty"`
+	Running              bool     `protobuf:"varint,11,opt,name=running,proto3" json:"running,omitempty"`
+	Pid                  int32    `protobuf:"varint,12,opt,name=pid,proto3" json:"pid,omitempty"`
+	ClusterState         string   `protobuf:"bytes,13,opt,name=clusterState,proto3" json:"clusterState,omitempty"`
+	Error                string   `protobuf:"bytes,14,opt,name=error,proto3" json:"error,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *StopHubRequest) Reset()         { *m = StopHubRequest{} }
-func (m *StopHubRequest) String() string { return proto.CompactTextString(m) }
-func (*StopHubRequest) ProtoMessage()    {}
-func (*StopHubRequest) Descriptor() ([]byte, []int) {
+func (m *SegmentStatus) Reset()         { *m = SegmentStatus{} }
+func (m *SegmentStatus) String() string { return proto.CompactTextString(m) }
+func (*SegmentStatus) ProtoMessage()    {}
+func (*SegmentStatus) Descriptor() ([]byte, []int) {
 	return fileDescriptor_b3103f8d3056b01c, []int{3}
 }
 
-func (m *StopHubRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_StopHubRequest.Unmarshal(m, b)
+func (m *SegmentStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SegmentStatus.Unmarshal(m, b)
 }
-func (m *StopHubRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_StopHubRequest.Marshal(b, m, deterministic)
+func (m *SegmentStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SegmentStatus.Marshal(b, m, deterministic)
 }
-func (m *StopHubRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_StopHubRequest.Merge(m, src)
+func (m *SegmentStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SegmentStatus.Merge(m, src)
 }
-func (m *StopHubRequest) XXX_Size() int {
-	return xxx_messageInfo_StopHubRequest.Size(m)
+func (m *SegmentStatus) XXX_Size() int {
+	return xxx_messageInfo_SegmentStatus.Size(m)
 }
-func (m *StopHubRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_StopHubRequest.DiscardUnknown(m)
+func (m *SegmentStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_SegmentStatus.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_StopHubRequest proto.InternalMessageInfo
+var xxx_messageInfo_SegmentStatus proto.InternalMessageInfo
 
-type StopHubReply struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *SegmentStatus) GetDbid() int32 {
+	if m != nil {
+		return m.Dbid
+	}
+	return 0
 }
 
-func (m *StopHubReply) Reset()         { *m = StopHubReply{} }
-func (m *StopHubReply) String() string { return proto.CompactTextString(m) }
-func (*StopHubReply) ProtoMessage()    {}
-func (*StopHubReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b3103f8d3056b01c, []int{4}
+func (m *SegmentStatus) GetContent() int32 {
+	if m != nil {
+		return m.Content
+	}
+	return 0
 }
 
-func (m *StopHubReply) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_StopHubReply.Unmarshal(m, b)
-}
-func (m *StopHubReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_StopHubReply.Marshal(b, m, deterministic)
-}
-func (m *StopHubReply) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_StopHubReply.Merge(m, src)
+func (m *SegmentStatus) GetRole() string {
+	if m != nil {
+		return m.Role
+	}
+	return ""
 }
-func (m *StopHubReply) XXX_Size() int {
-	return xxx_messageInfo_StopHubReply.Size(m)
+
+func (m *SegmentStatus) GetPreferredRole() string {
+	if m != nil {
+		return m.PreferredRole
+	}
+	return ""
 }
-func (m *StopHubReply) XXX_DiscardUnknown() {
-	xxx_messageInfo_StopHubReply.DiscardUnknown(m)
+
+func (m *SegmentStatus) GetMode() string {
+	if m != nil {
+		return m.Mode
+	}
+	return ""
 }
 
-var xxx_messageInfo_StopHubReply proto.InternalMessageInfo
+func (m *SegmentStatus) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
 
-type StartAgentsRequest struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *SegmentStatus) GetHostname() string {
+	if m != nil {
+		return m.Hostname
+	}
+	return ""
 }
 
-func (m *StartAgentsRequest) Reset()         { *m = StartAgentsRequest{} }
-func (m *StartAgentsRequest) String() string { return proto.CompactTextString(m) }
-func (*StartAgentsRequest) ProtoMessage()    {}
-func (*StartAgentsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b3103f8d3056b01c, []int{5}
+func (m *SegmentStatus) GetAddress() string {
+	if m != nil {
+		return m.Address
+	}
+	return ""
 }
 
-func (m *StartAgentsRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_StartAgentsRequest.Unmarshal(m, b)
+func (m *SegmentStatus) GetPort() int32 {
+	if m != nil {
+		return m.Port
+	}
+	return 0
 }
-func (m *StartAgentsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_StartAgentsRequest.Marshal(b, m, deterministic)
+
+func (m *SegmentStatus) GetDataDir() string {
+	if m != nil {
+		return m.DataDir
+	}
+	return ""
 }
-func (m *StartAgentsRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_StartAgentsRequest.Merge(m, src)
+
+func (m *SegmentStatus) GetRunning() bool {
+	if m != nil {
+		return m.Running
+	}
+	return false
 }
-func (m *StartAgentsRequest) XXX_Size() int {
-	return xxx_messageInfo_StartAgentsRequest.Size(m)
+
+func (m *SegmentStatus) GetPid() int32 {
+	if m != nil {
+		return m.Pid
+	}
+	return 0
 }
-func (m *StartAgentsRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_StartAgentsRequest.DiscardUnknown(m)
+
+func (m *SegmentStatus) GetClusterState() string {
+	if m != nil {
+		return m.ClusterState
+	}
+	return ""
 }
 
-var xxx_messageInfo_StartAgentsRequest proto.InternalMessageInfo
+func (m *SegmentStatus) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
 
-type StartAgentsReply struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+type ClusterStatusReply struct {
+	Segments             []*SegmentStatus `protobuf:"bytes,1,rep,name=segments,proto3" json:"segments,omitempty"`
+	Agents               []*ServiceStatus `protobuf:"bytes,2,rep,name=agents,proto3" json:"agents,omitempty"`
+	CatalogError         string           `protobuf:"bytes,3,opt,name=catalogError,proto3" json:"catalogError,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
 }
 
-func (m *StartAgentsReply) Reset()         { *m = StartAgentsReply{} }
-func (m *StartAgentsReply) String() string { return proto.CompactTextString(m) }
-func (*StartAgentsReply) ProtoMessage()    {}
-func (*StartAgentsReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b3103f8d3056b01c, []int{6}
+func (m *ClusterStatusReply) Reset()         { *m = ClusterStatusReply{} }
+func (m *ClusterStatusReply) String() string { return proto.CompactTextString(m) }
+func (*ClusterStatusReply) ProtoMessage()    {}
+func (*ClusterStatusReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{4}
 }
 
-func (m *StartAgentsReply) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_StartAgentsReply.Unmarshal(m, b)
+func (m *ClusterStatusReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ClusterStatusReply.Unmarshal(m, b)
 }
-func (m *StartAgentsReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_StartAgentsReply.Marshal(b, m, deterministic)
+func (m *ClusterStatusReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ClusterStatusReply.Marshal(b, m, deterministic)
 }
-func (m *StartAgentsReply) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_StartAgentsReply.Merge(m, src)
+func (m *ClusterStatusReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ClusterStatusReply.Merge(m, src)
 }
-func (m *StartAgentsReply) XXX_Size() int {
-	return xxx_messageInfo_StartAgentsReply.Size(m)
+func (m *ClusterStatusReply) XXX_Size() int {
+	return xxx_messageInfo_ClusterStatusReply.Size(m)
 }
-func (m *StartAgentsReply) XXX_DiscardUnknown() {
-	xxx_messageInfo_StartAgentsReply.DiscardUnknown(m)
+func (m *ClusterStatusReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_ClusterStatusReply.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_StartAgentsReply proto.InternalMessageInfo
+var xxx_messageInfo_ClusterStatusReply proto.InternalMessageInfo
 
-type StatusAgentsRequest struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *ClusterStatusReply) GetSegments() []*SegmentStatus {
+	if m != nil {
+		return m.Segments
+	}
+	return nil
 }
 
-func (m *StatusAgentsRequest) Reset()         { *m = StatusAgentsRequest{} }
-func (m *StatusAgentsRequest) String() string { return proto.CompactTextString(m) }
-func (*StatusAgentsRequest) ProtoMessage()    {}
-func (*StatusAgentsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b3103f8d3056b01c, []int{7}
+func (m *ClusterStatusReply) GetAgents() []*ServiceStatus {
+	if m != nil {
+		return m.Agents
+	}
+	return nil
 }
 
-func (m *StatusAgentsRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_StatusAgentsRequest.Unmarshal(m, b)
-}
-func (m *StatusAgentsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_StatusAgentsRequest.Marshal(b, m, deterministic)
-}
-func (m *StatusAgentsRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_StatusAgentsRequest.Merge(m, src)
-}
-func (m *StatusAgentsRequest) XXX_Size() int {
-	return xxx_messageInfo_StatusAgentsRequest.Size(m)
-}
-func (m *StatusAgentsRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_StatusAgentsRequest.DiscardUnknown(m)
+func (m *ClusterStatusReply) GetCatalogError() string {
+	if m != nil {
+		return m.CatalogError
+	}
+	return ""
 }
 
-var xxx_messageInfo_StatusAgentsRequest proto.InternalMessageInfo
-
-type ReportAgentHealthRequest struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+type RecoverSegmentsRequest struct {
+	CoordinatorDataDir   string                `protobuf:"bytes,1,opt,name=CoordinatorDataDir,proto3" json:"CoordinatorDataDir,omitempty"`
+	Full                 bool                  `protobuf:"varint,2,opt,name=Full,proto3" json:"Full,omitempty"`
+	HbaHostnames         bool                  `protobuf:"varint,3,opt,name=HbaHostnames,proto3" json:"HbaHostnames,omitempty"`
+	RecoverPairs         []*RecoverSegmentPair `protobuf:"bytes,4,rep,name=recoverPairs,proto3" json:"recoverPairs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
 }
 
-func (m *ReportAgentHealthRequest) Reset()         { *m = ReportAgentHealthRequest{} }
-func (m *ReportAgentHealthRequest) String() string { return proto.CompactTextString(m) }
-func (*ReportAgentHealthRequest) ProtoMessage()    {}
-func (*ReportAgentHealthRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b3103f8d3056b01c, []int{8}
+func (m *RecoverSegmentsRequest) Reset()         { *m = RecoverSegmentsRequest{} }
+func (m *RecoverSegmentsRequest) String() string { return proto.CompactTextString(m) }
+func (*RecoverSegmentsRequest) ProtoMessage()    {}
+func (*RecoverSegmentsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{5}
 }
 
-func (m *ReportAgentHealthRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ReportAgentHealthRequest.Unmarshal(m, b)
+func (m *RecoverSegmentsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RecoverSegmentsRequest.Unmarshal(m, b)
 }
-func (m *ReportAgentHealthRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ReportAgentHealthRequest.Marshal(b, m, deterministic)
+func (m *RecoverSegmentsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RecoverSegmentsRequest.Marshal(b, m, deterministic)
 }
-func (m *ReportAgentHealthRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ReportAgentHealthRequest.Merge(m, src)
+func (m *RecoverSegmentsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RecoverSegmentsRequest.Merge(m, src)
 }
-func (m *ReportAgentHealthRequest) XXX_Size() int {
-	return xxx_messageInfo_ReportAgentHealthRequest.Size(m)
+func (m *RecoverSegmentsRequest) XXX_Size() int {
+	return xxx_messageInfo_RecoverSegmentsRequest.Size(m)
 }
-func (m *ReportAgentHealthRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_ReportAgentHealthRequest.DiscardUnknown(m)
+func (m *RecoverSegmentsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RecoverSegmentsRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ReportAgentHealthRequest proto.InternalMessageInfo
+var xxx_messageInfo_RecoverSegmentsRequest proto.InternalMessageInfo
 
-type ReportAgentHealthResponse struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+func (m *RecoverSegmentsRequest) GetCoordinatorDataDir() string {
+	if m != nil {
+		return m.CoordinatorDataDir
+	}
+	return ""
 }
 
-func (m *ReportAgentHealthResponse) Reset()         { *m = ReportAgentHealthResponse{} }
-func (m *ReportAgentHealthResponse) String() string { return proto.CompactTextString(m) }
-func (*ReportAgentHealthResponse) ProtoMessage()    {}
-func (*ReportAgentHealthResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b3103f8d3056b01c, []int{9}
+func (m *RecoverSegmentsRequest) GetFull() bool {
+	if m != nil {
+		return m.Full
+	}
+	return false
 }
 
-func (m *ReportAgentHealthResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ReportAgentHealthResponse.Unmarshal(m, b)
-}
-func (m *ReportAgentHealthResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ReportAgentHealthResponse.Marshal(b, m, deterministic)
-}
-func (m *ReportAgentHealthResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ReportAgentHealthResponse.Merge(m, src)
-}
-func (m *ReportAgentHealthResponse) XXX_Size() int {
-	return xxx_messageInfo_ReportAgentHealthResponse.Size(m)
-}
-func (m *ReportAgentHealthResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_ReportAgentHealthResponse.DiscardUnknown(m)
+func (m *RecoverSegmentsRequest) GetHbaHostnames() bool {
+	if m != nil {
+		return m.HbaHostnames
+	}
+	return false
 }
 
-var xxx_messageInfo_ReportAgentHealthResponse proto.InternalMessageInfo
+func (m *RecoverSegmentsRequest) GetRecoverPairs() []*RecoverSegmentPair {
+	if m != nil {
+		return m.RecoverPairs
+	}
+	return nil
+}
 
-type CleanInitClusterRequest struct {
+type RecoverSegmentPair struct {
+	Failed               *Segment `protobuf:"bytes,1,opt,name=failed,proto3" json:"failed,omitempty"`
+	Target               *Segment `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *CleanInitClusterRequest) Reset()         { *m = CleanInitClusterRequest{} }
-func (m *CleanInitClusterRequest) String() string { return proto.CompactTextString(m) }
-func (*CleanInitClusterRequest) ProtoMessage()    {}
-func (*CleanInitClusterRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b3103f8d3056b01c, []int{10}
+func (m *RecoverSegmentPair) Reset()         { *m = RecoverSegmentPair{} }
+func (m *RecoverSegmentPair) String() string { return proto.CompactTextString(m) }
+func (*RecoverSegmentPair) ProtoMessage()    {}
+func (*RecoverSegmentPair) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{6}
 }
 
-func (m *CleanInitClusterRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CleanInitClusterRequest.Unmarshal(m, b)
+func (m *RecoverSegmentPair) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RecoverSegmentPair.Unmarshal(m, b)
 }
-func (m *CleanInitClusterRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_CleanInitClusterRequest.Marshal(b, m, deterministic)
+func (m *RecoverSegmentPair) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RecoverSegmentPair.Marshal(b, m, deterministic)
 }
-func (m *CleanInitClusterRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CleanInitClusterRequest.Merge(m, src)
+func (m *RecoverSegmentPair) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RecoverSegmentPair.Merge(m, src)
 }
-func (m *CleanInitClusterRequest) XXX_Size() int {
-	return xxx_messageInfo_CleanInitClusterRequest.Size(m)
+func (m *RecoverSegmentPair) XXX_Size() int {
+	return xxx_messageInfo_RecoverSegmentPair.Size(m)
 }
-func (m *CleanInitClusterRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_CleanInitClusterRequest.DiscardUnknown(m)
+func (m *RecoverSegmentPair) XXX_DiscardUnknown() {
+	xxx_messageInfo_RecoverSegmentPair.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CleanInitClusterRequest proto.InternalMessageInfo
-
-type CleanInitClusterReply struct {
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
-}
+var xxx_messageInfo_RecoverSegmentPair proto.InternalMessageInfo
 
-func (m *CleanInitClusterReply) Reset()         { *m = CleanInitClusterReply{} }
-func (m *CleanInitClusterReply) String() string { return proto.CompactTextString(m) }
-func (*CleanInitClusterReply) ProtoMessage()    {}
-func (*CleanInitClusterReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b3103f8d3056b01c, []int{11}
+func (m *RecoverSegmentPair) GetFailed() *Segment {
+	if m != nil {
+		return m.Failed
+	}
+	return nil
 }
 
-func (m *CleanInitClusterReply) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CleanInitClusterReply.Unmarshal(m, b)
-}
-func (m *CleanInitClusterReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_CleanInitClusterReply.Marshal(b, m, deterministic)
-}
-func (m *CleanInitClusterReply) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CleanInitClusterReply.Merge(m, src)
-}
-func (m *CleanInitClusterReply) XXX_Size() int {
-	return xxx_messageInfo_CleanInitClusterReply.Size(m)
-}
-func (m *CleanInitClusterReply) XXX_DiscardUnknown() {
-	xxx_messageInfo_CleanInitClusterReply.DiscardUnknown(m)
+func (m *RecoverSegmentPair) GetTarget() *Segment {
+	if m != nil {
+		return m.Target
+	}
+	return nil
 }
 
-var xxx_messageInfo_CleanInitClusterReply proto.InternalMessageInfo
-
-type ServiceStatus struct {
-	Role                 string   `protobuf:"bytes,1,opt,name=role,proto3" json:"role,omitempty"`
-	Host                 string   `protobuf:"bytes,2,opt,name=host,proto3" json:"host,omitempty"`
-	Status               string   `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
-	Uptime               string   `protobuf:"bytes,4,opt,name=uptime,proto3" json:"uptime,omitempty"`
-	Pid                  uint32   `protobuf:"varint,5,opt,name=pid,proto3" json:"pid,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+type ExpandClusterRequest struct {
+	CoordinatorDataDir   string         `protobuf:"bytes,1,opt,name=CoordinatorDataDir,proto3" json:"CoordinatorDataDir,omitempty"`
+	HbaHostnames         bool           `protobuf:"varint,2,opt,name=HbaHostnames,proto3" json:"HbaHostnames,omitempty"`
+	SegmentPairs         []*SegmentPair `protobuf:"bytes,3,rep,name=segmentPairs,proto3" json:"segmentPairs,omitempty"`
+	BatchSize            int32          `protobuf:"varint,4,opt,name=BatchSize,proto3" json:"BatchSize,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
 }
 
-func (m *ServiceStatus) Reset()         { *m = ServiceStatus{} }
-func (m *ServiceStatus) String() string { return proto.CompactTextString(m) }
-func (*ServiceStatus) ProtoMessage()    {}
-func (*ServiceStatus) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b3103f8d3056b01c, []int{12}
+func (m *ExpandClusterRequest) Reset()         { *m = ExpandClusterRequest{} }
+func (m *ExpandClusterRequest) String() string { return proto.CompactTextString(m) }
+func (*ExpandClusterRequest) ProtoMessage()    {}
+func (*ExpandClusterRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{7}
 }
 
-func (m *ServiceStatus) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ServiceStatus.Unmarshal(m, b)
+func (m *ExpandClusterRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ExpandClusterRequest.Unmarshal(m, b)
 }
-func (m *ServiceStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ServiceStatus.Marshal(b, m, deterministic)
+func (m *ExpandClusterRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ExpandClusterRequest.Marshal(b, m, deterministic)
 }
-func (m *ServiceStatus) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ServiceStatus.Merge(m, src)
+func (m *ExpandClusterRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExpandClusterRequest.Merge(m, src)
 }
-func (m *ServiceStatus) XXX_Size() int {
-	return xxx_messageInfo_ServiceStatus.Size(m)
+func (m *ExpandClusterRequest) XXX_Size() int {
+	return xxx_messageInfo_ExpandClusterRequest.Size(m)
 }
-func (m *ServiceStatus) XXX_DiscardUnknown() {
-	xxx_messageInfo_ServiceStatus.DiscardUnknown(m)
+func (m *ExpandClusterRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExpandClusterRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ServiceStatus proto.InternalMessageInfo
-
-func (m *ServiceStatus) GetRole() string {
-	if m != nil {
-		return m.Role
-	}
-	return ""
-}
+var xxx_messageInfo_ExpandClusterRequest proto.InternalMessageInfo
 
-func (m *ServiceStatus) GetHost() string {
+func (m *ExpandClusterRequest) GetCoordinatorDataDir() string {
 	if m != nil {
-		return m.Host
+		return m.CoordinatorDataDir
 	}
 	return ""
 }
 
-func (m *ServiceStatus) GetStatus() string {
+func (m *ExpandClusterRequest) GetHbaHostnames() bool {
 	if m != nil {
-		return m.Status
+		return m.HbaHostnames
 	}
-	return ""
+	return false
 }
 
-func (m *ServiceStatus) GetUptime() string {
+func (m *ExpandClusterRequest) GetSegmentPairs() []*SegmentPair {
 	if m != nil {
-		return m.Uptime
+		return m.SegmentPairs
 	}
-	return ""
+	return nil
 }
 
-func (m *ServiceStatus) GetPid() uint32 {
+func (m *ExpandClusterRequest) GetBatchSize() int32 {
 	if m != nil {
-		return m.Pid
+		return m.BatchSize
 	}
 	return 0
 }
 
-type StatusAgentsReply struct {
-	Statuses             []*ServiceStatus `protobuf:"bytes,1,rep,name=statuses,proto3" json:"statuses,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
-	XXX_unrecognized     []byte           `json:"-"`
-	XXX_sizecache        int32            `json:"-"`
+type AddStandbyRequest struct {
+	CoordinatorDataDir   string   `protobuf:"bytes,1,opt,name=CoordinatorDataDir,proto3" json:"CoordinatorDataDir,omitempty"`
+	HbaHostnames         bool     `protobuf:"varint,2,opt,name=HbaHostnames,proto3" json:"HbaHostnames,omitempty"`
+	Standby              *Segment `protobuf:"bytes,3,opt,name=standby,proto3" json:"standby,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *StatusAgentsReply) Reset()         { *m = StatusAgentsReply{} }
-func (m *StatusAgentsReply) String() string { return proto.CompactTextString(m) }
-func (*StatusAgentsReply) ProtoMessage()    {}
-func (*StatusAgentsReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b3103f8d3056b01c, []int{13}
+func (m *AddStandbyRequest) Reset()         { *m = AddStandbyRequest{} }
+func (m *AddStandbyRequest) String() string { return proto.CompactTextString(m) }
+func (*AddStandbyRequest) ProtoMessage()    {}
+func (*AddStandbyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{8}
 }
 
-func (m *StatusAgentsReply) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_StatusAgentsReply.Unmarshal(m, b)
+func (m *AddStandbyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddStandbyRequest.Unmarshal(m, b)
 }
-func (m *StatusAgentsReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_StatusAgentsReply.Marshal(b, m, deterministic)
+func (m *AddStandbyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddStandbyRequest.Marshal(b, m, deterministic)
 }
-func (m *StatusAgentsReply) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_StatusAgentsReply.Merge(m, src)
+func (m *AddStandbyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddStandbyRequest.Merge(m, src)
 }
-func (m *StatusAgentsReply) XXX_Size() int {
-	return xxx_messageInfo_StatusAgentsReply.Size(m)
+func (m *AddStandbyRequest) XXX_Size() int {
+	return xxx_messageInfo_AddStandbyRequest.Size(m)
 }
-func (m *StatusAgentsReply) XXX_DiscardUnknown() {
-	xxx_messageInfo_StatusAgentsReply.DiscardUnknown(m)
+func (m *AddStandbyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddStandbyRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_StatusAgentsReply proto.InternalMessageInfo
+var xxx_messageInfo_AddStandbyRequest proto.InternalMessageInfo
 
-func (m *StatusAgentsReply) GetStatuses() []*ServiceStatus {
+func (m *AddStandbyRequest) GetCoordinatorDataDir() string {
 	if m != nil {
-		return m.Statuses
+		return m.CoordinatorDataDir
+	}
+	return ""
+}
+
+func (m *AddStandbyRequest) GetHbaHostnames() bool {
+	if m != nil {
+		return m.HbaHostnames
+	}
+	return false
+}
+
+func (m *AddStandbyRequest) GetStandby() *Segment {
+	if m != nil {
+		return m.Standby
 	}
 	return nil
 }
 
-type StopAgentsRequest struct {
+type RemoveStandbyRequest struct {
+	CoordinatorDataDir   string   `protobuf:"bytes,1,opt,name=CoordinatorDataDir,proto3" json:"CoordinatorDataDir,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *StopAgentsRequest) Reset()         { *m = StopAgentsRequest{} }
-func (m *StopAgentsRequest) String() string { return proto.CompactTextString(m) }
-func (*StopAgentsRequest) ProtoMessage()    {}
-func (*StopAgentsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b3103f8d3056b01c, []int{14}
+func (m *RemoveStandbyRequest) Reset()         { *m = RemoveStandbyRequest{} }
+func (m *RemoveStandbyRequest) String() string { return proto.CompactTextString(m) }
+func (*RemoveStandbyRequest) ProtoMessage()    {}
+func (*RemoveStandbyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{9}
 }
 
-func (m *StopAgentsRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_StopAgentsRequest.Unmarshal(m, b)
+func (m *RemoveStandbyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RemoveStandbyRequest.Unmarshal(m, b)
 }
-func (m *StopAgentsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_StopAgentsRequest.Marshal(b, m, deterministic)
+func (m *RemoveStandbyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RemoveStandbyRequest.Marshal(b, m, deterministic)
 }
-func (m *StopAgentsRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_StopAgentsRequest.Merge(m, src)
+func (m *RemoveStandbyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RemoveStandbyRequest.Merge(m, src)
 }
-func (m *StopAgentsRequest) XXX_Size() int {
-	return xxx_messageInfo_StopAgentsRequest.Size(m)
+func (m *RemoveStandbyRequest) XXX_Size() int {
+	return xxx_messageInfo_RemoveStandbyRequest.Size(m)
 }
-func (m *StopAgentsRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_StopAgentsRequest.DiscardUnknown(m)
+func (m *RemoveStandbyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RemoveStandbyRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_StopAgentsRequest proto.InternalMessageInfo
+var xxx_messageInfo_RemoveStandbyRequest proto.InternalMessageInfo
 
-type StopAgentsReply struct {
+func (m *RemoveStandbyRequest) GetCoordinatorDataDir() string {
+	if m != nil {
+		return m.CoordinatorDataDir
+	}
+	return ""
+}
+
+type ActivateStandbyRequest struct {
+	CoordinatorDataDir   string   `protobuf:"bytes,1,opt,name=CoordinatorDataDir,proto3" json:"CoordinatorDataDir,omitempty"`
+	Hostname             string   `protobuf:"bytes,2,opt,name=Hostname,proto3" json:"Hostname,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *StopAgentsReply) Reset()         { *m = StopAgentsReply{} }
-func (m *StopAgentsReply) String() string { return proto.CompactTextString(m) }
-func (*StopAgentsReply) ProtoMessage()    {}
-func (*StopAgentsReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b3103f8d3056b01c, []int{15}
+func (m *ActivateStandbyRequest) Reset()         { *m = ActivateStandbyRequest{} }
+func (m *ActivateStandbyRequest) String() string { return proto.CompactTextString(m) }
+func (*ActivateStandbyRequest) ProtoMessage()    {}
+func (*ActivateStandbyRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{10}
 }
 
-func (m *StopAgentsReply) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_StopAgentsReply.Unmarshal(m, b)
+func (m *ActivateStandbyRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ActivateStandbyRequest.Unmarshal(m, b)
 }
-func (m *StopAgentsReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_StopAgentsReply.Marshal(b, m, deterministic)
+func (m *ActivateStandbyRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ActivateStandbyRequest.Marshal(b, m, deterministic)
 }
-func (m *StopAgentsReply) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_StopAgentsReply.Merge(m, src)
+func (m *ActivateStandbyRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ActivateStandbyRequest.Merge(m, src)
 }
-func (m *StopAgentsReply) XXX_Size() int {
-	return xxx_messageInfo_StopAgentsReply.Size(m)
+func (m *ActivateStandbyRequest) XXX_Size() int {
+	return xxx_messageInfo_ActivateStandbyRequest.Size(m)
 }
-func (m *StopAgentsReply) XXX_DiscardUnknown() {
-	xxx_messageInfo_StopAgentsReply.DiscardUnknown(m)
+func (m *ActivateStandbyRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ActivateStandbyRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_StopAgentsReply proto.InternalMessageInfo
+var xxx_messageInfo_ActivateStandbyRequest proto.InternalMessageInfo
 
-type MakeClusterRequest struct {
-	GpArray              *GpArray       `protobuf:"bytes,1,opt,name=gpArray,proto3" json:"gpArray,omitempty"`
-	ClusterParams        *ClusterParams `protobuf:"bytes,2,opt,name=clusterParams,proto3" json:"clusterParams,omitempty"`
-	ForceFlag            bool           `protobuf:"varint,3,opt,name=forceFlag,proto3" json:"forceFlag,omitempty"`
-	Verbose              bool           `protobuf:"varint,4,opt,name=verbose,proto3" json:"verbose,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
-	XXX_unrecognized     []byte         `json:"-"`
-	XXX_sizecache        int32          `json:"-"`
+func (m *ActivateStandbyRequest) GetCoordinatorDataDir() string {
+	if m != nil {
+		return m.CoordinatorDataDir
+	}
+	return ""
 }
 
-func (m *MakeClusterRequest) Reset()         { *m = MakeClusterRequest{} }
-func (m *MakeClusterRequest) String() string { return proto.CompactTextString(m) }
-func (*MakeClusterRequest) ProtoMessage()    {}
-func (*MakeClusterRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b3103f8d3056b01c, []int{16}
+func (m *ActivateStandbyRequest) GetHostname() string {
+	if m != nil {
+		return m.Hostname
+	}
+	return ""
 }
 
-func (m *MakeClusterRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_MakeClusterRequest.Unmarshal(m, b)
+type AddMirrorsRequest struct {
+	CoordinatorDataDir   string     `protobuf:"bytes,1,opt,name=CoordinatorDataDir,proto3" json:"CoordinatorDataDir,omitempty"`
+	HbaHostnames         bool       `protobuf:"varint,2,opt,name=HbaHostnames,proto3" json:"HbaHostnames,omitempty"`
+	Mirrors              []*Segment `protobuf:"bytes,3,rep,name=mirrors,proto3" json:"mirrors,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
 }
-func (m *MakeClusterRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_MakeClusterRequest.Marshal(b, m, deterministic)
+
+func (m *AddMirrorsRequest) Reset()         { *m = AddMirrorsRequest{} }
+func (m *AddMirrorsRequest) String() string { return proto.CompactTextString(m) }
+func (*AddMirrorsRequest) ProtoMessage()    {}
+func (*AddMirrorsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{11}
 }
-func (m *MakeClusterRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_MakeClusterRequest.Merge(m, src)
+
+func (m *AddMirrorsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddMirrorsRequest.Unmarshal(m, b)
 }
-func (m *MakeClusterRequest) XXX_Size() int {
-	return xxx_messageInfo_MakeClusterRequest.Size(m)
+func (m *AddMirrorsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddMirrorsRequest.Marshal(b, m, deterministic)
 }
-func (m *MakeClusterRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_MakeClusterRequest.DiscardUnknown(m)
+func (m *AddMirrorsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddMirrorsRequest.Merge(m, src)
 }
-
-var xxx_messageInfo_MakeClusterRequest proto.InternalMessageInfo
-
-func (m *MakeClusterRequest) GetGpArray() *GpArray {
-	if m != nil {
-		return m.GpArray
-	}
-	return nil
+func (m *AddMirrorsRequest) XXX_Size() int {
+	return xxx_messageInfo_AddMirrorsRequest.Size(m)
+}
+func (m *AddMirrorsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddMirrorsRequest.DiscardUnknown(m)
 }
 
-func (m *MakeClusterRequest) GetClusterParams() *ClusterParams {
+var xxx_messageInfo_AddMirrorsRequest proto.InternalMessageInfo
+
+func (m *AddMirrorsRequest) GetCoordinatorDataDir() string {
 	if m != nil {
-		return m.ClusterParams
+		return m.CoordinatorDataDir
 	}
-	return nil
+	return ""
 }
 
-func (m *MakeClusterRequest) GetForceFlag() bool {
+func (m *AddMirrorsRequest) GetHbaHostnames() bool {
 	if m != nil {
-		return m.ForceFlag
+		return m.HbaHostnames
 	}
 	return false
 }
 
-func (m *MakeClusterRequest) GetVerbose() bool {
+func (m *AddMirrorsRequest) GetMirrors() []*Segment {
 	if m != nil {
-		return m.Verbose
+		return m.Mirrors
 	}
-	return false
+	return nil
 }
 
-type HubReply struct {
-	// Types that are valid to be assigned to Message:
-	//
-	//	*HubReply_LogMsg
-	//	*HubReply_StdoutMsg
-	//	*HubReply_ProgressMsg
-	Message              isHubReply_Message `protobuf_oneof:"message"`
-	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
-	XXX_unrecognized     []byte             `json:"-"`
-	XXX_sizecache        int32              `json:"-"`
+type GetAllHostNamesRequest struct {
+	HostList             []string `protobuf:"bytes,1,rep,name=hostList,proto3" json:"hostList,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *HubReply) Reset()         { *m = HubReply{} }
-func (m *HubReply) String() string { return proto.CompactTextString(m) }
-func (*HubReply) ProtoMessage()    {}
-func (*HubReply) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b3103f8d3056b01c, []int{17}
+func (m *GetAllHostNamesRequest) Reset()         { *m = GetAllHostNamesRequest{} }
+func (m *GetAllHostNamesRequest) String() string { return proto.CompactTextString(m) }
+func (*GetAllHostNamesRequest) ProtoMessage()    {}
+func (*GetAllHostNamesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{12}
 }
 
-func (m *HubReply) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_HubReply.Unmarshal(m, b)
+func (m *GetAllHostNamesRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetAllHostNamesRequest.Unmarshal(m, b)
 }
-func (m *HubReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_HubReply.Marshal(b, m, deterministic)
+func (m *GetAllHostNamesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetAllHostNamesRequest.Marshal(b, m, deterministic)
 }
-func (m *HubReply) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_HubReply.Merge(m, src)
+func (m *GetAllHostNamesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetAllHostNamesRequest.Merge(m, src)
 }
-func (m *HubReply) XXX_Size() int {
-	return xxx_messageInfo_HubReply.Size(m)
+func (m *GetAllHostNamesRequest) XXX_Size() int {
+	return xxx_messageInfo_GetAllHostNamesRequest.Size(m)
 }
-func (m *HubReply) XXX_DiscardUnknown() {
-	xxx_messageInfo_HubReply.DiscardUnknown(m)
+func (m *GetAllHostNamesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetAllHostNamesRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_HubReply proto.InternalMessageInfo
+var xxx_messageInfo_GetAllHostNamesRequest proto.InternalMessageInfo
 
-type isHubReply_Message interface {
-	isHubReply_Message()
+func (m *GetAllHostNamesRequest) GetHostList() []string {
+	if m != nil {
+		return m.HostList
+	}
+	return nil
 }
 
-type HubReply_LogMsg struct {
-	LogMsg *LogMessage `protobuf:"bytes,1,opt,name=logMsg,proto3,oneof"`
+type GetAllHostNamesReply struct {
+	HostNameMap          map[string]string `protobuf:"bytes,1,rep,name=hostNameMap,proto3" json:"hostNameMap,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
-type HubReply_StdoutMsg struct {
-	StdoutMsg string `protobuf:"bytes,2,opt,name=stdoutMsg,proto3,oneof"`
+func (m *GetAllHostNamesReply) Reset()         { *m = GetAllHostNamesReply{} }
+func (m *GetAllHostNamesReply) String() string { return proto.CompactTextString(m) }
+func (*GetAllHostNamesReply) ProtoMessage()    {}
+func (*GetAllHostNamesReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{13}
 }
 
-type HubReply_ProgressMsg struct {
-	ProgressMsg *ProgressMessage `protobuf:"bytes,3,opt,name=progressMsg,proto3,oneof"`
+func (m *GetAllHostNamesReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetAllHostNamesReply.Unmarshal(m, b)
 }
-
-func (*HubReply_LogMsg) isHubReply_Message() {}
-
-func (*HubReply_StdoutMsg) isHubReply_Message() {}
-
-func (*HubReply_ProgressMsg) isHubReply_Message() {}
-
-func (m *HubReply) GetMessage() isHubReply_Message {
-	if m != nil {
-		return m.Message
-	}
-	return nil
+func (m *GetAllHostNamesReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetAllHostNamesReply.Marshal(b, m, deterministic)
 }
-
-func (m *HubReply) GetLogMsg() *LogMessage {
-	if x, ok := m.GetMessage().(*HubReply_LogMsg); ok {
-		return x.LogMsg
-	}
-	return nil
+func (m *GetAllHostNamesReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetAllHostNamesReply.Merge(m, src)
 }
-
-func (m *HubReply) GetStdoutMsg() string {
-	if x, ok := m.GetMessage().(*HubReply_StdoutMsg); ok {
-		return x.StdoutMsg
-	}
-	return ""
+func (m *GetAllHostNamesReply) XXX_Size() int {
+	return xxx_messageInfo_GetAllHostNamesReply.Size(m)
 }
-
-func (m *HubReply) GetProgressMsg() *ProgressMessage {
-	if x, ok := m.GetMessage().(*HubReply_ProgressMsg); ok {
-		return x.ProgressMsg
-	}
-	return nil
+func (m *GetAllHostNamesReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetAllHostNamesReply.DiscardUnknown(m)
 }
 
-// XXX_OneofWrappers is for the internal use of the proto package.
-func (*HubReply) XXX_OneofWrappers() []interface{} {
-	return []interface{}{
-		(*HubReply_LogMsg)(nil),
-		(*HubReply_StdoutMsg)(nil),
-		(*HubReply_ProgressMsg)(nil),
+var xxx_messageInfo_GetAllHostNamesReply proto.InternalMessageInfo
+
+func (m *GetAllHostNamesReply) GetHostNameMap() map[string]string {
+	if m != nil {
+		return m.HostNameMap
 	}
+	return nil
 }
 
-type LogMessage struct {
-	Message              string   `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
-	Level                LogLevel `protobuf:"varint,2,opt,name=level,proto3,enum=idl.LogLevel" json:"level,omitempty"`
+type StopHubRequest struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *LogMessage) Reset()         { *m = LogMessage{} }
-func (m *LogMessage) String() string { return proto.CompactTextString(m) }
-func (*LogMessage) ProtoMessage()    {}
-func (*LogMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b3103f8d3056b01c, []int{18}
+func (m *StopHubRequest) Reset()         { *m = StopHubRequest{} }
+func (m *StopHubRequest) String() string { return proto.CompactTextString(m) }
+func (*StopHubRequest) ProtoMessage()    {}
+func (*StopHubRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{14}
 }
 
-func (m *LogMessage) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_LogMessage.Unmarshal(m, b)
+func (m *StopHubRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StopHubRequest.Unmarshal(m, b)
 }
-func (m *LogMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_LogMessage.Marshal(b, m, deterministic)
+func (m *StopHubRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StopHubRequest.Marshal(b, m, deterministic)
 }
-func (m *LogMessage) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_LogMessage.Merge(m, src)
+func (m *StopHubRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StopHubRequest.Merge(m, src)
 }
-func (m *LogMessage) XXX_Size() int {
-	return xxx_messageInfo_LogMessage.Size(m)
+func (m *StopHubRequest) XXX_Size() int {
+	return xxx_messageInfo_StopHubRequest.Size(m)
 }
-func (m *LogMessage) XXX_DiscardUnknown() {
-	xxx_messageInfo_LogMessage.DiscardUnknown(m)
+func (m *StopHubRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StopHubRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_LogMessage proto.InternalMessageInfo
+var xxx_messageInfo_StopHubRequest proto.InternalMessageInfo
 
-func (m *LogMessage) GetMessage() string {
-	if m != nil {
-		return m.Message
-	}
-	return ""
-}
-
-func (m *LogMessage) GetLevel() LogLevel {
-	if m != nil {
-		return m.Level
-	}
-	return LogLevel_FATAL
-}
-
-type ProgressMessage struct {
-	Label                string   `protobuf:"bytes,1,opt,name=label,proto3" json:"label,omitempty"`
-	Current              int32    `protobuf:"varint,2,opt,name=current,proto3" json:"current,omitempty"`
-	Total                int32    `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+type StopHubReply struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ProgressMessage) Reset()         { *m = ProgressMessage{} }
-func (m *ProgressMessage) String() string { return proto.CompactTextString(m) }
-func (*ProgressMessage) ProtoMessage()    {}
-func (*ProgressMessage) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b3103f8d3056b01c, []int{19}
+func (m *StopHubReply) Reset()         { *m = StopHubReply{} }
+func (m *StopHubReply) String() string { return proto.CompactTextString(m) }
+func (*StopHubReply) ProtoMessage()    {}
+func (*StopHubReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{15}
 }
 
-func (m *ProgressMessage) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ProgressMessage.Unmarshal(m, b)
-}
-func (m *ProgressMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ProgressMessage.Marshal(b, m, deterministic)
-}
-func (m *ProgressMessage) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ProgressMessage.Merge(m, src)
+func (m *StopHubReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StopHubReply.Unmarshal(m, b)
 }
-func (m *ProgressMessage) XXX_Size() int {
-	return xxx_messageInfo_ProgressMessage.Size(m)
+func (m *StopHubReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StopHubReply.Marshal(b, m, deterministic)
 }
-func (m *ProgressMessage) XXX_DiscardUnknown() {
-	xxx_messageInfo_ProgressMessage.DiscardUnknown(m)
+func (m *StopHubReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StopHubReply.Merge(m, src)
 }
-
-var xxx_messageInfo_ProgressMessage proto.InternalMessageInfo
-
-func (m *ProgressMessage) GetLabel() string {
-	if m != nil {
-		return m.Label
-	}
-	return ""
+func (m *StopHubReply) XXX_Size() int {
+	return xxx_messageInfo_StopHubReply.Size(m)
 }
-
-func (m *ProgressMessage) GetCurrent() int32 {
-	if m != nil {
-		return m.Current
-	}
-	return 0
+func (m *StopHubReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_StopHubReply.DiscardUnknown(m)
 }
 
-func (m *ProgressMessage) GetTotal() int32 {
-	if m != nil {
-		return m.Total
-	}
-	return 0
-}
+var xxx_messageInfo_StopHubReply proto.InternalMessageInfo
 
-type GpArray struct {
-	Coordinator          *Segment       `protobuf:"bytes,1,opt,name=Coordinator,proto3" json:"Coordinator,omitempty"`
-	SegmentArray         []*SegmentPair `protobuf:"bytes,2,rep,name=SegmentArray,proto3" json:"SegmentArray,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
-	XXX_unrecognized     []byte         `json:"-"`
-	XXX_sizecache        int32          `json:"-"`
+type StartAgentsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GpArray) Reset()         { *m = GpArray{} }
-func (m *GpArray) String() string { return proto.CompactTextString(m) }
-func (*GpArray) ProtoMessage()    {}
-func (*GpArray) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b3103f8d3056b01c, []int{20}
+func (m *StartAgentsRequest) Reset()         { *m = StartAgentsRequest{} }
+func (m *StartAgentsRequest) String() string { return proto.CompactTextString(m) }
+func (*StartAgentsRequest) ProtoMessage()    {}
+func (*StartAgentsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{16}
 }
 
-func (m *GpArray) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GpArray.Unmarshal(m, b)
-}
-func (m *GpArray) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GpArray.Marshal(b, m, deterministic)
+func (m *StartAgentsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StartAgentsRequest.Unmarshal(m, b)
 }
-func (m *GpArray) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GpArray.Merge(m, src)
+func (m *StartAgentsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StartAgentsRequest.Marshal(b, m, deterministic)
 }
-func (m *GpArray) XXX_Size() int {
-	return xxx_messageInfo_GpArray.Size(m)
+func (m *StartAgentsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StartAgentsRequest.Merge(m, src)
 }
-func (m *GpArray) XXX_DiscardUnknown() {
-	xxx_messageInfo_GpArray.DiscardUnknown(m)
+func (m *StartAgentsRequest) XXX_Size() int {
+	return xxx_messageInfo_StartAgentsRequest.Size(m)
 }
-
-var xxx_messageInfo_GpArray proto.InternalMessageInfo
-
-func (m *GpArray) GetCoordinator() *Segment {
-	if m != nil {
-		return m.Coordinator
-	}
-	return nil
+func (m *StartAgentsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StartAgentsRequest.DiscardUnknown(m)
 }
 
-func (m *GpArray) GetSegmentArray() []*SegmentPair {
-	if m != nil {
-		return m.SegmentArray
-	}
-	return nil
-}
+var xxx_messageInfo_StartAgentsRequest proto.InternalMessageInfo
 
-type Segment struct {
-	Port                 int32    `protobuf:"varint,1,opt,name=port,proto3" json:"port,omitempty"`
-	DataDirectory        string   `protobuf:"bytes,2,opt,name=dataDirectory,proto3" json:"dataDirectory,omitempty"`
-	HostName             string   `protobuf:"bytes,3,opt,name=hostName,proto3" json:"hostName,omitempty"`
-	HostAddress          string   `protobuf:"bytes,4,opt,name=hostAddress,proto3" json:"hostAddress,omitempty"`
-	Contentid            int32    `protobuf:"varint,5,opt,name=contentid,proto3" json:"contentid,omitempty"`
-	Dbid                 int32    `protobuf:"varint,6,opt,name=dbid,proto3" json:"dbid,omitempty"`
+type StartAgentsReply struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Segment) Reset()         { *m = Segment{} }
-func (m *Segment) String() string { return proto.CompactTextString(m) }
-func (*Segment) ProtoMessage()    {}
-func (*Segment) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b3103f8d3056b01c, []int{21}
+func (m *StartAgentsReply) Reset()         { *m = StartAgentsReply{} }
+func (m *StartAgentsReply) String() string { return proto.CompactTextString(m) }
+func (*StartAgentsReply) ProtoMessage()    {}
+func (*StartAgentsReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{17}
 }
 
-func (m *Segment) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Segment.Unmarshal(m, b)
+func (m *StartAgentsReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StartAgentsReply.Unmarshal(m, b)
 }
-func (m *Segment) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Segment.Marshal(b, m, deterministic)
+func (m *StartAgentsReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StartAgentsReply.Marshal(b, m, deterministic)
 }
-func (m *Segment) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Segment.Merge(m, src)
+func (m *StartAgentsReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StartAgentsReply.Merge(m, src)
 }
-func (m *Segment) XXX_Size() int {
-	return xxx_messageInfo_Segment.Size(m)
+func (m *StartAgentsReply) XXX_Size() int {
+	return xxx_messageInfo_StartAgentsReply.Size(m)
 }
-func (m *Segment) XXX_DiscardUnknown() {
-	xxx_messageInfo_Segment.DiscardUnknown(m)
+func (m *StartAgentsReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_StartAgentsReply.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Segment proto.InternalMessageInfo
+var xxx_messageInfo_StartAgentsReply proto.InternalMessageInfo
 
-func (m *Segment) GetPort() int32 {
-	if m != nil {
-		return m.Port
-	}
-	return 0
+type StatusAgentsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Segment) GetDataDirectory() string {
-	if m != nil {
-		return m.DataDirectory
-	}
-	return ""
+func (m *StatusAgentsRequest) Reset()         { *m = StatusAgentsRequest{} }
+func (m *StatusAgentsRequest) String() string { return proto.CompactTextString(m) }
+func (*StatusAgentsRequest) ProtoMessage()    {}
+func (*StatusAgentsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{18}
 }
 
-func (m *Segment) GetHostName() string {
-	if m != nil {
-		return m.HostName
-	}
-	return ""
+func (m *StatusAgentsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StatusAgentsRequest.Unmarshal(m, b)
 }
-
-func (m *Segment) GetHostAddress() string {
-	if m != nil {
-		return m.HostAddress
-	}
-	return ""
+func (m *StatusAgentsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StatusAgentsRequest.Marshal(b, m, deterministic)
 }
-
-func (m *Segment) GetContentid() int32 {
-	if m != nil {
-		return m.Contentid
-	}
-	return 0
+func (m *StatusAgentsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StatusAgentsRequest.Merge(m, src)
 }
-
-func (m *Segment) GetDbid() int32 {
-	if m != nil {
-		return m.Dbid
-	}
-	return 0
+func (m *StatusAgentsRequest) XXX_Size() int {
+	return xxx_messageInfo_StatusAgentsRequest.Size(m)
+}
+func (m *StatusAgentsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StatusAgentsRequest.DiscardUnknown(m)
 }
 
-type SegmentPair struct {
-	Primary              *Segment `protobuf:"bytes,1,opt,name=primary,proto3" json:"primary,omitempty"`
-	Mirror               *Segment `protobuf:"bytes,2,opt,name=mirror,proto3" json:"mirror,omitempty"`
+var xxx_messageInfo_StatusAgentsRequest proto.InternalMessageInfo
+
+type ReportAgentHealthRequest struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *SegmentPair) Reset()         { *m = SegmentPair{} }
-func (m *SegmentPair) String() string { return proto.CompactTextString(m) }
-func (*SegmentPair) ProtoMessage()    {}
-func (*SegmentPair) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b3103f8d3056b01c, []int{22}
+func (m *ReportAgentHealthRequest) Reset()         { *m = ReportAgentHealthRequest{} }
+func (m *ReportAgentHealthRequest) String() string { return proto.CompactTextString(m) }
+func (*ReportAgentHealthRequest) ProtoMessage()    {}
+func (*ReportAgentHealthRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{19}
 }
 
-func (m *SegmentPair) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_SegmentPair.Unmarshal(m, b)
+func (m *ReportAgentHealthRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReportAgentHealthRequest.Unmarshal(m, b)
 }
-func (m *SegmentPair) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_SegmentPair.Marshal(b, m, deterministic)
+func (m *ReportAgentHealthRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReportAgentHealthRequest.Marshal(b, m, deterministic)
 }
-func (m *SegmentPair) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_SegmentPair.Merge(m, src)
+func (m *ReportAgentHealthRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReportAgentHealthRequest.Merge(m, src)
 }
-func (m *SegmentPair) XXX_Size() int {
-	return xxx_messageInfo_SegmentPair.Size(m)
+func (m *ReportAgentHealthRequest) XXX_Size() int {
+	return xxx_messageInfo_ReportAgentHealthRequest.Size(m)
 }
-func (m *SegmentPair) XXX_DiscardUnknown() {
-	xxx_messageInfo_SegmentPair.DiscardUnknown(m)
+func (m *ReportAgentHealthRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReportAgentHealthRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_SegmentPair proto.InternalMessageInfo
+var xxx_messageInfo_ReportAgentHealthRequest proto.InternalMessageInfo
 
-func (m *SegmentPair) GetPrimary() *Segment {
-	if m != nil {
-		return m.Primary
-	}
-	return nil
+type ReportAgentHealthResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *SegmentPair) GetMirror() *Segment {
+func (m *ReportAgentHealthResponse) Reset()         { *m = ReportAgentHealthResponse{} }
+func (m *ReportAgentHealthResponse) String() string { return proto.CompactTextString(m) }
+func (*ReportAgentHealthResponse) ProtoMessage()    {}
+func (*ReportAgentHealthResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{20}
+}
+
+func (m *ReportAgentHealthResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReportAgentHealthResponse.Unmarshal(m, b)
+}
+func (m *ReportAgentHealthResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReportAgentHealthResponse.Marshal(b, m, deterministic)
+}
+func (m *ReportAgentHealthResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReportAgentHealthResponse.Merge(m, src)
+}
+func (m *ReportAgentHealthResponse) XXX_Size() int {
+	return xxx_messageInfo_ReportAgentHealthResponse.Size(m)
+}
+func (m *ReportAgentHealthResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReportAgentHealthResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReportAgentHealthResponse proto.InternalMessageInfo
+
+type UpdateHostsRequest struct {
+	Hostnames            []string `protobuf:"bytes,1,rep,name=hostnames,proto3" json:"hostnames,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UpdateHostsRequest) Reset()         { *m = UpdateHostsRequest{} }
+func (m *UpdateHostsRequest) String() string { return proto.CompactTextString(m) }
+func (*UpdateHostsRequest) ProtoMessage()    {}
+func (*UpdateHostsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{21}
+}
+
+func (m *UpdateHostsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateHostsRequest.Unmarshal(m, b)
+}
+func (m *UpdateHostsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateHostsRequest.Marshal(b, m, deterministic)
+}
+func (m *UpdateHostsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateHostsRequest.Merge(m, src)
+}
+func (m *UpdateHostsRequest) XXX_Size() int {
+	return xxx_messageInfo_UpdateHostsRequest.Size(m)
+}
+func (m *UpdateHostsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateHostsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateHostsRequest proto.InternalMessageInfo
+
+func (m *UpdateHostsRequest) GetHostnames() []string {
 	if m != nil {
-		return m.Mirror
+		return m.Hostnames
 	}
 	return nil
 }
 
-type ClusterParams struct {
-	CoordinatorConfig    map[string]string `protobuf:"bytes,1,rep,name=CoordinatorConfig,proto3" json:"CoordinatorConfig,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	SegmentConfig        map[string]string `protobuf:"bytes,2,rep,name=SegmentConfig,proto3" json:"SegmentConfig,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	CommonConfig         map[string]string `protobuf:"bytes,3,rep,name=CommonConfig,proto3" json:"CommonConfig,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	Locale               *Locale           `protobuf:"bytes,4,opt,name=locale,proto3" json:"locale,omitempty"`
-	HbaHostnames         bool              `protobuf:"varint,5,opt,name=hbaHostnames,proto3" json:"hbaHostnames,omitempty"`
-	Encoding             string            `protobuf:"bytes,6,opt,name=encoding,proto3" json:"encoding,omitempty"`
-	SuPassword           string            `protobuf:"bytes,7,opt,name=suPassword,proto3" json:"suPassword,omitempty"`
-	DbName               string            `protobuf:"bytes,8,opt,name=dbName,proto3" json:"dbName,omitempty"`
-	DataChecksums        bool              `protobuf:"varint,9,opt,name=dataChecksums,proto3" json:"dataChecksums,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+type UpdateHostsReply struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ClusterParams) Reset()         { *m = ClusterParams{} }
-func (m *ClusterParams) String() string { return proto.CompactTextString(m) }
-func (*ClusterParams) ProtoMessage()    {}
-func (*ClusterParams) Descriptor() ([]byte, []int) {
+func (m *UpdateHostsReply) Reset()         { *m = UpdateHostsReply{} }
+func (m *UpdateHostsReply) String() string { return proto.CompactTextString(m) }
+func (*UpdateHostsReply) ProtoMessage()    {}
+func (*UpdateHostsReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{22}
+}
+
+func (m *UpdateHostsReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UpdateHostsReply.Unmarshal(m, b)
+}
+func (m *UpdateHostsReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UpdateHostsReply.Marshal(b, m, deterministic)
+}
+func (m *UpdateHostsReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UpdateHostsReply.Merge(m, src)
+}
+func (m *UpdateHostsReply) XXX_Size() int {
+	return xxx_messageInfo_UpdateHostsReply.Size(m)
+}
+func (m *UpdateHostsReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_UpdateHostsReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UpdateHostsReply proto.InternalMessageInfo
+
+type PushFileRequest struct {
+	Hostnames            []string `protobuf:"bytes,1,rep,name=hostnames,proto3" json:"hostnames,omitempty"`
+	Path                 string   `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	Mode                 uint32   `protobuf:"varint,3,opt,name=mode,proto3" json:"mode,omitempty"`
+	Contents             []byte   `protobuf:"bytes,4,opt,name=contents,proto3" json:"contents,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PushFileRequest) Reset()         { *m = PushFileRequest{} }
+func (m *PushFileRequest) String() string { return proto.CompactTextString(m) }
+func (*PushFileRequest) ProtoMessage()    {}
+func (*PushFileRequest) Descriptor() ([]byte, []int) {
 	return fileDescriptor_b3103f8d3056b01c, []int{23}
 }
 
-func (m *ClusterParams) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ClusterParams.Unmarshal(m, b)
+func (m *PushFileRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PushFileRequest.Unmarshal(m, b)
 }
-func (m *ClusterParams) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ClusterParams.Marshal(b, m, deterministic)
+func (m *PushFileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PushFileRequest.Marshal(b, m, deterministic)
 }
-func (m *ClusterParams) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ClusterParams.Merge(m, src)
+func (m *PushFileRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PushFileRequest.Merge(m, src)
 }
-func (m *ClusterParams) XXX_Size() int {
-	return xxx_messageInfo_ClusterParams.Size(m)
+func (m *PushFileRequest) XXX_Size() int {
+	return xxx_messageInfo_PushFileRequest.Size(m)
 }
-func (m *ClusterParams) XXX_DiscardUnknown() {
-	xxx_messageInfo_ClusterParams.DiscardUnknown(m)
+func (m *PushFileRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PushFileRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ClusterParams proto.InternalMessageInfo
+var xxx_messageInfo_PushFileRequest proto.InternalMessageInfo
 
-func (m *ClusterParams) GetCoordinatorConfig() map[string]string {
+func (m *PushFileRequest) GetHostnames() []string {
 	if m != nil {
-		return m.CoordinatorConfig
+		return m.Hostnames
 	}
 	return nil
 }
 
-func (m *ClusterParams) GetSegmentConfig() map[string]string {
+func (m *PushFileRequest) GetPath() string {
 	if m != nil {
-		return m.SegmentConfig
+		return m.Path
 	}
-	return nil
+	return ""
 }
 
-func (m *ClusterParams) GetCommonConfig() map[string]string {
+func (m *PushFileRequest) GetMode() uint32 {
 	if m != nil {
-		return m.CommonConfig
+		return m.Mode
 	}
-	return nil
+	return 0
 }
 
-func (m *ClusterParams) GetLocale() *Locale {
+func (m *PushFileRequest) GetContents() []byte {
 	if m != nil {
-		return m.Locale
+		return m.Contents
 	}
 	return nil
 }
 
-func (m *ClusterParams) GetHbaHostnames() bool {
-	if m != nil {
-		return m.HbaHostnames
-	}
-	return false
+type PushFileReply struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *ClusterParams) GetEncoding() string {
+func (m *PushFileReply) Reset()         { *m = PushFileReply{} }
+func (m *PushFileReply) String() string { return proto.CompactTextString(m) }
+func (*PushFileReply) ProtoMessage()    {}
+func (*PushFileReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{24}
+}
+
+func (m *PushFileReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PushFileReply.Unmarshal(m, b)
+}
+func (m *PushFileReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PushFileReply.Marshal(b, m, deterministic)
+}
+func (m *PushFileReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PushFileReply.Merge(m, src)
+}
+func (m *PushFileReply) XXX_Size() int {
+	return xxx_messageInfo_PushFileReply.Size(m)
+}
+func (m *PushFileReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_PushFileReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PushFileReply proto.InternalMessageInfo
+
+type CollectFileRequest struct {
+	Hostnames            []string `protobuf:"bytes,1,rep,name=hostnames,proto3" json:"hostnames,omitempty"`
+	Path                 string   `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	DestDir              string   `protobuf:"bytes,3,opt,name=destDir,proto3" json:"destDir,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CollectFileRequest) Reset()         { *m = CollectFileRequest{} }
+func (m *CollectFileRequest) String() string { return proto.CompactTextString(m) }
+func (*CollectFileRequest) ProtoMessage()    {}
+func (*CollectFileRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{25}
+}
+
+func (m *CollectFileRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CollectFileRequest.Unmarshal(m, b)
+}
+func (m *CollectFileRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CollectFileRequest.Marshal(b, m, deterministic)
+}
+func (m *CollectFileRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CollectFileRequest.Merge(m, src)
+}
+func (m *CollectFileRequest) XXX_Size() int {
+	return xxx_messageInfo_CollectFileRequest.Size(m)
+}
+func (m *CollectFileRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CollectFileRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CollectFileRequest proto.InternalMessageInfo
+
+func (m *CollectFileRequest) GetHostnames() []string {
 	if m != nil {
-		return m.Encoding
+		return m.Hostnames
 	}
-	return ""
+	return nil
 }
 
-func (m *ClusterParams) GetSuPassword() string {
+func (m *CollectFileRequest) GetPath() string {
 	if m != nil {
-		return m.SuPassword
+		return m.Path
 	}
 	return ""
 }
 
-func (m *ClusterParams) GetDbName() string {
+func (m *CollectFileRequest) GetDestDir() string {
 	if m != nil {
-		return m.DbName
+		return m.DestDir
 	}
 	return ""
 }
 
-func (m *ClusterParams) GetDataChecksums() bool {
-	if m != nil {
-		return m.DataChecksums
-	}
-	return false
+type CollectFileReply struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-type Locale struct {
-	LcAll                string   `protobuf:"bytes,1,opt,name=lc_all,json=lcAll,proto3" json:"lc_all,omitempty"`
-	LcCollate            string   `protobuf:"bytes,2,opt,name=lc_collate,json=lcCollate,proto3" json:"lc_collate,omitempty"`
-	LcCtype              string   `protobuf:"bytes,3,opt,name=lc_ctype,json=lcCtype,proto3" json:"lc_ctype,omitempty"`
-	LcMessages           string   `protobuf:"bytes,4,opt,name=lc_messages,json=lcMessages,proto3" json:"lc_messages,omitempty"`
-	LcMonetory           string   `protobuf:"bytes,5,opt,name=lc_monetory,json=lcMonetory,proto3" json:"lc_monetory,omitempty"`
-	LcNumeric            string   `protobuf:"bytes,6,opt,name=lc_numeric,json=lcNumeric,proto3" json:"lc_numeric,omitempty"`
-	LcTime               string   `protobuf:"bytes,7,opt,name=lc_time,json=lcTime,proto3" json:"lc_time,omitempty"`
+func (m *CollectFileReply) Reset()         { *m = CollectFileReply{} }
+func (m *CollectFileReply) String() string { return proto.CompactTextString(m) }
+func (*CollectFileReply) ProtoMessage()    {}
+func (*CollectFileReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{26}
+}
+
+func (m *CollectFileReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CollectFileReply.Unmarshal(m, b)
+}
+func (m *CollectFileReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CollectFileReply.Marshal(b, m, deterministic)
+}
+func (m *CollectFileReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CollectFileReply.Merge(m, src)
+}
+func (m *CollectFileReply) XXX_Size() int {
+	return xxx_messageInfo_CollectFileReply.Size(m)
+}
+func (m *CollectFileReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_CollectFileReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CollectFileReply proto.InternalMessageInfo
+
+type ReloadCredentialsRequest struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Locale) Reset()         { *m = Locale{} }
-func (m *Locale) String() string { return proto.CompactTextString(m) }
-func (*Locale) ProtoMessage()    {}
-func (*Locale) Descriptor() ([]byte, []int) {
-	return fileDescriptor_b3103f8d3056b01c, []int{24}
+func (m *ReloadCredentialsRequest) Reset()         { *m = ReloadCredentialsRequest{} }
+func (m *ReloadCredentialsRequest) String() string { return proto.CompactTextString(m) }
+func (*ReloadCredentialsRequest) ProtoMessage()    {}
+func (*ReloadCredentialsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{27}
 }
 
-func (m *Locale) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Locale.Unmarshal(m, b)
+func (m *ReloadCredentialsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReloadCredentialsRequest.Unmarshal(m, b)
 }
-func (m *Locale) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Locale.Marshal(b, m, deterministic)
+func (m *ReloadCredentialsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReloadCredentialsRequest.Marshal(b, m, deterministic)
 }
-func (m *Locale) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Locale.Merge(m, src)
+func (m *ReloadCredentialsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReloadCredentialsRequest.Merge(m, src)
 }
-func (m *Locale) XXX_Size() int {
-	return xxx_messageInfo_Locale.Size(m)
+func (m *ReloadCredentialsRequest) XXX_Size() int {
+	return xxx_messageInfo_ReloadCredentialsRequest.Size(m)
 }
-func (m *Locale) XXX_DiscardUnknown() {
-	xxx_messageInfo_Locale.DiscardUnknown(m)
+func (m *ReloadCredentialsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReloadCredentialsRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Locale proto.InternalMessageInfo
+var xxx_messageInfo_ReloadCredentialsRequest proto.InternalMessageInfo
 
-func (m *Locale) GetLcAll() string {
+type ReloadCredentialsReply struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReloadCredentialsReply) Reset()         { *m = ReloadCredentialsReply{} }
+func (m *ReloadCredentialsReply) String() string { return proto.CompactTextString(m) }
+func (*ReloadCredentialsReply) ProtoMessage()    {}
+func (*ReloadCredentialsReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{28}
+}
+
+func (m *ReloadCredentialsReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReloadCredentialsReply.Unmarshal(m, b)
+}
+func (m *ReloadCredentialsReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReloadCredentialsReply.Marshal(b, m, deterministic)
+}
+func (m *ReloadCredentialsReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReloadCredentialsReply.Merge(m, src)
+}
+func (m *ReloadCredentialsReply) XXX_Size() int {
+	return xxx_messageInfo_ReloadCredentialsReply.Size(m)
+}
+func (m *ReloadCredentialsReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReloadCredentialsReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReloadCredentialsReply proto.InternalMessageInfo
+
+type ListOperationsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListOperationsRequest) Reset()         { *m = ListOperationsRequest{} }
+func (m *ListOperationsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListOperationsRequest) ProtoMessage()    {}
+func (*ListOperationsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{29}
+}
+
+func (m *ListOperationsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListOperationsRequest.Unmarshal(m, b)
+}
+func (m *ListOperationsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListOperationsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListOperationsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListOperationsRequest.Merge(m, src)
+}
+func (m *ListOperationsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListOperationsRequest.Size(m)
+}
+func (m *ListOperationsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListOperationsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListOperationsRequest proto.InternalMessageInfo
+
+type ListOperationsReply struct {
+	Operations           []*Operation `protobuf:"bytes,1,rep,name=operations,proto3" json:"operations,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *ListOperationsReply) Reset()         { *m = ListOperationsReply{} }
+func (m *ListOperationsReply) String() string { return proto.CompactTextString(m) }
+func (*ListOperationsReply) ProtoMessage()    {}
+func (*ListOperationsReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{30}
+}
+
+func (m *ListOperationsReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListOperationsReply.Unmarshal(m, b)
+}
+func (m *ListOperationsReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListOperationsReply.Marshal(b, m, deterministic)
+}
+func (m *ListOperationsReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListOperationsReply.Merge(m, src)
+}
+func (m *ListOperationsReply) XXX_Size() int {
+	return xxx_messageInfo_ListOperationsReply.Size(m)
+}
+func (m *ListOperationsReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListOperationsReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListOperationsReply proto.InternalMessageInfo
+
+func (m *ListOperationsReply) GetOperations() []*Operation {
 	if m != nil {
-		return m.LcAll
+		return m.Operations
+	}
+	return nil
+}
+
+type Operation struct {
+	Id                   string         `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Method               string         `protobuf:"bytes,2,opt,name=method,proto3" json:"method,omitempty"`
+	State                OperationState `protobuf:"varint,3,opt,name=state,proto3,enum=idl.OperationState" json:"state,omitempty"`
+	User                 string         `protobuf:"bytes,4,opt,name=user,proto3" json:"user,omitempty"`
+	StartTime            int64          `protobuf:"varint,5,opt,name=startTime,proto3" json:"startTime,omitempty"`
+	EndTime              int64          `protobuf:"varint,6,opt,name=endTime,proto3" json:"endTime,omitempty"`
+	Error                string         `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *Operation) Reset()         { *m = Operation{} }
+func (m *Operation) String() string { return proto.CompactTextString(m) }
+func (*Operation) ProtoMessage()    {}
+func (*Operation) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{31}
+}
+
+func (m *Operation) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Operation.Unmarshal(m, b)
+}
+func (m *Operation) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Operation.Marshal(b, m, deterministic)
+}
+func (m *Operation) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Operation.Merge(m, src)
+}
+func (m *Operation) XXX_Size() int {
+	return xxx_messageInfo_Operation.Size(m)
+}
+func (m *Operation) XXX_DiscardUnknown() {
+	xxx_messageInfo_Operation.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Operation proto.InternalMessageInfo
+
+func (m *Operation) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Operation) GetMethod() string {
+	if m != nil {
+		return m.Method
 	}
 	return ""
 }
 
-func (m *Locale) GetLcCollate() string {
-	if m != nil {
-		return m.LcCollate
+func (m *Operation) GetState() OperationState {
+	if m != nil {
+		return m.State
+	}
+	return OperationState_RUNNING
+}
+
+func (m *Operation) GetUser() string {
+	if m != nil {
+		return m.User
+	}
+	return ""
+}
+
+func (m *Operation) GetStartTime() int64 {
+	if m != nil {
+		return m.StartTime
+	}
+	return 0
+}
+
+func (m *Operation) GetEndTime() int64 {
+	if m != nil {
+		return m.EndTime
+	}
+	return 0
+}
+
+func (m *Operation) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type AttachOperationRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AttachOperationRequest) Reset()         { *m = AttachOperationRequest{} }
+func (m *AttachOperationRequest) String() string { return proto.CompactTextString(m) }
+func (*AttachOperationRequest) ProtoMessage()    {}
+func (*AttachOperationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{32}
+}
+
+func (m *AttachOperationRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AttachOperationRequest.Unmarshal(m, b)
+}
+func (m *AttachOperationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AttachOperationRequest.Marshal(b, m, deterministic)
+}
+func (m *AttachOperationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AttachOperationRequest.Merge(m, src)
+}
+func (m *AttachOperationRequest) XXX_Size() int {
+	return xxx_messageInfo_AttachOperationRequest.Size(m)
+}
+func (m *AttachOperationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AttachOperationRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AttachOperationRequest proto.InternalMessageInfo
+
+func (m *AttachOperationRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type CancelOperationRequest struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CancelOperationRequest) Reset()         { *m = CancelOperationRequest{} }
+func (m *CancelOperationRequest) String() string { return proto.CompactTextString(m) }
+func (*CancelOperationRequest) ProtoMessage()    {}
+func (*CancelOperationRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{33}
+}
+
+func (m *CancelOperationRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CancelOperationRequest.Unmarshal(m, b)
+}
+func (m *CancelOperationRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CancelOperationRequest.Marshal(b, m, deterministic)
+}
+func (m *CancelOperationRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CancelOperationRequest.Merge(m, src)
+}
+func (m *CancelOperationRequest) XXX_Size() int {
+	return xxx_messageInfo_CancelOperationRequest.Size(m)
+}
+func (m *CancelOperationRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CancelOperationRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CancelOperationRequest proto.InternalMessageInfo
+
+func (m *CancelOperationRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type CancelOperationReply struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CancelOperationReply) Reset()         { *m = CancelOperationReply{} }
+func (m *CancelOperationReply) String() string { return proto.CompactTextString(m) }
+func (*CancelOperationReply) ProtoMessage()    {}
+func (*CancelOperationReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{34}
+}
+
+func (m *CancelOperationReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CancelOperationReply.Unmarshal(m, b)
+}
+func (m *CancelOperationReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CancelOperationReply.Marshal(b, m, deterministic)
+}
+func (m *CancelOperationReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CancelOperationReply.Merge(m, src)
+}
+func (m *CancelOperationReply) XXX_Size() int {
+	return xxx_messageInfo_CancelOperationReply.Size(m)
+}
+func (m *CancelOperationReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_CancelOperationReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CancelOperationReply proto.InternalMessageInfo
+
+type CleanInitClusterRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CleanInitClusterRequest) Reset()         { *m = CleanInitClusterRequest{} }
+func (m *CleanInitClusterRequest) String() string { return proto.CompactTextString(m) }
+func (*CleanInitClusterRequest) ProtoMessage()    {}
+func (*CleanInitClusterRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{35}
+}
+
+func (m *CleanInitClusterRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CleanInitClusterRequest.Unmarshal(m, b)
+}
+func (m *CleanInitClusterRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CleanInitClusterRequest.Marshal(b, m, deterministic)
+}
+func (m *CleanInitClusterRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CleanInitClusterRequest.Merge(m, src)
+}
+func (m *CleanInitClusterRequest) XXX_Size() int {
+	return xxx_messageInfo_CleanInitClusterRequest.Size(m)
+}
+func (m *CleanInitClusterRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CleanInitClusterRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CleanInitClusterRequest proto.InternalMessageInfo
+
+type CleanInitClusterReply struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CleanInitClusterReply) Reset()         { *m = CleanInitClusterReply{} }
+func (m *CleanInitClusterReply) String() string { return proto.CompactTextString(m) }
+func (*CleanInitClusterReply) ProtoMessage()    {}
+func (*CleanInitClusterReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{36}
+}
+
+func (m *CleanInitClusterReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CleanInitClusterReply.Unmarshal(m, b)
+}
+func (m *CleanInitClusterReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CleanInitClusterReply.Marshal(b, m, deterministic)
+}
+func (m *CleanInitClusterReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CleanInitClusterReply.Merge(m, src)
+}
+func (m *CleanInitClusterReply) XXX_Size() int {
+	return xxx_messageInfo_CleanInitClusterReply.Size(m)
+}
+func (m *CleanInitClusterReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_CleanInitClusterReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CleanInitClusterReply proto.InternalMessageInfo
+
+type ServiceStatus struct {
+	Role                 string   `protobuf:"bytes,1,opt,name=role,proto3" json:"role,omitempty"`
+	Host                 string   `protobuf:"bytes,2,opt,name=host,proto3" json:"host,omitempty"`
+	Status               string   `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Uptime               string   `protobuf:"bytes,4,opt,name=uptime,proto3" json:"uptime,omitempty"`
+	Pid                  uint32   `protobuf:"varint,5,opt,name=pid,proto3" json:"pid,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ServiceStatus) Reset()         { *m = ServiceStatus{} }
+func (m *ServiceStatus) String() string { return proto.CompactTextString(m) }
+func (*ServiceStatus) ProtoMessage()    {}
+func (*ServiceStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{37}
+}
+
+func (m *ServiceStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ServiceStatus.Unmarshal(m, b)
+}
+func (m *ServiceStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ServiceStatus.Marshal(b, m, deterministic)
+}
+func (m *ServiceStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ServiceStatus.Merge(m, src)
+}
+func (m *ServiceStatus) XXX_Size() int {
+	return xxx_messageInfo_ServiceStatus.Size(m)
+}
+func (m *ServiceStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_ServiceStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ServiceStatus proto.InternalMessageInfo
+
+func (m *ServiceStatus) GetRole() string {
+	if m != nil {
+		return m.Role
+	}
+	return ""
+}
+
+func (m *ServiceStatus) GetHost() string {
+	if m != nil {
+		return m.Host
+	}
+	return ""
+}
+
+func (m *ServiceStatus) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *ServiceStatus) GetUptime() string {
+	if m != nil {
+		return m.Uptime
+	}
+	return ""
+}
+
+func (m *ServiceStatus) GetPid() uint32 {
+	if m != nil {
+		return m.Pid
+	}
+	return 0
+}
+
+type StatusAgentsReply struct {
+	Statuses             []*ServiceStatus `protobuf:"bytes,1,rep,name=statuses,proto3" json:"statuses,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *StatusAgentsReply) Reset()         { *m = StatusAgentsReply{} }
+func (m *StatusAgentsReply) String() string { return proto.CompactTextString(m) }
+func (*StatusAgentsReply) ProtoMessage()    {}
+func (*StatusAgentsReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{38}
+}
+
+func (m *StatusAgentsReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StatusAgentsReply.Unmarshal(m, b)
+}
+func (m *StatusAgentsReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StatusAgentsReply.Marshal(b, m, deterministic)
+}
+func (m *StatusAgentsReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StatusAgentsReply.Merge(m, src)
+}
+func (m *StatusAgentsReply) XXX_Size() int {
+	return xxx_messageInfo_StatusAgentsReply.Size(m)
+}
+func (m *StatusAgentsReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_StatusAgentsReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StatusAgentsReply proto.InternalMessageInfo
+
+func (m *StatusAgentsReply) GetStatuses() []*ServiceStatus {
+	if m != nil {
+		return m.Statuses
+	}
+	return nil
+}
+
+type StopAgentsRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StopAgentsRequest) Reset()         { *m = StopAgentsRequest{} }
+func (m *StopAgentsRequest) String() string { return proto.CompactTextString(m) }
+func (*StopAgentsRequest) ProtoMessage()    {}
+func (*StopAgentsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{39}
+}
+
+func (m *StopAgentsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StopAgentsRequest.Unmarshal(m, b)
+}
+func (m *StopAgentsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StopAgentsRequest.Marshal(b, m, deterministic)
+}
+func (m *StopAgentsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StopAgentsRequest.Merge(m, src)
+}
+func (m *StopAgentsRequest) XXX_Size() int {
+	return xxx_messageInfo_StopAgentsRequest.Size(m)
+}
+func (m *StopAgentsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StopAgentsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StopAgentsRequest proto.InternalMessageInfo
+
+type StopAgentsReply struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StopAgentsReply) Reset()         { *m = StopAgentsReply{} }
+func (m *StopAgentsReply) String() string { return proto.CompactTextString(m) }
+func (*StopAgentsReply) ProtoMessage()    {}
+func (*StopAgentsReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{40}
+}
+
+func (m *StopAgentsReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StopAgentsReply.Unmarshal(m, b)
+}
+func (m *StopAgentsReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StopAgentsReply.Marshal(b, m, deterministic)
+}
+func (m *StopAgentsReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StopAgentsReply.Merge(m, src)
+}
+func (m *StopAgentsReply) XXX_Size() int {
+	return xxx_messageInfo_StopAgentsReply.Size(m)
+}
+func (m *StopAgentsReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_StopAgentsReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StopAgentsReply proto.InternalMessageInfo
+
+type MakeClusterRequest struct {
+	GpArray              *GpArray       `protobuf:"bytes,1,opt,name=gpArray,proto3" json:"gpArray,omitempty"`
+	ClusterParams        *ClusterParams `protobuf:"bytes,2,opt,name=clusterParams,proto3" json:"clusterParams,omitempty"`
+	ForceFlag            bool           `protobuf:"varint,3,opt,name=forceFlag,proto3" json:"forceFlag,omitempty"`
+	Verbose              bool           `protobuf:"varint,4,opt,name=verbose,proto3" json:"verbose,omitempty"`
+	DryRun               bool           `protobuf:"varint,5,opt,name=dryRun,proto3" json:"dryRun,omitempty"`
+	Resume               bool           `protobuf:"varint,6,opt,name=resume,proto3" json:"resume,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *MakeClusterRequest) Reset()         { *m = MakeClusterRequest{} }
+func (m *MakeClusterRequest) String() string { return proto.CompactTextString(m) }
+func (*MakeClusterRequest) ProtoMessage()    {}
+func (*MakeClusterRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{41}
+}
+
+func (m *MakeClusterRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MakeClusterRequest.Unmarshal(m, b)
+}
+func (m *MakeClusterRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MakeClusterRequest.Marshal(b, m, deterministic)
+}
+func (m *MakeClusterRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MakeClusterRequest.Merge(m, src)
+}
+func (m *MakeClusterRequest) XXX_Size() int {
+	return xxx_messageInfo_MakeClusterRequest.Size(m)
+}
+func (m *MakeClusterRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MakeClusterRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MakeClusterRequest proto.InternalMessageInfo
+
+func (m *MakeClusterRequest) GetGpArray() *GpArray {
+	if m != nil {
+		return m.GpArray
+	}
+	return nil
+}
+
+func (m *MakeClusterRequest) GetClusterParams() *ClusterParams {
+	if m != nil {
+		return m.ClusterParams
+	}
+	return nil
+}
+
+func (m *MakeClusterRequest) GetForceFlag() bool {
+	if m != nil {
+		return m.ForceFlag
+	}
+	return false
+}
+
+func (m *MakeClusterRequest) GetVerbose() bool {
+	if m != nil {
+		return m.Verbose
+	}
+	return false
+}
+
+func (m *MakeClusterRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+func (m *MakeClusterRequest) GetResume() bool {
+	if m != nil {
+		return m.Resume
+	}
+	return false
+}
+
+type HubReply struct {
+	// Types that are valid to be assigned to Message:
+	//	*HubReply_LogMsg
+	//	*HubReply_StdoutMsg
+	//	*HubReply_ProgressMsg
+	Message              isHubReply_Message `protobuf_oneof:"message"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *HubReply) Reset()         { *m = HubReply{} }
+func (m *HubReply) String() string { return proto.CompactTextString(m) }
+func (*HubReply) ProtoMessage()    {}
+func (*HubReply) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{42}
+}
+
+func (m *HubReply) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HubReply.Unmarshal(m, b)
+}
+func (m *HubReply) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HubReply.Marshal(b, m, deterministic)
+}
+func (m *HubReply) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HubReply.Merge(m, src)
+}
+func (m *HubReply) XXX_Size() int {
+	return xxx_messageInfo_HubReply.Size(m)
+}
+func (m *HubReply) XXX_DiscardUnknown() {
+	xxx_messageInfo_HubReply.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HubReply proto.InternalMessageInfo
+
+type isHubReply_Message interface {
+	isHubReply_Message()
+}
+
+type HubReply_LogMsg struct {
+	LogMsg *LogMessage `protobuf:"bytes,1,opt,name=logMsg,proto3,oneof"`
+}
+
+type HubReply_StdoutMsg struct {
+	StdoutMsg string `protobuf:"bytes,2,opt,name=stdoutMsg,proto3,oneof"`
+}
+
+type HubReply_ProgressMsg struct {
+	ProgressMsg *ProgressMessage `protobuf:"bytes,3,opt,name=progressMsg,proto3,oneof"`
+}
+
+func (*HubReply_LogMsg) isHubReply_Message() {}
+
+func (*HubReply_StdoutMsg) isHubReply_Message() {}
+
+func (*HubReply_ProgressMsg) isHubReply_Message() {}
+
+func (m *HubReply) GetMessage() isHubReply_Message {
+	if m != nil {
+		return m.Message
+	}
+	return nil
+}
+
+func (m *HubReply) GetLogMsg() *LogMessage {
+	if x, ok := m.GetMessage().(*HubReply_LogMsg); ok {
+		return x.LogMsg
+	}
+	return nil
+}
+
+func (m *HubReply) GetStdoutMsg() string {
+	if x, ok := m.GetMessage().(*HubReply_StdoutMsg); ok {
+		return x.StdoutMsg
+	}
+	return ""
+}
+
+func (m *HubReply) GetProgressMsg() *ProgressMessage {
+	if x, ok := m.GetMessage().(*HubReply_ProgressMsg); ok {
+		return x.ProgressMsg
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*HubReply) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*HubReply_LogMsg)(nil),
+		(*HubReply_StdoutMsg)(nil),
+		(*HubReply_ProgressMsg)(nil),
+	}
+}
+
+type LogMessage struct {
+	Message              string   `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Level                LogLevel `protobuf:"varint,2,opt,name=level,proto3,enum=idl.LogLevel" json:"level,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *LogMessage) Reset()         { *m = LogMessage{} }
+func (m *LogMessage) String() string { return proto.CompactTextString(m) }
+func (*LogMessage) ProtoMessage()    {}
+func (*LogMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{43}
+}
+
+func (m *LogMessage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LogMessage.Unmarshal(m, b)
+}
+func (m *LogMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LogMessage.Marshal(b, m, deterministic)
+}
+func (m *LogMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LogMessage.Merge(m, src)
+}
+func (m *LogMessage) XXX_Size() int {
+	return xxx_messageInfo_LogMessage.Size(m)
+}
+func (m *LogMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_LogMessage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LogMessage proto.InternalMessageInfo
+
+func (m *LogMessage) GetMessage() string {
+	if m != nil {
+		return m.Message
+	}
+	return ""
+}
+
+func (m *LogMessage) GetLevel() LogLevel {
+	if m != nil {
+		return m.Level
+	}
+	return LogLevel_FATAL
+}
+
+type ProgressMessage struct {
+	Label                string   `protobuf:"bytes,1,opt,name=label,proto3" json:"label,omitempty"`
+	Current              int32    `protobuf:"varint,2,opt,name=current,proto3" json:"current,omitempty"`
+	Total                int32    `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ProgressMessage) Reset()         { *m = ProgressMessage{} }
+func (m *ProgressMessage) String() string { return proto.CompactTextString(m) }
+func (*ProgressMessage) ProtoMessage()    {}
+func (*ProgressMessage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{44}
+}
+
+func (m *ProgressMessage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ProgressMessage.Unmarshal(m, b)
+}
+func (m *ProgressMessage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ProgressMessage.Marshal(b, m, deterministic)
+}
+func (m *ProgressMessage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProgressMessage.Merge(m, src)
+}
+func (m *ProgressMessage) XXX_Size() int {
+	return xxx_messageInfo_ProgressMessage.Size(m)
+}
+func (m *ProgressMessage) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProgressMessage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProgressMessage proto.InternalMessageInfo
+
+func (m *ProgressMessage) GetLabel() string {
+	if m != nil {
+		return m.Label
+	}
+	return ""
+}
+
+func (m *ProgressMessage) GetCurrent() int32 {
+	if m != nil {
+		return m.Current
+	}
+	return 0
+}
+
+func (m *ProgressMessage) GetTotal() int32 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+type GpArray struct {
+	Coordinator          *Segment       `protobuf:"bytes,1,opt,name=Coordinator,proto3" json:"Coordinator,omitempty"`
+	SegmentArray         []*SegmentPair `protobuf:"bytes,2,rep,name=SegmentArray,proto3" json:"SegmentArray,omitempty"`
+	Standby              *Segment       `protobuf:"bytes,3,opt,name=Standby,proto3" json:"Standby,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *GpArray) Reset()         { *m = GpArray{} }
+func (m *GpArray) String() string { return proto.CompactTextString(m) }
+func (*GpArray) ProtoMessage()    {}
+func (*GpArray) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{45}
+}
+
+func (m *GpArray) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GpArray.Unmarshal(m, b)
+}
+func (m *GpArray) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GpArray.Marshal(b, m, deterministic)
+}
+func (m *GpArray) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GpArray.Merge(m, src)
+}
+func (m *GpArray) XXX_Size() int {
+	return xxx_messageInfo_GpArray.Size(m)
+}
+func (m *GpArray) XXX_DiscardUnknown() {
+	xxx_messageInfo_GpArray.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GpArray proto.InternalMessageInfo
+
+func (m *GpArray) GetCoordinator() *Segment {
+	if m != nil {
+		return m.Coordinator
+	}
+	return nil
+}
+
+func (m *GpArray) GetSegmentArray() []*SegmentPair {
+	if m != nil {
+		return m.SegmentArray
+	}
+	return nil
+}
+
+func (m *GpArray) GetStandby() *Segment {
+	if m != nil {
+		return m.Standby
+	}
+	return nil
+}
+
+type Segment struct {
+	Port                 int32    `protobuf:"varint,1,opt,name=port,proto3" json:"port,omitempty"`
+	DataDirectory        string   `protobuf:"bytes,2,opt,name=dataDirectory,proto3" json:"dataDirectory,omitempty"`
+	HostName             string   `protobuf:"bytes,3,opt,name=hostName,proto3" json:"hostName,omitempty"`
+	HostAddress          string   `protobuf:"bytes,4,opt,name=hostAddress,proto3" json:"hostAddress,omitempty"`
+	Contentid            int32    `protobuf:"varint,5,opt,name=contentid,proto3" json:"contentid,omitempty"`
+	Dbid                 int32    `protobuf:"varint,6,opt,name=dbid,proto3" json:"dbid,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Segment) Reset()         { *m = Segment{} }
+func (m *Segment) String() string { return proto.CompactTextString(m) }
+func (*Segment) ProtoMessage()    {}
+func (*Segment) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{46}
+}
+
+func (m *Segment) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Segment.Unmarshal(m, b)
+}
+func (m *Segment) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Segment.Marshal(b, m, deterministic)
+}
+func (m *Segment) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Segment.Merge(m, src)
+}
+func (m *Segment) XXX_Size() int {
+	return xxx_messageInfo_Segment.Size(m)
+}
+func (m *Segment) XXX_DiscardUnknown() {
+	xxx_messageInfo_Segment.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Segment proto.InternalMessageInfo
+
+func (m *Segment) GetPort() int32 {
+	if m != nil {
+		return m.Port
+	}
+	return 0
+}
+
+func (m *Segment) GetDataDirectory() string {
+	if m != nil {
+		return m.DataDirectory
+	}
+	return ""
+}
+
+func (m *Segment) GetHostName() string {
+	if m != nil {
+		return m.HostName
+	}
+	return ""
+}
+
+func (m *Segment) GetHostAddress() string {
+	if m != nil {
+		return m.HostAddress
+	}
+	return ""
+}
+
+func (m *Segment) GetContentid() int32 {
+	if m != nil {
+		return m.Contentid
+	}
+	return 0
+}
+
+func (m *Segment) GetDbid() int32 {
+	if m != nil {
+		return m.Dbid
+	}
+	return 0
+}
+
+type SegmentPair struct {
+	Primary              *Segment `protobuf:"bytes,1,opt,name=primary,proto3" json:"primary,omitempty"`
+	Mirror               *Segment `protobuf:"bytes,2,opt,name=mirror,proto3" json:"mirror,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SegmentPair) Reset()         { *m = SegmentPair{} }
+func (m *SegmentPair) String() string { return proto.CompactTextString(m) }
+func (*SegmentPair) ProtoMessage()    {}
+func (*SegmentPair) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{47}
+}
+
+func (m *SegmentPair) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SegmentPair.Unmarshal(m, b)
+}
+func (m *SegmentPair) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SegmentPair.Marshal(b, m, deterministic)
+}
+func (m *SegmentPair) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SegmentPair.Merge(m, src)
+}
+func (m *SegmentPair) XXX_Size() int {
+	return xxx_messageInfo_SegmentPair.Size(m)
+}
+func (m *SegmentPair) XXX_DiscardUnknown() {
+	xxx_messageInfo_SegmentPair.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SegmentPair proto.InternalMessageInfo
+
+func (m *SegmentPair) GetPrimary() *Segment {
+	if m != nil {
+		return m.Primary
+	}
+	return nil
+}
+
+func (m *SegmentPair) GetMirror() *Segment {
+	if m != nil {
+		return m.Mirror
+	}
+	return nil
+}
+
+type ClusterParams struct {
+	CoordinatorConfig    map[string]string `protobuf:"bytes,1,rep,name=CoordinatorConfig,proto3" json:"CoordinatorConfig,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	SegmentConfig        map[string]string `protobuf:"bytes,2,rep,name=SegmentConfig,proto3" json:"SegmentConfig,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	CommonConfig         map[string]string `protobuf:"bytes,3,rep,name=CommonConfig,proto3" json:"CommonConfig,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Locale               *Locale           `protobuf:"bytes,4,opt,name=locale,proto3" json:"locale,omitempty"`
+	HbaHostnames         bool              `protobuf:"varint,5,opt,name=hbaHostnames,proto3" json:"hbaHostnames,omitempty"`
+	Encoding             string            `protobuf:"bytes,6,opt,name=encoding,proto3" json:"encoding,omitempty"`
+	SuPassword           string            `protobuf:"bytes,7,opt,name=suPassword,proto3" json:"suPassword,omitempty"`
+	DbName               string            `protobuf:"bytes,8,opt,name=dbName,proto3" json:"dbName,omitempty"`
+	DataChecksums        bool              `protobuf:"varint,9,opt,name=dataChecksums,proto3" json:"dataChecksums,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *ClusterParams) Reset()         { *m = ClusterParams{} }
+func (m *ClusterParams) String() string { return proto.CompactTextString(m) }
+func (*ClusterParams) ProtoMessage()    {}
+func (*ClusterParams) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{48}
+}
+
+func (m *ClusterParams) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ClusterParams.Unmarshal(m, b)
+}
+func (m *ClusterParams) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ClusterParams.Marshal(b, m, deterministic)
+}
+func (m *ClusterParams) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ClusterParams.Merge(m, src)
+}
+func (m *ClusterParams) XXX_Size() int {
+	return xxx_messageInfo_ClusterParams.Size(m)
+}
+func (m *ClusterParams) XXX_DiscardUnknown() {
+	xxx_messageInfo_ClusterParams.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ClusterParams proto.InternalMessageInfo
+
+func (m *ClusterParams) GetCoordinatorConfig() map[string]string {
+	if m != nil {
+		return m.CoordinatorConfig
+	}
+	return nil
+}
+
+func (m *ClusterParams) GetSegmentConfig() map[string]string {
+	if m != nil {
+		return m.SegmentConfig
+	}
+	return nil
+}
+
+func (m *ClusterParams) GetCommonConfig() map[string]string {
+	if m != nil {
+		return m.CommonConfig
+	}
+	return nil
+}
+
+func (m *ClusterParams) GetLocale() *Locale {
+	if m != nil {
+		return m.Locale
+	}
+	return nil
+}
+
+func (m *ClusterParams) GetHbaHostnames() bool {
+	if m != nil {
+		return m.HbaHostnames
+	}
+	return false
+}
+
+func (m *ClusterParams) GetEncoding() string {
+	if m != nil {
+		return m.Encoding
+	}
+	return ""
+}
+
+func (m *ClusterParams) GetSuPassword() string {
+	if m != nil {
+		return m.SuPassword
+	}
+	return ""
+}
+
+func (m *ClusterParams) GetDbName() string {
+	if m != nil {
+		return m.DbName
+	}
+	return ""
+}
+
+func (m *ClusterParams) GetDataChecksums() bool {
+	if m != nil {
+		return m.DataChecksums
+	}
+	return false
+}
+
+type Locale struct {
+	LcAll                string   `protobuf:"bytes,1,opt,name=lc_all,json=lcAll,proto3" json:"lc_all,omitempty"`
+	LcCollate            string   `protobuf:"bytes,2,opt,name=lc_collate,json=lcCollate,proto3" json:"lc_collate,omitempty"`
+	LcCtype              string   `protobuf:"bytes,3,opt,name=lc_ctype,json=lcCtype,proto3" json:"lc_ctype,omitempty"`
+	LcMessages           string   `protobuf:"bytes,4,opt,name=lc_messages,json=lcMessages,proto3" json:"lc_messages,omitempty"`
+	LcMonetory           string   `protobuf:"bytes,5,opt,name=lc_monetory,json=lcMonetory,proto3" json:"lc_monetory,omitempty"`
+	LcNumeric            string   `protobuf:"bytes,6,opt,name=lc_numeric,json=lcNumeric,proto3" json:"lc_numeric,omitempty"`
+	LcTime               string   `protobuf:"bytes,7,opt,name=lc_time,json=lcTime,proto3" json:"lc_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Locale) Reset()         { *m = Locale{} }
+func (m *Locale) String() string { return proto.CompactTextString(m) }
+func (*Locale) ProtoMessage()    {}
+func (*Locale) Descriptor() ([]byte, []int) {
+	return fileDescriptor_b3103f8d3056b01c, []int{49}
+}
+
+func (m *Locale) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Locale.Unmarshal(m, b)
+}
+func (m *Locale) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Locale.Marshal(b, m, deterministic)
+}
+func (m *Locale) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Locale.Merge(m, src)
+}
+func (m *Locale) XXX_Size() int {
+	return xxx_messageInfo_Locale.Size(m)
+}
+func (m *Locale) XXX_DiscardUnknown() {
+	xxx_messageInfo_Locale.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Locale proto.InternalMessageInfo
+
+func (m *Locale) GetLcAll() string {
+	if m != nil {
+		return m.LcAll
+	}
+	return ""
+}
+
+func (m *Locale) GetLcCollate() string {
+	if m != nil {
+		return m.LcCollate
+	}
+	return ""
+}
+
+func (m *Locale) GetLcCtype() string {
+	if m != nil {
+		return m.LcCtype
+	}
+	return ""
+}
+
+func (m *Locale) GetLcMessages() string {
+	if m != nil {
+		return m.LcMessages
+	}
+	return ""
+}
+
+func (m *Locale) GetLcMonetory() string {
+	if m != nil {
+		return m.LcMonetory
+	}
+	return ""
+}
+
+func (m *Locale) GetLcNumeric() string {
+	if m != nil {
+		return m.LcNumeric
+	}
+	return ""
+}
+
+func (m *Locale) GetLcTime() string {
+	if m != nil {
+		return m.LcTime
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterEnum("idl.OperationState", OperationState_name, OperationState_value)
+	proto.RegisterEnum("idl.LogLevel", LogLevel_name, LogLevel_value)
+	proto.RegisterType((*StartClusterRequest)(nil), "idl.StartClusterRequest")
+	proto.RegisterType((*StopClusterRequest)(nil), "idl.StopClusterRequest")
+	proto.RegisterType((*ClusterStatusRequest)(nil), "idl.ClusterStatusRequest")
+	proto.RegisterType((*SegmentStatus)(nil), "idl.SegmentStatus")
+	proto.RegisterType((*ClusterStatusReply)(nil), "idl.ClusterStatusReply")
+	proto.RegisterType((*RecoverSegmentsRequest)(nil), "idl.RecoverSegmentsRequest")
+	proto.RegisterType((*RecoverSegmentPair)(nil), "idl.RecoverSegmentPair")
+	proto.RegisterType((*ExpandClusterRequest)(nil), "idl.ExpandClusterRequest")
+	proto.RegisterType((*AddStandbyRequest)(nil), "idl.AddStandbyRequest")
+	proto.RegisterType((*RemoveStandbyRequest)(nil), "idl.RemoveStandbyRequest")
+	proto.RegisterType((*ActivateStandbyRequest)(nil), "idl.ActivateStandbyRequest")
+	proto.RegisterType((*AddMirrorsRequest)(nil), "idl.AddMirrorsRequest")
+	proto.RegisterType((*GetAllHostNamesRequest)(nil), "idl.GetAllHostNamesRequest")
+	proto.RegisterType((*GetAllHostNamesReply)(nil), "idl.GetAllHostNamesReply")
+	proto.RegisterMapType((map[string]string)(nil), "idl.GetAllHostNamesReply.HostNameMapEntry")
+	proto.RegisterType((*StopHubRequest)(nil), "idl.StopHubRequest")
+	proto.RegisterType((*StopHubReply)(nil), "idl.StopHubReply")
+	proto.RegisterType((*StartAgentsRequest)(nil), "idl.StartAgentsRequest")
+	proto.RegisterType((*StartAgentsReply)(nil), "idl.StartAgentsReply")
+	proto.RegisterType((*StatusAgentsRequest)(nil), "idl.StatusAgentsRequest")
+	proto.RegisterType((*ReportAgentHealthRequest)(nil), "idl.ReportAgentHealthRequest")
+	proto.RegisterType((*ReportAgentHealthResponse)(nil), "idl.ReportAgentHealthResponse")
+	proto.RegisterType((*UpdateHostsRequest)(nil), "idl.UpdateHostsRequest")
+	proto.RegisterType((*UpdateHostsReply)(nil), "idl.UpdateHostsReply")
+	proto.RegisterType((*PushFileRequest)(nil), "idl.PushFileRequest")
+	proto.RegisterType((*PushFileReply)(nil), "idl.PushFileReply")
+	proto.RegisterType((*CollectFileRequest)(nil), "idl.CollectFileRequest")
+	proto.RegisterType((*CollectFileReply)(nil), "idl.CollectFileReply")
+	proto.RegisterType((*ReloadCredentialsRequest)(nil), "idl.ReloadCredentialsRequest")
+	proto.RegisterType((*ReloadCredentialsReply)(nil), "idl.ReloadCredentialsReply")
+	proto.RegisterType((*ListOperationsRequest)(nil), "idl.ListOperationsRequest")
+	proto.RegisterType((*ListOperationsReply)(nil), "idl.ListOperationsReply")
+	proto.RegisterType((*Operation)(nil), "idl.Operation")
+	proto.RegisterType((*AttachOperationRequest)(nil), "idl.AttachOperationRequest")
+	proto.RegisterType((*CancelOperationRequest)(nil), "idl.CancelOperationRequest")
+	proto.RegisterType((*CancelOperationReply)(nil), "idl.CancelOperationReply")
+	proto.RegisterType((*CleanInitClusterRequest)(nil), "idl.CleanInitClusterRequest")
+	proto.RegisterType((*CleanInitClusterReply)(nil), "idl.CleanInitClusterReply")
+	proto.RegisterType((*ServiceStatus)(nil), "idl.ServiceStatus")
+	proto.RegisterType((*StatusAgentsReply)(nil), "idl.StatusAgentsReply")
+	proto.RegisterType((*StopAgentsRequest)(nil), "idl.StopAgentsRequest")
+	proto.RegisterType((*StopAgentsReply)(nil), "idl.StopAgentsReply")
+	proto.RegisterType((*MakeClusterRequest)(nil), "idl.MakeClusterRequest")
+	proto.RegisterType((*HubReply)(nil), "idl.HubReply")
+	proto.RegisterType((*LogMessage)(nil), "idl.LogMessage")
+	proto.RegisterType((*ProgressMessage)(nil), "idl.ProgressMessage")
+	proto.RegisterType((*GpArray)(nil), "idl.gpArray")
+	proto.RegisterType((*Segment)(nil), "idl.Segment")
+	proto.RegisterType((*SegmentPair)(nil), "idl.SegmentPair")
+	proto.RegisterType((*ClusterParams)(nil), "idl.ClusterParams")
+	proto.RegisterMapType((map[string]string)(nil), "idl.ClusterParams.CommonConfigEntry")
+	proto.RegisterMapType((map[string]string)(nil), "idl.ClusterParams.CoordinatorConfigEntry")
+	proto.RegisterMapType((map[string]string)(nil), "idl.ClusterParams.SegmentConfigEntry")
+	proto.RegisterType((*Locale)(nil), "idl.Locale")
+}
+
+func init() { proto.RegisterFile("hub.proto", fileDescriptor_b3103f8d3056b01c) }
+
+var fileDescriptor_b3103f8d3056b01c = []byte{
+	// 2254 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x59, 0xcd, 0x72, 0x1b, 0xb9,
+	0xf1, 0xd7, 0x88, 0xe2, 0x57, 0x53, 0x94, 0x28, 0x58, 0xa2, 0x28, 0xda, 0xeb, 0xbf, 0x6b, 0xd6,
+	0x7f, 0x97, 0xed, 0x83, 0xb2, 0xa5, 0x6c, 0x25, 0xde, 0x7c, 0x39, 0x14, 0x45, 0x59, 0x2e, 0x4b,
+	0xb2, 0x32, 0xb2, 0xcb, 0x55, 0xc9, 0xc1, 0x0b, 0xcd, 0xc0, 0xe4, 0x94, 0xc1, 0x19, 0x06, 0x83,
+	0x51, 0xc2, 0xbc, 0x42, 0x0e, 0xb9, 0xa4, 0x2a, 0x55, 0x39, 0xe7, 0x0d, 0x72, 0x4e, 0x2a, 0x87,
+	0xbc, 0x41, 0xee, 0x79, 0x83, 0xbc, 0x43, 0xaa, 0x01, 0xcc, 0x17, 0x39, 0xca, 0xc6, 0x51, 0xed,
+	0x6d, 0xfa, 0x03, 0x8d, 0x46, 0xa3, 0xd1, 0xf8, 0x35, 0x06, 0x9a, 0x93, 0xf8, 0x6a, 0x7f, 0x26,
+	0x42, 0x19, 0x92, 0x8a, 0xef, 0x71, 0x7b, 0x04, 0x77, 0x2e, 0x25, 0x15, 0x72, 0xc8, 0xe3, 0x48,
+	0x32, 0xe1, 0xb0, 0x5f, 0xc6, 0x2c, 0x92, 0x64, 0x1f, 0xc8, 0x30, 0x0c, 0x85, 0xe7, 0x07, 0x54,
+	0x86, 0xe2, 0x88, 0x4a, 0x7a, 0xe4, 0x8b, 0x9e, 0xf5, 0xc0, 0x7a, 0xdc, 0x74, 0x4a, 0x24, 0xb6,
+	0x00, 0x72, 0x29, 0xc3, 0xd9, 0xed, 0xac, 0x10, 0x02, 0x6b, 0x67, 0xa1, 0xc7, 0x7a, 0xab, 0x4a,
+	0x43, 0x7d, 0x93, 0x1e, 0xd4, 0xdf, 0xf8, 0x53, 0x16, 0xc6, 0xb2, 0x57, 0x79, 0x60, 0x3d, 0xae,
+	0x3a, 0x09, 0x69, 0x1f, 0xc3, 0xb6, 0x99, 0xef, 0x52, 0x52, 0x19, 0x47, 0xff, 0xab, 0xef, 0xff,
+	0x5a, 0x85, 0xf6, 0x25, 0x1b, 0x4f, 0x59, 0x20, 0xb5, 0x21, 0xf4, 0xc3, 0xbb, 0xf2, 0x3d, 0x35,
+	0xa6, 0xea, 0xa8, 0x6f, 0xf4, 0xc3, 0x0d, 0x03, 0xc9, 0x02, 0xa9, 0xdc, 0xab, 0x3a, 0x09, 0x89,
+	0xda, 0x22, 0xe4, 0x4c, 0xb9, 0xd7, 0x74, 0xd4, 0x37, 0x79, 0x08, 0xed, 0x99, 0x60, 0x1f, 0x98,
+	0x10, 0xcc, 0x73, 0x50, 0xb8, 0xa6, 0x84, 0x45, 0x26, 0x8e, 0x9c, 0xe2, 0x7a, 0xab, 0x7a, 0x24,
+	0x7e, 0x93, 0x2e, 0xd4, 0x22, 0xe5, 0x45, 0xaf, 0xa6, 0xb8, 0x86, 0x22, 0x7d, 0x68, 0x4c, 0xc2,
+	0x48, 0x06, 0x74, 0xca, 0x7a, 0x75, 0x25, 0x49, 0x69, 0xf4, 0x8d, 0x7a, 0x9e, 0x60, 0x51, 0xd4,
+	0x6b, 0x28, 0x51, 0x42, 0xe2, 0x0c, 0xb3, 0x50, 0xc8, 0x5e, 0x53, 0xaf, 0x04, 0xbf, 0x51, 0xdb,
+	0x33, 0x41, 0x01, 0xad, 0x6d, 0x48, 0x94, 0x88, 0x38, 0x08, 0xfc, 0x60, 0xdc, 0x6b, 0x3d, 0xb0,
+	0x1e, 0x37, 0x9c, 0x84, 0x24, 0x1d, 0xa8, 0xcc, 0x7c, 0xaf, 0xb7, 0xae, 0xcc, 0xe0, 0x27, 0xb1,
+	0x61, 0xdd, 0xcd, 0xa2, 0xcf, 0x7a, 0x6d, 0x65, 0xaa, 0xc0, 0x23, 0xdb, 0x50, 0x65, 0x42, 0x84,
+	0xa2, 0xb7, 0xa1, 0x84, 0x9a, 0xb0, 0x7f, 0x6f, 0x01, 0x59, 0xd8, 0xb8, 0x19, 0x9f, 0x93, 0x7d,
+	0x68, 0x44, 0x7a, 0x17, 0xa2, 0x9e, 0xf5, 0xa0, 0xf2, 0xb8, 0x75, 0x40, 0xf6, 0x7d, 0x8f, 0xef,
+	0x17, 0xb6, 0xc6, 0x49, 0x75, 0xc8, 0x53, 0xa8, 0xd1, 0xb1, 0xd2, 0x5e, 0x2d, 0x68, 0x8b, 0x6b,
+	0xdf, 0x65, 0x46, 0xdb, 0x68, 0x28, 0x67, 0xa9, 0xa4, 0x3c, 0x1c, 0x8f, 0x94, 0x3f, 0x15, 0xe3,
+	0x6c, 0x8e, 0x67, 0xff, 0xc5, 0x82, 0xae, 0xc3, 0xdc, 0xf0, 0x9a, 0x09, 0x33, 0x65, 0x74, 0x8b,
+	0x3c, 0x3e, 0x8e, 0x39, 0x57, 0x89, 0xd2, 0x70, 0xd4, 0x37, 0xba, 0x70, 0x72, 0x45, 0x4f, 0xcc,
+	0x96, 0x45, 0xca, 0x85, 0x86, 0x53, 0xe0, 0x91, 0x1f, 0xc2, 0xba, 0xd0, 0x1e, 0x5c, 0x50, 0x5f,
+	0x44, 0xbd, 0x35, 0xb5, 0xb0, 0x5d, 0xb5, 0xb0, 0xa2, 0x6b, 0x28, 0x77, 0x0a, 0xca, 0xf6, 0xd7,
+	0x40, 0x96, 0x75, 0xc8, 0x43, 0xa8, 0x7d, 0xa0, 0x3e, 0x67, 0x3a, 0x99, 0x5b, 0x07, 0xeb, 0xf9,
+	0x98, 0x3a, 0x46, 0x86, 0x5a, 0x92, 0x8a, 0x31, 0xd3, 0xb9, 0xbd, 0xa4, 0xa5, 0x65, 0xf6, 0x5f,
+	0x2d, 0xd8, 0x1e, 0xfd, 0x7a, 0x46, 0x03, 0xef, 0x96, 0xe7, 0x7c, 0x31, 0x16, 0xab, 0x25, 0xb1,
+	0xf8, 0x12, 0xd6, 0xa3, 0x6c, 0x1d, 0x18, 0x2f, 0x8c, 0x45, 0x27, 0xef, 0x98, 0x0e, 0x42, 0x5e,
+	0x8b, 0xdc, 0x83, 0xe6, 0x21, 0x95, 0xee, 0xe4, 0xd2, 0xff, 0x8d, 0x3e, 0x73, 0x55, 0x27, 0x63,
+	0xd8, 0xbf, 0xb3, 0x60, 0x6b, 0xe0, 0x79, 0x97, 0x92, 0x06, 0xde, 0xd5, 0xfc, 0xdb, 0xf4, 0xfe,
+	0x11, 0xd4, 0x23, 0x3d, 0x4b, 0xaf, 0x52, 0x12, 0xd1, 0x44, 0x88, 0x35, 0xcc, 0x61, 0xd3, 0xf0,
+	0x9a, 0xdd, 0xce, 0x27, 0xdb, 0x83, 0xee, 0xc0, 0x95, 0xfe, 0x35, 0x95, 0xb7, 0xb4, 0x84, 0x75,
+	0x26, 0x59, 0x86, 0xa9, 0xc3, 0x29, 0x9d, 0xc4, 0xef, 0xcc, 0xc7, 0x03, 0x13, 0x7d, 0xcb, 0xf1,
+	0x9b, 0xea, 0x59, 0xcc, 0xc6, 0x2f, 0xc4, 0xcf, 0x08, 0xed, 0x2f, 0xa1, 0xfb, 0x82, 0xc9, 0x01,
+	0xe7, 0x38, 0xf4, 0x1c, 0x87, 0x26, 0x5e, 0x99, 0x7a, 0x79, 0xea, 0x47, 0x52, 0x95, 0x93, 0xa6,
+	0x93, 0xd2, 0xf6, 0x9f, 0x2c, 0xd8, 0x5e, 0x1a, 0x86, 0x35, 0xe8, 0x14, 0x5a, 0x13, 0xc3, 0x39,
+	0xa3, 0x33, 0x53, 0x86, 0x9e, 0xaa, 0xa9, 0xcb, 0xf4, 0xf7, 0x4f, 0x32, 0xe5, 0x51, 0x20, 0xc5,
+	0xdc, 0xc9, 0x0f, 0xef, 0xff, 0x04, 0x3a, 0x8b, 0x0a, 0x58, 0x48, 0x3f, 0xb2, 0xb9, 0x89, 0x0e,
+	0x7e, 0x62, 0x91, 0xbc, 0xa6, 0x3c, 0x4e, 0xa2, 0xad, 0x89, 0x1f, 0xac, 0x3e, 0xb3, 0xec, 0x0e,
+	0x6c, 0xe0, 0xa5, 0x7a, 0x12, 0x5f, 0x99, 0x45, 0xd9, 0x1b, 0xb0, 0x9e, 0x72, 0x66, 0x7c, 0x6e,
+	0x6f, 0xe3, 0xb5, 0x4b, 0x85, 0x1c, 0x8c, 0x73, 0xe5, 0xca, 0x26, 0xd0, 0x29, 0x70, 0x51, 0x73,
+	0x47, 0xdd, 0xf3, 0x32, 0x8e, 0x8a, 0xaa, 0x7d, 0xe8, 0x39, 0x0c, 0x6f, 0x05, 0xc5, 0x3e, 0x61,
+	0x94, 0xcb, 0x49, 0x22, 0xbb, 0x0b, 0x7b, 0x25, 0xb2, 0x68, 0x16, 0x06, 0x11, 0xb3, 0x0f, 0x80,
+	0xbc, 0x9d, 0x79, 0x54, 0x32, 0x5c, 0x61, 0x1a, 0xf4, 0x7b, 0xd0, 0x9c, 0xa4, 0xfb, 0xaa, 0xa3,
+	0x9e, 0x31, 0xd0, 0xaf, 0xc2, 0x18, 0xf4, 0x2b, 0x82, 0xcd, 0x8b, 0x38, 0x9a, 0x1c, 0xfb, 0x9c,
+	0xfd, 0x57, 0x46, 0xd4, 0x8d, 0x46, 0xe5, 0x24, 0xc1, 0x08, 0xf8, 0x9d, 0xde, 0xa3, 0x78, 0xd4,
+	0xda, 0xe6, 0x1e, 0xed, 0x43, 0xc3, 0x5c, 0xd0, 0x91, 0x2a, 0x04, 0xeb, 0x4e, 0x4a, 0xdb, 0x9b,
+	0xd0, 0xce, 0x26, 0x45, 0x2f, 0xbe, 0xc6, 0x14, 0xe6, 0x9c, 0xb9, 0xf2, 0x76, 0x8e, 0xe0, 0xd5,
+	0xca, 0x22, 0x89, 0xf9, 0x5f, 0x31, 0x57, 0xab, 0x26, 0x71, 0xed, 0x85, 0x19, 0x70, 0x56, 0x15,
+	0x7c, 0x1e, 0x52, 0x6f, 0x28, 0x98, 0xc7, 0x02, 0xe9, 0x53, 0x9e, 0x6e, 0x4c, 0x0f, 0xba, 0x25,
+	0x32, 0x1c, 0xb5, 0x0b, 0x3b, 0x98, 0xc4, 0xaf, 0x67, 0x4c, 0x50, 0xe9, 0x87, 0x41, 0x3a, 0x64,
+	0x04, 0x77, 0x16, 0x05, 0xfa, 0x5e, 0x85, 0x30, 0x65, 0x99, 0x94, 0xde, 0x50, 0x29, 0x9d, 0x6a,
+	0x3a, 0x39, 0x0d, 0xfb, 0x6f, 0x16, 0x34, 0x53, 0x09, 0xd9, 0x80, 0x55, 0x03, 0x84, 0x9a, 0xce,
+	0xaa, 0xef, 0x21, 0x3c, 0x99, 0x32, 0x39, 0x09, 0x3d, 0xb3, 0x6e, 0x43, 0x91, 0x27, 0x50, 0x8d,
+	0x14, 0x0e, 0xc0, 0x75, 0x6f, 0x1c, 0xdc, 0x51, 0x13, 0xa4, 0x56, 0x15, 0x1c, 0x70, 0xb4, 0x06,
+	0x06, 0x2e, 0x8e, 0x98, 0x30, 0x90, 0x48, 0x7d, 0x63, 0xa8, 0x23, 0x4c, 0x59, 0xc4, 0x76, 0x0a,
+	0x0e, 0x55, 0x9c, 0x8c, 0x81, 0x61, 0x65, 0x81, 0xa7, 0x64, 0x35, 0x25, 0x4b, 0xc8, 0x0c, 0x61,
+	0xd4, 0xf3, 0x08, 0xe3, 0x31, 0x74, 0x07, 0x52, 0x52, 0x77, 0x92, 0xad, 0xd0, 0x6c, 0xe9, 0xc2,
+	0x72, 0x50, 0x73, 0x48, 0x03, 0x97, 0xf1, 0x6f, 0xd4, 0xec, 0xc2, 0xf6, 0x92, 0x26, 0x6e, 0xc7,
+	0x1e, 0xec, 0x0e, 0x39, 0xa3, 0xc1, 0xcb, 0xc0, 0x5f, 0x00, 0xd1, 0xb8, 0x53, 0xcb, 0x22, 0x1c,
+	0x33, 0x87, 0x76, 0x01, 0xa7, 0xa4, 0x10, 0xd2, 0xca, 0x41, 0x48, 0x02, 0x6b, 0x98, 0x6c, 0x49,
+	0x7e, 0xe1, 0x77, 0x0e, 0x1c, 0x56, 0x0a, 0xe0, 0xb0, 0x0b, 0xb5, 0x78, 0x26, 0x31, 0x3e, 0x3a,
+	0xa8, 0x86, 0x4a, 0x60, 0x5b, 0x55, 0x9d, 0x0b, 0xfc, 0xb4, 0x87, 0xb0, 0x55, 0xac, 0x03, 0x09,
+	0xf4, 0x52, 0x4c, 0xb6, 0x08, 0xbd, 0xf2, 0x60, 0x2a, 0xd5, 0xb1, 0xef, 0xa0, 0x91, 0x70, 0x56,
+	0x2c, 0x25, 0x5b, 0xb0, 0x99, 0x67, 0xe2, 0x3a, 0xff, 0x69, 0x01, 0x39, 0xa3, 0x1f, 0xd9, 0x02,
+	0x5c, 0x78, 0x04, 0xf5, 0xf1, 0x6c, 0x20, 0x04, 0x9d, 0x17, 0x40, 0x89, 0xe1, 0x39, 0x89, 0x90,
+	0x3c, 0x83, 0xb6, 0x81, 0x93, 0x17, 0x54, 0xd0, 0x69, 0x64, 0xc0, 0x89, 0xf6, 0x6d, 0x98, 0x97,
+	0x38, 0x45, 0x45, 0x4c, 0xa7, 0x0f, 0xa1, 0x70, 0xd9, 0x31, 0xa7, 0x63, 0x83, 0xb4, 0x32, 0x06,
+	0xa6, 0xd3, 0x35, 0x13, 0x57, 0x61, 0xa4, 0xc3, 0xd5, 0x70, 0x12, 0x12, 0xe3, 0xe8, 0x89, 0xb9,
+	0x13, 0x07, 0x2a, 0x64, 0x0d, 0xc7, 0x50, 0xc8, 0x17, 0x2c, 0x8a, 0x4d, 0xfe, 0x35, 0x1c, 0x43,
+	0xd9, 0x7f, 0xb4, 0xa0, 0x91, 0x14, 0x63, 0xf2, 0x04, 0x6a, 0x3c, 0x1c, 0x9f, 0x45, 0x63, 0xb3,
+	0xaa, 0x4d, 0xe5, 0xe7, 0x69, 0x38, 0x3e, 0x63, 0x51, 0x44, 0xc7, 0xec, 0x64, 0xc5, 0x31, 0x0a,
+	0xe4, 0x3e, 0xa6, 0xbb, 0x17, 0xc6, 0x12, 0xb5, 0xd5, 0x06, 0x9f, 0xac, 0x38, 0x19, 0x8b, 0x3c,
+	0x83, 0xd6, 0x4c, 0x84, 0x63, 0xc1, 0xa2, 0xe8, 0x2c, 0xd2, 0x2b, 0x68, 0x1d, 0x6c, 0x2b, 0x7b,
+	0x17, 0x09, 0x3f, 0x35, 0x9a, 0x57, 0x3d, 0x6c, 0x42, 0x7d, 0xaa, 0x25, 0xf6, 0x2b, 0x80, 0x6c,
+	0x72, 0xd2, 0x4b, 0x05, 0x26, 0xcb, 0x12, 0x92, 0x7c, 0x0e, 0x55, 0xce, 0xae, 0x99, 0x86, 0xab,
+	0x1b, 0x07, 0x6d, 0x35, 0x0d, 0x0f, 0xc7, 0xa7, 0xc8, 0x74, 0xb4, 0xcc, 0x7e, 0x07, 0x9b, 0x0b,
+	0x33, 0xe3, 0xd9, 0xe3, 0xf4, 0x8a, 0x71, 0x63, 0x4f, 0x13, 0xaa, 0x4f, 0x8a, 0x85, 0xc8, 0xf7,
+	0x49, 0x9a, 0x44, 0x7d, 0x19, 0x4a, 0xca, 0x4d, 0x1f, 0xa7, 0x09, 0xfb, 0x0f, 0x56, 0x9a, 0x0d,
+	0x64, 0x1f, 0x5a, 0x39, 0xbc, 0x50, 0x8a, 0x58, 0xf3, 0x0a, 0x88, 0x11, 0x0d, 0x5f, 0x67, 0xd3,
+	0xea, 0x4d, 0x18, 0x31, 0xaf, 0x85, 0xe9, 0x77, 0xf9, 0x9f, 0xb0, 0x99, 0x11, 0xda, 0x7f, 0xb6,
+	0xa0, 0x6e, 0x98, 0x69, 0x1f, 0x65, 0xe5, 0xfa, 0xa8, 0x87, 0xd0, 0x36, 0x8d, 0x13, 0x73, 0x65,
+	0x28, 0xe6, 0xe6, 0xa4, 0x16, 0x99, 0x09, 0x0e, 0x41, 0x10, 0x60, 0x0e, 0x6d, 0x4a, 0x93, 0x07,
+	0x1a, 0x6e, 0x0c, 0x4c, 0xef, 0xa6, 0xcf, 0x6e, 0x9e, 0x85, 0x89, 0x6c, 0x6e, 0x2d, 0x73, 0x8c,
+	0xab, 0x4e, 0xc6, 0x48, 0xfb, 0xd4, 0x5a, 0xd6, 0xa7, 0xda, 0xbf, 0x80, 0x56, 0x1e, 0xff, 0x3f,
+	0x82, 0xfa, 0x4c, 0xf8, 0x53, 0x2a, 0xe6, 0xa5, 0xe1, 0x4c, 0x84, 0xd8, 0x01, 0x68, 0x4c, 0x55,
+	0xde, 0x01, 0x68, 0x99, 0xfd, 0xdb, 0x2a, 0xb4, 0x0b, 0x07, 0x8f, 0xbc, 0x83, 0xad, 0xdc, 0x8e,
+	0x0c, 0xc3, 0xe0, 0x83, 0x3f, 0x36, 0x35, 0xe4, 0xc9, 0xf2, 0x39, 0xdd, 0x5f, 0xd2, 0xd5, 0xb0,
+	0x69, 0xd9, 0x06, 0x79, 0x95, 0x36, 0xe5, 0xc6, 0xa8, 0xde, 0xdc, 0xff, 0x2f, 0x31, 0x5a, 0xd0,
+	0xd3, 0x06, 0x8b, 0x63, 0xc9, 0x09, 0xac, 0x0f, 0xc3, 0xe9, 0x34, 0x0c, 0x8c, 0x2d, 0x8d, 0x29,
+	0x1f, 0x96, 0x3a, 0x98, 0xa9, 0x69, 0x53, 0x85, 0x91, 0xe4, 0x73, 0x3c, 0xe4, 0x2e, 0x35, 0x1d,
+	0x7d, 0xeb, 0xa0, 0x65, 0x0e, 0x39, 0xb2, 0x1c, 0x23, 0x42, 0x84, 0x3b, 0xc9, 0x23, 0x5c, 0x5d,
+	0x4c, 0x0a, 0x3c, 0xcc, 0x0b, 0x16, 0xb8, 0xa1, 0x87, 0xcd, 0xb6, 0xee, 0xf4, 0x53, 0x9a, 0xdc,
+	0x07, 0x88, 0xe2, 0x0b, 0x1a, 0x45, 0xbf, 0x0a, 0x85, 0x67, 0xae, 0xb6, 0x1c, 0x47, 0x95, 0xa9,
+	0x2b, 0x95, 0x51, 0xba, 0xdd, 0x37, 0x54, 0x92, 0x91, 0xc3, 0x09, 0x73, 0x3f, 0x46, 0xf1, 0x34,
+	0x52, 0x6d, 0x7f, 0xc3, 0x29, 0x32, 0xfb, 0x47, 0xd0, 0x2d, 0xdf, 0x86, 0x4f, 0x01, 0xa7, 0xfd,
+	0x9f, 0x02, 0x59, 0x8e, 0xfb, 0x27, 0x59, 0x78, 0x0e, 0x5b, 0xf9, 0xd0, 0x7e, 0x3a, 0x3e, 0xfe,
+	0x87, 0x05, 0x35, 0x1d, 0x79, 0xb2, 0x03, 0x35, 0xee, 0xbe, 0xa7, 0x3c, 0x2b, 0x46, 0xee, 0x80,
+	0x73, 0xf2, 0x19, 0x00, 0x77, 0xdf, 0xbb, 0x21, 0xe7, 0x54, 0x26, 0x06, 0x9a, 0xdc, 0x1d, 0x6a,
+	0x06, 0xd9, 0x83, 0x06, 0x8a, 0xe5, 0x7c, 0x96, 0x9c, 0xcd, 0x3a, 0x77, 0x87, 0x48, 0x92, 0xff,
+	0x83, 0x16, 0x77, 0xdf, 0x9b, 0x12, 0x99, 0x1c, 0x4d, 0xe0, 0xae, 0x29, 0x7e, 0x51, 0xa2, 0x10,
+	0x06, 0x4c, 0x9d, 0xfd, 0x6a, 0xaa, 0x60, 0x38, 0x66, 0xee, 0x20, 0x9e, 0x32, 0xe1, 0xbb, 0x66,
+	0x8b, 0x9b, 0xdc, 0x3d, 0xd7, 0x0c, 0xb2, 0x0b, 0x75, 0xee, 0xbe, 0x57, 0x77, 0xb6, 0xde, 0xe0,
+	0x1a, 0x77, 0x11, 0xd2, 0x3c, 0x7d, 0x01, 0x1b, 0x45, 0xdc, 0x44, 0x5a, 0x50, 0x77, 0xde, 0x9e,
+	0x9f, 0xbf, 0x3c, 0x7f, 0xd1, 0x59, 0x21, 0x6d, 0x68, 0x5e, 0xbe, 0x1d, 0x0e, 0x47, 0xa3, 0xa3,
+	0xd1, 0x51, 0xc7, 0x22, 0x00, 0xb5, 0xe3, 0xc1, 0xcb, 0xd3, 0xd1, 0x51, 0x67, 0x15, 0x45, 0xc3,
+	0xc1, 0xf9, 0x70, 0x74, 0x8a, 0x64, 0xe5, 0xe9, 0x21, 0x34, 0x92, 0x2a, 0x4e, 0x9a, 0x50, 0x3d,
+	0x1e, 0xbc, 0x19, 0x9c, 0x76, 0x56, 0xf0, 0x73, 0xe4, 0x38, 0xaf, 0x9d, 0x8e, 0x85, 0x86, 0xdf,
+	0x0d, 0x1c, 0x65, 0x78, 0x95, 0x34, 0x60, 0xed, 0xe5, 0xf9, 0xf1, 0xeb, 0x4e, 0x05, 0x35, 0x8e,
+	0x46, 0x87, 0x6f, 0x5f, 0x74, 0xd6, 0x0e, 0xfe, 0xbe, 0x0e, 0x95, 0x93, 0xf8, 0x8a, 0x7c, 0x01,
+	0x6b, 0x78, 0xb9, 0x13, 0x8d, 0xeb, 0x8a, 0x5d, 0x49, 0x7f, 0xab, 0xc8, 0xc4, 0x9b, 0x7f, 0x85,
+	0x3c, 0x87, 0x56, 0xae, 0x09, 0x21, 0xbb, 0x46, 0x67, 0xb1, 0x59, 0xe9, 0xef, 0x2c, 0x0b, 0xb4,
+	0x81, 0x43, 0xec, 0x75, 0x32, 0xa4, 0x42, 0x7a, 0x89, 0xe2, 0x62, 0x13, 0xd3, 0xef, 0x96, 0x48,
+	0xb4, 0x8d, 0x1f, 0x01, 0x64, 0x98, 0x84, 0x74, 0x53, 0x3f, 0x8b, 0xe3, 0xb7, 0x97, 0xf8, 0x7a,
+	0xf4, 0x1b, 0xd8, 0x5a, 0x6a, 0x80, 0xc8, 0x67, 0xe6, 0x35, 0xa6, 0xbc, 0x69, 0xea, 0xdf, 0xbf,
+	0x49, 0x6c, 0xfa, 0xa6, 0x15, 0xf2, 0x15, 0xb4, 0x72, 0x98, 0xc8, 0x04, 0x66, 0x19, 0x25, 0xf5,
+	0xf5, 0x3d, 0x9c, 0x45, 0xf4, 0x0b, 0x8b, 0x9c, 0x43, 0x67, 0x11, 0x50, 0x92, 0x7b, 0xa6, 0x88,
+	0x95, 0x42, 0xd0, 0x7e, 0xff, 0x06, 0xa9, 0x5e, 0xe0, 0xf7, 0x01, 0xb2, 0x76, 0xde, 0x84, 0x67,
+	0xa9, 0xbf, 0x2f, 0x73, 0xe4, 0x15, 0x6c, 0x2e, 0xf4, 0xc3, 0xe4, 0x6e, 0x79, 0x97, 0xac, 0x4d,
+	0xec, 0xdd, 0xd8, 0x42, 0xdb, 0x2b, 0xf8, 0xea, 0x95, 0x7f, 0x82, 0xce, 0x36, 0x7a, 0xf1, 0x55,
+	0xba, 0xcc, 0x93, 0xaf, 0xa0, 0x95, 0x7b, 0x78, 0x4e, 0xd3, 0x2c, 0x9c, 0x7d, 0xf3, 0xd0, 0x51,
+	0x7a, 0x97, 0x19, 0x14, 0xbe, 0x97, 0xbf, 0x0f, 0x0a, 0x6f, 0xca, 0xfd, 0xdd, 0x32, 0x91, 0x76,
+	0x7f, 0x00, 0x9b, 0x0b, 0xcf, 0x86, 0x26, 0x16, 0xe5, 0x8f, 0x89, 0x65, 0x9e, 0xfc, 0x18, 0xda,
+	0x85, 0x77, 0x35, 0xe3, 0x49, 0xd9, 0x5b, 0x5b, 0xd9, 0x70, 0xbd, 0x8d, 0x06, 0xb6, 0x64, 0xdb,
+	0x58, 0x7c, 0x08, 0xba, 0x61, 0xde, 0xc2, 0xeb, 0x93, 0x99, 0xb7, 0xec, 0x45, 0xaa, 0x6c, 0xf8,
+	0x00, 0x36, 0x17, 0x1e, 0x9d, 0xcc, 0xca, 0xcb, 0x9f, 0xa2, 0xca, 0x4c, 0x3c, 0x87, 0x56, 0xee,
+	0x49, 0xc0, 0x6c, 0xdf, 0xf2, 0xc3, 0x42, 0x7f, 0x67, 0x59, 0xa0, 0xa3, 0xff, 0x3d, 0x68, 0x24,
+	0xad, 0x3c, 0x31, 0x00, 0xb9, 0xf8, 0x9c, 0xd0, 0x27, 0x0b, 0xdc, 0xb4, 0x3c, 0xe5, 0xfa, 0x71,
+	0x33, 0xf1, 0xf2, 0x1b, 0x40, 0x7f, 0x67, 0x59, 0xa0, 0x0d, 0xfc, 0x0c, 0xb6, 0x96, 0x1a, 0xf4,
+	0xb4, 0x38, 0x94, 0x37, 0xf5, 0xfd, 0xbb, 0x37, 0x89, 0xb5, 0xc9, 0x13, 0xd8, 0x28, 0x36, 0xf0,
+	0x44, 0x1f, 0xdf, 0xd2, 0x76, 0xbf, 0xdf, 0x2b, 0x95, 0xa5, 0x39, 0xb9, 0xd0, 0x00, 0x27, 0x3b,
+	0x53, 0xda, 0x16, 0xdf, 0x70, 0xc4, 0x17, 0xfa, 0x5d, 0x63, 0xa2, 0xbc, 0x5f, 0xee, 0xef, 0x95,
+	0x0b, 0x95, 0xb9, 0xc3, 0xc6, 0xcf, 0x6b, 0xfb, 0xfb, 0xdf, 0xf1, 0x3d, 0x7e, 0x55, 0x53, 0xff,
+	0x9e, 0xbe, 0xfb, 0xef, 0x01, 0x00, 0x07, 0xac, 0x7a, 0x54, 0x88, 0x1a, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// HubClient is the client API for Hub service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type HubClient interface {
+	Stop(ctx context.Context, in *StopHubRequest, opts ...grpc.CallOption) (*StopHubReply, error)
+	StartAgents(ctx context.Context, in *StartAgentsRequest, opts ...grpc.CallOption) (*StartAgentsReply, error)
+	StatusAgents(ctx context.Context, in *StatusAgentsRequest, opts ...grpc.CallOption) (*StatusAgentsReply, error)
+	StopAgents(ctx context.Context, in *StopAgentsRequest, opts ...grpc.CallOption) (*StopAgentsReply, error)
+	ReportAgentHealth(ctx context.Context, in *ReportAgentHealthRequest, opts ...grpc.CallOption) (*ReportAgentHealthResponse, error)
+	MakeCluster(ctx context.Context, in *MakeClusterRequest, opts ...grpc.CallOption) (Hub_MakeClusterClient, error)
+	CleanInitCluster(ctx context.Context, in *CleanInitClusterRequest, opts ...grpc.CallOption) (*CleanInitClusterReply, error)
+	AddMirrors(ctx context.Context, in *AddMirrorsRequest, opts ...grpc.CallOption) (Hub_AddMirrorsClient, error)
+	GetAllHostNames(ctx context.Context, in *GetAllHostNamesRequest, opts ...grpc.CallOption) (*GetAllHostNamesReply, error)
+	StartCluster(ctx context.Context, in *StartClusterRequest, opts ...grpc.CallOption) (Hub_StartClusterClient, error)
+	StopCluster(ctx context.Context, in *StopClusterRequest, opts ...grpc.CallOption) (Hub_StopClusterClient, error)
+	ClusterStatus(ctx context.Context, in *ClusterStatusRequest, opts ...grpc.CallOption) (*ClusterStatusReply, error)
+	RecoverSegments(ctx context.Context, in *RecoverSegmentsRequest, opts ...grpc.CallOption) (Hub_RecoverSegmentsClient, error)
+	ExpandCluster(ctx context.Context, in *ExpandClusterRequest, opts ...grpc.CallOption) (Hub_ExpandClusterClient, error)
+	AddStandby(ctx context.Context, in *AddStandbyRequest, opts ...grpc.CallOption) (Hub_AddStandbyClient, error)
+	RemoveStandby(ctx context.Context, in *RemoveStandbyRequest, opts ...grpc.CallOption) (Hub_RemoveStandbyClient, error)
+	ActivateStandby(ctx context.Context, in *ActivateStandbyRequest, opts ...grpc.CallOption) (Hub_ActivateStandbyClient, error)
+	UpdateHosts(ctx context.Context, in *UpdateHostsRequest, opts ...grpc.CallOption) (*UpdateHostsReply, error)
+	PushFile(ctx context.Context, in *PushFileRequest, opts ...grpc.CallOption) (*PushFileReply, error)
+	CollectFile(ctx context.Context, in *CollectFileRequest, opts ...grpc.CallOption) (*CollectFileReply, error)
+	ReloadCredentials(ctx context.Context, in *ReloadCredentialsRequest, opts ...grpc.CallOption) (*ReloadCredentialsReply, error)
+	ListOperations(ctx context.Context, in *ListOperationsRequest, opts ...grpc.CallOption) (*ListOperationsReply, error)
+	AttachOperation(ctx context.Context, in *AttachOperationRequest, opts ...grpc.CallOption) (Hub_AttachOperationClient, error)
+	CancelOperation(ctx context.Context, in *CancelOperationRequest, opts ...grpc.CallOption) (*CancelOperationReply, error)
+}
+
+type hubClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewHubClient(cc *grpc.ClientConn) HubClient {
+	return &hubClient{cc}
+}
+
+func (c *hubClient) Stop(ctx context.Context, in *StopHubRequest, opts ...grpc.CallOption) (*StopHubReply, error) {
+	out := new(StopHubReply)
+	err := c.cc.Invoke(ctx, "/idl.Hub/Stop", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hubClient) StartAgents(ctx context.Context, in *StartAgentsRequest, opts ...grpc.CallOption) (*StartAgentsReply, error) {
+	out := new(StartAgentsReply)
+	err := c.cc.Invoke(ctx, "/idl.Hub/StartAgents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hubClient) StatusAgents(ctx context.Context, in *StatusAgentsRequest, opts ...grpc.CallOption) (*StatusAgentsReply, error) {
+	out := new(StatusAgentsReply)
+	err := c.cc.Invoke(ctx, "/idl.Hub/StatusAgents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hubClient) StopAgents(ctx context.Context, in *StopAgentsRequest, opts ...grpc.CallOption) (*StopAgentsReply, error) {
+	out := new(StopAgentsReply)
+	err := c.cc.Invoke(ctx, "/idl.Hub/StopAgents", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hubClient) ReportAgentHealth(ctx context.Context, in *ReportAgentHealthRequest, opts ...grpc.CallOption) (*ReportAgentHealthResponse, error) {
+	out := new(ReportAgentHealthResponse)
+	err := c.cc.Invoke(ctx, "/idl.Hub/ReportAgentHealth", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hubClient) MakeCluster(ctx context.Context, in *MakeClusterRequest, opts ...grpc.CallOption) (Hub_MakeClusterClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Hub_serviceDesc.Streams[0], "/idl.Hub/MakeCluster", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &hubMakeClusterClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Hub_MakeClusterClient interface {
+	Recv() (*HubReply, error)
+	grpc.ClientStream
+}
+
+type hubMakeClusterClient struct {
+	grpc.ClientStream
+}
+
+func (x *hubMakeClusterClient) Recv() (*HubReply, error) {
+	m := new(HubReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *hubClient) CleanInitCluster(ctx context.Context, in *CleanInitClusterRequest, opts ...grpc.CallOption) (*CleanInitClusterReply, error) {
+	out := new(CleanInitClusterReply)
+	err := c.cc.Invoke(ctx, "/idl.Hub/CleanInitCluster", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hubClient) AddMirrors(ctx context.Context, in *AddMirrorsRequest, opts ...grpc.CallOption) (Hub_AddMirrorsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Hub_serviceDesc.Streams[1], "/idl.Hub/AddMirrors", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &hubAddMirrorsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Hub_AddMirrorsClient interface {
+	Recv() (*HubReply, error)
+	grpc.ClientStream
+}
+
+type hubAddMirrorsClient struct {
+	grpc.ClientStream
+}
+
+func (x *hubAddMirrorsClient) Recv() (*HubReply, error) {
+	m := new(HubReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *hubClient) GetAllHostNames(ctx context.Context, in *GetAllHostNamesRequest, opts ...grpc.CallOption) (*GetAllHostNamesReply, error) {
+	out := new(GetAllHostNamesReply)
+	err := c.cc.Invoke(ctx, "/idl.Hub/GetAllHostNames", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hubClient) StartCluster(ctx context.Context, in *StartClusterRequest, opts ...grpc.CallOption) (Hub_StartClusterClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Hub_serviceDesc.Streams[2], "/idl.Hub/StartCluster", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &hubStartClusterClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Hub_StartClusterClient interface {
+	Recv() (*HubReply, error)
+	grpc.ClientStream
+}
+
+type hubStartClusterClient struct {
+	grpc.ClientStream
+}
+
+func (x *hubStartClusterClient) Recv() (*HubReply, error) {
+	m := new(HubReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *hubClient) StopCluster(ctx context.Context, in *StopClusterRequest, opts ...grpc.CallOption) (Hub_StopClusterClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Hub_serviceDesc.Streams[3], "/idl.Hub/StopCluster", opts...)
+	if err != nil {
+		return nil, err
 	}
-	return ""
+	x := &hubStopClusterClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
 
-func (m *Locale) GetLcCtype() string {
-	if m != nil {
-		return m.LcCtype
-	}
-	return ""
+type Hub_StopClusterClient interface {
+	Recv() (*HubReply, error)
+	grpc.ClientStream
 }
 
-func (m *Locale) GetLcMessages() string {
-	if m != nil {
-		return m.LcMessages
-	}
-	return ""
+type hubStopClusterClient struct {
+	grpc.ClientStream
 }
 
-func (m *Locale) GetLcMonetory() string {
-	if m != nil {
-		return m.LcMonetory
+func (x *hubStopClusterClient) Recv() (*HubReply, error) {
+	m := new(HubReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
 	}
-	return ""
+	return m, nil
 }
 
-func (m *Locale) GetLcNumeric() string {
-	if m != nil {
-		return m.LcNumeric
+func (c *hubClient) ClusterStatus(ctx context.Context, in *ClusterStatusRequest, opts ...grpc.CallOption) (*ClusterStatusReply, error) {
+	out := new(ClusterStatusReply)
+	err := c.cc.Invoke(ctx, "/idl.Hub/ClusterStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return ""
+	return out, nil
 }
 
-func (m *Locale) GetLcTime() string {
-	if m != nil {
-		return m.LcTime
+func (c *hubClient) RecoverSegments(ctx context.Context, in *RecoverSegmentsRequest, opts ...grpc.CallOption) (Hub_RecoverSegmentsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Hub_serviceDesc.Streams[4], "/idl.Hub/RecoverSegments", opts...)
+	if err != nil {
+		return nil, err
 	}
-	return ""
+	x := &hubRecoverSegmentsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
 
-func init() {
-	proto.RegisterEnum("idl.LogLevel", LogLevel_name, LogLevel_value)
-	proto.RegisterType((*AddMirrorsRequest)(nil), "idl.AddMirrorsRequest")
-	proto.RegisterType((*GetAllHostNamesRequest)(nil), "idl.GetAllHostNamesRequest")
-	proto.RegisterType((*GetAllHostNamesReply)(nil), "idl.GetAllHostNamesReply")
-	proto.RegisterMapType((map[string]string)(nil), "idl.GetAllHostNamesReply.HostNameMapEntry")
-	proto.RegisterType((*StopHubRequest)(nil), "idl.StopHubRequest")
-	proto.RegisterType((*StopHubReply)(nil), "idl.StopHubReply")
-	proto.RegisterType((*StartAgentsRequest)(nil), "idl.StartAgentsRequest")
-	proto.RegisterType((*StartAgentsReply)(nil), "idl.StartAgentsReply")
-	proto.RegisterType((*StatusAgentsRequest)(nil), "idl.StatusAgentsRequest")
-	proto.RegisterType((*ReportAgentHealthRequest)(nil), "idl.ReportAgentHealthRequest")
-	proto.RegisterType((*ReportAgentHealthResponse)(nil), "idl.ReportAgentHealthResponse")
-	proto.RegisterType((*CleanInitClusterRequest)(nil), "idl.CleanInitClusterRequest")
-	proto.RegisterType((*CleanInitClusterReply)(nil), "idl.CleanInitClusterReply")
-	proto.RegisterType((*ServiceStatus)(nil), "idl.ServiceStatus")
-	proto.RegisterType((*StatusAgentsReply)(nil), "idl.StatusAgentsReply")
-	proto.RegisterType((*StopAgentsRequest)(nil), "idl.StopAgentsRequest")
-	proto.RegisterType((*StopAgentsReply)(nil), "idl.StopAgentsReply")
-	proto.RegisterType((*MakeClusterRequest)(nil), "idl.MakeClusterRequest")
-	proto.RegisterType((*HubReply)(nil), "idl.HubReply")
-	proto.RegisterType((*LogMessage)(nil), "idl.LogMessage")
-	proto.RegisterType((*ProgressMessage)(nil), "idl.ProgressMessage")
-	proto.RegisterType((*GpArray)(nil), "idl.gpArray")
-	proto.RegisterType((*Segment)(nil), "idl.Segment")
-	proto.RegisterType((*SegmentPair)(nil), "idl.SegmentPair")
-	proto.RegisterType((*ClusterParams)(nil), "idl.ClusterParams")
-	proto.RegisterMapType((map[string]string)(nil), "idl.ClusterParams.CommonConfigEntry")
-	proto.RegisterMapType((map[string]string)(nil), "idl.ClusterParams.CoordinatorConfigEntry")
-	proto.RegisterMapType((map[string]string)(nil), "idl.ClusterParams.SegmentConfigEntry")
-	proto.RegisterType((*Locale)(nil), "idl.Locale")
+type Hub_RecoverSegmentsClient interface {
+	Recv() (*HubReply, error)
+	grpc.ClientStream
 }
 
-func init() { proto.RegisterFile("hub.proto", fileDescriptor_b3103f8d3056b01c) }
-
-var fileDescriptor_b3103f8d3056b01c = []byte{
-	// 1330 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x57, 0xdd, 0x6e, 0xdb, 0xc6,
-	0x12, 0x36, 0x2d, 0xeb, 0x6f, 0x64, 0xc7, 0xf2, 0xc4, 0x3f, 0xb4, 0xf2, 0x73, 0x0c, 0x26, 0xe7,
-	0xc0, 0xc9, 0x85, 0x4e, 0xe0, 0x13, 0xe0, 0xa4, 0x45, 0xd1, 0x54, 0x96, 0x93, 0x28, 0x88, 0xed,
-	0x18, 0xeb, 0x14, 0x01, 0xda, 0x0b, 0x83, 0x22, 0x37, 0x32, 0x91, 0x15, 0x97, 0x5d, 0x2e, 0x5d,
-	0xe8, 0x19, 0x7a, 0xd1, 0xfb, 0x5e, 0xf7, 0xba, 0x37, 0x7d, 0x94, 0x3e, 0x41, 0xdf, 0xa4, 0xd8,
-	0x1f, 0x4a, 0xa4, 0x24, 0x5f, 0xe4, 0x8e, 0xf3, 0xcd, 0x37, 0xb3, 0xbb, 0xb3, 0x33, 0xb3, 0x43,
-	0x68, 0x5e, 0x67, 0xc3, 0x6e, 0x22, 0xb8, 0xe4, 0x58, 0x89, 0x42, 0xe6, 0xfd, 0xea, 0xc0, 0x56,
-	0x2f, 0x0c, 0xcf, 0x22, 0x21, 0xb8, 0x48, 0x09, 0xfd, 0x29, 0xa3, 0xa9, 0xc4, 0x2e, 0x60, 0x9f,
-	0x73, 0x11, 0x46, 0xb1, 0x2f, 0xb9, 0x38, 0xf1, 0xa5, 0x7f, 0x12, 0x09, 0xd7, 0x39, 0x70, 0x0e,
-	0x9b, 0x64, 0x89, 0x06, 0x3d, 0x58, 0x1f, 0x0c, 0xfd, 0x01, 0x4f, 0x65, 0xec, 0x8f, 0x69, 0xea,
-	0xae, 0x1e, 0x38, 0x87, 0x0d, 0x52, 0xc2, 0xf0, 0x3f, 0x50, 0x1f, 0x9b, 0x55, 0xdc, 0xca, 0x41,
-	0xe5, 0xb0, 0x75, 0xb4, 0xde, 0x8d, 0x42, 0xd6, 0xbd, 0xa4, 0xa3, 0x31, 0x8d, 0x25, 0xc9, 0x95,
-	0xde, 0x73, 0xd8, 0x7d, 0x43, 0x65, 0x8f, 0x31, 0x65, 0x7a, 0xae, 0x4c, 0xf3, 0x5d, 0x75, 0xa0,
-	0x71, 0xcd, 0x53, 0x79, 0x1a, 0xa5, 0xd2, 0x75, 0x0e, 0x2a, 0x87, 0x4d, 0x32, 0x95, 0xbd, 0xdf,
-	0x1d, 0xd8, 0x5e, 0x30, 0x4b, 0xd8, 0x04, 0x4f, 0xa1, 0x75, 0x6d, 0x91, 0x33, 0x3f, 0xd1, 0x76,
-	0xad, 0xa3, 0xa7, 0x7a, 0xe9, 0x65, 0xfc, 0xee, 0x60, 0x46, 0x7e, 0x15, 0x4b, 0x31, 0x21, 0x45,
-	0xf3, 0xce, 0xb7, 0xd0, 0x9e, 0x27, 0x60, 0x1b, 0x2a, 0x9f, 0xe9, 0xc4, 0x46, 0x47, 0x7d, 0xe2,
-	0x36, 0x54, 0x6f, 0x7c, 0x96, 0x51, 0x1d, 0x87, 0x26, 0x31, 0xc2, 0xd7, 0xab, 0x2f, 0x1c, 0xaf,
-	0x0d, 0x77, 0x2e, 0x25, 0x4f, 0x06, 0xd9, 0xd0, 0x1e, 0xca, 0xbb, 0x03, 0xeb, 0x53, 0x24, 0x61,
-	0x13, 0x6f, 0x1b, 0xf0, 0x52, 0xfa, 0x42, 0xf6, 0x46, 0x34, 0x96, 0xf9, 0xd1, 0x3d, 0x84, 0x76,
-	0x09, 0x55, 0xcc, 0x1d, 0xb8, 0x7b, 0x29, 0x7d, 0x99, 0xa5, 0x65, 0x6a, 0x07, 0x5c, 0x42, 0x13,
-	0x6e, 0xb9, 0x03, 0xea, 0x33, 0x79, 0x9d, 0xeb, 0xee, 0xc1, 0xfe, 0x12, 0x5d, 0x9a, 0xf0, 0x38,
-	0xa5, 0xde, 0x3e, 0xec, 0xf5, 0x19, 0xf5, 0xe3, 0xb7, 0x71, 0x24, 0xfb, 0x2c, 0x4b, 0x25, 0x15,
-	0xb9, 0xdd, 0x1e, 0xec, 0x2c, 0xaa, 0xd4, 0x1e, 0x26, 0xb0, 0x71, 0x49, 0xc5, 0x4d, 0x14, 0x50,
-	0xb3, 0x15, 0x44, 0x58, 0x13, 0x9c, 0x51, 0x1b, 0x0d, 0xfd, 0xad, 0x30, 0x15, 0x43, 0x1b, 0x0d,
-	0xfd, 0x8d, 0xbb, 0x50, 0x4b, 0xb5, 0x85, 0x5b, 0xd1, 0xa8, 0x95, 0x14, 0x9e, 0x25, 0x32, 0x1a,
-	0x53, 0x77, 0xcd, 0xe0, 0x46, 0x52, 0x41, 0x4e, 0xa2, 0xd0, 0xad, 0x1e, 0x38, 0x87, 0x1b, 0x44,
-	0x7d, 0x7a, 0x7d, 0xd8, 0x2a, 0x1f, 0x5f, 0xdd, 0x76, 0x17, 0x1a, 0xc6, 0x11, 0x4d, 0xed, 0x55,
-	0xa3, 0xcd, 0xb2, 0xc2, 0x26, 0xc9, 0x94, 0xe3, 0xdd, 0x55, 0x4e, 0x78, 0x52, 0x8e, 0xe0, 0x16,
-	0x6c, 0x16, 0x41, 0x75, 0xce, 0x3f, 0x1c, 0xc0, 0x33, 0xff, 0x33, 0x2d, 0xc7, 0x45, 0xe5, 0xf4,
-	0x28, 0xe9, 0x09, 0xe1, 0x9b, 0xeb, 0xcf, 0x73, 0xda, 0x62, 0x24, 0x57, 0xe2, 0x0b, 0xd8, 0x08,
-	0x8c, 0xe5, 0x85, 0x2f, 0xfc, 0xb1, 0x29, 0x90, 0x7c, 0x6f, 0xfd, 0xa2, 0x86, 0x94, 0x89, 0x78,
-	0x1f, 0x9a, 0x9f, 0xb8, 0x08, 0xe8, 0x6b, 0xe6, 0x8f, 0x74, 0xa8, 0x1a, 0x64, 0x06, 0xa0, 0x0b,
-	0xf5, 0x1b, 0x2a, 0x86, 0x3c, 0x35, 0xe1, 0x6a, 0x90, 0x5c, 0xf4, 0x7e, 0x73, 0xa0, 0x91, 0xe7,
-	0x14, 0x3e, 0x81, 0x1a, 0xe3, 0xa3, 0xb3, 0x74, 0x64, 0x77, 0xb9, 0xa9, 0xd7, 0x3d, 0xe5, 0xa3,
-	0x33, 0x9a, 0xa6, 0xfe, 0x88, 0x0e, 0x56, 0x88, 0x25, 0xe0, 0x43, 0x68, 0xa6, 0x32, 0xe4, 0x99,
-	0x54, 0x6c, 0x7d, 0x61, 0x83, 0x15, 0x32, 0x83, 0xf0, 0x05, 0xb4, 0x12, 0xc1, 0x47, 0x82, 0xa6,
-	0xa9, 0x62, 0x54, 0xb4, 0xbf, 0x6d, 0xed, 0xef, 0x22, 0xc7, 0xa7, 0x4e, 0x8b, 0xd4, 0xe3, 0x26,
-	0xd4, 0xc7, 0x46, 0xe3, 0xbd, 0x03, 0x98, 0x2d, 0xae, 0x0e, 0x61, 0x15, 0x36, 0x6b, 0x72, 0x11,
-	0x1f, 0x41, 0x95, 0xd1, 0x1b, 0xca, 0xf4, 0x46, 0xee, 0x1c, 0x6d, 0xe8, 0x65, 0x18, 0x1f, 0x9d,
-	0x2a, 0x90, 0x18, 0x9d, 0xf7, 0x11, 0x36, 0xe7, 0x56, 0x56, 0xf5, 0xc7, 0xfc, 0x21, 0x65, 0xd6,
-	0x9f, 0x11, 0xd4, 0x3a, 0x41, 0x26, 0x04, 0x8d, 0x4d, 0x26, 0x56, 0x49, 0x2e, 0x2a, 0xbe, 0xe4,
-	0xd2, 0x67, 0xfa, 0x38, 0x55, 0x62, 0x04, 0x8f, 0x4f, 0x2f, 0x17, 0xbb, 0xd0, 0x2a, 0x74, 0xbd,
-	0xd2, 0x5d, 0xe7, 0xfd, 0xab, 0x48, 0xc0, 0xe7, 0xb0, 0x6e, 0x71, 0x93, 0x1c, 0xab, 0x3a, 0x15,
-	0xdb, 0x45, 0x83, 0x0b, 0x3f, 0x12, 0xa4, 0xc4, 0xf2, 0xfe, 0x74, 0xa0, 0x6e, 0x01, 0x55, 0x33,
-	0xaa, 0x4e, 0xf5, 0x52, 0x55, 0xa2, 0xbf, 0xf1, 0x31, 0x6c, 0x84, 0xa6, 0xe1, 0xd2, 0x40, 0x72,
-	0x31, 0xb1, 0x05, 0x55, 0x06, 0xf3, 0x2e, 0xa9, 0x5a, 0x94, 0xad, 0xad, 0xa9, 0x8c, 0x07, 0xa6,
-	0x19, 0xf6, 0xc2, 0x50, 0x85, 0xcb, 0x96, 0x58, 0x11, 0x52, 0xf9, 0x16, 0xf0, 0x58, 0xd2, 0x58,
-	0xda, 0x6a, 0xab, 0x92, 0x19, 0xa0, 0x76, 0x15, 0x0e, 0xa3, 0xd0, 0xad, 0x99, 0x5d, 0xa9, 0x6f,
-	0xef, 0x47, 0x68, 0x15, 0x8e, 0xa4, 0x4a, 0x22, 0x11, 0xd1, 0xd8, 0x17, 0x93, 0xa5, 0x61, 0xca,
-	0x95, 0xf8, 0x18, 0x6a, 0xa6, 0xe3, 0xdb, 0x5a, 0x28, 0xd3, 0xac, 0xce, 0xfb, 0xa5, 0x0a, 0x1b,
-	0xa5, 0xfa, 0xc0, 0x8f, 0xb0, 0x55, 0x88, 0x74, 0x9f, 0xc7, 0x9f, 0xa2, 0x91, 0x2d, 0xf5, 0x27,
-	0x8b, 0xe5, 0xd4, 0x5d, 0xe0, 0x9a, 0xa6, 0xbe, 0xe8, 0x03, 0xdf, 0xa9, 0x56, 0xa6, 0x57, 0xb7,
-	0x4e, 0xcd, 0xa5, 0xfd, 0x7b, 0x89, 0xd3, 0x12, 0xcf, 0x38, 0x2c, 0xdb, 0xe2, 0x00, 0xd6, 0xfb,
-	0x7c, 0x3c, 0xe6, 0xb1, 0xf5, 0x65, 0x5e, 0xbc, 0xc7, 0x4b, 0x37, 0x38, 0xa3, 0x19, 0x57, 0x25,
-	0x4b, 0x7c, 0xa4, 0x6a, 0x37, 0xf0, 0x99, 0xa9, 0xf0, 0xd6, 0x51, 0xcb, 0xd6, 0xae, 0x82, 0x88,
-	0x55, 0xa9, 0xf7, 0xf7, 0xba, 0xf8, 0xfe, 0x56, 0xcd, 0xfb, 0x5b, 0xc4, 0x54, 0x5e, 0xd0, 0x38,
-	0xe0, 0x61, 0x14, 0x8f, 0xf4, 0xfd, 0x35, 0xc9, 0x54, 0xc6, 0x87, 0x00, 0x69, 0x76, 0xe1, 0xa7,
-	0xe9, 0xcf, 0x5c, 0x84, 0x6e, 0x5d, 0x6b, 0x0b, 0x88, 0xea, 0xca, 0xe1, 0x50, 0x67, 0x54, 0xc3,
-	0x74, 0x65, 0x23, 0xe5, 0x19, 0xd9, 0xbf, 0xa6, 0xc1, 0xe7, 0x34, 0x1b, 0xa7, 0x6e, 0x53, 0x2f,
-	0x5c, 0x06, 0x3b, 0x27, 0xb0, 0xbb, 0xfc, 0x1a, 0xbe, 0xe4, 0xe9, 0xec, 0x7c, 0x07, 0xb8, 0x18,
-	0xf7, 0x2f, 0xf2, 0xf0, 0x52, 0xa5, 0xce, 0x5c, 0xb4, 0xbf, 0xe8, 0xf5, 0xfe, 0xcb, 0x81, 0x9a,
-	0x89, 0x3c, 0xee, 0x40, 0x8d, 0x05, 0x57, 0x3e, 0x9b, 0xf5, 0x98, 0xa0, 0xc7, 0x18, 0x3e, 0x00,
-	0x60, 0xc1, 0x55, 0xc0, 0x19, 0xf3, 0x65, 0xee, 0xa0, 0xc9, 0x82, 0xbe, 0x01, 0x70, 0x1f, 0x1a,
-	0x4a, 0x2d, 0x27, 0x49, 0x5e, 0x9b, 0x75, 0x16, 0xf4, 0x95, 0x88, 0xff, 0x82, 0x16, 0x0b, 0xae,
-	0x6c, 0xe7, 0xcb, 0x4b, 0x13, 0x58, 0x60, 0x7b, 0x5a, 0x9a, 0x13, 0x78, 0x4c, 0x75, 0xed, 0x57,
-	0xa7, 0x04, 0x8b, 0xd8, 0xb5, 0xe3, 0x6c, 0x4c, 0x45, 0x14, 0xd8, 0x2b, 0x6e, 0xb2, 0xe0, 0xdc,
-	0x00, 0xb8, 0x07, 0x75, 0x16, 0x5c, 0xe9, 0xa7, 0xd5, 0x5c, 0x70, 0x8d, 0x05, 0x1f, 0xa2, 0x31,
-	0x7d, 0x7a, 0x0c, 0x8d, 0xbc, 0xa7, 0x62, 0x13, 0xaa, 0xaf, 0x7b, 0x1f, 0x7a, 0xa7, 0xed, 0x15,
-	0xf5, 0xf9, 0x8a, 0x90, 0xf7, 0xa4, 0xed, 0x60, 0x0b, 0xea, 0x1f, 0x7b, 0xe4, 0xfc, 0xed, 0xf9,
-	0x9b, 0xf6, 0x2a, 0x36, 0x60, 0xed, 0xed, 0xf9, 0xeb, 0xf7, 0xed, 0x8a, 0x62, 0x9c, 0xbc, 0x3a,
-	0xfe, 0xfe, 0x4d, 0x7b, 0xed, 0xe8, 0xef, 0x35, 0xa8, 0x0c, 0xb2, 0x21, 0x3e, 0x83, 0x35, 0xf5,
-	0x74, 0xe2, 0x5d, 0x53, 0xcd, 0xa5, 0x51, 0xa7, 0xb3, 0x55, 0x06, 0xd5, 0xbb, 0xba, 0x82, 0x2f,
-	0xa1, 0x55, 0x98, 0x6c, 0x70, 0xcf, 0x72, 0xe6, 0x27, 0xa0, 0xce, 0xce, 0xa2, 0xc2, 0x38, 0x38,
-	0x56, 0x03, 0xd4, 0x6c, 0x0e, 0x40, 0x37, 0x27, 0xce, 0x4f, 0x46, 0x9d, 0xdd, 0x25, 0x1a, 0xe3,
-	0xe3, 0x1b, 0x80, 0xd9, 0x8b, 0x8f, 0xbb, 0xd3, 0x7d, 0x96, 0xed, 0xb7, 0x17, 0x70, 0x63, 0xfd,
-	0x01, 0xb6, 0x16, 0xa6, 0x2a, 0x7c, 0xa0, 0xc9, 0xb7, 0x4d, 0x62, 0x9d, 0x87, 0xb7, 0xa9, 0xed,
-	0x30, 0xb6, 0x82, 0x5f, 0x41, 0xab, 0x30, 0x71, 0xd8, 0xc0, 0x2c, 0xce, 0x20, 0x1d, 0xf3, 0x2a,
-	0xce, 0x22, 0xfa, 0xcc, 0xc1, 0x73, 0x68, 0xcf, 0x8f, 0x6b, 0x78, 0xdf, 0xf6, 0x9e, 0xa5, 0x03,
-	0x5e, 0xa7, 0x73, 0x8b, 0xd6, 0x1c, 0xf0, 0xff, 0x00, 0xb3, 0x7f, 0x04, 0x1b, 0x9e, 0x85, 0x9f,
-	0x86, 0x65, 0x1b, 0x79, 0x07, 0x9b, 0x73, 0x43, 0x36, 0xde, 0x5b, 0x3e, 0x7a, 0x1b, 0x17, 0xfb,
-	0xb7, 0xce, 0xe5, 0xde, 0xca, 0x71, 0xe3, 0x87, 0x5a, 0xb7, 0xfb, 0xdf, 0x28, 0x64, 0xc3, 0x9a,
-	0xfe, 0x81, 0xf9, 0xdf, 0x3f, 0x01, 0x00, 0x00, 0xff, 0xff, 0xc2, 0x2d, 0xc5, 0x72, 0xcd, 0x0c,
-	0x00, 0x00,
+type hubRecoverSegmentsClient struct {
+	grpc.ClientStream
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+func (x *hubRecoverSegmentsClient) Recv() (*HubReply, error) {
+	m := new(HubReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
 
-// HubClient is the client API for Hub service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type HubClient interface {
-	Stop(ctx context.Context, in *StopHubRequest, opts ...grpc.CallOption) (*StopHubReply, error)
-	StartAgents(ctx context.Context, in *StartAgentsRequest, opts ...grpc.CallOption) (*StartAgentsReply, error)
-	StatusAgents(ctx context.Context, in *StatusAgentsRequest, opts ...grpc.CallOption) (*StatusAgentsReply, error)
-	StopAgents(ctx context.Context, in *StopAgentsRequest, opts ...grpc.CallOption) (*StopAgentsReply, error)
-	ReportAgentHealth(ctx context.Context, in *ReportAgentHealthRequest, opts ...grpc.CallOption) (*ReportAgentHealthResponse, error)
-	MakeCluster(ctx context.Context, in *MakeClusterRequest, opts ...grpc.CallOption) (Hub_MakeClusterClient, error)
-	CleanInitCluster(ctx context.Context, in *CleanInitClusterRequest, opts ...grpc.CallOption) (*CleanInitClusterReply, error)
-	AddMirrors(ctx context.Context, in *AddMirrorsRequest, opts ...grpc.CallOption) (Hub_AddMirrorsClient, error)
-	GetAllHostNames(ctx context.Context, in *GetAllHostNamesRequest, opts ...grpc.CallOption) (*GetAllHostNamesReply, error)
+func (c *hubClient) ExpandCluster(ctx context.Context, in *ExpandClusterRequest, opts ...grpc.CallOption) (Hub_ExpandClusterClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Hub_serviceDesc.Streams[5], "/idl.Hub/ExpandCluster", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &hubExpandClusterClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
 
-type hubClient struct {
-	cc *grpc.ClientConn
+type Hub_ExpandClusterClient interface {
+	Recv() (*HubReply, error)
+	grpc.ClientStream
 }
 
-func NewHubClient(cc *grpc.ClientConn) HubClient {
-	return &hubClient{cc}
+type hubExpandClusterClient struct {
+	grpc.ClientStream
 }
 
-func (c *hubClient) Stop(ctx context.Context, in *StopHubRequest, opts ...grpc.CallOption) (*StopHubReply, error) {
-	out := new(StopHubReply)
-	err := c.cc.Invoke(ctx, "/idl.Hub/Stop", in, out, opts...)
-	if err != nil {
+func (x *hubExpandClusterClient) Recv() (*HubReply, error) {
+	m := new(HubReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	return out, nil
+	return m, nil
 }
 
-func (c *hubClient) StartAgents(ctx context.Context, in *StartAgentsRequest, opts ...grpc.CallOption) (*StartAgentsReply, error) {
-	out := new(StartAgentsReply)
-	err := c.cc.Invoke(ctx, "/idl.Hub/StartAgents", in, out, opts...)
+func (c *hubClient) AddStandby(ctx context.Context, in *AddStandbyRequest, opts ...grpc.CallOption) (Hub_AddStandbyClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Hub_serviceDesc.Streams[6], "/idl.Hub/AddStandby", opts...)
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
+	x := &hubAddStandbyClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
 
-func (c *hubClient) StatusAgents(ctx context.Context, in *StatusAgentsRequest, opts ...grpc.CallOption) (*StatusAgentsReply, error) {
-	out := new(StatusAgentsReply)
-	err := c.cc.Invoke(ctx, "/idl.Hub/StatusAgents", in, out, opts...)
-	if err != nil {
+type Hub_AddStandbyClient interface {
+	Recv() (*HubReply, error)
+	grpc.ClientStream
+}
+
+type hubAddStandbyClient struct {
+	grpc.ClientStream
+}
+
+func (x *hubAddStandbyClient) Recv() (*HubReply, error) {
+	m := new(HubReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	return out, nil
+	return m, nil
 }
 
-func (c *hubClient) StopAgents(ctx context.Context, in *StopAgentsRequest, opts ...grpc.CallOption) (*StopAgentsReply, error) {
-	out := new(StopAgentsReply)
-	err := c.cc.Invoke(ctx, "/idl.Hub/StopAgents", in, out, opts...)
+func (c *hubClient) RemoveStandby(ctx context.Context, in *RemoveStandbyRequest, opts ...grpc.CallOption) (Hub_RemoveStandbyClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Hub_serviceDesc.Streams[7], "/idl.Hub/RemoveStandby", opts...)
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
+	x := &hubRemoveStandbyClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
 
-func (c *hubClient) ReportAgentHealth(ctx context.Context, in *ReportAgentHealthRequest, opts ...grpc.CallOption) (*ReportAgentHealthResponse, error) {
-	out := new(ReportAgentHealthResponse)
-	err := c.cc.Invoke(ctx, "/idl.Hub/ReportAgentHealth", in, out, opts...)
-	if err != nil {
+type Hub_RemoveStandbyClient interface {
+	Recv() (*HubReply, error)
+	grpc.ClientStream
+}
+
+type hubRemoveStandbyClient struct {
+	grpc.ClientStream
+}
+
+func (x *hubRemoveStandbyClient) Recv() (*HubReply, error) {
+	m := new(HubReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	return out, nil
+	return m, nil
 }
 
-func (c *hubClient) MakeCluster(ctx context.Context, in *MakeClusterRequest, opts ...grpc.CallOption) (Hub_MakeClusterClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Hub_serviceDesc.Streams[0], "/idl.Hub/MakeCluster", opts...)
+func (c *hubClient) ActivateStandby(ctx context.Context, in *ActivateStandbyRequest, opts ...grpc.CallOption) (Hub_ActivateStandbyClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Hub_serviceDesc.Streams[8], "/idl.Hub/ActivateStandby", opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &hubMakeClusterClient{stream}
+	x := &hubActivateStandbyClient{stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -1481,16 +3139,16 @@ func (c *hubClient) MakeCluster(ctx context.Context, in *MakeClusterRequest, opt
 	return x, nil
 }
 
-type Hub_MakeClusterClient interface {
+type Hub_ActivateStandbyClient interface {
 	Recv() (*HubReply, error)
 	grpc.ClientStream
 }
 
-type hubMakeClusterClient struct {
+type hubActivateStandbyClient struct {
 	grpc.ClientStream
 }
 
-func (x *hubMakeClusterClient) Recv() (*HubReply, error) {
+func (x *hubActivateStandbyClient) Recv() (*HubReply, error) {
 	m := new(HubReply)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
@@ -1498,21 +3156,57 @@ func (x *hubMakeClusterClient) Recv() (*HubReply, error) {
 	return m, nil
 }
 
-func (c *hubClient) CleanInitCluster(ctx context.Context, in *CleanInitClusterRequest, opts ...grpc.CallOption) (*CleanInitClusterReply, error) {
-	out := new(CleanInitClusterReply)
-	err := c.cc.Invoke(ctx, "/idl.Hub/CleanInitCluster", in, out, opts...)
+func (c *hubClient) UpdateHosts(ctx context.Context, in *UpdateHostsRequest, opts ...grpc.CallOption) (*UpdateHostsReply, error) {
+	out := new(UpdateHostsReply)
+	err := c.cc.Invoke(ctx, "/idl.Hub/UpdateHosts", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *hubClient) AddMirrors(ctx context.Context, in *AddMirrorsRequest, opts ...grpc.CallOption) (Hub_AddMirrorsClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Hub_serviceDesc.Streams[1], "/idl.Hub/AddMirrors", opts...)
+func (c *hubClient) PushFile(ctx context.Context, in *PushFileRequest, opts ...grpc.CallOption) (*PushFileReply, error) {
+	out := new(PushFileReply)
+	err := c.cc.Invoke(ctx, "/idl.Hub/PushFile", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &hubAddMirrorsClient{stream}
+	return out, nil
+}
+
+func (c *hubClient) CollectFile(ctx context.Context, in *CollectFileRequest, opts ...grpc.CallOption) (*CollectFileReply, error) {
+	out := new(CollectFileReply)
+	err := c.cc.Invoke(ctx, "/idl.Hub/CollectFile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hubClient) ReloadCredentials(ctx context.Context, in *ReloadCredentialsRequest, opts ...grpc.CallOption) (*ReloadCredentialsReply, error) {
+	out := new(ReloadCredentialsReply)
+	err := c.cc.Invoke(ctx, "/idl.Hub/ReloadCredentials", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hubClient) ListOperations(ctx context.Context, in *ListOperationsRequest, opts ...grpc.CallOption) (*ListOperationsReply, error) {
+	out := new(ListOperationsReply)
+	err := c.cc.Invoke(ctx, "/idl.Hub/ListOperations", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *hubClient) AttachOperation(ctx context.Context, in *AttachOperationRequest, opts ...grpc.CallOption) (Hub_AttachOperationClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Hub_serviceDesc.Streams[9], "/idl.Hub/AttachOperation", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &hubAttachOperationClient{stream}
 	if err := x.ClientStream.SendMsg(in); err != nil {
 		return nil, err
 	}
@@ -1522,16 +3216,16 @@ func (c *hubClient) AddMirrors(ctx context.Context, in *AddMirrorsRequest, opts
 	return x, nil
 }
 
-type Hub_AddMirrorsClient interface {
+type Hub_AttachOperationClient interface {
 	Recv() (*HubReply, error)
 	grpc.ClientStream
 }
 
-type hubAddMirrorsClient struct {
+type hubAttachOperationClient struct {
 	grpc.ClientStream
 }
 
-func (x *hubAddMirrorsClient) Recv() (*HubReply, error) {
+func (x *hubAttachOperationClient) Recv() (*HubReply, error) {
 	m := new(HubReply)
 	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
@@ -1539,9 +3233,9 @@ func (x *hubAddMirrorsClient) Recv() (*HubReply, error) {
 	return m, nil
 }
 
-func (c *hubClient) GetAllHostNames(ctx context.Context, in *GetAllHostNamesRequest, opts ...grpc.CallOption) (*GetAllHostNamesReply, error) {
-	out := new(GetAllHostNamesReply)
-	err := c.cc.Invoke(ctx, "/idl.Hub/GetAllHostNames", in, out, opts...)
+func (c *hubClient) CancelOperation(ctx context.Context, in *CancelOperationRequest, opts ...grpc.CallOption) (*CancelOperationReply, error) {
+	out := new(CancelOperationReply)
+	err := c.cc.Invoke(ctx, "/idl.Hub/CancelOperation", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -1559,6 +3253,21 @@ type HubServer interface {
 	CleanInitCluster(context.Context, *CleanInitClusterRequest) (*CleanInitClusterReply, error)
 	AddMirrors(*AddMirrorsRequest, Hub_AddMirrorsServer) error
 	GetAllHostNames(context.Context, *GetAllHostNamesRequest) (*GetAllHostNamesReply, error)
+	StartCluster(*StartClusterRequest, Hub_StartClusterServer) error
+	StopCluster(*StopClusterRequest, Hub_StopClusterServer) error
+	ClusterStatus(context.Context, *ClusterStatusRequest) (*ClusterStatusReply, error)
+	RecoverSegments(*RecoverSegmentsRequest, Hub_RecoverSegmentsServer) error
+	ExpandCluster(*ExpandClusterRequest, Hub_ExpandClusterServer) error
+	AddStandby(*AddStandbyRequest, Hub_AddStandbyServer) error
+	RemoveStandby(*RemoveStandbyRequest, Hub_RemoveStandbyServer) error
+	ActivateStandby(*ActivateStandbyRequest, Hub_ActivateStandbyServer) error
+	UpdateHosts(context.Context, *UpdateHostsRequest) (*UpdateHostsReply, error)
+	PushFile(context.Context, *PushFileRequest) (*PushFileReply, error)
+	CollectFile(context.Context, *CollectFileRequest) (*CollectFileReply, error)
+	ReloadCredentials(context.Context, *ReloadCredentialsRequest) (*ReloadCredentialsReply, error)
+	ListOperations(context.Context, *ListOperationsRequest) (*ListOperationsReply, error)
+	AttachOperation(*AttachOperationRequest, Hub_AttachOperationServer) error
+	CancelOperation(context.Context, *CancelOperationRequest) (*CancelOperationReply, error)
 }
 
 // UnimplementedHubServer can be embedded to have forward compatible implementations.
@@ -1583,14 +3292,59 @@ func (*UnimplementedHubServer) ReportAgentHealth(ctx context.Context, req *Repor
 func (*UnimplementedHubServer) MakeCluster(req *MakeClusterRequest, srv Hub_MakeClusterServer) error {
 	return status.Errorf(codes.Unimplemented, "method MakeCluster not implemented")
 }
-func (*UnimplementedHubServer) CleanInitCluster(ctx context.Context, req *CleanInitClusterRequest) (*CleanInitClusterReply, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CleanInitCluster not implemented")
+func (*UnimplementedHubServer) CleanInitCluster(ctx context.Context, req *CleanInitClusterRequest) (*CleanInitClusterReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CleanInitCluster not implemented")
+}
+func (*UnimplementedHubServer) AddMirrors(req *AddMirrorsRequest, srv Hub_AddMirrorsServer) error {
+	return status.Errorf(codes.Unimplemented, "method AddMirrors not implemented")
+}
+func (*UnimplementedHubServer) GetAllHostNames(ctx context.Context, req *GetAllHostNamesRequest) (*GetAllHostNamesReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAllHostNames not implemented")
+}
+func (*UnimplementedHubServer) StartCluster(req *StartClusterRequest, srv Hub_StartClusterServer) error {
+	return status.Errorf(codes.Unimplemented, "method StartCluster not implemented")
+}
+func (*UnimplementedHubServer) StopCluster(req *StopClusterRequest, srv Hub_StopClusterServer) error {
+	return status.Errorf(codes.Unimplemented, "method StopCluster not implemented")
+}
+func (*UnimplementedHubServer) ClusterStatus(ctx context.Context, req *ClusterStatusRequest) (*ClusterStatusReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClusterStatus not implemented")
+}
+func (*UnimplementedHubServer) RecoverSegments(req *RecoverSegmentsRequest, srv Hub_RecoverSegmentsServer) error {
+	return status.Errorf(codes.Unimplemented, "method RecoverSegments not implemented")
+}
+func (*UnimplementedHubServer) ExpandCluster(req *ExpandClusterRequest, srv Hub_ExpandClusterServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExpandCluster not implemented")
+}
+func (*UnimplementedHubServer) AddStandby(req *AddStandbyRequest, srv Hub_AddStandbyServer) error {
+	return status.Errorf(codes.Unimplemented, "method AddStandby not implemented")
+}
+func (*UnimplementedHubServer) RemoveStandby(req *RemoveStandbyRequest, srv Hub_RemoveStandbyServer) error {
+	return status.Errorf(codes.Unimplemented, "method RemoveStandby not implemented")
+}
+func (*UnimplementedHubServer) ActivateStandby(req *ActivateStandbyRequest, srv Hub_ActivateStandbyServer) error {
+	return status.Errorf(codes.Unimplemented, "method ActivateStandby not implemented")
+}
+func (*UnimplementedHubServer) UpdateHosts(ctx context.Context, req *UpdateHostsRequest) (*UpdateHostsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateHosts not implemented")
+}
+func (*UnimplementedHubServer) PushFile(ctx context.Context, req *PushFileRequest) (*PushFileReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PushFile not implemented")
+}
+func (*UnimplementedHubServer) CollectFile(ctx context.Context, req *CollectFileRequest) (*CollectFileReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CollectFile not implemented")
+}
+func (*UnimplementedHubServer) ReloadCredentials(ctx context.Context, req *ReloadCredentialsRequest) (*ReloadCredentialsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReloadCredentials not implemented")
 }
-func (*UnimplementedHubServer) AddMirrors(req *AddMirrorsRequest, srv Hub_AddMirrorsServer) error {
-	return status.Errorf(codes.Unimplemented, "method AddMirrors not implemented")
+func (*UnimplementedHubServer) ListOperations(ctx context.Context, req *ListOperationsRequest) (*ListOperationsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOperations not implemented")
 }
-func (*UnimplementedHubServer) GetAllHostNames(ctx context.Context, req *GetAllHostNamesRequest) (*GetAllHostNamesReply, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetAllHostNames not implemented")
+func (*UnimplementedHubServer) AttachOperation(req *AttachOperationRequest, srv Hub_AttachOperationServer) error {
+	return status.Errorf(codes.Unimplemented, "method AttachOperation not implemented")
+}
+func (*UnimplementedHubServer) CancelOperation(ctx context.Context, req *CancelOperationRequest) (*CancelOperationReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelOperation not implemented")
 }
 
 func RegisterHubServer(s *grpc.Server, srv HubServer) {
@@ -1765,6 +3519,300 @@ func _Hub_GetAllHostNames_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Hub_StartCluster_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StartClusterRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(HubServer).StartCluster(m, &hubStartClusterServer{stream})
+}
+
+type Hub_StartClusterServer interface {
+	Send(*HubReply) error
+	grpc.ServerStream
+}
+
+type hubStartClusterServer struct {
+	grpc.ServerStream
+}
+
+func (x *hubStartClusterServer) Send(m *HubReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Hub_StopCluster_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StopClusterRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(HubServer).StopCluster(m, &hubStopClusterServer{stream})
+}
+
+type Hub_StopClusterServer interface {
+	Send(*HubReply) error
+	grpc.ServerStream
+}
+
+type hubStopClusterServer struct {
+	grpc.ServerStream
+}
+
+func (x *hubStopClusterServer) Send(m *HubReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Hub_ClusterStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClusterStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HubServer).ClusterStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/idl.Hub/ClusterStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HubServer).ClusterStatus(ctx, req.(*ClusterStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Hub_RecoverSegments_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RecoverSegmentsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(HubServer).RecoverSegments(m, &hubRecoverSegmentsServer{stream})
+}
+
+type Hub_RecoverSegmentsServer interface {
+	Send(*HubReply) error
+	grpc.ServerStream
+}
+
+type hubRecoverSegmentsServer struct {
+	grpc.ServerStream
+}
+
+func (x *hubRecoverSegmentsServer) Send(m *HubReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Hub_ExpandCluster_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExpandClusterRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(HubServer).ExpandCluster(m, &hubExpandClusterServer{stream})
+}
+
+type Hub_ExpandClusterServer interface {
+	Send(*HubReply) error
+	grpc.ServerStream
+}
+
+type hubExpandClusterServer struct {
+	grpc.ServerStream
+}
+
+func (x *hubExpandClusterServer) Send(m *HubReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Hub_AddStandby_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(AddStandbyRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(HubServer).AddStandby(m, &hubAddStandbyServer{stream})
+}
+
+type Hub_AddStandbyServer interface {
+	Send(*HubReply) error
+	grpc.ServerStream
+}
+
+type hubAddStandbyServer struct {
+	grpc.ServerStream
+}
+
+func (x *hubAddStandbyServer) Send(m *HubReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Hub_RemoveStandby_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RemoveStandbyRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(HubServer).RemoveStandby(m, &hubRemoveStandbyServer{stream})
+}
+
+type Hub_RemoveStandbyServer interface {
+	Send(*HubReply) error
+	grpc.ServerStream
+}
+
+type hubRemoveStandbyServer struct {
+	grpc.ServerStream
+}
+
+func (x *hubRemoveStandbyServer) Send(m *HubReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Hub_ActivateStandby_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ActivateStandbyRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(HubServer).ActivateStandby(m, &hubActivateStandbyServer{stream})
+}
+
+type Hub_ActivateStandbyServer interface {
+	Send(*HubReply) error
+	grpc.ServerStream
+}
+
+type hubActivateStandbyServer struct {
+	grpc.ServerStream
+}
+
+func (x *hubActivateStandbyServer) Send(m *HubReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Hub_UpdateHosts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateHostsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HubServer).UpdateHosts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/idl.Hub/UpdateHosts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HubServer).UpdateHosts(ctx, req.(*UpdateHostsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Hub_PushFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PushFileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HubServer).PushFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/idl.Hub/PushFile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HubServer).PushFile(ctx, req.(*PushFileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Hub_CollectFile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CollectFileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HubServer).CollectFile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/idl.Hub/CollectFile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HubServer).CollectFile(ctx, req.(*CollectFileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Hub_ReloadCredentials_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReloadCredentialsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HubServer).ReloadCredentials(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/idl.Hub/ReloadCredentials",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HubServer).ReloadCredentials(ctx, req.(*ReloadCredentialsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Hub_ListOperations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOperationsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HubServer).ListOperations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/idl.Hub/ListOperations",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HubServer).ListOperations(ctx, req.(*ListOperationsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Hub_AttachOperation_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(AttachOperationRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(HubServer).AttachOperation(m, &hubAttachOperationServer{stream})
+}
+
+type Hub_AttachOperationServer interface {
+	Send(*HubReply) error
+	grpc.ServerStream
+}
+
+type hubAttachOperationServer struct {
+	grpc.ServerStream
+}
+
+func (x *hubAttachOperationServer) Send(m *HubReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Hub_CancelOperation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelOperationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HubServer).CancelOperation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/idl.Hub/CancelOperation",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HubServer).CancelOperation(ctx, req.(*CancelOperationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Hub_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "idl.Hub",
 	HandlerType: (*HubServer)(nil),
@@ -1797,6 +3845,34 @@ var _Hub_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetAllHostNames",
 			Handler:    _Hub_GetAllHostNames_Handler,
 		},
+		{
+			MethodName: "ClusterStatus",
+			Handler:    _Hub_ClusterStatus_Handler,
+		},
+		{
+			MethodName: "UpdateHosts",
+			Handler:    _Hub_UpdateHosts_Handler,
+		},
+		{
+			MethodName: "PushFile",
+			Handler:    _Hub_PushFile_Handler,
+		},
+		{
+			MethodName: "CollectFile",
+			Handler:    _Hub_CollectFile_Handler,
+		},
+		{
+			MethodName: "ReloadCredentials",
+			Handler:    _Hub_ReloadCredentials_Handler,
+		},
+		{
+			MethodName: "ListOperations",
+			Handler:    _Hub_ListOperations_Handler,
+		},
+		{
+			MethodName: "CancelOperation",
+			Handler:    _Hub_CancelOperation_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -1809,6 +3885,46 @@ var _Hub_serviceDesc = grpc.ServiceDesc{
 			Handler:       _Hub_AddMirrors_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "StartCluster",
+			Handler:       _Hub_StartCluster_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "StopCluster",
+			Handler:       _Hub_StopCluster_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "RecoverSegments",
+			Handler:       _Hub_RecoverSegments_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ExpandCluster",
+			Handler:       _Hub_ExpandCluster_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "AddStandby",
+			Handler:       _Hub_AddStandby_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "RemoveStandby",
+			Handler:       _Hub_RemoveStandby_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ActivateStandby",
+			Handler:       _Hub_ActivateStandby_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "AttachOperation",
+			Handler:       _Hub_AttachOperation_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "hub.proto",
 }
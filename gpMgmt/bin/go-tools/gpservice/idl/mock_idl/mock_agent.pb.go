@@ -11,8 +11,79 @@ import (
 	gomock "github.com/golang/mock/gomock"
 	idl "github.com/greenplum-db/gpdb/gpservice/idl"
 	grpc "google.golang.org/grpc"
+	metadata "google.golang.org/grpc/metadata"
 )
 
+// MockisPutFileRequest_Content is a mock of isPutFileRequest_Content interface.
+type MockisPutFileRequest_Content struct {
+	ctrl     *gomock.Controller
+	recorder *MockisPutFileRequest_ContentMockRecorder
+}
+
+// MockisPutFileRequest_ContentMockRecorder is the mock recorder for MockisPutFileRequest_Content.
+type MockisPutFileRequest_ContentMockRecorder struct {
+	mock *MockisPutFileRequest_Content
+}
+
+// NewMockisPutFileRequest_Content creates a new mock instance.
+func NewMockisPutFileRequest_Content(ctrl *gomock.Controller) *MockisPutFileRequest_Content {
+	mock := &MockisPutFileRequest_Content{ctrl: ctrl}
+	mock.recorder = &MockisPutFileRequest_ContentMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockisPutFileRequest_Content) EXPECT() *MockisPutFileRequest_ContentMockRecorder {
+	return m.recorder
+}
+
+// isPutFileRequest_Content mocks base method.
+func (m *MockisPutFileRequest_Content) isPutFileRequest_Content() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "isPutFileRequest_Content")
+}
+
+// isPutFileRequest_Content indicates an expected call of isPutFileRequest_Content.
+func (mr *MockisPutFileRequest_ContentMockRecorder) isPutFileRequest_Content() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "isPutFileRequest_Content", reflect.TypeOf((*MockisPutFileRequest_Content)(nil).isPutFileRequest_Content))
+}
+
+// MockisGetFileReply_Content is a mock of isGetFileReply_Content interface.
+type MockisGetFileReply_Content struct {
+	ctrl     *gomock.Controller
+	recorder *MockisGetFileReply_ContentMockRecorder
+}
+
+// MockisGetFileReply_ContentMockRecorder is the mock recorder for MockisGetFileReply_Content.
+type MockisGetFileReply_ContentMockRecorder struct {
+	mock *MockisGetFileReply_Content
+}
+
+// NewMockisGetFileReply_Content creates a new mock instance.
+func NewMockisGetFileReply_Content(ctrl *gomock.Controller) *MockisGetFileReply_Content {
+	mock := &MockisGetFileReply_Content{ctrl: ctrl}
+	mock.recorder = &MockisGetFileReply_ContentMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockisGetFileReply_Content) EXPECT() *MockisGetFileReply_ContentMockRecorder {
+	return m.recorder
+}
+
+// isGetFileReply_Content mocks base method.
+func (m *MockisGetFileReply_Content) isGetFileReply_Content() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "isGetFileReply_Content")
+}
+
+// isGetFileReply_Content indicates an expected call of isGetFileReply_Content.
+func (mr *MockisGetFileReply_ContentMockRecorder) isGetFileReply_Content() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "isGetFileReply_Content", reflect.TypeOf((*MockisGetFileReply_Content)(nil).isGetFileReply_Content))
+}
+
 // MockAgentClient is a mock of AgentClient interface.
 type MockAgentClient struct {
 	ctrl     *gomock.Controller
@@ -36,6 +107,26 @@ func (m *MockAgentClient) EXPECT() *MockAgentClientMockRecorder {
 	return m.recorder
 }
 
+// GetFile mocks base method.
+func (m *MockAgentClient) GetFile(ctx context.Context, in *idl.GetFileRequest, opts ...grpc.CallOption) (idl.Agent_GetFileClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetFile", varargs...)
+	ret0, _ := ret[0].(idl.Agent_GetFileClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFile indicates an expected call of GetFile.
+func (mr *MockAgentClientMockRecorder) GetFile(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFile", reflect.TypeOf((*MockAgentClient)(nil).GetFile), varargs...)
+}
+
 // GetHostName mocks base method.
 func (m *MockAgentClient) GetHostName(ctx context.Context, in *idl.GetHostNameRequest, opts ...grpc.CallOption) (*idl.GetHostNameReply, error) {
 	m.ctrl.T.Helper()
@@ -76,6 +167,26 @@ func (mr *MockAgentClientMockRecorder) GetInterfaceAddrs(ctx, in interface{}, op
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInterfaceAddrs", reflect.TypeOf((*MockAgentClient)(nil).GetInterfaceAddrs), varargs...)
 }
 
+// GetSegmentStatus mocks base method.
+func (m *MockAgentClient) GetSegmentStatus(ctx context.Context, in *idl.GetSegmentStatusRequest, opts ...grpc.CallOption) (*idl.GetSegmentStatusReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetSegmentStatus", varargs...)
+	ret0, _ := ret[0].(*idl.GetSegmentStatusReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSegmentStatus indicates an expected call of GetSegmentStatus.
+func (mr *MockAgentClientMockRecorder) GetSegmentStatus(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSegmentStatus", reflect.TypeOf((*MockAgentClient)(nil).GetSegmentStatus), varargs...)
+}
+
 // MakeSegment mocks base method.
 func (m *MockAgentClient) MakeSegment(ctx context.Context, in *idl.MakeSegmentRequest, opts ...grpc.CallOption) (*idl.MakeSegmentReply, error) {
 	m.ctrl.T.Helper()
@@ -116,6 +227,86 @@ func (mr *MockAgentClientMockRecorder) PgBasebackup(ctx, in interface{}, opts ..
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PgBasebackup", reflect.TypeOf((*MockAgentClient)(nil).PgBasebackup), varargs...)
 }
 
+// PgRewind mocks base method.
+func (m *MockAgentClient) PgRewind(ctx context.Context, in *idl.PgRewindRequest, opts ...grpc.CallOption) (*idl.PgRewindResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PgRewind", varargs...)
+	ret0, _ := ret[0].(*idl.PgRewindResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PgRewind indicates an expected call of PgRewind.
+func (mr *MockAgentClientMockRecorder) PgRewind(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PgRewind", reflect.TypeOf((*MockAgentClient)(nil).PgRewind), varargs...)
+}
+
+// PromoteSegment mocks base method.
+func (m *MockAgentClient) PromoteSegment(ctx context.Context, in *idl.PromoteSegmentRequest, opts ...grpc.CallOption) (*idl.PromoteSegmentReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PromoteSegment", varargs...)
+	ret0, _ := ret[0].(*idl.PromoteSegmentReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PromoteSegment indicates an expected call of PromoteSegment.
+func (mr *MockAgentClientMockRecorder) PromoteSegment(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PromoteSegment", reflect.TypeOf((*MockAgentClient)(nil).PromoteSegment), varargs...)
+}
+
+// PutFile mocks base method.
+func (m *MockAgentClient) PutFile(ctx context.Context, opts ...grpc.CallOption) (idl.Agent_PutFileClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PutFile", varargs...)
+	ret0, _ := ret[0].(idl.Agent_PutFileClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PutFile indicates an expected call of PutFile.
+func (mr *MockAgentClientMockRecorder) PutFile(ctx interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutFile", reflect.TypeOf((*MockAgentClient)(nil).PutFile), varargs...)
+}
+
+// ReloadCredentials mocks base method.
+func (m *MockAgentClient) ReloadCredentials(ctx context.Context, in *idl.ReloadAgentCredentialsRequest, opts ...grpc.CallOption) (*idl.ReloadAgentCredentialsReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ReloadCredentials", varargs...)
+	ret0, _ := ret[0].(*idl.ReloadAgentCredentialsReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReloadCredentials indicates an expected call of ReloadCredentials.
+func (mr *MockAgentClientMockRecorder) ReloadCredentials(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReloadCredentials", reflect.TypeOf((*MockAgentClient)(nil).ReloadCredentials), varargs...)
+}
+
 // RemoveDirectory mocks base method.
 func (m *MockAgentClient) RemoveDirectory(ctx context.Context, in *idl.RemoveDirectoryRequest, opts ...grpc.CallOption) (*idl.RemoveDirectoryReply, error) {
 	m.ctrl.T.Helper()
@@ -196,6 +387,26 @@ func (mr *MockAgentClientMockRecorder) Stop(ctx, in interface{}, opts ...interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockAgentClient)(nil).Stop), varargs...)
 }
 
+// StopSegment mocks base method.
+func (m *MockAgentClient) StopSegment(ctx context.Context, in *idl.StopSegmentRequest, opts ...grpc.CallOption) (*idl.StopSegmentReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "StopSegment", varargs...)
+	ret0, _ := ret[0].(*idl.StopSegmentReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StopSegment indicates an expected call of StopSegment.
+func (mr *MockAgentClientMockRecorder) StopSegment(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopSegment", reflect.TypeOf((*MockAgentClient)(nil).StopSegment), varargs...)
+}
+
 // UpdatePgConf mocks base method.
 func (m *MockAgentClient) UpdatePgConf(ctx context.Context, in *idl.UpdatePgConfRequest, opts ...grpc.CallOption) (*idl.UpdatePgConfRespoonse, error) {
 	m.ctrl.T.Helper()
@@ -256,6 +467,266 @@ func (mr *MockAgentClientMockRecorder) ValidateHostEnv(ctx, in interface{}, opts
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateHostEnv", reflect.TypeOf((*MockAgentClient)(nil).ValidateHostEnv), varargs...)
 }
 
+// MockAgent_PutFileClient is a mock of Agent_PutFileClient interface.
+type MockAgent_PutFileClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockAgent_PutFileClientMockRecorder
+}
+
+// MockAgent_PutFileClientMockRecorder is the mock recorder for MockAgent_PutFileClient.
+type MockAgent_PutFileClientMockRecorder struct {
+	mock *MockAgent_PutFileClient
+}
+
+// NewMockAgent_PutFileClient creates a new mock instance.
+func NewMockAgent_PutFileClient(ctrl *gomock.Controller) *MockAgent_PutFileClient {
+	mock := &MockAgent_PutFileClient{ctrl: ctrl}
+	mock.recorder = &MockAgent_PutFileClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAgent_PutFileClient) EXPECT() *MockAgent_PutFileClientMockRecorder {
+	return m.recorder
+}
+
+// CloseAndRecv mocks base method.
+func (m *MockAgent_PutFileClient) CloseAndRecv() (*idl.PutFileReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseAndRecv")
+	ret0, _ := ret[0].(*idl.PutFileReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CloseAndRecv indicates an expected call of CloseAndRecv.
+func (mr *MockAgent_PutFileClientMockRecorder) CloseAndRecv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseAndRecv", reflect.TypeOf((*MockAgent_PutFileClient)(nil).CloseAndRecv))
+}
+
+// CloseSend mocks base method.
+func (m *MockAgent_PutFileClient) CloseSend() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseSend")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseSend indicates an expected call of CloseSend.
+func (mr *MockAgent_PutFileClientMockRecorder) CloseSend() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseSend", reflect.TypeOf((*MockAgent_PutFileClient)(nil).CloseSend))
+}
+
+// Context mocks base method.
+func (m *MockAgent_PutFileClient) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockAgent_PutFileClientMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockAgent_PutFileClient)(nil).Context))
+}
+
+// Header mocks base method.
+func (m *MockAgent_PutFileClient) Header() (metadata.MD, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Header")
+	ret0, _ := ret[0].(metadata.MD)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Header indicates an expected call of Header.
+func (mr *MockAgent_PutFileClientMockRecorder) Header() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Header", reflect.TypeOf((*MockAgent_PutFileClient)(nil).Header))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockAgent_PutFileClient) RecvMsg(m any) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockAgent_PutFileClientMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockAgent_PutFileClient)(nil).RecvMsg), m)
+}
+
+// Send mocks base method.
+func (m *MockAgent_PutFileClient) Send(arg0 *idl.PutFileRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockAgent_PutFileClientMockRecorder) Send(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockAgent_PutFileClient)(nil).Send), arg0)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockAgent_PutFileClient) SendMsg(m any) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockAgent_PutFileClientMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockAgent_PutFileClient)(nil).SendMsg), m)
+}
+
+// Trailer mocks base method.
+func (m *MockAgent_PutFileClient) Trailer() metadata.MD {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trailer")
+	ret0, _ := ret[0].(metadata.MD)
+	return ret0
+}
+
+// Trailer indicates an expected call of Trailer.
+func (mr *MockAgent_PutFileClientMockRecorder) Trailer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trailer", reflect.TypeOf((*MockAgent_PutFileClient)(nil).Trailer))
+}
+
+// MockAgent_GetFileClient is a mock of Agent_GetFileClient interface.
+type MockAgent_GetFileClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockAgent_GetFileClientMockRecorder
+}
+
+// MockAgent_GetFileClientMockRecorder is the mock recorder for MockAgent_GetFileClient.
+type MockAgent_GetFileClientMockRecorder struct {
+	mock *MockAgent_GetFileClient
+}
+
+// NewMockAgent_GetFileClient creates a new mock instance.
+func NewMockAgent_GetFileClient(ctrl *gomock.Controller) *MockAgent_GetFileClient {
+	mock := &MockAgent_GetFileClient{ctrl: ctrl}
+	mock.recorder = &MockAgent_GetFileClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAgent_GetFileClient) EXPECT() *MockAgent_GetFileClientMockRecorder {
+	return m.recorder
+}
+
+// CloseSend mocks base method.
+func (m *MockAgent_GetFileClient) CloseSend() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseSend")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseSend indicates an expected call of CloseSend.
+func (mr *MockAgent_GetFileClientMockRecorder) CloseSend() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseSend", reflect.TypeOf((*MockAgent_GetFileClient)(nil).CloseSend))
+}
+
+// Context mocks base method.
+func (m *MockAgent_GetFileClient) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockAgent_GetFileClientMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockAgent_GetFileClient)(nil).Context))
+}
+
+// Header mocks base method.
+func (m *MockAgent_GetFileClient) Header() (metadata.MD, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Header")
+	ret0, _ := ret[0].(metadata.MD)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Header indicates an expected call of Header.
+func (mr *MockAgent_GetFileClientMockRecorder) Header() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Header", reflect.TypeOf((*MockAgent_GetFileClient)(nil).Header))
+}
+
+// Recv mocks base method.
+func (m *MockAgent_GetFileClient) Recv() (*idl.GetFileReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recv")
+	ret0, _ := ret[0].(*idl.GetFileReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recv indicates an expected call of Recv.
+func (mr *MockAgent_GetFileClientMockRecorder) Recv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recv", reflect.TypeOf((*MockAgent_GetFileClient)(nil).Recv))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockAgent_GetFileClient) RecvMsg(m any) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockAgent_GetFileClientMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockAgent_GetFileClient)(nil).RecvMsg), m)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockAgent_GetFileClient) SendMsg(m any) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockAgent_GetFileClientMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockAgent_GetFileClient)(nil).SendMsg), m)
+}
+
+// Trailer mocks base method.
+func (m *MockAgent_GetFileClient) Trailer() metadata.MD {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Trailer")
+	ret0, _ := ret[0].(metadata.MD)
+	return ret0
+}
+
+// Trailer indicates an expected call of Trailer.
+func (mr *MockAgent_GetFileClientMockRecorder) Trailer() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trailer", reflect.TypeOf((*MockAgent_GetFileClient)(nil).Trailer))
+}
+
 // MockAgentServer is a mock of AgentServer interface.
 type MockAgentServer struct {
 	ctrl     *gomock.Controller
@@ -279,6 +750,20 @@ func (m *MockAgentServer) EXPECT() *MockAgentServerMockRecorder {
 	return m.recorder
 }
 
+// GetFile mocks base method.
+func (m *MockAgentServer) GetFile(arg0 *idl.GetFileRequest, arg1 idl.Agent_GetFileServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFile", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetFile indicates an expected call of GetFile.
+func (mr *MockAgentServerMockRecorder) GetFile(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFile", reflect.TypeOf((*MockAgentServer)(nil).GetFile), arg0, arg1)
+}
+
 // GetHostName mocks base method.
 func (m *MockAgentServer) GetHostName(arg0 context.Context, arg1 *idl.GetHostNameRequest) (*idl.GetHostNameReply, error) {
 	m.ctrl.T.Helper()
@@ -309,6 +794,21 @@ func (mr *MockAgentServerMockRecorder) GetInterfaceAddrs(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInterfaceAddrs", reflect.TypeOf((*MockAgentServer)(nil).GetInterfaceAddrs), arg0, arg1)
 }
 
+// GetSegmentStatus mocks base method.
+func (m *MockAgentServer) GetSegmentStatus(arg0 context.Context, arg1 *idl.GetSegmentStatusRequest) (*idl.GetSegmentStatusReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSegmentStatus", arg0, arg1)
+	ret0, _ := ret[0].(*idl.GetSegmentStatusReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSegmentStatus indicates an expected call of GetSegmentStatus.
+func (mr *MockAgentServerMockRecorder) GetSegmentStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSegmentStatus", reflect.TypeOf((*MockAgentServer)(nil).GetSegmentStatus), arg0, arg1)
+}
+
 // MakeSegment mocks base method.
 func (m *MockAgentServer) MakeSegment(arg0 context.Context, arg1 *idl.MakeSegmentRequest) (*idl.MakeSegmentReply, error) {
 	m.ctrl.T.Helper()
@@ -339,6 +839,65 @@ func (mr *MockAgentServerMockRecorder) PgBasebackup(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PgBasebackup", reflect.TypeOf((*MockAgentServer)(nil).PgBasebackup), arg0, arg1)
 }
 
+// PgRewind mocks base method.
+func (m *MockAgentServer) PgRewind(arg0 context.Context, arg1 *idl.PgRewindRequest) (*idl.PgRewindResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PgRewind", arg0, arg1)
+	ret0, _ := ret[0].(*idl.PgRewindResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PgRewind indicates an expected call of PgRewind.
+func (mr *MockAgentServerMockRecorder) PgRewind(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PgRewind", reflect.TypeOf((*MockAgentServer)(nil).PgRewind), arg0, arg1)
+}
+
+// PromoteSegment mocks base method.
+func (m *MockAgentServer) PromoteSegment(arg0 context.Context, arg1 *idl.PromoteSegmentRequest) (*idl.PromoteSegmentReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PromoteSegment", arg0, arg1)
+	ret0, _ := ret[0].(*idl.PromoteSegmentReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PromoteSegment indicates an expected call of PromoteSegment.
+func (mr *MockAgentServerMockRecorder) PromoteSegment(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PromoteSegment", reflect.TypeOf((*MockAgentServer)(nil).PromoteSegment), arg0, arg1)
+}
+
+// PutFile mocks base method.
+func (m *MockAgentServer) PutFile(arg0 idl.Agent_PutFileServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PutFile", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PutFile indicates an expected call of PutFile.
+func (mr *MockAgentServerMockRecorder) PutFile(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PutFile", reflect.TypeOf((*MockAgentServer)(nil).PutFile), arg0)
+}
+
+// ReloadCredentials mocks base method.
+func (m *MockAgentServer) ReloadCredentials(arg0 context.Context, arg1 *idl.ReloadAgentCredentialsRequest) (*idl.ReloadAgentCredentialsReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReloadCredentials", arg0, arg1)
+	ret0, _ := ret[0].(*idl.ReloadAgentCredentialsReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReloadCredentials indicates an expected call of ReloadCredentials.
+func (mr *MockAgentServerMockRecorder) ReloadCredentials(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReloadCredentials", reflect.TypeOf((*MockAgentServer)(nil).ReloadCredentials), arg0, arg1)
+}
+
 // RemoveDirectory mocks base method.
 func (m *MockAgentServer) RemoveDirectory(arg0 context.Context, arg1 *idl.RemoveDirectoryRequest) (*idl.RemoveDirectoryReply, error) {
 	m.ctrl.T.Helper()
@@ -399,6 +958,21 @@ func (mr *MockAgentServerMockRecorder) Stop(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockAgentServer)(nil).Stop), arg0, arg1)
 }
 
+// StopSegment mocks base method.
+func (m *MockAgentServer) StopSegment(arg0 context.Context, arg1 *idl.StopSegmentRequest) (*idl.StopSegmentReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopSegment", arg0, arg1)
+	ret0, _ := ret[0].(*idl.StopSegmentReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StopSegment indicates an expected call of StopSegment.
+func (mr *MockAgentServerMockRecorder) StopSegment(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopSegment", reflect.TypeOf((*MockAgentServer)(nil).StopSegment), arg0, arg1)
+}
+
 // UpdatePgConf mocks base method.
 func (m *MockAgentServer) UpdatePgConf(arg0 context.Context, arg1 *idl.UpdatePgConfRequest) (*idl.UpdatePgConfRespoonse, error) {
 	m.ctrl.T.Helper()
@@ -443,3 +1017,256 @@ func (mr *MockAgentServerMockRecorder) ValidateHostEnv(arg0, arg1 interface{}) *
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateHostEnv", reflect.TypeOf((*MockAgentServer)(nil).ValidateHostEnv), arg0, arg1)
 }
+
+// MockAgent_PutFileServer is a mock of Agent_PutFileServer interface.
+type MockAgent_PutFileServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockAgent_PutFileServerMockRecorder
+}
+
+// MockAgent_PutFileServerMockRecorder is the mock recorder for MockAgent_PutFileServer.
+type MockAgent_PutFileServerMockRecorder struct {
+	mock *MockAgent_PutFileServer
+}
+
+// NewMockAgent_PutFileServer creates a new mock instance.
+func NewMockAgent_PutFileServer(ctrl *gomock.Controller) *MockAgent_PutFileServer {
+	mock := &MockAgent_PutFileServer{ctrl: ctrl}
+	mock.recorder = &MockAgent_PutFileServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAgent_PutFileServer) EXPECT() *MockAgent_PutFileServerMockRecorder {
+	return m.recorder
+}
+
+// Context mocks base method.
+func (m *MockAgent_PutFileServer) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockAgent_PutFileServerMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockAgent_PutFileServer)(nil).Context))
+}
+
+// Recv mocks base method.
+func (m *MockAgent_PutFileServer) Recv() (*idl.PutFileRequest, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Recv")
+	ret0, _ := ret[0].(*idl.PutFileRequest)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Recv indicates an expected call of Recv.
+func (mr *MockAgent_PutFileServerMockRecorder) Recv() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Recv", reflect.TypeOf((*MockAgent_PutFileServer)(nil).Recv))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockAgent_PutFileServer) RecvMsg(m any) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockAgent_PutFileServerMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockAgent_PutFileServer)(nil).RecvMsg), m)
+}
+
+// SendAndClose mocks base method.
+func (m *MockAgent_PutFileServer) SendAndClose(arg0 *idl.PutFileReply) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendAndClose", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendAndClose indicates an expected call of SendAndClose.
+func (mr *MockAgent_PutFileServerMockRecorder) SendAndClose(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendAndClose", reflect.TypeOf((*MockAgent_PutFileServer)(nil).SendAndClose), arg0)
+}
+
+// SendHeader mocks base method.
+func (m *MockAgent_PutFileServer) SendHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendHeader indicates an expected call of SendHeader.
+func (mr *MockAgent_PutFileServerMockRecorder) SendHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendHeader", reflect.TypeOf((*MockAgent_PutFileServer)(nil).SendHeader), arg0)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockAgent_PutFileServer) SendMsg(m any) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockAgent_PutFileServerMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockAgent_PutFileServer)(nil).SendMsg), m)
+}
+
+// SetHeader mocks base method.
+func (m *MockAgent_PutFileServer) SetHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetHeader indicates an expected call of SetHeader.
+func (mr *MockAgent_PutFileServerMockRecorder) SetHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHeader", reflect.TypeOf((*MockAgent_PutFileServer)(nil).SetHeader), arg0)
+}
+
+// SetTrailer mocks base method.
+func (m *MockAgent_PutFileServer) SetTrailer(arg0 metadata.MD) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTrailer", arg0)
+}
+
+// SetTrailer indicates an expected call of SetTrailer.
+func (mr *MockAgent_PutFileServerMockRecorder) SetTrailer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockAgent_PutFileServer)(nil).SetTrailer), arg0)
+}
+
+// MockAgent_GetFileServer is a mock of Agent_GetFileServer interface.
+type MockAgent_GetFileServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockAgent_GetFileServerMockRecorder
+}
+
+// MockAgent_GetFileServerMockRecorder is the mock recorder for MockAgent_GetFileServer.
+type MockAgent_GetFileServerMockRecorder struct {
+	mock *MockAgent_GetFileServer
+}
+
+// NewMockAgent_GetFileServer creates a new mock instance.
+func NewMockAgent_GetFileServer(ctrl *gomock.Controller) *MockAgent_GetFileServer {
+	mock := &MockAgent_GetFileServer{ctrl: ctrl}
+	mock.recorder = &MockAgent_GetFileServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAgent_GetFileServer) EXPECT() *MockAgent_GetFileServerMockRecorder {
+	return m.recorder
+}
+
+// Context mocks base method.
+func (m *MockAgent_GetFileServer) Context() context.Context {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Context")
+	ret0, _ := ret[0].(context.Context)
+	return ret0
+}
+
+// Context indicates an expected call of Context.
+func (mr *MockAgent_GetFileServerMockRecorder) Context() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Context", reflect.TypeOf((*MockAgent_GetFileServer)(nil).Context))
+}
+
+// RecvMsg mocks base method.
+func (m_2 *MockAgent_GetFileServer) RecvMsg(m any) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "RecvMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecvMsg indicates an expected call of RecvMsg.
+func (mr *MockAgent_GetFileServerMockRecorder) RecvMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecvMsg", reflect.TypeOf((*MockAgent_GetFileServer)(nil).RecvMsg), m)
+}
+
+// Send mocks base method.
+func (m *MockAgent_GetFileServer) Send(arg0 *idl.GetFileReply) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockAgent_GetFileServerMockRecorder) Send(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockAgent_GetFileServer)(nil).Send), arg0)
+}
+
+// SendHeader mocks base method.
+func (m *MockAgent_GetFileServer) SendHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendHeader indicates an expected call of SendHeader.
+func (mr *MockAgent_GetFileServerMockRecorder) SendHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendHeader", reflect.TypeOf((*MockAgent_GetFileServer)(nil).SendHeader), arg0)
+}
+
+// SendMsg mocks base method.
+func (m_2 *MockAgent_GetFileServer) SendMsg(m any) error {
+	m_2.ctrl.T.Helper()
+	ret := m_2.ctrl.Call(m_2, "SendMsg", m)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendMsg indicates an expected call of SendMsg.
+func (mr *MockAgent_GetFileServerMockRecorder) SendMsg(m interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMsg", reflect.TypeOf((*MockAgent_GetFileServer)(nil).SendMsg), m)
+}
+
+// SetHeader mocks base method.
+func (m *MockAgent_GetFileServer) SetHeader(arg0 metadata.MD) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetHeader", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetHeader indicates an expected call of SetHeader.
+func (mr *MockAgent_GetFileServerMockRecorder) SetHeader(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetHeader", reflect.TypeOf((*MockAgent_GetFileServer)(nil).SetHeader), arg0)
+}
+
+// SetTrailer mocks base method.
+func (m *MockAgent_GetFileServer) SetTrailer(arg0 metadata.MD) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetTrailer", arg0)
+}
+
+// SetTrailer indicates an expected call of SetTrailer.
+func (mr *MockAgent_GetFileServerMockRecorder) SetTrailer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTrailer", reflect.TypeOf((*MockAgent_GetFileServer)(nil).SetTrailer), arg0)
+}
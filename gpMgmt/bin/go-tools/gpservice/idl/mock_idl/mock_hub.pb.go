@@ -36,6 +36,26 @@ func (m *MockHubClient) EXPECT() *MockHubClientMockRecorder {
 	return m.recorder
 }
 
+// ActivateStandby mocks base method.
+func (m *MockHubClient) ActivateStandby(arg0 context.Context, arg1 *idl.ActivateStandbyRequest, arg2 ...grpc.CallOption) (idl.Hub_ActivateStandbyClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ActivateStandby", varargs...)
+	ret0, _ := ret[0].(idl.Hub_ActivateStandbyClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ActivateStandby indicates an expected call of ActivateStandby.
+func (mr *MockHubClientMockRecorder) ActivateStandby(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActivateStandby", reflect.TypeOf((*MockHubClient)(nil).ActivateStandby), varargs...)
+}
+
 // AddMirrors mocks base method.
 func (m *MockHubClient) AddMirrors(arg0 context.Context, arg1 *idl.AddMirrorsRequest, arg2 ...grpc.CallOption) (idl.Hub_AddMirrorsClient, error) {
 	m.ctrl.T.Helper()
@@ -56,6 +76,66 @@ func (mr *MockHubClientMockRecorder) AddMirrors(arg0, arg1 interface{}, arg2 ...
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMirrors", reflect.TypeOf((*MockHubClient)(nil).AddMirrors), varargs...)
 }
 
+// AddStandby mocks base method.
+func (m *MockHubClient) AddStandby(arg0 context.Context, arg1 *idl.AddStandbyRequest, arg2 ...grpc.CallOption) (idl.Hub_AddStandbyClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AddStandby", varargs...)
+	ret0, _ := ret[0].(idl.Hub_AddStandbyClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddStandby indicates an expected call of AddStandby.
+func (mr *MockHubClientMockRecorder) AddStandby(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddStandby", reflect.TypeOf((*MockHubClient)(nil).AddStandby), varargs...)
+}
+
+// AttachOperation mocks base method.
+func (m *MockHubClient) AttachOperation(arg0 context.Context, arg1 *idl.AttachOperationRequest, arg2 ...grpc.CallOption) (idl.Hub_AttachOperationClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "AttachOperation", varargs...)
+	ret0, _ := ret[0].(idl.Hub_AttachOperationClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AttachOperation indicates an expected call of AttachOperation.
+func (mr *MockHubClientMockRecorder) AttachOperation(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AttachOperation", reflect.TypeOf((*MockHubClient)(nil).AttachOperation), varargs...)
+}
+
+// CancelOperation mocks base method.
+func (m *MockHubClient) CancelOperation(arg0 context.Context, arg1 *idl.CancelOperationRequest, arg2 ...grpc.CallOption) (*idl.CancelOperationReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CancelOperation", varargs...)
+	ret0, _ := ret[0].(*idl.CancelOperationReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelOperation indicates an expected call of CancelOperation.
+func (mr *MockHubClientMockRecorder) CancelOperation(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelOperation", reflect.TypeOf((*MockHubClient)(nil).CancelOperation), varargs...)
+}
+
 // CleanInitCluster mocks base method.
 func (m *MockHubClient) CleanInitCluster(arg0 context.Context, arg1 *idl.CleanInitClusterRequest, arg2 ...grpc.CallOption) (*idl.CleanInitClusterReply, error) {
 	m.ctrl.T.Helper()
@@ -76,6 +156,66 @@ func (mr *MockHubClientMockRecorder) CleanInitCluster(arg0, arg1 interface{}, ar
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CleanInitCluster", reflect.TypeOf((*MockHubClient)(nil).CleanInitCluster), varargs...)
 }
 
+// ClusterStatus mocks base method.
+func (m *MockHubClient) ClusterStatus(arg0 context.Context, arg1 *idl.ClusterStatusRequest, arg2 ...grpc.CallOption) (*idl.ClusterStatusReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ClusterStatus", varargs...)
+	ret0, _ := ret[0].(*idl.ClusterStatusReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClusterStatus indicates an expected call of ClusterStatus.
+func (mr *MockHubClientMockRecorder) ClusterStatus(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClusterStatus", reflect.TypeOf((*MockHubClient)(nil).ClusterStatus), varargs...)
+}
+
+// CollectFile mocks base method.
+func (m *MockHubClient) CollectFile(arg0 context.Context, arg1 *idl.CollectFileRequest, arg2 ...grpc.CallOption) (*idl.CollectFileReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CollectFile", varargs...)
+	ret0, _ := ret[0].(*idl.CollectFileReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CollectFile indicates an expected call of CollectFile.
+func (mr *MockHubClientMockRecorder) CollectFile(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CollectFile", reflect.TypeOf((*MockHubClient)(nil).CollectFile), varargs...)
+}
+
+// ExpandCluster mocks base method.
+func (m *MockHubClient) ExpandCluster(arg0 context.Context, arg1 *idl.ExpandClusterRequest, arg2 ...grpc.CallOption) (idl.Hub_ExpandClusterClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ExpandCluster", varargs...)
+	ret0, _ := ret[0].(idl.Hub_ExpandClusterClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExpandCluster indicates an expected call of ExpandCluster.
+func (mr *MockHubClientMockRecorder) ExpandCluster(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpandCluster", reflect.TypeOf((*MockHubClient)(nil).ExpandCluster), varargs...)
+}
+
 // GetAllHostNames mocks base method.
 func (m *MockHubClient) GetAllHostNames(arg0 context.Context, arg1 *idl.GetAllHostNamesRequest, arg2 ...grpc.CallOption) (*idl.GetAllHostNamesReply, error) {
 	m.ctrl.T.Helper()
@@ -96,6 +236,26 @@ func (mr *MockHubClientMockRecorder) GetAllHostNames(arg0, arg1 interface{}, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllHostNames", reflect.TypeOf((*MockHubClient)(nil).GetAllHostNames), varargs...)
 }
 
+// ListOperations mocks base method.
+func (m *MockHubClient) ListOperations(arg0 context.Context, arg1 *idl.ListOperationsRequest, arg2 ...grpc.CallOption) (*idl.ListOperationsReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListOperations", varargs...)
+	ret0, _ := ret[0].(*idl.ListOperationsReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOperations indicates an expected call of ListOperations.
+func (mr *MockHubClientMockRecorder) ListOperations(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOperations", reflect.TypeOf((*MockHubClient)(nil).ListOperations), varargs...)
+}
+
 // MakeCluster mocks base method.
 func (m *MockHubClient) MakeCluster(arg0 context.Context, arg1 *idl.MakeClusterRequest, arg2 ...grpc.CallOption) (idl.Hub_MakeClusterClient, error) {
 	m.ctrl.T.Helper()
@@ -116,6 +276,86 @@ func (mr *MockHubClientMockRecorder) MakeCluster(arg0, arg1 interface{}, arg2 ..
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MakeCluster", reflect.TypeOf((*MockHubClient)(nil).MakeCluster), varargs...)
 }
 
+// PushFile mocks base method.
+func (m *MockHubClient) PushFile(arg0 context.Context, arg1 *idl.PushFileRequest, arg2 ...grpc.CallOption) (*idl.PushFileReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PushFile", varargs...)
+	ret0, _ := ret[0].(*idl.PushFileReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PushFile indicates an expected call of PushFile.
+func (mr *MockHubClientMockRecorder) PushFile(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PushFile", reflect.TypeOf((*MockHubClient)(nil).PushFile), varargs...)
+}
+
+// RecoverSegments mocks base method.
+func (m *MockHubClient) RecoverSegments(arg0 context.Context, arg1 *idl.RecoverSegmentsRequest, arg2 ...grpc.CallOption) (idl.Hub_RecoverSegmentsClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RecoverSegments", varargs...)
+	ret0, _ := ret[0].(idl.Hub_RecoverSegmentsClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecoverSegments indicates an expected call of RecoverSegments.
+func (mr *MockHubClientMockRecorder) RecoverSegments(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecoverSegments", reflect.TypeOf((*MockHubClient)(nil).RecoverSegments), varargs...)
+}
+
+// ReloadCredentials mocks base method.
+func (m *MockHubClient) ReloadCredentials(arg0 context.Context, arg1 *idl.ReloadCredentialsRequest, arg2 ...grpc.CallOption) (*idl.ReloadCredentialsReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ReloadCredentials", varargs...)
+	ret0, _ := ret[0].(*idl.ReloadCredentialsReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReloadCredentials indicates an expected call of ReloadCredentials.
+func (mr *MockHubClientMockRecorder) ReloadCredentials(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReloadCredentials", reflect.TypeOf((*MockHubClient)(nil).ReloadCredentials), varargs...)
+}
+
+// RemoveStandby mocks base method.
+func (m *MockHubClient) RemoveStandby(arg0 context.Context, arg1 *idl.RemoveStandbyRequest, arg2 ...grpc.CallOption) (idl.Hub_RemoveStandbyClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RemoveStandby", varargs...)
+	ret0, _ := ret[0].(idl.Hub_RemoveStandbyClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveStandby indicates an expected call of RemoveStandby.
+func (mr *MockHubClientMockRecorder) RemoveStandby(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveStandby", reflect.TypeOf((*MockHubClient)(nil).RemoveStandby), varargs...)
+}
+
 // ReportAgentHealth mocks base method.
 func (m *MockHubClient) ReportAgentHealth(arg0 context.Context, arg1 *idl.ReportAgentHealthRequest, arg2 ...grpc.CallOption) (*idl.ReportAgentHealthResponse, error) {
 	m.ctrl.T.Helper()
@@ -156,6 +396,26 @@ func (mr *MockHubClientMockRecorder) StartAgents(arg0, arg1 interface{}, arg2 ..
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartAgents", reflect.TypeOf((*MockHubClient)(nil).StartAgents), varargs...)
 }
 
+// StartCluster mocks base method.
+func (m *MockHubClient) StartCluster(arg0 context.Context, arg1 *idl.StartClusterRequest, arg2 ...grpc.CallOption) (idl.Hub_StartClusterClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "StartCluster", varargs...)
+	ret0, _ := ret[0].(idl.Hub_StartClusterClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartCluster indicates an expected call of StartCluster.
+func (mr *MockHubClientMockRecorder) StartCluster(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartCluster", reflect.TypeOf((*MockHubClient)(nil).StartCluster), varargs...)
+}
+
 // StatusAgents mocks base method.
 func (m *MockHubClient) StatusAgents(arg0 context.Context, arg1 *idl.StatusAgentsRequest, arg2 ...grpc.CallOption) (*idl.StatusAgentsReply, error) {
 	m.ctrl.T.Helper()
@@ -216,6 +476,46 @@ func (mr *MockHubClientMockRecorder) StopAgents(arg0, arg1 interface{}, arg2 ...
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopAgents", reflect.TypeOf((*MockHubClient)(nil).StopAgents), varargs...)
 }
 
+// StopCluster mocks base method.
+func (m *MockHubClient) StopCluster(arg0 context.Context, arg1 *idl.StopClusterRequest, arg2 ...grpc.CallOption) (idl.Hub_StopClusterClient, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "StopCluster", varargs...)
+	ret0, _ := ret[0].(idl.Hub_StopClusterClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StopCluster indicates an expected call of StopCluster.
+func (mr *MockHubClientMockRecorder) StopCluster(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopCluster", reflect.TypeOf((*MockHubClient)(nil).StopCluster), varargs...)
+}
+
+// UpdateHosts mocks base method.
+func (m *MockHubClient) UpdateHosts(arg0 context.Context, arg1 *idl.UpdateHostsRequest, arg2 ...grpc.CallOption) (*idl.UpdateHostsReply, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateHosts", varargs...)
+	ret0, _ := ret[0].(*idl.UpdateHostsReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateHosts indicates an expected call of UpdateHosts.
+func (mr *MockHubClientMockRecorder) UpdateHosts(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateHosts", reflect.TypeOf((*MockHubClient)(nil).UpdateHosts), varargs...)
+}
+
 // MockHubServer is a mock of HubServer interface.
 type MockHubServer struct {
 	ctrl     *gomock.Controller
@@ -239,6 +539,20 @@ func (m *MockHubServer) EXPECT() *MockHubServerMockRecorder {
 	return m.recorder
 }
 
+// ActivateStandby mocks base method.
+func (m *MockHubServer) ActivateStandby(arg0 *idl.ActivateStandbyRequest, arg1 idl.Hub_ActivateStandbyServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActivateStandby", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ActivateStandby indicates an expected call of ActivateStandby.
+func (mr *MockHubServerMockRecorder) ActivateStandby(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActivateStandby", reflect.TypeOf((*MockHubServer)(nil).ActivateStandby), arg0, arg1)
+}
+
 // AddMirrors mocks base method.
 func (m *MockHubServer) AddMirrors(arg0 *idl.AddMirrorsRequest, arg1 idl.Hub_AddMirrorsServer) error {
 	m.ctrl.T.Helper()
@@ -253,6 +567,49 @@ func (mr *MockHubServerMockRecorder) AddMirrors(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMirrors", reflect.TypeOf((*MockHubServer)(nil).AddMirrors), arg0, arg1)
 }
 
+// AddStandby mocks base method.
+func (m *MockHubServer) AddStandby(arg0 *idl.AddStandbyRequest, arg1 idl.Hub_AddStandbyServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddStandby", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddStandby indicates an expected call of AddStandby.
+func (mr *MockHubServerMockRecorder) AddStandby(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddStandby", reflect.TypeOf((*MockHubServer)(nil).AddStandby), arg0, arg1)
+}
+
+// AttachOperation mocks base method.
+func (m *MockHubServer) AttachOperation(arg0 *idl.AttachOperationRequest, arg1 idl.Hub_AttachOperationServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AttachOperation", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AttachOperation indicates an expected call of AttachOperation.
+func (mr *MockHubServerMockRecorder) AttachOperation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AttachOperation", reflect.TypeOf((*MockHubServer)(nil).AttachOperation), arg0, arg1)
+}
+
+// CancelOperation mocks base method.
+func (m *MockHubServer) CancelOperation(arg0 context.Context, arg1 *idl.CancelOperationRequest) (*idl.CancelOperationReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelOperation", arg0, arg1)
+	ret0, _ := ret[0].(*idl.CancelOperationReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CancelOperation indicates an expected call of CancelOperation.
+func (mr *MockHubServerMockRecorder) CancelOperation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelOperation", reflect.TypeOf((*MockHubServer)(nil).CancelOperation), arg0, arg1)
+}
+
 // CleanInitCluster mocks base method.
 func (m *MockHubServer) CleanInitCluster(arg0 context.Context, arg1 *idl.CleanInitClusterRequest) (*idl.CleanInitClusterReply, error) {
 	m.ctrl.T.Helper()
@@ -268,6 +625,50 @@ func (mr *MockHubServerMockRecorder) CleanInitCluster(arg0, arg1 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CleanInitCluster", reflect.TypeOf((*MockHubServer)(nil).CleanInitCluster), arg0, arg1)
 }
 
+// ClusterStatus mocks base method.
+func (m *MockHubServer) ClusterStatus(arg0 context.Context, arg1 *idl.ClusterStatusRequest) (*idl.ClusterStatusReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClusterStatus", arg0, arg1)
+	ret0, _ := ret[0].(*idl.ClusterStatusReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClusterStatus indicates an expected call of ClusterStatus.
+func (mr *MockHubServerMockRecorder) ClusterStatus(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClusterStatus", reflect.TypeOf((*MockHubServer)(nil).ClusterStatus), arg0, arg1)
+}
+
+// CollectFile mocks base method.
+func (m *MockHubServer) CollectFile(arg0 context.Context, arg1 *idl.CollectFileRequest) (*idl.CollectFileReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CollectFile", arg0, arg1)
+	ret0, _ := ret[0].(*idl.CollectFileReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CollectFile indicates an expected call of CollectFile.
+func (mr *MockHubServerMockRecorder) CollectFile(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CollectFile", reflect.TypeOf((*MockHubServer)(nil).CollectFile), arg0, arg1)
+}
+
+// ExpandCluster mocks base method.
+func (m *MockHubServer) ExpandCluster(arg0 *idl.ExpandClusterRequest, arg1 idl.Hub_ExpandClusterServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExpandCluster", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExpandCluster indicates an expected call of ExpandCluster.
+func (mr *MockHubServerMockRecorder) ExpandCluster(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExpandCluster", reflect.TypeOf((*MockHubServer)(nil).ExpandCluster), arg0, arg1)
+}
+
 // GetAllHostNames mocks base method.
 func (m *MockHubServer) GetAllHostNames(arg0 context.Context, arg1 *idl.GetAllHostNamesRequest) (*idl.GetAllHostNamesReply, error) {
 	m.ctrl.T.Helper()
@@ -283,6 +684,21 @@ func (mr *MockHubServerMockRecorder) GetAllHostNames(arg0, arg1 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllHostNames", reflect.TypeOf((*MockHubServer)(nil).GetAllHostNames), arg0, arg1)
 }
 
+// ListOperations mocks base method.
+func (m *MockHubServer) ListOperations(arg0 context.Context, arg1 *idl.ListOperationsRequest) (*idl.ListOperationsReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOperations", arg0, arg1)
+	ret0, _ := ret[0].(*idl.ListOperationsReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOperations indicates an expected call of ListOperations.
+func (mr *MockHubServerMockRecorder) ListOperations(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOperations", reflect.TypeOf((*MockHubServer)(nil).ListOperations), arg0, arg1)
+}
+
 // MakeCluster mocks base method.
 func (m *MockHubServer) MakeCluster(arg0 *idl.MakeClusterRequest, arg1 idl.Hub_MakeClusterServer) error {
 	m.ctrl.T.Helper()
@@ -297,6 +713,64 @@ func (mr *MockHubServerMockRecorder) MakeCluster(arg0, arg1 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MakeCluster", reflect.TypeOf((*MockHubServer)(nil).MakeCluster), arg0, arg1)
 }
 
+// PushFile mocks base method.
+func (m *MockHubServer) PushFile(arg0 context.Context, arg1 *idl.PushFileRequest) (*idl.PushFileReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PushFile", arg0, arg1)
+	ret0, _ := ret[0].(*idl.PushFileReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PushFile indicates an expected call of PushFile.
+func (mr *MockHubServerMockRecorder) PushFile(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PushFile", reflect.TypeOf((*MockHubServer)(nil).PushFile), arg0, arg1)
+}
+
+// RecoverSegments mocks base method.
+func (m *MockHubServer) RecoverSegments(arg0 *idl.RecoverSegmentsRequest, arg1 idl.Hub_RecoverSegmentsServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecoverSegments", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecoverSegments indicates an expected call of RecoverSegments.
+func (mr *MockHubServerMockRecorder) RecoverSegments(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecoverSegments", reflect.TypeOf((*MockHubServer)(nil).RecoverSegments), arg0, arg1)
+}
+
+// ReloadCredentials mocks base method.
+func (m *MockHubServer) ReloadCredentials(arg0 context.Context, arg1 *idl.ReloadCredentialsRequest) (*idl.ReloadCredentialsReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReloadCredentials", arg0, arg1)
+	ret0, _ := ret[0].(*idl.ReloadCredentialsReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReloadCredentials indicates an expected call of ReloadCredentials.
+func (mr *MockHubServerMockRecorder) ReloadCredentials(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReloadCredentials", reflect.TypeOf((*MockHubServer)(nil).ReloadCredentials), arg0, arg1)
+}
+
+// RemoveStandby mocks base method.
+func (m *MockHubServer) RemoveStandby(arg0 *idl.RemoveStandbyRequest, arg1 idl.Hub_RemoveStandbyServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveStandby", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveStandby indicates an expected call of RemoveStandby.
+func (mr *MockHubServerMockRecorder) RemoveStandby(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveStandby", reflect.TypeOf((*MockHubServer)(nil).RemoveStandby), arg0, arg1)
+}
+
 // ReportAgentHealth mocks base method.
 func (m *MockHubServer) ReportAgentHealth(arg0 context.Context, arg1 *idl.ReportAgentHealthRequest) (*idl.ReportAgentHealthResponse, error) {
 	m.ctrl.T.Helper()
@@ -327,6 +801,20 @@ func (mr *MockHubServerMockRecorder) StartAgents(arg0, arg1 interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartAgents", reflect.TypeOf((*MockHubServer)(nil).StartAgents), arg0, arg1)
 }
 
+// StartCluster mocks base method.
+func (m *MockHubServer) StartCluster(arg0 *idl.StartClusterRequest, arg1 idl.Hub_StartClusterServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartCluster", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartCluster indicates an expected call of StartCluster.
+func (mr *MockHubServerMockRecorder) StartCluster(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartCluster", reflect.TypeOf((*MockHubServer)(nil).StartCluster), arg0, arg1)
+}
+
 // StatusAgents mocks base method.
 func (m *MockHubServer) StatusAgents(arg0 context.Context, arg1 *idl.StatusAgentsRequest) (*idl.StatusAgentsReply, error) {
 	m.ctrl.T.Helper()
@@ -371,3 +859,32 @@ func (mr *MockHubServerMockRecorder) StopAgents(arg0, arg1 interface{}) *gomock.
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopAgents", reflect.TypeOf((*MockHubServer)(nil).StopAgents), arg0, arg1)
 }
+
+// StopCluster mocks base method.
+func (m *MockHubServer) StopCluster(arg0 *idl.StopClusterRequest, arg1 idl.Hub_StopClusterServer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopCluster", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StopCluster indicates an expected call of StopCluster.
+func (mr *MockHubServerMockRecorder) StopCluster(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopCluster", reflect.TypeOf((*MockHubServer)(nil).StopCluster), arg0, arg1)
+}
+
+// UpdateHosts mocks base method.
+func (m *MockHubServer) UpdateHosts(arg0 context.Context, arg1 *idl.UpdateHostsRequest) (*idl.UpdateHostsReply, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateHosts", arg0, arg1)
+	ret0, _ := ret[0].(*idl.UpdateHostsReply)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateHosts indicates an expected call of UpdateHosts.
+func (mr *MockHubServerMockRecorder) UpdateHosts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateHosts", reflect.TypeOf((*MockHubServer)(nil).UpdateHosts), arg0, arg1)
+}
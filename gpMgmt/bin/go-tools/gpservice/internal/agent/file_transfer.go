@@ -0,0 +1,129 @@
+package agent
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"github.com/greenplum-db/gpdb/gpservice/idl"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+)
+
+/*
+PutFile receives a file from the hub and writes it to the path in the header,
+which is the first message of the stream. Each chunk is verified against its
+checksum, and the file is only moved into place once it has been received in
+full, so a failed transfer leaves the destination untouched.
+*/
+func (s *Server) PutFile(stream idl.Agent_PutFileServer) error {
+	req, err := stream.Recv()
+	if err != nil {
+		return utils.LogAndReturnError(fmt.Errorf("could not receive the file header: %w", err))
+	}
+
+	header := req.GetHeader()
+	if header == nil {
+		return utils.LogAndReturnError(errors.New("expected the file header as the first message"))
+	}
+
+	if !utils.IsPathAllowed(header.Path, s.PutAllowedFiles, nil) || utils.IsPrivateKeyPath(header.Path) {
+		return utils.LogAndReturnError(fmt.Errorf("writing to %s is not allowed", header.Path))
+	}
+
+	writer, err := utils.NewAtomicFileWriter(header.Path, os.FileMode(header.Mode).Perm())
+	if err != nil {
+		return utils.LogAndReturnError(err)
+	}
+
+	var size int64
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			writer.Abort()
+			return utils.LogAndReturnError(fmt.Errorf("could not receive %s: %w", header.Path, err))
+		}
+
+		chunk := req.GetChunk()
+		if chunk == nil {
+			writer.Abort()
+			return utils.LogAndReturnError(fmt.Errorf("expected a chunk of %s", header.Path))
+		}
+
+		if utils.ChunkChecksum(chunk.Data) != chunk.Checksum {
+			writer.Abort()
+			return utils.LogAndReturnError(fmt.Errorf("checksum mismatch for the chunk at offset %d of %s", size, header.Path))
+		}
+
+		_, err = writer.Write(chunk.Data)
+		if err != nil {
+			writer.Abort()
+			return utils.LogAndReturnError(fmt.Errorf("could not write %s: %w", header.Path, err))
+		}
+		size += int64(len(chunk.Data))
+	}
+
+	if size != header.Size {
+		writer.Abort()
+		return utils.LogAndReturnError(fmt.Errorf("received %d bytes of %s, expected %d", size, header.Path, header.Size))
+	}
+
+	err = writer.Commit()
+	if err != nil {
+		return utils.LogAndReturnError(err)
+	}
+
+	gplog.Debug("Received file %s", header.Path)
+	return stream.SendAndClose(&idl.PutFileReply{})
+}
+
+// GetFile sends the file at the requested path to the hub, starting with its header
+func (s *Server) GetFile(req *idl.GetFileRequest, stream idl.Agent_GetFileServer) error {
+	if !utils.IsPathAllowed(req.Path, s.GetAllowedFiles, s.GetAllowedDirs) || utils.IsPrivateKeyPath(req.Path) {
+		return utils.LogAndReturnError(fmt.Errorf("reading %s is not allowed", req.Path))
+	}
+
+	file, err := utils.System.Open(req.Path)
+	if err != nil {
+		return utils.LogAndReturnError(fmt.Errorf("could not open %s: %w", req.Path, err))
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return utils.LogAndReturnError(fmt.Errorf("could not stat %s: %w", req.Path, err))
+	}
+
+	if !info.Mode().IsRegular() {
+		return utils.LogAndReturnError(fmt.Errorf("%s is not a regular file", req.Path))
+	}
+
+	header := &idl.FileHeader{Path: req.Path, Mode: uint32(info.Mode().Perm()), Size: info.Size()}
+	err = stream.Send(&idl.GetFileReply{Content: &idl.GetFileReply_Header{Header: header}})
+	if err != nil {
+		return utils.LogAndReturnError(fmt.Errorf("could not send %s: %w", req.Path, err))
+	}
+
+	buffer := make([]byte, utils.FileChunkSize)
+	for {
+		n, err := file.Read(buffer)
+		if n > 0 {
+			chunk := &idl.FileChunk{Data: buffer[:n], Checksum: utils.ChunkChecksum(buffer[:n])}
+			sendErr := stream.Send(&idl.GetFileReply{Content: &idl.GetFileReply_Chunk{Chunk: chunk}})
+			if sendErr != nil {
+				return utils.LogAndReturnError(fmt.Errorf("could not send %s: %w", req.Path, sendErr))
+			}
+		}
+
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return utils.LogAndReturnError(fmt.Errorf("could not read %s: %w", req.Path, err))
+		}
+	}
+}
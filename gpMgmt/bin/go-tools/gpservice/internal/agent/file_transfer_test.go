@@ -0,0 +1,284 @@
+package agent_test
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+	"github.com/greenplum-db/gpdb/gpservice/idl"
+	"github.com/greenplum-db/gpdb/gpservice/idl/mock_idl"
+	"github.com/greenplum-db/gpdb/gpservice/internal/agent"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+)
+
+func TestPutFile(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	dir := t.TempDir()
+	agentServer := agent.New(agent.Config{PutAllowedFiles: []string{
+		filepath.Join(dir, "gpservice.conf"),
+		filepath.Join(dir, "server.crt"),
+		filepath.Join(dir, "partial"),
+		filepath.Join(dir, "server-key.pem"),
+	}})
+
+	t.Run("writes the file with the given mode", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		path := filepath.Join(dir, "gpservice.conf")
+
+		stream := mock_idl.NewMockAgent_PutFileServer(ctrl)
+		expectPutFileRequests(stream, putFileHeader(path, 0640, 11), putFileChunk("hello "), putFileChunk("world"))
+		stream.EXPECT().SendAndClose(&idl.PutFileReply{}).Return(nil)
+
+		err := agentServer.PutFile(stream)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		assertFile(t, path, "hello world", 0640)
+	})
+
+	t.Run("leaves the destination untouched when a chunk is corrupted", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		path := filepath.Join(dir, "server.crt")
+		writeFile(t, path, "old", 0600)
+
+		corrupted := putFileChunk("new")
+		corrupted.GetChunk().Checksum++
+
+		stream := mock_idl.NewMockAgent_PutFileServer(ctrl)
+		expectPutFileRequests(stream, putFileHeader(path, 0600, 3), corrupted)
+
+		err := agentServer.PutFile(stream)
+		expected := "checksum mismatch for the chunk at offset 0 of " + path
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+
+		assertFile(t, path, "old", 0600)
+		assertNoTemporaryFiles(t, dir)
+	})
+
+	t.Run("errors out when the file is not received in full", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		path := filepath.Join(dir, "partial")
+
+		stream := mock_idl.NewMockAgent_PutFileServer(ctrl)
+		expectPutFileRequests(stream, putFileHeader(path, 0600, 10), putFileChunk("short"))
+
+		err := agentServer.PutFile(stream)
+		expected := "received 5 bytes of " + path + ", expected 10"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+
+		if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+			t.Fatalf("expected %s to not exist, got %v", path, err)
+		}
+		assertNoTemporaryFiles(t, dir)
+	})
+
+	t.Run("errors out when the path is not allowed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		stream := mock_idl.NewMockAgent_PutFileServer(ctrl)
+		expectPutFileRequests(stream, putFileHeader("/etc/passwd", 0644, 0))
+
+		err := agentServer.PutFile(stream)
+		expected := "writing to /etc/passwd is not allowed"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+
+	t.Run("errors out when the path is within the directory of an allowed file", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		path := filepath.Join(dir, "gpservice.pid")
+
+		stream := mock_idl.NewMockAgent_PutFileServer(ctrl)
+		expectPutFileRequests(stream, putFileHeader(path, 0644, 0))
+
+		err := agentServer.PutFile(stream)
+		expected := "writing to " + path + " is not allowed"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+
+	t.Run("errors out when the path is a private key", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		path := filepath.Join(dir, "server-key.pem")
+
+		stream := mock_idl.NewMockAgent_PutFileServer(ctrl)
+		expectPutFileRequests(stream, putFileHeader(path, 0600, 0))
+
+		err := agentServer.PutFile(stream)
+		expected := "writing to " + path + " is not allowed"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+
+	t.Run("errors out when the header is not the first message", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+
+		stream := mock_idl.NewMockAgent_PutFileServer(ctrl)
+		expectPutFileRequests(stream, putFileChunk("data"))
+
+		err := agentServer.PutFile(stream)
+		expected := "expected the file header as the first message"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+}
+
+func TestGetFile(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	dir := t.TempDir()
+	agentServer := agent.New(agent.Config{GetAllowedDirs: []string{dir}})
+
+	t.Run("sends the header followed by the chunks of the file", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		path := filepath.Join(dir, "gpservice.log")
+		contents := strings.Repeat("x", utils.FileChunkSize+10)
+		writeFile(t, path, contents, 0640)
+
+		var replies []*idl.GetFileReply
+		stream := mock_idl.NewMockAgent_GetFileServer(ctrl)
+		stream.EXPECT().Send(gomock.Any()).DoAndReturn(func(reply *idl.GetFileReply) error {
+			replies = append(replies, reply)
+			return nil
+		}).Times(3)
+
+		err := agentServer.GetFile(&idl.GetFileRequest{Path: path}, stream)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		header := replies[0].GetHeader()
+		if header.Path != path || header.Mode != 0640 || header.Size != int64(len(contents)) {
+			t.Fatalf("got header %+v", header)
+		}
+
+		var received string
+		for _, reply := range replies[1:] {
+			chunk := reply.GetChunk()
+			if utils.ChunkChecksum(chunk.Data) != chunk.Checksum {
+				t.Fatalf("got invalid checksum %d for the chunk", chunk.Checksum)
+			}
+			received += string(chunk.Data)
+		}
+
+		if received != contents {
+			t.Fatalf("got %d bytes, want %d", len(received), len(contents))
+		}
+	})
+
+	t.Run("errors out when the path is not allowed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		stream := mock_idl.NewMockAgent_GetFileServer(ctrl)
+
+		err := agentServer.GetFile(&idl.GetFileRequest{Path: filepath.Join(dir, "..", "secret")}, stream)
+		expected := "reading " + filepath.Join(dir, "..", "secret") + " is not allowed"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+
+	t.Run("errors out when the path is a private key", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		stream := mock_idl.NewMockAgent_GetFileServer(ctrl)
+
+		path := filepath.Join(dir, "server-key.pem")
+		writeFile(t, path, "key", 0600)
+
+		err := agentServer.GetFile(&idl.GetFileRequest{Path: path}, stream)
+		expected := "reading " + path + " is not allowed"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+
+	t.Run("errors out when the path is not a regular file", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		stream := mock_idl.NewMockAgent_GetFileServer(ctrl)
+
+		subdir := filepath.Join(dir, "subdir")
+		err := os.Mkdir(subdir, 0700)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		err = agentServer.GetFile(&idl.GetFileRequest{Path: subdir}, stream)
+		expected := subdir + " is not a regular file"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+}
+
+func expectPutFileRequests(stream *mock_idl.MockAgent_PutFileServer, requests ...*idl.PutFileRequest) {
+	var calls []*gomock.Call
+	for _, req := range requests {
+		calls = append(calls, stream.EXPECT().Recv().Return(req, nil))
+	}
+	calls = append(calls, stream.EXPECT().Recv().Return(nil, io.EOF).MaxTimes(1))
+	gomock.InOrder(calls...)
+}
+
+func putFileHeader(path string, mode uint32, size int64) *idl.PutFileRequest {
+	return &idl.PutFileRequest{Content: &idl.PutFileRequest_Header{Header: &idl.FileHeader{Path: path, Mode: mode, Size: size}}}
+}
+
+func putFileChunk(data string) *idl.PutFileRequest {
+	chunk := &idl.FileChunk{Data: []byte(data), Checksum: utils.ChunkChecksum([]byte(data))}
+	return &idl.PutFileRequest{Content: &idl.PutFileRequest_Chunk{Chunk: chunk}}
+}
+
+func writeFile(t *testing.T, path, contents string, mode os.FileMode) {
+	t.Helper()
+
+	err := os.WriteFile(path, []byte(contents), mode)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func assertFile(t *testing.T, path, contents string, mode os.FileMode) {
+	t.Helper()
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if info.Mode().Perm() != mode {
+		t.Fatalf("got mode %o, want %o", info.Mode().Perm(), mode)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(data) != contents {
+		t.Fatalf("got %q, want %q", data, contents)
+	}
+}
+
+func assertNoTemporaryFiles(t *testing.T, dir string) {
+	t.Helper()
+
+	matches, err := filepath.Glob(filepath.Join(dir, ".*.tmp"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(matches) != 0 {
+		t.Fatalf("expected no temporary files, got %v", matches)
+	}
+}
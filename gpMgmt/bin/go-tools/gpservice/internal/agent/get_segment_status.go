@@ -0,0 +1,22 @@
+package agent
+
+import (
+	"context"
+
+	"github.com/greenplum-db/gpdb/gpservice/idl"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/greenplum"
+)
+
+/*
+GetSegmentStatus implements agent RPC to get the process status of the segments.
+Input: list of data-directories.
+Makes a call to pg_ctl status and pg_controldata for every data directory
+*/
+func (s *Server) GetSegmentStatus(ctx context.Context, in *idl.GetSegmentStatusRequest) (*idl.GetSegmentStatusReply, error) {
+	var statuses []*idl.SegmentProcessStatus
+	for _, dataDir := range in.DataDirs {
+		statuses = append(statuses, greenplum.GetSegmentProcessStatus(s.GpHome, dataDir))
+	}
+
+	return &idl.GetSegmentStatusReply{Statuses: statuses}, nil
+}
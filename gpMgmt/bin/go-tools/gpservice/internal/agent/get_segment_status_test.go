@@ -0,0 +1,56 @@
+package agent_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+	"github.com/greenplum-db/gpdb/gpservice/idl"
+	"github.com/greenplum-db/gpdb/gpservice/internal/agent"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+	"github.com/greenplum-db/gpdb/gpservice/testutils/exectest"
+)
+
+func TestGetSegmentStatus(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	agentServer := agent.New(agent.Config{
+		GpHome: "gpHome",
+	})
+
+	t.Run("reports the segments as not running when pg_ctl status fails", func(t *testing.T) {
+		var calls []string
+		utils.System.ExecCommand = exectest.NewCommandWithVerifier(exectest.Failure, func(utility string, args ...string) {
+			calls = append(calls, utility)
+		})
+		defer utils.ResetSystemFunctions()
+
+		reply, err := agentServer.GetSegmentStatus(context.Background(), &idl.GetSegmentStatusRequest{
+			DataDirs: []string{"/gpseg0", "/gpseg1"},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		if len(reply.Statuses) != 2 {
+			t.Fatalf("got %d statuses, want 2", len(reply.Statuses))
+		}
+
+		for i, dataDir := range []string{"/gpseg0", "/gpseg1"} {
+			status := reply.Statuses[i]
+			if status.DataDir != dataDir || status.Running || status.Error == "" {
+				t.Fatalf("got %+v, want a stopped segment with data directory %s and an error", status, dataDir)
+			}
+		}
+
+		expectedCalls := []string{"gpHome/bin/pg_ctl", "gpHome/bin/pg_controldata", "gpHome/bin/pg_ctl", "gpHome/bin/pg_controldata"}
+		if len(calls) != len(expectedCalls) {
+			t.Fatalf("got %+v, want %+v", calls, expectedCalls)
+		}
+		for i := range calls {
+			if calls[i] != expectedCalls[i] {
+				t.Fatalf("got %+v, want %+v", calls, expectedCalls)
+			}
+		}
+	})
+}
@@ -14,29 +14,43 @@ import (
 )
 
 // MakeSegment is an RPC which creates a new segment instance with the specified
-// configuration from the MakeSegmentRequest. It calls initdb and updates the
-// necessary configuration files.
+// configuration from the MakeSegmentRequest. It calls initdb, or copies the data
+// directory of the template segment when one is given, and updates the necessary
+// configuration files.
 func (s *Server) MakeSegment(ctx context.Context, request *idl.MakeSegmentRequest) (*idl.MakeSegmentReply, error) {
 	dataDirectory := request.Segment.DataDirectory
 	locale := request.Locale
 
 	gplog.Debug("Creating segment with data directory %q", dataDirectory)
 
-	initdbOptions := postgres.Initdb{
-		PgData:        dataDirectory,
-		Encoding:      request.Encoding,
-		Locale:        locale.LcAll,
-		LcCollate:     locale.LcCollate,
-		LcCtype:       locale.LcCtype,
-		LcMessages:    locale.LcMessages,
-		LcMonetory:    locale.LcMonetory,
-		LcNumeric:     locale.LcNumeric,
-		LcTime:        locale.LcTime,
-		DataChecksums: request.DataChecksums,
-	}
-	out, err := utils.RunGpCommandContext(ctx, &initdbOptions, s.GpHome)
-	if err != nil {
-		return &idl.MakeSegmentReply{}, utils.LogAndReturnError(fmt.Errorf("executing initdb: %s, %w", out, err))
+	if request.Template != nil {
+		// The catalog of the template is copied so that the segment has the objects of the running cluster
+		_, err := s.PgBasebackup(ctx, &idl.PgBasebackupRequest{
+			TargetDir:  dataDirectory,
+			SourceHost: request.Template.HostName,
+			SourcePort: request.Template.Port,
+			TargetDbid: request.Segment.Dbid,
+		})
+		if err != nil {
+			return &idl.MakeSegmentReply{}, utils.LogAndReturnError(fmt.Errorf("copying the template %s:%d: %w", request.Template.HostName, request.Template.Port, err))
+		}
+	} else {
+		initdbOptions := postgres.Initdb{
+			PgData:        dataDirectory,
+			Encoding:      request.Encoding,
+			Locale:        locale.LcAll,
+			LcCollate:     locale.LcCollate,
+			LcCtype:       locale.LcCtype,
+			LcMessages:    locale.LcMessages,
+			LcMonetory:    locale.LcMonetory,
+			LcNumeric:     locale.LcNumeric,
+			LcTime:        locale.LcTime,
+			DataChecksums: request.DataChecksums,
+		}
+		out, err := utils.RunGpCommandContext(ctx, &initdbOptions, s.GpHome)
+		if err != nil {
+			return &idl.MakeSegmentReply{}, utils.LogAndReturnError(fmt.Errorf("executing initdb: %s, %w", out, err))
+		}
 	}
 
 	configParams := make(map[string]string)
@@ -46,16 +60,21 @@ func (s *Server) MakeSegment(ctx context.Context, request *idl.MakeSegmentReques
 	configParams["gp_contentid"] = strconv.Itoa(int(request.Segment.Contentid))
 	if request.Segment.Contentid == -1 {
 		configParams["log_statement"] = "all"
+	} else if request.Template != nil {
+		configParams["log_statement"] = "none"
 	}
 
-	err = postgres.UpdatePostgresqlConf(dataDirectory, configParams, false)
+	err := postgres.UpdatePostgresqlConf(dataDirectory, configParams, false)
 	if err != nil {
 		return &idl.MakeSegmentReply{}, utils.LogAndReturnError(fmt.Errorf("updating postgresql.conf: %w", err))
 	}
 
-	err = postgres.UpdatePostgresInternalConf(dataDirectory, int(request.Segment.Dbid))
-	if err != nil {
-		return &idl.MakeSegmentReply{}, utils.LogAndReturnError(fmt.Errorf("creating internal.auto.conf: %w", err))
+	// pg_basebackup already writes the dbid of the copy of a template
+	if request.Template == nil {
+		err = postgres.UpdatePostgresInternalConf(dataDirectory, int(request.Segment.Dbid))
+		if err != nil {
+			return &idl.MakeSegmentReply{}, utils.LogAndReturnError(fmt.Errorf("creating internal.auto.conf: %w", err))
+		}
 	}
 
 	var addrs []string
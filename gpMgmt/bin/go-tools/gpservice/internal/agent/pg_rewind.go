@@ -0,0 +1,35 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/greenplum-db/gpdb/gpservice/constants"
+	"github.com/greenplum-db/gpdb/gpservice/idl"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/postgres"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+)
+
+// PgRewind is an agent RPC implementation that executes the pg_rewind command to
+// incrementally resynchronize a segment data directory with its source segment.
+// It redirects its output to a file, which is cleaned up if the command executes successfully.
+func (s *Server) PgRewind(ctx context.Context, req *idl.PgRewindRequest) (*idl.PgRewindResponse, error) {
+	pgRewindCmd := &postgres.PgRewind{
+		TargetDir:         req.TargetDir,
+		SourceServer:      fmt.Sprintf("host=%s port=%d dbname=%s", req.SourceHost, req.SourcePort, constants.DefaultDatabase),
+		WriteRecoveryConf: true,
+		Progress:          true,
+	}
+
+	pgRewindLog := filepath.Join(s.LogDir, fmt.Sprintf("pg_rewind.%s.dbid%d.out", time.Now().Format("20060102_150405"), req.TargetDbid))
+	out, err := utils.RunGpCommandAndRedirectOutput(ctx, pgRewindCmd, s.GpHome, pgRewindLog)
+	if err != nil {
+		return &idl.PgRewindResponse{}, fmt.Errorf("executing pg_rewind: %s, logfile: %s, %w", out, pgRewindLog, err)
+	}
+	os.Remove(pgRewindLog)
+
+	return &idl.PgRewindResponse{}, nil
+}
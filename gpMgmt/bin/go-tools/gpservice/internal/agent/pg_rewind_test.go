@@ -0,0 +1,77 @@
+package agent_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+	"github.com/greenplum-db/gpdb/gpservice/idl"
+	"github.com/greenplum-db/gpdb/gpservice/internal/agent"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+	"github.com/greenplum-db/gpdb/gpservice/testutils/exectest"
+)
+
+func TestPgRewind(t *testing.T) {
+	testhelper.SetupTestLogger()
+	tempDir, _ := os.MkdirTemp("", "")
+	defer os.RemoveAll(tempDir)
+
+	agentServer := agent.New(agent.Config{
+		GpHome: "gpHome",
+		LogDir: tempDir,
+	})
+
+	request := &idl.PgRewindRequest{
+		TargetDir:  "/mirror/gpseg0",
+		SourceHost: "sdw1",
+		SourcePort: 1234,
+		TargetDbid: 3,
+	}
+
+	t.Run("succesfully runs pg_rewind", func(t *testing.T) {
+		var pgRewindCalled bool
+		utils.System.ExecCommandContext = exectest.NewCommandContextWithVerifier(exectest.Success, func(utility string, args ...string) {
+			pgRewindCalled = true
+			expectedUtility := "gpHome/bin/pg_rewind"
+			if utility != expectedUtility {
+				t.Fatalf("got %s, want %s", utility, expectedUtility)
+			}
+
+			expectedArgs := []string{"--target-pgdata", "/mirror/gpseg0", "--source-server", "host=sdw1 port=1234 dbname=template1", "--write-recovery-conf", "--progress"}
+			if !reflect.DeepEqual(args, expectedArgs) {
+				t.Fatalf("got %+v, want %+v", args, expectedArgs)
+			}
+		})
+		defer utils.ResetSystemFunctions()
+
+		_, err := agentServer.PgRewind(context.Background(), request)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !pgRewindCalled {
+			t.Fatalf("expected pg_rewind to be called")
+		}
+	})
+
+	t.Run("errors out when fails to execute pg_rewind", func(t *testing.T) {
+		utils.System.ExecCommandContext = exectest.NewCommandContext(exectest.Failure)
+		defer utils.ResetSystemFunctions()
+
+		_, err := agentServer.PgRewind(context.Background(), request)
+		var expectedErr *exec.ExitError
+		if !errors.As(err, &expectedErr) {
+			t.Errorf("got %T, want %T", err, expectedErr)
+		}
+
+		expectedErrPrefix := "executing pg_rewind:"
+		if !strings.HasPrefix(err.Error(), expectedErrPrefix) {
+			t.Fatalf("got %v, want %s", err, expectedErrPrefix)
+		}
+	})
+}
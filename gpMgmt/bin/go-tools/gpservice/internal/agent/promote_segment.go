@@ -0,0 +1,29 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/greenplum-db/gpdb/gpservice/idl"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/postgres"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+)
+
+/*
+PromoteSegment implements agent RPC to promote a standby or mirror segment.
+Input: data-directory, wait and timeout.
+Makes a call to pg_ctl promote command
+*/
+func (s *Server) PromoteSegment(ctx context.Context, in *idl.PromoteSegmentRequest) (*idl.PromoteSegmentReply, error) {
+	pgCtlPromoteOptions := postgres.PgCtlPromote{
+		PgData:  in.DataDir,
+		Wait:    in.Wait,
+		Timeout: int(in.Timeout),
+	}
+	out, err := utils.RunGpCommandContext(ctx, &pgCtlPromoteOptions, s.GpHome)
+	if err != nil {
+		return &idl.PromoteSegmentReply{}, utils.LogAndReturnError(fmt.Errorf("executing pg_ctl promote: %s, %w", out, err))
+	}
+
+	return &idl.PromoteSegmentReply{}, nil
+}
@@ -0,0 +1,65 @@
+package agent_test
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+	"github.com/greenplum-db/gpdb/gpservice/idl"
+	"github.com/greenplum-db/gpdb/gpservice/internal/agent"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+	"github.com/greenplum-db/gpdb/gpservice/testutils/exectest"
+)
+
+func TestPromoteSegment(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	agentServer := agent.New(agent.Config{
+		GpHome: "gpHome",
+	})
+
+	request := &idl.PromoteSegmentRequest{
+		DataDir: "gpseg",
+		Wait:    true,
+		Timeout: 60,
+	}
+
+	t.Run("successfully promotes the segment", func(t *testing.T) {
+		var pgCtlCalled bool
+		utils.System.ExecCommandContext = exectest.NewCommandContextWithVerifier(exectest.Success, func(utility string, args ...string) {
+			pgCtlCalled = true
+			expectedUtility := "gpHome/bin/pg_ctl"
+			if utility != expectedUtility {
+				t.Fatalf("got %s, want %s", utility, expectedUtility)
+			}
+
+			expectedArgs := []string{"promote", "--pgdata", "gpseg", "--timeout", "60", "--wait"}
+			if !reflect.DeepEqual(args, expectedArgs) {
+				t.Fatalf("got %+v, want %+v", args, expectedArgs)
+			}
+		})
+		defer utils.ResetSystemFunctions()
+
+		_, err := agentServer.PromoteSegment(context.Background(), request)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		if !pgCtlCalled {
+			t.Fatalf("expected pg_ctl to be called")
+		}
+	})
+
+	t.Run("returns appropriate error when it fails", func(t *testing.T) {
+		utils.System.ExecCommandContext = exectest.NewCommandContext(exectest.Failure)
+		defer utils.ResetSystemFunctions()
+
+		expectedErrPrefix := "executing pg_ctl promote:"
+		_, err := agentServer.PromoteSegment(context.Background(), request)
+		if !strings.HasPrefix(err.Error(), expectedErrPrefix) {
+			t.Fatalf("got %v, want %v", err, expectedErrPrefix)
+		}
+	})
+}
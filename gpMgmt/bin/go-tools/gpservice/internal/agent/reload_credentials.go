@@ -0,0 +1,30 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"github.com/greenplum-db/gpdb/gpservice/idl"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+)
+
+// ReloadCredentials loads the TLS certificate and key files again, so that rotated
+// certificates are used for new connections without restarting the agent
+func (s *Server) ReloadCredentials(ctx context.Context, req *idl.ReloadAgentCredentialsRequest) (*idl.ReloadAgentCredentialsReply, error) {
+	s.mutex.Lock()
+	credentials := s.credentials
+	s.mutex.Unlock()
+
+	if credentials == nil {
+		return &idl.ReloadAgentCredentialsReply{}, utils.LogAndReturnError(fmt.Errorf("agent server is not started"))
+	}
+
+	err := credentials.Reload()
+	if err != nil {
+		return &idl.ReloadAgentCredentialsReply{}, utils.LogAndReturnError(fmt.Errorf("could not reload credentials: %w", err))
+	}
+
+	gplog.Info("Reloaded the TLS credentials")
+	return &idl.ReloadAgentCredentialsReply{}, nil
+}
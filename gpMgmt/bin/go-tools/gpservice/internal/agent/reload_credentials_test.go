@@ -0,0 +1,24 @@
+package agent_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+	"github.com/greenplum-db/gpdb/gpservice/idl"
+	"github.com/greenplum-db/gpdb/gpservice/internal/agent"
+)
+
+func TestReloadCredentials(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	t.Run("errors out when the server is not started", func(t *testing.T) {
+		agentServer := agent.New(agent.Config{})
+
+		_, err := agentServer.ReloadCredentials(context.Background(), &idl.ReloadAgentCredentialsRequest{})
+		expected := "agent server is not started"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+}
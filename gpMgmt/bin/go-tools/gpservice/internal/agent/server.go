@@ -2,15 +2,18 @@ package agent
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"net"
 	"sync"
 
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc/health"
 	healthgrpc "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 
 	"github.com/greenplum-db/gpdb/gpservice/idl"
+	"github.com/greenplum-db/gpdb/gpservice/internal/metrics"
 	. "github.com/greenplum-db/gpdb/gpservice/internal/platform"
 	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
 	"google.golang.org/grpc"
@@ -26,19 +29,39 @@ type Config struct {
 	GpHome      string
 	LogDir      string
 	Credentials utils.Credentials
+
+	// Files can only be put if they are one of PutAllowedFiles, and fetched if they are one of
+	// GetAllowedFiles or are within one of GetAllowedDirs
+	PutAllowedFiles []string
+	GetAllowedFiles []string
+	GetAllowedDirs  []string
+
+	// Auth configures which callers are allowed to call the agent, of which the hosts in
+	// ServiceIdentities are always allowed as the hub calls the agent using their certificates
+	Auth              *utils.AuthConfig
+	ServiceIdentities []string
+
+	// MetricsPort is the port on which the metrics of the agent are served, if not 0, on
+	// MetricsBindAddress and over HTTPS when MetricsTLS is set
+	MetricsPort        int
+	MetricsBindAddress string
+	MetricsTLS         bool
 }
 
 type Server struct {
 	*Config
 
-	mutex      sync.Mutex
-	grpcServer *grpc.Server
-	listener   net.Listener
+	mutex       sync.Mutex
+	grpcServer  *grpc.Server
+	listener    net.Listener
+	credentials *utils.ReloadableCredentials
+	metrics     *metrics.Metrics
 }
 
 func New(conf Config) *Server {
 	return &Server{
-		Config: &conf,
+		Config:  &conf,
+		metrics: metrics.New("agent", conf.LogDir),
 	}
 }
 
@@ -53,20 +76,49 @@ func (s *Server) Start() error {
 		return fmt.Errorf("could not listen on port %d: %w", s.Port, err)
 	}
 
-	interceptor := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
-		// handle stuff here if needed
-		return handler(ctx, req)
+	err = s.Auth.Validate(s.Credentials)
+	if err != nil {
+		listener.Close()
+		return err
 	}
+	authorizer := utils.NewAuthorizer(s.Auth, s.ServiceIdentities)
 
-	credentials, err := s.Credentials.LoadServerCredentials()
+	credentials, err := utils.NewReloadableCredentials(s.Auth.ServerCredentials(s.Credentials))
 	if err != nil {
 		listener.Close()
 		return err
 	}
 
+	auditLog, err := utils.OpenAuditLog(s.LogDir, "agent")
+	if err != nil {
+		listener.Close()
+		return err
+	}
+	defer auditLog.Close()
+
+	if s.MetricsPort != 0 {
+		var tlsConfig *tls.Config
+		if s.MetricsTLS {
+			tlsConfig, err = metrics.TLSConfig(s.Credentials)
+			if err != nil {
+				listener.Close()
+				return err
+			}
+		}
+
+		metricsServer, err := s.metrics.Serve(s.MetricsBindAddress, s.MetricsPort, tlsConfig)
+		if err != nil {
+			listener.Close()
+			return err
+		}
+		defer metricsServer.Close()
+	}
+
 	grpcServer := grpc.NewServer(
 		grpc.Creds(credentials),
-		grpc.UnaryInterceptor(interceptor))
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(s.metrics.UnaryInterceptor(), auditLog.UnaryInterceptor(), authorizer.UnaryInterceptor()),
+		grpc.ChainStreamInterceptor(s.metrics.StreamInterceptor(), auditLog.StreamInterceptor(), authorizer.StreamInterceptor()))
 
 	healthcheck := health.NewServer()
 	healthgrpc.RegisterHealthServer(grpcServer, healthcheck)
@@ -74,11 +126,16 @@ func (s *Server) Start() error {
 	s.mutex.Lock()
 	s.grpcServer = grpcServer
 	s.listener = listener
+	s.credentials = credentials
 	s.mutex.Unlock()
 
 	idl.RegisterAgentServer(grpcServer, s)
 	reflection.Register(grpcServer)
 
+	done := make(chan struct{})
+	defer close(done)
+	go credentials.Watch(utils.CredentialPaths(s.Credentials), done)
+
 	err = grpcServer.Serve(listener)
 	if err != nil {
 		return fmt.Errorf("failed to serve: %w", err)
@@ -25,6 +25,7 @@ func TestStartServer(t *testing.T) {
 			Port:        constants.DefaultAgentPort,
 			ServiceName: constants.DefaultServiceName,
 			Credentials: credentials,
+			LogDir:      t.TempDir(),
 		})
 		errChan := make(chan error, 1)
 
@@ -121,6 +122,7 @@ func TestGetStatus(t *testing.T) {
 			Port:        constants.DefaultAgentPort,
 			ServiceName: constants.DefaultServiceName,
 			Credentials: credentials,
+			LogDir:      t.TempDir(),
 		})
 
 		result, err := agentServer.GetStatus()
@@ -139,6 +141,7 @@ func TestGetStatus(t *testing.T) {
 			Port:        constants.DefaultAgentPort,
 			ServiceName: constants.DefaultServiceName,
 			Credentials: credentials,
+			LogDir:      t.TempDir(),
 		})
 
 		expected := &idl.ServiceStatus{
@@ -0,0 +1,30 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/greenplum-db/gpdb/gpservice/idl"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/postgres"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+)
+
+/*
+StopSegment implements agent RPC to stop the segment.
+Input: data-directory, wait, timeout and shutdown mode.
+Makes a call to pg_ctl stop command
+*/
+func (s *Server) StopSegment(ctx context.Context, in *idl.StopSegmentRequest) (*idl.StopSegmentReply, error) {
+	pgCtlStopOptions := postgres.PgCtlStop{
+		PgData:  in.DataDir,
+		Wait:    in.Wait,
+		Timeout: int(in.Timeout),
+		Mode:    in.Mode,
+	}
+	out, err := utils.RunGpCommandContext(ctx, &pgCtlStopOptions, s.GpHome)
+	if err != nil {
+		return &idl.StopSegmentReply{}, utils.LogAndReturnError(fmt.Errorf("executing pg_ctl stop: %s, %w", out, err))
+	}
+
+	return &idl.StopSegmentReply{}, nil
+}
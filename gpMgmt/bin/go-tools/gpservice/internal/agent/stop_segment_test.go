@@ -0,0 +1,66 @@
+package agent_test
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+	"github.com/greenplum-db/gpdb/gpservice/idl"
+	"github.com/greenplum-db/gpdb/gpservice/internal/agent"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+	"github.com/greenplum-db/gpdb/gpservice/testutils/exectest"
+)
+
+func TestStopSegment(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	agentServer := agent.New(agent.Config{
+		GpHome: "gpHome",
+	})
+
+	request := &idl.StopSegmentRequest{
+		DataDir: "gpseg",
+		Wait:    true,
+		Timeout: 60,
+		Mode:    "fast",
+	}
+
+	t.Run("succesfully stops the segment", func(t *testing.T) {
+		var pgCtlCalled bool
+		utils.System.ExecCommandContext = exectest.NewCommandContextWithVerifier(exectest.Success, func(utility string, args ...string) {
+			pgCtlCalled = true
+			expectedUtility := "gpHome/bin/pg_ctl"
+			if utility != expectedUtility {
+				t.Fatalf("got %s, want %s", utility, expectedUtility)
+			}
+
+			expectedArgs := []string{"stop", "--pgdata", "gpseg", "--timeout", "60", "--wait", "--mode", "fast"}
+			if !reflect.DeepEqual(args, expectedArgs) {
+				t.Fatalf("got %+v, want %+v", args, expectedArgs)
+			}
+		})
+		defer utils.ResetSystemFunctions()
+
+		_, err := agentServer.StopSegment(context.Background(), request)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		if !pgCtlCalled {
+			t.Fatalf("expected pg_ctl to be called")
+		}
+	})
+
+	t.Run("returns appropriate error when it fails", func(t *testing.T) {
+		utils.System.ExecCommandContext = exectest.NewCommandContext(exectest.Failure)
+		defer utils.ResetSystemFunctions()
+
+		expectedErrPrefix := "executing pg_ctl stop:"
+		_, err := agentServer.StopSegment(context.Background(), request)
+		if !strings.HasPrefix(err.Error(), expectedErrPrefix) {
+			t.Fatalf("got %v, want %v", err, expectedErrPrefix)
+		}
+	})
+}
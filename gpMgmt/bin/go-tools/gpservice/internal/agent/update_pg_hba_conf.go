@@ -10,9 +10,15 @@ import (
 )
 
 // UpdatePgHbaConf is agent RPC implementation which updates the segment pg_hba.conf
-// with the given address list and then reloads the segment with pg_ctl reload.
+// with the given address list, or removes the entries of the addresses from it, and
+// then reloads the segment with pg_ctl reload.
 func (s *Server) UpdatePgHbaConfAndReload(ctx context.Context, req *idl.UpdatePgHbaConfRequest) (*idl.UpdatePgHbaConfResponse, error) {
-	err := postgres.UpdateSegmentPgHbaConf(req.Pgdata, req.Addrs, req.Replication)
+	var err error
+	if req.Remove {
+		err = postgres.RemoveSegmentPgHbaConfEntries(req.Pgdata, req.Addrs)
+	} else {
+		err = postgres.UpdateSegmentPgHbaConf(req.Pgdata, req.Addrs, req.Replication)
+	}
 	if err != nil {
 		return &idl.UpdatePgHbaConfResponse{}, fmt.Errorf("updating pg_hba.conf: %w", err)
 	}
@@ -71,8 +71,16 @@ func (s *Server) ValidateHostEnv(ctx context.Context, request *idl.ValidateHostE
 	if len(nonEmptyDirList) > 0 && !forced {
 		return &idl.ValidateHostEnvReply{}, utils.LogAndReturnError(fmt.Errorf("directory not empty:%v", nonEmptyDirList))
 	}
-	if forced && len(nonEmptyDirList) > 0 {
 
+	// Any checks to raise warnings
+	var warnings []*idl.LogMessage
+
+	if forced && len(nonEmptyDirList) > 0 && request.DryRun {
+		warnings = append(warnings, &idl.LogMessage{
+			Message: fmt.Sprintf("directories %v are not empty and will be deleted", nonEmptyDirList),
+			Level:   idl.LogLevel_WARNING,
+		})
+	} else if forced && len(nonEmptyDirList) > 0 {
 		gplog.Debug("Forced init. Deleting non-empty directories:%s", nonEmptyDirList)
 		for _, dir := range nonEmptyDirList {
 			err := utils.System.RemoveAll(dir)
@@ -101,11 +109,8 @@ func (s *Server) ValidateHostEnv(ctx context.Context, request *idl.ValidateHostE
 		return &idl.ValidateHostEnvReply{}, utils.LogAndReturnError(err)
 	}
 
-	// Any checks to raise warnings
-	var warnings []*idl.LogMessage
-
 	// check coordinator open file values
-	warnings = CheckOpenFilesLimit()
+	warnings = append(warnings, CheckOpenFilesLimit()...)
 	addressWarnings := CheckHostAddressInHostsFile(request.HostAddressList)
 	warnings = append(warnings, addressWarnings...)
 	return &idl.ValidateHostEnvReply{Messages: warnings}, nil
@@ -496,6 +496,42 @@ func TestValidateHostEnv(t *testing.T) {
 			t.Fatalf("got %v, expected no error", err)
 		}
 	})
+	t.Run("does not delete the non-empty directories in a dry run when force is true", func(t *testing.T) {
+		defer resetAgentFunctions()
+		agent.VerifyPgVersion = func(expectedVersion string, gpHome string) error {
+			return nil
+		}
+		agent.GetAllNonEmptyDir = func(dirList []string) ([]string, error) {
+			return []string{"/tmp/1", "/tmp/2"}, nil
+		}
+		agent.CheckFilePermissions = func(filePath string) error {
+			return nil
+		}
+		agent.ValidateLocaleSettings = func(locale *idl.Locale) error {
+			return nil
+		}
+		agent.ValidatePorts = func(portList []string) error {
+			return nil
+		}
+		utils.System.RemoveAll = func(path string) error {
+			t.Fatalf("unexpected call to remove %s", path)
+			return nil
+		}
+
+		req := idl.ValidateHostEnvRequest{Forced: true, DryRun: true}
+		server := agent.New(agent.Config{})
+		ctx := context.Background()
+
+		reply, err := server.ValidateHostEnv(ctx, &req)
+		if err != nil {
+			t.Fatalf("got %v, expected no error", err)
+		}
+
+		expected := "directories [/tmp/1 /tmp/2] are not empty and will be deleted"
+		if len(reply.Messages) == 0 || reply.Messages[0].Message != expected || reply.Messages[0].Level != idl.LogLevel_WARNING {
+			t.Fatalf("got %v, want warning %q", reply.Messages, expected)
+		}
+	})
 }
 func TestCheckFileOwnerGroupFn(t *testing.T) {
 	testhelper.SetupTestLogger()
@@ -1,42 +1,92 @@
 package cli
 
 import (
+	"path/filepath"
+
 	"github.com/greenplum-db/gpdb/gpservice/internal/agent"
 	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
 func AgentCmd() *cobra.Command {
+	var supervise, daemon bool
+
 	agentCmd := &cobra.Command{
 		Use:    "agent",
 		Short:  "Start gpservice as an agent process",
 		Long:   "Start gpservice as an agent process",
 		Hidden: true, // Should not be invoked by the user
 		Run: func(cmd *cobra.Command, args []string) {
-			err := runAgentCmd()
+			err := runAgentCmd(supervise, daemon)
 			if err != nil {
-				utils.LogErrorAndExit(err, 1)
+				logErrorAndExit(cmd, err)
 			}
 		},
 	}
 
+	agentCmd.Flags().BoolVar(&supervise, "supervise", false, "Run the agent under the gpservice supervisor, which restarts it when it crashes")
+	agentCmd.Flags().BoolVar(&daemon, "daemon", false, "Run the gpservice supervisor of the agent in the background")
+
 	return agentCmd
 }
 
-func runAgentCmd() error {
+func runAgentCmd(supervise, daemon bool) error {
+	if supervise || daemon {
+		return runSupervisor("agent", daemon)
+	}
+
+	shutdownTracing, err := utils.SetupTracing(serviceConfig.Tracing, "gpservice-agent", serviceConfig.LogDir)
+	if err != nil {
+		return err
+	}
+	defer shutdownTracing()
+
 	agentConf := agent.Config{
 		Port:        serviceConfig.AgentPort,
 		ServiceName: serviceConfig.ServiceName,
 		GpHome:      serviceConfig.GpHome,
 		Credentials: serviceConfig.Credentials,
 		LogDir:      serviceConfig.LogDir,
+
+		PutAllowedFiles: putAllowedFiles(),
+		GetAllowedFiles: []string{absConfigFilepath()},
+		GetAllowedDirs:  []string{serviceConfig.LogDir},
+
+		Auth:              serviceConfig.Auth,
+		ServiceIdentities: serviceConfig.ServiceIdentities(),
+	}
+	if serviceConfig.Metrics != nil {
+		agentConf.MetricsPort = serviceConfig.Metrics.AgentPort
+		agentConf.MetricsBindAddress = serviceConfig.Metrics.GetBindAddress()
+		agentConf.MetricsTLS = serviceConfig.Metrics.TLS
 	}
 	a := agent.New(agentConf)
 
-	err := a.Start()
+	err = a.Start()
 	if err != nil {
 		return err
 	}
 
 	return nil
 }
+
+// putAllowedFiles returns the service configuration and the certificate files, which are the
+// files distributed to the agents. The private keys are left out, as they are copied over ssh.
+func putAllowedFiles() []string {
+	files := []string{absConfigFilepath()}
+
+	if creds, ok := serviceConfig.Credentials.(*utils.GpCredentials); ok && creds.TlsEnabled {
+		files = append(files, creds.CertPaths()...)
+	}
+
+	return files
+}
+
+func absConfigFilepath() string {
+	configFile, err := filepath.Abs(configFilepath)
+	if err != nil {
+		return configFilepath
+	}
+
+	return configFile
+}
@@ -0,0 +1,172 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	config "github.com/greenplum-db/gpdb/gpservice/pkg/gpservice_config"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+)
+
+// AuditQuery selects the audit records to show and the hosts to read them from
+type AuditQuery struct {
+	Filter  utils.AuditFilter
+	Hosts   []string
+	Verbose bool
+}
+
+func AuditCmd() *cobra.Command {
+	var since, until string
+	var allHosts bool
+	query := AuditQuery{}
+
+	auditCmd := &cobra.Command{
+		Use:   "audit",
+		Short: "Show the audit log of the hub and agent services",
+		Long: `Show the audit log of the hub and agent services, which records every call made to them
+along with the caller, the request with secrets redacted, and the outcome. The log of the
+local host is shown by default, which holds the calls made to the hub when it runs on it.`,
+		Args: cobra.NoArgs,
+		Example: `Show who called CleanInitCluster in the last week
+$ gpservice audit --method CleanInitCluster --since 168h
+
+Show the calls made to the agents on all the hosts on a given day
+$ gpservice audit --all-hosts --since 2024-06-01 --until 2024-06-02
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var err error
+			query.Filter.Since, err = parseAuditTime(since)
+			if err != nil {
+				return err
+			}
+
+			query.Filter.Until, err = parseAuditTime(until)
+			if err != nil {
+				return err
+			}
+
+			if allHosts {
+				query.Hosts = serviceConfig.ServiceIdentities()
+			}
+
+			return QueryAuditLog(os.Stdout, serviceConfig, query)
+		},
+	}
+
+	auditCmd.Flags().StringVar(&since, "since", "", `Only show the calls made since the given time, either as a date, an RFC 3339 timestamp or a duration before now such as 24h`)
+	auditCmd.Flags().StringVar(&until, "until", "", `Only show the calls made until the given time, in the same formats as --since`)
+	auditCmd.Flags().StringArrayVar(&query.Filter.Methods, "method", []string{}, `Only show the calls of the given method, such as CleanInitCluster`)
+	auditCmd.Flags().StringArrayVar(&query.Hosts, "host", []string{}, `Show the audit log of the given host instead of the local host`)
+	auditCmd.Flags().BoolVar(&allHosts, "all-hosts", false, `Show the audit logs of all the hosts in the configuration`)
+	auditCmd.Flags().BoolVar(&query.Verbose, "verbose", false, `Show the request of each call`)
+
+	auditCmd.MarkFlagsMutuallyExclusive("host", "all-hosts")
+
+	return auditCmd
+}
+
+// QueryAuditLog writes the audit records of the hosts which match the query, ordered by the time they started
+func QueryAuditLog(out io.Writer, conf *config.Config, query AuditQuery) error {
+	localHost, err := utils.System.GetHostName()
+	if err != nil {
+		return fmt.Errorf("could not get the hostname: %w", err)
+	}
+
+	hosts := query.Hosts
+	if len(hosts) == 0 {
+		hosts = []string{localHost}
+	}
+
+	path := filepath.Join(conf.LogDir, utils.AuditLogFile)
+
+	var records []utils.AuditRecord
+	if slices.Contains(hosts, localHost) {
+		contents, err := utils.System.ReadFile(path)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("could not read the audit log: %w", err)
+		}
+
+		records, err = utils.ReadAuditLog(bytes.NewReader(contents), query.Filter)
+		if err != nil {
+			return err
+		}
+	}
+
+	remoteHosts := slices.DeleteFunc(slices.Clone(hosts), func(host string) bool { return host == localHost })
+	if len(remoteHosts) > 0 {
+		results := utils.Remote.Run(remoteHosts, fmt.Sprintf("if [ -f %[1]s ]; then cat %[1]s; fi", utils.ShellQuote(path)))
+		err = results.Err()
+		if err != nil {
+			return fmt.Errorf("could not read the audit log on the hosts: %w", err)
+		}
+
+		for _, result := range results {
+			hostRecords, err := utils.ReadAuditLog(strings.NewReader(result.Stdout), query.Filter)
+			if err != nil {
+				return fmt.Errorf("host %s: %w", result.Hostname, err)
+			}
+			records = append(records, hostRecords...)
+		}
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Start.Before(records[j].Start)
+	})
+
+	displayAuditRecords(out, records, query.Verbose)
+	return nil
+}
+
+func displayAuditRecords(out io.Writer, records []utils.AuditRecord, verbose bool) {
+	w := new(tabwriter.Writer)
+	w.Init(out, 10, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "START\tHOST\tSERVICE\tCALLER\tMETHOD\tOUTCOME\tDURATION\tERROR")
+
+	for _, r := range records {
+		method := r.Method[strings.LastIndex(r.Method, "/")+1:]
+
+		// A call which has only started is either still running, or the service stopped during it
+		outcome, duration := r.Outcome, "-"
+		if r.End != nil {
+			duration = r.End.Sub(r.Start).Round(time.Millisecond).String()
+		} else if r.Event == utils.AuditEventStarted {
+			outcome = "started"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", r.Start.Local().Format(time.RFC3339), r.Host, r.Service, r.Caller, method, outcome, duration, r.Error)
+
+		if verbose && len(r.Request) > 0 {
+			fmt.Fprintf(w, "\trequest: %s\n", r.Request)
+		}
+	}
+	w.Flush()
+}
+
+// parseAuditTime parses a date, an RFC 3339 timestamp, or a duration before now
+func parseAuditTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	if duration, err := time.ParseDuration(value); err == nil {
+		return time.Now().Add(-duration), nil
+	}
+
+	for _, layout := range []string{time.RFC3339, time.DateTime, time.DateOnly} {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid time %q, expected a date, an RFC 3339 timestamp or a duration such as 24h", value)
+}
@@ -0,0 +1,100 @@
+package cli_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+
+	"github.com/greenplum-db/gpdb/gpservice/internal/cli"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+	"github.com/greenplum-db/gpdb/gpservice/testutils"
+)
+
+func TestQueryAuditLog(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	utils.System.GetHostName = func() (string, error) {
+		return "cdw", nil
+	}
+	defer utils.ResetSystemFunctions()
+
+	start := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	auditLog := func(records ...utils.AuditRecord) string {
+		var log strings.Builder
+		for _, record := range records {
+			line, _ := json.Marshal(record)
+			log.Write(append(line, '\n'))
+		}
+		return log.String()
+	}
+	at := func(offset time.Duration) *time.Time {
+		end := start.Add(offset)
+		return &end
+	}
+
+	conf := testutils.CreateDummyServiceConfig(t)
+	conf.LogDir = t.TempDir()
+	err := os.WriteFile(filepath.Join(conf.LogDir, utils.AuditLogFile), []byte(auditLog(
+		utils.AuditRecord{ID: "1", Event: utils.AuditEventStarted, Start: start.Add(2 * time.Hour), Host: "cdw", Service: "hub", Caller: "alice", Method: "/idl.Hub/CleanInitCluster"},
+		utils.AuditRecord{Start: start, End: at(0), Host: "cdw", Service: "hub", Caller: "bob", Method: "/idl.Hub/ClusterStatus", Outcome: "success", Code: "OK"},
+		utils.AuditRecord{ID: "2", Event: utils.AuditEventStarted, Start: start.Add(3 * time.Hour), Host: "cdw", Service: "hub", Caller: "alice", Method: "/idl.Hub/ExpandCluster"},
+		utils.AuditRecord{ID: "1", Event: utils.AuditEventFinished, Start: start.Add(2 * time.Hour), End: at(2*time.Hour + time.Second), Host: "cdw", Service: "hub", Caller: "alice", Method: "/idl.Hub/CleanInitCluster", Outcome: "success", Code: "OK"},
+	)), 0600)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	t.Run("shows the matching records of the local host", func(t *testing.T) {
+		var out bytes.Buffer
+		err := cli.QueryAuditLog(&out, conf, cli.AuditQuery{Filter: utils.AuditFilter{Methods: []string{"CleanInitCluster"}}})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := regexp.MustCompile(`^START +HOST +SERVICE +CALLER +METHOD +OUTCOME +DURATION +ERROR
+\S+ +cdw +hub +alice +CleanInitCluster +success +1s +
+$`)
+		if !expected.MatchString(out.String()) {
+			t.Fatalf("got %s, want to match %s", out.String(), expected)
+		}
+	})
+
+	t.Run("reads the audit logs of the given hosts in the order of the calls", func(t *testing.T) {
+		remote := testutils.SetMockRemoteExecutor(t)
+		remote.Result = func(hostname, command string) *utils.RemoteResult {
+			return &utils.RemoteResult{Stdout: auditLog(utils.AuditRecord{
+				Start: start.Add(time.Hour), End: at(time.Hour), Host: hostname, Service: "agent", Caller: "cdw",
+				Method: "/idl.Agent/RemoveDirectory", Request: json.RawMessage(`{"dataDirectory":"/data/primary"}`), Outcome: "success", Code: "OK",
+			})}
+		}
+
+		var out bytes.Buffer
+		err := cli.QueryAuditLog(&out, conf, cli.AuditQuery{Hosts: []string{"cdw", "sdw1"}, Verbose: true})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expectedCommand := "if [ -f '" + filepath.Join(conf.LogDir, utils.AuditLogFile) + "' ]; then cat '" + filepath.Join(conf.LogDir, utils.AuditLogFile) + "'; fi"
+		if len(remote.Commands) != 1 || remote.Commands[0] != expectedCommand {
+			t.Fatalf("got %v, want %s", remote.Commands, expectedCommand)
+		}
+
+		expected := regexp.MustCompile(`^START +HOST +SERVICE +CALLER +METHOD +OUTCOME +DURATION +ERROR
+\S+ +cdw +hub +bob +ClusterStatus +success +0s +
+\S+ +sdw1 +agent +cdw +RemoveDirectory +success +0s +
+ +request: {"dataDirectory":"/data/primary"}
+\S+ +cdw +hub +alice +CleanInitCluster +success +1s +
+\S+ +cdw +hub +alice +ExpandCluster +started +- +
+$`)
+		if !expected.MatchString(out.String()) {
+			t.Fatalf("got %s, want to match %s", out.String(), expected)
+		}
+	})
+}
@@ -0,0 +1,317 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	config "github.com/greenplum-db/gpdb/gpservice/pkg/gpservice_config"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+)
+
+const authRestartHint = "Restart the services using the 'gpservice stop' and 'gpservice start' commands for the change to take effect"
+
+func AuthCmd() *cobra.Command {
+	authCmd := &cobra.Command{
+		Use:   "auth",
+		Short: "Manage who is allowed to call the hub and agent services",
+		Long: `Manage who is allowed to call the hub and agent services. Once enabled, callers are
+identified by the common name of their client certificate, or by a bearer token set in
+the ` + utils.AuthTokenEnv + ` environment variable. Callers with the read-only role can
+only get the status of the services and the cluster, while the admin role is needed for
+everything else. The hosts of the configuration are always admins, since the services
+call each other using the certificates of their host. Callers with a token do not present
+a client certificate, which the services stop requiring once tokens have been created.
+Authentication requires TLS.`,
+		Example: `Enable authentication and let the certificate with the common name alice manage the cluster
+$ gpservice auth enable
+$ gpservice auth grant alice --role admin
+
+Create a token which can only get the status of the cluster
+$ gpservice auth create-token monitoring --role read-only
+`,
+	}
+
+	authCmd.AddCommand(
+		enableAuthCmd(),
+		disableAuthCmd(),
+		grantCmd(),
+		revokeCmd(),
+		createTokenCmd(),
+		listAuthCmd(),
+	)
+
+	return authCmd
+}
+
+func enableAuthCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "enable",
+		Short: "Require the callers of the services to be authenticated",
+		Long: `Require the callers of the services to be authenticated. The common name of the client
+certificate in the configuration is granted the admin role, so that it keeps being able to
+manage the services.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return EnableAuth(serviceConfig, configFilepath)
+		},
+	}
+}
+
+func disableAuthCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "disable",
+		Short: "Allow all callers which pass the TLS verification to call the services",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return DisableAuth(serviceConfig, configFilepath)
+		},
+	}
+}
+
+func grantCmd() *cobra.Command {
+	var role string
+
+	grantCmd := &cobra.Command{
+		Use:   "grant <common name>",
+		Short: "Grant a role to the callers with a client certificate of the given common name",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			parsedRole, err := utils.ParseRole(role)
+			if err != nil {
+				return err
+			}
+
+			return GrantRole(serviceConfig, configFilepath, args[0], parsedRole)
+		},
+	}
+
+	grantCmd.Flags().StringVar(&role, "role", string(utils.RoleReadOnly), `Role to grant, either read-only or admin`)
+
+	return grantCmd
+}
+
+func revokeCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "revoke <name>",
+		Short: "Revoke the role of a certificate common name and the tokens of the given name",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RevokeRole(serviceConfig, configFilepath, args[0])
+		},
+	}
+}
+
+func createTokenCmd() *cobra.Command {
+	var role string
+
+	createTokenCmd := &cobra.Command{
+		Use:   "create-token <name>",
+		Short: "Create a bearer token with the given role",
+		Long: `Create a bearer token with the given role, which is printed once and cannot be retrieved
+afterwards. Callers use it by setting the ` + utils.AuthTokenEnv + ` environment variable.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			parsedRole, err := utils.ParseRole(role)
+			if err != nil {
+				return err
+			}
+
+			return CreateToken(os.Stdout, serviceConfig, configFilepath, args[0], parsedRole)
+		},
+	}
+
+	createTokenCmd.Flags().StringVar(&role, "role", string(utils.RoleReadOnly), `Role of the token, either read-only or admin`)
+
+	return createTokenCmd
+}
+
+func listAuthCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List the certificate common names and tokens along with their roles",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return ListRoles(os.Stdout, serviceConfig)
+		},
+	}
+}
+
+// EnableAuth enables authentication, granting the admin role to the client certificate of the configuration
+func EnableAuth(conf *config.Config, configFilepath string) error {
+	auth := authConfig(conf)
+	auth.Enabled = true
+
+	err := auth.Validate(conf.Credentials)
+	if err != nil {
+		return utils.NewHelpErr(err, "Enable TLS using the 'gpservice certs issue' command first.")
+	}
+
+	name, err := clientCertificateName(conf.Credentials)
+	if err != nil {
+		return err
+	}
+
+	if _, ok := auth.Users[name]; !ok && !slices.Contains(conf.ServiceIdentities(), name) {
+		auth.Users[name] = utils.RoleAdmin
+	}
+
+	err = conf.Write(configFilepath)
+	if err != nil {
+		return err
+	}
+
+	gplog.Info("Enabled authentication. %s", authRestartHint)
+	return nil
+}
+
+func DisableAuth(conf *config.Config, configFilepath string) error {
+	authConfig(conf).Enabled = false
+
+	err := conf.Write(configFilepath)
+	if err != nil {
+		return err
+	}
+
+	gplog.Info("Disabled authentication. %s", authRestartHint)
+	return nil
+}
+
+// GrantRole grants the role to the callers with a client certificate of the given common name
+func GrantRole(conf *config.Config, configFilepath, name string, role utils.Role) error {
+	if slices.Contains(conf.ServiceIdentities(), name) {
+		return fmt.Errorf("%s is a host of the configuration, which is always granted the %s role", name, utils.RoleAdmin)
+	}
+
+	authConfig(conf).Users[name] = role
+
+	err := conf.Write(configFilepath)
+	if err != nil {
+		return err
+	}
+
+	gplog.Info("Granted the %s role to %s. %s", role, name, authRestartHint)
+	return nil
+}
+
+// RevokeRole removes the role of the given certificate common name, and the tokens of the given name
+func RevokeRole(conf *config.Config, configFilepath, name string) error {
+	auth := authConfig(conf)
+
+	_, found := auth.Users[name]
+	delete(auth.Users, name)
+
+	tokens := slices.DeleteFunc(auth.Tokens, func(token utils.AuthToken) bool {
+		return token.Name == name
+	})
+	found = found || len(tokens) != len(auth.Tokens)
+	auth.Tokens = tokens
+
+	if !found {
+		return fmt.Errorf("no role or token is granted to %s", name)
+	}
+
+	err := conf.Write(configFilepath)
+	if err != nil {
+		return err
+	}
+
+	gplog.Info("Revoked the access of %s. %s", name, authRestartHint)
+	return nil
+}
+
+// CreateToken creates a bearer token with the given role, writing the token to out
+func CreateToken(out io.Writer, conf *config.Config, configFilepath, name string, role utils.Role) error {
+	auth := authConfig(conf)
+	if slices.ContainsFunc(auth.Tokens, func(token utils.AuthToken) bool { return token.Name == name }) {
+		return utils.NewHelpErr(fmt.Errorf("a token named %s already exists", name), "Revoke it using the 'gpservice auth revoke' command to replace it.")
+	}
+
+	token, entry, err := utils.NewAuthToken(name, role)
+	if err != nil {
+		return err
+	}
+	auth.Tokens = append(auth.Tokens, entry)
+
+	err = conf.Write(configFilepath)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(out, token)
+	gplog.Info("Created the token %s with the %s role. It is not shown again. %s", name, role, authRestartHint)
+	return nil
+}
+
+// ListRoles writes the certificate common names and tokens which are granted a role
+func ListRoles(out io.Writer, conf *config.Config) error {
+	auth := authConfig(conf)
+
+	w := new(tabwriter.Writer)
+	w.Init(out, 10, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "Authentication enabled: %t\n", auth.Enabled)
+	fmt.Fprintln(w, "NAME\tTYPE\tROLE")
+
+	for _, host := range conf.ServiceIdentities() {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", host, "Host", utils.RoleAdmin)
+	}
+
+	var names []string
+	for name := range auth.Users {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", name, "Certificate", auth.Users[name])
+	}
+
+	for _, token := range auth.Tokens {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", token.Name, "Token", token.Role)
+	}
+
+	return w.Flush()
+}
+
+// authConfig returns the authentication configuration, adding it to the configuration if missing
+func authConfig(conf *config.Config) *utils.AuthConfig {
+	if conf.Auth == nil {
+		conf.Auth = &utils.AuthConfig{}
+	}
+
+	if conf.Auth.Users == nil {
+		conf.Auth.Users = map[string]utils.Role{}
+	}
+
+	return conf.Auth
+}
+
+// clientCertificateName returns the common name of the client certificate the callers present
+func clientCertificateName(creds utils.Credentials) (string, error) {
+	gpCreds, ok := creds.(*utils.GpCredentials)
+	if !ok {
+		return "", fmt.Errorf("could not find the client certificate in the configuration")
+	}
+
+	path := gpCreds.ClientCertPath
+	if path == "" {
+		path = gpCreds.ServerCertPath
+	}
+
+	contents, err := utils.System.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("could not read client certificate: %w", err)
+	}
+
+	cert, err := utils.ParseCertificatePEM(contents)
+	if err != nil {
+		return "", fmt.Errorf("could not parse client certificate %s: %w", path, err)
+	}
+
+	return cert.Subject.CommonName, nil
+}
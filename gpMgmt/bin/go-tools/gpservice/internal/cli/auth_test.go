@@ -0,0 +1,180 @@
+package cli_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+
+	"github.com/greenplum-db/gpdb/gpservice/internal/cli"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/gpservice_config"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+	"github.com/greenplum-db/gpdb/gpservice/testutils"
+)
+
+func TestEnableAuth(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	gpservice_config.SetCopyConfigFileToAgents()
+	defer gpservice_config.ResetConfigFunctions()
+
+	t.Run("enables authentication and grants the admin role to the client certificate", func(t *testing.T) {
+		conf := testutils.CreateDummyServiceConfig(t)
+		conf.Credentials = writeClientCertificate(t, "gpadmin")
+		configFile := filepath.Join(t.TempDir(), "gpservice.conf")
+
+		err := cli.EnableAuth(conf, configFile)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		result, err := gpservice_config.Read(configFile)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := &utils.AuthConfig{Enabled: true, Users: map[string]utils.Role{"gpadmin": utils.RoleAdmin}}
+		if !reflect.DeepEqual(result.Auth, expected) {
+			t.Fatalf("got %+v, want %+v", result.Auth, expected)
+		}
+	})
+
+	t.Run("does not grant a role to the certificate of a host", func(t *testing.T) {
+		conf := testutils.CreateDummyServiceConfig(t)
+		conf.Credentials = writeClientCertificate(t, "sdw1")
+
+		err := cli.EnableAuth(conf, filepath.Join(t.TempDir(), "gpservice.conf"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(conf.Auth.Users) != 0 {
+			t.Fatalf("got %v, want no users", conf.Auth.Users)
+		}
+	})
+
+	t.Run("errors out when TLS is not enabled", func(t *testing.T) {
+		conf := testutils.CreateDummyServiceConfig(t)
+
+		err := cli.EnableAuth(conf, filepath.Join(t.TempDir(), "gpservice.conf"))
+		expected := "authentication requires TLS to be enabled"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+}
+
+func TestGrantAndRevokeRole(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	gpservice_config.SetCopyConfigFileToAgents()
+	defer gpservice_config.ResetConfigFunctions()
+
+	conf := testutils.CreateDummyServiceConfig(t)
+	configFile := filepath.Join(t.TempDir(), "gpservice.conf")
+
+	err := cli.GrantRole(conf, configFile, "alice", utils.RoleAdmin)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var out bytes.Buffer
+	err = cli.CreateToken(&out, conf, configFile, "monitoring", utils.RoleReadOnly)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	token := strings.TrimSpace(out.String())
+	if token == "" || strings.Contains(conf.Auth.Tokens[0].Hash, token) {
+		t.Fatalf("expected the token to be printed and only its hash to be stored, got %+v", conf.Auth.Tokens)
+	}
+
+	err = cli.CreateToken(&out, conf, configFile, "monitoring", utils.RoleAdmin)
+	expected := "a token named monitoring already exists"
+	if err == nil || err.Error() != expected {
+		t.Fatalf("got %v, want %s", err, expected)
+	}
+
+	err = cli.GrantRole(conf, configFile, "sdw1", utils.RoleReadOnly)
+	expected = "sdw1 is a host of the configuration, which is always granted the admin role"
+	if err == nil || err.Error() != expected {
+		t.Fatalf("got %v, want %s", err, expected)
+	}
+
+	out.Reset()
+	err = cli.ListRoles(&out, conf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expectedList := `Authentication enabled: false
+NAME        TYPE         ROLE
+sdw1        Host         admin
+sdw2        Host         admin
+alice       Certificate  admin
+monitoring  Token        read-only
+`
+	if out.String() != expectedList {
+		t.Fatalf("got %q, want %q", out.String(), expectedList)
+	}
+
+	for _, name := range []string{"alice", "monitoring"} {
+		err = cli.RevokeRole(conf, configFile, name)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	result, err := gpservice_config.Read(configFile)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(result.Auth.Users) != 0 || len(result.Auth.Tokens) != 0 {
+		t.Fatalf("got %+v, want the roles to be revoked", result.Auth)
+	}
+
+	err = cli.RevokeRole(conf, configFile, "alice")
+	expected = "no role or token is granted to alice"
+	if err == nil || err.Error() != expected {
+		t.Fatalf("got %v, want %s", err, expected)
+	}
+}
+
+func writeClientCertificate(t *testing.T, commonName string) *utils.GpCredentials {
+	t.Helper()
+
+	ca, err := utils.NewCertificateAuthority("ca", time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cert, key, err := ca.IssueClientCertificate(commonName, time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	dir := t.TempDir()
+	creds := &utils.GpCredentials{
+		CACertPath:     filepath.Join(dir, "ca-cert.pem"),
+		ServerCertPath: filepath.Join(dir, "server-cert.pem"),
+		ServerKeyPath:  filepath.Join(dir, "server-key.pem"),
+		ClientCertPath: filepath.Join(dir, "client-cert.pem"),
+		ClientKeyPath:  filepath.Join(dir, "client-key.pem"),
+		TlsEnabled:     true,
+	}
+
+	for path, contents := range map[string][]byte{creds.CACertPath: ca.CertPEM(), creds.ClientCertPath: cert, creds.ClientKeyPath: key} {
+		err = os.WriteFile(path, contents, 0600)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	return creds
+}
@@ -0,0 +1,672 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"github.com/greenplum-db/gpdb/gpservice/idl"
+	config "github.com/greenplum-db/gpdb/gpservice/pkg/gpservice_config"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+)
+
+const (
+	caCertFile     = "ca-cert.pem"
+	caKeyFile      = "ca-key.pem"
+	serverCertFile = "server-cert.pem"
+	serverKeyFile  = "server-key.pem"
+	clientCertFile = "client-cert.pem"
+	clientKeyFile  = "client-key.pem"
+
+	// issuedCertsDir holds a copy of the certificates issued to each host, without their keys
+	issuedCertsDir = "issued"
+)
+
+// rotatedFiles are the files in the certificate directory which are replaced when rotating the certificates
+var rotatedFiles = []string{serverCertFile, serverKeyFile, clientCertFile, clientKeyFile}
+
+func CertsCmd() *cobra.Command {
+	var certDir string
+	var caDir string
+
+	certsCmd := &cobra.Command{
+		Use:   "certs",
+		Short: "Manage the TLS certificates of the hub and agent services",
+		Long: `Manage the TLS certificates of the hub and agent services. A cluster CA is created on the
+local host, which issues a server and a client certificate to every host in the gpservice
+configuration. The CA private key never leaves the local host, and is kept in its own
+directory, outside of the directories the agents serve files from.`,
+		Example: `Create the CA, and issue and distribute the certificates to all the hosts
+$ gpservice certs create-ca
+$ gpservice certs issue
+
+List the certificates along with their expiry dates
+$ gpservice certs list
+
+Replace the certificates of all the hosts while the services are running
+$ gpservice certs rotate
+`,
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			cmd.Root().PersistentPreRun(cmd, args)
+
+			if certDir == "" {
+				certDir = filepath.Join(filepath.Dir(configFilepath), "certificates")
+			}
+
+			// The certificates are written to the same path on all the hosts
+			absDir, err := filepath.Abs(certDir)
+			if err != nil {
+				logErrorAndExit(cmd, fmt.Errorf("failed to resolve absolute path for %s: %w", certDir, err))
+			}
+			certDir = absDir
+
+			if caDir == "" {
+				caDir = filepath.Join(filepath.Dir(configFilepath), "ca")
+			}
+
+			absDir, err = filepath.Abs(caDir)
+			if err != nil {
+				logErrorAndExit(cmd, fmt.Errorf("failed to resolve absolute path for %s: %w", caDir, err))
+			}
+			caDir = absDir
+
+			err = checkCADir(caDir, certDir, serviceConfig.LogDir)
+			if err != nil {
+				logErrorAndExit(cmd, err)
+			}
+		},
+	}
+
+	certsCmd.PersistentFlags().StringVar(&certDir, "cert-dir", "", `Directory of the certificates on all the hosts, defaults to the certificates directory next to the configuration file`)
+	certsCmd.PersistentFlags().StringVar(&caDir, "ca-dir", "", `Directory of the CA private key on the local host, defaults to the ca directory next to the configuration file`)
+
+	certsCmd.AddCommand(
+		createCACmd(&certDir, &caDir),
+		issueCertsCmd(&certDir, &caDir),
+		listCertsCmd(&certDir),
+		rotateCertsCmd(&certDir, &caDir),
+		reloadCertsCmd(),
+	)
+
+	return certsCmd
+}
+
+// checkCADir ensures the CA private key is not written to a directory whose files are copied to
+// the other hosts or can be fetched from the agents
+func checkCADir(caDir, certDir, logDir string) error {
+	if utils.IsPathAllowed(filepath.Join(caDir, caKeyFile), nil, []string{certDir, logDir}) {
+		return fmt.Errorf("the CA directory %s cannot be within the certificate directory %s or the log directory %s", caDir, certDir, logDir)
+	}
+
+	return nil
+}
+
+func createCACmd(certDir, caDir *string) *cobra.Command {
+	var days int
+	var force bool
+
+	createCACmd := &cobra.Command{
+		Use:   "create-ca",
+		Short: "Create the cluster CA on the local host",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return CreateCA(*certDir, *caDir, time.Duration(days)*24*time.Hour, force)
+		},
+	}
+
+	createCACmd.Flags().IntVar(&days, "days", int(utils.DefaultCAValidity.Hours()/24), `Number of days the CA is valid for`)
+	createCACmd.Flags().BoolVar(&force, "force", false, `Replace an existing CA. Certificates issued by it are no longer trusted once reissued`)
+
+	return createCACmd
+}
+
+func issueCertsCmd(certDir, caDir *string) *cobra.Command {
+	var days int
+
+	issueCmd := &cobra.Command{
+		Use:   "issue",
+		Short: "Issue certificates to all the hosts and enable TLS in the configuration",
+		Long: `Issue a server and a client certificate to every host in the gpservice configuration
+and distribute them to the hosts along with the CA certificate. The server certificate is
+valid for the hostname as well as for localhost. The configuration is updated to use the
+certificates, and takes effect once the services are restarted.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return IssueCertificates(serviceConfig, configFilepath, *certDir, *caDir, time.Duration(days)*24*time.Hour)
+		},
+	}
+
+	issueCmd.Flags().IntVar(&days, "days", int(utils.DefaultCertificateValidity.Hours()/24), `Number of days the certificates are valid for`)
+
+	return issueCmd
+}
+
+func listCertsCmd(certDir *string) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List the CA and issued certificates along with their expiry dates",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return ListCertificates(os.Stdout, *certDir)
+		},
+	}
+}
+
+func rotateCertsCmd(certDir, caDir *string) *cobra.Command {
+	var days int
+
+	rotateCmd := &cobra.Command{
+		Use:   "rotate",
+		Short: "Replace the certificates of all the hosts without restarting the services",
+		Long: `Issue new server and client certificates to every host in the gpservice configuration,
+and reload them in the running hub and agent services. The previous certificates are kept
+until the hub has reached all the agents using the new ones, and are restored if it could
+not. The CA is not replaced, create a new CA and issue the certificates again for that.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RotateCertificates(serviceConfig, *certDir, *caDir, time.Duration(days)*24*time.Hour)
+		},
+	}
+
+	rotateCmd.Flags().IntVar(&days, "days", int(utils.DefaultCertificateValidity.Hours()/24), `Number of days the certificates are valid for`)
+
+	return rotateCmd
+}
+
+func reloadCertsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "reload",
+		Short: "Reload the certificates in the running hub and agent services",
+		Long: `Reload the certificate and key files in the running hub and agent services, for when they
+have been replaced by other means. The services also reload them on their own shortly
+after the files change.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			reloaded, err := reloadCredentials(serviceConfig)
+			if err != nil {
+				return err
+			}
+
+			if reloaded {
+				gplog.Info("Reloaded the certificates in the hub and agent services")
+			}
+			return nil
+		},
+	}
+}
+
+// CreateCA creates a CA certificate in the certificate directory of the local host, and its
+// private key in the CA directory
+func CreateCA(certDir, caDir string, validity time.Duration, force bool) error {
+	certPath := filepath.Join(certDir, caCertFile)
+	keyPath := filepath.Join(caDir, caKeyFile)
+
+	if _, err := utils.System.Stat(certPath); err == nil && !force {
+		return utils.NewHelpErr(fmt.Errorf("CA certificate %s already exists", certPath),
+			"Use --force to replace it. Certificates issued by the existing CA have to be issued again.")
+	}
+
+	hostname, err := utils.System.GetHostName()
+	if err != nil {
+		return fmt.Errorf("could not get the hostname: %w", err)
+	}
+
+	ca, err := utils.NewCertificateAuthority(fmt.Sprintf("gpservice CA %s", hostname), validity)
+	if err != nil {
+		return err
+	}
+
+	keyPEM, err := ca.KeyPEM()
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(certDir, 0700)
+	if err != nil {
+		return fmt.Errorf("could not create directory %s: %w", certDir, err)
+	}
+
+	err = os.MkdirAll(caDir, 0700)
+	if err != nil {
+		return fmt.Errorf("could not create directory %s: %w", caDir, err)
+	}
+
+	err = writeFileAtomic(keyPath, keyPEM, 0600)
+	if err != nil {
+		return err
+	}
+
+	err = writeFileAtomic(certPath, ca.CertPEM(), 0644)
+	if err != nil {
+		return err
+	}
+
+	gplog.Info("Created CA certificate %s, valid until %s", certPath, ca.Cert.NotAfter.Format(time.DateOnly))
+	return nil
+}
+
+/*
+IssueCertificates issues a server and a client certificate to each host of the configuration
+and the local host, and copies them to the certificate directory on the hosts along with the
+CA certificate. The private keys are generated locally and only kept on the host they were
+issued to. Finally, the configuration is updated to enable TLS using the certificates.
+*/
+func IssueCertificates(conf *config.Config, configFilepath, certDir, caDir string, validity time.Duration) error {
+	ca, err := utils.LoadCertificateAuthority(filepath.Join(certDir, caCertFile), filepath.Join(caDir, caKeyFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return utils.NewHelpErr(err, "Create the CA using the 'gpservice certs create-ca' command first.")
+	}
+	if err != nil {
+		return err
+	}
+
+	localHost, remoteHosts, err := certificateHosts(conf)
+	if err != nil {
+		return err
+	}
+
+	if len(remoteHosts) > 0 {
+		err = utils.Remote.Run(remoteHosts, fmt.Sprintf("mkdir -p -m 700 %s", utils.ShellQuote(certDir))).Err()
+		if err != nil {
+			return fmt.Errorf("could not create directory %s on the hosts: %w", certDir, err)
+		}
+	}
+
+	err = issueAllCertificates(conf, ca, certDir, localHost, remoteHosts, validity)
+	if err != nil {
+		return err
+	}
+
+	creds := &utils.GpCredentials{}
+	if existing, ok := conf.Credentials.(*utils.GpCredentials); ok && existing.TlsEnabled {
+		creds.CRLPath = existing.CRLPath
+		creds.MinTLSVersion = existing.MinTLSVersion
+	}
+	creds.CACertPath = filepath.Join(certDir, caCertFile)
+	creds.ServerCertPath = filepath.Join(certDir, serverCertFile)
+	creds.ServerKeyPath = filepath.Join(certDir, serverKeyFile)
+	creds.ClientCertPath = filepath.Join(certDir, clientCertFile)
+	creds.ClientKeyPath = filepath.Join(certDir, clientKeyFile)
+	creds.TlsEnabled = true
+
+	conf.Credentials = creds
+	err = conf.Write(configFilepath)
+	if err != nil {
+		return err
+	}
+
+	gplog.Info("Issued certificates to hosts %s. Restart the services using the 'gpservice stop' and 'gpservice start' commands to use them",
+		strings.Join(append([]string{localHost}, remoteHosts...), ", "))
+	return nil
+}
+
+/*
+RotateCertificates issues new certificates to each host of the configuration and the local
+host, and reloads them in the running services. The previous certificates are backed up
+first, and restored if the hub could not reach the agents using the new certificates, so
+that the services are never left unable to connect to each other. The backups are removed
+once the new certificates are known to work.
+*/
+func RotateCertificates(conf *config.Config, certDir, caDir string, validity time.Duration) error {
+	creds, ok := conf.Credentials.(*utils.GpCredentials)
+	if !ok || !creds.TlsEnabled || creds.ServerCertPath != filepath.Join(certDir, serverCertFile) {
+		return utils.NewHelpErr(fmt.Errorf("the services are not using the certificates in %s", certDir),
+			"Issue the certificates using the 'gpservice certs issue' command first.")
+	}
+
+	ca, err := utils.LoadCertificateAuthority(filepath.Join(certDir, caCertFile), filepath.Join(caDir, caKeyFile))
+	if err != nil {
+		return err
+	}
+
+	localHost, remoteHosts, err := certificateHosts(conf)
+	if err != nil {
+		return err
+	}
+
+	backup, err := backupCertificates(certDir, localHost, remoteHosts)
+	if err != nil {
+		return fmt.Errorf("could not back up the certificates: %w", err)
+	}
+
+	err = rotateCertificates(conf, ca, certDir, localHost, remoteHosts, validity)
+	if err != nil {
+		restoreErr := backup.restore()
+		if restoreErr != nil {
+			return fmt.Errorf("could not rotate the certificates: %w, and could not restore the previous certificates: %v", err, restoreErr)
+		}
+
+		_, reloadErr := reloadCredentials(conf)
+		if reloadErr != nil {
+			gplog.Warn("Could not reload the previous certificates: %v", reloadErr)
+		}
+
+		return fmt.Errorf("could not rotate the certificates, the previous certificates have been restored: %w", err)
+	}
+
+	backup.remove()
+
+	gplog.Info("Rotated the certificates of hosts %s", strings.Join(append([]string{localHost}, remoteHosts...), ", "))
+	return nil
+}
+
+// rotateCertificates issues the new certificates, and verifies the hub reaches the agents using them
+func rotateCertificates(conf *config.Config, ca *utils.CertificateAuthority, certDir, localHost string, remoteHosts []string, validity time.Duration) error {
+	err := issueAllCertificates(conf, ca, certDir, localHost, remoteHosts, validity)
+	if err != nil {
+		return err
+	}
+
+	reloaded, err := reloadCredentials(conf)
+	if err != nil || !reloaded {
+		return err
+	}
+
+	client, err := config.ConnectToHub(conf)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.StatusAgents(context.Background(), &idl.StatusAgentsRequest{})
+	if err != nil {
+		return fmt.Errorf("could not reach the agents using the new certificates: %w", err)
+	}
+
+	return nil
+}
+
+// reloadCredentials reloads the certificates in the running services. It reports whether the
+// hub was running, as the services use the certificates on the files once started otherwise.
+func reloadCredentials(conf *config.Config) (bool, error) {
+	client, err := config.ConnectToHub(conf)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = client.ReloadCredentials(context.Background(), &idl.ReloadCredentialsRequest{})
+	if err != nil {
+		if utils.IsGrpcServerUnavailableErr(err) {
+			gplog.Info("Hub service is not running, the certificates will be used once the services are started")
+			return false, nil
+		}
+
+		return false, fmt.Errorf("failed to reload the certificates in the services: %w", err)
+	}
+
+	return true, nil
+}
+
+// certificateBackup holds the certificates in use before they are rotated
+type certificateBackup struct {
+	certDir     string
+	remoteHosts []string
+	localFiles  map[string][]byte
+}
+
+// backupCertificates keeps the current certificates and keys of the local host, and the copies of
+// the certificates issued to each host, in memory. The files on the remote hosts are copied to
+// a file with the .old suffix next to them.
+func backupCertificates(certDir, localHost string, remoteHosts []string) (*certificateBackup, error) {
+	backup := &certificateBackup{certDir: certDir, remoteHosts: remoteHosts, localFiles: map[string][]byte{}}
+
+	var paths []string
+	for _, name := range rotatedFiles {
+		paths = append(paths, filepath.Join(certDir, name))
+	}
+	for _, host := range append([]string{localHost}, remoteHosts...) {
+		hostDir := filepath.Join(certDir, issuedCertsDir, host)
+		paths = append(paths, filepath.Join(hostDir, serverCertFile), filepath.Join(hostDir, clientCertFile))
+	}
+
+	for _, path := range paths {
+		contents, err := utils.System.ReadFile(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		backup.localFiles[path] = contents
+	}
+
+	if len(remoteHosts) > 0 {
+		err := utils.Remote.Run(remoteHosts, backup.remoteCommand(`cp -p "$f" "$f.old"`)).Err()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return backup, nil
+}
+
+// restore puts the backed up certificates back in place on all the hosts
+func (b *certificateBackup) restore() error {
+	for path, contents := range b.localFiles {
+		mode := os.FileMode(0644)
+		if strings.HasSuffix(path, "-key.pem") {
+			mode = 0600
+		}
+
+		err := writeFileAtomic(path, contents, mode)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(b.remoteHosts) > 0 {
+		return utils.Remote.Run(b.remoteHosts, b.remoteCommand(`mv -f "$f.old" "$f"`)).Err()
+	}
+
+	return nil
+}
+
+// remove deletes the backed up certificates from the remote hosts
+func (b *certificateBackup) remove() {
+	if len(b.remoteHosts) == 0 {
+		return
+	}
+
+	err := utils.Remote.Run(b.remoteHosts, b.remoteCommand(`rm -f "$f.old"`)).Err()
+	if err != nil {
+		gplog.Warn("Could not remove the previous certificates from the hosts: %v", err)
+	}
+}
+
+// remoteCommand returns a command which runs the given command for each rotated file on the remote hosts
+func (b *certificateBackup) remoteCommand(command string) string {
+	return fmt.Sprintf("cd %s && for f in %s; do %s || exit 1; done", utils.ShellQuote(b.certDir), strings.Join(rotatedFiles, " "), command)
+}
+
+// certificateHosts returns the local host, followed by the other hosts of the configuration
+func certificateHosts(conf *config.Config) (string, []string, error) {
+	localHost, err := utils.System.GetHostName()
+	if err != nil {
+		return "", nil, fmt.Errorf("could not get the hostname: %w", err)
+	}
+
+	var remoteHosts []string
+	for _, host := range conf.Hostnames {
+		if host != localHost {
+			remoteHosts = append(remoteHosts, host)
+		}
+	}
+
+	return localHost, remoteHosts, nil
+}
+
+func issueAllCertificates(conf *config.Config, ca *utils.CertificateAuthority, certDir, localHost string, remoteHosts []string, validity time.Duration) error {
+	for _, host := range append([]string{localHost}, remoteHosts...) {
+		err := issueHostCertificates(conf, ca, certDir, host, host == localHost, validity)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// issueHostCertificates issues the certificates of a single host and writes them to the certificate
+// directory, either directly on the local host or by copying them to a remote host. The private
+// keys are always copied over ssh, as the hub may transfer files without TLS.
+func issueHostCertificates(conf *config.Config, ca *utils.CertificateAuthority, certDir, host string, local bool, validity time.Duration) error {
+	serverCert, serverKey, err := ca.IssueServerCertificate(host, validity)
+	if err != nil {
+		return err
+	}
+
+	clientCert, clientKey, err := ca.IssueClientCertificate(host, validity)
+	if err != nil {
+		return err
+	}
+
+	// A copy of the certificates is kept to list them, while the keys are only kept on the host
+	hostDir := filepath.Join(certDir, issuedCertsDir, host)
+	err = os.MkdirAll(hostDir, 0700)
+	if err != nil {
+		return fmt.Errorf("could not create directory %s: %w", hostDir, err)
+	}
+
+	err = writeFileAtomic(filepath.Join(hostDir, serverCertFile), serverCert, 0644)
+	if err != nil {
+		return err
+	}
+
+	err = writeFileAtomic(filepath.Join(hostDir, clientCertFile), clientCert, 0644)
+	if err != nil {
+		return err
+	}
+
+	files := []struct {
+		name     string
+		contents []byte
+		mode     os.FileMode
+	}{
+		{serverCertFile, serverCert, 0644},
+		{serverKeyFile, serverKey, 0600},
+		{clientCertFile, clientCert, 0644},
+		{clientKeyFile, clientKey, 0600},
+		{caCertFile, ca.CertPEM(), 0644},
+	}
+
+	if local {
+		for _, file := range files {
+			err = writeFileAtomic(filepath.Join(certDir, file.name), file.contents, file.mode)
+			if err != nil {
+				return err
+			}
+		}
+
+		gplog.Debug("Issued certificates to host %s", host)
+		return nil
+	}
+
+	stagingDir, err := os.MkdirTemp("", "gpservice-certs-")
+	if err != nil {
+		return fmt.Errorf("could not create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(stagingDir)
+
+	for _, file := range files {
+		stagedPath := filepath.Join(stagingDir, file.name)
+		err = writeFileAtomic(stagedPath, file.contents, file.mode)
+		if err != nil {
+			return err
+		}
+
+		destination := filepath.Join(certDir, file.name)
+		if file.mode == 0600 {
+			err = utils.Remote.CopyFile([]string{host}, stagedPath, destination).Err()
+			if err != nil {
+				return fmt.Errorf("could not copy %s to host %s: %w", destination, host, err)
+			}
+			continue
+		}
+
+		err = conf.CopyFileToHosts([]string{host}, stagedPath, destination)
+		if err != nil {
+			return err
+		}
+	}
+
+	gplog.Debug("Issued certificates to host %s", host)
+	return nil
+}
+
+// ListCertificates writes the CA certificate and the certificates issued to each host, along with their expiry dates
+func ListCertificates(out io.Writer, certDir string) error {
+	type row struct {
+		host, kind string
+		path       string
+	}
+
+	rows := []row{{"-", "CA", filepath.Join(certDir, caCertFile)}}
+
+	entries, err := os.ReadDir(filepath.Join(certDir, issuedCertsDir))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("could not read the issued certificates: %w", err)
+	}
+
+	var hosts []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			hosts = append(hosts, entry.Name())
+		}
+	}
+	slices.Sort(hosts)
+
+	for _, host := range hosts {
+		rows = append(rows,
+			row{host, "Server", filepath.Join(certDir, issuedCertsDir, host, serverCertFile)},
+			row{host, "Client", filepath.Join(certDir, issuedCertsDir, host, clientCertFile)},
+		)
+	}
+
+	w := new(tabwriter.Writer)
+	w.Init(out, 10, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "HOST\tTYPE\tSUBJECT\tEXPIRES\tDAYS LEFT")
+
+	for _, r := range rows {
+		contents, err := utils.System.ReadFile(r.path)
+		if err != nil {
+			if r.kind == "CA" && errors.Is(err, os.ErrNotExist) {
+				return utils.NewHelpErr(fmt.Errorf("CA certificate %s does not exist", r.path), "Create the CA using the 'gpservice certs create-ca' command.")
+			}
+			return fmt.Errorf("could not read certificate: %w", err)
+		}
+
+		cert, err := utils.ParseCertificatePEM(contents)
+		if err != nil {
+			return fmt.Errorf("could not parse certificate %s: %w", r.path, err)
+		}
+
+		daysLeft := int(time.Until(cert.NotAfter).Hours() / 24)
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\n", r.host, r.kind, cert.Subject.CommonName, cert.NotAfter.Format(time.DateOnly), daysLeft)
+	}
+
+	return w.Flush()
+}
+
+func writeFileAtomic(path string, contents []byte, mode os.FileMode) error {
+	writer, err := utils.NewAtomicFileWriter(path, mode)
+	if err != nil {
+		return err
+	}
+
+	_, err = writer.Write(contents)
+	if err != nil {
+		writer.Abort()
+		return fmt.Errorf("could not write %s: %w", path, err)
+	}
+
+	return writer.Commit()
+}
@@ -0,0 +1,395 @@
+package cli_test
+
+import (
+	"bytes"
+	"crypto/x509"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+
+	"github.com/greenplum-db/gpdb/gpservice/idl"
+	"github.com/greenplum-db/gpdb/gpservice/idl/mock_idl"
+	"github.com/greenplum-db/gpdb/gpservice/internal/cli"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/gpservice_config"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+	"github.com/greenplum-db/gpdb/gpservice/testutils"
+)
+
+func TestCreateCA(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	utils.System.GetHostName = func() (string, error) {
+		return "cdw", nil
+	}
+	defer utils.ResetSystemFunctions()
+
+	t.Run("creates the CA certificate and key", func(t *testing.T) {
+		certDir := filepath.Join(t.TempDir(), "certificates")
+		caDir := filepath.Join(t.TempDir(), "ca")
+
+		err := cli.CreateCA(certDir, caDir, 24*time.Hour, false)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		ca, err := utils.LoadCertificateAuthority(filepath.Join(certDir, "ca-cert.pem"), filepath.Join(caDir, "ca-key.pem"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !ca.Cert.IsCA || ca.Cert.Subject.CommonName != "gpservice CA cdw" {
+			t.Fatalf("got CA %q, want a CA for cdw", ca.Cert.Subject.CommonName)
+		}
+
+		assertFileMode(t, filepath.Join(caDir, "ca-key.pem"), 0600)
+		assertFileMode(t, caDir, 0700)
+
+		if _, err := os.Stat(filepath.Join(certDir, "ca-key.pem")); !os.IsNotExist(err) {
+			t.Fatalf("expected the CA key to not be in the certificate directory, got %v", err)
+		}
+	})
+
+	t.Run("errors out when the CA already exists", func(t *testing.T) {
+		certDir, caDir := t.TempDir(), t.TempDir()
+
+		err := cli.CreateCA(certDir, caDir, 24*time.Hour, false)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		err = cli.CreateCA(certDir, caDir, 24*time.Hour, false)
+		expected := "CA certificate " + filepath.Join(certDir, "ca-cert.pem") + " already exists"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+
+		err = cli.CreateCA(certDir, caDir, 24*time.Hour, true)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestIssueCertificates(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	utils.System.GetHostName = func() (string, error) {
+		return "cdw", nil
+	}
+	defer utils.ResetSystemFunctions()
+
+	gpservice_config.SetCopyConfigFileToAgents()
+	defer gpservice_config.ResetConfigFunctions()
+
+	t.Run("issues certificates to all the hosts and enables TLS", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		remote := testutils.SetMockRemoteExecutor(t)
+
+		certDir, caDir := t.TempDir(), t.TempDir()
+		err := cli.CreateCA(certDir, caDir, 24*time.Hour, false)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		pushed := map[string]*idl.PushFileRequest{}
+		hubClient := mock_idl.NewMockHubClient(ctrl)
+		hubClient.EXPECT().PushFile(gomock.Any(), gomock.Any()).DoAndReturn(func(_ interface{}, req *idl.PushFileRequest, _ ...interface{}) (*idl.PushFileReply, error) {
+			pushed[req.Hostnames[0]+":"+filepath.Base(req.Path)] = req
+			return &idl.PushFileReply{}, nil
+		}).Times(6)
+		gpservice_config.SetConnectToHub(hubClient)
+
+		conf := testutils.CreateDummyServiceConfig(t)
+		configFile := filepath.Join(t.TempDir(), "gpservice.conf")
+		err = cli.IssueCertificates(conf, configFile, certDir, caDir, time.Hour)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expectedCommands := []string{"mkdir -p -m 700 '" + certDir + "'"}
+		if !reflect.DeepEqual(remote.Commands, expectedCommands) {
+			t.Fatalf("got %v, want %v", remote.Commands, expectedCommands)
+		}
+
+		expectedCopies := []string{
+			filepath.Join(certDir, "server-key.pem"), filepath.Join(certDir, "client-key.pem"),
+			filepath.Join(certDir, "server-key.pem"), filepath.Join(certDir, "client-key.pem"),
+		}
+		if !reflect.DeepEqual(remote.Copies, expectedCopies) {
+			t.Fatalf("got %v, want the keys to be copied over ssh to %v", remote.Copies, expectedCopies)
+		}
+
+		ca, err := utils.LoadCertificateAuthority(filepath.Join(certDir, "ca-cert.pem"), filepath.Join(caDir, "ca-key.pem"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		roots := x509.NewCertPool()
+		roots.AddCert(ca.Cert)
+
+		for _, host := range []string{"sdw1", "sdw2"} {
+			serverCert := pushed[host+":server-cert.pem"]
+			cert, err := utils.ParseCertificatePEM(serverCert.Contents)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			_, err = cert.Verify(x509.VerifyOptions{DNSName: host, Roots: roots})
+			if err != nil {
+				t.Fatalf("expected the certificate to be valid for %s: %v", host, err)
+			}
+
+			if pushed[host+":server-key.pem"] != nil || pushed[host+":client-key.pem"] != nil {
+				t.Fatalf("expected the keys of %s to not be pushed through the hub", host)
+			}
+
+			if serverCert.Path != filepath.Join(certDir, "server-cert.pem") {
+				t.Fatalf("got %s, want the certificate to be written to %s", serverCert.Path, certDir)
+			}
+
+			issued, err := os.ReadFile(filepath.Join(certDir, "issued", host, "server-cert.pem"))
+			if err != nil || !bytes.Equal(issued, serverCert.Contents) {
+				t.Fatalf("expected a copy of the server certificate of %s to be kept: %v", host, err)
+			}
+
+			if _, err := os.Stat(filepath.Join(certDir, "issued", host, "server-key.pem")); !os.IsNotExist(err) {
+				t.Fatalf("expected the key of %s to not be kept, got %v", host, err)
+			}
+		}
+
+		for _, file := range []string{"server-cert.pem", "server-key.pem", "client-cert.pem", "client-key.pem"} {
+			if _, err := os.Stat(filepath.Join(certDir, file)); err != nil {
+				t.Fatalf("expected %s to be written on the local host: %v", file, err)
+			}
+		}
+
+		result, err := gpservice_config.Read(configFile)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expectedCreds := &utils.GpCredentials{
+			CACertPath:     filepath.Join(certDir, "ca-cert.pem"),
+			ServerCertPath: filepath.Join(certDir, "server-cert.pem"),
+			ServerKeyPath:  filepath.Join(certDir, "server-key.pem"),
+			ClientCertPath: filepath.Join(certDir, "client-cert.pem"),
+			ClientKeyPath:  filepath.Join(certDir, "client-key.pem"),
+			TlsEnabled:     true,
+		}
+		if !reflect.DeepEqual(result.Credentials, expectedCreds) {
+			t.Fatalf("got %+v, want %+v", result.Credentials, expectedCreds)
+		}
+
+		_, err = expectedCreds.LoadClientCredentials()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("errors out when the CA does not exist", func(t *testing.T) {
+		certDir := t.TempDir()
+
+		err := cli.IssueCertificates(testutils.CreateDummyServiceConfig(t), filepath.Join(t.TempDir(), "gpservice.conf"), certDir, t.TempDir(), time.Hour)
+		expected := "could not read CA certificate: open " + filepath.Join(certDir, "ca-cert.pem") + ": no such file or directory"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+}
+
+func TestListCertificates(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	utils.System.GetHostName = func() (string, error) {
+		return "cdw", nil
+	}
+	defer utils.ResetSystemFunctions()
+
+	gpservice_config.SetCopyConfigFileToAgents()
+	defer gpservice_config.ResetConfigFunctions()
+
+	t.Run("lists the CA and the issued certificates with their expiry dates", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		testutils.SetMockRemoteExecutor(t)
+
+		hubClient := mock_idl.NewMockHubClient(ctrl)
+		hubClient.EXPECT().PushFile(gomock.Any(), gomock.Any()).Return(&idl.PushFileReply{}, nil).AnyTimes()
+		gpservice_config.SetConnectToHub(hubClient)
+
+		certDir, caDir := t.TempDir(), t.TempDir()
+		err := cli.CreateCA(certDir, caDir, 10*24*time.Hour, false)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		conf := testutils.CreateDummyServiceConfig(t)
+		conf.Hostnames = []string{"sdw1"}
+		err = cli.IssueCertificates(conf, filepath.Join(t.TempDir(), "gpservice.conf"), certDir, caDir, 5*24*time.Hour)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var out bytes.Buffer
+		err = cli.ListCertificates(&out, certDir)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := regexp.MustCompile(`^HOST +TYPE +SUBJECT +EXPIRES +DAYS LEFT
+- +CA +gpservice CA cdw +\d{4}-\d{2}-\d{2} +9
+cdw +Server +cdw +\d{4}-\d{2}-\d{2} +4
+cdw +Client +cdw +\d{4}-\d{2}-\d{2} +4
+sdw1 +Server +sdw1 +\d{4}-\d{2}-\d{2} +4
+sdw1 +Client +sdw1 +\d{4}-\d{2}-\d{2} +4
+$`)
+		if !expected.MatchString(out.String()) {
+			t.Fatalf("got %s, want to match %s", out.String(), expected)
+		}
+	})
+
+	t.Run("errors out when the CA does not exist", func(t *testing.T) {
+		certDir := t.TempDir()
+
+		err := cli.ListCertificates(&bytes.Buffer{}, certDir)
+		expected := "CA certificate " + filepath.Join(certDir, "ca-cert.pem") + " does not exist"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+}
+
+func TestRotateCertificates(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	utils.System.GetHostName = func() (string, error) {
+		return "cdw", nil
+	}
+	defer utils.ResetSystemFunctions()
+
+	gpservice_config.SetCopyConfigFileToAgents()
+	defer gpservice_config.ResetConfigFunctions()
+
+	setup := func(t *testing.T, hubClient *mock_idl.MockHubClient) (*gpservice_config.Config, string, string, []byte) {
+		t.Helper()
+
+		hubClient.EXPECT().PushFile(gomock.Any(), gomock.Any()).Return(&idl.PushFileReply{}, nil).AnyTimes()
+		gpservice_config.SetConnectToHub(hubClient)
+
+		certDir, caDir := t.TempDir(), t.TempDir()
+		err := cli.CreateCA(certDir, caDir, 24*time.Hour, false)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		conf := testutils.CreateDummyServiceConfig(t)
+		conf.Hostnames = []string{"sdw1"}
+		err = cli.IssueCertificates(conf, filepath.Join(t.TempDir(), "gpservice.conf"), certDir, caDir, time.Hour)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		serverCert, err := os.ReadFile(filepath.Join(certDir, "server-cert.pem"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		return conf, certDir, caDir, serverCert
+	}
+
+	t.Run("replaces the certificates and removes the previous ones once the agents are reachable", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		hubClient := mock_idl.NewMockHubClient(ctrl)
+		remote := testutils.SetMockRemoteExecutor(t)
+		conf, certDir, caDir, previousCert := setup(t, hubClient)
+		remote.Commands = nil
+
+		hubClient.EXPECT().ReloadCredentials(gomock.Any(), gomock.Any()).Return(&idl.ReloadCredentialsReply{}, nil)
+		hubClient.EXPECT().StatusAgents(gomock.Any(), gomock.Any()).Return(&idl.StatusAgentsReply{}, nil)
+
+		err := cli.RotateCertificates(conf, certDir, caDir, time.Hour)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		serverCert, err := os.ReadFile(filepath.Join(certDir, "server-cert.pem"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if bytes.Equal(serverCert, previousCert) {
+			t.Fatalf("expected the server certificate to be replaced")
+		}
+
+		files := "server-cert.pem server-key.pem client-cert.pem client-key.pem"
+		expectedCommands := []string{
+			"cd '" + certDir + "' && for f in " + files + `; do cp -p "$f" "$f.old" || exit 1; done`,
+			"cd '" + certDir + "' && for f in " + files + `; do rm -f "$f.old" || exit 1; done`,
+		}
+		if !reflect.DeepEqual(remote.Commands, expectedCommands) {
+			t.Fatalf("got %v, want %v", remote.Commands, expectedCommands)
+		}
+	})
+
+	t.Run("restores the previous certificates when the agents are not reachable", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		hubClient := mock_idl.NewMockHubClient(ctrl)
+		remote := testutils.SetMockRemoteExecutor(t)
+		conf, certDir, caDir, previousCert := setup(t, hubClient)
+		remote.Commands = nil
+
+		hubClient.EXPECT().ReloadCredentials(gomock.Any(), gomock.Any()).Return(&idl.ReloadCredentialsReply{}, nil).Times(2)
+		hubClient.EXPECT().StatusAgents(gomock.Any(), gomock.Any()).Return(nil, errors.New("error"))
+
+		err := cli.RotateCertificates(conf, certDir, caDir, time.Hour)
+		expected := "could not rotate the certificates, the previous certificates have been restored: could not reach the agents using the new certificates: error"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+
+		serverCert, err := os.ReadFile(filepath.Join(certDir, "server-cert.pem"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !bytes.Equal(serverCert, previousCert) {
+			t.Fatalf("expected the previous server certificate to be restored")
+		}
+
+		issued, err := os.ReadFile(filepath.Join(certDir, "issued", "cdw", "server-cert.pem"))
+		if err != nil || !bytes.Equal(issued, previousCert) {
+			t.Fatalf("expected the copy of the previous server certificate to be restored: %v", err)
+		}
+
+		expectedCommand := "cd '" + certDir + "' && for f in server-cert.pem server-key.pem client-cert.pem client-key.pem; do mv -f \"$f.old\" \"$f\" || exit 1; done"
+		if len(remote.Commands) != 2 || remote.Commands[1] != expectedCommand {
+			t.Fatalf("got %v, want the previous certificates to be restored with %s", remote.Commands, expectedCommand)
+		}
+	})
+
+	t.Run("errors out when the certificates have not been issued", func(t *testing.T) {
+		certDir := t.TempDir()
+
+		err := cli.RotateCertificates(testutils.CreateDummyServiceConfig(t), certDir, t.TempDir(), time.Hour)
+		expected := "the services are not using the certificates in " + certDir
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+}
+
+func assertFileMode(t *testing.T, path string, mode os.FileMode) {
+	t.Helper()
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if info.Mode().Perm() != mode {
+		t.Fatalf("got mode %o, want %o", info.Mode().Perm(), mode)
+	}
+}
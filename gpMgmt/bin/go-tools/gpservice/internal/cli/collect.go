@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"github.com/spf13/cobra"
+
+	"github.com/greenplum-db/gpdb/gpservice/idl"
+	config "github.com/greenplum-db/gpdb/gpservice/pkg/gpservice_config"
+)
+
+func CollectCmd() *cobra.Command {
+	var hosts []string
+	var destDir string
+
+	collectCmd := &cobra.Command{
+		Use:   "collect <path>",
+		Short: "Collect a file from the hosts through the agent services",
+		Long: `Collect a file from the hosts through the agent services, such as their logs. The file
+of each host is written to a directory named after the host within the destination directory
+on the hub host. Only the service configuration and the files in the log directory of the
+services can be collected.`,
+		Args: cobra.ExactArgs(1),
+		Example: `Collect the agent log of all the hosts into the current directory
+$ gpservice collect /home/gpadmin/gpAdminLogs/gpservice_agent.log
+
+Collect it from a single host into a given directory
+$ gpservice collect /home/gpadmin/gpAdminLogs/gpservice_agent.log --host sdw1 --dest-dir /tmp/logs
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return CollectFile(serviceConfig, hosts, args[0], destDir)
+		},
+	}
+
+	collectCmd.Flags().StringArrayVar(&hosts, "host", []string{}, `Collect the file from the given host instead of all the hosts in the configuration`)
+	collectCmd.Flags().StringVar(&destDir, "dest-dir", ".", `Directory on the hub host to write the collected files to`)
+
+	return collectCmd
+}
+
+// CollectFile collects the file at the given path from the hosts, or all the hosts in the configuration when none are given
+func CollectFile(conf *config.Config, hosts []string, path, destDir string) error {
+	if len(hosts) == 0 {
+		hosts = conf.Hostnames
+	}
+
+	destDir, err := filepath.Abs(destDir)
+	if err != nil {
+		return fmt.Errorf("could not get the absolute path of %s: %w", destDir, err)
+	}
+
+	client, err := config.ConnectToHub(conf)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.CollectFile(context.Background(), &idl.CollectFileRequest{
+		Hostnames: hosts,
+		Path:      path,
+		DestDir:   destDir,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to collect %s: %w", path, err)
+	}
+
+	gplog.Info("Collected %s from hosts %v into %s", path, hosts, destDir)
+	return nil
+}
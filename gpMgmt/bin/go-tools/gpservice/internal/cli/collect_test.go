@@ -0,0 +1,79 @@
+package cli_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+
+	"github.com/greenplum-db/gpdb/gpservice/idl"
+	"github.com/greenplum-db/gpdb/gpservice/idl/mock_idl"
+	"github.com/greenplum-db/gpdb/gpservice/internal/cli"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/gpservice_config"
+	"github.com/greenplum-db/gpdb/gpservice/testutils"
+)
+
+func TestCollectFile(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	conf := testutils.CreateDummyServiceConfig(t)
+
+	t.Run("collects the file from all the hosts into the absolute destination directory", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		hubClient := mock_idl.NewMockHubClient(ctrl)
+		gpservice_config.SetConnectToHub(hubClient)
+		defer gpservice_config.ResetConfigFunctions()
+
+		cwd, err := os.Getwd()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		hubClient.EXPECT().CollectFile(gomock.Any(), &idl.CollectFileRequest{
+			Hostnames: conf.Hostnames,
+			Path:      "/var/log/gpservice.log",
+			DestDir:   filepath.Join(cwd, "logs"),
+		}).Return(&idl.CollectFileReply{}, nil)
+
+		err = cli.CollectFile(conf, nil, "/var/log/gpservice.log", "logs")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("collects the file from the given hosts only", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		hubClient := mock_idl.NewMockHubClient(ctrl)
+		gpservice_config.SetConnectToHub(hubClient)
+		defer gpservice_config.ResetConfigFunctions()
+
+		hubClient.EXPECT().CollectFile(gomock.Any(), &idl.CollectFileRequest{
+			Hostnames: []string{"sdw1"},
+			Path:      "/var/log/gpservice.log",
+			DestDir:   "/tmp/logs",
+		}).Return(&idl.CollectFileReply{}, nil)
+
+		err := cli.CollectFile(conf, []string{"sdw1"}, "/var/log/gpservice.log", "/tmp/logs")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("returns the error of the hub", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		hubClient := mock_idl.NewMockHubClient(ctrl)
+		gpservice_config.SetConnectToHub(hubClient)
+		defer gpservice_config.ResetConfigFunctions()
+
+		hubClient.EXPECT().CollectFile(gomock.Any(), gomock.Any()).Return(nil, errors.New("reading /etc/shadow is not allowed"))
+
+		err := cli.CollectFile(conf, []string{"sdw1"}, "/etc/shadow", "/tmp/logs")
+		expected := "failed to collect /etc/shadow: reading /etc/shadow is not allowed"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+}
@@ -37,6 +37,7 @@ func TestDeleteServices(t *testing.T) {
 			return nil
 		}
 		defer utils.ResetSystemFunctions()
+		testutils.SetMockRemoteExecutor(t)
 
 		_, err := testutils.ExecuteCobraCommand(t, cli.DeleteCmd(), "services")
 		if err != nil {
@@ -54,18 +55,21 @@ func TestDeleteServices(t *testing.T) {
 		}
 		defer func() { cli.StopServices = cli.StopServicesFunc }()
 
-		utils.System.ExecCommand = func(name string, args ...string) *exec.Cmd {
-			if strings.Contains(strings.Join(args, " "), "rm") {
-				return exectest.NewCommand(exectest.Failure)(name, args...)
-			}
-			return exectest.NewCommand(exectest.Success)(name, args...)
-		}
+		utils.System.ExecCommand = exectest.NewCommand(exectest.Success)
 		utils.System.Remove = func(name string) error {
 			return nil
 		}
-
 		defer utils.ResetSystemFunctions()
 
+		remote := testutils.SetMockRemoteExecutor(t)
+		remote.Result = func(hostname, command string) *utils.RemoteResult {
+			if strings.HasPrefix(command, "rm ") {
+				return &utils.RemoteResult{ExitCode: 1, Stderr: "error"}
+			}
+
+			return &utils.RemoteResult{}
+		}
+
 		_, err := testutils.ExecuteCobraCommand(t, cli.DeleteCmd())
 		expectedErrPrefix := "could not delete agent service file"
 		if !strings.HasPrefix(err.Error(), expectedErrPrefix) {
@@ -87,8 +91,8 @@ func TestDeleteServices(t *testing.T) {
 
 			return exectest.NewCommand(exectest.Success)(name, args...)
 		}
-
 		defer utils.ResetSystemFunctions()
+		testutils.SetMockRemoteExecutor(t)
 
 		_, err := testutils.ExecuteCobraCommand(t, cli.DeleteCmd())
 		expectedErrPrefix := "could not remove hub service"
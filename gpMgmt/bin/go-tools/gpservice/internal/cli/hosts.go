@@ -0,0 +1,310 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"github.com/greenplum-db/gpdb/gpservice/idl"
+	config "github.com/greenplum-db/gpdb/gpservice/pkg/gpservice_config"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+)
+
+func AddHostsCmd() *cobra.Command {
+	addHostsCmd := &cobra.Command{
+		Use:   "add-hosts",
+		Short: "Add hosts to the gpservice configuration",
+		Long: `Add hosts to the gpservice configuration. The agent service is installed on the new hosts
+and the updated configuration is copied to all the hosts. If the hub service is running,
+it starts managing the new hosts without a restart and the agents on them are started.`,
+		Args: cobra.NoArgs,
+		Example: `Add the hosts sdw3 and sdw4
+$ gpservice add-hosts --host sdw3 --host sdw4
+
+Add the hosts listed in a file
+$ gpservice add-hosts --hostfile /tmp/new_hosts
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			hosts, err := getHostnames()
+			if err != nil {
+				return err
+			}
+
+			return AddHosts(serviceConfig, configFilepath, hosts)
+		},
+	}
+
+	addHostFlags(addHostsCmd)
+
+	return addHostsCmd
+}
+
+func RemoveHostsCmd() *cobra.Command {
+	removeHostsCmd := &cobra.Command{
+		Use:   "remove-hosts",
+		Short: "Remove hosts from the gpservice configuration",
+		Long: `Remove hosts from the gpservice configuration. The agent service is stopped and removed
+from the hosts along with the configuration file, and the updated configuration is copied
+to the remaining hosts. Make sure the hosts are no longer part of the database cluster
+before removing them.`,
+		Args: cobra.NoArgs,
+		Example: `Remove the host sdw4
+$ gpservice remove-hosts --host sdw4
+`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			hosts, err := getHostnames()
+			if err != nil {
+				return err
+			}
+
+			return RemoveHosts(serviceConfig, configFilepath, hosts)
+		},
+	}
+
+	addHostFlags(removeHostsCmd)
+
+	return removeHostsCmd
+}
+
+func addHostFlags(cmd *cobra.Command) {
+	cmd.Flags().StringArrayVar(&hostnames, "host", []string{}, `Segment hostname`)
+	cmd.Flags().StringVar(&hostfilePath, "hostfile", "", `Path to file containing a list of segment hostnames`)
+
+	cmd.MarkFlagsMutuallyExclusive("host", "hostfile")
+	cmd.MarkFlagsOneRequired("host", "hostfile")
+}
+
+/*
+AddHosts installs the agent service on the given hosts and adds them to the service
+configuration. The configuration is written before installing the agent service, since
+the agents read it on startup. A running hub is updated with the new host set and the
+agents on the new hosts are started. The hosts are removed from the configuration again
+if any of the steps after writing it fail.
+*/
+func AddHosts(conf *config.Config, configFilepath string, hosts []string) (err error) {
+	for _, host := range hosts {
+		if slices.Contains(conf.Hostnames, host) {
+			return fmt.Errorf("host %s is already part of the gpservice configuration", host)
+		}
+	}
+
+	err = platform.CreateServiceDir(hosts, conf.GpHome)
+	if err != nil {
+		return err
+	}
+
+	previous := slices.Clone(conf.Hostnames)
+	conf.Hostnames = append(conf.Hostnames, hosts...)
+
+	var updated bool
+	defer func() {
+		if err != nil {
+			revertAddHosts(conf, configFilepath, hosts, previous, updated)
+		}
+	}()
+
+	err = conf.Write(configFilepath)
+	if err != nil {
+		return err
+	}
+
+	err = platform.CreateAndInstallAgentServiceFile(hosts, conf.GpHome, conf.ServiceName, configFilepath)
+	if err != nil {
+		return err
+	}
+
+	err = platform.EnableUserLingering(hosts, conf.GpHome)
+	if err != nil {
+		return err
+	}
+
+	CheckOpenFilesLimitOnHosts(hosts)
+
+	updated, err = updateHubHosts(conf)
+	if err != nil {
+		return err
+	}
+
+	if !updated {
+		gplog.Info("Successfully added hosts %s. Start the agents on them using the 'gpservice start' command", strings.Join(hosts, ", "))
+		return nil
+	}
+
+	err = startAgentService(conf)
+	if err != nil {
+		return err
+	}
+
+	gplog.Info("Successfully added hosts %s", strings.Join(hosts, ", "))
+	return nil
+}
+
+// revertAddHosts restores the previous hosts of the configuration and removes what was set
+// up on the added hosts. It is best effort, so failures are only logged.
+func revertAddHosts(conf *config.Config, configFilepath string, hosts, previous []string, hubUpdated bool) {
+	gplog.Info("Removing hosts %s from the gpservice configuration", strings.Join(hosts, ", "))
+	conf.Hostnames = previous
+
+	if hubUpdated {
+		if _, err := updateHubHosts(conf); err != nil {
+			gplog.Warn("%v", err)
+		}
+	}
+
+	if err := platform.RemoveAgentService(conf.GpHome, conf.ServiceName, hosts); err != nil {
+		gplog.Warn("%v", err)
+	}
+
+	if err := platform.RemoveAgentServiceFile(conf.GpHome, conf.ServiceName, hosts); err != nil {
+		gplog.Warn("%v", err)
+	}
+
+	if err := conf.Write(configFilepath); err != nil {
+		gplog.Warn("%v", err)
+	}
+
+	removedConf := &config.Config{Hostnames: hosts, GpHome: conf.GpHome}
+	if err := removedConf.Remove(configFilepath); err != nil {
+		gplog.Warn("%v", err)
+	}
+}
+
+// revertRemoveHosts restores the removed hosts in the configuration and sets up the agent
+// service on them again. It is best effort, so failures are only logged.
+func revertRemoveHosts(conf *config.Config, configFilepath string, hosts, previous []string, hubUpdated, servicesRemoved bool) {
+	gplog.Info("Adding hosts %s back to the gpservice configuration", strings.Join(hosts, ", "))
+	conf.Hostnames = previous
+
+	if err := conf.Write(configFilepath); err != nil {
+		gplog.Warn("%v", err)
+	}
+
+	if servicesRemoved {
+		if err := platform.CreateAndInstallAgentServiceFile(hosts, conf.GpHome, conf.ServiceName, configFilepath); err != nil {
+			gplog.Warn("%v", err)
+		}
+	}
+
+	if !hubUpdated {
+		return
+	}
+
+	if _, err := updateHubHosts(conf); err != nil {
+		gplog.Warn("%v", err)
+		return
+	}
+
+	if servicesRemoved {
+		if err := startAgentService(conf); err != nil {
+			gplog.Warn("%v", err)
+		}
+	}
+}
+
+/*
+RemoveHosts stops and removes the agent service on the given hosts and removes them from
+the service configuration. A running hub is updated with the new host set first, so that
+it no longer reaches out to the agents which are being removed. The hosts are added back
+if any of the steps up to writing the configuration fail.
+*/
+func RemoveHosts(conf *config.Config, configFilepath string, hosts []string) (err error) {
+	hubHost := conf.HubHost
+	if hubHost == "" {
+		hostname, err := utils.System.GetHostName()
+		if err != nil {
+			return fmt.Errorf("could not get the hostname: %w", err)
+		}
+		hubHost = hostname
+	}
+
+	var remaining []string
+	for _, host := range conf.Hostnames {
+		if !slices.Contains(hosts, host) {
+			remaining = append(remaining, host)
+		}
+	}
+
+	for _, host := range hosts {
+		if !slices.Contains(conf.Hostnames, host) {
+			return fmt.Errorf("host %s is not part of the gpservice configuration", host)
+		}
+
+		if host == hubHost {
+			return fmt.Errorf("cannot remove host %s since the hub service runs on it", host)
+		}
+	}
+
+	if len(remaining) == 0 {
+		return fmt.Errorf("cannot remove all the hosts from the gpservice configuration, use the 'gpservice delete' command instead")
+	}
+
+	previous := slices.Clone(conf.Hostnames)
+	conf.Hostnames = remaining
+
+	var hubUpdated, servicesRemoved, committed bool
+	defer func() {
+		if err != nil && !committed {
+			revertRemoveHosts(conf, configFilepath, hosts, previous, hubUpdated, servicesRemoved)
+		}
+	}()
+
+	hubUpdated, err = updateHubHosts(conf)
+	if err != nil {
+		return err
+	}
+
+	servicesRemoved = true
+	err = platform.RemoveAgentService(conf.GpHome, conf.ServiceName, hosts)
+	if err != nil {
+		return err
+	}
+
+	err = platform.RemoveAgentServiceFile(conf.GpHome, conf.ServiceName, hosts)
+	if err != nil {
+		return err
+	}
+
+	err = conf.Write(configFilepath)
+	if err != nil {
+		return err
+	}
+
+	// The hosts are no longer part of the configuration, so only the leftover
+	// configuration file on them remains to be removed
+	committed = true
+
+	removedConf := &config.Config{Hostnames: hosts, GpHome: conf.GpHome}
+	err = removedConf.Remove(configFilepath)
+	if err != nil {
+		return err
+	}
+
+	gplog.Info("Successfully removed hosts %s", strings.Join(hosts, ", "))
+	return nil
+}
+
+// updateHubHosts updates the running hub with the hosts of the given configuration.
+// It reports whether the hub was running, as the hub picks up the hosts from the
+// configuration file the next time it starts otherwise.
+func updateHubHosts(conf *config.Config) (bool, error) {
+	client, err := config.ConnectToHub(conf)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = client.UpdateHosts(context.Background(), &idl.UpdateHostsRequest{Hostnames: conf.Hostnames})
+	if err != nil {
+		if utils.IsGrpcServerUnavailableErr(err) {
+			gplog.Info("Hub service is not running, the updated hosts will be used once it is started")
+			return false, nil
+		}
+
+		return false, fmt.Errorf("failed to update the hosts of the hub service: %w", err)
+	}
+
+	return true, nil
+}
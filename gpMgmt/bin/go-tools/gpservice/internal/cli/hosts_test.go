@@ -0,0 +1,252 @@
+package cli_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/greenplum-db/gpdb/gpservice/idl"
+	"github.com/greenplum-db/gpdb/gpservice/idl/mock_idl"
+	"github.com/greenplum-db/gpdb/gpservice/internal/cli"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/gpservice_config"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+	"github.com/greenplum-db/gpdb/gpservice/testutils"
+	"github.com/greenplum-db/gpdb/gpservice/testutils/exectest"
+)
+
+func TestAddHosts(t *testing.T) {
+	utils.System.ExecCommand = exectest.NewCommand(exectest.Success)
+	defer utils.ResetSystemFunctions()
+
+	gpservice_config.SetCopyConfigFileToAgents()
+	defer gpservice_config.ResetConfigFunctions()
+
+	t.Run("adds the hosts and starts the agents on them", func(t *testing.T) {
+		_, _, logfile := testhelper.SetupTestLogger()
+		testutils.SetMockRemoteExecutor(t)
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		conf := testutils.CreateDummyServiceConfig(t)
+		configFile := filepath.Join(t.TempDir(), "gpservice.conf")
+
+		hubClient := mock_idl.NewMockHubClient(ctrl)
+		hubClient.EXPECT().UpdateHosts(gomock.Any(), &idl.UpdateHostsRequest{Hostnames: []string{"sdw1", "sdw2", "sdw3"}}).Return(&idl.UpdateHostsReply{}, nil)
+		hubClient.EXPECT().StartAgents(gomock.Any(), gomock.Any()).Return(&idl.StartAgentsReply{}, nil)
+		gpservice_config.SetConnectToHub(hubClient)
+
+		err := cli.AddHosts(conf, configFile, []string{"sdw3"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		result, err := gpservice_config.Read(configFile)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expectedHosts := []string{"sdw1", "sdw2", "sdw3"}
+		if !reflect.DeepEqual(result.Hostnames, expectedHosts) {
+			t.Fatalf("got %v, want %v", result.Hostnames, expectedHosts)
+		}
+
+		testutils.AssertLogMessage(t, logfile, `\[INFO\]:-Successfully added hosts sdw3`)
+	})
+
+	t.Run("does not start the agents when the hub is not running", func(t *testing.T) {
+		_, _, logfile := testhelper.SetupTestLogger()
+		testutils.SetMockRemoteExecutor(t)
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		hubClient := mock_idl.NewMockHubClient(ctrl)
+		hubClient.EXPECT().UpdateHosts(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.Unavailable, "unavailable"))
+		gpservice_config.SetConnectToHub(hubClient)
+
+		err := cli.AddHosts(testutils.CreateDummyServiceConfig(t), filepath.Join(t.TempDir(), "gpservice.conf"), []string{"sdw3"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		testutils.AssertLogMessage(t, logfile, `\[INFO\]:-Successfully added hosts sdw3. Start the agents on them using the 'gpservice start' command`)
+	})
+
+	t.Run("removes the hosts from the configuration when the agents fail to start", func(t *testing.T) {
+		testhelper.SetupTestLogger()
+		remote := testutils.SetMockRemoteExecutor(t)
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		conf := testutils.CreateDummyServiceConfig(t)
+		configFile := filepath.Join(t.TempDir(), "gpservice.conf")
+
+		hubClient := mock_idl.NewMockHubClient(ctrl)
+		hubClient.EXPECT().UpdateHosts(gomock.Any(), &idl.UpdateHostsRequest{Hostnames: []string{"sdw1", "sdw2", "sdw3"}}).Return(&idl.UpdateHostsReply{}, nil)
+		hubClient.EXPECT().StartAgents(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("error"))
+		hubClient.EXPECT().UpdateHosts(gomock.Any(), &idl.UpdateHostsRequest{Hostnames: []string{"sdw1", "sdw2"}}).Return(&idl.UpdateHostsReply{}, nil)
+		gpservice_config.SetConnectToHub(hubClient)
+
+		err := cli.AddHosts(conf, configFile, []string{"sdw3"})
+		if err == nil {
+			t.Fatalf("expected an error")
+		}
+
+		result, err := gpservice_config.Read(configFile)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expectedHosts := []string{"sdw1", "sdw2"}
+		if !reflect.DeepEqual(result.Hostnames, expectedHosts) {
+			t.Fatalf("got %v, want %v", result.Hostnames, expectedHosts)
+		}
+
+		expectedCommand := fmt.Sprintf("rm %s", configFile)
+		if !slices.Contains(remote.Commands, expectedCommand) {
+			t.Fatalf("got %v, want %s to be run", remote.Commands, expectedCommand)
+		}
+	})
+
+	t.Run("errors out when the host is already configured", func(t *testing.T) {
+		err := cli.AddHosts(testutils.CreateDummyServiceConfig(t), filepath.Join(t.TempDir(), "gpservice.conf"), []string{"sdw3", "sdw2"})
+		expected := "host sdw2 is already part of the gpservice configuration"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+}
+
+func TestRemoveHosts(t *testing.T) {
+	utils.System.ExecCommand = exectest.NewCommand(exectest.Success)
+	defer utils.ResetSystemFunctions()
+
+	gpservice_config.SetCopyConfigFileToAgents()
+	defer gpservice_config.ResetConfigFunctions()
+
+	t.Run("removes the hosts after updating the hub", func(t *testing.T) {
+		_, _, logfile := testhelper.SetupTestLogger()
+		remote := testutils.SetMockRemoteExecutor(t)
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		conf := testutils.CreateDummyServiceConfig(t)
+		conf.HubHost = "cdw"
+		configFile := filepath.Join(t.TempDir(), "gpservice.conf")
+
+		hubClient := mock_idl.NewMockHubClient(ctrl)
+		hubClient.EXPECT().UpdateHosts(gomock.Any(), &idl.UpdateHostsRequest{Hostnames: []string{"sdw1"}}).Return(&idl.UpdateHostsReply{}, nil)
+		gpservice_config.SetConnectToHub(hubClient)
+
+		err := cli.RemoveHosts(conf, configFile, []string{"sdw2"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		result, err := gpservice_config.Read(configFile)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expectedHosts := []string{"sdw1"}
+		if !reflect.DeepEqual(result.Hostnames, expectedHosts) {
+			t.Fatalf("got %v, want %v", result.Hostnames, expectedHosts)
+		}
+
+		expectedCommand := fmt.Sprintf("rm %s", configFile)
+		if !slices.Contains(remote.Commands, expectedCommand) {
+			t.Fatalf("got %v, want %s to be run", remote.Commands, expectedCommand)
+		}
+
+		testutils.AssertLogMessage(t, logfile, `\[INFO\]:-Successfully removed hosts sdw2`)
+	})
+
+	t.Run("adds the hosts back when removing the agent service file fails", func(t *testing.T) {
+		testhelper.SetupTestLogger()
+		remote := testutils.SetMockRemoteExecutor(t)
+		remote.Result = func(hostname, command string) *utils.RemoteResult {
+			if strings.HasPrefix(command, "rm ") {
+				return &utils.RemoteResult{Err: fmt.Errorf("error")}
+			}
+
+			return &utils.RemoteResult{}
+		}
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		conf := testutils.CreateDummyServiceConfig(t)
+		conf.HubHost = "cdw"
+		configFile := filepath.Join(t.TempDir(), "gpservice.conf")
+
+		hubClient := mock_idl.NewMockHubClient(ctrl)
+		hubClient.EXPECT().UpdateHosts(gomock.Any(), &idl.UpdateHostsRequest{Hostnames: []string{"sdw1"}}).Return(&idl.UpdateHostsReply{}, nil)
+		hubClient.EXPECT().UpdateHosts(gomock.Any(), &idl.UpdateHostsRequest{Hostnames: []string{"sdw1", "sdw2"}}).Return(&idl.UpdateHostsReply{}, nil)
+		hubClient.EXPECT().StartAgents(gomock.Any(), gomock.Any()).Return(&idl.StartAgentsReply{}, nil)
+		gpservice_config.SetConnectToHub(hubClient)
+
+		err := cli.RemoveHosts(conf, configFile, []string{"sdw2"})
+		expected := "could not delete agent service file"
+		if err == nil || !strings.HasPrefix(err.Error(), expected) {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+
+		result, err := gpservice_config.Read(configFile)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expectedHosts := []string{"sdw1", "sdw2"}
+		if !reflect.DeepEqual(result.Hostnames, expectedHosts) {
+			t.Fatalf("got %v, want %v", result.Hostnames, expectedHosts)
+		}
+
+		if len(remote.Copies) == 0 {
+			t.Fatalf("expected the agent service file to be installed again")
+		}
+	})
+
+	cases := []struct {
+		name     string
+		hubHost  string
+		hosts    []string
+		expected string
+	}{
+		{
+			name:     "errors out when the host is not configured",
+			hubHost:  "cdw",
+			hosts:    []string{"sdw3"},
+			expected: "host sdw3 is not part of the gpservice configuration",
+		},
+		{
+			name:     "errors out when removing the hub host",
+			hubHost:  "sdw1",
+			hosts:    []string{"sdw1"},
+			expected: "cannot remove host sdw1 since the hub service runs on it",
+		},
+		{
+			name:     "errors out when removing all the hosts",
+			hubHost:  "cdw",
+			hosts:    []string{"sdw1", "sdw2"},
+			expected: "cannot remove all the hosts from the gpservice configuration",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			conf := testutils.CreateDummyServiceConfig(t)
+			conf.HubHost = tc.hubHost
+
+			err := cli.RemoveHosts(conf, filepath.Join(t.TempDir(), "gpservice.conf"), tc.hosts)
+			if err == nil || !strings.HasPrefix(err.Error(), tc.expected) {
+				t.Fatalf("got %v, want %s", err, tc.expected)
+			}
+		})
+	}
+}
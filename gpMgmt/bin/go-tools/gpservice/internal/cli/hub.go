@@ -8,25 +8,40 @@ import (
 )
 
 func HubCmd() *cobra.Command {
+	var supervise, daemon bool
+
 	hubCmd := &cobra.Command{
 		Use:    "hub",
 		Short:  "Start gpservice as an agent process",
 		Long:   "Start gpservice as an agent process",
 		Hidden: true, // Should not be invoked by the user
 		Run: func(cmd *cobra.Command, args []string) {
-			err := runHubCmd()
+			err := runHubCmd(supervise, daemon)
 			if err != nil {
-				utils.LogErrorAndExit(err, 1)
+				logErrorAndExit(cmd, err)
 			}
 		},
 	}
 
+	hubCmd.Flags().BoolVar(&supervise, "supervise", false, "Run the hub under the gpservice supervisor, which restarts it when it crashes")
+	hubCmd.Flags().BoolVar(&daemon, "daemon", false, "Run the gpservice supervisor of the hub in the background")
+
 	return hubCmd
 }
 
-func runHubCmd() error {
+func runHubCmd(supervise, daemon bool) error {
+	if supervise || daemon {
+		return runSupervisor("hub", daemon)
+	}
+
+	shutdownTracing, err := utils.SetupTracing(serviceConfig.Tracing, "gpservice-hub", serviceConfig.LogDir)
+	if err != nil {
+		return err
+	}
+	defer shutdownTracing()
+
 	h := hub.New(serviceConfig)
-	err := h.Start()
+	err = h.Start()
 	if err != nil {
 		return err
 	}
@@ -31,9 +31,15 @@ var (
 	hostfilePath   string
 	serverCertPath string
 	serverKeyPath  string
+	clientCertPath string
+	clientKeyPath  string
+	crlPath        string
+	minTLSVersion  string
 	serviceName    string
 	noTlsFlag      bool
 
+	noServiceManager bool
+
 	GetUlimitSsh = GetUlimitSshFn
 )
 
@@ -54,7 +60,7 @@ func InitCmd() *cobra.Command {
 		Run: func(cmd *cobra.Command, args []string) {
 			err := RunConfigure(cmd)
 			if err != nil {
-				utils.LogErrorAndExit(err, 1)
+				logErrorAndExit(cmd, err)
 			}
 		},
 	}
@@ -71,51 +77,63 @@ func InitCmd() *cobra.Command {
 	initCmd.Flags().StringVar(&caCertPath, "ca-certificate", "", `Path to SSL/TLS CA certificate`)
 	initCmd.Flags().StringVar(&serverCertPath, "server-certificate", "", `Path to hub SSL/TLS server certificate`)
 	initCmd.Flags().StringVar(&serverKeyPath, "server-key", "", `Path to hub SSL/TLS server private key`)
+	initCmd.Flags().StringVar(&clientCertPath, "client-certificate", "", `Path to SSL/TLS client certificate, defaults to the server certificate`)
+	initCmd.Flags().StringVar(&clientKeyPath, "client-key", "", `Path to SSL/TLS client private key, defaults to the server private key`)
+	initCmd.Flags().StringVar(&crlPath, "crl", "", `Path to the certificate revocation list issued by the CA`)
+	initCmd.Flags().StringVar(&minTLSVersion, "tls-min-version", utils.DefaultMinTLSVersion, `Minimum TLS version to accept, either 1.2 or 1.3`)
 	initCmd.Flags().StringArrayVar(&hostnames, "host", []string{}, `Segment hostname`)
 	initCmd.Flags().StringVar(&hostfilePath, "hostfile", "", `Path to file containing a list of segment hostnames`)
 	initCmd.Flags().BoolVar(&noTlsFlag, "no-tls", false, "Set this flag if need to run hub and agents without transport layer security (TLS)")
+	initCmd.Flags().BoolVar(&noServiceManager, "no-service-manager", false, "Run hub and agents as supervised processes instead of systemd or launchd services, for hosts such as containers")
 
 	initCmd.MarkFlagsMutuallyExclusive("host", "hostfile")
 	initCmd.MarkFlagsOneRequired("host", "hostfile")
 	initCmd.MarkFlagsMutuallyExclusive("no-tls", "ca-certificate")
 	initCmd.MarkFlagsMutuallyExclusive("no-tls", "server-certificate")
 	initCmd.MarkFlagsMutuallyExclusive("no-tls", "server-key")
+	initCmd.MarkFlagsMutuallyExclusive("no-tls", "client-certificate")
+	initCmd.MarkFlagsMutuallyExclusive("no-tls", "client-key")
+	initCmd.MarkFlagsMutuallyExclusive("no-tls", "crl")
+	initCmd.MarkFlagsMutuallyExclusive("no-tls", "tls-min-version")
 	initCmd.MarkFlagsRequiredTogether("ca-certificate", "server-certificate", "server-key")
+	initCmd.MarkFlagsRequiredTogether("client-certificate", "client-key")
 
 	gpHome = v.GetString("gphome")
 
 	return initCmd
 }
 
+/*
+InitGpService writes the service configuration and installs the services on all the hosts.
+TLS is disabled unless credentials.TlsEnabled is set, in which case the certificates need
+not exist yet, but must be in place before the services are started. With noServiceManager
+the services are run by the gpservice supervisor rather than installed as services.
+*/
 func InitGpService(configFilepath string, hubPort, agentPort int, hostnames []string, hubLogDir, serviceName,
-	gpHome, caCertPath, serverCertPath, serverKeyPath string, noTlsFlag, defaultConfig bool) error {
-
-	credentials := &utils.GpCredentials{}
+	gpHome string, credentials *utils.GpCredentials, defaultConfig, noServiceManager bool) error {
 
-	if !noTlsFlag {
-		credentials = &utils.GpCredentials{
-			CACertPath:     caCertPath,
-			ServerCertPath: serverCertPath,
-			ServerKeyPath:  serverKeyPath,
-			TlsEnabled:     true,
-		}
-		if _, err := os.Stat(caCertPath); errors.Is(err, os.ErrNotExist) {
-			gplog.Warn("ca-certificate file %s does not exists. Please make sure file exists before starting services", caCertPath)
-		}
-		if _, err := os.Stat(serverCertPath); errors.Is(err, os.ErrNotExist) {
-			gplog.Warn("server-certificate file %s does not exists. Please make sure file exists before starting services", serverCertPath)
+	if credentials.TlsEnabled {
+		_, err := utils.ParseTLSVersion(credentials.MinTLSVersion)
+		if err != nil {
+			return err
 		}
-		if _, err := os.Stat(serverKeyPath); errors.Is(err, os.ErrNotExist) {
-			gplog.Warn("server-key file %s does not exists. Please make sure file exists before starting services", serverKeyPath)
+
+		for _, path := range credentials.Paths() {
+			if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+				gplog.Warn("file %s does not exist. Please make sure the file exists before starting services", path)
+			}
 		}
-	} else {
-		credentials.TlsEnabled = false
 	}
-	err := config.Create(configFilepath, hubPort, agentPort, hostnames, hubLogDir, serviceName, gpHome, credentials, false)
+
+	err := config.Create(configFilepath, hubPort, agentPort, hostnames, hubLogDir, serviceName, gpHome, credentials, false, noServiceManager)
 	if err != nil {
 		return err
 	}
 
+	if noServiceManager {
+		useSupervisor(gpHome, configFilepath)
+	}
+
 	err = platform.CreateServiceDir(hostnames, gpHome)
 	if err != nil {
 		return err
@@ -171,17 +189,30 @@ func RunConfigure(cmd *cobra.Command) error {
 	}
 
 
+	credentials := &utils.GpCredentials{}
+	if !noTlsFlag {
+		credentials = &utils.GpCredentials{
+			CACertPath:     caCertPath,
+			ServerCertPath: serverCertPath,
+			ServerKeyPath:  serverKeyPath,
+			ClientCertPath: clientCertPath,
+			ClientKeyPath:  clientKeyPath,
+			CRLPath:        crlPath,
+			MinTLSVersion:  minTLSVersion,
+			TlsEnabled:     true,
+		}
+	}
+
 	err = InitGpService(configFilepath, hubPort, agentPort, hostnames, hubLogDir, serviceName,
-		gpHome, caCertPath, serverCertPath, serverKeyPath, noTlsFlag, false)
+		gpHome, credentials, false, noServiceManager)
 
-	return nil
+	return err
 }
 
 /*
 CheckOpenFilesLimitOnHosts checks for open files limit by calling ulimit command
-Executes gpssh command to get the ulimit from remote hosts using go routine
+Executes the command over SSH to get the ulimit from remote hosts using go routine
 Prints a warning if ulimit is lower.
-This function depends on gpssh. Use only in the configure command.
 */
 func CheckOpenFilesLimitOnHosts(hostnames []string) {
 	// check Ulimit on local host
@@ -214,26 +245,17 @@ func CheckOpenFilesLimitOnHosts(hostnames []string) {
 }
 func GetUlimitSshFn(hostname string, channel chan Response, wg *sync.WaitGroup) {
 	defer wg.Done()
-	cmd := utils.System.ExecCommand(filepath.Join(gpHome, "bin", constants.GpSSH), "-h", hostname, "-e", "ulimit -n")
-	out, err := cmd.CombinedOutput()
+	result := utils.Remote.Run([]string{hostname}, "ulimit -n")[0]
+	err := result.Error()
 	if err != nil {
 		gplog.Warn("error executing command to fetch open files limit on host:%s, %v", hostname, err)
 		return
 	}
 
-	lines := strings.Split(string(out), "\n")
-	if len(lines) < 2 {
-		gplog.Warn("unexpected output when fetching open files limit on host:%s, gpssh output:%s", hostname, lines)
-		return
-	}
-	values := strings.Split(lines[1], " ")
-	if len(values) < 2 {
-		gplog.Warn("unexpected output when parsing open files limit output for host:%s, gpssh output:%s", hostname, lines)
-		return
-	}
-	ulimit, err := strconv.Atoi(values[1])
+	value := strings.TrimSpace(result.Stdout)
+	ulimit, err := strconv.Atoi(value)
 	if err != nil {
-		gplog.Warn("unexpected output when converting open files limit value for host:%s, value:%s", hostname, values[1])
+		gplog.Warn("unexpected output when converting open files limit value for host:%s, value:%s", hostname, value)
 		return
 	}
 	channel <- Response{Hostname: hostname, Ulimit: ulimit}
@@ -246,6 +268,14 @@ type Response struct {
 
 func resolveAbsolutePaths() error {
 	paths := []*string{&caCertPath, &serverCertPath, &serverKeyPath, &hubLogDir, &gpHome}
+
+	// The optional paths are left empty when not given
+	for _, path := range []*string{&clientCertPath, &clientKeyPath, &crlPath} {
+		if *path != "" {
+			paths = append(paths, path)
+		}
+	}
+
 	for _, path := range paths {
 		p, err := filepath.Abs(*path)
 		if err != nil {
@@ -3,6 +3,7 @@ package cli_test
 import (
 	"fmt"
 	"os"
+	"reflect"
 	"sync"
 	"testing"
 
@@ -15,26 +16,6 @@ import (
 	"github.com/greenplum-db/gpdb/gpservice/testutils/exectest"
 )
 
-func init() {
-	exectest.RegisterMains(ValidGpsshOpt, InValidGpsshOpt, InValidGpsshOpt2, InValidGpsshOpt3)
-}
-
-func ValidGpsshOpt() {
-	os.Stdout.WriteString("test output\nTEST 1234")
-}
-
-func InValidGpsshOpt() {
-	os.Stdout.WriteString("test output")
-}
-
-func InValidGpsshOpt2() {
-	os.Stdout.WriteString("test output\nTEST")
-}
-
-func InValidGpsshOpt3() {
-	os.Stdout.WriteString("test output\nTEST TEST")
-}
-
 func TestMain(m *testing.M) {
 	os.Exit(exectest.Run(m))
 }
@@ -100,67 +81,6 @@ func TestInitCmd(t *testing.T) {
 	})
 }
 
-func TestGetUlimitSshFn(t *testing.T) {
-	_, _, logile := testhelper.SetupTestLogger()
-	t.Run("logs error when gpssh command execution fails", func(t *testing.T) {
-		testStr := "error executing command to fetch open files limit on host"
-		defer utils.ResetSystemFunctions()
-		utils.System.ExecCommand = exectest.NewCommand(exectest.Failure)
-		var wg sync.WaitGroup
-		wg.Add(1)
-		channel := make(chan cli.Response)
-		cli.GetUlimitSshFn("sdw1", channel, &wg)
-		testutils.AssertLogMessage(t, logile, testStr)
-	})
-	t.Run("logs error when gpssh output has fewer lines", func(t *testing.T) {
-		testStr := "unexpected output when fetching open files limit on host"
-		defer utils.ResetSystemFunctions()
-		utils.System.ExecCommand = exectest.NewCommand(InValidGpsshOpt)
-		var wg sync.WaitGroup
-		wg.Add(1)
-		channel := make(chan cli.Response)
-		cli.GetUlimitSshFn("sdw1", channel, &wg)
-		testutils.AssertLogMessage(t, logile, testStr)
-	})
-	t.Run("logs error when gpssh output has unexpected format", func(t *testing.T) {
-		testStr := "unexpected output when parsing open files limit output for host"
-		defer utils.ResetSystemFunctions()
-		utils.System.ExecCommand = exectest.NewCommand(InValidGpsshOpt2)
-		var wg sync.WaitGroup
-		wg.Add(1)
-		channel := make(chan cli.Response)
-		cli.GetUlimitSshFn("sdw1", channel, &wg)
-		testutils.AssertLogMessage(t, logile, testStr)
-	})
-	t.Run("logs error when gpssh output fails to convert to integer", func(t *testing.T) {
-		testStr := "unexpected output when converting open files limit value for host"
-		defer utils.ResetSystemFunctions()
-		utils.System.ExecCommand = exectest.NewCommand(InValidGpsshOpt3)
-		var wg sync.WaitGroup
-		wg.Add(1)
-		channel := make(chan cli.Response)
-		cli.GetUlimitSshFn("sdw1", channel, &wg)
-		testutils.AssertLogMessage(t, logile, testStr)
-	})
-	t.Run("logs error when gpssh output fails to convert to integer", func(t *testing.T) {
-		defer utils.ResetSystemFunctions()
-		utils.System.ExecCommand = exectest.NewCommand(ValidGpsshOpt)
-		var wg sync.WaitGroup
-		wg.Add(1)
-		channel := make(chan cli.Response)
-		go cli.GetUlimitSshFn("sdw1", channel, &wg)
-		go func() {
-			wg.Wait()
-			close(channel)
-		}()
-
-		for result := range channel {
-			if result.Ulimit != 1234 {
-				t.Fatalf("got ulimit:%d, expected:1234", result.Ulimit)
-			}
-		}
-	})
-}
 func TestCheckOpenFilesLimitOnHosts(t *testing.T) {
 	_, _, logile := testhelper.SetupTestLogger()
 	t.Run("prints warning if fails to execute Ulimit command", func(t *testing.T) {
@@ -196,3 +116,45 @@ func TestCheckOpenFilesLimitOnHosts(t *testing.T) {
 		testutils.AssertLogMessage(t, logile, testStr)
 	})
 }
+
+func TestGetUlimitSshFn(t *testing.T) {
+	_, _, logfile := testhelper.SetupTestLogger()
+
+	t.Run("gets the open files limit of the host", func(t *testing.T) {
+		remote := testutils.SetMockRemoteExecutor(t)
+		remote.Result = func(hostname, command string) *utils.RemoteResult {
+			return &utils.RemoteResult{Stdout: "1024\n"}
+		}
+
+		var wg sync.WaitGroup
+		channel := make(chan cli.Response, 1)
+		wg.Add(1)
+		cli.GetUlimitSshFn("sdw1", channel, &wg)
+
+		expected := cli.Response{Hostname: "sdw1", Ulimit: 1024}
+		if result := <-channel; result != expected {
+			t.Fatalf("got %+v, want %+v", result, expected)
+		}
+
+		if !reflect.DeepEqual(remote.Commands, []string{"ulimit -n"}) {
+			t.Fatalf("got %v, want [ulimit -n]", remote.Commands)
+		}
+	})
+
+	t.Run("prints a warning when not able to get the open files limit", func(t *testing.T) {
+		remote := testutils.SetMockRemoteExecutor(t)
+		remote.Result = func(hostname, command string) *utils.RemoteResult {
+			return &utils.RemoteResult{ExitCode: 255, Stderr: "permission denied"}
+		}
+
+		var wg sync.WaitGroup
+		channel := make(chan cli.Response, 1)
+		wg.Add(1)
+		cli.GetUlimitSshFn("sdw1", channel, &wg)
+
+		if len(channel) != 0 {
+			t.Fatalf("expected no open files limit to be returned")
+		}
+		testutils.AssertLogMessage(t, logfile, `\[WARNING\]:-error executing command to fetch open files limit on host:sdw1, host sdw1: exit status 255: permission denied`)
+	})
+}
@@ -0,0 +1,135 @@
+package cli
+
+import (
+	"fmt"
+	"net"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"github.com/greenplum-db/gpdb/gpservice/constants"
+	config "github.com/greenplum-db/gpdb/gpservice/pkg/gpservice_config"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+)
+
+const restartHint = "Restart the services using the 'gpservice stop' and 'gpservice start' commands for the change to take effect"
+
+func MetricsCmd() *cobra.Command {
+	metricsCmd := &cobra.Command{
+		Use:   "metrics",
+		Short: "Manage the Prometheus metrics of the hub and agent services",
+		Long: `Manage the Prometheus metrics of the hub and agent services, which are served over HTTP
+on the /metrics path of the configured ports. Both services report the calls made to them and
+the state of their host. The hub also reports the health of the agents, the duration of the
+steps of creating the cluster and, given the coordinator data directory, the state of each
+segment as recorded in gp_segment_configuration. The metrics are only served on the loopback
+address unless another bind address is given, and can be served over HTTPS to the scrapers
+presenting a client certificate issued by the CA of the services.`,
+		Example: `Serve the metrics of the hub on port 9190 and of the agents on port 9191
+$ gpservice metrics enable --hub-port 9190 --agent-port 9191
+
+Serve them over HTTPS on all the interfaces of the hosts
+$ gpservice metrics enable --hub-port 9190 --agent-port 9191 --bind-address 0.0.0.0 --tls
+`,
+	}
+
+	metricsCmd.AddCommand(
+		enableMetricsCmd(),
+		disableMetricsCmd(),
+	)
+
+	return metricsCmd
+}
+
+func enableMetricsCmd() *cobra.Command {
+	metricsConf := config.MetricsConfig{}
+
+	enableCmd := &cobra.Command{
+		Use:   "enable",
+		Short: "Serve the metrics of the services on the given ports",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return EnableMetrics(serviceConfig, configFilepath, metricsConf)
+		},
+	}
+
+	enableCmd.Flags().IntVar(&metricsConf.HubPort, "hub-port", 0, `Port to serve the metrics of the hub on`)
+	enableCmd.Flags().IntVar(&metricsConf.AgentPort, "agent-port", 0, `Port to serve the metrics of the agents on`)
+	enableCmd.Flags().StringVar(&metricsConf.CoordinatorDataDir, "coordinator-data-directory", os.Getenv(constants.CoordinatorDataDirEnv), `Data directory of the coordinator, used by the hub to report the state of the segments`)
+	enableCmd.Flags().StringVar(&metricsConf.BindAddress, "bind-address", "", `IP address to serve the metrics on, such as 0.0.0.0 for all the interfaces (default 127.0.0.1)`)
+	enableCmd.Flags().BoolVar(&metricsConf.TLS, "tls", false, `Serve the metrics over HTTPS using the certificates of the services, requiring a client certificate issued by their CA`)
+
+	return enableCmd
+}
+
+func disableMetricsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "disable",
+		Short: "Stop serving the metrics of the services",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return DisableMetrics(serviceConfig, configFilepath)
+		},
+	}
+}
+
+// EnableMetrics configures the ports on which the hub and the agents serve their metrics
+func EnableMetrics(conf *config.Config, configFilepath string, metricsConf config.MetricsConfig) error {
+	if metricsConf.HubPort == 0 && metricsConf.AgentPort == 0 {
+		return fmt.Errorf("at least one of --hub-port or --agent-port must be provided")
+	}
+
+	// The hub and an agent run on the coordinator host, so all the ports need to be distinct
+	ports := map[int]string{conf.HubPort: "hub port", conf.AgentPort: "agent port"}
+	for _, port := range []struct {
+		value int
+		name  string
+	}{{metricsConf.HubPort, "hub metrics port"}, {metricsConf.AgentPort, "agent metrics port"}} {
+		if port.value == 0 {
+			continue
+		}
+
+		if port.value < 0 || port.value > 65535 {
+			return fmt.Errorf("invalid %s %d", port.name, port.value)
+		}
+
+		if other, ok := ports[port.value]; ok {
+			return fmt.Errorf("the %s %d is already used as the %s", port.name, port.value, other)
+		}
+		ports[port.value] = port.name
+	}
+
+	if metricsConf.BindAddress != "" && net.ParseIP(metricsConf.BindAddress) == nil {
+		return fmt.Errorf("invalid bind address %s, expected an IP address", metricsConf.BindAddress)
+	}
+
+	if metricsConf.TLS {
+		creds, ok := conf.Credentials.(*utils.GpCredentials)
+		if !ok || !creds.TlsEnabled {
+			return fmt.Errorf("--tls requires TLS to be enabled for the services")
+		}
+	}
+
+	conf.Metrics = &metricsConf
+
+	err := conf.Write(configFilepath)
+	if err != nil {
+		return err
+	}
+
+	gplog.Info("Enabled the metrics. %s", restartHint)
+	return nil
+}
+
+func DisableMetrics(conf *config.Config, configFilepath string) error {
+	conf.Metrics = nil
+
+	err := conf.Write(configFilepath)
+	if err != nil {
+		return err
+	}
+
+	gplog.Info("Disabled the metrics. %s", restartHint)
+	return nil
+}
@@ -0,0 +1,88 @@
+package cli_test
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+
+	"github.com/greenplum-db/gpdb/gpservice/internal/cli"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/gpservice_config"
+	"github.com/greenplum-db/gpdb/gpservice/testutils"
+)
+
+func TestEnableMetrics(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	gpservice_config.SetCopyConfigFileToAgents()
+	defer gpservice_config.ResetConfigFunctions()
+
+	t.Run("enables the metrics on the given ports", func(t *testing.T) {
+		conf := testutils.CreateDummyServiceConfig(t)
+		configFile := filepath.Join(t.TempDir(), "gpservice.conf")
+
+		metricsConf := gpservice_config.MetricsConfig{HubPort: 9190, AgentPort: 9191, CoordinatorDataDir: "/data/coordinator/gpseg-1", BindAddress: "0.0.0.0"}
+		err := cli.EnableMetrics(conf, configFile, metricsConf)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		result, err := gpservice_config.Read(configFile)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !reflect.DeepEqual(result.Metrics, &metricsConf) {
+			t.Fatalf("got %+v, want %+v", result.Metrics, metricsConf)
+		}
+	})
+
+	t.Run("disables the metrics", func(t *testing.T) {
+		conf := testutils.CreateDummyServiceConfig(t)
+		conf.Metrics = &gpservice_config.MetricsConfig{HubPort: 9190}
+		configFile := filepath.Join(t.TempDir(), "gpservice.conf")
+
+		err := cli.DisableMetrics(conf, configFile)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		result, err := gpservice_config.Read(configFile)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if result.Metrics != nil {
+			t.Fatalf("got %+v, want the metrics to be disabled", result.Metrics)
+		}
+	})
+
+	cases := []struct {
+		name        string
+		metricsConf gpservice_config.MetricsConfig
+		expected    string
+	}{
+		{"errors out when no port is given", gpservice_config.MetricsConfig{}, "at least one of --hub-port or --agent-port must be provided"},
+		{"errors out when the port is invalid", gpservice_config.MetricsConfig{HubPort: 70000}, "invalid hub metrics port 70000"},
+		{"errors out when the port is used by the services", gpservice_config.MetricsConfig{AgentPort: 5678}, "the agent metrics port 5678 is already used as the agent port"},
+		{"errors out when both the ports are the same", gpservice_config.MetricsConfig{HubPort: 9190, AgentPort: 9190}, "the agent metrics port 9190 is already used as the hub metrics port"},
+		{"errors out when the bind address is not an IP address", gpservice_config.MetricsConfig{HubPort: 9190, BindAddress: "cdw"}, "invalid bind address cdw, expected an IP address"},
+		{"errors out when serving over TLS while it is disabled for the services", gpservice_config.MetricsConfig{HubPort: 9190, TLS: true}, "--tls requires TLS to be enabled for the services"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			conf := testutils.CreateDummyServiceConfig(t)
+
+			err := cli.EnableMetrics(conf, filepath.Join(t.TempDir(), "gpservice.conf"), tc.metricsConf)
+			if err == nil || err.Error() != tc.expected {
+				t.Fatalf("got %v, want %s", err, tc.expected)
+			}
+
+			if conf.Metrics != nil {
+				t.Fatalf("got %+v, want the configuration to be unchanged", conf.Metrics)
+			}
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package cli
+
+import (
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/gpservice_config"
+)
+
+/*
+MoveHubService makes the local host the hub host of the cluster. The hub host is
+recorded in the service configuration, and the hub service is installed and started
+on the local host. The configuration is copied to the remaining hosts on a best
+effort basis, since the old coordinator host might not be reachable anymore when
+the standby coordinator is activated.
+*/
+func MoveHubService(conf *gpservice_config.Config, configFilepath, hostname string) error {
+	conf.HubHost = hostname
+	err := conf.WriteLocal(configFilepath)
+	if err != nil {
+		return err
+	}
+
+	err = platform.CreateAndInstallHubServiceFile(conf.GpHome, conf.ServiceName, configFilepath)
+	if err != nil {
+		return err
+	}
+
+	err = startHubService(conf)
+	if err != nil {
+		return err
+	}
+
+	err = conf.Write(configFilepath)
+	if err != nil {
+		gplog.Warn("%v. Copy the service configuration file %s from host %s to the remaining hosts once they are reachable", err, configFilepath, hostname)
+	}
+
+	return nil
+}
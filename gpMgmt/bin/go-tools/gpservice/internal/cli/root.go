@@ -9,6 +9,7 @@ import (
 	"github.com/greenplum-db/gp-common-go-libs/gplog"
 	"github.com/greenplum-db/gpdb/gpservice/constants"
 	config "github.com/greenplum-db/gpdb/gpservice/pkg/gpservice_config"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -16,13 +17,20 @@ var (
 	configFilepath string
 	serviceConfig  *config.Config
 	verbose        bool
+	outputFormat   string
+	output         = utils.NewOutput(os.Stdout, utils.OutputFormatText)
 )
 
 func RootCommand() *cobra.Command {
 	root := &cobra.Command{
 		Use: "gpservice",
 		PersistentPreRun: func(cmd *cobra.Command, args []string) {
-			var err error
+			err := utils.ValidateOutputFormat(outputFormat)
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+			output = utils.NewOutput(os.Stdout, outputFormat)
 
 			// gpservice configuration is created after the init command
 			if cmd.Name() == "init" {
@@ -32,16 +40,22 @@ func RootCommand() *cobra.Command {
 
 			serviceConfig, err = config.Read(configFilepath)
 			if err != nil {
+				output.Result(cmd.CommandPath(), err) // nolint
 				fmt.Println(err)
 				fmt.Println("If gpservice is not initialized, execute the 'gpservice init' command to initialize them.")
 				os.Exit(1)
 			}
 
 			initializeLogger(cmd, serviceConfig.LogDir)
+			UseServiceManager(serviceConfig, configFilepath)
+		},
+		PersistentPostRun: func(cmd *cobra.Command, args []string) {
+			WriteResult(cmd, nil)
 		}}
 
 	root.PersistentFlags().StringVar(&configFilepath, "config-file", filepath.Join(os.Getenv("GPHOME"), constants.ConfigFileName), `Path to gpservice configuration file`)
 	root.PersistentFlags().BoolVar(&verbose, "verbose", false, `Provide verbose output`)
+	root.PersistentFlags().StringVar(&outputFormat, "output", utils.OutputFormatText, fmt.Sprintf("Output format, one of %s, %s, %s or %s", utils.OutputFormatText, utils.OutputFormatJson, utils.OutputFormatNdjson, utils.OutputFormatYaml))
 
 	root.CompletionOptions.DisableDefaultCmd = true
 
@@ -53,6 +67,14 @@ func RootCommand() *cobra.Command {
 		StatusCmd(),
 		StopCmd(),
 		DeleteCmd(),
+		AddHostsCmd(),
+		RemoveHostsCmd(),
+		CertsCmd(),
+		AuthCmd(),
+		AuditCmd(),
+		CollectCmd(),
+		MetricsCmd(),
+		TracingCmd(),
 	)
 
 	return root
@@ -67,6 +89,31 @@ func initializeLogger(cmd *cobra.Command, logdir string) {
 	if verbose {
 		gplog.SetVerbosity(gplog.LOGVERBOSE)
 	}
+
+	// Only the errors are displayed along with the structured output, the log file has the rest
+	if output.Structured() {
+		gplog.SetVerbosity(gplog.LOGERROR)
+	}
+}
+
+// WriteResult writes the result of the command which returned, when the output is structured
+func WriteResult(cmd *cobra.Command, err error) {
+	output.Result(cmd.CommandPath(), err) // nolint
+}
+
+/*
+logErrorAndExit logs the error and exits. With a structured output, the error is reported in
+the result of the command and the help text of the error is left out of the standard output.
+*/
+func logErrorAndExit(cmd *cobra.Command, err error) {
+	if !output.Structured() {
+		utils.LogErrorAndExit(err, 1)
+		return
+	}
+
+	WriteResult(cmd, err)
+	gplog.Error(err.Error())
+	utils.System.OSExit(1)
 }
 
 // used only for testing
@@ -78,3 +125,13 @@ func SetConf(customConf *config.Config) func() {
 		serviceConfig = oldConf
 	}
 }
+
+// used only for testing
+func SetOutputFormat(format string) func() {
+	oldOutput := output
+	output = utils.NewOutput(os.Stdout, format)
+
+	return func() {
+		output = oldOutput
+	}
+}
@@ -35,7 +35,7 @@ $ gpservice start --agent
 		Run: func(cmd *cobra.Command, args []string) {
 			err := runStartCmd(startHub, startAgent)
 			if err != nil {
-				utils.LogErrorAndExit(err, 1)
+				logErrorAndExit(cmd, err)
 			}
 		},
 	}
@@ -25,7 +25,7 @@ $ gpservice status
 		Run: func(cmd *cobra.Command, args []string) {
 			err := runStatusCmd()
 			if err != nil {
-				utils.LogErrorAndExit(err, 1)
+				logErrorAndExit(cmd, err)
 			}
 		},
 	}
@@ -84,7 +84,15 @@ func getAgentStatus(conf *gpservice_config.Config) ([]*idl.ServiceStatus, error)
 	return reply.Statuses, nil
 }
 
+// displayServiceStatus displays the statuses as a table, or adds them to the result of the command
+// when the output is structured
 func displayServiceStatus(outfile io.Writer, statuses []*idl.ServiceStatus) {
+	if output.Structured() {
+		reported, _ := output.Data().([]*idl.ServiceStatus)
+		output.SetData(append(reported, statuses...))
+		return
+	}
+
 	w := new(tabwriter.Writer)
 	w.Init(outfile, 10, 0, 2, ' ', 0)
 	fmt.Fprintln(w, "ROLE\tHOST\tSTATUS\tPID\tUPTIME")
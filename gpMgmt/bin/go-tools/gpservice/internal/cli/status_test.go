@@ -1,9 +1,11 @@
 package cli_test
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
+	"reflect"
 	"testing"
 
 	"github.com/greenplum-db/gp-common-go-libs/testhelper"
@@ -71,6 +73,64 @@ Agent     sdw1      running   123       5H
 		}
 	})
 
+	t.Run("reports the service status in the result with the json output", func(t *testing.T) {
+		resetConf := cli.SetConf(testutils.CreateDummyServiceConfig(t))
+		defer resetConf()
+
+		utils.System.ExecCommand = exectest.NewCommand(ServiceStatusOutput)
+		utils.System.GetHostName = func() (name string, err error) {
+			return "cdw", err
+		}
+		defer utils.ResetSystemFunctions()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		client := mock_idl.NewMockHubClient(ctrl)
+		client.EXPECT().StatusAgents(
+			gomock.Any(),
+			gomock.Any(),
+		).Return(&idl.StatusAgentsReply{
+			Statuses: []*idl.ServiceStatus{
+				{Role: "Agent", Host: "sdw1", Status: "running", Uptime: "5H", Pid: 123},
+			},
+		}, nil)
+		gpservice_config.SetConnectToHub(client)
+		defer gpservice_config.ResetConfigFunctions()
+
+		buffer, writer, resetStdout := testutils.CaptureStdout(t)
+		defer resetStdout()
+
+		resetOutput := cli.SetOutputFormat(utils.OutputFormatJson)
+		defer resetOutput()
+
+		statusCmd := cli.StatusCmd()
+		_, err := testutils.ExecuteCobraCommand(t, statusCmd)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		cli.WriteResult(statusCmd, nil)
+		writer.Close()
+		stdout := <-buffer
+
+		var result struct {
+			Status string               `json:"status"`
+			Data   []*idl.ServiceStatus `json:"data"`
+		}
+		err = json.Unmarshal([]byte(stdout), &result)
+		if err != nil {
+			t.Fatalf("got %s, want a json result: %v", stdout, err)
+		}
+
+		expected := []*idl.ServiceStatus{
+			{Role: "Hub", Host: "cdw", Status: "running", Uptime: "10H", Pid: 83008},
+			{Role: "Agent", Host: "sdw1", Status: "running", Uptime: "5H", Pid: 123},
+		}
+		if result.Status != utils.ResultSucceeded || !reflect.DeepEqual(result.Data, expected) {
+			t.Fatalf("got %s, want %v", stdout, expected)
+		}
+	})
+
 	t.Run("errors out when not able to display the hub status", func(t *testing.T) {
 		_, _, logfile := testhelper.SetupTestLogger()
 
@@ -34,7 +34,7 @@ $ gpservice stop --agent
 		Run: func(cmd *cobra.Command, args []string) {
 			err := runStopCmd(stopHub, stopAgent)
 			if err != nil {
-				utils.LogErrorAndExit(err, 1)
+				logErrorAndExit(cmd, err)
 			}
 		},
 	}
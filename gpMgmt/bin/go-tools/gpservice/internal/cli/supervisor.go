@@ -0,0 +1,48 @@
+package cli
+
+import (
+	"path/filepath"
+
+	"github.com/greenplum-db/gpdb/gpservice/internal/agent"
+	"github.com/greenplum-db/gpdb/gpservice/internal/hub"
+	. "github.com/greenplum-db/gpdb/gpservice/internal/platform"
+	config "github.com/greenplum-db/gpdb/gpservice/pkg/gpservice_config"
+)
+
+/*
+UseServiceManager makes the services of the configuration start, stop and report their status
+through the gpservice supervisor when the configuration has no service manager. Otherwise the
+platform of the host is used, which is either systemd or launchd.
+*/
+func UseServiceManager(conf *config.Config, confFile string) {
+	if conf.NoServiceManager {
+		useSupervisor(conf.GpHome, confFile)
+	}
+}
+
+func useSupervisor(gpHome, confFile string) {
+	// The configuration file is passed to the supervisors of the agents over ssh
+	if path, err := filepath.Abs(confFile); err == nil {
+		confFile = path
+	}
+
+	p := NewForegroundPlatform(gpHome, confFile, SupervisorRunDir(gpHome))
+	platform = p
+	hub.SetPlatform(p)
+	agent.SetPlatform(p)
+}
+
+// runSupervisor runs the supervisor of the hub or the agent, detached from the terminal with daemon
+func runSupervisor(process string, daemon bool) error {
+	confFile, err := filepath.Abs(configFilepath)
+	if err != nil {
+		confFile = configFilepath
+	}
+
+	p := NewForegroundPlatform(serviceConfig.GpHome, confFile, SupervisorRunDir(serviceConfig.GpHome))
+	if daemon {
+		return p.Daemonize(serviceConfig.ServiceName, process)
+	}
+
+	return p.Supervise(serviceConfig.ServiceName, process)
+}
@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	config "github.com/greenplum-db/gpdb/gpservice/pkg/gpservice_config"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+)
+
+func TracingCmd() *cobra.Command {
+	tracingCmd := &cobra.Command{
+		Use:   "tracing",
+		Short: "Manage the OpenTelemetry tracing of gpctl and the hub and agent services",
+		Long: `Manage the OpenTelemetry tracing of gpctl and the hub and agent services. Each gpctl command
+starts a trace which is carried over to the hub and from the hub to the agents, with a span
+for each call made to a host, each external command such as initdb, pg_ctl or pg_basebackup
+run on it and each step of creating the cluster. The spans are either sent to an OTLP
+collector or appended to a file on each host, by default ` + utils.TraceFile("<service>") + ` in
+the log directory, such as ` + utils.TraceFile("gpservice-hub") + ` for the hub.`,
+		Example: `Send the traces to the OTLP collector listening on otel-collector:4317
+$ gpservice tracing enable --exporter otlp --endpoint otel-collector:4317
+
+Write the traces to a file in the log directory of each host
+$ gpservice tracing enable --exporter file
+`,
+	}
+
+	tracingCmd.AddCommand(
+		enableTracingCmd(),
+		disableTracingCmd(),
+	)
+
+	return tracingCmd
+}
+
+func enableTracingCmd() *cobra.Command {
+	tracingConf := utils.TracingConfig{}
+
+	enableCmd := &cobra.Command{
+		Use:   "enable",
+		Short: "Export the traces of gpctl and the services",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return EnableTracing(serviceConfig, configFilepath, tracingConf)
+		},
+	}
+
+	enableCmd.Flags().StringVar(&tracingConf.Exporter, "exporter", utils.TraceExporterOTLP, `Exporter of the spans, either otlp or file`)
+	enableCmd.Flags().StringVar(&tracingConf.Endpoint, "endpoint", "", `Address of the OTLP gRPC collector, defaults to the OTEL_EXPORTER_OTLP_ENDPOINT environment variable of each service`)
+	enableCmd.Flags().BoolVar(&tracingConf.Insecure, "insecure", false, `Send the spans to the OTLP collector without TLS`)
+	enableCmd.Flags().StringVar(&tracingConf.File, "file", "", `File the spans are appended to on each host, defaults to `+utils.TraceFile("<service>")+` in the log directory`)
+	enableCmd.Flags().Float64Var(&tracingConf.SampleRatio, "sample-ratio", 1, `Fraction of the gpctl commands which are traced`)
+
+	return enableCmd
+}
+
+func disableTracingCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "disable",
+		Short: "Stop exporting the traces of gpctl and the services",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return DisableTracing(serviceConfig, configFilepath)
+		},
+	}
+}
+
+// EnableTracing configures the export of the traces of gpctl, the hub and the agents
+func EnableTracing(conf *config.Config, configFilepath string, tracingConf utils.TracingConfig) error {
+	err := tracingConf.Validate()
+	if err != nil {
+		return err
+	}
+
+	conf.Tracing = &tracingConf
+
+	err = conf.Write(configFilepath)
+	if err != nil {
+		return err
+	}
+
+	gplog.Info("Enabled the tracing. %s", restartHint)
+	return nil
+}
+
+func DisableTracing(conf *config.Config, configFilepath string) error {
+	conf.Tracing = nil
+
+	err := conf.Write(configFilepath)
+	if err != nil {
+		return err
+	}
+
+	gplog.Info("Disabled the tracing. %s", restartHint)
+	return nil
+}
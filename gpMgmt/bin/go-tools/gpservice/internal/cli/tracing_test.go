@@ -0,0 +1,75 @@
+package cli_test
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+
+	"github.com/greenplum-db/gpdb/gpservice/internal/cli"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/gpservice_config"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+	"github.com/greenplum-db/gpdb/gpservice/testutils"
+)
+
+func TestEnableTracing(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	gpservice_config.SetCopyConfigFileToAgents()
+	defer gpservice_config.ResetConfigFunctions()
+
+	t.Run("enables the tracing with the given exporter", func(t *testing.T) {
+		conf := testutils.CreateDummyServiceConfig(t)
+		configFile := filepath.Join(t.TempDir(), "gpservice.conf")
+
+		tracingConf := utils.TracingConfig{Exporter: utils.TraceExporterOTLP, Endpoint: "otel-collector:4317", Insecure: true, SampleRatio: 0.5}
+		err := cli.EnableTracing(conf, configFile, tracingConf)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		result, err := gpservice_config.Read(configFile)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !reflect.DeepEqual(result.Tracing, &tracingConf) {
+			t.Fatalf("got %+v, want %+v", result.Tracing, tracingConf)
+		}
+	})
+
+	t.Run("disables the tracing", func(t *testing.T) {
+		conf := testutils.CreateDummyServiceConfig(t)
+		conf.Tracing = &utils.TracingConfig{Exporter: utils.TraceExporterFile}
+		configFile := filepath.Join(t.TempDir(), "gpservice.conf")
+
+		err := cli.DisableTracing(conf, configFile)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		result, err := gpservice_config.Read(configFile)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if result.Tracing != nil {
+			t.Fatalf("got %+v, want the tracing to be disabled", result.Tracing)
+		}
+	})
+
+	t.Run("errors out when the configuration is invalid", func(t *testing.T) {
+		conf := testutils.CreateDummyServiceConfig(t)
+
+		err := cli.EnableTracing(conf, filepath.Join(t.TempDir(), "gpservice.conf"), utils.TracingConfig{Exporter: "jaeger"})
+		expected := `unsupported trace exporter "jaeger", supported exporters are otlp and file`
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+
+		if conf.Tracing != nil {
+			t.Fatalf("got %+v, want the configuration to be unchanged", conf.Tracing)
+		}
+	})
+}
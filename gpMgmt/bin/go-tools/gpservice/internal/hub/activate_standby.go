@@ -0,0 +1,116 @@
+package hub
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"github.com/greenplum-db/gpdb/gpservice/constants"
+	"github.com/greenplum-db/gpdb/gpservice/idl"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/greenplum"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+)
+
+var (
+	// The promoted standby only accepts connections once it has finished its
+	// recovery, so the connection is retried until the promote timeout expires
+	CoordinatorConnRetryInterval = 2 * time.Second
+	CoordinatorConnTimeout       = constants.DefaultPromoteTimeout * time.Second
+)
+
+// ActivateStandby is the hub RPC which promotes the standby coordinator to be the
+// coordinator of the cluster. Promoting the standby updates gp_segment_configuration
+// so that the standby becomes the acting coordinator and the old coordinator is removed.
+// The hub is expected to be running on the standby host since it connects to the
+// promoted coordinator using its local data directory.
+func (s *Server) ActivateStandby(req *idl.ActivateStandbyRequest, stream idl.Hub_ActivateStandbyServer) error {
+	hubStream := NewHubStream(stream)
+	hubStream.StreamLogMsg("Starting to activate the standby coordinator")
+
+	err := s.DialAllAgents()
+	if err != nil {
+		return utils.LogAndReturnError(err)
+	}
+
+	conns := getConnForHosts(s.connections(), []string{req.Hostname})
+	if len(conns) == 0 {
+		return utils.LogAndReturnError(fmt.Errorf("following hostnames [%s] do not have gp services configured. Please configure the services", req.Hostname))
+	}
+
+	reply, err := conns[0].AgentClient.GetSegmentStatus(stream.Context(), &idl.GetSegmentStatusRequest{
+		DataDirs: []string{req.CoordinatorDataDir},
+	})
+	if err != nil {
+		return utils.LogAndReturnError(utils.FormatGrpcError(err))
+	}
+
+	if len(reply.Statuses) == 0 || !reply.Statuses[0].Running {
+		return utils.LogAndReturnError(fmt.Errorf("the standby coordinator with data directory %s is not running on host %s", req.CoordinatorDataDir, req.Hostname))
+	}
+
+	// A previous attempt might have failed after the standby was promoted,
+	// in which case only the remaining steps need to be performed
+	if reply.Statuses[0].ClusterState == constants.ClusterStateInProduction {
+		hubStream.StreamLogMsg("Standby coordinator has already been promoted, skipping the promotion")
+	} else {
+		hubStream.StreamLogMsg(fmt.Sprintf("Promoting the standby coordinator on host %s", req.Hostname))
+		_, err = conns[0].AgentClient.PromoteSegment(stream.Context(), &idl.PromoteSegmentRequest{
+			DataDir: req.CoordinatorDataDir,
+			Wait:    true,
+			Timeout: constants.DefaultPromoteTimeout,
+		})
+		if err != nil {
+			return utils.LogAndReturnError(fmt.Errorf("promoting standby coordinator: %w", utils.FormatGrpcError(err)))
+		}
+		hubStream.StreamLogMsg("Successfully promoted the standby coordinator")
+	}
+
+	hubStream.StreamLogMsg("Waiting for the new coordinator to accept connections")
+	conn, err := WaitForCoordinatorConn(stream.Context(), req.CoordinatorDataDir)
+	if err != nil {
+		return utils.LogAndReturnError(err)
+	}
+	defer conn.DB.Close()
+
+	// Force a checkpoint so that the new coordinator does not need to replay
+	// the WAL generated during the recovery in case of a crash
+	_, err = conn.DB.ExecContext(conn.Ctx, "CHECKPOINT")
+	if err != nil {
+		return utils.LogAndReturnError(fmt.Errorf("running checkpoint on the new coordinator: %w", err))
+	}
+
+	gparray, err := greenplum.NewGpArrayFromCatalog(conn.DB)
+	if err != nil {
+		return utils.LogAndReturnError(err)
+	}
+
+	if gparray.Standby != nil || gparray.Coordinator == nil || gparray.Coordinator.Hostname != req.Hostname || gparray.Coordinator.DataDir != req.CoordinatorDataDir {
+		return utils.LogAndReturnError(fmt.Errorf("the catalog has not been updated with the new coordinator on host %s after the promotion", req.Hostname))
+	}
+
+	hubStream.StreamLogMsg(fmt.Sprintf("Standby coordinator on host %s has been activated", req.Hostname))
+
+	return nil
+}
+
+// WaitForCoordinatorConn connects to the coordinator in utility mode, retrying
+// until the coordinator accepts connections or the timeout expires
+func WaitForCoordinatorConn(ctx context.Context, dataDir string) (*utils.DBConnWithContext, error) {
+	timeout := time.After(CoordinatorConnTimeout)
+	for {
+		conn, err := greenplum.GetCoordinatorConn(ctx, dataDir, "", true)
+		if err == nil {
+			return conn, nil
+		}
+		gplog.Debug("failed to connect to the coordinator with data directory %s: %v", dataDir, err)
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-timeout:
+			return nil, fmt.Errorf("timed out waiting for the coordinator with data directory %s to accept connections: %w", dataDir, err)
+		case <-time.After(CoordinatorConnRetryInterval):
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package hub_test
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+
+	"github.com/greenplum-db/gp-common-go-libs/dbconn"
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+	"github.com/greenplum-db/gpdb/gpservice/constants"
+	"github.com/greenplum-db/gpdb/gpservice/idl"
+	"github.com/greenplum-db/gpdb/gpservice/idl/mock_idl"
+	"github.com/greenplum-db/gpdb/gpservice/internal/hub"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/greenplum"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+	"github.com/greenplum-db/gpdb/gpservice/testutils"
+)
+
+func TestActivateStandby(t *testing.T) {
+	testhelper.SetupTestLogger()
+	initialize(t)
+
+	newCoordinator := createSegment(t, 6, -1, constants.RolePrimary, constants.RolePrimary, 7000, "scdw", "scdw", "/data/standby/gpseg-1")
+	request := &idl.ActivateStandbyRequest{
+		CoordinatorDataDir: "/data/standby/gpseg-1",
+		Hostname:           "scdw",
+	}
+
+	setupCatalog := func(t *testing.T, segs ...*greenplum.Segment) {
+		utils.System.Open = func(name string) (*os.File, error) {
+			reader, writer, _ := os.Pipe()
+			defer writer.Close()
+
+			_, err := writer.WriteString("port=7000")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			return reader, nil
+		}
+
+		utils.SetNewDBConnFromEnvironment(func(dbname string) *dbconn.DBConn {
+			conn, mock := testutils.CreateMockDBConnForUtilityMode(t)
+			testhelper.ExpectVersionQuery(mock, "7.0.0")
+			mock.ExpectExec("CHECKPOINT").WillReturnResult(sqlmock.NewResult(0, 0))
+
+			rows := sqlmock.NewRows([]string{"dbid", "content", "role", "preferredrole", "port", "hostname", "address", "datadir"})
+			addSegmentRows(t, rows, segs...)
+			mock.ExpectQuery("SELECT").WillReturnRows(rows)
+			return conn
+		})
+	}
+
+	expectSegmentStatus := func(client *mock_idl.MockAgentClient, status *idl.SegmentProcessStatus) {
+		client.EXPECT().GetSegmentStatus(
+			gomock.Any(),
+			&idl.GetSegmentStatusRequest{DataDirs: []string{"/data/standby/gpseg-1"}},
+		).Return(&idl.GetSegmentStatusReply{Statuses: []*idl.SegmentProcessStatus{status}}, nil)
+	}
+
+	t.Run("promotes the standby and verifies the catalog", func(t *testing.T) {
+		setupCatalog(t, newCoordinator, primary1, primary2)
+		defer utils.ResetSystemFunctions()
+		defer utils.ResetNewDBConnFromEnvironment()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		scdw := mock_idl.NewMockAgentClient(ctrl)
+		expectSegmentStatus(scdw, &idl.SegmentProcessStatus{Running: true, ClusterState: "in archive recovery"})
+		scdw.EXPECT().PromoteSegment(
+			gomock.Any(),
+			&idl.PromoteSegmentRequest{DataDir: "/data/standby/gpseg-1", Wait: true, Timeout: constants.DefaultPromoteTimeout},
+		).Return(&idl.PromoteSegmentReply{}, nil)
+
+		hubServer.Conns = []*hub.Connection{{AgentClient: scdw, Hostname: "scdw"}}
+
+		_, stream := testutils.NewMockStream()
+		err := hubServer.ActivateStandby(request, stream)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("does not promote the standby again when it has already been promoted", func(t *testing.T) {
+		setupCatalog(t, newCoordinator, primary1, primary2)
+		defer utils.ResetSystemFunctions()
+		defer utils.ResetNewDBConnFromEnvironment()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		scdw := mock_idl.NewMockAgentClient(ctrl)
+		expectSegmentStatus(scdw, &idl.SegmentProcessStatus{Running: true, ClusterState: "in production"})
+
+		hubServer.Conns = []*hub.Connection{{AgentClient: scdw, Hostname: "scdw"}}
+
+		_, stream := testutils.NewMockStream()
+		err := hubServer.ActivateStandby(request, stream)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("errors out when the catalog still has the old coordinator", func(t *testing.T) {
+		standby := createSegment(t, 6, -1, constants.RoleMirror, constants.RoleMirror, 7000, "scdw", "scdw", "/data/standby/gpseg-1")
+		setupCatalog(t, coordinator, standby, primary1, primary2)
+		defer utils.ResetSystemFunctions()
+		defer utils.ResetNewDBConnFromEnvironment()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		scdw := mock_idl.NewMockAgentClient(ctrl)
+		expectSegmentStatus(scdw, &idl.SegmentProcessStatus{Running: true, ClusterState: "in production"})
+
+		hubServer.Conns = []*hub.Connection{{AgentClient: scdw, Hostname: "scdw"}}
+
+		_, stream := testutils.NewMockStream()
+		err := hubServer.ActivateStandby(request, stream)
+
+		expectedErr := "the catalog has not been updated with the new coordinator on host scdw after the promotion"
+		if err == nil || err.Error() != expectedErr {
+			t.Fatalf("got %v, want %s", err, expectedErr)
+		}
+	})
+
+	t.Run("errors out when the standby host does not have gp services configured", func(t *testing.T) {
+		hubServer.Conns = []*hub.Connection{{Hostname: "sdw1"}, {Hostname: "sdw2"}}
+
+		_, stream := testutils.NewMockStream()
+		err := hubServer.ActivateStandby(request, stream)
+
+		expectedErr := "following hostnames [scdw] do not have gp services configured. Please configure the services"
+		if err == nil || err.Error() != expectedErr {
+			t.Fatalf("got %v, want %s", err, expectedErr)
+		}
+	})
+
+	t.Run("errors out when the standby is not running", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		scdw := mock_idl.NewMockAgentClient(ctrl)
+		expectSegmentStatus(scdw, &idl.SegmentProcessStatus{Running: false})
+
+		hubServer.Conns = []*hub.Connection{{AgentClient: scdw, Hostname: "scdw"}}
+
+		_, stream := testutils.NewMockStream()
+		err := hubServer.ActivateStandby(request, stream)
+
+		expectedErr := "the standby coordinator with data directory /data/standby/gpseg-1 is not running on host scdw"
+		if err == nil || err.Error() != expectedErr {
+			t.Fatalf("got %v, want %s", err, expectedErr)
+		}
+	})
+
+	t.Run("errors out when the promotion fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		expectedErr := errors.New("error")
+		scdw := mock_idl.NewMockAgentClient(ctrl)
+		expectSegmentStatus(scdw, &idl.SegmentProcessStatus{Running: true, ClusterState: "in archive recovery"})
+		scdw.EXPECT().PromoteSegment(gomock.Any(), gomock.Any()).Return(nil, expectedErr)
+
+		hubServer.Conns = []*hub.Connection{{AgentClient: scdw, Hostname: "scdw"}}
+
+		_, stream := testutils.NewMockStream()
+		err := hubServer.ActivateStandby(request, stream)
+		if !errors.Is(err, expectedErr) {
+			t.Fatalf("got %v, want %v", err, expectedErr)
+		}
+
+		expectedErrPrefix := "promoting standby coordinator:"
+		if !strings.HasPrefix(err.Error(), expectedErrPrefix) {
+			t.Fatalf("got %v, want %s", err, expectedErrPrefix)
+		}
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"path/filepath"
 	"strconv"
 	"sync"
@@ -15,12 +16,18 @@ import (
 	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
 )
 
-func (s *Server) AddMirrors(req *idl.AddMirrorsRequest, stream idl.Hub_AddMirrorsServer) error {
+func (s *Server) AddMirrors(req *idl.AddMirrorsRequest, stream idl.Hub_AddMirrorsServer) (err error) {
 	hubStream := NewHubStream(stream)
 	hubStream.StreamLogMsg("Starting to add mirrors to the cluster")
 
+	// A rollback removes everything listed in the cleanup file, which must only be the mirrors
+	err = s.checkNoPendingCleanup()
+	if err != nil {
+		return utils.LogAndReturnError(err)
+	}
+
 	// Make sure all agents are up and listening for requests
-	err := s.DialAllAgents()
+	err = s.DialAllAgents()
 	if err != nil {
 		return utils.LogAndReturnError(err)
 	}
@@ -56,6 +63,14 @@ func (s *Server) AddMirrors(req *idl.AddMirrorsRequest, stream idl.Hub_AddMirror
 	}
 	hubStream.StreamLogMsg("Successfully registered the mirror segments with the coordinator")
 
+	// Once registered, remove the mirrors from the catalog again if any of the
+	// later steps fail so that the rollback leaves the cluster mirrorless
+	defer func() {
+		if err != nil {
+			unregisterMirrorSegments(req.Mirrors, conn)
+		}
+	}()
+
 	// Build the new gparray and validate it
 	gparray, err = greenplum.NewGpArrayFromCatalog(conn.DB)
 	if err != nil {
@@ -100,13 +115,25 @@ func (s *Server) AddMirrors(req *idl.AddMirrorsRequest, stream idl.Hub_AddMirror
 		return utils.LogAndReturnError(err)
 	}
 
+	// The mirrors are now part of the cluster, so they should no longer be removed on a rollback
+	os.Remove(filename)
+
 	hubStream.StreamLogMsg("Mirror segments have been added")
 	hubStream.StreamLogMsg("Data synchronization might be in progress and will continue in the background")
-	hubStream.StreamLogMsg("Use 'gpstate -s' to check the resynchronization progress")
+	hubStream.StreamLogMsg("Use 'gpctl status' to check the state of the segments")
 
 	return nil
 }
 
+func unregisterMirrorSegments(mirrorSegs []*idl.Segment, conn *utils.DBConnWithContext) {
+	for _, seg := range mirrorSegs {
+		err := greenplum.UnregisterMirrorSegment(int(seg.Contentid), conn)
+		if err != nil {
+			gplog.Warn("failed to unregister the mirror segment with content %d: %v", seg.Contentid, err)
+		}
+	}
+}
+
 func (s *Server) CreateMirrorSegments(stream hubStreamer, ctx context.Context, gparray *greenplum.GpArray, mirrorSegs []*idl.Segment) error {
 	if ctx.Err() != nil {
 		return ctx.Err()
@@ -189,7 +216,7 @@ func (s *Server) CreateMirrorSegments(stream hubStreamer, ctx context.Context, g
 		return err
 	}
 
-	return ExecuteRPC(s.Conns, request)
+	return ExecuteRPC(s.connections(), request)
 }
 
 func (s *Server) StartMirrorSegments(ctx context.Context, mirrorSegs []*idl.Segment) error {
@@ -237,5 +264,5 @@ func (s *Server) StartMirrorSegments(ctx context.Context, mirrorSegs []*idl.Segm
 		return err
 	}
 
-	return ExecuteRPC(s.Conns, request)
+	return ExecuteRPC(s.connections(), request)
 }
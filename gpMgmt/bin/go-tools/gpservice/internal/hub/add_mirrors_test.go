@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io/fs"
 	"os"
+	"path/filepath"
 	"reflect"
 	"strconv"
 	"testing"
@@ -405,6 +406,9 @@ func TestAddMirrors(t *testing.T) {
 		defer ctrl.Finish()
 
 		utils.System.Stat = func(name string) (os.FileInfo, error) {
+			if filepath.Base(name) == constants.CleanFileName {
+				return nil, os.ErrNotExist
+			}
 			return nil, nil
 		}
 		utils.System.Open = func(name string) (*os.File, error) {
@@ -463,6 +467,20 @@ func TestAddMirrors(t *testing.T) {
 		}
 	})
 
+	t.Run("errors out when a failed command has left its cleanup file", func(t *testing.T) {
+		utils.System.Stat = func(name string) (os.FileInfo, error) {
+			return nil, nil
+		}
+		defer utils.ResetSystemFunctions()
+
+		_, stream := testutils.NewMockStream()
+		err := hubServer.AddMirrors(&idl.AddMirrorsRequest{HbaHostnames: true, Mirrors: mirrorSegs}, stream)
+		expectedErrString := fmt.Sprintf("the cleanup file %s of a previously failed command exists. Run the failed gpctl command with --clean before proceeding", filepath.Join(hubServer.LogDir, constants.CleanFileName))
+		if err == nil || err.Error() != expectedErrString {
+			t.Fatalf("got %v, want %s", err, expectedErrString)
+		}
+	})
+
 	t.Run("when cluster already has mirrors", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
@@ -560,6 +578,9 @@ func TestAddMirrors(t *testing.T) {
 			defer ctrl.Finish()
 
 			utils.System.Stat = func(name string) (os.FileInfo, error) {
+				if filepath.Base(name) == constants.CleanFileName {
+					return nil, os.ErrNotExist
+				}
 				return nil, nil
 			}
 
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
 
@@ -27,7 +28,10 @@ func CreateHostDataDirMap(entries []string, file string) (map[string][]string, e
 		if len(fields) == 2 {
 			host := fields[0]
 			dataDir := fields[1]
-			hostDataDirMap[host] = append(hostDataDirMap[host], dataDir)
+			// The same entry is written again when a failed cluster initialization is resumed
+			if !slices.Contains(hostDataDirMap[host], dataDir) {
+				hostDataDirMap[host] = append(hostDataDirMap[host], dataDir)
+			}
 		} else {
 			return nil, errors.New("invalid entries in map")
 		}
@@ -38,7 +42,7 @@ func CreateHostDataDirMap(entries []string, file string) (map[string][]string, e
 /*
 rpc to cleanup the data directories in case gpctl init fails.
 */
-func (s *Server) CleanInitCluster(context.Context, *idl.CleanInitClusterRequest) (*idl.CleanInitClusterReply, error) {
+func (s *Server) CleanInitCluster(ctx context.Context, req *idl.CleanInitClusterRequest) (*idl.CleanInitClusterReply, error) {
 
 	var err error
 	fileName := filepath.Join(s.LogDir, constants.CleanFileName)
@@ -61,6 +65,32 @@ func (s *Server) CleanInitCluster(context.Context, *idl.CleanInitClusterRequest)
 		return &idl.CleanInitClusterReply{}, utils.LogAndReturnError(fmt.Errorf("invalid entries in cleanup file"))
 	}
 
+	defer os.Remove(fileName)
+
+	// The cluster initialization can no longer be resumed once the data directories are removed
+	defer os.Remove(filepath.Join(s.LogDir, constants.InitJournalFileName))
+
+	return &idl.CleanInitClusterReply{}, s.RemoveDataDirectories(ctx, hostDataDirMap)
+}
+
+/*
+checkNoPendingCleanup errors out when a failed command has left its cleanup file behind. The
+data directories listed there would otherwise be removed along with the ones of the calling
+command when it is rolled back, and they may have been taken into use since.
+*/
+func (s *Server) checkNoPendingCleanup() error {
+	fileName := filepath.Join(s.LogDir, constants.CleanFileName)
+
+	_, err := utils.System.Stat(fileName)
+	if err == nil {
+		return fmt.Errorf("the cleanup file %s of a previously failed command exists. Run the failed gpctl command with --clean before proceeding", fileName)
+	}
+
+	return nil
+}
+
+// RemoveDataDirectories removes the given data directories on each of the hosts in parallel
+func (s *Server) RemoveDataDirectories(ctx context.Context, hostDataDirMap map[string][]string) error {
 	request := func(conn *Connection) error {
 		var wg sync.WaitGroup
 
@@ -75,7 +105,7 @@ func (s *Server) CleanInitCluster(context.Context, *idl.CleanInitClusterRequest)
 				defer wg.Done()
 
 				gplog.Debug("Removing Data Directories: %s", dir)
-				_, err := conn.AgentClient.RemoveDirectory(context.Background(), &idl.RemoveDirectoryRequest{
+				_, err := conn.AgentClient.RemoveDirectory(ctx, &idl.RemoveDirectoryRequest{
 					DataDirectory: dir,
 				})
 				if err != nil {
@@ -94,7 +124,5 @@ func (s *Server) CleanInitCluster(context.Context, *idl.CleanInitClusterRequest)
 		return err
 	}
 
-	defer os.Remove(fileName)
-
-	return &idl.CleanInitClusterReply{}, ExecuteRPC(s.Conns, request)
+	return ExecuteRPC(s.connections(), request)
 }
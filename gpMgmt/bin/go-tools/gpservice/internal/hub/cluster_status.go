@@ -0,0 +1,190 @@
+package hub
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+
+	"github.com/greenplum-db/gpdb/gpservice/idl"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/greenplum"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+)
+
+// ClusterStatus is the hub RPC which reports the status of every segment in
+// the cluster. The segment configuration from the catalog is combined with the
+// process level status reported by the agents for each data directory. When the
+// catalog cannot be read, such as when the coordinator is down, only the status
+// of the coordinator is reported along with the reason in CatalogError.
+// The status of the agent on each host is reported either way.
+func (s *Server) ClusterStatus(ctx context.Context, req *idl.ClusterStatusRequest) (*idl.ClusterStatusReply, error) {
+	err := s.DialAllAgents()
+	if err != nil {
+		return nil, utils.LogAndReturnError(err)
+	}
+
+	agents := s.getAgentStatuses(ctx)
+
+	gparray, err := getGpArrayFromCoordinator(ctx, req.CoordinatorDataDir)
+	if err != nil {
+		catalogErr := fmt.Errorf("could not read the segment configuration, make sure the coordinator is running: %w", err)
+		gplog.Warn(catalogErr.Error())
+
+		coordinator := greenplum.GetSegmentProcessStatus(s.GpHome, req.CoordinatorDataDir)
+		if coordinator.Error == "" {
+			coordinator.Error = catalogErr.Error()
+		}
+
+		hostname, _ := os.Hostname()
+		segments := []*idl.SegmentStatus{
+			newSegmentStatus(greenplum.Segment{Content: -1, Hostname: hostname, DataDir: req.CoordinatorDataDir}, coordinator),
+		}
+
+		return &idl.ClusterStatusReply{Segments: segments, Agents: agents, CatalogError: catalogErr.Error()}, nil
+	}
+
+	segments, err := s.GetSegmentStatuses(ctx, gparray)
+	if err != nil {
+		return nil, utils.LogAndReturnError(err)
+	}
+
+	return &idl.ClusterStatusReply{Segments: segments, Agents: agents}, nil
+}
+
+// getAgentStatuses returns the status of the agent on each host. An agent which cannot be
+// reached is reported as unreachable rather than failing the request.
+func (s *Server) getAgentStatuses(ctx context.Context) []*idl.ServiceStatus {
+	var statuses []*idl.ServiceStatus
+
+	request := func(conn *Connection) error {
+		status := &idl.ServiceStatus{Role: "Agent", Host: conn.Hostname, Status: "unreachable"}
+
+		reply, err := conn.AgentClient.Status(ctx, &idl.StatusAgentRequest{})
+		if err != nil {
+			gplog.Debug("failed to get the status of the agent on host %s: %v", conn.Hostname, err)
+		} else {
+			status.Status = reply.Status
+			status.Uptime = reply.Uptime
+			status.Pid = reply.Pid
+		}
+
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+		statuses = append(statuses, status)
+
+		return nil
+	}
+
+	ExecuteRPC(s.connections(), request) // nolint
+
+	// the agents reply in any order, so they are sorted for a stable output
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Host < statuses[j].Host
+	})
+
+	return statuses
+}
+
+// GetSegmentStatuses returns the status of all the segments in the given gparray in
+// the catalog order i.e. coordinator, standby followed by the primary and mirror pairs.
+// The coordinator is checked locally while the rest of the segments are checked through
+// the agents on their respective hosts. An unreachable agent does not fail the whole
+// request; the error is reported against each of the segments on that host instead.
+func (s *Server) GetSegmentStatuses(ctx context.Context, gparray *greenplum.GpArray) ([]*idl.SegmentStatus, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	var segs []greenplum.Segment
+	if gparray.Standby != nil {
+		segs = append(segs, *gparray.Standby)
+	}
+	for _, pair := range gparray.SegmentPairs {
+		if pair.Primary != nil {
+			segs = append(segs, *pair.Primary)
+		}
+		if pair.Mirror != nil {
+			segs = append(segs, *pair.Mirror)
+		}
+	}
+
+	hostDataDirMap := make(map[string][]string)
+	for _, seg := range segs {
+		hostDataDirMap[seg.Hostname] = append(hostDataDirMap[seg.Hostname], seg.DataDir)
+	}
+
+	hostStatusMap := make(map[string]map[string]*idl.SegmentProcessStatus)
+	hostErrMap := make(map[string]error)
+
+	request := func(conn *Connection) error {
+		dataDirs := hostDataDirMap[conn.Hostname]
+		if len(dataDirs) == 0 {
+			return nil
+		}
+
+		reply, err := conn.AgentClient.GetSegmentStatus(ctx, &idl.GetSegmentStatusRequest{DataDirs: dataDirs})
+
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+
+		if err != nil {
+			hostErrMap[conn.Hostname] = utils.FormatGrpcError(err)
+			return nil
+		}
+
+		statuses := make(map[string]*idl.SegmentProcessStatus)
+		for _, status := range reply.Statuses {
+			statuses[status.DataDir] = status
+		}
+		hostStatusMap[conn.Hostname] = statuses
+
+		return nil
+	}
+
+	err := ExecuteRPC(s.connections(), request)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*idl.SegmentStatus
+	if gparray.Coordinator != nil {
+		result = append(result, newSegmentStatus(*gparray.Coordinator, greenplum.GetSegmentProcessStatus(s.GpHome, gparray.Coordinator.DataDir)))
+	}
+
+	for _, seg := range segs {
+		processStatus, ok := hostStatusMap[seg.Hostname][seg.DataDir]
+		if !ok {
+			processStatus = &idl.SegmentProcessStatus{DataDir: seg.DataDir}
+			if hostErr, ok := hostErrMap[seg.Hostname]; ok {
+				processStatus.Error = hostErr.Error()
+			} else {
+				processStatus.Error = fmt.Sprintf("unable to get the status from host %s", seg.Hostname)
+			}
+		}
+
+		result = append(result, newSegmentStatus(seg, processStatus))
+	}
+
+	return result, nil
+}
+
+func newSegmentStatus(seg greenplum.Segment, processStatus *idl.SegmentProcessStatus) *idl.SegmentStatus {
+	return &idl.SegmentStatus{
+		Dbid:          int32(seg.Dbid),
+		Content:       int32(seg.Content),
+		Role:          seg.Role,
+		PreferredRole: seg.PreferredRole,
+		Mode:          seg.Mode,
+		Status:        seg.Status,
+		Hostname:      seg.Hostname,
+		Address:       seg.Address,
+		Port:          int32(seg.Port),
+		DataDir:       seg.DataDir,
+		Running:       processStatus.Running,
+		Pid:           processStatus.Pid,
+		ClusterState:  processStatus.ClusterState,
+		Error:         processStatus.Error,
+	}
+}
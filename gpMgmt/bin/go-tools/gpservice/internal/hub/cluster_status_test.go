@@ -0,0 +1,154 @@
+package hub_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+	"github.com/greenplum-db/gpdb/gpservice/idl"
+	"github.com/greenplum-db/gpdb/gpservice/idl/mock_idl"
+	"github.com/greenplum-db/gpdb/gpservice/internal/hub"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/greenplum"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+	"github.com/greenplum-db/gpdb/gpservice/testutils"
+	"github.com/greenplum-db/gpdb/gpservice/testutils/exectest"
+)
+
+func TestGetSegmentStatuses(t *testing.T) {
+	testhelper.SetupTestLogger()
+	hubServer := hub.New(testutils.CreateDummyServiceConfig(t))
+
+	gparray := &greenplum.GpArray{
+		Coordinator: &greenplum.Segment{Dbid: 1, Content: -1, Role: "p", Hostname: "cdw", DataDir: "/coordinator/gpseg-1"},
+		Standby:     &greenplum.Segment{Dbid: 4, Content: -1, Role: "m", Hostname: "sdw1", DataDir: "/standby/gpseg-1"},
+		SegmentPairs: []greenplum.SegmentPair{
+			{
+				Primary: &greenplum.Segment{Dbid: 2, Content: 0, Role: "p", Hostname: "sdw1", DataDir: "/primary/gpseg0"},
+				Mirror:  &greenplum.Segment{Dbid: 3, Content: 0, Role: "m", Hostname: "sdw2", DataDir: "/mirror/gpseg0"},
+			},
+		},
+	}
+
+	t.Run("returns the status of all the segments in catalog order", func(t *testing.T) {
+		utils.System.ExecCommand = exectest.NewCommand(exectest.Failure)
+		defer utils.ResetSystemFunctions()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().GetSegmentStatus(
+			gomock.Any(),
+			&idl.GetSegmentStatusRequest{DataDirs: []string{"/standby/gpseg-1", "/primary/gpseg0"}},
+		).Return(&idl.GetSegmentStatusReply{
+			Statuses: []*idl.SegmentProcessStatus{
+				{DataDir: "/standby/gpseg-1", Running: true, Pid: 100, ClusterState: "in archive recovery"},
+				{DataDir: "/primary/gpseg0", Running: true, Pid: 200, ClusterState: "in production"},
+			},
+		}, nil)
+
+		sdw2 := mock_idl.NewMockAgentClient(ctrl)
+		sdw2.EXPECT().GetSegmentStatus(
+			gomock.Any(),
+			&idl.GetSegmentStatusRequest{DataDirs: []string{"/mirror/gpseg0"}},
+		).Return(nil, errors.New("error"))
+
+		hubServer.Conns = []*hub.Connection{
+			{AgentClient: sdw1, Hostname: "sdw1"},
+			{AgentClient: sdw2, Hostname: "sdw2"},
+		}
+
+		result, err := hubServer.GetSegmentStatuses(context.Background(), gparray)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := []struct {
+			dbid    int32
+			running bool
+			pid     int32
+			err     string
+		}{
+			{dbid: 1, running: false, pid: 0},
+			{dbid: 4, running: true, pid: 100},
+			{dbid: 2, running: true, pid: 200},
+			{dbid: 3, running: false, pid: 0, err: "error"},
+		}
+
+		if len(result) != len(expected) {
+			t.Fatalf("got %d segments, want %d", len(result), len(expected))
+		}
+
+		for i, exp := range expected {
+			got := result[i]
+			if got.Dbid != exp.dbid || got.Running != exp.running || got.Pid != exp.pid {
+				t.Fatalf("got %+v, want dbid %d, running %t and pid %d", got, exp.dbid, exp.running, exp.pid)
+			}
+
+			if exp.err != "" && got.Error != exp.err {
+				t.Fatalf("got error %q, want %q", got.Error, exp.err)
+			}
+		}
+	})
+
+	t.Run("errors out when the context is cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := hubServer.GetSegmentStatuses(ctx, gparray)
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("got %v, want %v", err, context.Canceled)
+		}
+	})
+}
+
+func TestClusterStatus(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	t.Run("reports the coordinator as not running and the status of the agents when the coordinator is down", func(t *testing.T) {
+		utils.System.ExecCommand = exectest.NewCommand(exectest.Failure)
+		defer utils.ResetSystemFunctions()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().Status(gomock.Any(), gomock.Any()).Return(&idl.StatusAgentReply{Status: "running", Pid: 300}, nil)
+
+		sdw2 := mock_idl.NewMockAgentClient(ctrl)
+		sdw2.EXPECT().Status(gomock.Any(), gomock.Any()).Return(nil, errors.New("error"))
+
+		hubServer := hub.New(testutils.CreateDummyServiceConfig(t))
+		hubServer.Conns = []*hub.Connection{
+			{AgentClient: sdw2, Hostname: "sdw2"},
+			{AgentClient: sdw1, Hostname: "sdw1"},
+		}
+
+		coordinatorDataDir := t.TempDir()
+		reply, err := hubServer.ClusterStatus(context.Background(), &idl.ClusterStatusRequest{CoordinatorDataDir: coordinatorDataDir})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !strings.HasPrefix(reply.CatalogError, "could not read the segment configuration") {
+			t.Fatalf("got %q, want the catalog error to be reported", reply.CatalogError)
+		}
+
+		if len(reply.Segments) != 1 || reply.Segments[0].Content != -1 || reply.Segments[0].DataDir != coordinatorDataDir || reply.Segments[0].Running || reply.Segments[0].Error == "" {
+			t.Fatalf("got %+v, want only the coordinator which is not running", reply.Segments)
+		}
+
+		expected := []*idl.ServiceStatus{
+			{Role: "Agent", Host: "sdw1", Status: "running", Pid: 300},
+			{Role: "Agent", Host: "sdw2", Status: "unreachable"},
+		}
+		if !reflect.DeepEqual(reply.Agents, expected) {
+			t.Fatalf("got %+v, want %+v", reply.Agents, expected)
+		}
+	})
+}
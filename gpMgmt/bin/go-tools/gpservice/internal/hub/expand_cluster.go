@@ -0,0 +1,511 @@
+package hub
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"github.com/greenplum-db/gp-common-go-libs/dbconn"
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"github.com/greenplum-db/gpdb/gpservice/constants"
+	"github.com/greenplum-db/gpdb/gpservice/idl"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/greenplum"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+)
+
+var ExpandTable = ExpandTableFn
+
+// ExpandStatus is the redistribution progress of an expansion. It is persisted in the
+// hub log directory so that an interrupted redistribution can be resumed later.
+type ExpandStatus struct {
+	Tables []RedistributeTable `json:"tables"`
+}
+
+type RedistributeTable struct {
+	Database string `json:"database"`
+	Name     string `json:"name"`
+	Done     bool   `json:"done"`
+}
+
+// ExpandCluster is the hub RPC which adds new segments to a running cluster and then
+// redistributes the existing tables across all the segments in batches. When called
+// without any new segments, it only resumes the redistribution of a previous expansion.
+func (s *Server) ExpandCluster(req *idl.ExpandClusterRequest, stream idl.Hub_ExpandClusterServer) error {
+	hubStream := NewHubStream(stream)
+
+	err := s.DialAllAgents()
+	if err != nil {
+		return utils.LogAndReturnError(err)
+	}
+
+	batchSize := int(req.BatchSize)
+	if batchSize <= 0 {
+		batchSize = constants.DefaultExpandBatchSize
+	}
+
+	statusFile := filepath.Join(s.LogDir, constants.ExpandStatusFileName)
+	_, statErr := utils.System.Stat(statusFile)
+
+	if len(req.SegmentPairs) > 0 {
+		if statErr == nil {
+			return utils.LogAndReturnError(fmt.Errorf("a previous expansion has not finished redistributing the tables, run gpctl expand --redistribute to complete it first"))
+		}
+
+		// A rollback removes everything listed in the cleanup file, which must only be the new primary segments
+		err = s.checkNoPendingCleanup()
+		if err != nil {
+			return utils.LogAndReturnError(err)
+		}
+
+		hubStream.StreamLogMsg("Starting to expand the cluster")
+		err = s.AddExpansionSegments(stream.Context(), &hubStream, req)
+		if err != nil {
+			return utils.LogAndReturnError(err)
+		}
+		hubStream.StreamLogMsg("Successfully added the new segments to the cluster")
+	}
+
+	if len(req.SegmentPairs) > 0 || statErr != nil {
+		hubStream.StreamLogMsg("Building the list of tables to redistribute")
+		err = CreateRedistributionPlan(stream.Context(), req.CoordinatorDataDir, statusFile)
+		if err != nil {
+			return utils.LogAndReturnError(err)
+		}
+	}
+
+	hubStream.StreamLogMsg("Starting to redistribute the tables")
+	err = s.RedistributeTables(stream.Context(), &hubStream, req.CoordinatorDataDir, statusFile, batchSize)
+	if err != nil {
+		return utils.LogAndReturnError(fmt.Errorf("redistributing tables, run gpctl expand --redistribute to resume: %w", err))
+	}
+	hubStream.StreamLogMsg("Successfully redistributed all the tables")
+
+	return nil
+}
+
+// AddExpansionSegments creates, starts and registers the new primary segments, and then
+// their mirrors. The new segments get the dbids and contents following the existing segments.
+func (s *Server) AddExpansionSegments(ctx context.Context, stream hubStreamer, req *idl.ExpandClusterRequest) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	conn, err := greenplum.GetCoordinatorConn(ctx, req.CoordinatorDataDir, "", true)
+	if err != nil {
+		return err
+	}
+	defer conn.DB.Close()
+
+	gparray, err := greenplum.NewGpArrayFromCatalog(conn.DB)
+	if err != nil {
+		return err
+	}
+
+	var primaries, mirrors []*idl.Segment
+	for _, pair := range req.SegmentPairs {
+		primaries = append(primaries, pair.Primary)
+		if pair.Mirror != nil {
+			mirrors = append(mirrors, pair.Mirror)
+		}
+	}
+
+	if gparray.HasMirrors() && len(mirrors) != len(primaries) {
+		return fmt.Errorf("the cluster is configured with mirrors, a mirror must be provided for each of the new primary segments")
+	}
+
+	if !gparray.HasMirrors() && len(mirrors) > 0 {
+		return fmt.Errorf("the cluster is not configured with mirrors, cannot add mirrors for the new primary segments")
+	}
+
+	var hostnames []string
+	for _, seg := range append(slices.Clone(primaries), mirrors...) {
+		if !slices.Contains(hostnames, seg.HostName) {
+			hostnames = append(hostnames, seg.HostName)
+		}
+	}
+
+	conns := getConnForHosts(s.connections(), hostnames)
+	if len(conns) != len(hostnames) {
+		var missing []string
+		for _, hostname := range hostnames {
+			if len(getConnForHosts(s.connections(), []string{hostname})) == 0 {
+				missing = append(missing, hostname)
+			}
+		}
+
+		return fmt.Errorf("following hostnames %v do not have gp services configured. Please configure the services", missing)
+	}
+
+	maxDbid := gparray.Coordinator.Dbid
+	if gparray.Standby != nil {
+		maxDbid = max(maxDbid, gparray.Standby.Dbid)
+	}
+	for _, seg := range gparray.GetAllSegments() {
+		maxDbid = max(maxDbid, seg.Dbid)
+	}
+
+	nextContent := 0
+	for _, seg := range gparray.GetAllSegments() {
+		nextContent = max(nextContent, seg.Content+1)
+	}
+	for idx, seg := range primaries {
+		seg.Dbid = int32(maxDbid + idx + 1)
+		seg.Contentid = int32(nextContent + idx)
+		if len(mirrors) > 0 {
+			mirrors[idx].Contentid = seg.Contentid
+		}
+	}
+
+	clusterParams, err := GetExpansionClusterParams(conn)
+	if err != nil {
+		return err
+	}
+	clusterParams.HbaHostnames = req.HbaHostnames
+
+	err = s.ValidateExpansionHosts(ctx, stream, conns, primaries, mirrors, clusterParams.Locale)
+	if err != nil {
+		return fmt.Errorf("validating hosts: %w", err)
+	}
+
+	var coordinatorAddrs []string
+	if req.HbaHostnames {
+		coordinatorAddrs = append(coordinatorAddrs, gparray.Coordinator.Address)
+	} else {
+		addrs, err := utils.GetHostAddrsNoLoopback()
+		if err != nil {
+			return err
+		}
+
+		coordinatorAddrs = append(coordinatorAddrs, addrs...)
+	}
+
+	var primarySegs []greenplum.Segment
+	for _, seg := range primaries {
+		primarySegs = append(primarySegs, greenplum.Segment{
+			Dbid:     int(seg.Dbid),
+			Content:  int(seg.Contentid),
+			Port:     int(seg.Port),
+			Hostname: seg.HostName,
+			Address:  seg.HostAddress,
+			DataDir:  seg.DataDirectory,
+		})
+	}
+
+	// The new segments are copies of the coordinator, so its catalog must not change until they
+	// are registered. Closing the connection on a failure releases the lock.
+	lockConn, err := greenplum.GetCoordinatorConn(ctx, req.CoordinatorDataDir, "", true)
+	if err != nil {
+		return err
+	}
+	defer lockConn.DB.Close()
+
+	stream.StreamLogMsg("Locking the catalog")
+	err = greenplum.LockCatalog(lockConn)
+	if err != nil {
+		return err
+	}
+
+	err = s.UpdatePgHbaConfWithExpansionEntries(ctx, gparray.Coordinator, primarySegs, req.HbaHostnames)
+	if err != nil {
+		return err
+	}
+
+	// Adding the new primary segments to the entries file so that a failed attempt can be cleaned up
+	filename := filepath.Join(s.LogDir, constants.CleanFileName)
+	err = WriteSegmentCleanupFile(primarySegs, filename)
+	if err != nil {
+		return err
+	}
+
+	stream.StreamLogMsg("Creating the new primary segments")
+	err = s.CreateSegments(ctx, stream, primarySegs, clusterParams, coordinatorAddrs, gparray.Coordinator, nil)
+	if err != nil {
+		return err
+	}
+
+	err = s.StartSegments(ctx, stream, primarySegs, "-c gp_role=execute")
+	if err != nil {
+		return fmt.Errorf("starting the new primary segments: %w", err)
+	}
+	stream.StreamLogMsg("Successfully created the new primary segments")
+
+	// The new primary segments are only registered once they are running, so that a failure to
+	// create them leaves the catalog untouched
+	stream.StreamLogMsg("Starting to register the new primary segments with the coordinator")
+	err = greenplum.RegisterExpansionPrimarySegments(primaries, conn)
+	if err != nil {
+		_, stopErr := s.StopSegments(ctx, stream, primarySegs, constants.ShutdownModeFast, 0)
+		if stopErr != nil {
+			gplog.Warn("failed to stop the new primary segments: %v", stopErr)
+		}
+
+		return err
+	}
+	stream.StreamLogMsg("Successfully registered the new primary segments with the coordinator")
+
+	// The new primary segments are now part of the cluster, so they should no longer be removed on a rollback
+	os.Remove(filename)
+
+	err = greenplum.UnlockCatalog(lockConn)
+	if err != nil {
+		return err
+	}
+	stream.StreamLogMsg("Unlocked the catalog")
+
+	if len(mirrors) == 0 {
+		return nil
+	}
+
+	// The mirrors are registered before they are created, so a failure from here on leaves
+	// them marked down in the catalog and they are recreated by a full recovery
+	err = s.addExpansionMirrors(ctx, stream, conn, mirrors, req.HbaHostnames)
+	if err != nil {
+		return fmt.Errorf("%w. The new mirror segments are registered with the cluster, run gpctl recover --full to create them", err)
+	}
+
+	return greenplum.TriggerFtsProbe(req.CoordinatorDataDir)
+}
+
+func (s *Server) addExpansionMirrors(ctx context.Context, stream hubStreamer, conn *utils.DBConnWithContext, mirrors []*idl.Segment, hbaHostnames bool) error {
+	stream.StreamLogMsg("Starting to register the new mirror segments with the coordinator")
+	err := greenplum.RegisterMirrorSegments(mirrors, conn)
+	if err != nil {
+		return err
+	}
+
+	gparray, err := greenplum.NewGpArrayFromCatalog(conn.DB)
+	if err != nil {
+		return err
+	}
+
+	err = s.UpdatePgHbaConfWithMirrorEntries(ctx, gparray, mirrors, hbaHostnames)
+	if err != nil {
+		return err
+	}
+
+	stream.StreamLogMsg("Creating the new mirror segments")
+	err = s.CreateMirrorSegments(stream, ctx, gparray, mirrors)
+	if err != nil {
+		return err
+	}
+
+	err = s.StartMirrorSegments(ctx, mirrors)
+	if err != nil {
+		return err
+	}
+	stream.StreamLogMsg("Successfully created the new mirror segments")
+
+	return nil
+}
+
+// ValidateExpansionHosts validates the environment of the hosts for the new segments
+func (s *Server) ValidateExpansionHosts(ctx context.Context, stream hubStreamer, conns []*Connection, primaries, mirrors []*idl.Segment, locale *idl.Locale) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	hostDirMap := make(map[string][]string)
+	hostPortMap := make(map[string][]string)
+	hostAddressMap := make(map[string]map[string]bool)
+	for _, seg := range append(slices.Clone(primaries), mirrors...) {
+		hostDirMap[seg.HostName] = append(hostDirMap[seg.HostName], seg.DataDirectory)
+		hostPortMap[seg.HostName] = append(hostPortMap[seg.HostName], fmt.Sprintf("%d", seg.Port))
+
+		if hostAddressMap[seg.HostName] == nil {
+			hostAddressMap[seg.HostName] = make(map[string]bool)
+		}
+		hostAddressMap[seg.HostName][seg.HostAddress] = true
+	}
+
+	return s.validateHosts(ctx, stream, conns, hostDirMap, hostPortMap, hostAddressMap, locale, false, false)
+}
+
+// GetExpansionClusterParams returns the encoding, locale and checksum settings of the running
+// cluster so that the new segments are initialized in the same way as the existing ones
+func GetExpansionClusterParams(conn *utils.DBConnWithContext) (*idl.ClusterParams, error) {
+	query := "SELECT name, setting FROM pg_catalog.pg_settings WHERE name IN ('server_encoding', 'lc_collate', 'lc_ctype', 'lc_messages', 'lc_monetary', 'lc_numeric', 'lc_time', 'data_checksums')"
+	rows, err := conn.DB.QueryContext(conn.Ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("getting the cluster settings: %w", err)
+	}
+	defer rows.Close()
+
+	settings := make(map[string]string)
+	for rows.Next() {
+		var name, setting string
+		if err := rows.Scan(&name, &setting); err != nil {
+			return nil, fmt.Errorf("getting the cluster settings: %w", err)
+		}
+		settings[name] = setting
+	}
+
+	return &idl.ClusterParams{
+		Encoding: settings["server_encoding"],
+		Locale: &idl.Locale{
+			LcAll:      settings["lc_ctype"],
+			LcCollate:  settings["lc_collate"],
+			LcCtype:    settings["lc_ctype"],
+			LcMessages: settings["lc_messages"],
+			LcMonetory: settings["lc_monetary"],
+			LcNumeric:  settings["lc_numeric"],
+			LcTime:     settings["lc_time"],
+		},
+		DataChecksums: settings["data_checksums"] == "on",
+	}, nil
+}
+
+// CreateRedistributionPlan lists the tables in all the databases which are not yet
+// distributed across all the primary segments and persists them to the status file
+func CreateRedistributionPlan(ctx context.Context, coordinatorDataDir, statusFile string) error {
+	conn, err := greenplum.GetCoordinatorConn(ctx, coordinatorDataDir, "")
+	if err != nil {
+		return err
+	}
+
+	databases, err := dbconn.SelectStringSlice(conn.DB, "SELECT datname FROM pg_catalog.pg_database WHERE datallowconn ORDER BY datname")
+	conn.DB.Close()
+	if err != nil {
+		return fmt.Errorf("getting the list of databases: %w", err)
+	}
+
+	tablesQuery := `SELECT c.oid::pg_catalog.regclass::text FROM pg_catalog.gp_distribution_policy p
+JOIN pg_catalog.pg_class c ON c.oid = p.localoid
+WHERE NOT c.relispartition
+AND p.numsegments < (SELECT count(*) FROM pg_catalog.gp_segment_configuration WHERE content >= 0 AND role = 'p')
+ORDER BY 1`
+
+	status := &ExpandStatus{}
+	for _, database := range databases {
+		conn, err := greenplum.GetCoordinatorConn(ctx, coordinatorDataDir, database)
+		if err != nil {
+			return err
+		}
+
+		tables, err := dbconn.SelectStringSlice(conn.DB, tablesQuery)
+		conn.DB.Close()
+		if err != nil {
+			return fmt.Errorf("getting the list of tables in database %s: %w", database, err)
+		}
+
+		for _, table := range tables {
+			status.Tables = append(status.Tables, RedistributeTable{Database: database, Name: table})
+		}
+	}
+
+	return WriteExpandStatus(statusFile, status)
+}
+
+// RedistributeTables expands the pending tables from the status file in batches. The status
+// file is updated after every batch so that a failed or cancelled redistribution can resume
+// from where it stopped. The status file is removed once all the tables are redistributed.
+func (s *Server) RedistributeTables(ctx context.Context, stream hubStreamer, coordinatorDataDir, statusFile string, batchSize int) error {
+	status, err := ReadExpandStatus(statusFile)
+	if err != nil {
+		return err
+	}
+
+	var pending []*RedistributeTable
+	for idx := range status.Tables {
+		if !status.Tables[idx].Done {
+			pending = append(pending, &status.Tables[idx])
+		}
+	}
+
+	progressLabel := "Redistributing tables:"
+	progressTotal := len(status.Tables)
+	current := progressTotal - len(pending)
+	stream.StreamProgressMsg(progressLabel, current, progressTotal)
+
+	for start := 0; start < len(pending); start += batchSize {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		batch := pending[start:min(start+batchSize, len(pending))]
+
+		var wg sync.WaitGroup
+		errs := make(chan error, len(batch))
+		for _, table := range batch {
+			wg.Add(1)
+
+			go func(table *RedistributeTable) {
+				defer wg.Done()
+
+				gplog.Debug("Redistributing table %s in database %s", table.Name, table.Database)
+				err := ExpandTable(ctx, coordinatorDataDir, table.Database, table.Name)
+				if err != nil {
+					errs <- fmt.Errorf("database: %s, table: %s, %w", table.Database, table.Name, err)
+					return
+				}
+
+				s.mutex.Lock()
+				defer s.mutex.Unlock()
+
+				table.Done = true
+				current++
+				stream.StreamProgressMsg(progressLabel, current, progressTotal)
+			}(table)
+		}
+
+		wg.Wait()
+		close(errs)
+
+		// Persist the progress of the batch even if some of its tables failed
+		err := WriteExpandStatus(statusFile, status)
+		for e := range errs {
+			err = errors.Join(err, e)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return utils.System.Remove(statusFile)
+}
+
+// ExpandTableFn redistributes the data of the given table across all the primary segments
+func ExpandTableFn(ctx context.Context, coordinatorDataDir, database, table string) error {
+	conn, err := greenplum.GetCoordinatorConn(ctx, coordinatorDataDir, database)
+	if err != nil {
+		return err
+	}
+	defer conn.DB.Close()
+
+	_, err = conn.DB.ExecContext(ctx, fmt.Sprintf("ALTER TABLE %s EXPAND TABLE", table))
+
+	return err
+}
+
+func ReadExpandStatus(statusFile string) (*ExpandStatus, error) {
+	content, err := utils.System.ReadFile(statusFile)
+	if err != nil {
+		return nil, fmt.Errorf("reading the expansion status file: %w", err)
+	}
+
+	var status ExpandStatus
+	err = json.Unmarshal(content, &status)
+	if err != nil {
+		return nil, fmt.Errorf("parsing the expansion status file %s: %w", statusFile, err)
+	}
+
+	return &status, nil
+}
+
+func WriteExpandStatus(statusFile string, status *ExpandStatus) error {
+	content, err := json.MarshalIndent(status, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	err = utils.System.WriteFile(statusFile, content, 0644)
+	if err != nil {
+		return fmt.Errorf("writing the expansion status file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,275 @@
+package hub_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/greenplum-db/gp-common-go-libs/dbconn"
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+	"github.com/greenplum-db/gpdb/gpservice/idl"
+	"github.com/greenplum-db/gpdb/gpservice/internal/hub"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+	"github.com/greenplum-db/gpdb/gpservice/testutils"
+)
+
+func TestRedistributeTables(t *testing.T) {
+	testhelper.SetupTestLogger()
+	hubServer := hub.New(testutils.CreateDummyServiceConfig(t))
+
+	status := &hub.ExpandStatus{
+		Tables: []hub.RedistributeTable{
+			{Database: "db1", Name: "public.t1", Done: true},
+			{Database: "db1", Name: "public.t2"},
+			{Database: "db2", Name: "public.t3"},
+			{Database: "db2", Name: "public.t4"},
+		},
+	}
+
+	t.Run("resumes the redistribution from the pending tables", func(t *testing.T) {
+		statusFile := filepath.Join(t.TempDir(), "gpexpand_status.json")
+		err := hub.WriteExpandStatus(statusFile, status)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var mutex sync.Mutex
+		var expanded []string
+		hub.ExpandTable = func(ctx context.Context, coordinatorDataDir, database, table string) error {
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			expanded = append(expanded, database+"."+table)
+			return nil
+		}
+		defer func() { hub.ExpandTable = hub.ExpandTableFn }()
+
+		mock, stream := testutils.NewMockStream()
+		err = hubServer.RedistributeTables(context.Background(), mock, "gpseg-1", statusFile, 2)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(expanded) != 3 || strings.Contains(strings.Join(expanded, ","), "public.t1") {
+			t.Fatalf("got %v, want only the pending tables to be expanded", expanded)
+		}
+
+		var lastMsg *idl.ProgressMessage
+		for _, reply := range stream.GetBuffer() {
+			lastMsg = reply.GetProgressMsg()
+		}
+		if lastMsg == nil || lastMsg.Current != 4 || lastMsg.Total != 4 {
+			t.Fatalf("got %+v, want the progress to be complete", lastMsg)
+		}
+
+		if _, err := os.Stat(statusFile); !os.IsNotExist(err) {
+			t.Fatalf("expected the status file to be removed, got %v", err)
+		}
+	})
+
+	t.Run("persists the progress of the batch when a table fails to redistribute", func(t *testing.T) {
+		statusFile := filepath.Join(t.TempDir(), "gpexpand_status.json")
+		err := hub.WriteExpandStatus(statusFile, status)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expectedErr := errors.New("error")
+		hub.ExpandTable = func(ctx context.Context, coordinatorDataDir, database, table string) error {
+			if table == "public.t3" {
+				return expectedErr
+			}
+
+			return nil
+		}
+		defer func() { hub.ExpandTable = hub.ExpandTableFn }()
+
+		mock, _ := testutils.NewMockStream()
+		err = hubServer.RedistributeTables(context.Background(), mock, "gpseg-1", statusFile, 2)
+		if !errors.Is(err, expectedErr) {
+			t.Fatalf("got %v, want %v", err, expectedErr)
+		}
+
+		expectedErrStr := "database: db2, table: public.t3, error"
+		if !strings.Contains(err.Error(), expectedErrStr) {
+			t.Fatalf("got %v, want %s", err, expectedErrStr)
+		}
+
+		result, err := hub.ReadExpandStatus(statusFile)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := &hub.ExpandStatus{
+			Tables: []hub.RedistributeTable{
+				{Database: "db1", Name: "public.t1", Done: true},
+				{Database: "db1", Name: "public.t2", Done: true},
+				{Database: "db2", Name: "public.t3"},
+				{Database: "db2", Name: "public.t4"},
+			},
+		}
+		if !reflect.DeepEqual(result, expected) {
+			t.Fatalf("got %+v, want %+v", result, expected)
+		}
+	})
+
+	t.Run("errors out when the status file cannot be read", func(t *testing.T) {
+		mock, _ := testutils.NewMockStream()
+		err := hubServer.RedistributeTables(context.Background(), mock, "gpseg-1", filepath.Join(t.TempDir(), "nonexistent"), 2)
+
+		expectedErr := "reading the expansion status file"
+		if err == nil || !strings.Contains(err.Error(), expectedErr) {
+			t.Fatalf("got %v, want %s", err, expectedErr)
+		}
+	})
+}
+
+func TestGetExpansionClusterParams(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	t.Run("returns the settings of the running cluster", func(t *testing.T) {
+		conn, mock := testutils.CreateAndConnectMockDBWithContext(t, context.Background(), 1)
+
+		rows := sqlmock.NewRows([]string{"name", "setting"}).
+			AddRow("server_encoding", "UTF8").
+			AddRow("lc_collate", "en_US.utf8").
+			AddRow("lc_ctype", "en_US.utf8").
+			AddRow("lc_messages", "C").
+			AddRow("lc_monetary", "en_US.utf8").
+			AddRow("lc_numeric", "en_US.utf8").
+			AddRow("lc_time", "en_US.utf8").
+			AddRow("data_checksums", "on")
+		mock.ExpectQuery("SELECT name, setting FROM pg_catalog.pg_settings").WillReturnRows(rows)
+
+		result, err := hub.GetExpansionClusterParams(conn)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := &idl.ClusterParams{
+			Encoding: "UTF8",
+			Locale: &idl.Locale{
+				LcAll:      "en_US.utf8",
+				LcCollate:  "en_US.utf8",
+				LcCtype:    "en_US.utf8",
+				LcMessages: "C",
+				LcMonetory: "en_US.utf8",
+				LcNumeric:  "en_US.utf8",
+				LcTime:     "en_US.utf8",
+			},
+			DataChecksums: true,
+		}
+		if !reflect.DeepEqual(result, expected) {
+			t.Fatalf("got %+v, want %+v", result, expected)
+		}
+	})
+
+	t.Run("errors out when the query fails", func(t *testing.T) {
+		conn, mock := testutils.CreateAndConnectMockDBWithContext(t, context.Background(), 1)
+
+		expectedErr := errors.New("error")
+		mock.ExpectQuery("SELECT").WillReturnError(expectedErr)
+
+		_, err := hub.GetExpansionClusterParams(conn)
+		if !errors.Is(err, expectedErr) {
+			t.Fatalf("got %v, want %v", err, expectedErr)
+		}
+	})
+}
+
+func TestExpandCluster(t *testing.T) {
+	testhelper.SetupTestLogger()
+	initialize(t)
+
+	t.Run("errors out when a previous expansion is still in progress", func(t *testing.T) {
+		utils.System.Stat = func(name string) (os.FileInfo, error) {
+			return nil, nil
+		}
+		defer utils.ResetSystemFunctions()
+
+		hubServer.Conns = []*hub.Connection{}
+
+		_, stream := testutils.NewMockStream()
+		err := hubServer.ExpandCluster(&idl.ExpandClusterRequest{
+			SegmentPairs: []*idl.SegmentPair{{Primary: &idl.Segment{HostName: "sdw3"}}},
+		}, stream)
+
+		expectedErr := "a previous expansion has not finished redistributing the tables"
+		if err == nil || !strings.Contains(err.Error(), expectedErr) {
+			t.Fatalf("got %v, want %s", err, expectedErr)
+		}
+	})
+}
+
+func TestAddExpansionSegments(t *testing.T) {
+	testhelper.SetupTestLogger()
+	initialize(t)
+
+	setupCatalog := func(t *testing.T) {
+		utils.System.Open = func(name string) (*os.File, error) {
+			reader, writer, _ := os.Pipe()
+			defer writer.Close()
+
+			_, err := writer.WriteString("port=1234")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			return reader, nil
+		}
+
+		utils.SetNewDBConnFromEnvironment(func(dbname string) *dbconn.DBConn {
+			conn, mock := testutils.CreateMockDBConnForUtilityMode(t)
+			testhelper.ExpectVersionQuery(mock, "7.0.0")
+
+			rows := sqlmock.NewRows([]string{"dbid", "content", "role", "preferredrole", "port", "hostname", "address", "datadir"})
+			addSegmentRows(t, rows, coordinator, primary1, primary2, mirror1, mirror2)
+			mock.ExpectQuery("SELECT").WillReturnRows(rows)
+			return conn
+		})
+	}
+
+	t.Run("errors out when the mirrors are not provided for a cluster with mirrors", func(t *testing.T) {
+		setupCatalog(t)
+		defer utils.ResetSystemFunctions()
+		defer utils.ResetNewDBConnFromEnvironment()
+
+		mock, _ := testutils.NewMockStream()
+		err := hubServer.AddExpansionSegments(context.Background(), mock, &idl.ExpandClusterRequest{
+			SegmentPairs: []*idl.SegmentPair{{Primary: &idl.Segment{HostName: "sdw3"}}},
+		})
+
+		expectedErr := "the cluster is configured with mirrors, a mirror must be provided for each of the new primary segments"
+		if err == nil || err.Error() != expectedErr {
+			t.Fatalf("got %v, want %s", err, expectedErr)
+		}
+	})
+
+	t.Run("errors out when the new hosts do not have gp services configured", func(t *testing.T) {
+		setupCatalog(t)
+		defer utils.ResetSystemFunctions()
+		defer utils.ResetNewDBConnFromEnvironment()
+
+		hubServer.Conns = []*hub.Connection{{Hostname: "sdw1"}, {Hostname: "sdw2"}}
+
+		mock, _ := testutils.NewMockStream()
+		err := hubServer.AddExpansionSegments(context.Background(), mock, &idl.ExpandClusterRequest{
+			SegmentPairs: []*idl.SegmentPair{
+				{Primary: &idl.Segment{HostName: "sdw3"}, Mirror: &idl.Segment{HostName: "sdw4"}},
+				{Primary: &idl.Segment{HostName: "sdw4"}, Mirror: &idl.Segment{HostName: "sdw1"}},
+			},
+		})
+
+		expectedErr := "following hostnames [sdw3 sdw4] do not have gp services configured. Please configure the services"
+		if err == nil || err.Error() != expectedErr {
+			t.Fatalf("got %v, want %s", err, expectedErr)
+		}
+	})
+}
@@ -0,0 +1,208 @@
+package hub
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"github.com/greenplum-db/gpdb/gpservice/idl"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+	"google.golang.org/grpc/codes"
+	grpcStatus "google.golang.org/grpc/status"
+)
+
+/*
+PushFile writes the file in the request to the given hosts through the agents running on them.
+It fails with codes.FailedPrecondition when a host is not managed by the hub or its agent cannot
+be reached, so that the caller knows the file can still be copied by other means.
+*/
+func (s *Server) PushFile(ctx context.Context, req *idl.PushFileRequest) (*idl.PushFileReply, error) {
+	err := s.checkManagedHosts(req.Hostnames)
+	if err != nil {
+		return &idl.PushFileReply{}, utils.LogAndReturnError(grpcStatus.Error(codes.FailedPrecondition, err.Error()))
+	}
+
+	err = s.DialAllAgents()
+	if err != nil {
+		return &idl.PushFileReply{}, utils.LogAndReturnError(grpcStatus.Errorf(codes.FailedPrecondition, "could not connect to the agents: %v", err))
+	}
+
+	header := &idl.FileHeader{Path: req.Path, Mode: req.Mode, Size: int64(len(req.Contents))}
+	err = ExecuteRPC(getConnForHosts(s.connections(), req.Hostnames), func(conn *Connection) error {
+		return PutFile(ctx, conn.AgentClient, header, bytes.NewReader(req.Contents))
+	})
+	if grpcStatus.Code(err) == codes.Unavailable {
+		return &idl.PushFileReply{}, utils.LogAndReturnError(grpcStatus.Errorf(codes.FailedPrecondition, "could not push %s to the hosts: %v", req.Path, err))
+	}
+	if err != nil {
+		return &idl.PushFileReply{}, utils.LogAndReturnError(fmt.Errorf("could not push %s to the hosts: %w", req.Path, err))
+	}
+
+	gplog.Info("Pushed %s to hosts %v", req.Path, req.Hostnames)
+	return &idl.PushFileReply{}, nil
+}
+
+/*
+CollectFile fetches the file at the given path from each of the hosts, and writes
+it to a directory named after the host within the destination directory on the
+hub host, keeping its file mode.
+*/
+func (s *Server) CollectFile(ctx context.Context, req *idl.CollectFileRequest) (*idl.CollectFileReply, error) {
+	if !filepath.IsAbs(req.DestDir) {
+		return &idl.CollectFileReply{}, utils.LogAndReturnError(fmt.Errorf("destination directory %q must be an absolute path", req.DestDir))
+	}
+
+	err := s.checkManagedHosts(req.Hostnames)
+	if err != nil {
+		return &idl.CollectFileReply{}, utils.LogAndReturnError(err)
+	}
+
+	err = s.DialAllAgents()
+	if err != nil {
+		return &idl.CollectFileReply{}, utils.LogAndReturnError(err)
+	}
+
+	err = ExecuteRPC(getConnForHosts(s.connections(), req.Hostnames), func(conn *Connection) error {
+		hostDir := filepath.Join(req.DestDir, conn.Hostname)
+		err := os.MkdirAll(hostDir, 0700)
+		if err != nil {
+			return fmt.Errorf("could not create directory %s: %w", hostDir, err)
+		}
+
+		writer, err := utils.NewAtomicFileWriter(filepath.Join(hostDir, filepath.Base(req.Path)), 0600)
+		if err != nil {
+			return err
+		}
+
+		header, err := GetFile(ctx, conn.AgentClient, req.Path, writer)
+		if err != nil {
+			writer.Abort()
+			return err
+		}
+
+		writer.SetMode(os.FileMode(header.Mode).Perm())
+		return writer.Commit()
+	})
+	if err != nil {
+		return &idl.CollectFileReply{}, utils.LogAndReturnError(fmt.Errorf("could not collect %s from the hosts: %w", req.Path, err))
+	}
+
+	gplog.Info("Collected %s from hosts %v into %s", req.Path, req.Hostnames, req.DestDir)
+	return &idl.CollectFileReply{}, nil
+}
+
+// checkManagedHosts returns an error for the first of the hosts not managed by the hub
+func (s *Server) checkManagedHosts(hostnames []string) error {
+	managed := s.hostnames()
+	for _, host := range hostnames {
+		if !slices.Contains(managed, host) {
+			return fmt.Errorf("host %s is not managed by the hub service", host)
+		}
+	}
+
+	return nil
+}
+
+// PutFile streams the contents to the agent in chunks, preceded by the header
+func PutFile(ctx context.Context, client idl.AgentClient, header *idl.FileHeader, contents io.Reader) error {
+	stream, err := client.PutFile(ctx)
+	if err != nil {
+		return fmt.Errorf("could not put file %s: %w", header.Path, err)
+	}
+
+	err = stream.Send(&idl.PutFileRequest{Content: &idl.PutFileRequest_Header{Header: header}})
+	if err != nil {
+		return putFileError(stream, header.Path, err)
+	}
+
+	buffer := make([]byte, utils.FileChunkSize)
+	for {
+		n, err := contents.Read(buffer)
+		if n > 0 {
+			chunk := &idl.FileChunk{Data: buffer[:n], Checksum: utils.ChunkChecksum(buffer[:n])}
+			sendErr := stream.Send(&idl.PutFileRequest{Content: &idl.PutFileRequest_Chunk{Chunk: chunk}})
+			if sendErr != nil {
+				return putFileError(stream, header.Path, sendErr)
+			}
+		}
+
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("could not read %s: %w", header.Path, err)
+		}
+	}
+
+	_, err = stream.CloseAndRecv()
+	if err != nil {
+		return fmt.Errorf("could not put file %s: %w", header.Path, err)
+	}
+
+	return nil
+}
+
+// putFileError returns the error of the agent when it aborted the stream, instead of the EOF seen by the sender
+func putFileError(stream idl.Agent_PutFileClient, path string, err error) error {
+	if errors.Is(err, io.EOF) {
+		_, err = stream.CloseAndRecv()
+	}
+
+	return fmt.Errorf("could not put file %s: %w", path, err)
+}
+
+// GetFile streams the file at the given path from the agent to the writer, verifying each chunk
+func GetFile(ctx context.Context, client idl.AgentClient, path string, writer io.Writer) (*idl.FileHeader, error) {
+	stream, err := client.GetFile(ctx, &idl.GetFileRequest{Path: path})
+	if err != nil {
+		return nil, fmt.Errorf("could not get file %s: %w", path, err)
+	}
+
+	reply, err := stream.Recv()
+	if err != nil {
+		return nil, fmt.Errorf("could not get file %s: %w", path, err)
+	}
+
+	header := reply.GetHeader()
+	if header == nil {
+		return nil, fmt.Errorf("expected the header of file %s as the first message", path)
+	}
+
+	var size int64
+	for {
+		reply, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not get file %s: %w", path, err)
+		}
+
+		chunk := reply.GetChunk()
+		if chunk == nil {
+			return nil, fmt.Errorf("expected a chunk of file %s", path)
+		}
+
+		if utils.ChunkChecksum(chunk.Data) != chunk.Checksum {
+			return nil, fmt.Errorf("checksum mismatch for the chunk at offset %d of %s", size, path)
+		}
+
+		_, err = writer.Write(chunk.Data)
+		if err != nil {
+			return nil, fmt.Errorf("could not write file %s: %w", path, err)
+		}
+		size += int64(len(chunk.Data))
+	}
+
+	if size != header.Size {
+		return nil, fmt.Errorf("received %d bytes of %s, expected %d", size, path, header.Size)
+	}
+
+	return header, nil
+}
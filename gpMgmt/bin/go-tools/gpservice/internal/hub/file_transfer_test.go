@@ -0,0 +1,263 @@
+package hub_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+	"github.com/greenplum-db/gpdb/gpservice/idl"
+	"github.com/greenplum-db/gpdb/gpservice/idl/mock_idl"
+	"github.com/greenplum-db/gpdb/gpservice/internal/hub"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+	"github.com/greenplum-db/gpdb/gpservice/testutils"
+	"google.golang.org/grpc/codes"
+	grpcStatus "google.golang.org/grpc/status"
+)
+
+func TestPushFile(t *testing.T) {
+	testhelper.SetupTestLogger()
+	hubConfig := testutils.CreateDummyServiceConfig(t)
+
+	t.Run("streams the file to the agents in chunks", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		hubServer := hub.New(hubConfig)
+
+		contents := []byte(strings.Repeat("x", utils.FileChunkSize+10))
+		var conns []*hub.Connection
+		var received [][]byte
+		for _, host := range []string{"sdw1", "sdw2"} {
+			var data []byte
+			stream := mock_idl.NewMockAgent_PutFileClient(ctrl)
+			gomock.InOrder(
+				stream.EXPECT().Send(&idl.PutFileRequest{Content: &idl.PutFileRequest_Header{
+					Header: &idl.FileHeader{Path: "/etc/gpservice.conf", Mode: 0644, Size: int64(len(contents))},
+				}}).Return(nil),
+				stream.EXPECT().Send(gomock.Any()).DoAndReturn(func(req *idl.PutFileRequest) error {
+					chunk := req.GetChunk()
+					if utils.ChunkChecksum(chunk.Data) != chunk.Checksum {
+						t.Fatalf("got invalid checksum %d for the chunk", chunk.Checksum)
+					}
+					data = append(data, chunk.Data...)
+					return nil
+				}).Times(2),
+				stream.EXPECT().CloseAndRecv().DoAndReturn(func() (*idl.PutFileReply, error) {
+					received = append(received, data)
+					return &idl.PutFileReply{}, nil
+				}),
+			)
+
+			client := mock_idl.NewMockAgentClient(ctrl)
+			client.EXPECT().PutFile(gomock.Any()).Return(stream, nil)
+			conns = append(conns, &hub.Connection{AgentClient: client, Hostname: host})
+		}
+		hubServer.Conns = conns
+
+		_, err := hubServer.PushFile(context.Background(), &idl.PushFileRequest{
+			Hostnames: []string{"sdw1", "sdw2"},
+			Path:      "/etc/gpservice.conf",
+			Mode:      0644,
+			Contents:  contents,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		for _, data := range received {
+			if string(data) != string(contents) {
+				t.Fatalf("got %d bytes, want %d", len(data), len(contents))
+			}
+		}
+	})
+
+	t.Run("returns the error of the agent when it aborts the stream", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		hubServer := hub.New(hubConfig)
+
+		stream := mock_idl.NewMockAgent_PutFileClient(ctrl)
+		stream.EXPECT().Send(gomock.Any()).Return(io.EOF)
+		stream.EXPECT().CloseAndRecv().Return(nil, errors.New("writing to /etc/passwd is not allowed"))
+
+		client := mock_idl.NewMockAgentClient(ctrl)
+		client.EXPECT().PutFile(gomock.Any()).Return(stream, nil)
+		hubServer.Conns = []*hub.Connection{{AgentClient: client, Hostname: "sdw1"}}
+
+		_, err := hubServer.PushFile(context.Background(), &idl.PushFileRequest{
+			Hostnames: []string{"sdw1"},
+			Path:      "/etc/passwd",
+		})
+		expected := "could not push /etc/passwd to the hosts: host: sdw1, could not put file /etc/passwd: writing to /etc/passwd is not allowed"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+
+	t.Run("errors out when the host is not managed by the hub", func(t *testing.T) {
+		hubServer := hub.New(hubConfig)
+
+		_, err := hubServer.PushFile(context.Background(), &idl.PushFileRequest{
+			Hostnames: []string{"sdw3"},
+			Path:      "/etc/gpservice.conf",
+		})
+		expected := "rpc error: code = FailedPrecondition desc = host sdw3 is not managed by the hub service"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+
+	t.Run("errors out with a failed precondition when the agent is unavailable", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		hubServer := hub.New(hubConfig)
+
+		client := mock_idl.NewMockAgentClient(ctrl)
+		client.EXPECT().PutFile(gomock.Any()).Return(nil, grpcStatus.Error(codes.Unavailable, "connection refused"))
+		hubServer.Conns = []*hub.Connection{{AgentClient: client, Hostname: "sdw1"}}
+
+		_, err := hubServer.PushFile(context.Background(), &idl.PushFileRequest{
+			Hostnames: []string{"sdw1"},
+			Path:      "/etc/gpservice.conf",
+		})
+		if grpcStatus.Code(err) != codes.FailedPrecondition {
+			t.Fatalf("got %v, want code %s", err, codes.FailedPrecondition)
+		}
+	})
+}
+
+func TestCollectFile(t *testing.T) {
+	testhelper.SetupTestLogger()
+	hubConfig := testutils.CreateDummyServiceConfig(t)
+
+	getFileStream := func(ctrl *gomock.Controller, header *idl.FileHeader, chunks ...*idl.FileChunk) *mock_idl.MockAgent_GetFileClient {
+		stream := mock_idl.NewMockAgent_GetFileClient(ctrl)
+		calls := []*gomock.Call{
+			stream.EXPECT().Recv().Return(&idl.GetFileReply{Content: &idl.GetFileReply_Header{Header: header}}, nil),
+		}
+		for _, chunk := range chunks {
+			calls = append(calls, stream.EXPECT().Recv().Return(&idl.GetFileReply{Content: &idl.GetFileReply_Chunk{Chunk: chunk}}, nil))
+		}
+		calls = append(calls, stream.EXPECT().Recv().Return(nil, io.EOF))
+		gomock.InOrder(calls...)
+
+		return stream
+	}
+
+	t.Run("writes the file of each host to its own directory", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		hubServer := hub.New(hubConfig)
+		destDir := t.TempDir()
+
+		var conns []*hub.Connection
+		for _, host := range []string{"sdw1", "sdw2"} {
+			data := []byte("log of " + host)
+			stream := getFileStream(ctrl,
+				&idl.FileHeader{Path: "/var/log/gpservice.log", Mode: 0640, Size: int64(len(data))},
+				&idl.FileChunk{Data: data, Checksum: utils.ChunkChecksum(data)},
+			)
+
+			client := mock_idl.NewMockAgentClient(ctrl)
+			client.EXPECT().GetFile(gomock.Any(), &idl.GetFileRequest{Path: "/var/log/gpservice.log"}).Return(stream, nil)
+			conns = append(conns, &hub.Connection{AgentClient: client, Hostname: host})
+		}
+		hubServer.Conns = conns
+
+		_, err := hubServer.CollectFile(context.Background(), &idl.CollectFileRequest{
+			Hostnames: []string{"sdw1", "sdw2"},
+			Path:      "/var/log/gpservice.log",
+			DestDir:   destDir,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		for _, host := range []string{"sdw1", "sdw2"} {
+			path := filepath.Join(destDir, host, "gpservice.log")
+			info, err := os.Stat(path)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if info.Mode().Perm() != 0640 {
+				t.Fatalf("got mode %o, want 640", info.Mode().Perm())
+			}
+
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(data) != "log of "+host {
+				t.Fatalf("got %q, want %q", data, "log of "+host)
+			}
+		}
+	})
+
+	t.Run("does not write the file when a chunk is corrupted", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		hubServer := hub.New(hubConfig)
+		destDir := t.TempDir()
+
+		data := []byte("contents")
+		stream := mock_idl.NewMockAgent_GetFileClient(ctrl)
+		gomock.InOrder(
+			stream.EXPECT().Recv().Return(&idl.GetFileReply{Content: &idl.GetFileReply_Header{
+				Header: &idl.FileHeader{Path: "/var/log/gpservice.log", Mode: 0640, Size: int64(len(data))},
+			}}, nil),
+			stream.EXPECT().Recv().Return(&idl.GetFileReply{Content: &idl.GetFileReply_Chunk{
+				Chunk: &idl.FileChunk{Data: data, Checksum: utils.ChunkChecksum(data) + 1},
+			}}, nil),
+		)
+
+		client := mock_idl.NewMockAgentClient(ctrl)
+		client.EXPECT().GetFile(gomock.Any(), gomock.Any()).Return(stream, nil)
+		hubServer.Conns = []*hub.Connection{{AgentClient: client, Hostname: "sdw1"}}
+
+		_, err := hubServer.CollectFile(context.Background(), &idl.CollectFileRequest{
+			Hostnames: []string{"sdw1"},
+			Path:      "/var/log/gpservice.log",
+			DestDir:   destDir,
+		})
+		expected := "could not collect /var/log/gpservice.log from the hosts: host: sdw1, checksum mismatch for the chunk at offset 0 of /var/log/gpservice.log"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+
+		entries, err := os.ReadDir(filepath.Join(destDir, "sdw1"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(entries) != 0 {
+			t.Fatalf("expected no files to be written, got %v", entries)
+		}
+	})
+
+	t.Run("errors out when the destination directory is relative", func(t *testing.T) {
+		hubServer := hub.New(hubConfig)
+
+		_, err := hubServer.CollectFile(context.Background(), &idl.CollectFileRequest{
+			Hostnames: []string{"sdw1"},
+			Path:      "/var/log/gpservice.log",
+			DestDir:   "logs",
+		})
+		expected := `destination directory "logs" must be an absolute path`
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+
+	t.Run("errors out when the host is not managed by the hub", func(t *testing.T) {
+		hubServer := hub.New(hubConfig)
+
+		_, err := hubServer.CollectFile(context.Background(), &idl.CollectFileRequest{
+			Hostnames: []string{"sdw3"},
+			Path:      "/var/log/gpservice.log",
+			DestDir:   t.TempDir(),
+		})
+		expected := "host sdw3 is not managed by the hub service"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+}
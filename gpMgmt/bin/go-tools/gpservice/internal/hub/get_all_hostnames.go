@@ -11,6 +11,7 @@ import (
 
 	"github.com/greenplum-db/gp-common-go-libs/gplog"
 	"github.com/greenplum-db/gpdb/gpservice/idl"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 )
 
@@ -46,7 +47,7 @@ func (s *Server) ConnectHostList(hostList []string) (map[string]idl.AgentClient,
 	if err != nil {
 		return nil, err
 	}
-	opts = append(opts, grpc.WithTransportCredentials(credentials))
+	opts = append(opts, grpc.WithTransportCredentials(credentials), grpc.WithStatsHandler(otelgrpc.NewClientHandler()))
 
 	return GetConnectionOnHostList(opts, s.AgentPort, hostList)
 }
@@ -66,7 +67,7 @@ func (s *Server) GetAllHostNames(ctx context.Context, request *idl.GetAllHostNam
 		go func(addr string, connection idl.AgentClient) {
 			defer wg.Done()
 			request := idl.GetHostNameRequest{}
-			reply, err := connection.GetHostName(context.Background(), &request)
+			reply, err := connection.GetHostName(ctx, &request)
 			if err != nil {
 				errs <- fmt.Errorf("host: %s, %w", addr, err)
 				errs <- utils.LogAndReturnError(fmt.Errorf("getting hostname for %s failed with error:%v", addr, err))
@@ -0,0 +1,161 @@
+package hub
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/greenplum-db/gpdb/gpservice/idl"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+)
+
+// InitStep is a step of the cluster initialization which is recorded in the init journal once completed
+type InitStep string
+
+const (
+	InitStepCoordinatorCreated InitStep = "coordinator-created"
+	InitStepSegmentsRegistered InitStep = "segments-registered"
+	InitStepPrimariesCreated   InitStep = "primaries-created"
+	InitStepClusterRestarted   InitStep = "cluster-restarted"
+	InitStepExtensionsCreated  InitStep = "extensions-created"
+	InitStepCollationsImported InitStep = "collations-imported"
+	InitStepDatabaseCreated    InitStep = "database-created"
+	InitStepPasswordSet        InitStep = "password-set"
+	InitStepStandbyCreated     InitStep = "standby-created"
+	InitStepMirrorsCreated     InitStep = "mirrors-created"
+)
+
+/*
+InitJournal keeps track of the completed steps of a cluster initialization so that
+a failed initialization can be resumed from the last completed step instead of being
+rolled back. The journal is written to the hub log directory after every step.
+The request it was created for is stored without the superuser password, and is
+used to make sure that a resumed initialization uses the same configuration.
+*/
+type InitJournal struct {
+	Request   *idl.MakeClusterRequest `json:"request"`
+	Steps     []InitStep              `json:"steps"`
+	Primaries []int32                 `json:"primaries"`
+
+	path  string
+	mutex sync.Mutex
+}
+
+// NewInitJournal creates an empty journal for the given request and writes it to path
+func NewInitJournal(path string, request *idl.MakeClusterRequest) (*InitJournal, error) {
+	journal := &InitJournal{
+		Request: journalRequest(request),
+		path:    path,
+	}
+
+	err := journal.write()
+	if err != nil {
+		return nil, err
+	}
+
+	return journal, nil
+}
+
+// ReadInitJournal reads the journal of a previous cluster initialization from path
+func ReadInitJournal(path string) (*InitJournal, error) {
+	contents, err := utils.System.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	journal := &InitJournal{path: path}
+	err = json.Unmarshal(contents, journal)
+	if err != nil {
+		return nil, fmt.Errorf("parsing init journal %s: %w", path, err)
+	}
+
+	return journal, nil
+}
+
+// Matches reports whether the journal was created for the same configuration as the given request
+func (j *InitJournal) Matches(request *idl.MakeClusterRequest) bool {
+	return proto.Equal(j.Request, journalRequest(request))
+}
+
+// Done reports whether the given step has been completed
+func (j *InitJournal) Done(step InitStep) bool {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	return slices.Contains(j.Steps, step)
+}
+
+// Complete records the given step as completed
+func (j *InitJournal) Complete(step InitStep) error {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	if !slices.Contains(j.Steps, step) {
+		j.Steps = append(j.Steps, step)
+	}
+
+	return j.write()
+}
+
+// PrimaryCreated reports whether the primary segment with the given dbid has been created
+func (j *InitJournal) PrimaryCreated(dbid int32) bool {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	return slices.Contains(j.Primaries, dbid)
+}
+
+// AddPrimary records the primary segment with the given dbid as created
+func (j *InitJournal) AddPrimary(dbid int32) error {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	if !slices.Contains(j.Primaries, dbid) {
+		j.Primaries = append(j.Primaries, dbid)
+	}
+
+	return j.write()
+}
+
+// Reset discards all the completed steps so that the initialization starts over
+func (j *InitJournal) Reset() error {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	j.Steps = nil
+	j.Primaries = nil
+
+	return j.write()
+}
+
+func (j *InitJournal) write() error {
+	contents, err := json.MarshalIndent(j, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding init journal: %w", err)
+	}
+
+	err = utils.System.WriteFile(j.path, contents, 0600)
+	if err != nil {
+		return fmt.Errorf("writing init journal %s: %w", j.path, err)
+	}
+
+	return nil
+}
+
+// journalRequest returns a copy of the request with only the fields which
+// describe the cluster, leaving out the superuser password and the flags
+func journalRequest(request *idl.MakeClusterRequest) *idl.MakeClusterRequest {
+	journalReq := &idl.MakeClusterRequest{
+		GpArray:       request.GpArray,
+		ClusterParams: request.ClusterParams,
+	}
+	journalReq = proto.Clone(journalReq).(*idl.MakeClusterRequest)
+	if journalReq.ClusterParams != nil {
+		journalReq.ClusterParams.SuPassword = ""
+	}
+
+	return journalReq
+}
@@ -0,0 +1,246 @@
+package hub_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+
+	"github.com/greenplum-db/gpdb/gpservice/idl"
+	"github.com/greenplum-db/gpdb/gpservice/idl/mock_idl"
+	"github.com/greenplum-db/gpdb/gpservice/internal/hub"
+	"github.com/greenplum-db/gpdb/gpservice/testutils"
+)
+
+func TestInitJournal(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	request := &idl.MakeClusterRequest{
+		GpArray: &idl.GpArray{
+			Coordinator: &idl.Segment{HostName: "cdw", HostAddress: "cdw", Port: 7000, DataDirectory: "/data/gpseg-1"},
+			SegmentArray: []*idl.SegmentPair{
+				{Primary: &idl.Segment{HostName: "sdw1", HostAddress: "sdw1", Port: 7002, DataDirectory: "/data/gpseg0"}},
+			},
+		},
+		ClusterParams: &idl.ClusterParams{
+			SuPassword:    "secret",
+			SegmentConfig: map[string]string{"max_connections": "100"},
+		},
+		ForceFlag: true,
+	}
+
+	t.Run("persists the completed steps and primary segments", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "journal.json")
+
+		journal, err := hub.NewInitJournal(path, request)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		err = journal.Complete(hub.InitStepCoordinatorCreated)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		err = journal.AddPrimary(2)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		result, err := hub.ReadInitJournal(path)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		if !result.Done(hub.InitStepCoordinatorCreated) {
+			t.Fatalf("expected step %s to be done", hub.InitStepCoordinatorCreated)
+		}
+
+		if result.Done(hub.InitStepSegmentsRegistered) {
+			t.Fatalf("expected step %s to not be done", hub.InitStepSegmentsRegistered)
+		}
+
+		if !result.PrimaryCreated(2) || result.PrimaryCreated(3) {
+			t.Fatalf("got primaries %v, want [2]", result.Primaries)
+		}
+
+		if !result.Matches(request) {
+			t.Fatalf("expected the journal to match the request")
+		}
+	})
+
+	t.Run("does not store the superuser password", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "journal.json")
+
+		_, err := hub.NewInitJournal(path, request)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		if strings.Contains(string(contents), "secret") {
+			t.Fatalf("expected the journal to not contain the password, got %s", contents)
+		}
+
+		if request.ClusterParams.SuPassword != "secret" {
+			t.Fatalf("expected the request to not be modified")
+		}
+	})
+
+	t.Run("does not match a request with a different configuration", func(t *testing.T) {
+		journal, err := hub.NewInitJournal(filepath.Join(t.TempDir(), "journal.json"), request)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		resumeRequest := &idl.MakeClusterRequest{
+			GpArray:       request.GpArray,
+			ClusterParams: &idl.ClusterParams{SuPassword: "other", SegmentConfig: map[string]string{"max_connections": "100"}},
+			Resume:        true,
+		}
+		if !journal.Matches(resumeRequest) {
+			t.Fatalf("expected the journal to match a request which only differs in the password and flags")
+		}
+
+		resumeRequest.ClusterParams.SegmentConfig = map[string]string{"max_connections": "200"}
+		if journal.Matches(resumeRequest) {
+			t.Fatalf("expected the journal to not match a request with a different configuration")
+		}
+	})
+
+	t.Run("reset discards the completed steps", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "journal.json")
+
+		journal, err := hub.NewInitJournal(path, request)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		for _, step := range []hub.InitStep{hub.InitStepCoordinatorCreated, hub.InitStepCoordinatorCreated} {
+			err = journal.Complete(step)
+			if err != nil {
+				t.Fatalf("unexpected error: %#v", err)
+			}
+		}
+
+		if !reflect.DeepEqual(journal.Steps, []hub.InitStep{hub.InitStepCoordinatorCreated}) {
+			t.Fatalf("got %v, want the step to be recorded once", journal.Steps)
+		}
+
+		err = journal.Reset()
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		result, err := hub.ReadInitJournal(path)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		if len(result.Steps) != 0 || len(result.Primaries) != 0 {
+			t.Fatalf("expected no completed steps, got %v and primaries %v", result.Steps, result.Primaries)
+		}
+	})
+
+	t.Run("errors out when the journal does not exist", func(t *testing.T) {
+		_, err := hub.ReadInitJournal(filepath.Join(t.TempDir(), "journal.json"))
+		if !errors.Is(err, os.ErrNotExist) {
+			t.Fatalf("got %v, want %v", err, os.ErrNotExist)
+		}
+	})
+
+	t.Run("errors out when the journal is not valid", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "journal.json")
+		err := os.WriteFile(path, []byte("{"), 0600)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		_, err = hub.ReadInitJournal(path)
+		expected := "parsing init journal"
+		if err == nil || !strings.Contains(err.Error(), expected) {
+			t.Fatalf("got %v, want error containing %s", err, expected)
+		}
+	})
+}
+
+func TestPrepareInitResume(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	coordinator := &idl.Segment{HostName: "cdw", DataDirectory: "/data/gpseg-1"}
+
+	t.Run("removes the coordinator and starts over when the segments were not registered", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		hubServer := hub.New(testutils.CreateDummyServiceConfig(t))
+
+		cdw := mock_idl.NewMockAgentClient(ctrl)
+		cdw.EXPECT().RemoveDirectory(
+			gomock.Any(),
+			&idl.RemoveDirectoryRequest{DataDirectory: "/data/gpseg-1"},
+		).Return(&idl.RemoveDirectoryReply{}, nil)
+		hubServer.Conns = []*hub.Connection{
+			{AgentClient: cdw, Hostname: "cdw"},
+		}
+
+		journal, err := hub.NewInitJournal(filepath.Join(t.TempDir(), "journal.json"), &idl.MakeClusterRequest{})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		err = journal.Complete(hub.InitStepCoordinatorCreated)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		mock, _ := testutils.NewMockStream()
+		utilityMode, err := hubServer.PrepareInitResume(context.Background(), mock, journal, coordinator)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		if utilityMode {
+			t.Fatalf("expected the coordinator to not be started")
+		}
+
+		if journal.Done(hub.InitStepCoordinatorCreated) {
+			t.Fatalf("expected the journal to be reset")
+		}
+	})
+
+	t.Run("errors out when not able to remove the coordinator", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		hubServer := hub.New(testutils.CreateDummyServiceConfig(t))
+
+		expectedErr := errors.New("error")
+		cdw := mock_idl.NewMockAgentClient(ctrl)
+		cdw.EXPECT().RemoveDirectory(gomock.Any(), gomock.Any()).Return(nil, expectedErr)
+		hubServer.Conns = []*hub.Connection{
+			{AgentClient: cdw, Hostname: "cdw"},
+		}
+
+		journal, err := hub.NewInitJournal(filepath.Join(t.TempDir(), "journal.json"), &idl.MakeClusterRequest{})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		mock, _ := testutils.NewMockStream()
+		_, err = hubServer.PrepareInitResume(context.Background(), mock, journal, coordinator)
+		if !errors.Is(err, expectedErr) {
+			t.Fatalf("got %v, want %v", err, expectedErr)
+		}
+	})
+}
@@ -7,12 +7,15 @@ import (
 	"os"
 	"path/filepath"
 	"sync"
+	"time"
 
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/exp/maps"
 
 	"github.com/greenplum-db/gp-common-go-libs/gplog"
 	"github.com/greenplum-db/gpdb/gpservice/constants"
 	"github.com/greenplum-db/gpdb/gpservice/idl"
+	"github.com/greenplum-db/gpdb/gpservice/internal/metrics"
 	"github.com/greenplum-db/gpdb/gpservice/pkg/greenplum"
 	"github.com/greenplum-db/gpdb/gpservice/pkg/postgres"
 	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
@@ -21,6 +24,7 @@ import (
 func (s *Server) MakeCluster(request *idl.MakeClusterRequest, stream idl.Hub_MakeClusterServer) error {
 	var err error
 	var shutdownCoordinator, mirrorless bool
+	var journal *InitJournal
 
 	mirrorless = len(request.GetMirrorSegments()) == 0
 	hubStream := NewHubStream(stream)
@@ -36,11 +40,23 @@ func (s *Server) MakeCluster(request *idl.MakeClusterRequest, stream idl.Hub_Mak
 		}
 	}()
 
-	// Check if entries.txt file exists and if it exists give user a message to clean the previous run.
 	filename := filepath.Join(s.LogDir, constants.CleanFileName)
-	_, err = utils.System.Stat(filename)
-	if err == nil {
-		return utils.LogAndReturnError(fmt.Errorf("gpinitsystem has failed previously. Run gpctl init --clean before creating cluster again"))
+	journalFile := filepath.Join(s.LogDir, constants.InitJournalFileName)
+	if request.Resume {
+		journal, err = ReadInitJournal(journalFile)
+		if err != nil {
+			return utils.LogAndReturnError(fmt.Errorf("no failed cluster initialization found to resume: %w", err))
+		}
+
+		if !journal.Matches(request) {
+			return utils.LogAndReturnError(fmt.Errorf("the configuration does not match the one used by the failed cluster initialization, provide the same configuration to resume"))
+		}
+	} else {
+		// Check if entries.txt file exists and if it exists give user a message to clean the previous run.
+		_, err = utils.System.Stat(filename)
+		if err == nil {
+			return utils.LogAndReturnError(fmt.Errorf("gpinitsystem has failed previously. Run gpctl init --resume to continue or gpctl init --clean before creating cluster again"))
+		}
 	}
 
 	err = s.DialAllAgents()
@@ -48,50 +64,87 @@ func (s *Server) MakeCluster(request *idl.MakeClusterRequest, stream idl.Hub_Mak
 		return utils.LogAndReturnError(err)
 	}
 
-	hubStream.StreamLogMsg("Starting to create the cluster")
-	err = s.ValidateEnvironment(stream.Context(), &hubStream, request)
-	if err != nil {
-		return utils.LogAndReturnError(fmt.Errorf("validating hosts: %w", err))
+	if request.Resume {
+		hubStream.StreamLogMsg("Resuming the creation of the cluster")
+		shutdownCoordinator, err = s.PrepareInitResume(stream.Context(), &hubStream, journal, request.GpArray.Coordinator)
+		if err != nil {
+			return utils.LogAndReturnError(err)
+		}
+	} else {
+		hubStream.StreamLogMsg("Starting to create the cluster")
+		err = s.ValidateEnvironment(stream.Context(), &hubStream, request)
+		if err != nil {
+			return utils.LogAndReturnError(fmt.Errorf("validating hosts: %w", err))
+		}
+
+		if request.DryRun {
+			hubStream.StreamLogMsg("Dry run completed, the hosts are ready for creating the cluster")
+			return nil
+		}
+
+		journal, err = NewInitJournal(journalFile, request)
+		if err != nil {
+			return utils.LogAndReturnError(err)
+		}
 	}
 
-	seg := greenplum.Segment{}
-	seg.Hostname = request.GpArray.Coordinator.HostName
-	seg.DataDir = request.GpArray.Coordinator.DataDirectory
+	if !journal.Done(InitStepCoordinatorCreated) {
+		ctx, step := s.startInitStep(stream.Context(), InitStepCoordinatorCreated)
+		defer step.end()
 
-	var segArray []greenplum.Segment
-	segArray = append(segArray, seg)
+		seg := greenplum.Segment{}
+		seg.Hostname = request.GpArray.Coordinator.HostName
+		seg.DataDir = request.GpArray.Coordinator.DataDirectory
 
-	err = WriteSegmentCleanupFile(segArray, filename)
-	if err != nil {
-		return utils.LogAndReturnError(err)
-	}
+		var segArray []greenplum.Segment
+		segArray = append(segArray, seg)
 
-	hubStream.StreamLogMsg("Creating coordinator segment")
-	err = s.CreateAndStartCoordinator(stream.Context(), request.GpArray.Coordinator, request.ClusterParams)
-	if err != nil {
-		return utils.LogAndReturnError(err)
-	}
-	hubStream.StreamLogMsg("Successfully created coordinator segment")
+		err = WriteSegmentCleanupFile(segArray, filename)
+		if err != nil {
+			return utils.LogAndReturnError(err)
+		}
+
+		hubStream.StreamLogMsg("Creating coordinator segment")
+		err = s.CreateAndStartCoordinator(ctx, request.GpArray.Coordinator, request.ClusterParams)
+		if err != nil {
+			return utils.LogAndReturnError(err)
+		}
+		hubStream.StreamLogMsg("Successfully created coordinator segment")
 
-	shutdownCoordinator = true
+		shutdownCoordinator = true
 
-	hubStream.StreamLogMsg("Starting to register primary segments with the coordinator")
+		err = step.complete(journal)
+		if err != nil {
+			return utils.LogAndReturnError(err)
+		}
+	}
 
 	conn, err := greenplum.GetCoordinatorConn(stream.Context(), request.GpArray.Coordinator.DataDirectory, "template1", true)
 	if err != nil {
 		return utils.LogAndReturnError(err)
 	}
 
-	err = greenplum.RegisterCoordinator(request.GpArray.Coordinator, conn)
-	if err != nil {
-		return utils.LogAndReturnError(err)
-	}
+	if !journal.Done(InitStepSegmentsRegistered) {
+		_, step := s.startInitStep(stream.Context(), InitStepSegmentsRegistered)
+		defer step.end()
 
-	err = greenplum.RegisterPrimarySegments(request.GetPrimarySegments(), conn)
-	if err != nil {
-		return utils.LogAndReturnError(err)
+		hubStream.StreamLogMsg("Starting to register primary segments with the coordinator")
+		err = greenplum.RegisterCoordinator(request.GpArray.Coordinator, conn)
+		if err != nil {
+			return utils.LogAndReturnError(err)
+		}
+
+		err = greenplum.RegisterPrimarySegments(request.GetPrimarySegments(), conn)
+		if err != nil {
+			return utils.LogAndReturnError(err)
+		}
+		hubStream.StreamLogMsg("Successfully registered primary segments with the coordinator")
+
+		err = step.complete(journal)
+		if err != nil {
+			return utils.LogAndReturnError(err)
+		}
 	}
-	hubStream.StreamLogMsg("Successfully registered primary segments with the coordinator")
 
 	gparray, err := greenplum.NewGpArrayFromCatalog(conn.DB)
 	if err != nil {
@@ -101,82 +154,218 @@ func (s *Server) MakeCluster(request *idl.MakeClusterRequest, stream idl.Hub_Mak
 
 	primarySegs := gparray.GetPrimarySegments()
 
-	var coordinatorAddrs []string
-	if request.ClusterParams.HbaHostnames {
-		coordinatorAddrs = append(coordinatorAddrs, request.GpArray.Coordinator.HostAddress)
-	} else {
-		addrs, err := utils.GetHostAddrsNoLoopback()
+	if !journal.Done(InitStepPrimariesCreated) {
+		ctx, step := s.startInitStep(stream.Context(), InitStepPrimariesCreated)
+		defer step.end()
+
+		var coordinatorAddrs []string
+		if request.ClusterParams.HbaHostnames {
+			coordinatorAddrs = append(coordinatorAddrs, request.GpArray.Coordinator.HostAddress)
+		} else {
+			addrs, err := utils.GetHostAddrsNoLoopback()
+			if err != nil {
+				return utils.LogAndReturnError(err)
+			}
+
+			coordinatorAddrs = append(coordinatorAddrs, addrs...)
+		}
+
+		// Only the segments which were not created by a previous attempt need to be created
+		var pendingSegs []greenplum.Segment
+		for _, seg := range primarySegs {
+			if !journal.PrimaryCreated(int32(seg.Dbid)) {
+				pendingSegs = append(pendingSegs, seg)
+			}
+		}
+
+		err = WriteSegmentCleanupFile(pendingSegs, filename)
 		if err != nil {
 			return utils.LogAndReturnError(err)
 		}
 
-		coordinatorAddrs = append(coordinatorAddrs, addrs...)
-	}
+		if request.Resume {
+			err = s.RemoveDataDirectories(ctx, segmentHostDataDirMap(pendingSegs))
+			if err != nil {
+				return utils.LogAndReturnError(err)
+			}
+		}
 
-	err = WriteSegmentCleanupFile(primarySegs, filename)
-	if err != nil {
-		return utils.LogAndReturnError(err)
-	}
-	hubStream.StreamLogMsg("Creating primary segments")
-	err = s.CreateSegments(stream.Context(), &hubStream, primarySegs, request.ClusterParams, coordinatorAddrs)
-	if err != nil {
-		return utils.LogAndReturnError(err)
+		hubStream.StreamLogMsg("Creating primary segments")
+		err = s.CreateSegments(ctx, &hubStream, pendingSegs, request.ClusterParams, coordinatorAddrs, nil, func(seg *idl.Segment) {
+			if err := journal.AddPrimary(seg.Dbid); err != nil {
+				gplog.Warn("failed to record the primary segment %s in the init journal: %v", seg.DataDirectory, err)
+			}
+		})
+		if err != nil {
+			return utils.LogAndReturnError(err)
+		}
+		hubStream.StreamLogMsg("Successfully created primary segments")
+
+		err = step.complete(journal)
+		if err != nil {
+			return utils.LogAndReturnError(err)
+		}
 	}
-	hubStream.StreamLogMsg("Successfully created primary segments")
 
 	shutdownCoordinator = false
 
-	hubStream.StreamLogMsg("Restarting the Greenplum cluster in production mode")
-	err = s.StopCoordinator(&hubStream, request.GpArray.Coordinator.DataDirectory)
-	if err != nil {
-		return utils.LogAndReturnError(err)
-	}
+	if !journal.Done(InitStepClusterRestarted) {
+		ctx, step := s.startInitStep(stream.Context(), InitStepClusterRestarted)
+		defer step.end()
 
-	// TODO: Replace this with the new gp start once it is complete
-	gpstartOptions := &greenplum.GpStart{
-		DataDirectory: request.GpArray.Coordinator.DataDirectory,
-		Verbose:       request.Verbose,
-	}
-	cmd := utils.NewGpSourcedCommandContext(stream.Context(), gpstartOptions, s.GpHome)
-	err = hubStream.StreamExecCommand(cmd, s.GpHome)
-	if err != nil {
-		return utils.LogAndReturnError(fmt.Errorf("executing gpstart: %w", err))
+		hubStream.StreamLogMsg("Restarting the Greenplum cluster in production mode")
+		err = s.StopCoordinator(&hubStream, request.GpArray.Coordinator.DataDirectory)
+		if err != nil {
+			return utils.LogAndReturnError(err)
+		}
+
+		// A previous attempt might have already started some of the segments
+		segsToStart := primarySegs
+		if request.Resume {
+			segsToStart, err = s.getStoppedSegments(ctx, gparray)
+			if err != nil {
+				return utils.LogAndReturnError(err)
+			}
+		}
+
+		err = s.StartSegments(ctx, &hubStream, segsToStart, "-c gp_role=execute")
+		if err != nil {
+			return utils.LogAndReturnError(fmt.Errorf("starting primary segments: %w", err))
+		}
+
+		err = s.StartCoordinator(ctx, &hubStream, request.GpArray.Coordinator.DataDirectory, "")
+		if err != nil {
+			return utils.LogAndReturnError(err)
+		}
+		hubStream.StreamLogMsg("Completed restart of Greenplum cluster in production mode")
+
+		err = step.complete(journal)
+		if err != nil {
+			return utils.LogAndReturnError(err)
+		}
 	}
-	hubStream.StreamLogMsg("Completed restart of Greenplum cluster in production mode")
 
-	hubStream.StreamLogMsg("Creating core GPDB extensions")
-	err = CreateGpToolkitExt(conn)
-	if err != nil {
-		return utils.LogAndReturnError(err)
+	if !journal.Done(InitStepExtensionsCreated) {
+		_, step := s.startInitStep(stream.Context(), InitStepExtensionsCreated)
+		defer step.end()
+
+		hubStream.StreamLogMsg("Creating core GPDB extensions")
+		err = CreateGpToolkitExt(conn)
+		if err != nil {
+			return utils.LogAndReturnError(err)
+		}
+		hubStream.StreamLogMsg("Successfully created core GPDB extensions")
+
+		err = step.complete(journal)
+		if err != nil {
+			return utils.LogAndReturnError(err)
+		}
 	}
-	hubStream.StreamLogMsg("Successfully created core GPDB extensions")
 
-	hubStream.StreamLogMsg("Importing system collations")
-	err = ImportCollation(conn)
-	if err != nil {
-		return utils.LogAndReturnError(err)
+	if !journal.Done(InitStepCollationsImported) {
+		_, step := s.startInitStep(stream.Context(), InitStepCollationsImported)
+		defer step.end()
+
+		hubStream.StreamLogMsg("Importing system collations")
+		err = ImportCollation(conn)
+		if err != nil {
+			return utils.LogAndReturnError(err)
+		}
+
+		err = step.complete(journal)
+		if err != nil {
+			return utils.LogAndReturnError(err)
+		}
 	}
 
-	if request.ClusterParams.DbName != "" {
+	if request.ClusterParams.DbName != "" && !journal.Done(InitStepDatabaseCreated) {
+		_, step := s.startInitStep(stream.Context(), InitStepDatabaseCreated)
+		defer step.end()
+
 		hubStream.StreamLogMsg(fmt.Sprintf("Creating database %q", request.ClusterParams.DbName))
 		err = CreateDatabase(conn, request.ClusterParams.DbName)
 		if err != nil {
 			return utils.LogAndReturnError(err)
 		}
+
+		err = step.complete(journal)
+		if err != nil {
+			return utils.LogAndReturnError(err)
+		}
 	}
 
-	hubStream.StreamLogMsg("Setting Greenplum superuser password")
-	err = SetGpUserPasswd(conn, request.ClusterParams.SuPassword)
-	if err != nil {
-		return utils.LogAndReturnError(err)
+	if !journal.Done(InitStepPasswordSet) {
+		_, step := s.startInitStep(stream.Context(), InitStepPasswordSet)
+		defer step.end()
+
+		hubStream.StreamLogMsg("Setting Greenplum superuser password")
+		err = SetGpUserPasswd(conn, request.ClusterParams.SuPassword)
+		if err != nil {
+			return utils.LogAndReturnError(err)
+		}
+
+		err = step.complete(journal)
+		if err != nil {
+			return utils.LogAndReturnError(err)
+		}
 	}
 
-	if !mirrorless {
+	if request.GpArray.Standby != nil && !journal.Done(InitStepStandbyCreated) {
+		ctx, step := s.startInitStep(stream.Context(), InitStepStandbyCreated)
+		defer step.end()
+
+		standby := greenplum.Segment{Hostname: request.GpArray.Standby.HostName, DataDir: request.GpArray.Standby.DataDirectory}
+		err = WriteSegmentCleanupFile([]greenplum.Segment{standby}, filename)
+		if err != nil {
+			return utils.LogAndReturnError(err)
+		}
+
+		if request.Resume {
+			err = s.RemoveDataDirectories(ctx, segmentHostDataDirMap([]greenplum.Segment{standby}))
+			if err != nil {
+				return utils.LogAndReturnError(err)
+			}
+		}
+
+		// The standby can only be registered over a utility mode connection
+		standbyConn, err := greenplum.GetCoordinatorConn(ctx, request.GpArray.Coordinator.DataDirectory, "", true)
+		if err != nil {
+			return utils.LogAndReturnError(err)
+		}
+
+		err = s.CreateStandby(ctx, &hubStream, standbyConn, gparray.Coordinator, request.GpArray.Standby, request.ClusterParams.HbaHostnames)
+		standbyConn.DB.Close()
+		if err != nil {
+			return utils.LogAndReturnError(err)
+		}
+
+		err = step.complete(journal)
+		if err != nil {
+			return utils.LogAndReturnError(err)
+		}
+	}
+
+	if !mirrorless && !journal.Done(InitStepMirrorsCreated) {
+		ctx, step := s.startInitStep(stream.Context(), InitStepMirrorsCreated)
+		defer step.end()
+
 		mirrorSegs, err := populateMirrorWithContentId(gparray, request.GpArray.SegmentArray)
 		if err != nil {
 			return err
 		}
 
+		if request.Resume {
+			var mirrors []greenplum.Segment
+			for _, mirror := range mirrorSegs {
+				mirrors = append(mirrors, greenplum.Segment{Hostname: mirror.HostName, DataDir: mirror.DataDirectory})
+			}
+
+			err = s.RemoveDataDirectories(ctx, segmentHostDataDirMap(mirrors))
+			if err != nil {
+				return utils.LogAndReturnError(err)
+			}
+		}
+
 		addMirrosReq := &idl.AddMirrorsRequest{
 			CoordinatorDataDir: request.GpArray.Coordinator.DataDirectory,
 			Mirrors:            mirrorSegs,
@@ -185,21 +374,97 @@ func (s *Server) MakeCluster(request *idl.MakeClusterRequest, stream idl.Hub_Mak
 		if err != nil {
 			return err
 		}
+
+		err = step.complete(journal)
+		if err != nil {
+			return utils.LogAndReturnError(err)
+		}
 	}
 
-	// If we reach till here cluster is created successfully. So remove the entries file
+	// If we reach till here cluster is created successfully. So remove the entries file and the journal
 	os.Remove(filename)
+	os.Remove(journalFile)
 
 	return nil
 }
 
+/*
+PrepareInitResume brings the coordinator back to the state which the remaining steps of
+a failed cluster initialization expect. If the segments were not registered, the catalog
+of the coordinator cannot be trusted, so the coordinator is removed to be created again.
+Until the cluster has been restarted in production mode the coordinator runs in utility
+mode, after which the whole cluster is started if the coordinator is not running.
+It reports whether the coordinator has been started in utility mode.
+*/
+func (s *Server) PrepareInitResume(ctx context.Context, stream hubStreamer, journal *InitJournal, coordinator *idl.Segment) (bool, error) {
+	if !journal.Done(InitStepSegmentsRegistered) {
+		stream.StreamLogMsg("Segments were not registered with the coordinator, removing the coordinator segment to create it again")
+		err := s.RemoveDataDirectories(ctx, map[string][]string{coordinator.HostName: {coordinator.DataDirectory}})
+		if err != nil {
+			return false, err
+		}
+
+		return false, journal.Reset()
+	}
+
+	running, _, err := postgres.GetPostmasterStatus(s.GpHome, coordinator.DataDirectory)
+	if err != nil {
+		return false, err
+	}
+
+	if !journal.Done(InitStepClusterRestarted) {
+		if running {
+			return true, nil
+		}
+
+		stream.StreamLogMsg("Starting the coordinator segment in utility mode")
+		return true, s.StartCoordinator(ctx, stream, coordinator.DataDirectory, "-c gp_role=utility")
+	}
+
+	if !running {
+		stream.StreamLogMsg("Starting the Greenplum cluster")
+		return false, s.StartClusterFromCatalog(ctx, stream, coordinator.DataDirectory)
+	}
+
+	return false, nil
+}
+
+// getStoppedSegments returns the primary segments of the cluster which are not running
+func (s *Server) getStoppedSegments(ctx context.Context, gparray *greenplum.GpArray) ([]greenplum.Segment, error) {
+	statuses, err := s.GetSegmentStatuses(ctx, &greenplum.GpArray{SegmentPairs: gparray.SegmentPairs})
+	if err != nil {
+		return nil, err
+	}
+
+	running := make(map[int32]bool)
+	for _, status := range statuses {
+		running[status.Dbid] = status.Running
+	}
+
+	var segs []greenplum.Segment
+	for _, seg := range gparray.GetPrimarySegments() {
+		if !running[int32(seg.Dbid)] {
+			segs = append(segs, seg)
+		}
+	}
+
+	return segs, nil
+}
+
+func segmentHostDataDirMap(segs []greenplum.Segment) map[string][]string {
+	hostDataDirMap := make(map[string][]string)
+	for _, seg := range segs {
+		hostDataDirMap[seg.Hostname] = append(hostDataDirMap[seg.Hostname], seg.DataDir)
+	}
+
+	return hostDataDirMap
+}
+
 func (s *Server) ValidateEnvironment(ctx context.Context, stream hubStreamer, request *idl.MakeClusterRequest) error {
 	if ctx.Err() != nil {
 		return ctx.Err()
 	}
 
-	var replies []*idl.LogMessage
-
 	gparray := request.GpArray
 	hostDirMap := make(map[string][]string)
 	hostPortMap := make(map[string][]string)
@@ -232,8 +497,28 @@ func (s *Server) ValidateEnvironment(ctx context.Context, stream hubStreamer, re
 		}
 		hostAddressMap[seg.HostName][seg.HostAddress] = true
 	}
+	// Add standby to the map
+	if gparray.Standby != nil {
+		seg := gparray.Standby
+		hostDirMap[seg.HostName] = append(hostDirMap[seg.HostName], seg.DataDirectory)
+		hostPortMap[seg.HostName] = append(hostPortMap[seg.HostName], fmt.Sprintf("%d", seg.Port))
+
+		if hostAddressMap[seg.HostName] == nil {
+			hostAddressMap[seg.HostName] = make(map[string]bool)
+		}
+		hostAddressMap[seg.HostName][seg.HostAddress] = true
+	}
 	gplog.Debug("Host-Address-Map:[%v]", hostAddressMap)
 
+	return s.validateHosts(ctx, stream, s.connections(), hostDirMap, hostPortMap, hostAddressMap, request.ClusterParams.Locale, request.ForceFlag, request.DryRun)
+}
+
+// validateHosts runs the host environment validation on the given hosts for the
+// data directories, ports and addresses of the segments which are going to be created.
+// In a dry run, the non-empty data directories are only reported even when forced.
+func (s *Server) validateHosts(ctx context.Context, stream hubStreamer, conns []*Connection, hostDirMap, hostPortMap map[string][]string, hostAddressMap map[string]map[string]bool, locale *idl.Locale, forced, dryRun bool) error {
+	var replies []*idl.LogMessage
+
 	localPgVersion, err := greenplum.GetPostgresGpVersion(s.GpHome)
 	if err != nil {
 		gplog.Error("fetching postgres gp-version:%v", err)
@@ -257,9 +542,10 @@ func (s *Server) ValidateEnvironment(ctx context.Context, stream hubStreamer, re
 
 		validateReq := idl.ValidateHostEnvRequest{
 			DirectoryList:   dirList,
-			Locale:          request.ClusterParams.Locale,
+			Locale:          locale,
 			PortList:        portList,
-			Forced:          request.ForceFlag,
+			Forced:          forced,
+			DryRun:          dryRun,
 			HostAddressList: addressList,
 			GpVersion:       localPgVersion,
 		}
@@ -284,7 +570,7 @@ func (s *Server) ValidateEnvironment(ctx context.Context, stream hubStreamer, re
 		return nil
 	}
 
-	err = ExecuteRPC(s.Conns, validateFn)
+	err = ExecuteRPC(conns, validateFn)
 	if err != nil {
 		return err
 	}
@@ -296,7 +582,7 @@ func (s *Server) ValidateEnvironment(ctx context.Context, stream hubStreamer, re
 	return nil
 }
 
-func CreateSingleSegment(ctx context.Context, conn *Connection, seg *idl.Segment, clusterParams *idl.ClusterParams, coordinatorAddrs []string) error {
+func CreateSingleSegment(ctx context.Context, conn *Connection, seg *idl.Segment, clusterParams *idl.ClusterParams, coordinatorAddrs []string, template *idl.Segment) error {
 	pgConfig := make(map[string]string)
 	maps.Copy(pgConfig, clusterParams.CommonConfig)
 	if seg.Contentid == -1 {
@@ -313,6 +599,7 @@ func CreateSingleSegment(ctx context.Context, conn *Connection, seg *idl.Segment
 		CoordinatorAddrs: coordinatorAddrs,
 		HbaHostNames:     clusterParams.HbaHostnames,
 		DataChecksums:    clusterParams.DataChecksums,
+		Template:         template,
 	}
 
 	_, err := conn.AgentClient.MakeSegment(ctx, makeSegmentReq)
@@ -328,12 +615,12 @@ func (s *Server) CreateAndStartCoordinator(ctx context.Context, seg *idl.Segment
 		return ctx.Err()
 	}
 
-	coordinatorConn := getConnForHosts(s.Conns, []string{seg.HostName})
+	coordinatorConn := getConnForHosts(s.connections(), []string{seg.HostName})
 
 	seg.Contentid = -1
 	seg.Dbid = 1
 	request := func(conn *Connection) error {
-		err := CreateSingleSegment(ctx, conn, seg, clusterParams, []string{})
+		err := CreateSingleSegment(ctx, conn, seg, clusterParams, []string{}, nil)
 		if err != nil {
 			return err
 		}
@@ -366,11 +653,26 @@ func (s *Server) StopCoordinator(stream hubStreamer, pgdata string) error {
 	return nil
 }
 
-func (s *Server) CreateSegments(ctx context.Context, stream hubStreamer, segs []greenplum.Segment, clusterParams *idl.ClusterParams, coordinatorAddrs []string) error {
+// CreateSegments creates the given primary segments in parallel. The segments are copies of
+// the template segment if one is given, and are initialized from scratch otherwise. The
+// created callback, if provided, is called for each segment as soon as it has been created.
+func (s *Server) CreateSegments(ctx context.Context, stream hubStreamer, segs []greenplum.Segment, clusterParams *idl.ClusterParams, coordinatorAddrs []string, template *greenplum.Segment, created func(seg *idl.Segment)) error {
 	if ctx.Err() != nil {
 		return ctx.Err()
 	}
 
+	var templateReq *idl.Segment
+	if template != nil {
+		templateReq = &idl.Segment{
+			Port:          int32(template.Port),
+			DataDirectory: template.DataDir,
+			HostName:      template.Hostname,
+			HostAddress:   template.Address,
+			Contentid:     int32(template.Content),
+			Dbid:          int32(template.Dbid),
+		}
+	}
+
 	hostSegmentMap := map[string][]*idl.Segment{}
 	for _, seg := range segs {
 		segReq := &idl.Segment{
@@ -406,7 +708,7 @@ func (s *Server) CreateSegments(ctx context.Context, stream hubStreamer, segs []
 				defer wg.Done()
 
 				gplog.Debug(fmt.Sprintf("Starting to create primary segment: %s", seg))
-				err := CreateSingleSegment(ctx, conn, seg, clusterParams, coordinatorAddrs)
+				err := CreateSingleSegment(ctx, conn, seg, clusterParams, coordinatorAddrs, templateReq)
 				if err != nil {
 					errs <- err
 				} else {
@@ -416,6 +718,9 @@ func (s *Server) CreateSegments(ctx context.Context, stream hubStreamer, segs []
 
 					stream.StreamProgressMsg(progressLabel, current, progressTotal)
 					gplog.Debug(fmt.Sprintf("Successfully created primary segment: %s", seg))
+					if created != nil {
+						created(seg)
+					}
 				}
 			}(seg)
 		}
@@ -430,7 +735,7 @@ func (s *Server) CreateSegments(ctx context.Context, stream hubStreamer, segs []
 		return err
 	}
 
-	return ExecuteRPC(s.Conns, request)
+	return ExecuteRPC(s.connections(), request)
 }
 
 func CreateGpToolkitExt(conn *utils.DBConnWithContext) error {
@@ -546,3 +851,33 @@ func WriteSegmentCleanupFile(segs []greenplum.Segment, filename string) error {
 	}
 	return nil
 }
+
+// initStep tracks a step of the cluster initialization in the metrics and the trace of the hub,
+// so that the steps which take long on a large cluster can be told apart
+type initStep struct {
+	name    InitStep
+	start   time.Time
+	span    trace.Span
+	metrics *metrics.Metrics
+}
+
+// startInitStep starts the span of the step, under which the calls made to the agents during
+// the step are traced through the returned context
+func (s *Server) startInitStep(ctx context.Context, name InitStep) (context.Context, *initStep) {
+	ctx, span := utils.StartSpan(ctx, fmt.Sprintf("init step %s", name))
+
+	return ctx, &initStep{name: name, start: time.Now(), span: span, metrics: s.metrics}
+}
+
+// complete records the step in the init journal, along with the time it took in the metrics
+func (step *initStep) complete(journal *InitJournal) error {
+	step.metrics.ObserveInitStep(string(step.name), step.start)
+	step.span.End()
+
+	return journal.Complete(step.name)
+}
+
+// end ends the span of a step which did not complete, and does nothing once it has completed
+func (step *initStep) end() {
+	step.span.End()
+}
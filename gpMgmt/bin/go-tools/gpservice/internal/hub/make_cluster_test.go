@@ -8,6 +8,7 @@ import (
 	"os/exec"
 	"os/user"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 	"testing"
@@ -113,12 +114,21 @@ func TestCreateSegments(t *testing.T) {
 			SegmentConfig:     segConfig,
 		}
 
+		var created []string
 		mock, stream := testutils.NewMockStream()
-		err := hubServer.CreateSegments(context.Background(), mock, segs, clusterParams, []string{})
+		err := hubServer.CreateSegments(context.Background(), mock, segs, clusterParams, []string{}, nil, func(seg *idl.Segment) {
+			created = append(created, seg.DataDirectory)
+		})
 		if err != nil {
 			t.Fatalf("unexpected error: %#v", err)
 		}
 
+		sort.Strings(created)
+		expectedCreated := []string{"/gpseg0", "/gpseg1", "/gpseg2"}
+		if !reflect.DeepEqual(created, expectedCreated) {
+			t.Fatalf("got %v, want %v", created, expectedCreated)
+		}
+
 		expectedStreamResponse := make([]*idl.HubReply, len(segs)+1)
 		for i := range expectedStreamResponse {
 			expectedStreamResponse[i] = &idl.HubReply{
@@ -137,6 +147,48 @@ func TestCreateSegments(t *testing.T) {
 		}
 	})
 
+	t.Run("creates the segments as copies of the template", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		template := &greenplum.Segment{
+			Dbid:     1,
+			Content:  -1,
+			Port:     5432,
+			DataDir:  "/gpseg-1",
+			Address:  "cdw",
+			Hostname: "cdw",
+		}
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().MakeSegment(
+			gomock.Any(),
+			&idl.MakeSegmentRequest{
+				Segment:          segmentToProto(segs[0]),
+				SegConfig:        map[string]string{},
+				CoordinatorAddrs: make([]string, 0),
+				Template: &idl.Segment{
+					Port:          5432,
+					DataDirectory: "/gpseg-1",
+					HostName:      "cdw",
+					HostAddress:   "cdw",
+					Contentid:     -1,
+					Dbid:          1,
+				},
+			},
+		).Return(&idl.MakeSegmentReply{}, nil)
+
+		hubServer.Conns = []*hub.Connection{
+			{AgentClient: sdw1, Hostname: "sdw1"},
+		}
+
+		mock, _ := testutils.NewMockStream()
+		err := hubServer.CreateSegments(context.Background(), mock, segs[:1], &idl.ClusterParams{}, []string{}, template, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+	})
+
 	t.Run("when fails to create one of the segments", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
@@ -173,7 +225,7 @@ func TestCreateSegments(t *testing.T) {
 		}
 
 		mock, stream := testutils.NewMockStream()
-		err := hubServer.CreateSegments(context.Background(), mock, segs, clusterParams, []string{})
+		err := hubServer.CreateSegments(context.Background(), mock, segs, clusterParams, []string{}, nil, nil)
 		if !errors.Is(err, expectedErr) {
 			t.Fatalf("got %#v, want %#V", err, expectedErr)
 		}
@@ -543,6 +595,42 @@ func TestValidateEnvironment(t *testing.T) {
 		}
 	})
 
+	t.Run("requests the hosts to only report the non-empty directories in a dry run", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		dryRunReq := &idl.MakeClusterRequest{
+			GpArray:       &idl.GpArray{Coordinator: segmentToProto(segs[0])},
+			ClusterParams: &idl.ClusterParams{Locale: &idl.Locale{}},
+			ForceFlag:     true,
+			DryRun:        true,
+		}
+
+		cdw := mock_idl.NewMockAgentClient(ctrl)
+		cdw.EXPECT().ValidateHostEnv(
+			gomock.Any(),
+			&idl.ValidateHostEnvRequest{
+				HostAddressList: []string{segs[0].Address},
+				DirectoryList:   []string{segs[0].DataDir},
+				Locale:          &idl.Locale{},
+				PortList:        []string{fmt.Sprintf("%d", segs[0].Port)},
+				Forced:          true,
+				DryRun:          true,
+			},
+		).Return(&idl.ValidateHostEnvReply{}, nil)
+
+		hubServer.Conns = []*hub.Connection{{AgentClient: cdw, Hostname: "cdw"}}
+
+		utils.System.ExecCommand = exectest.NewCommand(exectest.Success)
+		defer utils.ResetSystemFunctions()
+
+		mock, _ := testutils.NewMockStream()
+		err := hubServer.ValidateEnvironment(context.Background(), mock, dryRunReq)
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+	})
+
 	t.Run("errors out when not able to get the postgres version", func(t *testing.T) {
 		utils.System.ExecCommand = exectest.NewCommand(exectest.Failure)
 		defer utils.ResetSystemFunctions()
@@ -0,0 +1,81 @@
+package hub
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"sync"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/greenplum-db/gpdb/gpservice/internal/metrics"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/greenplum"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+)
+
+// serveMetrics serves the metrics of the hub when a metrics port is configured, along with the
+// health of the agents and, when the coordinator data directory is configured, the state of
+// the segments. It returns no server when the metrics are disabled.
+func (s *Server) serveMetrics() (*http.Server, error) {
+	if s.Metrics == nil || s.Metrics.HubPort == 0 {
+		return nil, nil
+	}
+
+	collectors := []prometheus.Collector{metrics.NewAgentCollector(s.AgentsServing)}
+	if s.Metrics.CoordinatorDataDir != "" {
+		dataDir := s.Metrics.CoordinatorDataDir
+		collectors = append(collectors, metrics.NewSegmentCollector(func(ctx context.Context) (*greenplum.GpArray, error) {
+			return getGpArrayFromCoordinator(ctx, dataDir)
+		}))
+	}
+
+	for _, collector := range collectors {
+		err := s.metrics.Registry.Register(collector)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var tlsConfig *tls.Config
+	if s.Metrics.TLS {
+		var err error
+		tlsConfig, err = metrics.TLSConfig(s.Credentials)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return s.metrics.Serve(s.Metrics.GetBindAddress(), s.Metrics.HubPort, tlsConfig)
+}
+
+// AgentsServing reports whether the agent on each host responds to health checks. The agents are
+// checked once and concurrently, so that an unreachable host is reported as soon as the context is done.
+func (s *Server) AgentsServing(ctx context.Context) map[string]bool {
+	result := make(map[string]bool)
+
+	err := s.DialAllAgents()
+	if err != nil {
+		gplog.Debug("could not connect to the agents: %v", err)
+		for _, host := range s.hostnames() {
+			result[host] = false
+		}
+
+		return result
+	}
+
+	var mutex sync.Mutex
+	request := func(conn *Connection) error {
+		serving := utils.IsGRPCServerServing(ctx, conn.Conn)
+
+		mutex.Lock()
+		defer mutex.Unlock()
+		result[conn.Hostname] = serving
+
+		return nil
+	}
+
+	_ = ExecuteRPC(s.connections(), request)
+
+	return result
+}
@@ -0,0 +1,59 @@
+package hub_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+	"google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/greenplum-db/gpdb/gpservice/internal/hub"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+	"github.com/greenplum-db/gpdb/gpservice/testutils"
+)
+
+func TestAgentsServing(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	cases := []struct {
+		name     string
+		status   grpc_health_v1.HealthCheckResponse_ServingStatus
+		err      error
+		expected bool
+	}{
+		{"reports the agents which are serving", grpc_health_v1.HealthCheckResponse_SERVING, nil, true},
+		{"reports the agents which are not serving", grpc_health_v1.HealthCheckResponse_NOT_SERVING, nil, false},
+		{"reports the agents which cannot be reached", grpc_health_v1.HealthCheckResponse_UNKNOWN, errors.New("connection refused"), false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			hubServer := hub.New(testutils.CreateDummyServiceConfig(t))
+
+			utils.SetNewHealthClient(testutils.NewMockHealthClient(tc.status, tc.err))
+			defer utils.ResetNewHealthClient()
+
+			result := hubServer.AgentsServing(context.Background())
+
+			expected := map[string]bool{"sdw1": tc.expected, "sdw2": tc.expected}
+			if !reflect.DeepEqual(result, expected) {
+				t.Fatalf("got %v, want %v", result, expected)
+			}
+		})
+	}
+
+	t.Run("reports all the agents as down when not able to connect to them", func(t *testing.T) {
+		conf := testutils.CreateDummyServiceConfig(t)
+		conf.Credentials = &testutils.MockCredentials{Err: errors.New("error")}
+		hubServer := hub.New(conf)
+
+		result := hubServer.AgentsServing(context.Background())
+
+		expected := map[string]bool{"sdw1": false, "sdw2": false}
+		if !reflect.DeepEqual(result, expected) {
+			t.Fatalf("got %v, want %v", result, expected)
+		}
+	})
+}
@@ -0,0 +1,436 @@
+package hub
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"golang.org/x/exp/slices"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	grpcStatus "google.golang.org/grpc/status"
+
+	"github.com/greenplum-db/gpdb/gpservice/constants"
+	"github.com/greenplum-db/gpdb/gpservice/idl"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+)
+
+// maxFinishedOperations is the number of finished operations whose output is kept by the hub
+const maxFinishedOperations = 50
+
+/*
+clusterOperations change the state or the topology of the cluster, so that no two of them
+can run at the same time, whichever host and user they are started from. ReloadCredentials
+and UpdateHosts replace the connections to the agents, which the other operations are using.
+The other calls only read the state of the cluster or of the services, and can run alongside
+any operation. No finer compatibility between the operations is kept, as each of them either
+changes the segments or the connections which all the others rely on.
+*/
+var clusterOperations = []string{
+	"MakeCluster",
+	"CleanInitCluster",
+	"StartCluster",
+	"StopCluster",
+	"AddMirrors",
+	"RecoverSegments",
+	"ExpandCluster",
+	"AddStandby",
+	"RemoveStandby",
+	"ActivateStandby",
+	"ReloadCredentials",
+	"UpdateHosts",
+}
+
+const attachOperationMethod = "/idl.Hub/AttachOperation"
+
+/*
+operation is a long running call to the hub, such as creating the cluster or adding mirrors.
+The replies streamed to the caller are kept, so that the output of the operation can be
+replayed and followed by any other client, even after the caller has gone away.
+*/
+type operation struct {
+	id     string
+	method string
+	user   string
+	start  time.Time
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mutex     sync.Mutex
+	state     idl.OperationState
+	end       time.Time
+	err       error
+	cancelled bool
+	history   []*idl.HubReply
+	updated   chan struct{} // closed and replaced each time the operation changes
+}
+
+func (op *operation) record(reply *idl.HubReply) {
+	op.mutex.Lock()
+	defer op.mutex.Unlock()
+
+	op.history = append(op.history, reply)
+	op.notify()
+}
+
+func (op *operation) finish(err error) {
+	op.mutex.Lock()
+	defer op.mutex.Unlock()
+
+	op.end = time.Now()
+	op.err = err
+	switch {
+	case err == nil:
+		op.state = idl.OperationState_SUCCEEDED
+	case op.cancelled:
+		op.state = idl.OperationState_CANCELLED
+	default:
+		op.state = idl.OperationState_FAILED
+	}
+	op.notify()
+}
+
+// notify wakes up the clients following the operation, with the mutex held
+func (op *operation) notify() {
+	close(op.updated)
+	op.updated = make(chan struct{})
+}
+
+// result returns the error the operation finished with, as returned to its caller
+func (op *operation) result() error {
+	switch op.state {
+	case idl.OperationState_SUCCEEDED:
+		return nil
+	case idl.OperationState_CANCELLED:
+		return grpcStatus.Errorf(codes.Canceled, "operation %s was cancelled", op.id)
+	default:
+		return op.err
+	}
+}
+
+/*
+follow sends the replies of the operation so far and then as they come, until the operation
+finishes, and returns the error it finished with. The client stops following the operation
+by cancelling the context, which does not affect the operation itself.
+*/
+func (op *operation) follow(ctx context.Context, send func(*idl.HubReply) error) error {
+	sent := 0
+	for {
+		op.mutex.Lock()
+		replies := op.history[sent:]
+		sent = len(op.history)
+		state, updated := op.state, op.updated
+		op.mutex.Unlock()
+
+		for _, reply := range replies {
+			err := send(reply)
+			if err != nil {
+				return err
+			}
+		}
+
+		if state != idl.OperationState_RUNNING {
+			op.mutex.Lock()
+			defer op.mutex.Unlock()
+
+			return op.result()
+		}
+
+		select {
+		case <-updated:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (op *operation) userOrUnknown() string {
+	if op.user == "" {
+		return "an unknown user"
+	}
+
+	return op.user
+}
+
+func (op *operation) toIdl() *idl.Operation {
+	op.mutex.Lock()
+	defer op.mutex.Unlock()
+
+	result := &idl.Operation{
+		Id:        op.id,
+		Method:    op.method,
+		State:     op.state,
+		User:      op.user,
+		StartTime: op.start.Unix(),
+	}
+	if op.state != idl.OperationState_RUNNING {
+		result.EndTime = op.end.Unix()
+	}
+	if op.err != nil {
+		result.Error = grpcStatus.Convert(op.err).Message()
+	}
+
+	return result
+}
+
+// operationRegistry keeps the running operations of the hub and the most recently finished ones
+type operationRegistry struct {
+	mutex      sync.Mutex
+	operations []*operation
+}
+
+func newOperationRegistry() *operationRegistry {
+	return &operationRegistry{}
+}
+
+/*
+register starts an operation for the call, unless it changes the cluster while another
+operation changing the cluster is running, in which case the call is rejected with the
+details of that operation
+*/
+func (r *operationRegistry) register(ctx context.Context, fullMethod string, cancel context.CancelFunc) (*operation, error) {
+	op := &operation{
+		id:      newOperationID(),
+		method:  strings.TrimPrefix(fullMethod, "/idl.Hub/"),
+		start:   time.Now(),
+		ctx:     ctx,
+		cancel:  cancel,
+		state:   idl.OperationState_RUNNING,
+		updated: make(chan struct{}),
+	}
+	if identity, ok := utils.IdentityFromContext(ctx); ok {
+		op.user = identity.Name
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if slices.Contains(clusterOperations, op.method) {
+		holder := r.clusterLockHolder()
+		if holder != nil {
+			return nil, grpcStatus.Errorf(codes.FailedPrecondition, "cannot run %s while operation %s for %s started by %s at %s is running on the cluster",
+				op.method, holder.id, holder.method, holder.userOrUnknown(), holder.start.Format(time.DateTime))
+		}
+	}
+
+	r.operations = append(r.operations, op)
+
+	return op, nil
+}
+
+/*
+clusterLockHolder returns the running operation which changes the cluster, if any, with the
+mutex held. A cancelled operation holds the lock until its handler returns, as it may still
+be changing the cluster until then.
+*/
+func (r *operationRegistry) clusterLockHolder() *operation {
+	for _, op := range r.operations {
+		if !slices.Contains(clusterOperations, op.method) {
+			continue
+		}
+
+		op.mutex.Lock()
+		running := op.state == idl.OperationState_RUNNING
+		op.mutex.Unlock()
+
+		if running {
+			return op
+		}
+	}
+
+	return nil
+}
+
+// prune forgets the oldest finished operations beyond maxFinishedOperations
+func (r *operationRegistry) prune() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	finished := 0
+	for i := len(r.operations) - 1; i >= 0; i-- {
+		op := r.operations[i]
+
+		op.mutex.Lock()
+		running := op.state == idl.OperationState_RUNNING
+		op.mutex.Unlock()
+
+		if running {
+			continue
+		}
+
+		finished++
+		if finished > maxFinishedOperations {
+			r.operations = append(r.operations[:i], r.operations[i+1:]...)
+		}
+	}
+}
+
+func (r *operationRegistry) get(id string) (*operation, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, op := range r.operations {
+		if op.id == id {
+			return op, nil
+		}
+	}
+
+	return nil, grpcStatus.Errorf(codes.NotFound, "operation %s not found", id)
+}
+
+func (r *operationRegistry) list() []*operation {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return append([]*operation(nil), r.operations...)
+}
+
+/*
+OperationsStreamInterceptor registers each streaming call to the hub as an operation. The ID
+of the operation is sent to the caller in the header of the stream. The operation runs on a
+context detached from the call, so that it keeps running when its caller goes away, such as
+when the session of gpctl is lost, and is only stopped by cancelling it. Its output is still
+sent to the caller for as long as it is there.
+*/
+func (s *Server) OperationsStreamInterceptor() grpc.StreamServerInterceptor {
+	r := s.operations
+
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !info.IsServerStream || !strings.HasPrefix(info.FullMethod, "/idl.Hub/") || info.FullMethod == attachOperationMethod {
+			return handler(srv, stream)
+		}
+
+		ctx, cancel := context.WithCancel(context.WithoutCancel(stream.Context()))
+		defer cancel()
+
+		op, err := r.register(ctx, info.FullMethod, cancel)
+		if err != nil {
+			return err
+		}
+		gplog.Debug("Started operation %s for %s", op.id, info.FullMethod)
+
+		err = stream.SetHeader(metadata.Pairs(constants.OperationIDHeader, op.id))
+		if err != nil {
+			gplog.Warn("Could not send the ID of operation %s: %v", op.id, err)
+		}
+
+		err = handler(srv, &operationStream{ServerStream: stream, ctx: ctx, op: op})
+		op.finish(err)
+		r.prune()
+
+		return err
+	}
+}
+
+/*
+OperationsUnaryInterceptor registers the calls to the hub which change the cluster without
+streaming their output as operations, so that they are also subject to the cluster lock
+*/
+func (s *Server) OperationsUnaryInterceptor() grpc.UnaryServerInterceptor {
+	r := s.operations
+
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !slices.Contains(clusterOperations, strings.TrimPrefix(info.FullMethod, "/idl.Hub/")) {
+			return handler(ctx, req)
+		}
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		op, err := r.register(ctx, info.FullMethod, cancel)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := handler(ctx, req)
+		op.finish(err)
+		r.prune()
+
+		return resp, err
+	}
+}
+
+// operationStream records the replies sent to the caller in the history of the operation
+type operationStream struct {
+	grpc.ServerStream
+	ctx context.Context
+	op  *operation
+
+	callerGone bool
+}
+
+func (s *operationStream) Context() context.Context {
+	return s.ctx
+}
+
+// SendMsg sends the message to the caller on a best-effort basis, as the operation carries on
+// once its caller has gone away, and can be followed using AttachOperation then
+func (s *operationStream) SendMsg(m interface{}) error {
+	if reply, ok := m.(*idl.HubReply); ok {
+		s.op.record(reply)
+	}
+
+	if s.callerGone {
+		return nil
+	}
+
+	err := s.ServerStream.SendMsg(m)
+	if err != nil {
+		gplog.Warn("Could not send the output of operation %s to its caller, which has likely gone away. The operation keeps running: %v", s.op.id, err)
+		s.callerGone = true
+	}
+
+	return nil
+}
+
+func newOperationID() string {
+	// The ID only needs to be unique among the operations kept by the hub
+	id := make([]byte, 4)
+	rand.Read(id) // nolint
+
+	return hex.EncodeToString(id)
+}
+
+func (s *Server) ListOperations(ctx context.Context, req *idl.ListOperationsRequest) (*idl.ListOperationsReply, error) {
+	var operations []*idl.Operation
+	for _, op := range s.operations.list() {
+		operations = append(operations, op.toIdl())
+	}
+
+	return &idl.ListOperationsReply{Operations: operations}, nil
+}
+
+// AttachOperation replays the output of the operation and follows it until it finishes
+func (s *Server) AttachOperation(req *idl.AttachOperationRequest, stream idl.Hub_AttachOperationServer) error {
+	op, err := s.operations.get(req.Id)
+	if err != nil {
+		return err
+	}
+
+	return op.follow(stream.Context(), stream.Send)
+}
+
+func (s *Server) CancelOperation(ctx context.Context, req *idl.CancelOperationRequest) (*idl.CancelOperationReply, error) {
+	op, err := s.operations.get(req.Id)
+	if err != nil {
+		return &idl.CancelOperationReply{}, err
+	}
+
+	op.mutex.Lock()
+	defer op.mutex.Unlock()
+
+	if op.state != idl.OperationState_RUNNING {
+		return &idl.CancelOperationReply{}, grpcStatus.Errorf(codes.FailedPrecondition, "operation %s has already finished", op.id)
+	}
+
+	gplog.Info("Cancelling operation %s for %s", op.id, op.method)
+	op.cancelled = true
+	op.cancel()
+
+	return &idl.CancelOperationReply{}, nil
+}
@@ -0,0 +1,492 @@
+package hub_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	grpcStatus "google.golang.org/grpc/status"
+
+	"github.com/greenplum-db/gpdb/gpservice/constants"
+	"github.com/greenplum-db/gpdb/gpservice/idl"
+	"github.com/greenplum-db/gpdb/gpservice/internal/hub"
+	"github.com/greenplum-db/gpdb/gpservice/testutils"
+)
+
+// operationTestStream records the header and the replies sent over a server stream
+type operationTestStream struct {
+	grpc.ServerStream
+	ctx context.Context
+
+	mutex   sync.Mutex
+	header  metadata.MD
+	replies []*idl.HubReply
+}
+
+func newOperationTestStream(ctx context.Context) *operationTestStream {
+	return &operationTestStream{ctx: ctx}
+}
+
+func (s *operationTestStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *operationTestStream) SetHeader(md metadata.MD) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.header = metadata.Join(s.header, md)
+	return nil
+}
+
+func (s *operationTestStream) SendMsg(m interface{}) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.ctx.Err() != nil {
+		return s.ctx.Err()
+	}
+
+	s.replies = append(s.replies, m.(*idl.HubReply))
+	return nil
+}
+
+func (s *operationTestStream) Send(reply *idl.HubReply) error {
+	return s.SendMsg(reply)
+}
+
+func (s *operationTestStream) Replies() []*idl.HubReply {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return append([]*idl.HubReply(nil), s.replies...)
+}
+
+func stdoutReply(msg string) *idl.HubReply {
+	return &idl.HubReply{Message: &idl.HubReply_StdoutMsg{StdoutMsg: msg}}
+}
+
+/*
+startOperation runs a streaming call to the hub through the operations interceptor, which
+sends the given replies and then waits to be released or cancelled, and returns the ID of
+the operation along with the error of the call once it returns
+*/
+func startOperation(t *testing.T, hubServer *hub.Server, replies []*idl.HubReply, release chan error) (string, chan error) {
+	t.Helper()
+
+	stream := newOperationTestStream(context.Background())
+	sent := make(chan struct{})
+	result := make(chan error, 1)
+
+	go func() {
+		info := &grpc.StreamServerInfo{FullMethod: "/idl.Hub/MakeCluster", IsServerStream: true}
+		result <- hubServer.OperationsStreamInterceptor()(nil, stream, info, func(srv interface{}, stream grpc.ServerStream) error {
+			for _, reply := range replies {
+				stream.SendMsg(reply) // nolint
+			}
+			close(sent)
+
+			select {
+			case err := <-release:
+				return err
+			case <-stream.Context().Done():
+				return stream.Context().Err()
+			}
+		})
+	}()
+	<-sent
+
+	stream.mutex.Lock()
+	defer stream.mutex.Unlock()
+
+	id := stream.header.Get(constants.OperationIDHeader)
+	if len(id) != 1 {
+		t.Fatalf("got header %v, want the ID of the operation", stream.header)
+	}
+
+	return id[0], result
+}
+
+func TestOperations(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	replies := []*idl.HubReply{stdoutReply("creating coordinator"), stdoutReply("creating segments")}
+
+	t.Run("lists the running and finished operations", func(t *testing.T) {
+		hubServer := hub.New(testutils.CreateDummyServiceConfig(t))
+
+		fail := make(chan error, 1)
+		fail <- errors.New("error")
+		finished, result := startOperation(t, hubServer, nil, fail)
+		<-result
+
+		release := make(chan error)
+		defer close(release)
+		running, _ := startOperation(t, hubServer, replies, release)
+
+		reply, err := hubServer.ListOperations(context.Background(), &idl.ListOperationsRequest{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		states := make(map[string]idl.OperationState)
+		for _, op := range reply.Operations {
+			if op.Method != "MakeCluster" {
+				t.Fatalf("got method %s, want MakeCluster", op.Method)
+			}
+			states[op.Id] = op.State
+		}
+
+		expected := map[string]idl.OperationState{running: idl.OperationState_RUNNING, finished: idl.OperationState_FAILED}
+		if !reflect.DeepEqual(states, expected) {
+			t.Fatalf("got %v, want %v", states, expected)
+		}
+	})
+
+	t.Run("replays and follows the output of an operation until it finishes", func(t *testing.T) {
+		hubServer := hub.New(testutils.CreateDummyServiceConfig(t))
+
+		release := make(chan error)
+		id, _ := startOperation(t, hubServer, replies, release)
+
+		stream := newOperationTestStream(context.Background())
+		attached := make(chan error)
+		go func() {
+			attached <- hubServer.AttachOperation(&idl.AttachOperationRequest{Id: id}, stream)
+		}()
+
+		expectedErr := grpcStatus.Error(codes.Internal, "could not create segments")
+		release <- expectedErr
+
+		err := <-attached
+		if !errors.Is(err, expectedErr) {
+			t.Fatalf("got %v, want %v", err, expectedErr)
+		}
+
+		if !reflect.DeepEqual(stream.Replies(), replies) {
+			t.Fatalf("got %v, want %v", stream.Replies(), replies)
+		}
+	})
+
+	t.Run("stops following the operation when the client goes away", func(t *testing.T) {
+		hubServer := hub.New(testutils.CreateDummyServiceConfig(t))
+
+		release := make(chan error)
+		id, result := startOperation(t, hubServer, replies, release)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err := hubServer.AttachOperation(&idl.AttachOperationRequest{Id: id}, newOperationTestStream(ctx))
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("got %v, want %v", err, context.Canceled)
+		}
+
+		release <- nil
+		err = <-result
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("keeps running the operation and recording its output when the caller goes away", func(t *testing.T) {
+		hubServer := hub.New(testutils.CreateDummyServiceConfig(t))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		stream := newOperationTestStream(ctx)
+		sent := make(chan struct{})
+		callerGone := make(chan struct{})
+		result := make(chan error, 1)
+		go func() {
+			info := &grpc.StreamServerInfo{FullMethod: "/idl.Hub/MakeCluster", IsServerStream: true}
+			result <- hubServer.OperationsStreamInterceptor()(nil, stream, info, func(srv interface{}, stream grpc.ServerStream) error {
+				stream.SendMsg(replies[0]) // nolint
+				close(sent)
+				<-callerGone
+
+				if stream.Context().Err() != nil {
+					return stream.Context().Err()
+				}
+				return stream.SendMsg(replies[1])
+			})
+		}()
+		<-sent
+
+		cancel()
+		close(callerGone)
+		err := <-result
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !reflect.DeepEqual(stream.Replies(), replies[:1]) {
+			t.Fatalf("got %v, want only the replies sent before the caller went away", stream.Replies())
+		}
+
+		reply, err := hubServer.ListOperations(context.Background(), &idl.ListOperationsRequest{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		attached := newOperationTestStream(context.Background())
+		err = hubServer.AttachOperation(&idl.AttachOperationRequest{Id: reply.Operations[0].Id}, attached)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !reflect.DeepEqual(attached.Replies(), replies) {
+			t.Fatalf("got %v, want %v", attached.Replies(), replies)
+		}
+	})
+
+	t.Run("cancels a running operation", func(t *testing.T) {
+		hubServer := hub.New(testutils.CreateDummyServiceConfig(t))
+
+		id, result := startOperation(t, hubServer, replies, make(chan error))
+
+		_, err := hubServer.CancelOperation(context.Background(), &idl.CancelOperationRequest{Id: id})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		err = <-result
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("got %v, want %v", err, context.Canceled)
+		}
+
+		err = hubServer.AttachOperation(&idl.AttachOperationRequest{Id: id}, newOperationTestStream(context.Background()))
+		if grpcStatus.Code(err) != codes.Canceled {
+			t.Fatalf("got %v, want the operation to be cancelled", err)
+		}
+
+		_, err = hubServer.CancelOperation(context.Background(), &idl.CancelOperationRequest{Id: id})
+		if grpcStatus.Code(err) != codes.FailedPrecondition {
+			t.Fatalf("got %v, want an error as the operation has already finished", err)
+		}
+	})
+
+	t.Run("errors out when the operation does not exist", func(t *testing.T) {
+		hubServer := hub.New(testutils.CreateDummyServiceConfig(t))
+
+		err := hubServer.AttachOperation(&idl.AttachOperationRequest{Id: "unknown"}, newOperationTestStream(context.Background()))
+		if grpcStatus.Code(err) != codes.NotFound {
+			t.Fatalf("got %v, want the operation to not be found", err)
+		}
+
+		_, err = hubServer.CancelOperation(context.Background(), &idl.CancelOperationRequest{Id: "unknown"})
+		if grpcStatus.Code(err) != codes.NotFound {
+			t.Fatalf("got %v, want the operation to not be found", err)
+		}
+	})
+
+	t.Run("does not register the calls which are not long running", func(t *testing.T) {
+		hubServer := hub.New(testutils.CreateDummyServiceConfig(t))
+
+		info := &grpc.StreamServerInfo{FullMethod: "/idl.Hub/AttachOperation", IsServerStream: true}
+		err := hubServer.OperationsStreamInterceptor()(nil, newOperationTestStream(context.Background()), info, func(srv interface{}, stream grpc.ServerStream) error {
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		reply, err := hubServer.ListOperations(context.Background(), &idl.ListOperationsRequest{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(reply.Operations) != 0 {
+			t.Fatalf("got %v, want no operations", reply.Operations)
+		}
+	})
+}
+
+func runStreamCall(hubServer *hub.Server, ctx context.Context, method string) error {
+	info := &grpc.StreamServerInfo{FullMethod: "/idl.Hub/" + method, IsServerStream: true}
+	return hubServer.OperationsStreamInterceptor()(nil, newOperationTestStream(ctx), info, func(srv interface{}, stream grpc.ServerStream) error {
+		return nil
+	})
+}
+
+func TestClusterLock(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	t.Run("rejects the operations changing the cluster while another one is running", func(t *testing.T) {
+		hubServer := hub.New(testutils.CreateDummyServiceConfig(t))
+
+		release := make(chan error)
+		defer close(release)
+		id, _ := startOperation(t, hubServer, nil, release)
+
+		err := runStreamCall(hubServer, context.Background(), "AddMirrors")
+		if grpcStatus.Code(err) != codes.FailedPrecondition {
+			t.Fatalf("got %v, want the operation to be rejected", err)
+		}
+
+		expected := fmt.Sprintf("cannot run AddMirrors while operation %s for MakeCluster started by an unknown user at", id)
+		if !strings.Contains(err.Error(), expected) {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+
+		info := &grpc.UnaryServerInfo{FullMethod: "/idl.Hub/CleanInitCluster"}
+		_, err = hubServer.OperationsUnaryInterceptor()(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			t.Fatalf("unexpected call to CleanInitCluster")
+			return nil, nil
+		})
+		if grpcStatus.Code(err) != codes.FailedPrecondition {
+			t.Fatalf("got %v, want the operation to be rejected", err)
+		}
+
+		reply, err := hubServer.ListOperations(context.Background(), &idl.ListOperationsRequest{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(reply.Operations) != 1 || reply.Operations[0].Id != id {
+			t.Fatalf("got %v, want only operation %s", reply.Operations, id)
+		}
+	})
+
+	t.Run("rejects replacing the connections to the agents while an operation is running", func(t *testing.T) {
+		hubServer := hub.New(testutils.CreateDummyServiceConfig(t))
+
+		release := make(chan error)
+		defer close(release)
+		startOperation(t, hubServer, nil, release)
+
+		for _, method := range []string{"ReloadCredentials", "UpdateHosts"} {
+			info := &grpc.UnaryServerInfo{FullMethod: "/idl.Hub/" + method}
+			_, err := hubServer.OperationsUnaryInterceptor()(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+				t.Fatalf("unexpected call to %s", method)
+				return nil, nil
+			})
+			if grpcStatus.Code(err) != codes.FailedPrecondition {
+				t.Fatalf("got %v, want %s to be rejected", err, method)
+			}
+		}
+	})
+
+	t.Run("allows the calls which do not change the cluster while an operation is running", func(t *testing.T) {
+		hubServer := hub.New(testutils.CreateDummyServiceConfig(t))
+
+		release := make(chan error)
+		defer close(release)
+		startOperation(t, hubServer, nil, release)
+
+		called := false
+		info := &grpc.UnaryServerInfo{FullMethod: "/idl.Hub/ClusterStatus"}
+		_, err := hubServer.OperationsUnaryInterceptor()(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			called = true
+			return nil, nil
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !called {
+			t.Fatalf("expected ClusterStatus to be called")
+		}
+	})
+
+	t.Run("releases the lock once the operation finishes", func(t *testing.T) {
+		hubServer := hub.New(testutils.CreateDummyServiceConfig(t))
+
+		release := make(chan error)
+		_, result := startOperation(t, hubServer, nil, release)
+		release <- nil
+		<-result
+
+		err := runStreamCall(hubServer, context.Background(), "AddMirrors")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		info := &grpc.UnaryServerInfo{FullMethod: "/idl.Hub/CleanInitCluster"}
+		_, err = hubServer.OperationsUnaryInterceptor()(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, nil
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("keeps the lock of an operation whose caller has gone away", func(t *testing.T) {
+		hubServer := hub.New(testutils.CreateDummyServiceConfig(t))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		started := make(chan struct{})
+		release := make(chan struct{})
+		result := make(chan error, 1)
+		go func() {
+			info := &grpc.StreamServerInfo{FullMethod: "/idl.Hub/MakeCluster", IsServerStream: true}
+			result <- hubServer.OperationsStreamInterceptor()(nil, newOperationTestStream(ctx), info, func(srv interface{}, stream grpc.ServerStream) error {
+				close(started)
+				<-release
+
+				return nil
+			})
+		}()
+		<-started
+
+		cancel()
+		err := runStreamCall(hubServer, context.Background(), "AddMirrors")
+		if grpcStatus.Code(err) != codes.FailedPrecondition {
+			t.Fatalf("got %v, want the operation to be rejected", err)
+		}
+
+		close(release)
+		<-result
+		err = runStreamCall(hubServer, context.Background(), "AddMirrors")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("keeps the lock of a cancelled operation until its handler returns", func(t *testing.T) {
+		hubServer := hub.New(testutils.CreateDummyServiceConfig(t))
+
+		started := make(chan struct{})
+		release := make(chan struct{})
+		result := make(chan error, 1)
+		go func() {
+			info := &grpc.StreamServerInfo{FullMethod: "/idl.Hub/MakeCluster", IsServerStream: true}
+			result <- hubServer.OperationsStreamInterceptor()(nil, newOperationTestStream(context.Background()), info, func(srv interface{}, stream grpc.ServerStream) error {
+				close(started)
+				<-release
+
+				return nil
+			})
+		}()
+		<-started
+
+		reply, err := hubServer.ListOperations(context.Background(), &idl.ListOperationsRequest{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		_, err = hubServer.CancelOperation(context.Background(), &idl.CancelOperationRequest{Id: reply.Operations[0].Id})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		err = runStreamCall(hubServer, context.Background(), "AddMirrors")
+		if grpcStatus.Code(err) != codes.FailedPrecondition {
+			t.Fatalf("got %v, want the operation to be rejected", err)
+		}
+
+		close(release)
+		<-result
+		err = runStreamCall(hubServer, context.Background(), "AddMirrors")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
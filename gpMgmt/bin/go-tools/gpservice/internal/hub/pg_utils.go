@@ -4,10 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"sync"
 
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
 	"github.com/greenplum-db/gpdb/gpservice/idl"
 	"github.com/greenplum-db/gpdb/gpservice/pkg/greenplum"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
 )
 
 // UpdatePgHbaConfWithMirrorEntries updates the pg_hba.conf file on the primary segments
@@ -82,7 +85,132 @@ func (s *Server) UpdatePgHbaConfWithMirrorEntries(ctx context.Context, gparray *
 		return err
 	}
 
-	return ExecuteRPC(s.Conns, request)
+	return ExecuteRPC(s.connections(), request)
+}
+
+// UpdatePgHbaConfWithStandbyEntries updates the pg_hba.conf file on the coordinator with
+// the replication entries of the standby coordinator. The hbaHostname parameter determines
+// whether to use hostnames or IP addresses in the pg_hba.conf file.
+func (s *Server) UpdatePgHbaConfWithStandbyEntries(ctx context.Context, coordinator, standby *greenplum.Segment, hbaHostname bool) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	var addrs []string
+	if hbaHostname {
+		addrs = []string{coordinator.Address, standby.Address}
+	} else {
+		coordinatorAddrs, err := s.GetInterfaceAddrs(ctx, coordinator.Hostname)
+		if err != nil {
+			return err
+		}
+
+		standbyAddrs, err := s.GetInterfaceAddrs(ctx, standby.Hostname)
+		if err != nil {
+			return err
+		}
+
+		addrs = append(coordinatorAddrs, standbyAddrs...)
+	}
+
+	request := func(conn *Connection) error {
+		_, err := conn.AgentClient.UpdatePgHbaConfAndReload(ctx, &idl.UpdatePgHbaConfRequest{
+			Pgdata:      coordinator.DataDir,
+			Addrs:       addrs,
+			Replication: true,
+		})
+
+		return utils.FormatGrpcError(err)
+	}
+
+	return ExecuteRPC(getConnForHosts(s.connections(), []string{coordinator.Hostname}), request)
+}
+
+// RemovePgHbaConfStandbyEntries removes the entries of the standby coordinator from the
+// pg_hba.conf file on the coordinator. The interface addresses of the standby are only
+// known while its host is reachable, otherwise just its hostname and address are removed.
+// Addresses which are also those of the coordinator are kept.
+func (s *Server) RemovePgHbaConfStandbyEntries(ctx context.Context, coordinator, standby *greenplum.Segment) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	standbyAddrs := []string{standby.Hostname, standby.Address}
+	interfaceAddrs, err := s.GetInterfaceAddrs(ctx, standby.Hostname)
+	if err != nil {
+		gplog.Debug("failed to get the interface addresses of the standby coordinator host %s: %v", standby.Hostname, err)
+	}
+	standbyAddrs = append(standbyAddrs, interfaceAddrs...)
+
+	coordinatorAddrs, err := s.GetInterfaceAddrs(ctx, coordinator.Hostname)
+	if err != nil {
+		return err
+	}
+	coordinatorAddrs = append(coordinatorAddrs, coordinator.Hostname, coordinator.Address, "localhost")
+
+	var addrs []string
+	for _, addr := range standbyAddrs {
+		if !slices.Contains(coordinatorAddrs, addr) && !slices.Contains(addrs, addr) {
+			addrs = append(addrs, addr)
+		}
+	}
+
+	if len(addrs) == 0 {
+		return nil
+	}
+
+	request := func(conn *Connection) error {
+		_, err := conn.AgentClient.UpdatePgHbaConfAndReload(ctx, &idl.UpdatePgHbaConfRequest{
+			Pgdata: coordinator.DataDir,
+			Addrs:  addrs,
+			Remove: true,
+		})
+
+		return utils.FormatGrpcError(err)
+	}
+
+	return ExecuteRPC(getConnForHosts(s.connections(), []string{coordinator.Hostname}), request)
+}
+
+// UpdatePgHbaConfWithExpansionEntries updates the pg_hba.conf file on the coordinator with
+// the replication entries of the hosts of the new segments, which copy the coordinator data
+// directory as their template. The hbaHostname parameter determines whether to use hostnames
+// or IP addresses in the pg_hba.conf file.
+func (s *Server) UpdatePgHbaConfWithExpansionEntries(ctx context.Context, coordinator *greenplum.Segment, segs []greenplum.Segment, hbaHostname bool) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	var addrs, hostnames []string
+	for _, seg := range segs {
+		if hbaHostname {
+			addrs = append(addrs, seg.Address)
+			continue
+		}
+
+		if slices.Contains(hostnames, seg.Hostname) {
+			continue
+		}
+		hostnames = append(hostnames, seg.Hostname)
+
+		hostAddrs, err := s.GetInterfaceAddrs(ctx, seg.Hostname)
+		if err != nil {
+			return err
+		}
+		addrs = append(addrs, hostAddrs...)
+	}
+
+	request := func(conn *Connection) error {
+		_, err := conn.AgentClient.UpdatePgHbaConfAndReload(ctx, &idl.UpdatePgHbaConfRequest{
+			Pgdata:      coordinator.DataDir,
+			Addrs:       addrs,
+			Replication: true,
+		})
+
+		return utils.FormatGrpcError(err)
+	}
+
+	return ExecuteRPC(getConnForHosts(s.connections(), []string{coordinator.Hostname}), request)
 }
 
 // GetInterfaceAddrs returns the interface addresses for a given host.
@@ -92,7 +220,7 @@ func (s *Server) GetInterfaceAddrs(ctx context.Context, host string) ([]string,
 		return nil, ctx.Err()
 	}
 
-	conns := getConnForHosts(s.Conns, []string{host})
+	conns := getConnForHosts(s.connections(), []string{host})
 
 	var addrs []string
 	request := func(conn *Connection) error {
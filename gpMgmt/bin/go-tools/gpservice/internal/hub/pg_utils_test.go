@@ -8,10 +8,13 @@ import (
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
 
+	"github.com/greenplum-db/gpdb/gpservice/constants"
 	"github.com/greenplum-db/gpdb/gpservice/idl"
 	"github.com/greenplum-db/gpdb/gpservice/idl/mock_idl"
 	"github.com/greenplum-db/gpdb/gpservice/internal/hub"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/greenplum"
 )
 
 func TestUpdatePgHbaConf(t *testing.T) {
@@ -219,3 +222,221 @@ func TestUpdatePgHbaConf(t *testing.T) {
 		}
 	})
 }
+
+func TestUpdatePgHbaConfWithStandbyEntries(t *testing.T) {
+	initialize(t)
+
+	standby := createSegment(t, 6, -1, constants.RoleMirror, constants.RoleMirror, 7000, "sdw1", "sdw1", "/data/standby/gpseg-1")
+
+	t.Run("succesfully updates the coordinator pg_hba.conf file when hba_hostnames is false", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		cdw := mock_idl.NewMockAgentClient(ctrl)
+		cdw.EXPECT().GetInterfaceAddrs(gomock.Any(), gomock.Any()).Return(&idl.GetInterfaceAddrsResponse{
+			Addrs: []string{"192.0.0.0/24"},
+		}, nil)
+		cdw.EXPECT().UpdatePgHbaConfAndReload(
+			gomock.Any(),
+			&idl.UpdatePgHbaConfRequest{
+				Pgdata:      coordinator.DataDir,
+				Addrs:       []string{"192.0.0.0/24", "192.0.1.0/24"},
+				Replication: true,
+			},
+		).Return(&idl.UpdatePgHbaConfResponse{}, nil)
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().GetInterfaceAddrs(gomock.Any(), gomock.Any()).Return(&idl.GetInterfaceAddrsResponse{
+			Addrs: []string{"192.0.1.0/24"},
+		}, nil)
+
+		hubServer.Conns = []*hub.Connection{
+			{AgentClient: cdw, Hostname: "cdw"},
+			{AgentClient: sdw1, Hostname: "sdw1"},
+		}
+
+		err := hubServer.UpdatePgHbaConfWithStandbyEntries(context.Background(), coordinator, standby, false)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("succesfully updates the coordinator pg_hba.conf file when hba_hostnames is true", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		cdw := mock_idl.NewMockAgentClient(ctrl)
+		cdw.EXPECT().UpdatePgHbaConfAndReload(
+			gomock.Any(),
+			&idl.UpdatePgHbaConfRequest{
+				Pgdata:      coordinator.DataDir,
+				Addrs:       []string{coordinator.Address, standby.Address},
+				Replication: true,
+			},
+		).Return(&idl.UpdatePgHbaConfResponse{}, nil)
+
+		hubServer.Conns = []*hub.Connection{
+			{AgentClient: cdw, Hostname: "cdw"},
+			{AgentClient: mock_idl.NewMockAgentClient(ctrl), Hostname: "sdw1"},
+		}
+
+		err := hubServer.UpdatePgHbaConfWithStandbyEntries(context.Background(), coordinator, standby, true)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("errors out when fails to modify the coordinator pg_hba.conf", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		expectedErr := errors.New("error")
+
+		cdw := mock_idl.NewMockAgentClient(ctrl)
+		cdw.EXPECT().UpdatePgHbaConfAndReload(gomock.Any(), gomock.Any()).Return(nil, expectedErr)
+
+		hubServer.Conns = []*hub.Connection{
+			{AgentClient: cdw, Hostname: "cdw"},
+		}
+
+		err := hubServer.UpdatePgHbaConfWithStandbyEntries(context.Background(), coordinator, standby, true)
+		if !errors.Is(err, expectedErr) {
+			t.Fatalf("got %#v, want %#v", err, expectedErr)
+		}
+	})
+}
+
+func TestRemovePgHbaConfStandbyEntries(t *testing.T) {
+	testhelper.SetupTestLogger()
+	initialize(t)
+
+	standby := createSegment(t, 6, -1, constants.RoleMirror, constants.RoleMirror, 7000, "sdw1", "sdw1", "/data/standby/gpseg-1")
+
+	t.Run("removes the standby entries but keeps the ones of the coordinator", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		cdw := mock_idl.NewMockAgentClient(ctrl)
+		cdw.EXPECT().GetInterfaceAddrs(gomock.Any(), gomock.Any()).Return(&idl.GetInterfaceAddrsResponse{
+			Addrs: []string{"192.0.0.0/24"},
+		}, nil)
+		cdw.EXPECT().UpdatePgHbaConfAndReload(
+			gomock.Any(),
+			&idl.UpdatePgHbaConfRequest{
+				Pgdata: coordinator.DataDir,
+				Addrs:  []string{"sdw1", "192.0.1.0/24"},
+				Remove: true,
+			},
+		).Return(&idl.UpdatePgHbaConfResponse{}, nil)
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().GetInterfaceAddrs(gomock.Any(), gomock.Any()).Return(&idl.GetInterfaceAddrsResponse{
+			Addrs: []string{"192.0.1.0/24", "192.0.0.0/24"},
+		}, nil)
+
+		hubServer.Conns = []*hub.Connection{
+			{AgentClient: cdw, Hostname: "cdw"},
+			{AgentClient: sdw1, Hostname: "sdw1"},
+		}
+
+		err := hubServer.RemovePgHbaConfStandbyEntries(context.Background(), coordinator, standby)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("removes the hostname entries when the standby host is unreachable", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		cdw := mock_idl.NewMockAgentClient(ctrl)
+		cdw.EXPECT().GetInterfaceAddrs(gomock.Any(), gomock.Any()).Return(&idl.GetInterfaceAddrsResponse{
+			Addrs: []string{"192.0.0.0/24"},
+		}, nil)
+		cdw.EXPECT().UpdatePgHbaConfAndReload(
+			gomock.Any(),
+			&idl.UpdatePgHbaConfRequest{
+				Pgdata: coordinator.DataDir,
+				Addrs:  []string{"sdw1"},
+				Remove: true,
+			},
+		).Return(&idl.UpdatePgHbaConfResponse{}, nil)
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().GetInterfaceAddrs(gomock.Any(), gomock.Any()).Return(nil, errors.New("error"))
+
+		hubServer.Conns = []*hub.Connection{
+			{AgentClient: cdw, Hostname: "cdw"},
+			{AgentClient: sdw1, Hostname: "sdw1"},
+		}
+
+		err := hubServer.RemovePgHbaConfStandbyEntries(context.Background(), coordinator, standby)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestUpdatePgHbaConfWithExpansionEntries(t *testing.T) {
+	initialize(t)
+
+	segs := []greenplum.Segment{
+		*createSegment(t, 6, 2, constants.RolePrimary, constants.RolePrimary, 7002, "sdw3", "sdw3", "/data/primary/gpseg2"),
+		*createSegment(t, 7, 3, constants.RolePrimary, constants.RolePrimary, 7003, "sdw3", "sdw3", "/data/primary/gpseg3"),
+	}
+
+	t.Run("succesfully updates the coordinator pg_hba.conf file once for each host when hba_hostnames is false", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		cdw := mock_idl.NewMockAgentClient(ctrl)
+		cdw.EXPECT().UpdatePgHbaConfAndReload(
+			gomock.Any(),
+			&idl.UpdatePgHbaConfRequest{
+				Pgdata:      coordinator.DataDir,
+				Addrs:       []string{"192.0.3.0/24"},
+				Replication: true,
+			},
+		).Return(&idl.UpdatePgHbaConfResponse{}, nil)
+
+		sdw3 := mock_idl.NewMockAgentClient(ctrl)
+		sdw3.EXPECT().GetInterfaceAddrs(gomock.Any(), gomock.Any()).Return(&idl.GetInterfaceAddrsResponse{
+			Addrs: []string{"192.0.3.0/24"},
+		}, nil).Times(1)
+
+		hubServer.Conns = []*hub.Connection{
+			{AgentClient: cdw, Hostname: "cdw"},
+			{AgentClient: sdw3, Hostname: "sdw3"},
+		}
+
+		err := hubServer.UpdatePgHbaConfWithExpansionEntries(context.Background(), coordinator, segs, false)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("succesfully updates the coordinator pg_hba.conf file when hba_hostnames is true", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		cdw := mock_idl.NewMockAgentClient(ctrl)
+		cdw.EXPECT().UpdatePgHbaConfAndReload(
+			gomock.Any(),
+			&idl.UpdatePgHbaConfRequest{
+				Pgdata:      coordinator.DataDir,
+				Addrs:       []string{"sdw3", "sdw3"},
+				Replication: true,
+			},
+		).Return(&idl.UpdatePgHbaConfResponse{}, nil)
+
+		hubServer.Conns = []*hub.Connection{
+			{AgentClient: cdw, Hostname: "cdw"},
+			{AgentClient: mock_idl.NewMockAgentClient(ctrl), Hostname: "sdw3"},
+		}
+
+		err := hubServer.UpdatePgHbaConfWithExpansionEntries(context.Background(), coordinator, segs, true)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
@@ -0,0 +1,362 @@
+package hub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"github.com/greenplum-db/gpdb/gpservice/constants"
+	"github.com/greenplum-db/gpdb/gpservice/idl"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/greenplum"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+)
+
+// SegmentRecovery describes how a single failed segment is to be recovered
+type SegmentRecovery struct {
+	Source greenplum.Segment // acting primary to recover from
+	Failed greenplum.Segment // failed segment as registered in the catalog
+	Target greenplum.Segment // location to recover the failed segment to
+	Full   bool
+}
+
+// IsRelocated returns true if the failed segment is recovered to a different location
+func (r *SegmentRecovery) IsRelocated() bool {
+	return r.Failed.Hostname != r.Target.Hostname || r.Failed.DataDir != r.Target.DataDir || r.Failed.Port != r.Target.Port
+}
+
+// RecoverSegments is the hub RPC which recovers the segments marked down in the
+// catalog. The segments are recovered in place using pg_rewind (incremental) or
+// pg_basebackup (full). Segments can also be recovered to a different host or
+// data directory, in which case a full recovery is done and the catalog is updated
+// once it succeeds.
+func (s *Server) RecoverSegments(req *idl.RecoverSegmentsRequest, stream idl.Hub_RecoverSegmentsServer) error {
+	hubStream := NewHubStream(stream)
+	hubStream.StreamLogMsg("Starting to recover the failed segments")
+
+	err := s.DialAllAgents()
+	if err != nil {
+		return utils.LogAndReturnError(err)
+	}
+
+	conn, err := greenplum.GetCoordinatorConn(stream.Context(), req.CoordinatorDataDir, "", true)
+	if err != nil {
+		return utils.LogAndReturnError(err)
+	}
+	defer conn.DB.Close()
+
+	gparray, err := greenplum.NewGpArrayFromCatalog(conn.DB)
+	if err != nil {
+		return utils.LogAndReturnError(err)
+	}
+
+	recoveries, err := GetSegmentsToRecover(gparray, req.RecoverPairs, req.Full)
+	if err != nil {
+		return utils.LogAndReturnError(err)
+	}
+
+	if len(recoveries) == 0 {
+		hubStream.StreamLogMsg("No segments found that require recovery")
+		return nil
+	}
+
+	for _, recovery := range recoveries {
+		recoveryType := "incremental"
+		if recovery.Full {
+			recoveryType = "full"
+		}
+
+		hubStream.StreamLogMsg(fmt.Sprintf("Recovering segment with content %d on host %s to %s:%s using %s recovery from host %s",
+			recovery.Failed.Content, recovery.Failed.Hostname, recovery.Target.Hostname, recovery.Target.DataDir, recoveryType, recovery.Source.Hostname))
+	}
+
+	var relocatedMirrors []*idl.Segment
+	for _, recovery := range recoveries {
+		if recovery.IsRelocated() {
+			relocatedMirrors = append(relocatedMirrors, &idl.Segment{
+				Contentid:     int32(recovery.Target.Content),
+				HostName:      recovery.Target.Hostname,
+				HostAddress:   recovery.Target.Address,
+				Port:          int32(recovery.Target.Port),
+				DataDirectory: recovery.Target.DataDir,
+			})
+		}
+	}
+
+	if len(relocatedMirrors) > 0 {
+		hubStream.StreamLogMsg("Starting to modify the pg_hba.conf on the primary segments to add the relocated mirror entries")
+		err = s.UpdatePgHbaConfWithMirrorEntries(stream.Context(), withRelocatedMirrors(gparray, recoveries), relocatedMirrors, req.HbaHostnames)
+		if err != nil {
+			return utils.LogAndReturnError(err)
+		}
+		hubStream.StreamLogMsg("Successfully modified the pg_hba.conf on the primary segments")
+	}
+
+	hubStream.StreamLogMsg("Recovering the failed segments")
+	err = s.RecoverMirrorSegments(stream.Context(), &hubStream, recoveries)
+	if err != nil {
+		return utils.LogAndReturnError(err)
+	}
+	hubStream.StreamLogMsg("Successfully recovered the failed segments")
+
+	// The catalog is only updated once the data has been copied, so that a failed recovery
+	// leaves the relocated mirrors registered at their old location
+	if len(relocatedMirrors) > 0 {
+		hubStream.StreamLogMsg("Updating the catalog with the new location of the relocated segments")
+		for _, recovery := range recoveries {
+			if !recovery.IsRelocated() {
+				continue
+			}
+
+			err = greenplum.RelocateMirrorSegment(recovery.Target, conn)
+			if err != nil {
+				return utils.LogAndReturnError(fmt.Errorf("relocating mirror for content %d: %w", recovery.Target.Content, err))
+			}
+		}
+	}
+
+	var mirrors []*idl.Segment
+	for _, recovery := range recoveries {
+		mirrors = append(mirrors, &idl.Segment{
+			HostName:      recovery.Target.Hostname,
+			DataDirectory: recovery.Target.DataDir,
+		})
+	}
+
+	hubStream.StreamLogMsg("Starting up the recovered segments")
+	err = s.StartMirrorSegments(stream.Context(), mirrors)
+	if err != nil {
+		return utils.LogAndReturnError(err)
+	}
+	hubStream.StreamLogMsg("Successfully started the recovered segments")
+
+	hubStream.StreamLogMsg("Triggering FTS probe")
+	err = greenplum.TriggerFtsProbe(req.CoordinatorDataDir)
+	if err != nil {
+		return utils.LogAndReturnError(err)
+	}
+
+	hubStream.StreamLogMsg("Segments have been recovered")
+	hubStream.StreamLogMsg("Data synchronization might be in progress and will continue in the background")
+	hubStream.StreamLogMsg("Use 'gpctl status' to check the state of the segments")
+
+	return nil
+}
+
+// withRelocatedMirrors returns a copy of the gparray where the mirrors of the relocated
+// segments are at their target location
+func withRelocatedMirrors(gparray *greenplum.GpArray, recoveries []SegmentRecovery) *greenplum.GpArray {
+	targets := make(map[int]greenplum.Segment)
+	for _, recovery := range recoveries {
+		if recovery.IsRelocated() {
+			targets[recovery.Target.Content] = recovery.Target
+		}
+	}
+
+	relocated := &greenplum.GpArray{Coordinator: gparray.Coordinator, Standby: gparray.Standby}
+	for _, pair := range gparray.SegmentPairs {
+		if target, ok := targets[pair.Primary.Content]; ok {
+			pair.Mirror = &target
+		}
+		relocated.SegmentPairs = append(relocated.SegmentPairs, pair)
+	}
+
+	return relocated
+}
+
+// GetSegmentsToRecover returns the list of segments to be recovered. If no recover pairs are
+// given, all the segments marked down in the catalog are recovered in place. Otherwise only the
+// given failed segments are recovered, to the target location if one is provided. A full recovery
+// is always done when a segment is recovered to a different location.
+func GetSegmentsToRecover(gparray *greenplum.GpArray, recoverPairs []*idl.RecoverSegmentPair, full bool) ([]SegmentRecovery, error) {
+	var recoveries []SegmentRecovery
+	failedSegs := make(map[string]greenplum.SegmentPair)
+
+	for _, pair := range gparray.SegmentPairs {
+		if pair.Mirror == nil || pair.Mirror.Status != constants.StatusDown {
+			continue
+		}
+
+		if pair.Primary.Status == constants.StatusDown {
+			return nil, fmt.Errorf("cannot recover content %d, both the primary and the mirror segments are down", pair.Primary.Content)
+		}
+
+		if len(recoverPairs) == 0 {
+			recoveries = append(recoveries, SegmentRecovery{
+				Source: *pair.Primary,
+				Failed: *pair.Mirror,
+				Target: *pair.Mirror,
+				Full:   full,
+			})
+			continue
+		}
+
+		failedSegs[fmt.Sprintf("%s:%s", pair.Mirror.Hostname, pair.Mirror.DataDir)] = pair
+	}
+
+	for _, recoverPair := range recoverPairs {
+		if recoverPair.Failed == nil {
+			return nil, fmt.Errorf("failed segment not provided in the recovery configuration")
+		}
+
+		key := fmt.Sprintf("%s:%s", recoverPair.Failed.HostName, recoverPair.Failed.DataDirectory)
+		pair, ok := failedSegs[key]
+		if !ok {
+			return nil, fmt.Errorf("no failed segment found with data directory %s on host %s", recoverPair.Failed.DataDirectory, recoverPair.Failed.HostName)
+		}
+		delete(failedSegs, key)
+
+		recovery := SegmentRecovery{
+			Source: *pair.Primary,
+			Failed: *pair.Mirror,
+			Target: *pair.Mirror,
+			Full:   full,
+		}
+
+		if recoverPair.Target != nil {
+			recovery.Target.Hostname = recoverPair.Target.HostName
+			recovery.Target.Address = recoverPair.Target.HostAddress
+			recovery.Target.Port = int(recoverPair.Target.Port)
+			recovery.Target.DataDir = recoverPair.Target.DataDirectory
+
+			if recovery.Target.Address == "" {
+				recovery.Target.Address = recovery.Target.Hostname
+			}
+		}
+
+		if recovery.IsRelocated() {
+			recovery.Full = true
+		}
+
+		recoveries = append(recoveries, recovery)
+	}
+
+	return recoveries, nil
+}
+
+// RecoverMirrorSegments rebuilds the given segments through the agents on the target hosts.
+// A full recovery copies the data directory from the source using pg_basebackup while an
+// incremental recovery resynchronizes the existing data directory using pg_rewind.
+func (s *Server) RecoverMirrorSegments(ctx context.Context, stream hubStreamer, recoveries []SegmentRecovery) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	hostRecoveryMap := make(map[string][]SegmentRecovery)
+	for _, recovery := range recoveries {
+		hostRecoveryMap[recovery.Target.Hostname] = append(hostRecoveryMap[recovery.Target.Hostname], recovery)
+	}
+
+	progressLabel := "Recovering segments:"
+	progressTotal := len(recoveries)
+	current := 0
+	stream.StreamProgressMsg(progressLabel, current, progressTotal)
+
+	request := func(conn *Connection) error {
+		var wg sync.WaitGroup
+
+		recoveries := hostRecoveryMap[conn.Hostname]
+		errs := make(chan error, len(recoveries))
+		for _, recovery := range recoveries {
+			recovery := recovery
+			wg.Add(1)
+
+			go func(recovery SegmentRecovery) {
+				defer wg.Done()
+
+				err := recoverSegment(ctx, conn, recovery)
+				if err != nil {
+					errs <- err
+					return
+				}
+
+				s.mutex.Lock()
+				current++
+				defer s.mutex.Unlock()
+
+				stream.StreamProgressMsg(progressLabel, current, progressTotal)
+			}(recovery)
+		}
+
+		wg.Wait()
+		close(errs)
+
+		var err error
+		for e := range errs {
+			err = errors.Join(err, e)
+		}
+
+		return err
+	}
+
+	return ExecuteRPC(s.connections(), request)
+}
+
+func recoverSegment(ctx context.Context, conn *Connection, recovery SegmentRecovery) error {
+	target := recovery.Target
+	source := recovery.Source
+
+	if !recovery.IsRelocated() {
+		// The failed segment could still be running even though it is marked down
+		_, err := conn.AgentClient.StopSegment(ctx, &idl.StopSegmentRequest{
+			DataDir: target.DataDir,
+			Wait:    true,
+			Mode:    constants.ShutdownModeFast,
+		})
+		if err != nil {
+			gplog.Debug("could not stop segment with data directory %s on host %s, it is likely not running: %v", target.DataDir, target.Hostname, err)
+		}
+	}
+
+	if recovery.Full {
+		gplog.Debug("Starting full recovery of segment with data directory %s on host %s", target.DataDir, target.Hostname)
+		_, err := conn.AgentClient.PgBasebackup(ctx, &idl.PgBasebackupRequest{
+			TargetDir:           target.DataDir,
+			SourceHost:          source.Hostname,
+			SourcePort:          int32(source.Port),
+			CreateSlot:          true,
+			ForceOverwrite:      true,
+			TargetDbid:          int32(target.Dbid),
+			WriteRecoveryConf:   true,
+			ReplicationSlotName: constants.ReplicationSlotName,
+		})
+		if err != nil {
+			return utils.FormatGrpcError(err)
+		}
+	} else {
+		gplog.Debug("Starting incremental recovery of segment with data directory %s on host %s", target.DataDir, target.Hostname)
+		_, err := conn.AgentClient.PgRewind(ctx, &idl.PgRewindRequest{
+			TargetDir:  target.DataDir,
+			SourceHost: source.Hostname,
+			SourcePort: int32(source.Port),
+			TargetDbid: int32(target.Dbid),
+		})
+		if err != nil {
+			return utils.FormatGrpcError(err)
+		}
+	}
+
+	// Both pg_basebackup and pg_rewind copy the configuration from the source segment,
+	// so restore the segment specific values
+	params := map[string]string{
+		"port": strconv.Itoa(target.Port),
+	}
+	if !recovery.Full {
+		params["primary_slot_name"] = constants.ReplicationSlotName
+	}
+
+	gplog.Debug("Starting to modify the postgresql.conf for segment with data directory %s on host %s with port value %d", target.DataDir, target.Hostname, target.Port)
+	_, err := conn.AgentClient.UpdatePgConf(ctx, &idl.UpdatePgConfRequest{
+		Pgdata:    target.DataDir,
+		Params:    params,
+		Overwrite: true,
+	})
+	if err != nil {
+		return utils.FormatGrpcError(err)
+	}
+	gplog.Debug("Successfully recovered segment with data directory %s on host %s", target.DataDir, target.Hostname)
+
+	return nil
+}
@@ -0,0 +1,200 @@
+package hub_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+	"github.com/greenplum-db/gpdb/gpservice/idl"
+	"github.com/greenplum-db/gpdb/gpservice/idl/mock_idl"
+	"github.com/greenplum-db/gpdb/gpservice/internal/hub"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/greenplum"
+	"github.com/greenplum-db/gpdb/gpservice/testutils"
+)
+
+func TestGetSegmentsToRecover(t *testing.T) {
+	primary0 := &greenplum.Segment{Dbid: 2, Content: 0, Role: "p", Status: "u", Hostname: "sdw1", Address: "sdw1", Port: 7000, DataDir: "/primary/gpseg0"}
+	mirror0 := &greenplum.Segment{Dbid: 4, Content: 0, Role: "m", Status: "d", Hostname: "sdw2", Address: "sdw2", Port: 8000, DataDir: "/mirror/gpseg0"}
+	primary1 := &greenplum.Segment{Dbid: 3, Content: 1, Role: "p", Status: "u", Hostname: "sdw2", Address: "sdw2", Port: 7000, DataDir: "/primary/gpseg1"}
+	mirror1 := &greenplum.Segment{Dbid: 5, Content: 1, Role: "m", Status: "u", Hostname: "sdw1", Address: "sdw1", Port: 8000, DataDir: "/mirror/gpseg1"}
+
+	gparray := &greenplum.GpArray{
+		SegmentPairs: []greenplum.SegmentPair{
+			{Primary: primary0, Mirror: mirror0},
+			{Primary: primary1, Mirror: mirror1},
+		},
+	}
+
+	t.Run("returns all the failed segments to be recovered in place", func(t *testing.T) {
+		result, err := hub.GetSegmentsToRecover(gparray, nil, false)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := []hub.SegmentRecovery{
+			{Source: *primary0, Failed: *mirror0, Target: *mirror0, Full: false},
+		}
+		if !reflect.DeepEqual(result, expected) {
+			t.Fatalf("got %+v, want %+v", result, expected)
+		}
+	})
+
+	t.Run("forces a full recovery when the segment is relocated", func(t *testing.T) {
+		recoverPairs := []*idl.RecoverSegmentPair{
+			{
+				Failed: &idl.Segment{HostName: "sdw2", DataDirectory: "/mirror/gpseg0"},
+				Target: &idl.Segment{HostName: "sdw3", Port: 9000, DataDirectory: "/new/gpseg0"},
+			},
+		}
+
+		result, err := hub.GetSegmentsToRecover(gparray, recoverPairs, false)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		target := *mirror0
+		target.Hostname = "sdw3"
+		target.Address = "sdw3"
+		target.Port = 9000
+		target.DataDir = "/new/gpseg0"
+		expected := []hub.SegmentRecovery{
+			{Source: *primary0, Failed: *mirror0, Target: target, Full: true},
+		}
+		if !reflect.DeepEqual(result, expected) {
+			t.Fatalf("got %+v, want %+v", result, expected)
+		}
+	})
+
+	t.Run("errors out when the given segment has not failed", func(t *testing.T) {
+		recoverPairs := []*idl.RecoverSegmentPair{
+			{Failed: &idl.Segment{HostName: "sdw1", DataDirectory: "/mirror/gpseg1"}},
+		}
+
+		_, err := hub.GetSegmentsToRecover(gparray, recoverPairs, false)
+		expected := "no failed segment found with data directory /mirror/gpseg1 on host sdw1"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+
+	t.Run("errors out when both the primary and mirror are down", func(t *testing.T) {
+		downPrimary := *primary0
+		downPrimary.Status = "d"
+		gparray := &greenplum.GpArray{
+			SegmentPairs: []greenplum.SegmentPair{{Primary: &downPrimary, Mirror: mirror0}},
+		}
+
+		_, err := hub.GetSegmentsToRecover(gparray, nil, true)
+		expected := "cannot recover content 0, both the primary and the mirror segments are down"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+}
+
+func TestRecoverMirrorSegments(t *testing.T) {
+	testhelper.SetupTestLogger()
+	hubServer := hub.New(testutils.CreateDummyServiceConfig(t))
+
+	source := greenplum.Segment{Dbid: 2, Content: 0, Hostname: "sdw1", Port: 7000, DataDir: "/primary/gpseg0"}
+	target := greenplum.Segment{Dbid: 4, Content: 0, Hostname: "sdw2", Port: 8000, DataDir: "/mirror/gpseg0"}
+
+	t.Run("runs pg_rewind for an incremental recovery", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sdw2 := mock_idl.NewMockAgentClient(ctrl)
+		sdw2.EXPECT().StopSegment(gomock.Any(), gomock.Any()).Return(nil, errors.New("not running"))
+		sdw2.EXPECT().PgRewind(
+			gomock.Any(),
+			&idl.PgRewindRequest{TargetDir: "/mirror/gpseg0", SourceHost: "sdw1", SourcePort: 7000, TargetDbid: 4},
+		).Return(&idl.PgRewindResponse{}, nil)
+		sdw2.EXPECT().UpdatePgConf(
+			gomock.Any(),
+			&idl.UpdatePgConfRequest{
+				Pgdata:    "/mirror/gpseg0",
+				Params:    map[string]string{"port": "8000", "primary_slot_name": "internal_wal_replication_slot"},
+				Overwrite: true,
+			},
+		).Return(&idl.UpdatePgConfRespoonse{}, nil)
+
+		hubServer.Conns = []*hub.Connection{
+			{AgentClient: sdw2, Hostname: "sdw2"},
+		}
+
+		mock, _ := testutils.NewMockStream()
+		err := hubServer.RecoverMirrorSegments(context.Background(), mock, []hub.SegmentRecovery{
+			{Source: source, Failed: target, Target: target},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("runs pg_basebackup for a full recovery on the relocated host", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		relocated := target
+		relocated.Hostname = "sdw3"
+
+		sdw3 := mock_idl.NewMockAgentClient(ctrl)
+		sdw3.EXPECT().PgBasebackup(
+			gomock.Any(),
+			&idl.PgBasebackupRequest{
+				TargetDir:           "/mirror/gpseg0",
+				SourceHost:          "sdw1",
+				SourcePort:          7000,
+				CreateSlot:          true,
+				ForceOverwrite:      true,
+				TargetDbid:          4,
+				WriteRecoveryConf:   true,
+				ReplicationSlotName: "internal_wal_replication_slot",
+			},
+		).Return(&idl.PgBasebackupResponse{}, nil)
+		sdw3.EXPECT().UpdatePgConf(gomock.Any(), gomock.Any()).Return(&idl.UpdatePgConfRespoonse{}, nil)
+
+		hubServer.Conns = []*hub.Connection{
+			{AgentClient: sdw3, Hostname: "sdw3"},
+		}
+
+		mock, _ := testutils.NewMockStream()
+		err := hubServer.RecoverMirrorSegments(context.Background(), mock, []hub.SegmentRecovery{
+			{Source: source, Failed: target, Target: relocated, Full: true},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("errors out when the recovery fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		expectedErr := errors.New("error")
+		sdw2 := mock_idl.NewMockAgentClient(ctrl)
+		sdw2.EXPECT().StopSegment(gomock.Any(), gomock.Any()).Return(&idl.StopSegmentReply{}, nil)
+		sdw2.EXPECT().PgRewind(gomock.Any(), gomock.Any()).Return(nil, expectedErr)
+
+		hubServer.Conns = []*hub.Connection{
+			{AgentClient: sdw2, Hostname: "sdw2"},
+		}
+
+		mock, _ := testutils.NewMockStream()
+		err := hubServer.RecoverMirrorSegments(context.Background(), mock, []hub.SegmentRecovery{
+			{Source: source, Failed: target, Target: target},
+		})
+		if !errors.Is(err, expectedErr) {
+			t.Fatalf("got %#v, want %#v", err, expectedErr)
+		}
+
+		expectedErrString := "host: sdw2, error"
+		if err.Error() != expectedErrString {
+			t.Fatalf("got %v, want %s", err, expectedErrString)
+		}
+	})
+}
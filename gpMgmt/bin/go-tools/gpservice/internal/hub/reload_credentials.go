@@ -0,0 +1,54 @@
+package hub
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"github.com/greenplum-db/gpdb/gpservice/idl"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+)
+
+/*
+ReloadCredentials loads the TLS certificate and key files again on the agents and the
+hub, so that rotated certificates take effect without restarting the services. The
+connections to the agents are closed afterwards, so that they are dialled again with
+the reloaded client certificate when next needed. It is a cluster operation, so that it
+is rejected rather than closing the connections while another operation is using them.
+*/
+func (s *Server) ReloadCredentials(ctx context.Context, req *idl.ReloadCredentialsRequest) (*idl.ReloadCredentialsReply, error) {
+	err := s.DialAllAgents()
+	if err != nil {
+		return &idl.ReloadCredentialsReply{}, utils.LogAndReturnError(err)
+	}
+
+	request := func(conn *Connection) error {
+		_, err := conn.AgentClient.ReloadCredentials(ctx, &idl.ReloadAgentCredentialsRequest{})
+		return err
+	}
+
+	err = ExecuteRPC(s.connections(), request)
+	if err != nil {
+		return &idl.ReloadCredentialsReply{}, utils.LogAndReturnError(fmt.Errorf("could not reload credentials on the agents: %w", err))
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.credentials != nil {
+		err = s.credentials.Reload()
+		if err != nil {
+			return &idl.ReloadCredentialsReply{}, utils.LogAndReturnError(fmt.Errorf("could not reload credentials on the hub: %w", err))
+		}
+	}
+
+	for _, conn := range s.Conns {
+		if conn.Conn != nil {
+			conn.Conn.Close()
+		}
+	}
+	s.Conns = nil
+
+	gplog.Info("Reloaded the TLS credentials on the hub and agents")
+	return &idl.ReloadCredentialsReply{}, nil
+}
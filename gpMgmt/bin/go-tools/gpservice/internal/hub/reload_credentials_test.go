@@ -0,0 +1,60 @@
+package hub_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+	"github.com/greenplum-db/gpdb/gpservice/idl"
+	"github.com/greenplum-db/gpdb/gpservice/idl/mock_idl"
+	"github.com/greenplum-db/gpdb/gpservice/internal/hub"
+	"github.com/greenplum-db/gpdb/gpservice/testutils"
+)
+
+func TestReloadCredentials(t *testing.T) {
+	testhelper.SetupTestLogger()
+	hubConfig := testutils.CreateDummyServiceConfig(t)
+
+	t.Run("reloads the credentials on the agents and reconnects to them", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		hubServer := hub.New(hubConfig)
+
+		var conns []*hub.Connection
+		for _, host := range []string{"sdw1", "sdw2"} {
+			client := mock_idl.NewMockAgentClient(ctrl)
+			client.EXPECT().ReloadCredentials(gomock.Any(), &idl.ReloadAgentCredentialsRequest{}).Return(&idl.ReloadAgentCredentialsReply{}, nil)
+			conns = append(conns, &hub.Connection{AgentClient: client, Hostname: host})
+		}
+		hubServer.Conns = conns
+
+		_, err := hubServer.ReloadCredentials(context.Background(), &idl.ReloadCredentialsRequest{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if hubServer.Conns != nil {
+			t.Fatalf("expected the connections to the agents to be closed, got %v", hubServer.Conns)
+		}
+	})
+
+	t.Run("errors out when the agents could not reload their credentials", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		hubServer := hub.New(hubConfig)
+
+		client := mock_idl.NewMockAgentClient(ctrl)
+		client.EXPECT().ReloadCredentials(gomock.Any(), gomock.Any()).Return(nil, errors.New("error"))
+		hubServer.Conns = []*hub.Connection{{AgentClient: client, Hostname: "sdw1"}}
+
+		_, err := hubServer.ReloadCredentials(context.Background(), &idl.ReloadCredentialsRequest{})
+		expected := "could not reload credentials on the agents: host: sdw1, error"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+
+		if len(hubServer.Conns) != 1 {
+			t.Fatalf("expected the connections to the agents to be kept, got %v", hubServer.Conns)
+		}
+	})
+}
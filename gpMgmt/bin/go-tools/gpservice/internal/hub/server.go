@@ -9,6 +9,7 @@ import (
 	"strings"
 	"sync"
 
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"golang.org/x/exp/slices"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -19,9 +20,9 @@ import (
 
 	"github.com/greenplum-db/gp-common-go-libs/gplog"
 	"github.com/greenplum-db/gpdb/gpservice/idl"
+	"github.com/greenplum-db/gpdb/gpservice/internal/metrics"
 	. "github.com/greenplum-db/gpdb/gpservice/internal/platform"
 	. "github.com/greenplum-db/gpdb/gpservice/pkg/gpservice_config"
-	"github.com/greenplum-db/gpdb/gpservice/pkg/greenplum"
 	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
 )
 
@@ -31,11 +32,14 @@ var (
 
 type Server struct {
 	*Config
-	Conns      []*Connection
-	mutex      sync.Mutex
-	grpcServer *grpc.Server
-	listener   net.Listener
-	finish     chan struct{}
+	Conns       []*Connection
+	mutex       sync.Mutex
+	grpcServer  *grpc.Server
+	listener    net.Listener
+	finish      chan struct{}
+	credentials *utils.ReloadableCredentials
+	metrics     *metrics.Metrics
+	operations  *operationRegistry
 }
 
 type Connection struct {
@@ -46,36 +50,64 @@ type Connection struct {
 
 func New(conf *Config) *Server {
 	h := &Server{
-		Config: conf,
-		finish: make(chan struct{}, 1),
+		Config:     conf,
+		finish:     make(chan struct{}, 1),
+		metrics:    metrics.New("hub", conf.LogDir),
+		operations: newOperationRegistry(),
 	}
 	return h
 }
 
 func (s *Server) Start() error {
-	listener, err := net.Listen("tcp", fmt.Sprintf("0.0.0.0:%d", s.HubPort)) // TODO: make this "hostname:port" so it can be started from somewhere other than the coordinator host
+	// Listen on all the interfaces since the hub can run on any host of the cluster
+	// after the standby coordinator is activated, and is reached through the hub host
+	// of the service configuration from the other hosts
+	listener, err := net.Listen("tcp", fmt.Sprintf("0.0.0.0:%d", s.HubPort))
 	if err != nil {
 		return fmt.Errorf("could not listen on port %d: %w", s.HubPort, err)
 	}
 
-	interceptor := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
-		// handle stuff here if needed
-		return handler(ctx, req)
+	err = s.Auth.Validate(s.Credentials)
+	if err != nil {
+		listener.Close()
+		return err
 	}
+	authorizer := utils.NewAuthorizer(s.Auth, s.ServiceIdentities())
 
 	var grpcServer *grpc.Server
-	credentials, err := s.Credentials.LoadServerCredentials()
+	credentials, err := utils.NewReloadableCredentials(s.Auth.ServerCredentials(s.Credentials))
+	if err != nil {
+		listener.Close()
+		return err
+	}
+
+	auditLog, err := utils.OpenAuditLog(s.LogDir, "hub")
+	if err != nil {
+		listener.Close()
+		return err
+	}
+	defer auditLog.Close()
+
+	metricsServer, err := s.serveMetrics()
 	if err != nil {
+		listener.Close()
 		return err
 	}
+	if metricsServer != nil {
+		defer metricsServer.Close()
+	}
+
 	grpcServer = grpc.NewServer(
 		grpc.Creds(credentials),
-		grpc.UnaryInterceptor(interceptor),
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(s.metrics.UnaryInterceptor(), auditLog.UnaryInterceptor(), authorizer.UnaryInterceptor(), s.OperationsUnaryInterceptor()),
+		grpc.ChainStreamInterceptor(s.metrics.StreamInterceptor(), auditLog.StreamInterceptor(), authorizer.StreamInterceptor(), s.OperationsStreamInterceptor()),
 	)
 
 	s.mutex.Lock()
 	s.grpcServer = grpcServer
 	s.listener = listener
+	s.credentials = credentials
 	s.mutex.Unlock()
 
 	healthcheck := health.NewServer()
@@ -94,6 +126,10 @@ func (s *Server) Start() error {
 		wg.Done()
 	}()
 
+	done := make(chan struct{})
+	defer close(done)
+	go credentials.Watch(utils.CredentialPaths(s.Credentials), done)
+
 	err = grpcServer.Serve(listener)
 	if err != nil {
 		return fmt.Errorf("failed to serve: %w", err)
@@ -137,19 +173,9 @@ func (s *Server) StartAgents(ctx context.Context, in *idl.StartAgentsRequest) (*
 }
 
 func (s *Server) StartAllAgents() error {
-	gpsshCmd := &greenplum.GpSSH{
-		Hostnames: s.Hostnames,
-		Command:   strings.Join(platform.GetStartAgentCommandString(s.ServiceName), " "),
-	}
-	out, err := utils.RunGpSourcedCommand(gpsshCmd, s.GpHome)
+	err := utils.Remote.Run(s.hostnames(), strings.Join(platform.GetStartAgentCommandString(s.ServiceName), " ")).Err()
 	if err != nil {
-		return fmt.Errorf("could not start agents: %s, %w", out, err)
-	}
-
-	// As command is run through gpssh, even if actual command returns error, gpssh still returns as success.
-	// to overcome this we have added check the command output.
-	if strings.Contains(out.String(), "ERROR") || strings.Contains(out.String(), "No such file or directory") {
-		return fmt.Errorf("could not start agents: %s, %w", out, err)
+		return fmt.Errorf("could not start agents: %w", err)
 	}
 
 	return nil
@@ -170,23 +196,110 @@ func (s *Server) DialAllAgents(opts ...grpc.DialOption) error {
 	opts = append(opts, grpc.WithTransportCredentials(credentials))
 
 	for _, host := range s.Hostnames {
-
-		address := net.JoinHostPort(host, strconv.Itoa(s.AgentPort))
-		conn, err := grpc.NewClient(address, opts...)
+		conn, err := s.newAgentConnection(host, opts...)
 		if err != nil {
-			return fmt.Errorf("could not connect to agent on host %s: %w", host, err)
+			return err
 		}
 
-		s.Conns = append(s.Conns, &Connection{
-			Conn:        conn,
-			AgentClient: idl.NewAgentClient(conn),
-			Hostname:    host,
-		})
+		s.Conns = append(s.Conns, conn)
 	}
 
 	return nil
 }
 
+// connections returns the connections to the agents. UpdateHosts replaces them while
+// other calls are running, so they are read under the mutex.
+func (s *Server) connections() []*Connection {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.Conns
+}
+
+// hostnames returns the hosts managed by the hub, which UpdateHosts replaces in the same
+// way as the connections
+func (s *Server) hostnames() []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.Hostnames
+}
+
+func (s *Server) newAgentConnection(host string, opts ...grpc.DialOption) (*Connection, error) {
+	address := net.JoinHostPort(host, strconv.Itoa(s.AgentPort))
+	opts = append(opts, grpc.WithStatsHandler(otelgrpc.NewClientHandler()))
+	conn, err := grpc.NewClient(address, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("could not connect to agent on host %s: %w", host, err)
+	}
+
+	return &Connection{
+		Conn:        conn,
+		AgentClient: idl.NewAgentClient(conn),
+		Hostname:    host,
+	}, nil
+}
+
+// UpdateHosts replaces the hosts on which the hub manages the agents, so that hosts can be
+// added to or removed from the service configuration without restarting the hub. The
+// connections to the removed hosts are closed, and the new hosts are connected to if the
+// hub has already connected to the agents.
+func (s *Server) UpdateHosts(ctx context.Context, req *idl.UpdateHostsRequest) (*idl.UpdateHostsReply, error) {
+	if len(req.Hostnames) == 0 {
+		return &idl.UpdateHostsReply{}, utils.LogAndReturnError(fmt.Errorf("no hosts provided to update the hub with"))
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var conns, removed []*Connection
+	for _, conn := range s.Conns {
+		if slices.Contains(req.Hostnames, conn.Hostname) {
+			conns = append(conns, conn)
+		} else {
+			removed = append(removed, conn)
+		}
+	}
+
+	if s.Conns != nil {
+		credentials, err := s.Credentials.LoadClientCredentials()
+		if err != nil {
+			return &idl.UpdateHostsReply{}, utils.LogAndReturnError(err)
+		}
+
+		var opened []*Connection
+		for _, host := range req.Hostnames {
+			if len(getConnForHosts(s.Conns, []string{host})) > 0 {
+				continue
+			}
+
+			conn, err := s.newAgentConnection(host, grpc.WithTransportCredentials(credentials))
+			if err != nil {
+				// The hosts are left unchanged, so the connections to the new hosts are not kept
+				for _, conn := range opened {
+					conn.Conn.Close()
+				}
+
+				return &idl.UpdateHostsReply{}, utils.LogAndReturnError(err)
+			}
+			opened = append(opened, conn)
+			conns = append(conns, conn)
+		}
+	}
+
+	for _, conn := range removed {
+		if conn.Conn != nil {
+			conn.Conn.Close()
+		}
+	}
+
+	s.Conns = conns
+	s.Hostnames = req.Hostnames
+	gplog.Info("Updated the hub to manage the agents on hosts %s", strings.Join(req.Hostnames, ", "))
+
+	return &idl.UpdateHostsReply{}, nil
+}
+
 func (s *Server) ReportAgentHealth(ctx context.Context, in *idl.ReportAgentHealthRequest) (*idl.ReportAgentHealthResponse, error) {
 	err := s.CheckAgentHealth()
 	if err != nil {
@@ -204,7 +317,7 @@ func (s *Server) CheckAgentHealth() error {
 		return err
 	}
 
-	for _, conn := range s.Conns {
+	for _, conn := range s.connections() {
 		healthErr := utils.CheckGRPCServerHealth(conn.Conn)
 		if healthErr != nil {
 			err = errors.Join(err, healthErr)
@@ -216,7 +329,7 @@ func (s *Server) CheckAgentHealth() error {
 
 func (s *Server) StopAgents(ctx context.Context, in *idl.StopAgentsRequest) (*idl.StopAgentsReply, error) {
 	request := func(conn *Connection) error {
-		_, err := conn.AgentClient.Stop(context.Background(), &idl.StopAgentRequest{})
+		_, err := conn.AgentClient.Stop(ctx, &idl.StopAgentRequest{})
 		if err == nil { // no error -> didn't stop
 			return fmt.Errorf("failed to stop agent on host %s", conn.Hostname)
 		}
@@ -234,17 +347,26 @@ func (s *Server) StopAgents(ctx context.Context, in *idl.StopAgentsRequest) (*id
 		return &idl.StopAgentsReply{}, err
 	}
 
-	err = ExecuteRPC(s.Conns, request)
+	err = ExecuteRPC(s.connections(), request)
+
+	s.mutex.Lock()
 	s.Conns = nil
+	s.mutex.Unlock()
 
 	return &idl.StopAgentsReply{}, err
 }
 
 func (s *Server) StatusAgents(ctx context.Context, in *idl.StatusAgentsRequest) (*idl.StatusAgentsReply, error) {
-	statusChan := make(chan *idl.ServiceStatus, len(s.Conns))
+	err := s.DialAllAgents()
+	if err != nil {
+		return &idl.StatusAgentsReply{}, err
+	}
+
+	conns := s.connections()
+	statusChan := make(chan *idl.ServiceStatus, len(conns))
 
 	request := func(conn *Connection) error {
-		status, err := conn.AgentClient.Status(context.Background(), &idl.StatusAgentRequest{})
+		status, err := conn.AgentClient.Status(ctx, &idl.StatusAgentRequest{})
 		if err != nil {
 			return fmt.Errorf("failed to get agent status on host %s", conn.Hostname)
 		}
@@ -260,11 +382,7 @@ func (s *Server) StatusAgents(ctx context.Context, in *idl.StatusAgentsRequest)
 		return nil
 	}
 
-	err := s.DialAllAgents()
-	if err != nil {
-		return &idl.StatusAgentsReply{}, err
-	}
-	err = ExecuteRPC(s.Conns, request)
+	err = ExecuteRPC(conns, request)
 	if err != nil {
 		return &idl.StatusAgentsReply{}, err
 	}
@@ -3,6 +3,8 @@ package hub_test
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net"
 	"os"
 	"reflect"
 	"sort"
@@ -43,6 +45,7 @@ func TestStartServer(t *testing.T) {
 		hubConfig := testutils.CreateDummyServiceConfig(t)
 		hubConfig.Hostnames = []string{"localhost"}
 		hubConfig.HubPort = testutils.GetPort(t)
+		hubConfig.LogDir = t.TempDir()
 		hubServer := hub.New(hubConfig)
 
 		errChan := make(chan error, 1)
@@ -61,9 +64,10 @@ func TestStartServer(t *testing.T) {
 		}
 	})
 
-	t.Run("fails to start the server if not able to load the credentials", func(t *testing.T) {
+	t.Run("fails to start the server and releases the port if not able to load the credentials", func(t *testing.T) {
 		expected := errors.New("error")
 		hubConfig := testutils.CreateDummyServiceConfig(t)
+		hubConfig.HubPort = testutils.GetPort(t)
 		hubConfig.Credentials = &testutils.MockCredentials{
 			Err: expected,
 		}
@@ -83,6 +87,12 @@ func TestStartServer(t *testing.T) {
 		case <-time.After(1 * time.Second):
 			t.Fatalf("failed to raise error if load credential fail")
 		}
+
+		listener, err := net.Listen("tcp", fmt.Sprintf("0.0.0.0:%d", hubConfig.HubPort))
+		if err != nil {
+			t.Fatalf("expected the port to be released, got %v", err)
+		}
+		listener.Close()
 	})
 }
 
@@ -92,8 +102,7 @@ func TestStartAgents(t *testing.T) {
 	t.Run("successfully starts the agents from hub", func(t *testing.T) {
 		hubServer := hub.New(testutils.CreateDummyServiceConfig(t))
 
-		utils.System.ExecCommand = exectest.NewCommand(exectest.Success)
-		defer utils.ResetSystemFunctions()
+		remote := testutils.SetMockRemoteExecutor(t)
 
 		utils.SetNewHealthClient(testutils.NewMockHealthClient(grpc_health_v1.HealthCheckResponse_SERVING, nil))
 		defer utils.ResetNewHealthClient()
@@ -102,6 +111,29 @@ func TestStartAgents(t *testing.T) {
 		if err != nil {
 			t.Fatalf("%v", err)
 		}
+
+		if len(remote.Commands) != 1 || !strings.Contains(remote.Commands[0], "start gpservice_agent") {
+			t.Fatalf("got %v, want the agents to be started", remote.Commands)
+		}
+	})
+
+	t.Run("errors out when not able to start the agent on a host", func(t *testing.T) {
+		hubServer := hub.New(testutils.CreateDummyServiceConfig(t))
+
+		remote := testutils.SetMockRemoteExecutor(t)
+		remote.Result = func(hostname, command string) *utils.RemoteResult {
+			if hostname == "sdw2" {
+				return &utils.RemoteResult{ExitCode: 5, Stderr: "Unit gpservice_agent.service not found."}
+			}
+
+			return &utils.RemoteResult{}
+		}
+
+		_, err := hubServer.StartAgents(context.Background(), &idl.StartAgentsRequest{})
+		expected := "could not start agents: host sdw2: exit status 5: Unit gpservice_agent.service not found."
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
 	})
 }
 
@@ -138,6 +170,73 @@ func TestDialAllAgents(t *testing.T) {
 	})
 }
 
+func TestUpdateHosts(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	t.Run("keeps the existing connections and connects to the new hosts", func(t *testing.T) {
+		hubServer := hub.New(testutils.CreateDummyServiceConfig(t))
+		err := hubServer.DialAllAgents()
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+		sdw2 := hubServer.Conns[1]
+
+		_, err = hubServer.UpdateHosts(context.Background(), &idl.UpdateHostsRequest{Hostnames: []string{"sdw2", "sdw3"}})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		expectedHosts := []string{"sdw2", "sdw3"}
+		if !reflect.DeepEqual(hubServer.Hostnames, expectedHosts) {
+			t.Fatalf("got %+v, want %+v", hubServer.Hostnames, expectedHosts)
+		}
+
+		connectedHosts := []string{}
+		for _, conn := range hubServer.Conns {
+			connectedHosts = append(connectedHosts, conn.Hostname)
+		}
+		if !reflect.DeepEqual(connectedHosts, expectedHosts) {
+			t.Fatalf("got %+v, want %+v", connectedHosts, expectedHosts)
+		}
+
+		if hubServer.Conns[0] != sdw2 {
+			t.Fatalf("expected the connection to sdw2 to be reused")
+		}
+	})
+
+	t.Run("does not connect to the agents if not already connected", func(t *testing.T) {
+		hubServer := hub.New(testutils.CreateDummyServiceConfig(t))
+
+		_, err := hubServer.UpdateHosts(context.Background(), &idl.UpdateHostsRequest{Hostnames: []string{"sdw1", "sdw3"}})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		if hubServer.Conns != nil {
+			t.Fatalf("expected no connections, got %+v", hubServer.Conns)
+		}
+
+		expectedHosts := []string{"sdw1", "sdw3"}
+		if !reflect.DeepEqual(hubServer.Hostnames, expectedHosts) {
+			t.Fatalf("got %+v, want %+v", hubServer.Hostnames, expectedHosts)
+		}
+	})
+
+	t.Run("errors out when no hosts are provided", func(t *testing.T) {
+		hubServer := hub.New(testutils.CreateDummyServiceConfig(t))
+
+		_, err := hubServer.UpdateHosts(context.Background(), &idl.UpdateHostsRequest{})
+		expected := "no hosts provided to update the hub with"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+
+		if !reflect.DeepEqual(hubServer.Hostnames, []string{"sdw1", "sdw2"}) {
+			t.Fatalf("expected the hosts to not be updated, got %+v", hubServer.Hostnames)
+		}
+	})
+}
+
 func TestStatusAgents(t *testing.T) {
 	testhelper.SetupTestLogger()
 	hubServer := hub.New(testutils.CreateDummyServiceConfig(t))
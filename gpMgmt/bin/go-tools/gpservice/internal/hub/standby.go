@@ -0,0 +1,285 @@
+package hub
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"github.com/greenplum-db/gpdb/gpservice/constants"
+	"github.com/greenplum-db/gpdb/gpservice/idl"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/greenplum"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+)
+
+// AddStandby is the hub RPC which adds a standby coordinator to a running cluster.
+// The standby is copied from the coordinator using pg_basebackup and started as a
+// streaming replica of the coordinator.
+func (s *Server) AddStandby(req *idl.AddStandbyRequest, stream idl.Hub_AddStandbyServer) error {
+	hubStream := NewHubStream(stream)
+	hubStream.StreamLogMsg("Starting to add the standby coordinator to the cluster")
+
+	// A rollback removes everything listed in the cleanup file, which must only be the standby
+	err := s.checkNoPendingCleanup()
+	if err != nil {
+		return utils.LogAndReturnError(err)
+	}
+
+	err = s.DialAllAgents()
+	if err != nil {
+		return utils.LogAndReturnError(err)
+	}
+
+	conn, err := greenplum.GetCoordinatorConn(stream.Context(), req.CoordinatorDataDir, "", true)
+	if err != nil {
+		return utils.LogAndReturnError(err)
+	}
+	defer conn.DB.Close()
+
+	gparray, err := greenplum.NewGpArrayFromCatalog(conn.DB)
+	if err != nil {
+		return utils.LogAndReturnError(err)
+	}
+
+	if gparray.Standby != nil {
+		return utils.LogAndReturnError(fmt.Errorf("cannot add the standby coordinator, the cluster already has a standby coordinator on host %s", gparray.Standby.Hostname))
+	}
+
+	conns := getConnForHosts(s.connections(), []string{req.Standby.HostName})
+	if len(conns) == 0 {
+		return utils.LogAndReturnError(fmt.Errorf("following hostnames [%s] do not have gp services configured. Please configure the services", req.Standby.HostName))
+	}
+
+	clusterParams, err := GetExpansionClusterParams(conn)
+	if err != nil {
+		return utils.LogAndReturnError(err)
+	}
+
+	err = s.ValidateExpansionHosts(stream.Context(), &hubStream, conns, []*idl.Segment{req.Standby}, nil, clusterParams.Locale)
+	if err != nil {
+		return utils.LogAndReturnError(fmt.Errorf("validating hosts: %w", err))
+	}
+
+	// Adding the standby data to the entries file so that a failed attempt can be cleaned up
+	filename := filepath.Join(s.LogDir, constants.CleanFileName)
+	err = WriteSegmentCleanupFile([]greenplum.Segment{{Hostname: req.Standby.HostName, DataDir: req.Standby.DataDirectory}}, filename)
+	if err != nil {
+		return utils.LogAndReturnError(err)
+	}
+
+	err = s.CreateStandby(stream.Context(), &hubStream, conn, gparray.Coordinator, req.Standby, req.HbaHostnames)
+	if err != nil {
+		return utils.LogAndReturnError(err)
+	}
+
+	// The standby is now part of the cluster, so it should no longer be removed on a rollback
+	os.Remove(filename)
+
+	hubStream.StreamLogMsg("Standby coordinator has been added")
+
+	return nil
+}
+
+// CreateStandby registers the standby coordinator with the coordinator, allows its
+// replication connections in the coordinator pg_hba.conf, copies the coordinator
+// data directory with pg_basebackup and starts the standby. The standby is removed
+// from the catalog, along with its replication slot and pg_hba.conf entries, again if
+// any of the steps after the registration fail.
+func (s *Server) CreateStandby(ctx context.Context, stream hubStreamer, conn *utils.DBConnWithContext, coordinator *greenplum.Segment, standby *idl.Segment, hbaHostnames bool) (err error) {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	stream.StreamLogMsg("Starting to register the standby coordinator with the coordinator")
+	err = greenplum.RegisterStandby(standby, conn)
+	if err != nil {
+		return err
+	}
+	stream.StreamLogMsg("Successfully registered the standby coordinator with the coordinator")
+
+	var registered *greenplum.Segment
+	defer func() {
+		if err != nil {
+			unregisterErr := greenplum.UnregisterStandby(conn)
+			if unregisterErr != nil {
+				gplog.Warn("failed to unregister the standby coordinator: %v", unregisterErr)
+			}
+
+			// pg_basebackup creates the replication slot, which would otherwise retain the WAL on the coordinator
+			dropErr := DropStandbyReplicationSlot(conn)
+			if dropErr != nil {
+				gplog.Warn("failed to drop the replication slot of the standby coordinator: %v", dropErr)
+			}
+
+			if registered != nil {
+				removeErr := s.RemovePgHbaConfStandbyEntries(context.Background(), coordinator, registered)
+				if removeErr != nil {
+					gplog.Warn("failed to remove the standby coordinator entries from the pg_hba.conf on the coordinator: %v", removeErr)
+				}
+			}
+		}
+	}()
+
+	gparray, err := greenplum.NewGpArrayFromCatalog(conn.DB)
+	if err != nil {
+		return err
+	}
+
+	if gparray.Standby == nil {
+		return fmt.Errorf("standby coordinator not found in the catalog after registering it")
+	}
+	registered = gparray.Standby
+
+	stream.StreamLogMsg("Starting to modify the pg_hba.conf on the coordinator to add the standby entries")
+	err = s.UpdatePgHbaConfWithStandbyEntries(ctx, coordinator, gparray.Standby, hbaHostnames)
+	if err != nil {
+		return err
+	}
+	stream.StreamLogMsg("Successfully modified the pg_hba.conf on the coordinator")
+
+	stream.StreamLogMsg(fmt.Sprintf("Creating the standby coordinator on host %s", standby.HostName))
+	request := func(conn *Connection) error {
+		_, err := conn.AgentClient.PgBasebackup(ctx, &idl.PgBasebackupRequest{
+			TargetDir:           gparray.Standby.DataDir,
+			SourceHost:          coordinator.Hostname,
+			SourcePort:          int32(coordinator.Port),
+			CreateSlot:          true,
+			TargetDbid:          int32(gparray.Standby.Dbid),
+			WriteRecoveryConf:   true,
+			ReplicationSlotName: constants.ReplicationSlotName,
+		})
+		if err != nil {
+			return utils.FormatGrpcError(err)
+		}
+		gplog.Debug("Successfully ran pg_basebackup for the standby coordinator with data directory %s on host %s", gparray.Standby.DataDir, gparray.Standby.Hostname)
+
+		_, err = conn.AgentClient.UpdatePgConf(ctx, &idl.UpdatePgConfRequest{
+			Pgdata: gparray.Standby.DataDir,
+			Params: map[string]string{
+				"port": strconv.Itoa(gparray.Standby.Port),
+			},
+			Overwrite: true,
+		})
+
+		return err
+	}
+
+	err = ExecuteRPC(getConnForHosts(s.connections(), []string{standby.HostName}), request)
+	if err != nil {
+		return err
+	}
+	stream.StreamLogMsg("Successfully created the standby coordinator")
+
+	stream.StreamLogMsg("Starting up the standby coordinator")
+	err = s.StartSegments(ctx, stream, []greenplum.Segment{*gparray.Standby}, "")
+	if err != nil {
+		return fmt.Errorf("starting standby coordinator: %w", err)
+	}
+	stream.StreamLogMsg("Successfully started the standby coordinator")
+
+	return nil
+}
+
+// RemoveStandby is the hub RPC which stops the standby coordinator, removes it from
+// the catalog along with its replication slot and pg_hba.conf entries, and deletes its
+// data directory.
+func (s *Server) RemoveStandby(req *idl.RemoveStandbyRequest, stream idl.Hub_RemoveStandbyServer) error {
+	hubStream := NewHubStream(stream)
+	hubStream.StreamLogMsg("Starting to remove the standby coordinator from the cluster")
+
+	err := s.DialAllAgents()
+	if err != nil {
+		return utils.LogAndReturnError(err)
+	}
+
+	conn, err := greenplum.GetCoordinatorConn(stream.Context(), req.CoordinatorDataDir, "", true)
+	if err != nil {
+		return utils.LogAndReturnError(err)
+	}
+	defer conn.DB.Close()
+
+	gparray, err := greenplum.NewGpArrayFromCatalog(conn.DB)
+	if err != nil {
+		return utils.LogAndReturnError(err)
+	}
+
+	if gparray.Standby == nil {
+		return utils.LogAndReturnError(fmt.Errorf("the cluster does not have a standby coordinator"))
+	}
+	standby := *gparray.Standby
+
+	// An unreachable standby host should not prevent the standby from being
+	// removed from the cluster, so only the catalog changes are mandatory
+	statuses, err := s.GetSegmentStatuses(stream.Context(), &greenplum.GpArray{Standby: &standby})
+	if err != nil {
+		return utils.LogAndReturnError(err)
+	}
+	reachable := statuses[0].Error == ""
+	if !reachable {
+		hubStream.StreamLogMsg(fmt.Sprintf("Unable to reach the standby coordinator on host %s: %s", standby.Hostname, statuses[0].Error), idl.LogLevel_WARNING)
+	}
+
+	if statuses[0].Running {
+		hubStream.StreamLogMsg(fmt.Sprintf("Stopping the standby coordinator on host %s", standby.Hostname))
+		_, err = s.StopSegments(stream.Context(), &hubStream, []greenplum.Segment{standby}, constants.ShutdownModeFast, 0)
+		if err != nil {
+			return utils.LogAndReturnError(fmt.Errorf("stopping standby coordinator: %w", err))
+		}
+		hubStream.StreamLogMsg("Successfully stopped the standby coordinator")
+	}
+
+	hubStream.StreamLogMsg("Removing the standby coordinator from the catalog")
+	err = greenplum.UnregisterStandby(conn)
+	if err != nil {
+		return utils.LogAndReturnError(err)
+	}
+
+	err = DropStandbyReplicationSlot(conn)
+	if err != nil {
+		return utils.LogAndReturnError(err)
+	}
+	hubStream.StreamLogMsg("Successfully removed the standby coordinator from the catalog")
+
+	hubStream.StreamLogMsg("Removing the standby coordinator entries from the pg_hba.conf on the coordinator")
+	err = s.RemovePgHbaConfStandbyEntries(stream.Context(), gparray.Coordinator, &standby)
+	if err != nil {
+		return utils.LogAndReturnError(err)
+	}
+
+	if !reachable {
+		hubStream.StreamLogMsg(fmt.Sprintf("Data directory %s on host %s has not been removed, please remove it manually", standby.DataDir, standby.Hostname), idl.LogLevel_WARNING)
+		return nil
+	}
+
+	hubStream.StreamLogMsg(fmt.Sprintf("Removing the standby data directory %s on host %s", standby.DataDir, standby.Hostname))
+	request := func(conn *Connection) error {
+		_, err := conn.AgentClient.RemoveDirectory(stream.Context(), &idl.RemoveDirectoryRequest{
+			DataDirectory: standby.DataDir,
+		})
+
+		return utils.FormatGrpcError(err)
+	}
+
+	err = ExecuteRPC(getConnForHosts(s.connections(), []string{standby.Hostname}), request)
+	if err != nil {
+		return utils.LogAndReturnError(err)
+	}
+
+	hubStream.StreamLogMsg("Standby coordinator has been removed")
+
+	return nil
+}
+
+// DropStandbyReplicationSlot drops the replication slot used by the standby coordinator
+// so that the coordinator does not retain the WAL once the standby is gone
+func DropStandbyReplicationSlot(conn *utils.DBConnWithContext) error {
+	query := fmt.Sprintf("SELECT pg_catalog.pg_drop_replication_slot(slot_name) FROM pg_catalog.pg_replication_slots WHERE slot_name = '%s'", constants.ReplicationSlotName)
+	_, err := conn.DB.ExecContext(conn.Ctx, query)
+	if err != nil {
+		return fmt.Errorf("dropping the replication slot %s: %w", constants.ReplicationSlotName, err)
+	}
+
+	return nil
+}
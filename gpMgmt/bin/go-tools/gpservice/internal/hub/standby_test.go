@@ -0,0 +1,155 @@
+package hub_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+
+	"github.com/greenplum-db/gp-common-go-libs/dbconn"
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+	"github.com/greenplum-db/gpdb/gpservice/constants"
+	"github.com/greenplum-db/gpdb/gpservice/idl"
+	"github.com/greenplum-db/gpdb/gpservice/internal/hub"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/greenplum"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+	"github.com/greenplum-db/gpdb/gpservice/testutils"
+)
+
+func TestStandby(t *testing.T) {
+	testhelper.SetupTestLogger()
+	initialize(t)
+
+	standby := createSegment(t, 6, -1, constants.RoleMirror, constants.RoleMirror, 7000, "scdw", "scdw", "/data/standby/gpseg-1")
+
+	setupCatalog := func(t *testing.T, segs ...*greenplum.Segment) {
+		utils.System.Open = func(name string) (*os.File, error) {
+			reader, writer, _ := os.Pipe()
+			defer writer.Close()
+
+			_, err := writer.WriteString("port=1234")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			return reader, nil
+		}
+
+		utils.SetNewDBConnFromEnvironment(func(dbname string) *dbconn.DBConn {
+			conn, mock := testutils.CreateMockDBConnForUtilityMode(t)
+			testhelper.ExpectVersionQuery(mock, "7.0.0")
+
+			rows := sqlmock.NewRows([]string{"dbid", "content", "role", "preferredrole", "port", "hostname", "address", "datadir"})
+			addSegmentRows(t, rows, segs...)
+			mock.ExpectQuery("SELECT").WillReturnRows(rows)
+			return conn
+		})
+	}
+
+	t.Run("AddStandby errors out when a failed command has left its cleanup file", func(t *testing.T) {
+		utils.System.Stat = func(name string) (os.FileInfo, error) {
+			return nil, nil
+		}
+		defer utils.ResetSystemFunctions()
+
+		_, stream := testutils.NewMockStream()
+		err := hubServer.AddStandby(&idl.AddStandbyRequest{
+			Standby: &idl.Segment{HostName: "sdw1"},
+		}, stream)
+
+		expectedErr := fmt.Sprintf("the cleanup file %s of a previously failed command exists. Run the failed gpctl command with --clean before proceeding", filepath.Join(hubServer.LogDir, constants.CleanFileName))
+		if err == nil || err.Error() != expectedErr {
+			t.Fatalf("got %v, want %s", err, expectedErr)
+		}
+	})
+
+	t.Run("AddStandby errors out when the cluster already has a standby", func(t *testing.T) {
+		setupCatalog(t, coordinator, standby, primary1, primary2)
+		defer utils.ResetSystemFunctions()
+		defer utils.ResetNewDBConnFromEnvironment()
+
+		hubServer.Conns = []*hub.Connection{}
+
+		_, stream := testutils.NewMockStream()
+		err := hubServer.AddStandby(&idl.AddStandbyRequest{
+			Standby: &idl.Segment{HostName: "sdw1"},
+		}, stream)
+
+		expectedErr := "cannot add the standby coordinator, the cluster already has a standby coordinator on host scdw"
+		if err == nil || err.Error() != expectedErr {
+			t.Fatalf("got %v, want %s", err, expectedErr)
+		}
+	})
+
+	t.Run("AddStandby errors out when the standby host does not have gp services configured", func(t *testing.T) {
+		setupCatalog(t, coordinator, primary1, primary2)
+		defer utils.ResetSystemFunctions()
+		defer utils.ResetNewDBConnFromEnvironment()
+
+		hubServer.Conns = []*hub.Connection{{Hostname: "sdw1"}, {Hostname: "sdw2"}}
+
+		_, stream := testutils.NewMockStream()
+		err := hubServer.AddStandby(&idl.AddStandbyRequest{
+			Standby: &idl.Segment{HostName: "scdw"},
+		}, stream)
+
+		expectedErr := "following hostnames [scdw] do not have gp services configured. Please configure the services"
+		if err == nil || err.Error() != expectedErr {
+			t.Fatalf("got %v, want %s", err, expectedErr)
+		}
+	})
+
+	t.Run("RemoveStandby errors out when the cluster does not have a standby", func(t *testing.T) {
+		setupCatalog(t, coordinator, primary1, primary2)
+		defer utils.ResetSystemFunctions()
+		defer utils.ResetNewDBConnFromEnvironment()
+
+		hubServer.Conns = []*hub.Connection{}
+
+		_, stream := testutils.NewMockStream()
+		err := hubServer.RemoveStandby(&idl.RemoveStandbyRequest{}, stream)
+
+		expectedErr := "the cluster does not have a standby coordinator"
+		if err == nil || err.Error() != expectedErr {
+			t.Fatalf("got %v, want %s", err, expectedErr)
+		}
+	})
+}
+
+func TestDropStandbyReplicationSlot(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	t.Run("drops the replication slot of the standby", func(t *testing.T) {
+		conn, mock := testutils.CreateAndConnectMockDBWithContext(t, context.Background(), 1)
+
+		mock.ExpectExec(regexp.QuoteMeta("SELECT pg_catalog.pg_drop_replication_slot(slot_name) FROM pg_catalog.pg_replication_slots WHERE slot_name = 'internal_wal_replication_slot'")).WillReturnResult(sqlmock.NewResult(1, 1))
+
+		err := hub.DropStandbyReplicationSlot(conn)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("errors out when the query fails", func(t *testing.T) {
+		conn, mock := testutils.CreateAndConnectMockDBWithContext(t, context.Background(), 1)
+
+		expectedErr := errors.New("error")
+		mock.ExpectExec("SELECT").WillReturnError(expectedErr)
+
+		err := hub.DropStandbyReplicationSlot(conn)
+		if !errors.Is(err, expectedErr) {
+			t.Fatalf("got %v, want %v", err, expectedErr)
+		}
+
+		expectedErrStr := "dropping the replication slot internal_wal_replication_slot"
+		if !strings.Contains(err.Error(), expectedErrStr) {
+			t.Fatalf("got %v, want %s", err, expectedErrStr)
+		}
+	})
+}
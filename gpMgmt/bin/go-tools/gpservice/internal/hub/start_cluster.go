@@ -0,0 +1,205 @@
+package hub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"github.com/greenplum-db/gpdb/gpservice/constants"
+	"github.com/greenplum-db/gpdb/gpservice/idl"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/greenplum"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/postgres"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+)
+
+// StartCluster is the hub RPC which starts all the segments of an
+// existing cluster. The segment configuration is read from the catalog
+// by starting the coordinator in utility mode, after which the segments
+// are started through the agents followed by the coordinator and the standby.
+func (s *Server) StartCluster(req *idl.StartClusterRequest, stream idl.Hub_StartClusterServer) error {
+	hubStream := NewHubStream(stream)
+
+	err := s.DialAllAgents()
+	if err != nil {
+		return utils.LogAndReturnError(err)
+	}
+
+	hubStream.StreamLogMsg("Starting the Greenplum cluster")
+	err = s.StartClusterFromCatalog(stream.Context(), &hubStream, req.CoordinatorDataDir)
+	if err != nil {
+		return utils.LogAndReturnError(err)
+	}
+	hubStream.StreamLogMsg("Successfully started the Greenplum cluster")
+
+	return nil
+}
+
+// StartClusterFromCatalog starts the cluster whose coordinator data directory is given.
+// It follows the same order as gpstart: the coordinator is brought up in utility mode
+// to read gp_segment_configuration and then shut down, the primaries and mirrors are
+// started in parallel on every host and finally the coordinator and standby are started.
+func (s *Server) StartClusterFromCatalog(ctx context.Context, stream hubStreamer, coordinatorDataDir string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	pgCtlStatusCmd := &postgres.PgCtlStatus{
+		PgData: coordinatorDataDir,
+	}
+	_, err := utils.RunGpCommand(pgCtlStatusCmd, s.GpHome)
+	if err == nil {
+		return fmt.Errorf("coordinator segment with data directory %s is already running", coordinatorDataDir)
+	}
+
+	stream.StreamLogMsg("Starting the coordinator segment in utility mode to read the segment configuration")
+	err = s.StartCoordinator(ctx, stream, coordinatorDataDir, "-c gp_role=utility")
+	if err != nil {
+		return err
+	}
+
+	gparray, err := getGpArrayFromCoordinator(ctx, coordinatorDataDir)
+	if err != nil {
+		stopErr := s.StopCoordinator(stream, coordinatorDataDir)
+		if stopErr != nil {
+			gplog.Error(stopErr.Error())
+		}
+
+		return err
+	}
+
+	err = s.StopCoordinator(stream, coordinatorDataDir)
+	if err != nil {
+		return err
+	}
+
+	var segs []greenplum.Segment
+	for _, seg := range gparray.GetAllSegments() {
+		if seg.Status == constants.StatusDown {
+			stream.StreamLogMsg(fmt.Sprintf("Skipping segment with dbid %d on host %s as it is marked down", seg.Dbid, seg.Hostname), idl.LogLevel_WARNING)
+			continue
+		}
+
+		segs = append(segs, seg)
+	}
+
+	stream.StreamLogMsg("Starting the primary and mirror segments")
+	err = s.StartSegments(ctx, stream, segs, "-c gp_role=execute")
+	if err != nil {
+		return err
+	}
+	stream.StreamLogMsg("Successfully started the primary and mirror segments")
+
+	stream.StreamLogMsg("Starting the coordinator segment in production mode")
+	err = s.StartCoordinator(ctx, stream, coordinatorDataDir, "")
+	if err != nil {
+		return err
+	}
+
+	if gparray.Standby != nil {
+		stream.StreamLogMsg(fmt.Sprintf("Starting the standby coordinator on host %s", gparray.Standby.Hostname))
+		err = s.StartSegments(ctx, stream, []greenplum.Segment{*gparray.Standby}, "")
+		if err != nil {
+			return fmt.Errorf("starting standby coordinator: %w", err)
+		}
+		stream.StreamLogMsg("Successfully started the standby coordinator")
+	}
+
+	return nil
+}
+
+// StartCoordinator starts the coordinator segment on the hub host with the given pg_ctl options
+func (s *Server) StartCoordinator(ctx context.Context, stream hubStreamer, pgdata, options string) error {
+	pgCtlStartCmd := &postgres.PgCtlStart{
+		PgData:  pgdata,
+		Wait:    true,
+		Options: options,
+	}
+
+	out, err := utils.RunGpCommandContext(ctx, pgCtlStartCmd, s.GpHome)
+	if err != nil {
+		return fmt.Errorf("executing pg_ctl start: %s, logfile: %s, %w", out, pgCtlStartCmd.Logfile, err)
+	}
+	stream.StreamLogMsg("Successfully started coordinator segment")
+
+	return nil
+}
+
+// StartSegments starts the given segments through the agents. All the segments
+// on a host are started in parallel and the progress is streamed per host.
+func (s *Server) StartSegments(ctx context.Context, stream hubStreamer, segs []greenplum.Segment, options string) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	hostSegmentMap := make(map[string][]greenplum.Segment)
+	for _, seg := range segs {
+		hostSegmentMap[seg.Hostname] = append(hostSegmentMap[seg.Hostname], seg)
+	}
+
+	request := func(conn *Connection) error {
+		var wg sync.WaitGroup
+
+		segs := hostSegmentMap[conn.Hostname]
+		if len(segs) == 0 {
+			return nil
+		}
+
+		progressLabel := fmt.Sprintf("Starting segments on host %s:", conn.Hostname)
+		progressTotal := len(segs)
+		current := 0
+		stream.StreamProgressMsg(progressLabel, current, progressTotal)
+
+		errs := make(chan error, len(segs))
+		for _, seg := range segs {
+			seg := seg
+			wg.Add(1)
+
+			go func(seg greenplum.Segment) {
+				defer wg.Done()
+
+				gplog.Debug("Starting segment with data directory %s on host %s", seg.DataDir, seg.Hostname)
+				req := &idl.StartSegmentRequest{
+					DataDir: seg.DataDir,
+					Wait:    true,
+					Options: options,
+				}
+				_, err := conn.AgentClient.StartSegment(ctx, req)
+				if err != nil {
+					errs <- utils.FormatGrpcError(err)
+					return
+				}
+
+				s.mutex.Lock()
+				current++
+				defer s.mutex.Unlock()
+
+				stream.StreamProgressMsg(progressLabel, current, progressTotal)
+				gplog.Debug("Successfully started segment with data directory %s on host %s", seg.DataDir, seg.Hostname)
+			}(seg)
+		}
+
+		wg.Wait()
+		close(errs)
+
+		var err error
+		for e := range errs {
+			err = errors.Join(err, e)
+		}
+
+		return err
+	}
+
+	return ExecuteRPC(s.connections(), request)
+}
+
+func getGpArrayFromCoordinator(ctx context.Context, coordinatorDataDir string) (*greenplum.GpArray, error) {
+	conn, err := greenplum.GetCoordinatorConn(ctx, coordinatorDataDir, "", true)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.DB.Close()
+
+	return greenplum.NewGpArrayFromCatalog(conn.DB)
+}
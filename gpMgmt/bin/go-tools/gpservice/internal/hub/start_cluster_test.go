@@ -0,0 +1,197 @@
+package hub_test
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+	"github.com/greenplum-db/gpdb/gpservice/idl"
+	"github.com/greenplum-db/gpdb/gpservice/idl/mock_idl"
+	"github.com/greenplum-db/gpdb/gpservice/internal/hub"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/greenplum"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+	"github.com/greenplum-db/gpdb/gpservice/testutils"
+	"github.com/greenplum-db/gpdb/gpservice/testutils/exectest"
+)
+
+func TestStartSegments(t *testing.T) {
+	testhelper.SetupTestLogger()
+	hubServer := hub.New(testutils.CreateDummyServiceConfig(t))
+
+	segs := []greenplum.Segment{
+		{DataDir: "/gpseg0", Hostname: "sdw1"},
+		{DataDir: "/gpseg1", Hostname: "sdw2"},
+		{DataDir: "/gpseg2", Hostname: "sdw2"},
+	}
+
+	t.Run("successfully starts the segments on all the hosts", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().StartSegment(
+			gomock.Any(),
+			&idl.StartSegmentRequest{DataDir: "/gpseg0", Wait: true, Options: "-c gp_role=execute"},
+		).Return(&idl.StartSegmentReply{}, nil)
+
+		sdw2 := mock_idl.NewMockAgentClient(ctrl)
+		sdw2.EXPECT().StartSegment(
+			gomock.Any(),
+			gomock.Any(),
+		).Return(&idl.StartSegmentReply{}, nil).Times(2)
+
+		sdw3 := mock_idl.NewMockAgentClient(ctrl)
+
+		hubServer.Conns = []*hub.Connection{
+			{AgentClient: sdw1, Hostname: "sdw1"},
+			{AgentClient: sdw2, Hostname: "sdw2"},
+			{AgentClient: sdw3, Hostname: "sdw3"},
+		}
+
+		mock, stream := testutils.NewMockStream()
+		err := hubServer.StartSegments(context.Background(), mock, segs, "-c gp_role=execute")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		progress := make(map[string]int32)
+		for _, reply := range stream.GetBuffer() {
+			msg := reply.GetProgressMsg()
+			if msg == nil {
+				t.Fatalf("got %+v, want a progress message", reply)
+			}
+
+			if msg.Current > progress[msg.Label] {
+				progress[msg.Label] = msg.Current
+			}
+		}
+
+		expected := map[string]int32{
+			"Starting segments on host sdw1:": 1,
+			"Starting segments on host sdw2:": 2,
+		}
+		if !reflect.DeepEqual(progress, expected) {
+			t.Fatalf("got %+v, want %+v", progress, expected)
+		}
+	})
+
+	t.Run("errors out when not able to start a segment", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		expectedErr := errors.New("error")
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().StartSegment(
+			gomock.Any(),
+			gomock.Any(),
+		).Return(nil, expectedErr)
+
+		sdw2 := mock_idl.NewMockAgentClient(ctrl)
+		sdw2.EXPECT().StartSegment(
+			gomock.Any(),
+			gomock.Any(),
+		).Return(&idl.StartSegmentReply{}, nil).Times(2)
+
+		hubServer.Conns = []*hub.Connection{
+			{AgentClient: sdw1, Hostname: "sdw1"},
+			{AgentClient: sdw2, Hostname: "sdw2"},
+		}
+
+		mock, _ := testutils.NewMockStream()
+		err := hubServer.StartSegments(context.Background(), mock, segs, "")
+		if !errors.Is(err, expectedErr) {
+			t.Fatalf("got %#v, want %#v", err, expectedErr)
+		}
+
+		expectedErrString := "host: sdw1, error"
+		if err.Error() != expectedErrString {
+			t.Fatalf("got %v, want %s", err, expectedErrString)
+		}
+	})
+}
+
+func TestStartCoordinator(t *testing.T) {
+	testhelper.SetupTestLogger()
+	hubServer := hub.New(testutils.CreateDummyServiceConfig(t))
+
+	t.Run("successfully starts the coordinator segment", func(t *testing.T) {
+		var pgCtlCalled bool
+		utils.System.ExecCommandContext = exectest.NewCommandContextWithVerifier(exectest.Success, func(utility string, args ...string) {
+			pgCtlCalled = true
+
+			expectedUtility := "/gphome/bin/pg_ctl"
+			if utility != expectedUtility {
+				t.Fatalf("got %s, want %s", utility, expectedUtility)
+			}
+
+			expectedArgs := []string{"start", "--pgdata", "gpseg-1", "--timeout", "600", "--wait", "--log", "gpseg-1/log/startup.log", "--options", "-c gp_role=utility"}
+			if !reflect.DeepEqual(args, expectedArgs) {
+				t.Fatalf("got %+v, want %+v", args, expectedArgs)
+			}
+		})
+		defer utils.ResetSystemFunctions()
+
+		mock, _ := testutils.NewMockStream()
+		err := hubServer.StartCoordinator(context.Background(), mock, "gpseg-1", "-c gp_role=utility")
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		if !pgCtlCalled {
+			t.Fatalf("expected pg_ctl to be called")
+		}
+	})
+
+	t.Run("errors out when fails to start the coordinator segment", func(t *testing.T) {
+		utils.System.ExecCommandContext = exectest.NewCommandContext(exectest.Failure)
+		defer utils.ResetSystemFunctions()
+
+		mock, _ := testutils.NewMockStream()
+		err := hubServer.StartCoordinator(context.Background(), mock, "gpseg-1", "")
+
+		expectedErrPrefix := "executing pg_ctl start:"
+		if !strings.HasPrefix(err.Error(), expectedErrPrefix) {
+			t.Fatalf("got %v, want prefix %v", err, expectedErrPrefix)
+		}
+
+		var expectedErr *exec.ExitError
+		if !errors.As(err, &expectedErr) {
+			t.Errorf("got %T, want %T", err, expectedErr)
+		}
+	})
+}
+
+func TestStartClusterFromCatalog(t *testing.T) {
+	testhelper.SetupTestLogger()
+	hubServer := hub.New(testutils.CreateDummyServiceConfig(t))
+
+	t.Run("errors out when the coordinator is already running", func(t *testing.T) {
+		utils.System.ExecCommand = exectest.NewCommand(exectest.Success)
+		defer utils.ResetSystemFunctions()
+
+		mock, _ := testutils.NewMockStream()
+		err := hubServer.StartClusterFromCatalog(context.Background(), mock, "gpseg-1")
+
+		expectedErr := "coordinator segment with data directory gpseg-1 is already running"
+		if err == nil || err.Error() != expectedErr {
+			t.Fatalf("got %v, want %s", err, expectedErr)
+		}
+	})
+
+	t.Run("errors out when the context is cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		mock, _ := testutils.NewMockStream()
+		err := hubServer.StartClusterFromCatalog(ctx, mock, "gpseg-1")
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("got %v, want %v", err, context.Canceled)
+		}
+	})
+}
@@ -0,0 +1,189 @@
+package hub
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"github.com/greenplum-db/gpdb/gpservice/constants"
+	"github.com/greenplum-db/gpdb/gpservice/idl"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/greenplum"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/postgres"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+)
+
+// StopCluster is the hub RPC which stops all the segments of a running
+// cluster. The segment configuration is read from the catalog before the
+// coordinator is shut down, after which the standby, primaries and mirrors
+// are stopped through the agents.
+func (s *Server) StopCluster(req *idl.StopClusterRequest, stream idl.Hub_StopClusterServer) error {
+	hubStream := NewHubStream(stream)
+
+	err := s.DialAllAgents()
+	if err != nil {
+		return utils.LogAndReturnError(err)
+	}
+
+	hubStream.StreamLogMsg("Stopping the Greenplum cluster")
+	err = s.StopClusterFromCatalog(stream.Context(), &hubStream, req.CoordinatorDataDir, req.Mode, int(req.Timeout))
+	if err != nil {
+		return utils.LogAndReturnError(err)
+	}
+	hubStream.StreamLogMsg("Successfully stopped the Greenplum cluster")
+
+	return nil
+}
+
+// StopClusterFromCatalog stops the cluster whose coordinator data directory is given
+// using the given pg_ctl shutdown mode. The coordinator is stopped first so that no new
+// sessions are dispatched, followed by the standby and the segments which are stopped
+// in parallel on every host. Segments that fail to stop do not abort the shutdown of the
+// remaining segments; they are reported once all the segments have been processed.
+func (s *Server) StopClusterFromCatalog(ctx context.Context, stream hubStreamer, coordinatorDataDir, mode string, timeout int) error {
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	if mode == "" {
+		mode = constants.ShutdownModeSmart
+	}
+
+	if mode != constants.ShutdownModeSmart && mode != constants.ShutdownModeFast && mode != constants.ShutdownModeImmediate {
+		return fmt.Errorf("invalid shutdown mode %q, valid modes are %s, %s and %s", mode,
+			constants.ShutdownModeSmart, constants.ShutdownModeFast, constants.ShutdownModeImmediate)
+	}
+
+	if timeout <= 0 {
+		timeout = constants.DefaultStopTimeout
+	}
+
+	pgCtlStatusCmd := &postgres.PgCtlStatus{
+		PgData: coordinatorDataDir,
+	}
+	_, err := utils.RunGpCommand(pgCtlStatusCmd, s.GpHome)
+	if err != nil {
+		return fmt.Errorf("coordinator segment with data directory %s is not running", coordinatorDataDir)
+	}
+
+	gparray, err := getGpArrayFromCoordinator(ctx, coordinatorDataDir)
+	if err != nil {
+		return err
+	}
+
+	stream.StreamLogMsg(fmt.Sprintf("Stopping the coordinator segment in %s mode", mode))
+	pgCtlStopCmd := &postgres.PgCtlStop{
+		PgData:  coordinatorDataDir,
+		Wait:    true,
+		Timeout: timeout,
+		Mode:    mode,
+	}
+	out, err := utils.RunGpCommandContext(ctx, pgCtlStopCmd, s.GpHome)
+	if err != nil {
+		return fmt.Errorf("executing pg_ctl stop: %s, %w", out, err)
+	}
+	stream.StreamLogMsg("Successfully shut down coordinator segment")
+
+	var segs []greenplum.Segment
+	if gparray.Standby != nil {
+		segs = append(segs, *gparray.Standby)
+	}
+
+	for _, seg := range gparray.GetAllSegments() {
+		if seg.Status == constants.StatusDown {
+			stream.StreamLogMsg(fmt.Sprintf("Skipping segment with dbid %d on host %s as it is marked down", seg.Dbid, seg.Hostname), idl.LogLevel_WARNING)
+			continue
+		}
+
+		segs = append(segs, seg)
+	}
+
+	stream.StreamLogMsg("Stopping the standby, primary and mirror segments")
+	failedSegs, err := s.StopSegments(ctx, stream, segs, mode, timeout)
+	if err != nil {
+		for _, seg := range failedSegs {
+			stream.StreamLogMsg(fmt.Sprintf("Failed to stop segment with dbid %d and data directory %s on host %s", seg.Dbid, seg.DataDir, seg.Hostname), idl.LogLevel_WARNING)
+		}
+
+		return fmt.Errorf("failed to stop %d out of %d segments: %w", len(failedSegs), len(segs), err)
+	}
+	stream.StreamLogMsg("Successfully stopped the standby, primary and mirror segments")
+
+	return nil
+}
+
+// StopSegments stops the given segments through the agents using the given
+// shutdown mode and timeout. All the segments on a host are stopped in parallel
+// and the progress is streamed per host. Unlike StartSegments, a failure on one
+// segment does not stop the others from being processed; the segments which
+// could not be stopped are returned along with the combined error.
+func (s *Server) StopSegments(ctx context.Context, stream hubStreamer, segs []greenplum.Segment, mode string, timeout int) ([]greenplum.Segment, error) {
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	hostSegmentMap := make(map[string][]greenplum.Segment)
+	for _, seg := range segs {
+		hostSegmentMap[seg.Hostname] = append(hostSegmentMap[seg.Hostname], seg)
+	}
+
+	var failedSegs []greenplum.Segment
+	var segErrs error
+
+	request := func(conn *Connection) error {
+		var wg sync.WaitGroup
+
+		segs := hostSegmentMap[conn.Hostname]
+		if len(segs) == 0 {
+			return nil
+		}
+
+		progressLabel := fmt.Sprintf("Stopping segments on host %s:", conn.Hostname)
+		progressTotal := len(segs)
+		current := 0
+		stream.StreamProgressMsg(progressLabel, current, progressTotal)
+
+		for _, seg := range segs {
+			seg := seg
+			wg.Add(1)
+
+			go func(seg greenplum.Segment) {
+				defer wg.Done()
+
+				gplog.Debug("Stopping segment with data directory %s on host %s", seg.DataDir, seg.Hostname)
+				req := &idl.StopSegmentRequest{
+					DataDir: seg.DataDir,
+					Wait:    true,
+					Timeout: int32(timeout),
+					Mode:    mode,
+				}
+				_, err := conn.AgentClient.StopSegment(ctx, req)
+
+				s.mutex.Lock()
+				defer s.mutex.Unlock()
+
+				if err != nil {
+					failedSegs = append(failedSegs, seg)
+					segErrs = errors.Join(segErrs, fmt.Errorf("host: %s, data directory: %s, %w", seg.Hostname, seg.DataDir, utils.FormatGrpcError(err)))
+					return
+				}
+
+				current++
+				stream.StreamProgressMsg(progressLabel, current, progressTotal)
+				gplog.Debug("Successfully stopped segment with data directory %s on host %s", seg.DataDir, seg.Hostname)
+			}(seg)
+		}
+
+		wg.Wait()
+
+		return nil
+	}
+
+	err := ExecuteRPC(s.connections(), request)
+	if err != nil {
+		return nil, err
+	}
+
+	return failedSegs, segErrs
+}
@@ -0,0 +1,171 @@
+package hub_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+	"github.com/greenplum-db/gpdb/gpservice/idl"
+	"github.com/greenplum-db/gpdb/gpservice/idl/mock_idl"
+	"github.com/greenplum-db/gpdb/gpservice/internal/hub"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/greenplum"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+	"github.com/greenplum-db/gpdb/gpservice/testutils"
+	"github.com/greenplum-db/gpdb/gpservice/testutils/exectest"
+)
+
+func TestStopSegments(t *testing.T) {
+	testhelper.SetupTestLogger()
+	hubServer := hub.New(testutils.CreateDummyServiceConfig(t))
+
+	segs := []greenplum.Segment{
+		{Dbid: 2, DataDir: "/gpseg0", Hostname: "sdw1"},
+		{Dbid: 3, DataDir: "/gpseg1", Hostname: "sdw2"},
+		{Dbid: 4, DataDir: "/gpseg2", Hostname: "sdw2"},
+	}
+
+	t.Run("successfully stops the segments on all the hosts", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().StopSegment(
+			gomock.Any(),
+			&idl.StopSegmentRequest{DataDir: "/gpseg0", Wait: true, Timeout: 60, Mode: "fast"},
+		).Return(&idl.StopSegmentReply{}, nil)
+
+		sdw2 := mock_idl.NewMockAgentClient(ctrl)
+		sdw2.EXPECT().StopSegment(
+			gomock.Any(),
+			gomock.Any(),
+		).Return(&idl.StopSegmentReply{}, nil).Times(2)
+
+		hubServer.Conns = []*hub.Connection{
+			{AgentClient: sdw1, Hostname: "sdw1"},
+			{AgentClient: sdw2, Hostname: "sdw2"},
+		}
+
+		mock, stream := testutils.NewMockStream()
+		failedSegs, err := hubServer.StopSegments(context.Background(), mock, segs, "fast", 60)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(failedSegs) != 0 {
+			t.Fatalf("got %+v, want no failed segments", failedSegs)
+		}
+
+		progress := make(map[string]int32)
+		for _, reply := range stream.GetBuffer() {
+			msg := reply.GetProgressMsg()
+			if msg == nil {
+				t.Fatalf("got %+v, want a progress message", reply)
+			}
+
+			if msg.Current > progress[msg.Label] {
+				progress[msg.Label] = msg.Current
+			}
+		}
+
+		expected := map[string]int32{
+			"Stopping segments on host sdw1:": 1,
+			"Stopping segments on host sdw2:": 2,
+		}
+		if !reflect.DeepEqual(progress, expected) {
+			t.Fatalf("got %+v, want %+v", progress, expected)
+		}
+	})
+
+	t.Run("stops the remaining segments and reports the ones which failed", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		expectedErr := errors.New("error")
+		sdw1 := mock_idl.NewMockAgentClient(ctrl)
+		sdw1.EXPECT().StopSegment(
+			gomock.Any(),
+			gomock.Any(),
+		).Return(nil, expectedErr)
+
+		sdw2 := mock_idl.NewMockAgentClient(ctrl)
+		sdw2.EXPECT().StopSegment(
+			gomock.Any(),
+			&idl.StopSegmentRequest{DataDir: "/gpseg1", Wait: true, Timeout: 60, Mode: "smart"},
+		).Return(&idl.StopSegmentReply{}, nil)
+		sdw2.EXPECT().StopSegment(
+			gomock.Any(),
+			&idl.StopSegmentRequest{DataDir: "/gpseg2", Wait: true, Timeout: 60, Mode: "smart"},
+		).Return(nil, expectedErr)
+
+		hubServer.Conns = []*hub.Connection{
+			{AgentClient: sdw1, Hostname: "sdw1"},
+			{AgentClient: sdw2, Hostname: "sdw2"},
+		}
+
+		mock, _ := testutils.NewMockStream()
+		failedSegs, err := hubServer.StopSegments(context.Background(), mock, segs, "smart", 60)
+		if !errors.Is(err, expectedErr) {
+			t.Fatalf("got %#v, want %#v", err, expectedErr)
+		}
+
+		for _, expected := range []string{"host: sdw1, data directory: /gpseg0, error", "host: sdw2, data directory: /gpseg2, error"} {
+			if !strings.Contains(err.Error(), expected) {
+				t.Fatalf("got %v, want %s", err, expected)
+			}
+		}
+
+		if len(failedSegs) != 2 {
+			t.Fatalf("got %d failed segments, want 2", len(failedSegs))
+		}
+
+		for _, seg := range failedSegs {
+			if seg.Dbid != 2 && seg.Dbid != 4 {
+				t.Fatalf("got unexpected failed segment %+v", seg)
+			}
+		}
+	})
+}
+
+func TestStopClusterFromCatalog(t *testing.T) {
+	testhelper.SetupTestLogger()
+	hubServer := hub.New(testutils.CreateDummyServiceConfig(t))
+
+	t.Run("errors out when the shutdown mode is invalid", func(t *testing.T) {
+		mock, _ := testutils.NewMockStream()
+		err := hubServer.StopClusterFromCatalog(context.Background(), mock, "gpseg-1", "abrupt", 0)
+
+		expectedErr := `invalid shutdown mode "abrupt", valid modes are smart, fast and immediate`
+		if err == nil || err.Error() != expectedErr {
+			t.Fatalf("got %v, want %s", err, expectedErr)
+		}
+	})
+
+	t.Run("errors out when the coordinator is not running", func(t *testing.T) {
+		utils.System.ExecCommand = exectest.NewCommand(exectest.Failure)
+		defer utils.ResetSystemFunctions()
+
+		mock, _ := testutils.NewMockStream()
+		err := hubServer.StopClusterFromCatalog(context.Background(), mock, "gpseg-1", "fast", 0)
+
+		expectedErr := "coordinator segment with data directory gpseg-1 is not running"
+		if err == nil || err.Error() != expectedErr {
+			t.Fatalf("got %v, want %s", err, expectedErr)
+		}
+	})
+
+	t.Run("errors out when the context is cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		mock, _ := testutils.NewMockStream()
+		err := hubServer.StopClusterFromCatalog(ctx, mock, "gpseg-1", "", 0)
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("got %v, want %v", err, context.Canceled)
+		}
+	})
+}
@@ -0,0 +1,129 @@
+package metrics
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/greenplum-db/gpdb/gpservice/constants"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/greenplum"
+)
+
+// ScrapeTimeout bounds the time spent checking the agents and reading the segment configuration
+// on each scrape, so that an unreachable host does not hold up the other metrics
+var ScrapeTimeout = 5 * time.Second
+
+// agentCollector reports whether the agents respond to health checks, which are made when scraped
+type agentCollector struct {
+	check func(ctx context.Context) map[string]bool
+	up    *prometheus.Desc
+}
+
+// NewAgentCollector returns the collector of the health of the agents, given a function which
+// reports whether the agent on each host is serving
+func NewAgentCollector(check func(ctx context.Context) map[string]bool) prometheus.Collector {
+	return &agentCollector{
+		check: check,
+		up:    prometheus.NewDesc(namespace+"_agent_up", "Whether the agent on the host responds to health checks.", []string{"host"}, nil),
+	}
+}
+
+func (c *agentCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.up
+}
+
+func (c *agentCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), ScrapeTimeout)
+	defer cancel()
+
+	for host, serving := range c.check(ctx) {
+		ch <- prometheus.MustNewConstMetric(c.up, prometheus.GaugeValue, boolValue(serving), host)
+	}
+}
+
+/*
+segmentCollector reports the state of each segment as recorded in gp_segment_configuration,
+which is read from the coordinator when scraped. A segment is up when the coordinator can
+reach it, and it is synchronized when it is in sync with its mirror or primary. The latter
+is only reported for the segments which are mirrored, so that unmirrored clusters do not
+report unsynchronized segments.
+*/
+type segmentCollector struct {
+	load func(ctx context.Context) (*greenplum.GpArray, error)
+
+	configUp     *prometheus.Desc
+	up           *prometheus.Desc
+	synchronized *prometheus.Desc
+	preferred    *prometheus.Desc
+}
+
+// NewSegmentCollector returns the collector of the state of the segments, given a function which
+// reads the segment configuration of the cluster
+func NewSegmentCollector(load func(ctx context.Context) (*greenplum.GpArray, error)) prometheus.Collector {
+	labels := []string{"dbid", "content", "role", "preferred_role", "host", "port"}
+
+	return &segmentCollector{
+		load:         load,
+		configUp:     prometheus.NewDesc(namespace+"_segment_configuration_up", "Whether the segment configuration could be read from the coordinator.", nil, nil),
+		up:           prometheus.NewDesc(namespace+"_segment_up", "Whether the segment is marked up in gp_segment_configuration.", labels, nil),
+		synchronized: prometheus.NewDesc(namespace+"_segment_synchronized", "Whether the mirrored segment is marked in sync in gp_segment_configuration.", labels, nil),
+		preferred:    prometheus.NewDesc(namespace+"_segment_in_preferred_role", "Whether the segment is acting in its preferred role.", labels, nil),
+	}
+}
+
+func (c *segmentCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.configUp
+	ch <- c.up
+	ch <- c.synchronized
+	ch <- c.preferred
+}
+
+func (c *segmentCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), ScrapeTimeout)
+	defer cancel()
+
+	gparray, err := c.load(ctx)
+	if err != nil {
+		gplog.Debug("could not read the segment configuration for the metrics: %v", err)
+		ch <- prometheus.MustNewConstMetric(c.configUp, prometheus.GaugeValue, 0)
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(c.configUp, prometheus.GaugeValue, 1)
+
+	if gparray.Coordinator != nil {
+		c.collectSegment(ch, gparray.Coordinator, gparray.Standby != nil)
+	}
+	if gparray.Standby != nil {
+		c.collectSegment(ch, gparray.Standby, true)
+	}
+
+	for _, pair := range gparray.SegmentPairs {
+		if pair.Primary != nil {
+			c.collectSegment(ch, pair.Primary, pair.Mirror != nil)
+		}
+		if pair.Mirror != nil {
+			c.collectSegment(ch, pair.Mirror, true)
+		}
+	}
+}
+
+func (c *segmentCollector) collectSegment(ch chan<- prometheus.Metric, seg *greenplum.Segment, mirrored bool) {
+	labels := []string{strconv.Itoa(seg.Dbid), strconv.Itoa(seg.Content), seg.Role, seg.PreferredRole, seg.Hostname, strconv.Itoa(seg.Port)}
+
+	ch <- prometheus.MustNewConstMetric(c.up, prometheus.GaugeValue, boolValue(seg.Status == constants.StatusUp), labels...)
+	ch <- prometheus.MustNewConstMetric(c.preferred, prometheus.GaugeValue, boolValue(seg.Role == seg.PreferredRole), labels...)
+	if mirrored {
+		ch <- prometheus.MustNewConstMetric(c.synchronized, prometheus.GaugeValue, boolValue(seg.Mode == constants.ModeSynchronized), labels...)
+	}
+}
+
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,105 @@
+package metrics_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/greenplum-db/gpdb/gpservice/internal/metrics"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/greenplum"
+)
+
+func TestAgentCollector(t *testing.T) {
+	collector := metrics.NewAgentCollector(func(ctx context.Context) map[string]bool {
+		if _, ok := ctx.Deadline(); !ok {
+			t.Fatalf("expected the agents to be checked with a timeout")
+		}
+
+		return map[string]bool{"sdw1": true, "sdw2": false}
+	})
+
+	expected := `
+# HELP gpservice_agent_up Whether the agent on the host responds to health checks.
+# TYPE gpservice_agent_up gauge
+gpservice_agent_up{host="sdw1"} 1
+gpservice_agent_up{host="sdw2"} 0
+`
+	err := testutil.CollectAndCompare(collector, strings.NewReader(expected))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestSegmentCollector(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	t.Run("reports the state of the segments", func(t *testing.T) {
+		gparray := &greenplum.GpArray{
+			Coordinator: &greenplum.Segment{Dbid: 1, Content: -1, Role: "p", PreferredRole: "p", Mode: "n", Status: "u", Port: 7000, Hostname: "cdw"},
+			SegmentPairs: []greenplum.SegmentPair{
+				{
+					Primary: &greenplum.Segment{Dbid: 2, Content: 0, Role: "p", PreferredRole: "p", Mode: "s", Status: "u", Port: 7002, Hostname: "sdw1"},
+					Mirror:  &greenplum.Segment{Dbid: 4, Content: 0, Role: "m", PreferredRole: "m", Mode: "s", Status: "u", Port: 7003, Hostname: "sdw2"},
+				},
+				{
+					Primary: &greenplum.Segment{Dbid: 5, Content: 1, Role: "p", PreferredRole: "m", Mode: "n", Status: "u", Port: 7003, Hostname: "sdw1"},
+					Mirror:  &greenplum.Segment{Dbid: 3, Content: 1, Role: "m", PreferredRole: "p", Mode: "n", Status: "d", Port: 7002, Hostname: "sdw2"},
+				},
+			},
+		}
+
+		collector := metrics.NewSegmentCollector(func(ctx context.Context) (*greenplum.GpArray, error) {
+			return gparray, nil
+		})
+
+		expected := `
+# HELP gpservice_segment_configuration_up Whether the segment configuration could be read from the coordinator.
+# TYPE gpservice_segment_configuration_up gauge
+gpservice_segment_configuration_up 1
+# HELP gpservice_segment_in_preferred_role Whether the segment is acting in its preferred role.
+# TYPE gpservice_segment_in_preferred_role gauge
+gpservice_segment_in_preferred_role{content="-1",dbid="1",host="cdw",port="7000",preferred_role="p",role="p"} 1
+gpservice_segment_in_preferred_role{content="0",dbid="2",host="sdw1",port="7002",preferred_role="p",role="p"} 1
+gpservice_segment_in_preferred_role{content="0",dbid="4",host="sdw2",port="7003",preferred_role="m",role="m"} 1
+gpservice_segment_in_preferred_role{content="1",dbid="3",host="sdw2",port="7002",preferred_role="p",role="m"} 0
+gpservice_segment_in_preferred_role{content="1",dbid="5",host="sdw1",port="7003",preferred_role="m",role="p"} 0
+# HELP gpservice_segment_synchronized Whether the mirrored segment is marked in sync in gp_segment_configuration.
+# TYPE gpservice_segment_synchronized gauge
+gpservice_segment_synchronized{content="0",dbid="2",host="sdw1",port="7002",preferred_role="p",role="p"} 1
+gpservice_segment_synchronized{content="0",dbid="4",host="sdw2",port="7003",preferred_role="m",role="m"} 1
+gpservice_segment_synchronized{content="1",dbid="3",host="sdw2",port="7002",preferred_role="p",role="m"} 0
+gpservice_segment_synchronized{content="1",dbid="5",host="sdw1",port="7003",preferred_role="m",role="p"} 0
+# HELP gpservice_segment_up Whether the segment is marked up in gp_segment_configuration.
+# TYPE gpservice_segment_up gauge
+gpservice_segment_up{content="-1",dbid="1",host="cdw",port="7000",preferred_role="p",role="p"} 1
+gpservice_segment_up{content="0",dbid="2",host="sdw1",port="7002",preferred_role="p",role="p"} 1
+gpservice_segment_up{content="0",dbid="4",host="sdw2",port="7003",preferred_role="m",role="m"} 1
+gpservice_segment_up{content="1",dbid="3",host="sdw2",port="7002",preferred_role="p",role="m"} 0
+gpservice_segment_up{content="1",dbid="5",host="sdw1",port="7003",preferred_role="m",role="p"} 1
+`
+		err := testutil.CollectAndCompare(collector, strings.NewReader(expected))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("reports when the segment configuration cannot be read", func(t *testing.T) {
+		collector := metrics.NewSegmentCollector(func(ctx context.Context) (*greenplum.GpArray, error) {
+			return nil, errors.New("connection refused")
+		})
+
+		expected := `
+# HELP gpservice_segment_configuration_up Whether the segment configuration could be read from the coordinator.
+# TYPE gpservice_segment_configuration_up gauge
+gpservice_segment_configuration_up 0
+`
+		err := testutil.CollectAndCompare(collector, strings.NewReader(expected))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
@@ -0,0 +1,106 @@
+package metrics
+
+import (
+	"bufio"
+	"bytes"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+)
+
+// hostCollector reports the load, the memory and the space left on the filesystem of the logs of
+// the host, read when scraped. The gauges which cannot be read on the platform are left out.
+type hostCollector struct {
+	dir string
+
+	load          *prometheus.Desc
+	memTotal      *prometheus.Desc
+	memAvailable  *prometheus.Desc
+	diskSize      *prometheus.Desc
+	diskAvailable *prometheus.Desc
+}
+
+// NewHostCollector returns the collector of the gauges of the host, reporting the filesystem holding dir
+func NewHostCollector(dir string) prometheus.Collector {
+	return &hostCollector{
+		dir:           dir,
+		load:          prometheus.NewDesc(namespace+"_host_load_average", "Load average of the host over the period.", []string{"period"}, nil),
+		memTotal:      prometheus.NewDesc(namespace+"_host_memory_total_bytes", "Total memory of the host.", nil, nil),
+		memAvailable:  prometheus.NewDesc(namespace+"_host_memory_available_bytes", "Memory of the host available for starting new processes.", nil, nil),
+		diskSize:      prometheus.NewDesc(namespace+"_host_log_filesystem_size_bytes", "Size of the filesystem holding the log directory.", nil, nil),
+		diskAvailable: prometheus.NewDesc(namespace+"_host_log_filesystem_available_bytes", "Space available to the services on the filesystem holding the log directory.", nil, nil),
+	}
+}
+
+func (c *hostCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.load
+	ch <- c.memTotal
+	ch <- c.memAvailable
+	ch <- c.diskSize
+	ch <- c.diskAvailable
+}
+
+func (c *hostCollector) Collect(ch chan<- prometheus.Metric) {
+	if contents, err := utils.System.ReadFile("/proc/loadavg"); err == nil {
+		fields := strings.Fields(string(contents))
+		for i, period := range []string{"1m", "5m", "15m"} {
+			if i >= len(fields) {
+				break
+			}
+
+			if value, err := strconv.ParseFloat(fields[i], 64); err == nil {
+				ch <- prometheus.MustNewConstMetric(c.load, prometheus.GaugeValue, value, period)
+			}
+		}
+	}
+
+	if contents, err := utils.System.ReadFile("/proc/meminfo"); err == nil {
+		meminfo := parseMeminfo(contents)
+		if value, ok := meminfo["MemTotal"]; ok {
+			ch <- prometheus.MustNewConstMetric(c.memTotal, prometheus.GaugeValue, value)
+		}
+		if value, ok := meminfo["MemAvailable"]; ok {
+			ch <- prometheus.MustNewConstMetric(c.memAvailable, prometheus.GaugeValue, value)
+		}
+	}
+
+	var stat syscall.Statfs_t
+	if c.dir != "" && syscall.Statfs(c.dir, &stat) == nil {
+		ch <- prometheus.MustNewConstMetric(c.diskSize, prometheus.GaugeValue, float64(stat.Blocks)*float64(stat.Bsize))
+		ch <- prometheus.MustNewConstMetric(c.diskAvailable, prometheus.GaugeValue, float64(stat.Bavail)*float64(stat.Bsize))
+	}
+}
+
+// parseMeminfo returns the values of /proc/meminfo in bytes, which are given in kB
+func parseMeminfo(contents []byte) map[string]float64 {
+	meminfo := make(map[string]float64)
+
+	scanner := bufio.NewScanner(bytes.NewReader(contents))
+	for scanner.Scan() {
+		name, value, found := strings.Cut(scanner.Text(), ":")
+		if !found {
+			continue
+		}
+
+		fields := strings.Fields(value)
+		if len(fields) == 0 {
+			continue
+		}
+
+		number, err := strconv.ParseFloat(fields[0], 64)
+		if err != nil {
+			continue
+		}
+
+		if len(fields) > 1 && fields[1] == "kB" {
+			number *= 1024
+		}
+		meminfo[name] = number
+	}
+
+	return meminfo
+}
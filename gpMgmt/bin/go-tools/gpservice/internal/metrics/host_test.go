@@ -0,0 +1,75 @@
+package metrics_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/greenplum-db/gpdb/gpservice/internal/metrics"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+)
+
+func TestHostCollector(t *testing.T) {
+	t.Run("reports the load and the memory of the host", func(t *testing.T) {
+		utils.System.ReadFile = func(name string) ([]byte, error) {
+			switch name {
+			case "/proc/loadavg":
+				return []byte("0.50 1.25 2.00 1/123 4567\n"), nil
+			case "/proc/meminfo":
+				return []byte("MemTotal:        2048 kB\nMemFree:         512 kB\nMemAvailable:    1024 kB\nHugePages_Total:       0\n"), nil
+			}
+			return nil, os.ErrNotExist
+		}
+		defer utils.ResetSystemFunctions()
+
+		expected := `
+# HELP gpservice_host_load_average Load average of the host over the period.
+# TYPE gpservice_host_load_average gauge
+gpservice_host_load_average{period="15m"} 2
+gpservice_host_load_average{period="1m"} 0.5
+gpservice_host_load_average{period="5m"} 1.25
+# HELP gpservice_host_memory_available_bytes Memory of the host available for starting new processes.
+# TYPE gpservice_host_memory_available_bytes gauge
+gpservice_host_memory_available_bytes 1.048576e+06
+# HELP gpservice_host_memory_total_bytes Total memory of the host.
+# TYPE gpservice_host_memory_total_bytes gauge
+gpservice_host_memory_total_bytes 2.097152e+06
+`
+		err := testutil.CollectAndCompare(metrics.NewHostCollector(""), strings.NewReader(expected))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("reports the size of the filesystem of the log directory", func(t *testing.T) {
+		utils.System.ReadFile = func(name string) ([]byte, error) {
+			return nil, os.ErrNotExist
+		}
+		defer utils.ResetSystemFunctions()
+
+		registry := prometheus.NewRegistry()
+		registry.MustRegister(metrics.NewHostCollector(t.TempDir()))
+
+		families, err := registry.Gather()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		values := make(map[string]float64)
+		for _, family := range families {
+			values[family.GetName()] = family.GetMetric()[0].GetGauge().GetValue()
+		}
+
+		if len(values) != 2 {
+			t.Fatalf("got %v, want only the filesystem gauges", values)
+		}
+
+		size, available := values["gpservice_host_log_filesystem_size_bytes"], values["gpservice_host_log_filesystem_available_bytes"]
+		if size <= 0 || available > size {
+			t.Fatalf("got size %f and available %f, want a positive size larger than the space available", size, available)
+		}
+	})
+}
@@ -0,0 +1,165 @@
+package metrics
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+)
+
+const namespace = "gpservice"
+
+/*
+Metrics holds the metrics of the hub or of an agent, which are exposed over HTTP
+in the Prometheus format when a metrics port is configured. Every service reports
+the calls made to its gRPC methods along with the state of the host it runs on,
+and further collectors can be registered for the metrics specific to a service.
+*/
+type Metrics struct {
+	Registry *prometheus.Registry
+
+	requests  *prometheus.CounterVec
+	latency   *prometheus.HistogramVec
+	streams   *prometheus.GaugeVec
+	initSteps *prometheus.HistogramVec
+}
+
+// New returns the metrics of the given service, reporting the state of the filesystem of logDir
+func New(service, logDir string) *Metrics {
+	labels := prometheus.Labels{"service": service}
+
+	m := &Metrics{
+		Registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   namespace,
+			Name:        "grpc_requests_total",
+			Help:        "Number of gRPC calls completed, by method and status code.",
+			ConstLabels: labels,
+		}, []string{"method", "code"}),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace:   namespace,
+			Name:        "grpc_request_duration_seconds",
+			Help:        "Duration of the gRPC calls, by method.",
+			ConstLabels: labels,
+			Buckets:     []float64{0.005, 0.025, 0.1, 0.5, 1, 5, 30, 120, 600, 1800},
+		}, []string{"method"}),
+		streams: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace:   namespace,
+			Name:        "grpc_active_streams",
+			Help:        "Number of streaming gRPC calls in progress, by method.",
+			ConstLabels: labels,
+		}, []string{"method"}),
+		initSteps: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace:   namespace,
+			Name:        "init_step_duration_seconds",
+			Help:        "Duration of the steps of creating the cluster, such as creating the primary segments.",
+			ConstLabels: labels,
+			Buckets:     []float64{1, 5, 15, 30, 60, 120, 300, 600, 1800, 3600},
+		}, []string{"step"}),
+	}
+
+	m.Registry.MustRegister(
+		m.requests,
+		m.latency,
+		m.streams,
+		m.initSteps,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{Namespace: namespace}),
+		NewHostCollector(logDir),
+	)
+
+	return m
+}
+
+// ObserveInitStep records the time taken by a step of creating the cluster which started at the given time
+func (m *Metrics) ObserveInitStep(step string, start time.Time) {
+	m.initSteps.WithLabelValues(step).Observe(time.Since(start).Seconds())
+}
+
+// UnaryInterceptor counts the calls of unary methods and records how long they take
+func (m *Metrics) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		m.observe(info.FullMethod, start, err)
+
+		return resp, err
+	}
+}
+
+// StreamInterceptor counts the calls of streaming methods, records how long they take and
+// keeps track of the ones in progress
+func (m *Metrics) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		active := m.streams.WithLabelValues(info.FullMethod)
+		active.Inc()
+		defer active.Dec()
+
+		start := time.Now()
+		err := handler(srv, stream)
+		m.observe(info.FullMethod, start, err)
+
+		return err
+	}
+}
+
+func (m *Metrics) observe(method string, start time.Time, err error) {
+	m.requests.WithLabelValues(method, status.Code(err).String()).Inc()
+	m.latency.WithLabelValues(method).Observe(time.Since(start).Seconds())
+}
+
+/*
+Serve starts serving the metrics on the /metrics path of the given address and port in the
+background, returning the server so that it can be closed once the service stops. The metrics
+are served over HTTPS when a TLS configuration is given, and over plain HTTP otherwise.
+*/
+func (m *Metrics) Serve(address string, port int, tlsConfig *tls.Config) (*http.Server, error) {
+	listener, err := net.Listen("tcp", net.JoinHostPort(address, strconv.Itoa(port)))
+	if err != nil {
+		return nil, fmt.Errorf("could not listen on metrics port %d: %w", port, err)
+	}
+
+	scheme := "http"
+	if tlsConfig != nil {
+		listener = tls.NewListener(listener, tlsConfig)
+		scheme = "https"
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(m.Registry, promhttp.HandlerOpts{Registry: m.Registry}))
+
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		err := server.Serve(listener)
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			gplog.Error("Metrics server on port %d stopped: %v", port, err)
+		}
+	}()
+
+	gplog.Info("Serving metrics on %s://%s/metrics", scheme, listener.Addr())
+	return server, nil
+}
+
+// TLSConfig returns the configuration to serve the metrics over HTTPS with, which uses the
+// certificates of the services and so requires TLS to be enabled for them
+func TLSConfig(creds utils.Credentials) (*tls.Config, error) {
+	gpCreds, ok := creds.(*utils.GpCredentials)
+	if !ok || !gpCreds.TlsEnabled {
+		return nil, errors.New("serving the metrics over TLS requires TLS to be enabled for the services")
+	}
+
+	return gpCreds.ServerTLSConfig()
+}
@@ -0,0 +1,272 @@
+package metrics_test
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/greenplum-db/gpdb/gpservice/internal/metrics"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+)
+
+func TestInterceptors(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	t.Run("counts the unary calls by method and status code", func(t *testing.T) {
+		m := metrics.New("hub", "")
+		interceptor := m.UnaryInterceptor()
+
+		info := &grpc.UnaryServerInfo{FullMethod: "/idl.Hub/StatusAgents"}
+		for _, err := range []error{nil, nil, status.Error(codes.Unavailable, "agent down")} {
+			_, _ = interceptor(context.Background(), nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+				return nil, err
+			})
+		}
+
+		expected := `
+# HELP gpservice_grpc_requests_total Number of gRPC calls completed, by method and status code.
+# TYPE gpservice_grpc_requests_total counter
+gpservice_grpc_requests_total{code="OK",method="/idl.Hub/StatusAgents",service="hub"} 2
+gpservice_grpc_requests_total{code="Unavailable",method="/idl.Hub/StatusAgents",service="hub"} 1
+`
+		err := testutil.GatherAndCompare(m.Registry, strings.NewReader(expected), "gpservice_grpc_requests_total")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		count, err := testutil.GatherAndCount(m.Registry, "gpservice_grpc_request_duration_seconds")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if count != 1 {
+			t.Fatalf("got %d latency series, want 1", count)
+		}
+	})
+
+	t.Run("keeps track of the streams in progress", func(t *testing.T) {
+		m := metrics.New("hub", "")
+		interceptor := m.StreamInterceptor()
+
+		info := &grpc.StreamServerInfo{FullMethod: "/idl.Hub/MakeCluster"}
+		err := interceptor(nil, nil, info, func(srv interface{}, stream grpc.ServerStream) error {
+			expected := `
+# HELP gpservice_grpc_active_streams Number of streaming gRPC calls in progress, by method.
+# TYPE gpservice_grpc_active_streams gauge
+gpservice_grpc_active_streams{method="/idl.Hub/MakeCluster",service="hub"} 1
+`
+			return testutil.GatherAndCompare(m.Registry, strings.NewReader(expected), "gpservice_grpc_active_streams")
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := `
+# HELP gpservice_grpc_active_streams Number of streaming gRPC calls in progress, by method.
+# TYPE gpservice_grpc_active_streams gauge
+gpservice_grpc_active_streams{method="/idl.Hub/MakeCluster",service="hub"} 0
+# HELP gpservice_grpc_requests_total Number of gRPC calls completed, by method and status code.
+# TYPE gpservice_grpc_requests_total counter
+gpservice_grpc_requests_total{code="OK",method="/idl.Hub/MakeCluster",service="hub"} 1
+`
+		err = testutil.GatherAndCompare(m.Registry, strings.NewReader(expected), "gpservice_grpc_active_streams", "gpservice_grpc_requests_total")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestObserveInitStep(t *testing.T) {
+	m := metrics.New("hub", "")
+	m.ObserveInitStep("primaries-created", time.Now().Add(-2*time.Second))
+
+	families, err := m.Registry.Gather()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, family := range families {
+		if family.GetName() != "gpservice_init_step_duration_seconds" {
+			continue
+		}
+
+		labels := family.GetMetric()[0].GetLabel()
+		step := labels[len(labels)-1]
+		if step.GetName() != "step" || step.GetValue() != "primaries-created" {
+			t.Fatalf("got label %s=%s, want step=primaries-created", step.GetName(), step.GetValue())
+		}
+
+		histogram := family.GetMetric()[0].GetHistogram()
+		if histogram.GetSampleCount() != 1 || histogram.GetSampleSum() < 2 {
+			t.Fatalf("got count %d and sum %f, want a single sample of at least 2 seconds", histogram.GetSampleCount(), histogram.GetSampleSum())
+		}
+
+		return
+	}
+
+	t.Fatalf("did not find the gpservice_init_step_duration_seconds metric")
+}
+
+func TestServe(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	t.Run("serves the metrics over HTTP", func(t *testing.T) {
+		m := metrics.New("agent", t.TempDir())
+
+		port := freePort(t)
+		server, err := m.Serve("127.0.0.1", port, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		defer server.Close()
+
+		resp, err := http.Get(fmt.Sprintf("http://localhost:%d/metrics", port))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		defer resp.Body.Close()
+
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		for _, expected := range []string{"go_goroutines", "gpservice_process_start_time_seconds", "gpservice_host_log_filesystem_size_bytes"} {
+			if !strings.Contains(string(body), expected) {
+				t.Fatalf("got %s, want it to contain %s", body, expected)
+			}
+		}
+	})
+
+	t.Run("errors out when the port is in use", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "0.0.0.0:0")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		defer listener.Close()
+		port := listener.Addr().(*net.TCPAddr).Port
+
+		_, err = metrics.New("agent", "").Serve("0.0.0.0", port, nil)
+		expected := fmt.Sprintf("could not listen on metrics port %d", port)
+		if err == nil || !strings.HasPrefix(err.Error(), expected) {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+
+		var opErr *net.OpError
+		if !errors.As(err, &opErr) {
+			t.Fatalf("got %T, want it to wrap a %T", err, opErr)
+		}
+	})
+
+	t.Run("serves the metrics over HTTPS to the clients with a certificate issued by the CA", func(t *testing.T) {
+		creds, clientCert := writeTestCredentials(t)
+
+		tlsConfig, err := metrics.TLSConfig(creds)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		port := freePort(t)
+		server, err := metrics.New("agent", t.TempDir()).Serve("127.0.0.1", port, tlsConfig)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		defer server.Close()
+
+		url := fmt.Sprintf("https://localhost:%d/metrics", port)
+		rootCAs := tlsConfig.ClientCAs
+
+		client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: rootCAs, Certificates: []tls.Certificate{clientCert}}}}
+		resp, err := client.Get(url)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("got status %d, want %d", resp.StatusCode, http.StatusOK)
+		}
+
+		client = &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: rootCAs}}}
+		_, err = client.Get(url)
+		if err == nil {
+			t.Fatalf("expected the client without a certificate to be refused")
+		}
+	})
+
+	t.Run("errors out when serving over TLS while it is disabled for the services", func(t *testing.T) {
+		_, err := metrics.TLSConfig(&utils.GpCredentials{TlsEnabled: false})
+		expected := "serving the metrics over TLS requires TLS to be enabled for the services"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+}
+
+// writeTestCredentials writes the certificates of a service running on localhost, returning
+// them along with a client certificate issued by the same CA
+func writeTestCredentials(t *testing.T) (*utils.GpCredentials, tls.Certificate) {
+	t.Helper()
+
+	ca, err := utils.NewCertificateAuthority("ca", time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	serverCert, serverKey, err := ca.IssueServerCertificate("localhost", time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	clientCert, clientKey, err := ca.IssueClientCertificate("prometheus", time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	dir := t.TempDir()
+	creds := &utils.GpCredentials{
+		CACertPath:     filepath.Join(dir, "ca-cert.pem"),
+		ServerCertPath: filepath.Join(dir, "server-cert.pem"),
+		ServerKeyPath:  filepath.Join(dir, "server-key.pem"),
+		TlsEnabled:     true,
+	}
+
+	for path, contents := range map[string][]byte{creds.CACertPath: ca.CertPEM(), creds.ServerCertPath: serverCert, creds.ServerKeyPath: serverKey} {
+		err = os.WriteFile(path, contents, 0600)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	cert, err := tls.X509KeyPair(clientCert, clientKey)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return creds, cert
+}
+
+func freePort(t *testing.T) int {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer listener.Close()
+
+	return listener.Addr().(*net.TCPAddr).Port
+}
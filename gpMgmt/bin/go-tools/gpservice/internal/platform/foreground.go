@@ -0,0 +1,407 @@
+package platform
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"github.com/greenplum-db/gpdb/gpservice/idl"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+)
+
+// The supervisor gives up restarting a service which crashed restartBurst times within
+// restartInterval, the same as the default start rate limiting of systemd
+var (
+	restartDelay    = time.Second
+	restartInterval = 10 * time.Second
+	restartBurst    = 5
+)
+
+// pidfile keys, which are named after the properties shown by systemctl so that the
+// status is parsed the same way as for systemd
+const (
+	supervisorPidKey = "SupervisorPID"
+	mainPidKey       = "MainPID"
+	startTimeKey     = "ActiveEnterTimestamp"
+)
+
+/*
+ForegroundPlatform runs the hub and the agents without a service manager, for hosts such as
+containers where neither systemd nor launchd is available. Each service is run by a gpservice
+supervisor process, which restarts it when it crashes and records its pid in a pidfile under
+RunDir. The supervisor either stays in the foreground or is detached as a daemon, which is how
+the services are started by the gpservice start command.
+*/
+type ForegroundPlatform struct {
+	GpHome         string
+	ConfigFilepath string
+	RunDir         string // Directory where the pidfiles of the services are written
+}
+
+// SupervisorRunDir returns the directory where the supervisors write their pidfiles, which is
+// kept apart from the log directory so that the files served by the agents never include them
+func SupervisorRunDir(gpHome string) string {
+	return filepath.Join(gpHome, "run")
+}
+
+func NewForegroundPlatform(gpHome, configFilepath, runDir string) ForegroundPlatform {
+	return ForegroundPlatform{
+		GpHome:         gpHome,
+		ConfigFilepath: configFilepath,
+		RunDir:         runDir,
+	}
+}
+
+func (p ForegroundPlatform) executable() string {
+	return filepath.Join(p.GpHome, "bin", "gpservice")
+}
+
+// supervisorCommand returns the command line of the supervisor of the process as started by Daemonize
+func (p ForegroundPlatform) supervisorCommand(process string) string {
+	return strings.Join([]string{p.executable(), process, "--config-file", p.ConfigFilepath, "--supervise"}, " ")
+}
+
+// Pidfile returns the pidfile of the service, such as gpservice_hub
+func (p ForegroundPlatform) Pidfile(serviceName string) string {
+	return filepath.Join(p.RunDir, fmt.Sprintf("%s.pid", serviceName))
+}
+
+func (p ForegroundPlatform) CreateServiceDir(hostnames []string, gpHome string) error {
+	err := utils.Remote.Run(hostnames, fmt.Sprintf("mkdir -p -m 700 %s", utils.ShellQuote(p.RunDir))).Err()
+	if err != nil {
+		return fmt.Errorf("could not create pidfile directory %s on hosts: %w", p.RunDir, err)
+	}
+
+	gplog.Info("Created pidfile directory %s on all hosts", p.RunDir)
+	return nil
+}
+
+// There are no service files without a service manager
+func (p ForegroundPlatform) GenerateServiceFileContents(process, gpHome, serviceName, serviceFilepath string) string {
+	return ""
+}
+
+func (p ForegroundPlatform) ReloadHubService(servicePath string) error {
+	return nil
+}
+
+func (p ForegroundPlatform) ReloadAgentService(gpHome string, hostnames []string, servicePath string) error {
+	return nil
+}
+
+func (p ForegroundPlatform) CreateAndInstallHubServiceFile(gpHome, serviceName, serviceFilepath string) error {
+	gplog.Info("No service manager is used, the hub service is run by the gpservice supervisor on coordinator host")
+	return nil
+}
+
+func (p ForegroundPlatform) CreateAndInstallAgentServiceFile(hostnames []string, gpHome, serviceName, serviceFilepath string) error {
+	gplog.Info("No service manager is used, the agent service is run by the gpservice supervisor on segment hosts")
+	return nil
+}
+
+func (p ForegroundPlatform) GetStartHubCommand(serviceName string) *exec.Cmd {
+	return utils.System.ExecCommand(p.executable(), "hub", "--config-file", p.ConfigFilepath, "--daemon")
+}
+
+func (p ForegroundPlatform) GetStartAgentCommandString(serviceName string) []string {
+	return []string{p.executable(), "agent", "--config-file", p.ConfigFilepath, "--daemon"}
+}
+
+// RemoveHubService stops the supervisor of the hub, which in turn stops the hub
+func (p ForegroundPlatform) RemoveHubService(serviceName string) error {
+	hubServiceName := fmt.Sprintf("%s_hub", serviceName)
+
+	pids, err := p.readPidfile(hubServiceName)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("could not remove hub service %s: %w", hubServiceName, err)
+	}
+
+	if p.isSupervisor(pids[supervisorPidKey], "hub") {
+		err = syscall.Kill(pids[supervisorPidKey], syscall.SIGTERM)
+		if err != nil {
+			return fmt.Errorf("could not remove hub service %s: %w", hubServiceName, err)
+		}
+	}
+
+	err = utils.System.Remove(p.Pidfile(hubServiceName))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("could not remove hub service %s: %w", hubServiceName, err)
+	}
+
+	return nil
+}
+
+// RemoveAgentService stops the supervisors of the agents, which in turn stop the agents. The pid of
+// the pidfile is only signalled when it is still the supervisor, as it may since have been reused.
+func (p ForegroundPlatform) RemoveAgentService(gpHome string, serviceName string, hostnames []string) error {
+	agentServiceName := fmt.Sprintf("%s_agent", serviceName)
+	pidfile := utils.ShellQuote(p.Pidfile(agentServiceName))
+
+	cmd := fmt.Sprintf(`pid=$(sed -n 's/^%[1]s=//p' %[2]s 2>/dev/null); `+
+		`if [ -n "$pid" ] && ps -ww -o args= -p "$pid" 2>/dev/null | grep -qF -- %[3]s; then kill "$pid"; fi; rm -f %[2]s`,
+		supervisorPidKey, pidfile, utils.ShellQuote(p.supervisorCommand("agent")))
+	err := utils.Remote.Run(hostnames, cmd).Err()
+	if err != nil {
+		return fmt.Errorf("could not remove agent service %s on segment hosts: %w", agentServiceName, err)
+	}
+
+	return nil
+}
+
+func (p ForegroundPlatform) RemoveHubServiceFile(serviceName string) error {
+	return nil
+}
+
+func (p ForegroundPlatform) RemoveAgentServiceFile(gpHome string, serviceName string, hostnames []string) error {
+	return nil
+}
+
+/*
+GetServiceStatusMessage returns the contents of the pidfile of the service, or an empty
+message when the service is not running. The pidfile is left behind when the supervisor
+is killed, in which case the service is not running either.
+*/
+func (p ForegroundPlatform) GetServiceStatusMessage(serviceName string) (string, error) {
+	contents, err := utils.System.ReadFile(p.Pidfile(serviceName))
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get service status: %w", err)
+	}
+
+	pids := parsePidfile(string(contents))
+	if !processAlive(pids[mainPidKey]) {
+		return "", nil
+	}
+
+	return string(contents), nil
+}
+
+/*
+Example pidfile contents
+
+SupervisorPID=83001
+MainPID=83008
+ActiveEnterTimestamp=Sun 2023-08-20 14:43:35 UTC
+*/
+func (p ForegroundPlatform) ParseServiceStatusMessage(message string) idl.ServiceStatus {
+	return GpPlatform{}.ParseServiceStatusMessage(message)
+}
+
+// There is no user session to outlive without a service manager
+func (p ForegroundPlatform) EnableUserLingering(hostnames []string, gpHome string) error {
+	return nil
+}
+
+/*
+Supervise runs the process of the service, either the hub or the agent, in the foreground
+and restarts it when it exits with an error. Supervision stops once the process exits
+successfully, as it does when it is stopped by gpservice, or when the supervisor receives
+an interrupt or a termination signal, which is passed on to the process.
+*/
+func (p ForegroundPlatform) Supervise(serviceName, process string) error {
+	name := fmt.Sprintf("%s_%s", serviceName, process)
+
+	pids, err := p.readPidfile(name)
+	if err == nil && processAlive(pids[supervisorPidKey]) {
+		return fmt.Errorf("%s is already running with supervisor pid %d", name, pids[supervisorPidKey])
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(signals)
+	defer os.Remove(p.Pidfile(name))
+
+	var starts []time.Time
+	for {
+		starts = append(recentStarts(starts), time.Now())
+		if len(starts) > restartBurst {
+			return fmt.Errorf("%s exited with an error %d times within %s, not restarting it", name, restartBurst, restartInterval)
+		}
+
+		cmd := utils.System.ExecCommand(p.executable(), process, "--config-file", p.ConfigFilepath)
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		err := cmd.Start()
+		if err != nil {
+			return fmt.Errorf("failed to start %s: %w", name, err)
+		}
+
+		err = p.writePidfile(name, cmd.Process.Pid)
+		if err != nil {
+			cmd.Process.Kill() // nolint
+			cmd.Wait()         // nolint
+			return err
+		}
+		gplog.Info("Started %s with pid %d", name, cmd.Process.Pid)
+
+		exited := make(chan error, 1)
+		go func() {
+			exited <- cmd.Wait()
+		}()
+
+		select {
+		case sig := <-signals:
+			gplog.Info("Received %s, stopping %s", sig, name)
+			cmd.Process.Signal(sig) // nolint
+			<-exited
+			return nil
+
+		case err := <-exited:
+			if err == nil {
+				gplog.Info("%s exited successfully, no longer supervising it", name)
+				return nil
+			}
+			gplog.Warn("%s exited with an error: %v, restarting it in %s", name, err, restartDelay)
+		}
+
+		select {
+		case sig := <-signals:
+			gplog.Info("Received %s, no longer supervising %s", sig, name)
+			return nil
+		case <-time.After(restartDelay):
+		}
+	}
+}
+
+/*
+Daemonize runs the supervisor of the service detached from the terminal and returns once it
+has been started, which is how the services are started over ssh. Nothing is done when the
+supervisor is already running.
+*/
+func (p ForegroundPlatform) Daemonize(serviceName, process string) error {
+	name := fmt.Sprintf("%s_%s", serviceName, process)
+
+	pids, err := p.readPidfile(name)
+	if err == nil && processAlive(pids[supervisorPidKey]) {
+		gplog.Info("%s is already running with supervisor pid %d", name, pids[supervisorPidKey])
+		return nil
+	}
+
+	logfile := fmt.Sprintf("/tmp/grpc_%s.log", process)
+	out, err := utils.System.OpenFile(logfile, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("could not open %s: %w", logfile, err)
+	}
+	defer out.Close()
+
+	cmd := utils.System.ExecCommand(p.executable(), process, "--config-file", p.ConfigFilepath, "--supervise")
+	cmd.Stdout = out
+	cmd.Stderr = out
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+	err = cmd.Start()
+	if err != nil {
+		return fmt.Errorf("failed to start the supervisor of %s: %w", name, err)
+	}
+
+	gplog.Info("Started the supervisor of %s with pid %d", name, cmd.Process.Pid)
+	return cmd.Process.Release()
+}
+
+func (p ForegroundPlatform) writePidfile(serviceName string, pid int) error {
+	contents := fmt.Sprintf("%s=%d\n%s=%d\n%s=%s\n",
+		supervisorPidKey, os.Getpid(),
+		mainPidKey, pid,
+		startTimeKey, time.Now().Format("Mon 2006-01-02 15:04:05 MST"))
+
+	// The run directory is created by gpservice init, but not for configurations created before it was used
+	err := os.MkdirAll(p.RunDir, 0700)
+	if err != nil {
+		return fmt.Errorf("could not create pidfile directory %s: %w", p.RunDir, err)
+	}
+
+	// Write to a temporary file first so that the status never reads a partial pidfile
+	pidfile := p.Pidfile(serviceName)
+	err = os.WriteFile(pidfile+".tmp", []byte(contents), 0644)
+	if err == nil {
+		err = os.Rename(pidfile+".tmp", pidfile)
+	}
+	if err != nil {
+		return fmt.Errorf("could not write pidfile %s: %w", pidfile, err)
+	}
+
+	return nil
+}
+
+func (p ForegroundPlatform) readPidfile(serviceName string) (map[string]int, error) {
+	contents, err := utils.System.ReadFile(p.Pidfile(serviceName))
+	if err != nil {
+		return nil, err
+	}
+
+	return parsePidfile(string(contents)), nil
+}
+
+func parsePidfile(contents string) map[string]int {
+	pids := make(map[string]int)
+	for _, line := range strings.Split(contents, "\n") {
+		key, value, found := strings.Cut(strings.TrimSpace(line), "=")
+		if !found {
+			continue
+		}
+
+		if pid, err := strconv.Atoi(value); err == nil {
+			pids[key] = pid
+		}
+	}
+
+	return pids
+}
+
+func processAlive(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+
+	err := syscall.Kill(pid, 0)
+	return err == nil || errors.Is(err, syscall.EPERM)
+}
+
+// isSupervisor reports whether the process with the given pid is the supervisor of the process,
+// rather than an unrelated one which was given the pid of a supervisor which is no longer running
+func (p ForegroundPlatform) isSupervisor(pid int, process string) bool {
+	if !processAlive(pid) {
+		return false
+	}
+
+	out, err := utils.System.ExecCommand("ps", "-ww", "-o", "args=", "-p", strconv.Itoa(pid)).Output()
+	if err != nil {
+		return false
+	}
+
+	return strings.Contains(string(out), p.supervisorCommand(process))
+}
+
+// recentStarts returns the start times of the service within the restart interval
+func recentStarts(starts []time.Time) []time.Time {
+	var recent []time.Time
+	for _, start := range starts {
+		if time.Since(start) < restartInterval {
+			recent = append(recent, start)
+		}
+	}
+
+	return recent
+}
+
+// used only for testing
+func SetRestartPolicy(delay, interval time.Duration, burst int) func() {
+	oldDelay, oldInterval, oldBurst := restartDelay, restartInterval, restartBurst
+	restartDelay, restartInterval, restartBurst = delay, interval, burst
+
+	return func() {
+		restartDelay, restartInterval, restartBurst = oldDelay, oldInterval, oldBurst
+	}
+}
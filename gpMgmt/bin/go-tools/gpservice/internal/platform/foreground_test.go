@@ -0,0 +1,353 @@
+package platform_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+	"github.com/greenplum-db/gpdb/gpservice/internal/platform"
+	"github.com/greenplum-db/gpdb/gpservice/testutils"
+)
+
+// foregroundPlatform returns a platform whose gpservice executable is a script which records
+// its arguments and exits with the given code
+func foregroundPlatform(t *testing.T, exitCode int) platform.ForegroundPlatform {
+	t.Helper()
+
+	dir := t.TempDir()
+	err := os.Mkdir(filepath.Join(dir, "bin"), 0755)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	script := fmt.Sprintf("#!/bin/bash\necho \"$@\" >> %s\nexit %d\n", filepath.Join(dir, "runs"), exitCode)
+	err = os.WriteFile(filepath.Join(dir, "bin", "gpservice"), []byte(script), 0755)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return platform.NewForegroundPlatform(dir, "/gphome/gpservice.conf", dir)
+}
+
+func readRuns(t *testing.T, p platform.ForegroundPlatform) []string {
+	t.Helper()
+
+	contents, err := os.ReadFile(filepath.Join(p.GpHome, "runs"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return strings.Split(strings.TrimSpace(string(contents)), "\n")
+}
+
+func writePidfile(t *testing.T, p platform.ForegroundPlatform, serviceName string, supervisorPid, mainPid int) {
+	t.Helper()
+
+	contents := fmt.Sprintf("SupervisorPID=%d\nMainPID=%d\nActiveEnterTimestamp=Sun 2023-08-20 14:43:35 UTC\n", supervisorPid, mainPid)
+	err := os.WriteFile(p.Pidfile(serviceName), []byte(contents), 0644)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+// startProcess starts a process which is killed once the test completes
+func startProcess(t *testing.T) *exec.Cmd {
+	t.Helper()
+
+	cmd := exec.Command("sleep", "30")
+	err := cmd.Start()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	t.Cleanup(func() {
+		cmd.Process.Kill() // nolint
+		cmd.Wait()         // nolint
+	})
+
+	return cmd
+}
+
+// startSupervisor starts a process with the command line of the supervisor of the given process,
+// which is killed once the test completes
+func startSupervisor(t *testing.T, process string) (platform.ForegroundPlatform, *exec.Cmd) {
+	t.Helper()
+
+	gpHome := t.TempDir()
+	err := os.Mkdir(filepath.Join(gpHome, "bin"), 0755)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	err = os.WriteFile(filepath.Join(gpHome, "bin", "gpservice"), []byte("#!/bin/bash\nsleep 30\n"), 0755)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	p := platform.NewForegroundPlatform(gpHome, "/gphome/gpservice.conf", t.TempDir())
+	cmd := exec.Command(filepath.Join(gpHome, "bin", "gpservice"), process, "--config-file", p.ConfigFilepath, "--supervise")
+	err = cmd.Start()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	t.Cleanup(func() {
+		cmd.Process.Kill() // nolint
+		cmd.Wait()         // nolint
+	})
+
+	return p, cmd
+}
+
+func TestForegroundStartCommands(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	p := platform.NewForegroundPlatform("/gphome", "/gphome/gpservice.conf", "/logdir")
+
+	t.Run("GetStartHubCommand starts the supervisor of the hub as a daemon", func(t *testing.T) {
+		result := p.GetStartHubCommand("gptest").Args
+		expected := []string{"/gphome/bin/gpservice", "hub", "--config-file", "/gphome/gpservice.conf", "--daemon"}
+		if !reflect.DeepEqual(result, expected) {
+			t.Fatalf("got %+v, want %+v", result, expected)
+		}
+	})
+
+	t.Run("GetStartAgentCommandString starts the supervisor of the agent as a daemon", func(t *testing.T) {
+		result := p.GetStartAgentCommandString("gptest")
+		expected := []string{"/gphome/bin/gpservice", "agent", "--config-file", "/gphome/gpservice.conf", "--daemon"}
+		if !reflect.DeepEqual(result, expected) {
+			t.Fatalf("got %+v, want %+v", result, expected)
+		}
+	})
+}
+
+func TestForegroundServiceStatus(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	t.Run("the service is not running when there is no pidfile", func(t *testing.T) {
+		p := platform.NewForegroundPlatform("/gphome", "/gphome/gpservice.conf", t.TempDir())
+
+		message, err := p.GetServiceStatusMessage("gptest_hub")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		status := p.ParseServiceStatusMessage(message)
+		if status.Status != "not running" || status.Pid != 0 {
+			t.Fatalf("got %+v, want the service to not be running", status)
+		}
+	})
+
+	t.Run("the service is running when the process of the pidfile is alive", func(t *testing.T) {
+		p := platform.NewForegroundPlatform("/gphome", "/gphome/gpservice.conf", t.TempDir())
+		cmd := startProcess(t)
+		writePidfile(t, p, "gptest_hub", os.Getpid(), cmd.Process.Pid)
+
+		message, err := p.GetServiceStatusMessage("gptest_hub")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		status := p.ParseServiceStatusMessage(message)
+		if status.Status != "running" || status.Pid != uint32(cmd.Process.Pid) || status.Uptime != "Sun 2023-08-20 14:43:35 UTC" {
+			t.Fatalf("got %+v, want the service to be running with pid %d", status, cmd.Process.Pid)
+		}
+	})
+
+	t.Run("the service is not running when the process of the pidfile has exited", func(t *testing.T) {
+		p := platform.NewForegroundPlatform("/gphome", "/gphome/gpservice.conf", t.TempDir())
+		cmd := exec.Command("true")
+		err := cmd.Run()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		writePidfile(t, p, "gptest_hub", cmd.Process.Pid, cmd.Process.Pid)
+
+		message, err := p.GetServiceStatusMessage("gptest_hub")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if message != "" {
+			t.Fatalf("got %q, want an empty message", message)
+		}
+	})
+}
+
+func TestForegroundRemoveService(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	t.Run("RemoveHubService stops the supervisor of the hub and removes its pidfile", func(t *testing.T) {
+		p, cmd := startSupervisor(t, "hub")
+		writePidfile(t, p, "gptest_hub", cmd.Process.Pid, cmd.Process.Pid)
+
+		err := p.RemoveHubService("gptest")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		err = cmd.Wait()
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) || exitErr.Sys().(syscall.WaitStatus).Signal() != syscall.SIGTERM {
+			t.Fatalf("got %v, want the supervisor to be terminated", err)
+		}
+
+		if _, err := os.Stat(p.Pidfile("gptest_hub")); !errors.Is(err, os.ErrNotExist) {
+			t.Fatalf("expected the pidfile to be removed, got %v", err)
+		}
+	})
+
+	t.Run("RemoveHubService does not signal a process which is not the supervisor of the hub", func(t *testing.T) {
+		p := platform.NewForegroundPlatform("/gphome", "/gphome/gpservice.conf", t.TempDir())
+		cmd := startProcess(t)
+		writePidfile(t, p, "gptest_hub", cmd.Process.Pid, cmd.Process.Pid)
+
+		err := p.RemoveHubService("gptest")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if cmd.Process.Signal(syscall.Signal(0)) != nil {
+			t.Fatalf("expected the process to be left running")
+		}
+
+		if _, err := os.Stat(p.Pidfile("gptest_hub")); !errors.Is(err, os.ErrNotExist) {
+			t.Fatalf("expected the pidfile to be removed, got %v", err)
+		}
+	})
+
+	t.Run("RemoveHubService succeeds when the hub has no pidfile", func(t *testing.T) {
+		p := platform.NewForegroundPlatform("/gphome", "/gphome/gpservice.conf", t.TempDir())
+
+		err := p.RemoveHubService("gptest")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("RemoveAgentService stops the supervisors of the agents and removes their pidfiles", func(t *testing.T) {
+		p, cmd := startSupervisor(t, "agent")
+		writePidfile(t, p, "gptest_agent", cmd.Process.Pid, cmd.Process.Pid)
+
+		remote := testutils.SetMockRemoteExecutor(t)
+		err := p.RemoveAgentService(p.GpHome, "gptest", []string{"sdw1"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		// run the command on the local host as it would be on the segment hosts
+		out, err := exec.Command("bash", "-c", remote.Commands[0]).CombinedOutput()
+		if err != nil {
+			t.Fatalf("unexpected error: %s, %v", out, err)
+		}
+
+		err = cmd.Wait()
+		if err == nil {
+			t.Fatalf("expected the supervisor to be terminated")
+		}
+
+		if _, err := os.Stat(p.Pidfile("gptest_agent")); !errors.Is(err, os.ErrNotExist) {
+			t.Fatalf("expected the pidfile to be removed, got %v", err)
+		}
+	})
+
+	t.Run("RemoveAgentService does not signal a process which is not the supervisor of the agent", func(t *testing.T) {
+		p := platform.NewForegroundPlatform("/gphome", "/gphome/gpservice.conf", t.TempDir())
+		cmd := startProcess(t)
+		writePidfile(t, p, "gptest_agent", cmd.Process.Pid, cmd.Process.Pid)
+
+		remote := testutils.SetMockRemoteExecutor(t)
+		err := p.RemoveAgentService("/gphome", "gptest", []string{"sdw1"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		out, err := exec.Command("bash", "-c", remote.Commands[0]).CombinedOutput()
+		if err != nil {
+			t.Fatalf("unexpected error: %s, %v", out, err)
+		}
+
+		if cmd.Process.Signal(syscall.Signal(0)) != nil {
+			t.Fatalf("expected the process to be left running")
+		}
+
+		if _, err := os.Stat(p.Pidfile("gptest_agent")); !errors.Is(err, os.ErrNotExist) {
+			t.Fatalf("expected the pidfile to be removed, got %v", err)
+		}
+	})
+}
+
+func TestSupervisorRunDir(t *testing.T) {
+	result := platform.SupervisorRunDir("/gphome")
+	if result != "/gphome/run" {
+		t.Fatalf("got %s, want /gphome/run", result)
+	}
+}
+
+func TestSupervise(t *testing.T) {
+	_, _, logfile := testhelper.SetupTestLogger()
+
+	t.Run("stops supervising the service once it exits successfully", func(t *testing.T) {
+		p := foregroundPlatform(t, 0)
+
+		err := p.Supervise("gptest", "hub")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		runs := readRuns(t, p)
+		expected := []string{"hub --config-file /gphome/gpservice.conf"}
+		if !reflect.DeepEqual(runs, expected) {
+			t.Fatalf("got %v, want %v", runs, expected)
+		}
+
+		if _, err := os.Stat(p.Pidfile("gptest_hub")); !errors.Is(err, os.ErrNotExist) {
+			t.Fatalf("expected the pidfile to be removed, got %v", err)
+		}
+		testutils.AssertLogMessage(t, logfile, `\[INFO\]:-gptest_hub exited successfully, no longer supervising it`)
+	})
+
+	t.Run("restarts the service when it exits with an error until it crashes too often", func(t *testing.T) {
+		resetRestartPolicy := platform.SetRestartPolicy(0, time.Minute, 3)
+		defer resetRestartPolicy()
+
+		p := foregroundPlatform(t, 1)
+
+		err := p.Supervise("gptest", "agent")
+		expected := "gptest_agent exited with an error 3 times within 1m0s, not restarting it"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+
+		runs := readRuns(t, p)
+		if len(runs) != 3 {
+			t.Fatalf("got %d runs, want 3", len(runs))
+		}
+
+		if _, err := os.Stat(p.Pidfile("gptest_agent")); !errors.Is(err, os.ErrNotExist) {
+			t.Fatalf("expected the pidfile to be removed, got %v", err)
+		}
+		testutils.AssertLogMessage(t, logfile, `\[WARNING\]:-gptest_agent exited with an error: exit status 1, restarting it in 0s`)
+	})
+
+	t.Run("errors when the supervisor of the service is already running", func(t *testing.T) {
+		p := foregroundPlatform(t, 0)
+		writePidfile(t, p, "gptest_hub", os.Getpid(), os.Getpid())
+
+		err := p.Supervise("gptest", "hub")
+		expected := fmt.Sprintf("gptest_hub is already running with supervisor pid %d", os.Getpid())
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+
+		if _, err := os.Stat(filepath.Join(p.GpHome, "runs")); !errors.Is(err, os.ErrNotExist) {
+			t.Fatalf("expected the service to not be started, got %v", err)
+		}
+	})
+}
@@ -13,7 +13,6 @@ import (
 	"github.com/greenplum-db/gp-common-go-libs/gplog"
 	"github.com/greenplum-db/gpdb/gpservice/constants"
 	"github.com/greenplum-db/gpdb/gpservice/idl"
-	"github.com/greenplum-db/gpdb/gpservice/pkg/greenplum"
 	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
 )
 
@@ -98,13 +97,9 @@ func GetPlatform() Platform {
 }
 
 func (p GpPlatform) CreateServiceDir(hostnames []string, gpHome string) error {
-	gpsshCmd := &greenplum.GpSSH{
-		Hostnames: hostnames,
-		Command:   fmt.Sprintf("mkdir -p %s", p.ServiceDir),
-	}
-	out, err := utils.RunGpSourcedCommand(gpsshCmd, gpHome)
+	err := utils.Remote.Run(hostnames, fmt.Sprintf("mkdir -p %s", p.ServiceDir)).Err()
 	if err != nil {
-		return fmt.Errorf("could not create service directory %s on hosts: %s, %w", p.ServiceDir, out, err)
+		return fmt.Errorf("could not create service directory %s on hosts: %w", p.ServiceDir, err)
 	}
 
 	gplog.Info("Created service file directory %s on all hosts", p.ServiceDir)
@@ -226,34 +221,22 @@ func (p GpPlatform) ReloadHubService(servicePath string) error {
 func (p GpPlatform) ReloadAgentService(gpHome string, hostnames []string, servicePath string) error {
 	if p.OS == constants.PlatformDarwin {
 		// launchctl does not have a single reload command. Hence unload and load the file to update the configuration.
-		gpsshCmd := &greenplum.GpSSH{
-			Hostnames: hostnames,
-			Command:   fmt.Sprintf("%s unload %s", p.ServiceCmd, servicePath),
-		}
-		out, err := utils.RunGpSourcedCommand(gpsshCmd, gpHome)
+		err := utils.Remote.Run(hostnames, fmt.Sprintf("%s unload %s", p.ServiceCmd, servicePath)).Err()
 		if err != nil {
-			return fmt.Errorf("could not unload agent service file %s on segment hosts: %s, %w", servicePath, out, err)
+			return fmt.Errorf("could not unload agent service file %s on segment hosts: %w", servicePath, err)
 		}
 
-		gpsshCmd = &greenplum.GpSSH{
-			Hostnames: hostnames,
-			Command:   fmt.Sprintf("%s load %s", p.ServiceCmd, servicePath),
-		}
-		out, err = utils.RunGpSourcedCommand(gpsshCmd, gpHome)
+		err = utils.Remote.Run(hostnames, fmt.Sprintf("%s load %s", p.ServiceCmd, servicePath)).Err()
 		if err != nil {
-			return fmt.Errorf("could not load agent service file %s on segment hosts: %s, %w", servicePath, out, err)
+			return fmt.Errorf("could not load agent service file %s on segment hosts: %w", servicePath, err)
 		}
 
 		return nil
 	}
 
-	gpsshCmd := &greenplum.GpSSH{
-		Hostnames: hostnames,
-		Command:   fmt.Sprintf("%s %s daemon-reload", p.ServiceCmd, p.UserArg),
-	}
-	out, err := utils.RunGpSourcedCommand(gpsshCmd, gpHome)
+	err := utils.Remote.Run(hostnames, fmt.Sprintf("%s %s daemon-reload", p.ServiceCmd, p.UserArg)).Err()
 	if err != nil {
-		return fmt.Errorf("could not reload agent service file %s on segment hosts: %s, %w", servicePath, out, err)
+		return fmt.Errorf("could not reload agent service file %s on segment hosts: %w", servicePath, err)
 	}
 
 	return nil
@@ -269,14 +252,9 @@ func (p GpPlatform) CreateAndInstallAgentServiceFile(hostnames []string, gpHome,
 	defer os.Remove(localAgentServiceFilePath)
 
 	remoteAgentServiceFilePath := fmt.Sprintf("%s/%s_agent.%s", p.ServiceDir, serviceName, p.ServiceExt)
-	gsyncCmd := &greenplum.GpSync{
-		Hostnames:   hostnames,
-		Source:      localAgentServiceFilePath,
-		Destination: remoteAgentServiceFilePath,
-	}
-	out, err := utils.RunGpSourcedCommand(gsyncCmd, gpHome)
+	err = utils.Remote.CopyFile(hostnames, localAgentServiceFilePath, remoteAgentServiceFilePath).Err()
 	if err != nil {
-		return fmt.Errorf("could not copy agent service file to segment hosts: %s, %w", out, err)
+		return fmt.Errorf("could not copy agent service file to segment hosts: %w", err)
 	}
 
 	err = p.ReloadAgentService(gpHome, hostnames, remoteAgentServiceFilePath)
@@ -328,23 +306,15 @@ func (p GpPlatform) RemoveHubService(serviceName string) error {
 func (p GpPlatform) RemoveAgentService(gpHome string, serviceName string, hostnames []string) error {
 	args := []string{p.ServiceCmd}
 	if p.OS == constants.PlatformDarwin {
-		gpsshCmd := &greenplum.GpSSH{
-			Hostnames: hostnames,
-			Command:   strings.Join(append(args, "remove", fmt.Sprintf("%s_agent", serviceName)), " "),
-		}
-		out, err := utils.RunGpSourcedCommand(gpsshCmd, gpHome)
+		err := utils.Remote.Run(hostnames, strings.Join(append(args, "remove", fmt.Sprintf("%s_agent", serviceName)), " ")).Err()
 		if err != nil {
-			return fmt.Errorf("could not remove agent service %s on segment hosts: %s, %w", fmt.Sprintf("%s_agent", serviceName), out, err)
+			return fmt.Errorf("could not remove agent service %s on segment hosts: %w", fmt.Sprintf("%s_agent", serviceName), err)
 		}
 		return nil
 	}
-	gpsshCmd := &greenplum.GpSSH{
-		Hostnames: hostnames,
-		Command:   strings.Join(append(args, p.UserArg, "stop", fmt.Sprintf("%s_agent", serviceName)), " "),
-	}
-	out, err := utils.RunGpSourcedCommand(gpsshCmd, gpHome)
+	err := utils.Remote.Run(hostnames, strings.Join(append(args, p.UserArg, "stop", fmt.Sprintf("%s_agent", serviceName)), " ")).Err()
 	if err != nil {
-		return fmt.Errorf("could not remove agent service %s on segment hosts: %s, %w", fmt.Sprintf("%s_agent", serviceName), out, err)
+		return fmt.Errorf("could not remove agent service %s on segment hosts: %w", fmt.Sprintf("%s_agent", serviceName), err)
 	}
 
 	remoteAgentServiceFilePath := fmt.Sprintf("%s/%s_agent.%s", p.ServiceDir, serviceName, p.ServiceExt)
@@ -370,13 +340,9 @@ func (p GpPlatform) RemoveHubServiceFile(serviceName string) error {
 func (p GpPlatform) RemoveAgentServiceFile(gpHome string, serviceName string, hostnames []string) error {
 	remoteAgentServiceFilePath := filepath.Join(p.ServiceDir, fmt.Sprintf("%s_agent.%s", serviceName, p.ServiceExt))
 
-	gpsshCmd := &greenplum.GpSSH{
-		Hostnames: hostnames,
-		Command:   fmt.Sprintf("rm %s", remoteAgentServiceFilePath),
-	}
-	out, err := utils.RunGpSourcedCommand(gpsshCmd, gpHome)
+	err := utils.Remote.Run(hostnames, fmt.Sprintf("rm %s", remoteAgentServiceFilePath)).Err()
 	if err != nil {
-		return fmt.Errorf("could not delete agent service file %s on hosts: %s, %w", remoteAgentServiceFilePath, out, err)
+		return fmt.Errorf("could not delete agent service file %s on hosts: %w", remoteAgentServiceFilePath, err)
 	}
 
 	gplog.Info("Successfully removed agent service file %s from segment hosts", remoteAgentServiceFilePath)
@@ -457,13 +423,9 @@ func (p GpPlatform) EnableUserLingering(hostnames []string, gpHome string) error
 		return nil
 	}
 
-	gpsshCmd := &greenplum.GpSSH{
-		Hostnames: hostnames,
-		Command:   fmt.Sprintf("loginctl enable-linger %s", p.User),
-	}
-	out, err := utils.RunGpSourcedCommand(gpsshCmd, gpHome)
+	err := utils.Remote.Run(hostnames, fmt.Sprintf("loginctl enable-linger %s", p.User)).Err()
 	if err != nil {
-		return fmt.Errorf("could not enable user lingering: %s, %w", out, err)
+		return fmt.Errorf("could not enable user lingering: %w", err)
 	}
 
 	return nil
@@ -17,6 +17,7 @@ import (
 	"github.com/greenplum-db/gp-common-go-libs/testhelper"
 	"github.com/greenplum-db/gpdb/gpservice/idl"
 	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+	"github.com/greenplum-db/gpdb/gpservice/testutils"
 )
 
 func init() {
@@ -37,31 +38,34 @@ func TestCreateServiceDir(t *testing.T) {
 	t.Run("returns error when not able to create the directory", func(t *testing.T) {
 		platform := GetPlatform(t, constants.PlatformLinux)
 
-		utils.System.ExecCommand = exectest.NewCommand(exectest.Failure)
-		defer utils.ResetSystemFunctions()
+		remote := testutils.SetMockRemoteExecutor(t)
+		remote.Result = failedRemoteResult
 
 		err := platform.CreateServiceDir([]string{"host1"}, "gpHome")
-		var expectedErr *exec.ExitError
-		if !errors.As(err, &expectedErr) {
-			t.Errorf("got %T, want %T", err, expectedErr)
-		}
-
 		expectedErrPrefix := "could not create service directory"
-		if !strings.Contains(err.Error(), expectedErrPrefix) {
+		if err == nil || !strings.HasPrefix(err.Error(), expectedErrPrefix) {
 			t.Fatalf("got %v, want %s", err, expectedErrPrefix)
 		}
+
+		expectedErrSuffix := "host host1: exit status 1: error"
+		if !strings.HasSuffix(err.Error(), expectedErrSuffix) {
+			t.Fatalf("got %v, want %s", err, expectedErrSuffix)
+		}
 	})
 
 	t.Run("succesfully creates the directory", func(t *testing.T) {
 		platform := GetPlatform(t, constants.PlatformLinux)
 
-		utils.System.ExecCommand = exectest.NewCommand(exectest.Success)
-		defer utils.ResetSystemFunctions()
+		remote := testutils.SetMockRemoteExecutor(t)
 
 		err := platform.CreateServiceDir([]string{"host1"}, "gpHome")
 		if err != nil {
 			t.Fatalf("unexpected error: %#v", err)
 		}
+
+		if len(remote.Commands) != 1 || !strings.HasPrefix(remote.Commands[0], "mkdir -p ") {
+			t.Fatalf("got %v, want the service directory to be created", remote.Commands)
+		}
 	})
 }
 
@@ -195,6 +199,7 @@ func TestReloadServices(t *testing.T) {
 
 			utils.System.ExecCommand = exectest.NewCommand(exectest.Success)
 			defer utils.ResetSystemFunctions()
+			testutils.SetMockRemoteExecutor(t)
 
 			if tc.service == "hub" {
 				err = platform.ReloadHubService("/path/to/service/file")
@@ -226,6 +231,15 @@ func TestReloadServices(t *testing.T) {
 			}
 			defer utils.ResetSystemFunctions()
 
+			remote := testutils.SetMockRemoteExecutor(t)
+			remote.Result = func(hostname, command string) *utils.RemoteResult {
+				if strings.Contains(command, "unload") {
+					return failedRemoteResult(hostname, command)
+				}
+
+				return &utils.RemoteResult{}
+			}
+
 			if tc.service == "hub" {
 				err = platform.ReloadHubService("/path/to/service/file")
 			} else {
@@ -233,7 +247,7 @@ func TestReloadServices(t *testing.T) {
 			}
 
 			var expectedErr *exec.ExitError
-			if !errors.As(err, &expectedErr) {
+			if tc.service == "hub" && !errors.As(err, &expectedErr) {
 				t.Errorf("got %T, want %T", err, expectedErr)
 			}
 
@@ -256,6 +270,15 @@ func TestReloadServices(t *testing.T) {
 			}
 			defer utils.ResetSystemFunctions()
 
+			remote := testutils.SetMockRemoteExecutor(t)
+			remote.Result = func(hostname, command string) *utils.RemoteResult {
+				if strings.Contains(command, "unload") {
+					return &utils.RemoteResult{}
+				}
+
+				return failedRemoteResult(hostname, command)
+			}
+
 			if tc.service == "hub" {
 				err = platform.ReloadHubService("/path/to/service/file")
 			} else {
@@ -263,7 +286,7 @@ func TestReloadServices(t *testing.T) {
 			}
 
 			var expectedErr *exec.ExitError
-			if !errors.As(err, &expectedErr) {
+			if tc.service == "hub" && !errors.As(err, &expectedErr) {
 				t.Errorf("got %T, want %T", err, expectedErr)
 			}
 
@@ -287,6 +310,9 @@ func TestReloadServices(t *testing.T) {
 			utils.System.ExecCommand = exectest.NewCommand(exectest.Failure)
 			defer utils.ResetSystemFunctions()
 
+			remote := testutils.SetMockRemoteExecutor(t)
+			remote.Result = failedRemoteResult
+
 			if tc.service == "hub" {
 				err = platform.ReloadHubService("/path/to/service/file")
 			} else {
@@ -294,7 +320,7 @@ func TestReloadServices(t *testing.T) {
 			}
 
 			var expectedErr *exec.ExitError
-			if !errors.As(err, &expectedErr) {
+			if tc.service == "hub" && !errors.As(err, &expectedErr) {
 				t.Errorf("got %T, want %T", err, expectedErr)
 			}
 
@@ -377,16 +403,20 @@ func TestCreateAndInstallAgentServiceFile(t *testing.T) {
 
 			return writer, nil
 		}
-		utils.System.ExecCommand = exectest.NewCommand(exectest.Success)
 		defer utils.ResetSystemFunctions()
+		remote := testutils.SetMockRemoteExecutor(t)
 
 		err := platform.CreateAndInstallAgentServiceFile([]string{"host1", "host2"}, "gpHome", "gptest", "/path/to/service/file")
 		if err != nil {
 			t.Fatalf("unexpected error: %#v", err)
 		}
+
+		if len(remote.Copies) != 1 || !strings.HasSuffix(remote.Copies[0], "/gptest_agent.service") {
+			t.Fatalf("got %v, want the agent service file to be copied", remote.Copies)
+		}
 	})
 
-	t.Run("CreateAndInstallAgentServiceFile errors when gpsync fails", func(t *testing.T) {
+	t.Run("CreateAndInstallAgentServiceFile errors when not able to copy the file", func(t *testing.T) {
 		platform := GetPlatform(t, constants.PlatformLinux)
 
 		utils.System.OpenFile = func(name string, flag int, perm os.FileMode) (*os.File, error) {
@@ -394,22 +424,19 @@ func TestCreateAndInstallAgentServiceFile(t *testing.T) {
 
 			return writer, nil
 		}
-		utils.System.ExecCommand = func(name string, args ...string) *exec.Cmd {
-			if strings.Contains(strings.Join(args, " "), "gpsync") {
-				return exectest.NewCommand(exectest.Failure)(name, args...)
+		defer utils.ResetSystemFunctions()
+
+		remote := testutils.SetMockRemoteExecutor(t)
+		remote.Result = func(hostname, command string) *utils.RemoteResult {
+			if strings.HasPrefix(command, "copy ") {
+				return failedRemoteResult(hostname, command)
 			}
 
-			return exectest.NewCommand(exectest.Success)(name, args...)
+			return &utils.RemoteResult{}
 		}
-		defer utils.ResetSystemFunctions()
 
 		err := platform.CreateAndInstallAgentServiceFile([]string{"host1", "host2"}, "gpHome", "gptest", "/path/to/service/file")
-		var expectedErr *exec.ExitError
-		if !errors.As(err, &expectedErr) {
-			t.Errorf("got %T, want %T", err, expectedErr)
-		}
-
-		expectedErrPrefix := "could not copy agent service file to segment hosts:"
+		expectedErrPrefix := "could not copy agent service file to segment hosts: host host1: exit status 1: error"
 		if !strings.Contains(err.Error(), expectedErrPrefix) {
 			t.Fatalf("got %v, want %s", err, expectedErrPrefix)
 		}
@@ -421,8 +448,8 @@ func TestCreateAndInstallAgentServiceFile(t *testing.T) {
 		utils.System.OpenFile = func(name string, flag int, perm os.FileMode) (*os.File, error) {
 			return nil, os.ErrPermission
 		}
-		utils.System.ExecCommand = exectest.NewCommand(exectest.Success)
 		defer utils.ResetSystemFunctions()
+		testutils.SetMockRemoteExecutor(t)
 
 		err := platform.CreateAndInstallAgentServiceFile([]string{"host1", "host2"}, "gpHome", "gptest", "/path/to/service/file")
 		expectedErr := os.ErrPermission
@@ -439,22 +466,19 @@ func TestCreateAndInstallAgentServiceFile(t *testing.T) {
 
 			return writer, nil
 		}
-		utils.System.ExecCommand = func(name string, args ...string) *exec.Cmd {
-			if strings.HasSuffix(name, "gpsync") {
-				return exectest.NewCommand(exectest.Success)(name, args...)
+		defer utils.ResetSystemFunctions()
+
+		remote := testutils.SetMockRemoteExecutor(t)
+		remote.Result = func(hostname, command string) *utils.RemoteResult {
+			if strings.HasPrefix(command, "copy ") {
+				return &utils.RemoteResult{}
 			}
 
-			return exectest.NewCommand(exectest.Failure)(name, args...)
+			return failedRemoteResult(hostname, command)
 		}
-		defer utils.ResetSystemFunctions()
 
 		err := platform.CreateAndInstallAgentServiceFile([]string{"host1", "host2"}, "gpHome", "gptest", "/path/to/service/file")
-		var expectedErr *exec.ExitError
-		if !errors.As(err, &expectedErr) {
-			t.Errorf("got %T, want %T", err, expectedErr)
-		}
-
-		expectedErrPrefix := "could not copy agent service file to segment hosts:"
+		expectedErrPrefix := "could not reload agent service file"
 		if !strings.Contains(err.Error(), expectedErrPrefix) {
 			t.Fatalf("got %v, want %s", err, expectedErrPrefix)
 		}
@@ -671,13 +695,16 @@ func TestEnableUserLingering(t *testing.T) {
 	t.Run("EnableUserLingering run successfully for linux", func(t *testing.T) {
 		platform := GetPlatform(t, constants.PlatformLinux)
 
-		utils.System.ExecCommand = exectest.NewCommand(exectest.Success)
-		defer utils.ResetSystemFunctions()
+		remote := testutils.SetMockRemoteExecutor(t)
 
 		err := platform.EnableUserLingering([]string{"host1", "host2"}, "/path/to/gpHome")
 		if err != nil {
 			t.Fatalf("unexpected error: %#v", err)
 		}
+
+		if len(remote.Commands) != 1 || !strings.HasPrefix(remote.Commands[0], "loginctl enable-linger ") {
+			t.Fatalf("got %v, want user lingering to be enabled", remote.Commands)
+		}
 	})
 
 	t.Run("EnableUserLingering runs successfully for other platforms", func(t *testing.T) {
@@ -692,17 +719,12 @@ func TestEnableUserLingering(t *testing.T) {
 	t.Run("EnableUserLingering returns error on failure", func(t *testing.T) {
 		platform := GetPlatform(t, constants.PlatformLinux)
 
-		utils.System.ExecCommand = exectest.NewCommand(exectest.Failure)
-		defer utils.ResetSystemFunctions()
+		remote := testutils.SetMockRemoteExecutor(t)
+		remote.Result = failedRemoteResult
 
 		err := platform.EnableUserLingering([]string{"host1", "host2"}, "path/to/gpHome")
-		var expectedErr *exec.ExitError
-		if !errors.As(err, &expectedErr) {
-			t.Errorf("got %T, want %T", err, expectedErr)
-		}
-
-		expectedErrPrefix := "could not enable user lingering:"
-		if !strings.Contains(err.Error(), expectedErrPrefix) {
+		expectedErrPrefix := "could not enable user lingering: host host1: exit status 1: error"
+		if err == nil || !strings.Contains(err.Error(), expectedErrPrefix) {
 			t.Fatalf("got %v, want %s", err, expectedErrPrefix)
 		}
 	})
@@ -717,6 +739,10 @@ func ServiceStopped() {
 	os.Exit(3)
 }
 
+func failedRemoteResult(hostname, command string) *utils.RemoteResult {
+	return &utils.RemoteResult{ExitCode: 1, Stderr: "error"}
+}
+
 func GetPlatform(t *testing.T, os string) platform.Platform {
 	t.Helper()
 
@@ -1,22 +1,27 @@
 package gpservice_config
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net"
 	"os"
+	"slices"
 	"strconv"
 
 	"github.com/greenplum-db/gp-common-go-libs/gplog"
 	"github.com/greenplum-db/gpdb/gpservice/idl"
 	"github.com/greenplum-db/gpdb/gpservice/idl/mock_idl"
-	"github.com/greenplum-db/gpdb/gpservice/pkg/greenplum"
 	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	grpcStatus "google.golang.org/grpc/status"
 )
 
 var (
 	ConnectToHub           = connectToHubFunc
+	ConnectToAgent         = connectToAgentFunc
 	copyConfigFileToAgents = copyConfigFileToAgentsFunc
 )
 
@@ -28,11 +33,63 @@ type Config struct {
 	ServiceName   string   `json:"serviceName"`
 	GpHome        string   `json:"gphome"`
 	DefaultConfig bool     `json:"defaultConfig"`
+	HubHost       string   `json:"hubHost,omitempty"`
+
+	// NoServiceManager runs the hub and the agents under the gpservice supervisor instead
+	// of systemd or launchd, for hosts such as containers which have no service manager
+	NoServiceManager bool `json:"noServiceManager,omitempty"`
+
+	Auth    *utils.AuthConfig    `json:"auth,omitempty"`
+	Metrics *MetricsConfig       `json:"metrics,omitempty"`
+	Tracing *utils.TracingConfig `json:"tracing,omitempty"`
 
 	Credentials utils.Credentials
 }
 
+// MetricsConfig configures the ports on which the hub and the agents serve their metrics
+// for Prometheus to scrape, where a port of 0 leaves the metrics of the service disabled
+type MetricsConfig struct {
+	HubPort   int `json:"hubPort,omitempty"`
+	AgentPort int `json:"agentPort,omitempty"`
+
+	// CoordinatorDataDir is where the hub reads the segment configuration from, which is
+	// only reported when set
+	CoordinatorDataDir string `json:"coordinatorDataDir,omitempty"`
+
+	// BindAddress is the address the metrics are served on, see GetBindAddress
+	BindAddress string `json:"bindAddress,omitempty"`
+
+	// TLS serves the metrics over HTTPS using the certificates of the services, requiring
+	// the scrapers to present a client certificate issued by the same CA
+	TLS bool `json:"tls,omitempty"`
+}
+
+// GetBindAddress returns the address to serve the metrics on, which is the loopback address
+// unless configured otherwise, so that they are only reachable from other hosts when allowed
+func (m *MetricsConfig) GetBindAddress() string {
+	if m.BindAddress == "" {
+		return "127.0.0.1"
+	}
+
+	return m.BindAddress
+}
+
 func (conf *Config) Write(filepath string) error {
+	err := conf.WriteLocal(filepath)
+	if err != nil {
+		return err
+	}
+
+	err = copyConfigFileToAgents(conf, filepath)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// WriteLocal writes the configuration to the given file on the local host only
+func (conf *Config) WriteLocal(filepath string) error {
 	file, err := utils.System.OpenFile(filepath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
 	if err != nil {
 		return fmt.Errorf("could not create service config file %s: %w", filepath, err)
@@ -49,20 +106,38 @@ func (conf *Config) Write(filepath string) error {
 		return fmt.Errorf("could not write to service config file %s: %w", filepath, err)
 	}
 
-	err = copyConfigFileToAgents(conf.Hostnames, filepath, conf.GpHome)
-	if err != nil {
-		return err
+	return nil
+}
+
+// ServiceIdentities returns the hosts of the configuration, as the hub and the agents call each
+// other using the certificate of the host they run on
+func (conf *Config) ServiceIdentities() []string {
+	identities := slices.Clone(conf.Hostnames)
+	if conf.HubHost != "" && !slices.Contains(identities, conf.HubHost) {
+		identities = append(identities, conf.HubHost)
 	}
 
-	return nil
+	return identities
 }
 
-func (conf *Config) Remove(configFilepath string) error {
-	gpsshCmd := &greenplum.GpSSH{
-		Hostnames: conf.Hostnames,
-		Command:   fmt.Sprintf("rm %s", configFilepath),
+// GetHubHost returns the host to connect to the hub on. The hub runs on the
+// coordinator host, which is the local host unless the standby coordinator has
+// been activated on a different host.
+func (conf *Config) GetHubHost() string {
+	if conf.HubHost == "" {
+		return "localhost"
+	}
+
+	hostname, err := utils.System.GetHostName()
+	if err == nil && hostname == conf.HubHost {
+		return "localhost"
 	}
-	_, err := utils.RunGpSourcedCommand(gpsshCmd, conf.GpHome)
+
+	return conf.HubHost
+}
+
+func (conf *Config) Remove(configFilepath string) error {
+	err := utils.Remote.Run(conf.Hostnames, fmt.Sprintf("rm %s", configFilepath)).Err()
 	if err != nil {
 		return fmt.Errorf("failed to delete service configuration file %s: %w", configFilepath, err)
 	}
@@ -70,16 +145,17 @@ func (conf *Config) Remove(configFilepath string) error {
 	return nil
 }
 
-func Create(filepath string, hubPort, agentPort int, hostnames []string, logdir, serviceName, gphome string, creds utils.Credentials, defaultConfig bool) error {
+func Create(filepath string, hubPort, agentPort int, hostnames []string, logdir, serviceName, gphome string, creds utils.Credentials, defaultConfig, noServiceManager bool) error {
 	conf := &Config{
-		HubPort:       hubPort,
-		AgentPort:     agentPort,
-		Hostnames:     hostnames,
-		LogDir:        logdir,
-		ServiceName:   serviceName,
-		GpHome:        gphome,
-		Credentials:   creds,
-		DefaultConfig: defaultConfig,
+		HubPort:          hubPort,
+		AgentPort:        agentPort,
+		Hostnames:        hostnames,
+		LogDir:           logdir,
+		ServiceName:      serviceName,
+		GpHome:           gphome,
+		Credentials:      creds,
+		DefaultConfig:    defaultConfig,
+		NoServiceManager: noServiceManager,
 	}
 
 	return conf.Write(filepath)
@@ -102,53 +178,123 @@ func Read(filepath string) (*Config, error) {
 	return config, nil
 }
 
-func copyConfigFileToAgentsFunc(hostnames []string, filepath, gpHome string) error {
-	gpsyncCmd := &greenplum.GpSync{
-		Hostnames:   hostnames,
-		Source:      filepath,
-		Destination: filepath,
+func copyConfigFileToAgentsFunc(conf *Config, filepath string) error {
+	return conf.CopyFileToHosts(conf.Hostnames, filepath, filepath)
+}
+
+/*
+CopyFileToHosts copies the local source file to the destination on the given hosts.
+The file is pushed through the hub when it and the agents are running, and copied
+over SSH otherwise, such as before the services are started for the first time.
+Any other error of the hub, such as an agent refusing to write the destination, is
+returned as is instead of being worked around over SSH.
+*/
+func (conf *Config) CopyFileToHosts(hostnames []string, source, destination string) error {
+	fallback, err := pushFileThroughHub(conf, hostnames, source, destination)
+	if err == nil {
+		return nil
+	}
+	if !fallback {
+		return fmt.Errorf("could not push %s to the hosts through the hub: %w", source, err)
 	}
+	gplog.Debug("Could not push %s through the hub, copying it over SSH instead: %v", source, err)
 
-	out, err := utils.RunGpSourcedCommand(gpsyncCmd, gpHome)
+	err = utils.Remote.CopyFile(hostnames, source, destination).Err()
 	if err != nil {
-		return fmt.Errorf("could not copy %s to segment hosts: %s, %w", filepath, out, err)
+		return fmt.Errorf("could not copy %s to segment hosts: %w", source, err)
 	}
 
 	return nil
 }
 
-func connectToHubFunc(conf *Config) (idl.HubClient, error) {
-	errPrefix := fmt.Sprintf("could not connect to hub on port %d", conf.HubPort)
-	var opts []grpc.DialOption
-	credentials, err := conf.Credentials.LoadClientCredentials()
+// pushFileThroughHub pushes the file to the hosts through the hub, and reports whether it should
+// be copied over SSH instead, which is when the hub or the agents on the hosts are not available
+func pushFileThroughHub(conf *Config, hostnames []string, source, destination string) (bool, error) {
+	info, err := utils.System.Stat(source)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", errPrefix, err)
+		return true, err
 	}
 
-	opts = append(opts, grpc.WithTransportCredentials(credentials))
+	contents, err := utils.System.ReadFile(source)
+	if err != nil {
+		return true, err
+	}
 
-	address := net.JoinHostPort("localhost", strconv.Itoa(conf.HubPort))
-	conn, err := grpc.NewClient(address, opts...)
+	client, err := ConnectToHub(conf)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", errPrefix, err)
+		return true, err
+	}
+
+	_, err = client.PushFile(context.Background(), &idl.PushFileRequest{
+		Hostnames: hostnames,
+		Path:      destination,
+		Mode:      uint32(info.Mode().Perm()),
+		Contents:  contents,
+	})
+	code := grpcStatus.Code(err)
+
+	return code == codes.Unavailable || code == codes.FailedPrecondition, err
+}
+
+func connectToHubFunc(conf *Config) (idl.HubClient, error) {
+	conn, err := dial(conf, conf.GetHubHost(), conf.HubPort)
+	if err != nil {
+		return nil, fmt.Errorf("could not connect to hub on port %d: %w", conf.HubPort, err)
 	}
 
 	return idl.NewHubClient(conn), nil
 }
 
+// connectToAgentFunc connects to the agent running on the local host
+func connectToAgentFunc(conf *Config) (idl.AgentClient, error) {
+	conn, err := dial(conf, "localhost", conf.AgentPort)
+	if err != nil {
+		return nil, fmt.Errorf("could not connect to agent on port %d: %w", conf.AgentPort, err)
+	}
+
+	return idl.NewAgentClient(conn), nil
+}
+
+func dial(conf *Config, host string, port int) (*grpc.ClientConn, error) {
+	var opts []grpc.DialOption
+	token, withToken := utils.TokenFromEnv()
+	credentials, err := utils.LoadCallerCredentials(conf.Credentials, withToken)
+	if err != nil {
+		return nil, err
+	}
+
+	opts = append(opts, grpc.WithTransportCredentials(credentials), grpc.WithStatsHandler(otelgrpc.NewClientHandler()))
+
+	if withToken {
+		if credentials.Info().SecurityProtocol != "tls" {
+			return nil, fmt.Errorf("the token in %s can only be sent when TLS is enabled", utils.AuthTokenEnv)
+		}
+		opts = append(opts, grpc.WithPerRPCCredentials(token))
+	}
+
+	return grpc.NewClient(net.JoinHostPort(host, strconv.Itoa(port)), opts...)
+}
+
 func SetConnectToHub(hubClient *mock_idl.MockHubClient) {
 	ConnectToHub = func(conf *Config) (idl.HubClient, error) {
 		return hubClient, nil
 	}
 }
 
+func SetConnectToAgent(agentClient *mock_idl.MockAgentClient) {
+	ConnectToAgent = func(conf *Config) (idl.AgentClient, error) {
+		return agentClient, nil
+	}
+}
+
 func ResetConfigFunctions() {
 	ConnectToHub = connectToHubFunc
+	ConnectToAgent = connectToAgentFunc
 	copyConfigFileToAgents = copyConfigFileToAgentsFunc
 }
 
 func SetCopyConfigFileToAgents() {
-	copyConfigFileToAgents = func(hostnames []string, filepath, gpHome string) error {
+	copyConfigFileToAgents = func(conf *Config, filepath string) error {
 		return nil
 	}
 }
@@ -5,19 +5,23 @@ import (
 	"errors"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
 
+	"github.com/golang/mock/gomock"
 	"github.com/greenplum-db/gpdb/gpservice/testutils"
 	"github.com/greenplum-db/gpdb/gpservice/testutils/exectest"
 
 	"github.com/greenplum-db/gp-common-go-libs/testhelper"
 	"github.com/greenplum-db/gpdb/gpservice/constants"
+	"github.com/greenplum-db/gpdb/gpservice/idl"
+	"github.com/greenplum-db/gpdb/gpservice/idl/mock_idl"
 	"github.com/greenplum-db/gpdb/gpservice/pkg/gpservice_config"
 	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+	"google.golang.org/grpc/codes"
+	grpcStatus "google.golang.org/grpc/status"
 )
 
 // Enable exectest.NewCommand mocking.
@@ -35,6 +39,9 @@ func TestConfig(t *testing.T) {
 		LogDir:      "logdir",
 		ServiceName: "serviceName",
 		GpHome:      "gphome",
+
+		NoServiceManager: true,
+
 		Credentials: &utils.GpCredentials{
 			CACertPath:     "ca-cert",
 			ServerCertPath: "server-cert",
@@ -44,25 +51,21 @@ func TestConfig(t *testing.T) {
 	}
 
 	t.Run("successfully stores the config on disk and able to read it back", func(t *testing.T) {
-		var gpsyncCalled bool
-		utils.System.ExecCommand = exectest.NewCommandWithVerifier(exectest.Success, func(utility string, args ...string) {
-			gpsyncCalled = true
-
-			expectedArgs := []string{"gpsync", "-h", expected.Hostnames[0], "-h", expected.Hostnames[1]}
-			if !strings.Contains(strings.Join(args, " "), strings.Join(expectedArgs, " ")) {
-				t.Fatalf("got %+v, want %+v", args, expectedArgs)
-			}
-		})
-		defer utils.ResetSystemFunctions()
+		var copiedHosts []string
+		remote := testutils.SetMockRemoteExecutor(t)
+		remote.Result = func(hostname, command string) *utils.RemoteResult {
+			copiedHosts = append(copiedHosts, hostname)
+			return &utils.RemoteResult{}
+		}
 
 		filepath := filepath.Join(t.TempDir(), constants.ConfigFileName)
-		err := gpservice_config.Create(filepath, expected.HubPort, expected.AgentPort, expected.Hostnames, expected.LogDir, expected.ServiceName, expected.GpHome, expected.Credentials, false)
+		err := gpservice_config.Create(filepath, expected.HubPort, expected.AgentPort, expected.Hostnames, expected.LogDir, expected.ServiceName, expected.GpHome, expected.Credentials, false, expected.NoServiceManager)
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
 
-		if !gpsyncCalled {
-			t.Fatalf("expected gpsync to be called")
+		if !reflect.DeepEqual(remote.Copies, []string{filepath}) || !reflect.DeepEqual(copiedHosts, expected.Hostnames) {
+			t.Fatalf("got copies %v to hosts %v, want %s to be copied to %v", remote.Copies, copiedHosts, filepath, expected.Hostnames)
 		}
 
 		result, err := gpservice_config.Read(filepath)
@@ -75,6 +78,31 @@ func TestConfig(t *testing.T) {
 		}
 	})
 
+	t.Run("writes the config only on the local host", func(t *testing.T) {
+		remote := testutils.SetMockRemoteExecutor(t)
+
+		filepath := filepath.Join(t.TempDir(), constants.ConfigFileName)
+		conf := *expected
+		conf.HubHost = "sdw1"
+		err := conf.WriteLocal(filepath)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(remote.Copies) != 0 {
+			t.Fatalf("expected the config to not be copied, got %v", remote.Copies)
+		}
+
+		result, err := gpservice_config.Read(filepath)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !reflect.DeepEqual(result, &conf) {
+			t.Fatalf("got %+v, want %+v", result, &conf)
+		}
+	})
+
 	t.Run("returns error when fails to create config file", func(t *testing.T) {
 		expectedErr := os.ErrNotExist
 		utils.System.OpenFile = func(name string, flag int, perm os.FileMode) (*os.File, error) {
@@ -122,17 +150,16 @@ func TestConfig(t *testing.T) {
 
 			return writer, nil
 		}
-		utils.System.ExecCommand = exectest.NewCommand(exectest.Failure)
 		defer utils.ResetSystemFunctions()
 
-		var expectedErr *exec.ExitError
-		expectedFilepath := "test.config"
-		err := expected.Write(expectedFilepath)
-		if !errors.As(err, &expectedErr) {
-			t.Errorf("got %T, want %T", err, expectedErr)
+		remote := testutils.SetMockRemoteExecutor(t)
+		remote.Result = func(hostname, command string) *utils.RemoteResult {
+			return &utils.RemoteResult{Err: errors.New("connection refused")}
 		}
 
-		expectedErrPrefix := fmt.Sprintf("could not copy %s to segment hosts:", expectedFilepath)
+		expectedFilepath := "test.config"
+		err := expected.Write(expectedFilepath)
+		expectedErrPrefix := fmt.Sprintf("could not copy %s to segment hosts: host sdw1: connection refused\nhost sdw2: connection refused", expectedFilepath)
 		if !strings.HasPrefix(err.Error(), expectedErrPrefix) {
 			t.Fatalf("got %v, want %s", err, expectedErrPrefix)
 		}
@@ -189,3 +216,172 @@ func TestConnectToHub(t *testing.T) {
 		}
 	})
 }
+
+func TestGetHubHost(t *testing.T) {
+	cases := []struct {
+		name     string
+		hubHost  string
+		expected string
+	}{
+		{
+			name:     "defaults to the local host",
+			hubHost:  "",
+			expected: "localhost",
+		},
+		{
+			name:     "returns localhost when the hub host is the local host",
+			hubHost:  "cdw",
+			expected: "localhost",
+		},
+		{
+			name:     "returns the hub host when it is a remote host",
+			hubHost:  "scdw",
+			expected: "scdw",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			utils.System.GetHostName = func() (string, error) {
+				return "cdw", nil
+			}
+			defer utils.ResetSystemFunctions()
+
+			conf := &gpservice_config.Config{HubHost: tc.hubHost}
+			result := conf.GetHubHost()
+			if result != tc.expected {
+				t.Fatalf("got %s, want %s", result, tc.expected)
+			}
+		})
+	}
+}
+
+func TestMetricsBindAddress(t *testing.T) {
+	t.Run("defaults to the loopback address", func(t *testing.T) {
+		metricsConf := &gpservice_config.MetricsConfig{HubPort: 9190}
+		result := metricsConf.GetBindAddress()
+		if result != "127.0.0.1" {
+			t.Fatalf("got %s, want 127.0.0.1", result)
+		}
+	})
+
+	t.Run("returns the configured address", func(t *testing.T) {
+		metricsConf := &gpservice_config.MetricsConfig{HubPort: 9190, BindAddress: "0.0.0.0"}
+		result := metricsConf.GetBindAddress()
+		if result != "0.0.0.0" {
+			t.Fatalf("got %s, want 0.0.0.0", result)
+		}
+	})
+}
+
+func TestCopyFileToHosts(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	conf := testutils.CreateDummyServiceConfig(t)
+
+	source := filepath.Join(t.TempDir(), "server.crt")
+	err := os.WriteFile(source, []byte("certificate"), 0600)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	t.Run("pushes the file through the hub when it is running", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		remote := testutils.SetMockRemoteExecutor(t)
+
+		hubClient := mock_idl.NewMockHubClient(ctrl)
+		hubClient.EXPECT().PushFile(gomock.Any(), &idl.PushFileRequest{
+			Hostnames: []string{"sdw1"},
+			Path:      "/etc/gpservice/server.crt",
+			Mode:      0600,
+			Contents:  []byte("certificate"),
+		}).Return(&idl.PushFileReply{}, nil)
+		gpservice_config.SetConnectToHub(hubClient)
+		defer gpservice_config.ResetConfigFunctions()
+
+		err := conf.CopyFileToHosts([]string{"sdw1"}, source, "/etc/gpservice/server.crt")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(remote.Copies) != 0 {
+			t.Fatalf("expected the file to not be copied over SSH, got %v", remote.Copies)
+		}
+	})
+
+	t.Run("copies the file over SSH when the hub is not able to push it", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		remote := testutils.SetMockRemoteExecutor(t)
+
+		hubClient := mock_idl.NewMockHubClient(ctrl)
+		hubClient.EXPECT().PushFile(gomock.Any(), gomock.Any()).Return(nil, grpcStatus.Error(codes.FailedPrecondition, "host sdw3 is not managed by the hub service"))
+		gpservice_config.SetConnectToHub(hubClient)
+		defer gpservice_config.ResetConfigFunctions()
+
+		err := conf.CopyFileToHosts([]string{"sdw3"}, source, "/etc/gpservice/server.crt")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !reflect.DeepEqual(remote.Copies, []string{"/etc/gpservice/server.crt"}) {
+			t.Fatalf("got %v, want [/etc/gpservice/server.crt]", remote.Copies)
+		}
+	})
+
+	t.Run("copies the file over SSH when the hub is not running", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		remote := testutils.SetMockRemoteExecutor(t)
+
+		hubClient := mock_idl.NewMockHubClient(ctrl)
+		hubClient.EXPECT().PushFile(gomock.Any(), gomock.Any()).Return(nil, grpcStatus.Error(codes.Unavailable, "connection refused"))
+		gpservice_config.SetConnectToHub(hubClient)
+		defer gpservice_config.ResetConfigFunctions()
+
+		err := conf.CopyFileToHosts([]string{"sdw1"}, source, "/etc/gpservice/server.crt")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !reflect.DeepEqual(remote.Copies, []string{"/etc/gpservice/server.crt"}) {
+			t.Fatalf("got %v, want [/etc/gpservice/server.crt]", remote.Copies)
+		}
+	})
+
+	t.Run("does not copy the file over SSH when the agent refuses to write it", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		remote := testutils.SetMockRemoteExecutor(t)
+
+		hubClient := mock_idl.NewMockHubClient(ctrl)
+		hubClient.EXPECT().PushFile(gomock.Any(), gomock.Any()).Return(nil, errors.New("writing to /etc/passwd is not allowed"))
+		gpservice_config.SetConnectToHub(hubClient)
+		defer gpservice_config.ResetConfigFunctions()
+
+		err := conf.CopyFileToHosts([]string{"sdw1"}, source, "/etc/passwd")
+		expected := fmt.Sprintf("could not push %s to the hosts through the hub: writing to /etc/passwd is not allowed", source)
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+
+		if len(remote.Copies) != 0 {
+			t.Fatalf("expected the file to not be copied over SSH, got %v", remote.Copies)
+		}
+	})
+
+	t.Run("returns error when not able to copy the file over SSH either", func(t *testing.T) {
+		gpservice_config.ConnectToHub = func(conf *gpservice_config.Config) (idl.HubClient, error) {
+			return nil, errors.New("connection refused")
+		}
+		defer gpservice_config.ResetConfigFunctions()
+
+		remote := testutils.SetMockRemoteExecutor(t)
+		remote.Result = func(hostname, command string) *utils.RemoteResult {
+			return &utils.RemoteResult{Err: errors.New("no route to host")}
+		}
+
+		err := conf.CopyFileToHosts([]string{"sdw1"}, source, "/etc/gpservice/server.crt")
+		expected := fmt.Sprintf("could not copy %s to segment hosts: host sdw1: no route to host", source)
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+}
@@ -3,9 +3,11 @@ package gpservice_mgmt
 import (
 	"github.com/greenplum-db/gpdb/gpservice/internal/cli"
 	"github.com/greenplum-db/gpdb/gpservice/pkg/gpservice_config"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
 )
 
-func StartServices(conf *gpservice_config.Config) error {
+func StartServices(conf *gpservice_config.Config, confFile string) error {
+	cli.UseServiceManager(conf, confFile)
 	return cli.StartServices(conf)
 }
 
@@ -14,12 +16,18 @@ func StopServices(conf *gpservice_config.Config) error {
 }
 
 func DeleteServices(conf *gpservice_config.Config, confFile string) error {
+	cli.UseServiceManager(conf, confFile)
 	return cli.DeleteServices(conf, confFile)
 }
 
 func InitialiseGpService(configFilepath string, hubPort, agentPort int, hostnames []string, hubLogDir, serviceName,
-	gpHome, caCertPath, serverCertPath, serverKeyPath string, NoTls, defaultConfig bool) error {
+	gpHome string, credentials *utils.GpCredentials, defaultConfig, noServiceManager bool) error {
 
 	return cli.InitGpService(configFilepath, hubPort, agentPort, hostnames, hubLogDir, serviceName,
-		gpHome, caCertPath, serverCertPath, serverKeyPath, NoTls, defaultConfig)
+		gpHome, credentials, defaultConfig, noServiceManager)
+}
+
+func MoveHubService(conf *gpservice_config.Config, configFilepath, hostname string) error {
+	cli.UseServiceManager(conf, configFilepath)
+	return cli.MoveHubService(conf, configFilepath, hostname)
 }
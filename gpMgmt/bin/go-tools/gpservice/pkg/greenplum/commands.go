@@ -1,7 +1,6 @@
 package greenplum
 
 import (
-	"fmt"
 	"os/exec"
 
 	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
@@ -9,8 +8,6 @@ import (
 
 const (
 	gpstart = "gpstart"
-	gpsync  = "gpsync"
-	gpssh   = "gpssh"
 )
 
 type GpStart struct {
@@ -24,39 +21,3 @@ func (cmd *GpStart) BuildExecCommand(gpHome string) *exec.Cmd {
 
 	return utils.System.ExecCommand(utility, args...)
 }
-
-type GpSync struct {
-	Hostnames   []string
-	Source      string
-	Destination string
-}
-
-func (cmd *GpSync) BuildExecCommand(gpHome string) *exec.Cmd {
-	utility := utils.GetGpUtilityPath(gpHome, gpsync)
-	args := []string{}
-
-	for _, host := range cmd.Hostnames {
-		args = append(args, "-h", host)
-	}
-
-	args = append(args, cmd.Source, fmt.Sprintf("=:%s", cmd.Destination))
-	return exec.Command(utility, args...)
-}
-
-type GpSSH struct {
-	Hostnames []string
-	Command   string
-}
-
-func (cmd *GpSSH) BuildExecCommand(gpHome string) *exec.Cmd {
-	utility := utils.GetGpUtilityPath(gpHome, gpssh)
-	args := []string{}
-
-	for _, host := range cmd.Hostnames {
-		args = append(args, "-h", host)
-	}
-
-	args = append(args, cmd.Command)
-
-	return exec.Command(utility, args...)
-}
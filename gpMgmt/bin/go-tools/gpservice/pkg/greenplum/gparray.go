@@ -3,6 +3,7 @@ package greenplum
 import (
 	"fmt"
 	"sort"
+	"strings"
 
 	_ "github.com/lib/pq"
 
@@ -188,6 +189,59 @@ func RegisterPrimarySegments(segs []*idl.Segment, conn *utils.DBConnWithContext)
 	return nil
 }
 
+// RegisterExpansionPrimarySegments adds the new primary segments of an expansion to
+// gp_segment_configuration with the dbid and content already assigned to them. The
+// segments are added in a single statement so that either all of them or none are
+// registered.
+func RegisterExpansionPrimarySegments(segs []*idl.Segment, conn *utils.DBConnWithContext) error {
+	addPrimaryQuery := "SELECT pg_catalog.gp_add_segment(%d::int2, %d::int2, 'p', 'p', 'n', 'u', %d, '%s', '%s', '%s')"
+
+	if len(segs) == 0 {
+		return nil
+	}
+
+	var queries []string
+	for _, seg := range segs {
+		queries = append(queries, fmt.Sprintf(addPrimaryQuery, seg.Dbid, seg.Contentid, seg.Port, seg.HostName, seg.HostAddress, seg.DataDirectory))
+	}
+
+	_, err := conn.DB.ExecContext(conn.Ctx, strings.Join(queries, "; "))
+
+	return err
+}
+
+// LockCatalog blocks any changes to the catalog of the cluster until UnlockCatalog is
+// called or the connection is closed, in the same way as gpexpand. The coordinator is
+// checkpointed so that the copies of it made for the new segments are up to date.
+func LockCatalog(conn *utils.DBConnWithContext) error {
+	err := conn.DB.Begin()
+	if err != nil {
+		return fmt.Errorf("locking the catalog: %w", err)
+	}
+
+	_, err = conn.DB.ExecContext(conn.Ctx, "SELECT pg_catalog.gp_expand_lock_catalog()")
+	if err != nil {
+		return fmt.Errorf("locking the catalog: %w", err)
+	}
+
+	_, err = conn.DB.ExecContext(conn.Ctx, "CHECKPOINT")
+	if err != nil {
+		return fmt.Errorf("locking the catalog: %w", err)
+	}
+
+	return nil
+}
+
+// UnlockCatalog releases the lock taken by LockCatalog
+func UnlockCatalog(conn *utils.DBConnWithContext) error {
+	err := conn.DB.Commit()
+	if err != nil {
+		return fmt.Errorf("unlocking the catalog: %w", err)
+	}
+
+	return nil
+}
+
 func RegisterMirrorSegments(segs []*idl.Segment, conn *utils.DBConnWithContext) error {
 	addMirrorQuery := "SELECT pg_catalog.gp_add_segment_mirror(%d::int2, '%s', '%s', %d, '%s');"
 	for _, seg := range segs {
@@ -202,6 +256,57 @@ func RegisterMirrorSegments(segs []*idl.Segment, conn *utils.DBConnWithContext)
 	return nil
 }
 
+// UnregisterMirrorSegment removes the mirror of the given content from gp_segment_configuration
+func UnregisterMirrorSegment(content int, conn *utils.DBConnWithContext) error {
+	removeMirrorQuery := "SELECT pg_catalog.gp_remove_segment_mirror(%d::int2);"
+	_, err := conn.DB.ExecContext(conn.Ctx, fmt.Sprintf(removeMirrorQuery, content))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+/*
+RelocateMirrorSegment moves the mirror registered for the content of the given segment to the
+location of the segment, keeping its dbid. The old entry is removed and the new one is added
+in a single transaction so that the content is never left without a mirror in the catalog.
+*/
+func RelocateMirrorSegment(seg Segment, conn *utils.DBConnWithContext) error {
+	relocateMirrorQuery := "SELECT pg_catalog.gp_remove_segment_mirror(%[2]d::int2); " +
+		"SELECT pg_catalog.gp_add_segment(%[1]d::int2, %[2]d::int2, 'm', 'm', 'n', 'd', %[3]d, '%[4]s', '%[5]s', '%[6]s')"
+	query := fmt.Sprintf(relocateMirrorQuery, seg.Dbid, seg.Content, seg.Port, seg.Hostname, seg.Address, seg.DataDir)
+
+	_, err := conn.DB.ExecContext(conn.Ctx, query)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// RegisterStandby adds the standby coordinator to gp_segment_configuration
+func RegisterStandby(seg *idl.Segment, conn *utils.DBConnWithContext) error {
+	addStandbyQuery := "SELECT pg_catalog.gp_add_coordinator_standby('%s', '%s', '%s', %d)"
+	_, err := conn.DB.ExecContext(conn.Ctx, fmt.Sprintf(addStandbyQuery, seg.HostName, seg.HostAddress, seg.DataDirectory, seg.Port))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UnregisterStandby removes the standby coordinator from gp_segment_configuration
+func UnregisterStandby(conn *utils.DBConnWithContext) error {
+	removeStandbyQuery := "SELECT pg_catalog.gp_remove_coordinator_standby()"
+	_, err := conn.DB.ExecContext(conn.Ctx, removeStandbyQuery)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func getSegmentPairsFromContentMap(contentMap map[int][]Segment) ([]SegmentPair, error) {
 	var pairs []SegmentPair
 	segsPerContent := 0
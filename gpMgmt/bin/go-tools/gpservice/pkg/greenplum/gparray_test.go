@@ -366,6 +366,125 @@ func TestRegisterSegments(t *testing.T) {
 		}
 	})
 
+	t.Run("succesfully registers the expansion primary segments", func(t *testing.T) {
+		conn, mock := testutils.CreateAndConnectMockDBWithContext(t, context.Background(), 1)
+
+		segs := []*idl.Segment{
+			{
+				Dbid:          6,
+				Contentid:     2,
+				Port:          1111,
+				HostName:      "sdw3",
+				HostAddress:   "sdw3",
+				DataDirectory: "/data/gpseg2",
+			},
+			{
+				Dbid:          7,
+				Contentid:     3,
+				Port:          1112,
+				HostName:      "sdw3",
+				HostAddress:   "sdw3",
+				DataDirectory: "/data/gpseg3",
+			},
+		}
+
+		mock.ExpectExec(regexp.QuoteMeta("SELECT pg_catalog.gp_add_segment(6::int2, 2::int2, 'p', 'p', 'n', 'u', 1111, 'sdw3', 'sdw3', '/data/gpseg2'); " +
+			"SELECT pg_catalog.gp_add_segment(7::int2, 3::int2, 'p', 'p', 'n', 'u', 1112, 'sdw3', 'sdw3', '/data/gpseg3')")).WillReturnResult(sqlmock.NewResult(1, 1))
+
+		err := greenplum.RegisterExpansionPrimarySegments(segs, conn)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("succesfully locks and unlocks the catalog", func(t *testing.T) {
+		conn, mock := testutils.CreateAndConnectMockDBWithContext(t, context.Background(), 1)
+
+		mock.ExpectBegin()
+		mock.ExpectExec("SET TRANSACTION ISOLATION LEVEL SERIALIZABLE").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(regexp.QuoteMeta("SELECT pg_catalog.gp_expand_lock_catalog()")).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CHECKPOINT").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		err := greenplum.LockCatalog(conn)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		err = greenplum.UnlockCatalog(conn)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("returns appropriate error when fails to lock the catalog", func(t *testing.T) {
+		conn, mock := testutils.CreateAndConnectMockDBWithContext(t, context.Background(), 1)
+
+		expectedErr := errors.New("error")
+		mock.ExpectBegin()
+		mock.ExpectExec("SET TRANSACTION ISOLATION LEVEL SERIALIZABLE").WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(regexp.QuoteMeta("SELECT pg_catalog.gp_expand_lock_catalog()")).WillReturnError(expectedErr)
+
+		err := greenplum.LockCatalog(conn)
+		if !errors.Is(err, expectedErr) {
+			t.Fatalf("got %#v, want %#v", err, expectedErr)
+		}
+	})
+
+	t.Run("succesfully unregisters the mirror segment", func(t *testing.T) {
+		conn, mock := testutils.CreateAndConnectMockDBWithContext(t, context.Background(), 1)
+
+		mock.ExpectExec(regexp.QuoteMeta("SELECT pg_catalog.gp_remove_segment_mirror(0::int2)")).WillReturnResult(sqlmock.NewResult(1, 1))
+
+		err := greenplum.UnregisterMirrorSegment(0, conn)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("succesfully relocates the mirror segment keeping its dbid", func(t *testing.T) {
+		conn, mock := testutils.CreateAndConnectMockDBWithContext(t, context.Background(), 1)
+
+		seg := greenplum.Segment{
+			Dbid:     5,
+			Content:  1,
+			Port:     7005,
+			Hostname: "sdw3",
+			Address:  "sdw3",
+			DataDir:  "/data/mirror1",
+		}
+		mock.ExpectExec(regexp.QuoteMeta("SELECT pg_catalog.gp_remove_segment_mirror(1::int2); " +
+			"SELECT pg_catalog.gp_add_segment(5::int2, 1::int2, 'm', 'm', 'n', 'd', 7005, 'sdw3', 'sdw3', '/data/mirror1')")).WillReturnResult(sqlmock.NewResult(1, 1))
+
+		err := greenplum.RelocateMirrorSegment(seg, conn)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("succesfully registers and unregisters the standby", func(t *testing.T) {
+		conn, mock := testutils.CreateAndConnectMockDBWithContext(t, context.Background(), 1)
+
+		seg := &idl.Segment{
+			Port:          7001,
+			HostName:      "scdw",
+			HostAddress:   "scdw",
+			DataDirectory: "/data/standby",
+		}
+		mock.ExpectExec(regexp.QuoteMeta("SELECT pg_catalog.gp_add_coordinator_standby('scdw', 'scdw', '/data/standby', 7001)")).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(regexp.QuoteMeta("SELECT pg_catalog.gp_remove_coordinator_standby()")).WillReturnResult(sqlmock.NewResult(1, 1))
+
+		err := greenplum.RegisterStandby(seg, conn)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		err = greenplum.UnregisterStandby(conn)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
 	t.Run("returns appropriate error when fails to register the segment", func(t *testing.T) {
 		conn, mock := testutils.CreateAndConnectMockDBWithContext(t, context.Background(), 1)
 
@@ -373,6 +492,11 @@ func TestRegisterSegments(t *testing.T) {
 		mock.ExpectExec("SELECT").WillReturnError(expectedErr)
 		mock.ExpectExec("SELECT").WillReturnError(expectedErr)
 		mock.ExpectExec("SELECT").WillReturnError(expectedErr)
+		mock.ExpectExec("SELECT").WillReturnError(expectedErr)
+		mock.ExpectExec("SELECT").WillReturnError(expectedErr)
+		mock.ExpectExec("SELECT").WillReturnError(expectedErr)
+		mock.ExpectExec("SELECT").WillReturnError(expectedErr)
+		mock.ExpectExec("SELECT").WillReturnError(expectedErr)
 
 		err := greenplum.RegisterCoordinator(&idl.Segment{}, conn)
 		if !errors.Is(err, expectedErr) {
@@ -388,6 +512,31 @@ func TestRegisterSegments(t *testing.T) {
 		if !errors.Is(err, expectedErr) {
 			t.Fatalf("got %#v, want %#v", err, expectedErr)
 		}
+
+		err = greenplum.RegisterExpansionPrimarySegments([]*idl.Segment{{}}, conn)
+		if !errors.Is(err, expectedErr) {
+			t.Fatalf("got %#v, want %#v", err, expectedErr)
+		}
+
+		err = greenplum.UnregisterMirrorSegment(0, conn)
+		if !errors.Is(err, expectedErr) {
+			t.Fatalf("got %#v, want %#v", err, expectedErr)
+		}
+
+		err = greenplum.RelocateMirrorSegment(greenplum.Segment{}, conn)
+		if !errors.Is(err, expectedErr) {
+			t.Fatalf("got %#v, want %#v", err, expectedErr)
+		}
+
+		err = greenplum.RegisterStandby(&idl.Segment{}, conn)
+		if !errors.Is(err, expectedErr) {
+			t.Fatalf("got %#v, want %#v", err, expectedErr)
+		}
+
+		err = greenplum.UnregisterStandby(conn)
+		if !errors.Is(err, expectedErr) {
+			t.Fatalf("got %#v, want %#v", err, expectedErr)
+		}
 	})
 }
 
@@ -9,6 +9,7 @@ import (
 
 	"github.com/greenplum-db/gp-common-go-libs/gplog"
 	"github.com/greenplum-db/gpdb/gpservice/constants"
+	"github.com/greenplum-db/gpdb/gpservice/idl"
 	"github.com/greenplum-db/gpdb/gpservice/pkg/postgres"
 	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
 )
@@ -74,3 +75,30 @@ func TriggerFtsProbe(coordinatorDataDir string) error {
 
 	return nil
 }
+
+// GetSegmentProcessStatus returns the process level status of the segment with the
+// given data directory. Errors are reported as part of the status rather than being
+// returned so that a single unhealthy segment does not hide the state of the others.
+func GetSegmentProcessStatus(gpHome, dataDir string) *idl.SegmentProcessStatus {
+	status := &idl.SegmentProcessStatus{
+		DataDir: dataDir,
+	}
+
+	running, pid, err := postgres.GetPostmasterStatus(gpHome, dataDir)
+	if err != nil {
+		status.Error = err.Error()
+	}
+	status.Running = running
+	status.Pid = int32(pid)
+
+	state, err := postgres.GetClusterState(gpHome, dataDir)
+	if err != nil {
+		gplog.Debug("failed to get the cluster state for data directory %s: %v", dataDir, err)
+		if status.Error == "" {
+			status.Error = err.Error()
+		}
+	}
+	status.ClusterState = state
+
+	return status
+}
@@ -14,6 +14,7 @@ const (
 	postgresUtility      = "postgres"
 	pgbasebackupUtility  = "pg_basebackup"
 	pgControlDataUtility = "pg_controldata"
+	pgRewindUtility      = "pg_rewind"
 )
 
 type Initdb struct {
@@ -89,6 +90,19 @@ func (cmd *PgCtlReload) BuildExecCommand(gpHome string) *exec.Cmd {
 	return utils.System.ExecCommand(utility, args...)
 }
 
+type PgCtlPromote struct {
+	PgData  string `flag:"--pgdata"`
+	Timeout int    `flag:"--timeout"`
+	Wait    bool   `flag:"--wait"`
+}
+
+func (cmd *PgCtlPromote) BuildExecCommand(gpHome string) *exec.Cmd {
+	utility := utils.GetGpUtilityPath(gpHome, pgCtlUtility)
+	args := append([]string{"promote"}, utils.GenerateArgs(cmd)...)
+
+	return utils.System.ExecCommand(utility, args...)
+}
+
 type PgCtlStatus struct {
 	PgData string `flag:"--pgdata"`
 }
@@ -155,3 +169,17 @@ func (cmd *PgControlData) BuildExecCommand(gphome string) *exec.Cmd {
 
 	return utils.System.ExecCommand(utility, args...)
 }
+
+type PgRewind struct {
+	TargetDir         string `flag:"--target-pgdata"`
+	SourceServer      string `flag:"--source-server"`
+	WriteRecoveryConf bool   `flag:"--write-recovery-conf"`
+	Progress          bool   `flag:"--progress"`
+}
+
+func (cmd *PgRewind) BuildExecCommand(gphome string) *exec.Cmd {
+	utility := utils.GetGpUtilityPath(gphome, pgRewindUtility)
+	args := utils.GenerateArgs(cmd)
+
+	return utils.System.ExecCommand(utility, args...)
+}
@@ -77,6 +77,14 @@ func TestPgCommand(t *testing.T) {
 			},
 			expected: `gpHome/bin/pg_ctl reload --pgdata pgdata`,
 		},
+		{
+			pgCmdOptions: &postgres.PgCtlPromote{
+				PgData:  "pgdata",
+				Timeout: 600,
+				Wait:    true,
+			},
+			expected: `gpHome/bin/pg_ctl promote --pgdata pgdata --timeout 600 --wait`,
+		},
 		{
 			pgCmdOptions: &postgres.Postgres{
 				GpVersion: true,
@@ -114,6 +122,15 @@ func TestPgCommand(t *testing.T) {
 			},
 			expected: `gpHome/bin/pg_controldata --pgdata pgdata`,
 		},
+		{
+			pgCmdOptions: &postgres.PgRewind{
+				TargetDir:         "pgdata",
+				SourceServer:      "host=sdw1 port=1234 dbname=template1",
+				WriteRecoveryConf: true,
+				Progress:          true,
+			},
+			expected: `gpHome/bin/pg_rewind --target-pgdata pgdata --source-server host=sdw1 port=1234 dbname=template1 --write-recovery-conf --progress`,
+		},
 	}
 
 	for _, tc := range cases {
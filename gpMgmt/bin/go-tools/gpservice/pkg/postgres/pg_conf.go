@@ -16,6 +16,7 @@ import (
 const (
 	postgresqlConfFile       = "postgresql.conf"
 	postgresInternalConfFile = "internal.auto.conf"
+	postgresqlAutoConfFile   = "postgresql.auto.conf"
 )
 
 // UpdatePostgresqlConf updates given config params to postgresql.conf file
@@ -161,3 +162,39 @@ func GetConfigValue(pgdata, config string) (string, error) {
 
 	return strings.Trim(value, "'"), nil
 }
+
+// GetPrimaryConnInfo returns the keywords of the primary_conninfo written to the
+// postgresql.auto.conf of a mirror or standby by pg_basebackup, such as the host and
+// the port of the server it replicates from
+func GetPrimaryConnInfo(pgdata string) (map[string]string, error) {
+	autoConfFilePath := filepath.Join(pgdata, postgresqlAutoConfFile)
+
+	file, err := utils.System.Open(autoConfFilePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var connInfo string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		key, value, found := strings.Cut(strings.TrimSpace(scanner.Text()), "=")
+		if found && strings.TrimSpace(key) == "primary_conninfo" {
+			connInfo = strings.Trim(strings.TrimSpace(value), "'")
+		}
+	}
+
+	if connInfo == "" {
+		return nil, fmt.Errorf("did not find primary_conninfo in %s", autoConfFilePath)
+	}
+
+	keywords := make(map[string]string)
+	for _, field := range strings.Fields(connInfo) {
+		key, value, found := strings.Cut(field, "=")
+		if found {
+			keywords[key] = strings.Trim(value, "'")
+		}
+	}
+
+	return keywords, nil
+}
@@ -2,9 +2,11 @@ package postgres_test
 
 import (
 	"errors"
+	"fmt"
 	"io/fs"
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
 
 	"github.com/greenplum-db/gpdb/gpservice/testutils"
@@ -291,6 +293,45 @@ guc4         =   value4
 	})
 }
 
+func TestGetPrimaryConnInfo(t *testing.T) {
+	t.Run("returns the keywords of the primary_conninfo", func(t *testing.T) {
+		content := `# Do not edit this file manually!
+primary_conninfo = 'user=gpadmin passfile=''/home/gpadmin/.pgpass'' host=cdw port=7000 sslmode=prefer application_name=gp_walreceiver'
+primary_slot_name = 'internal_wal_replication_slot'
+`
+		dname, _ := createTempConfFile(t, "postgresql.auto.conf", content, 0644)
+		defer os.RemoveAll(dname)
+
+		result, err := postgres.GetPrimaryConnInfo(dname)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := map[string]string{
+			"user":             "gpadmin",
+			"passfile":         "/home/gpadmin/.pgpass",
+			"host":             "cdw",
+			"port":             "7000",
+			"sslmode":          "prefer",
+			"application_name": "gp_walreceiver",
+		}
+		if !reflect.DeepEqual(result, expected) {
+			t.Fatalf("got %v, want %v", result, expected)
+		}
+	})
+
+	t.Run("returns error when there is no primary_conninfo", func(t *testing.T) {
+		dname, _ := createTempConfFile(t, "postgresql.auto.conf", "# Do not edit this file manually!\n", 0644)
+		defer os.RemoveAll(dname)
+
+		_, err := postgres.GetPrimaryConnInfo(dname)
+		expectedErrString := fmt.Sprintf("did not find primary_conninfo in %s/postgresql.auto.conf", dname)
+		if err == nil || err.Error() != expectedErrString {
+			t.Fatalf("got %v, want %s", err, expectedErrString)
+		}
+	})
+}
+
 func createTempConfFile(t *testing.T, filename, content string, perm fs.FileMode) (string, string) {
 	t.Helper()
 
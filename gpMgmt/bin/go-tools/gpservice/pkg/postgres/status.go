@@ -0,0 +1,69 @@
+package postgres
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+)
+
+const clusterStateKey = "Database cluster state"
+
+var postmasterPidRegex = regexp.MustCompile(`\(PID: (\d+)\)`)
+
+// GetPostmasterStatus reports whether a postmaster is running for the given
+// data directory along with its PID, as reported by pg_ctl status.
+// A non-zero exit from pg_ctl is treated as the server not running.
+func GetPostmasterStatus(gpHome, pgdata string) (bool, int, error) {
+	pgCtlStatusCmd := &PgCtlStatus{
+		PgData: pgdata,
+	}
+	out, err := utils.RunGpCommand(pgCtlStatusCmd, gpHome)
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return false, 0, nil
+		}
+
+		return false, 0, fmt.Errorf("executing pg_ctl status: %s, %w", out, err)
+	}
+
+	match := postmasterPidRegex.FindStringSubmatch(out.String())
+	if match == nil {
+		return true, 0, fmt.Errorf("unable to find the postmaster PID in pg_ctl status output: %s", out)
+	}
+
+	pid, err := strconv.Atoi(match[1])
+	if err != nil {
+		return true, 0, fmt.Errorf("parsing postmaster PID: %w", err)
+	}
+
+	return true, pid, nil
+}
+
+// GetClusterState returns the database cluster state of the
+// given data directory as reported by pg_controldata
+func GetClusterState(gpHome, pgdata string) (string, error) {
+	pgControlDataCmd := &PgControlData{
+		PgData: pgdata,
+	}
+	out, err := utils.RunGpCommand(pgControlDataCmd, gpHome)
+	if err != nil {
+		return "", fmt.Errorf("executing pg_controldata: %s, %w", out, err)
+	}
+
+	scanner := bufio.NewScanner(out)
+	for scanner.Scan() {
+		key, value, found := strings.Cut(scanner.Text(), ":")
+		if found && strings.TrimSpace(key) == clusterStateKey {
+			return strings.TrimSpace(value), nil
+		}
+	}
+
+	return "", fmt.Errorf("unable to find %q in pg_controldata output", clusterStateKey)
+}
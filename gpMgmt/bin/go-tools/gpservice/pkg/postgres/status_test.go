@@ -0,0 +1,116 @@
+package postgres_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/postgres"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+	"github.com/greenplum-db/gpdb/gpservice/testutils/exectest"
+)
+
+func init() {
+	exectest.RegisterMains(
+		PgCtlStatusRunning,
+		PgCtlStatusNoPid,
+		PgControlDataOutput,
+	)
+}
+
+func PgCtlStatusRunning() {
+	os.Stdout.WriteString("pg_ctl: server is running (PID: 1234)\n/usr/local/gpdb/bin/postgres \"-D\" \"/data/gpseg0\"\n")
+}
+
+func PgCtlStatusNoPid() {
+	os.Stdout.WriteString("pg_ctl: server is running\n")
+}
+
+func PgControlDataOutput() {
+	os.Stdout.WriteString("pg_control version number:            12010700\nDatabase cluster state:               in production\npg_control last modified:             Thu 01 Jan 2024\n")
+}
+
+func TestGetPostmasterStatus(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	t.Run("returns the PID when the server is running", func(t *testing.T) {
+		utils.System.ExecCommand = exectest.NewCommand(PgCtlStatusRunning)
+		defer utils.ResetSystemFunctions()
+
+		running, pid, err := postgres.GetPostmasterStatus("gpHome", "/data/gpseg0")
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		if !running || pid != 1234 {
+			t.Fatalf("got running %t and pid %d, want running true and pid 1234", running, pid)
+		}
+	})
+
+	t.Run("returns not running when pg_ctl status fails", func(t *testing.T) {
+		utils.System.ExecCommand = exectest.NewCommand(exectest.Failure)
+		defer utils.ResetSystemFunctions()
+
+		running, pid, err := postgres.GetPostmasterStatus("gpHome", "/data/gpseg0")
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		if running || pid != 0 {
+			t.Fatalf("got running %t and pid %d, want running false and pid 0", running, pid)
+		}
+	})
+
+	t.Run("errors out when the PID is not present in the output", func(t *testing.T) {
+		utils.System.ExecCommand = exectest.NewCommand(PgCtlStatusNoPid)
+		defer utils.ResetSystemFunctions()
+
+		_, _, err := postgres.GetPostmasterStatus("gpHome", "/data/gpseg0")
+		expected := "unable to find the postmaster PID in pg_ctl status output"
+		if err == nil || !strings.HasPrefix(err.Error(), expected) {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+}
+
+func TestGetClusterState(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	t.Run("returns the database cluster state", func(t *testing.T) {
+		utils.System.ExecCommand = exectest.NewCommand(PgControlDataOutput)
+		defer utils.ResetSystemFunctions()
+
+		state, err := postgres.GetClusterState("gpHome", "/data/gpseg0")
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		expected := "in production"
+		if state != expected {
+			t.Fatalf("got %q, want %q", state, expected)
+		}
+	})
+
+	t.Run("errors out when the cluster state is not present in the output", func(t *testing.T) {
+		utils.System.ExecCommand = exectest.NewCommand(exectest.Success)
+		defer utils.ResetSystemFunctions()
+
+		_, err := postgres.GetClusterState("gpHome", "/data/gpseg0")
+		expected := `unable to find "Database cluster state" in pg_controldata output`
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+
+	t.Run("errors out when pg_controldata fails", func(t *testing.T) {
+		utils.System.ExecCommand = exectest.NewCommand(exectest.Failure)
+		defer utils.ResetSystemFunctions()
+
+		_, err := postgres.GetClusterState("gpHome", "/data/gpseg0")
+		expected := "executing pg_controldata:"
+		if err == nil || !strings.HasPrefix(err.Error(), expected) {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+}
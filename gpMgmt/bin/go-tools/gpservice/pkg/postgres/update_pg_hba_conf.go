@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"fmt"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/greenplum-db/gp-common-go-libs/gplog"
@@ -79,6 +80,41 @@ func UpdateSegmentPgHbaConf(pgdata string, addrs []string, replication bool, coo
 	return nil
 }
 
+// RemoveSegmentPgHbaConfEntries removes the entries of the current user for the given
+// addresses, both the regular and the replication ones, from the pg_hba.conf file
+func RemoveSegmentPgHbaConfEntries(pgdata string, addrs []string) error {
+	gplog.Info("Starting to remove entries from %s for data directory %s", pgHbaConfFile, pgdata)
+	pgHbaConfFilePath := filepath.Join(pgdata, pgHbaConfFile)
+
+	user, err := utils.System.CurrentUser()
+	if err != nil {
+		return err
+	}
+
+	content, err := utils.System.ReadFile(pgHbaConfFilePath)
+	if err != nil {
+		return err
+	}
+
+	var lines []string
+	for _, line := range strings.Split(string(content), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) >= 4 && fields[0] == "host" && fields[2] == user.Username && slices.Contains(addrs, fields[3]) {
+			continue
+		}
+
+		lines = append(lines, line)
+	}
+
+	err = utils.WriteLinesToFile(pgHbaConfFilePath, lines)
+	if err != nil {
+		return err
+	}
+
+	gplog.Info("Successfully removed entries from %s for data directory %s", pgHbaConfFile, pgdata)
+	return nil
+}
+
 func appendPgHbaEntries(pgdata string, entries []string) error {
 	pgHbaConfFilePath := filepath.Join(pgdata, pgHbaConfFile)
 
@@ -210,3 +210,46 @@ host	all	gpadmin	sdw	trust`,
 		})
 	}
 }
+
+func TestRemoveSegmentPgHbaConfEntries(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	t.Run("removes the entries of the current user for the addresses", func(t *testing.T) {
+		confContent := `# foo
+host	all	all	cdw	trust
+host	all	gpadmin	cdw	trust
+host	all	gpadmin	scdw	trust
+host	replication	gpadmin	samehost	trust
+host	replication	gpadmin	cdw	trust
+host    replication    gpadmin    scdw    trust`
+		dname, confPath := createTempConfFile(t, "pg_hba.conf", confContent, 0644)
+		defer os.RemoveAll(dname)
+
+		utils.System.CurrentUser = func() (*user.User, error) {
+			return &user.User{Username: "gpadmin"}, nil
+		}
+		defer utils.ResetSystemFunctions()
+
+		err := postgres.RemoveSegmentPgHbaConfEntries(dname, []string{"scdw"})
+		if err != nil {
+			t.Fatalf("unexpected error: %#v", err)
+		}
+
+		expected := `# foo
+host	all	all	cdw	trust
+host	all	gpadmin	cdw	trust
+host	replication	gpadmin	samehost	trust
+host	replication	gpadmin	cdw	trust`
+		testutils.AssertFileContents(t, confPath, expected)
+	})
+
+	t.Run("errors out when there is no file present", func(t *testing.T) {
+		dname, _ := createTempConfFile(t, "", "", 0644)
+		defer os.RemoveAll(dname)
+
+		err := postgres.RemoveSegmentPgHbaConfEntries(dname, []string{"scdw"})
+		if !errors.Is(err, os.ErrNotExist) {
+			t.Fatalf("got %#v, want %#v", err, os.ErrNotExist)
+		}
+	})
+}
@@ -0,0 +1,437 @@
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	protov1 "github.com/golang/protobuf/proto"
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// AuditLogFile is the name of the audit log in the log directory of the hub and agents
+const AuditLogFile = "gpservice_audit.log"
+
+const (
+	AuditOutcomeSuccess = "success"
+	AuditOutcomeFailure = "failure"
+	AuditOutcomeDenied  = "denied"
+)
+
+const (
+	AuditEventStarted  = "started"
+	AuditEventFinished = "finished"
+)
+
+// redacted replaces the values of the request fields which hold secrets
+const redacted = "REDACTED"
+
+var sensitiveFieldPattern = regexp.MustCompile(`(?i)password|passwd|secret|token`)
+
+/*
+AuditRecord is an entry written to the audit log for a call of a gRPC method. A record is
+written when the call starts and another when it finishes, both with the ID of the call, so
+that the calls which never finish, for instance as the service was killed, are recorded too.
+Only the finished record has the end time and the outcome of the call.
+*/
+type AuditRecord struct {
+	ID         string          `json:"id,omitempty"`
+	Event      string          `json:"event,omitempty"`
+	Start      time.Time       `json:"start"`
+	End        *time.Time      `json:"end,omitempty"`
+	Host       string          `json:"host"`
+	Service    string          `json:"service"`
+	Caller     string          `json:"caller"`
+	AuthMethod string          `json:"authMethod,omitempty"`
+	Method     string          `json:"method"`
+	Request    json.RawMessage `json:"request,omitempty"`
+	Outcome    string          `json:"outcome,omitempty"`
+	Code       string          `json:"code,omitempty"`
+	Error      string          `json:"error,omitempty"`
+}
+
+/*
+AuditLogger appends a structured record of every call of a gRPC method to the audit log,
+one JSON object per line. The log is only ever appended to, and can be shared by the hub
+and the agent running on the same host.
+*/
+type AuditLogger struct {
+	service string
+	host    string
+
+	mutex sync.Mutex
+	out   io.Writer
+}
+
+// OpenAuditLog opens the audit log in the given directory for appending, creating it if needed
+func OpenAuditLog(logDir, service string) (*AuditLogger, error) {
+	path := filepath.Join(logDir, AuditLogFile)
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("could not open audit log %s: %w", path, err)
+	}
+
+	return NewAuditLogger(file, service), nil
+}
+
+func NewAuditLogger(out io.Writer, service string) *AuditLogger {
+	host, _ := System.GetHostName()
+	return &AuditLogger{service: service, host: host, out: out}
+}
+
+// Close closes the audit log, if it was opened from a file
+func (a *AuditLogger) Close() error {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	if closer, ok := a.out.(io.Closer); ok {
+		return closer.Close()
+	}
+
+	return nil
+}
+
+// Write appends the record to the audit log as a single line
+func (a *AuditLogger) Write(record AuditRecord) {
+	record.Host = a.host
+	record.Service = a.service
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		gplog.Warn("Could not write the audit record of %s: %v", record.Method, err)
+		return
+	}
+
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	_, err = a.out.Write(append(line, '\n'))
+	if err != nil {
+		gplog.Warn("Could not write the audit record of %s: %v", record.Method, err)
+	}
+}
+
+// UnaryInterceptor records the calls of unary methods, other than health checks. It is to be chained
+// before the auth interceptors, so that the calls they reject are recorded along with the caller.
+func (a *AuditLogger) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if slices.Contains(publicMethods, info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		ctx, caller := withCallerHolder(ctx)
+		record := AuditRecord{ID: newAuditID(), Start: time.Now(), Method: info.FullMethod, Request: SummarizeRequest(req)}
+		a.start(ctx, record, caller)
+
+		resp, err := handler(ctx, req)
+		a.finish(ctx, &record, caller, err)
+
+		return resp, err
+	}
+}
+
+// StreamInterceptor records the calls of streaming methods, along with the first request received.
+// The call is recorded as started once that request is received.
+func (a *AuditLogger) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if slices.Contains(publicMethods, info.FullMethod) {
+			return handler(srv, stream)
+		}
+
+		ctx, caller := withCallerHolder(stream.Context())
+		record := AuditRecord{ID: newAuditID(), Start: time.Now(), Method: info.FullMethod}
+		audited := &auditedStream{ServerStream: stream, ctx: ctx, received: func(request json.RawMessage) {
+			record.Request = request
+			a.start(ctx, record, caller)
+		}}
+
+		err := handler(srv, audited)
+		a.finish(ctx, &record, caller, err)
+
+		return err
+	}
+}
+
+func (a *AuditLogger) start(ctx context.Context, record AuditRecord, caller *callerHolder) {
+	record.Event = AuditEventStarted
+	record.Caller, record.AuthMethod = callerName(ctx, caller)
+
+	a.Write(record)
+}
+
+func (a *AuditLogger) finish(ctx context.Context, record *AuditRecord, caller *callerHolder, err error) {
+	end := time.Now()
+	record.End = &end
+	record.Event = AuditEventFinished
+	record.Caller, record.AuthMethod = callerName(ctx, caller)
+
+	code := status.Code(err)
+	record.Code = code.String()
+	switch code {
+	case codes.OK:
+		record.Outcome = AuditOutcomeSuccess
+	case codes.PermissionDenied, codes.Unauthenticated:
+		record.Outcome = AuditOutcomeDenied
+	default:
+		record.Outcome = AuditOutcomeFailure
+	}
+
+	if err != nil {
+		record.Error = status.Convert(err).Message()
+	}
+
+	a.Write(*record)
+}
+
+// auditedStream passes a summary of the first request received on the stream to received
+type auditedStream struct {
+	grpc.ServerStream
+	ctx      context.Context
+	received func(request json.RawMessage)
+}
+
+func (s *auditedStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *auditedStream) RecvMsg(m interface{}) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err == nil && s.received != nil {
+		s.received(SummarizeRequest(m))
+		s.received = nil
+	}
+
+	return err
+}
+
+func newAuditID() string {
+	// The ID only needs to tell apart the calls recorded in the same audit log
+	id := make([]byte, 8)
+	rand.Read(id) // nolint
+
+	return hex.EncodeToString(id)
+}
+
+// callerHolder is filled in by the auth interceptors with the identity of the caller
+type callerHolder struct {
+	identity *Identity
+}
+
+type callerHolderKey struct{}
+
+func withCallerHolder(ctx context.Context) (context.Context, *callerHolder) {
+	holder := &callerHolder{}
+	return context.WithValue(ctx, callerHolderKey{}, holder), holder
+}
+
+func recordCaller(ctx context.Context, identity *Identity) {
+	if holder, ok := ctx.Value(callerHolderKey{}).(*callerHolder); ok {
+		holder.identity = identity
+	}
+}
+
+// callerName returns the name of the caller and how it was authenticated, if it was
+func callerName(ctx context.Context, caller *callerHolder) (string, string) {
+	if caller.identity != nil {
+		return caller.identity.Name, caller.identity.Method
+	}
+
+	return peerName(ctx), ""
+}
+
+// peerName identifies the caller when authentication is disabled, by the common name of its
+// client certificate or otherwise by its address
+func peerName(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return "unknown"
+	}
+
+	if tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo); ok && len(tlsInfo.State.VerifiedChains) > 0 && len(tlsInfo.State.VerifiedChains[0]) > 0 {
+		return tlsInfo.State.VerifiedChains[0][0].Subject.CommonName
+	}
+
+	if p.Addr != nil {
+		return p.Addr.String()
+	}
+
+	return "unknown"
+}
+
+/*
+SummarizeRequest returns the request as JSON, with the values of the fields which hold
+secrets such as passwords redacted. The contents of files are left out, as they can hold
+secrets too and would make the log grow with the size of the files transferred.
+*/
+func SummarizeRequest(req interface{}) json.RawMessage {
+	msg, ok := req.(protov1.Message)
+	if !ok || msg == nil {
+		return nil
+	}
+
+	clone := protov1.MessageV2(protov1.Clone(msg))
+	redactMessage(clone.ProtoReflect())
+
+	contents, err := protojson.Marshal(clone)
+	if err != nil {
+		return nil
+	}
+
+	// protojson randomly adds whitespace to its output, which is compacted to keep one record per line
+	var summary bytes.Buffer
+	err = json.Compact(&summary, contents)
+	if err != nil || summary.String() == "{}" {
+		return nil
+	}
+
+	return summary.Bytes()
+}
+
+func redactMessage(m protoreflect.Message) {
+	var fields []protoreflect.FieldDescriptor
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		fields = append(fields, fd)
+		return true
+	})
+
+	for _, fd := range fields {
+		value := m.Get(fd)
+
+		switch {
+		case fd.IsMap():
+			redactMap(fd, value.Map())
+		case fd.Kind() == protoreflect.BytesKind:
+			m.Clear(fd)
+		case fd.Kind() == protoreflect.StringKind && sensitiveFieldPattern.MatchString(string(fd.Name())):
+			redactString(m, fd, value)
+		case fd.Kind() == protoreflect.MessageKind || fd.Kind() == protoreflect.GroupKind:
+			if fd.IsList() {
+				for i := 0; i < value.List().Len(); i++ {
+					redactMessage(value.List().Get(i).Message())
+				}
+			} else {
+				redactMessage(value.Message())
+			}
+		}
+	}
+}
+
+// redactMap redacts the values of maps holding messages, and the values of string maps which
+// are either secret as a whole or whose keys suggest so, such as server configuration parameters
+func redactMap(fd protoreflect.FieldDescriptor, m protoreflect.Map) {
+	m.Range(func(key protoreflect.MapKey, v protoreflect.Value) bool {
+		switch fd.MapValue().Kind() {
+		case protoreflect.MessageKind, protoreflect.GroupKind:
+			redactMessage(v.Message())
+		case protoreflect.StringKind:
+			if sensitiveFieldPattern.MatchString(string(fd.Name())) || sensitiveFieldPattern.MatchString(key.String()) {
+				m.Set(key, protoreflect.ValueOfString(redacted))
+			}
+		}
+		return true
+	})
+}
+
+func redactString(m protoreflect.Message, fd protoreflect.FieldDescriptor, value protoreflect.Value) {
+	if fd.IsList() {
+		for i := 0; i < value.List().Len(); i++ {
+			value.List().Set(i, protoreflect.ValueOfString(redacted))
+		}
+		return
+	}
+
+	m.Set(fd, protoreflect.ValueOfString(redacted))
+}
+
+// AuditFilter selects the audit records to return, where the zero value of each field matches all the records
+type AuditFilter struct {
+	Since   time.Time
+	Until   time.Time
+	Methods []string
+}
+
+// Matches reports whether the record started within the time range and is of one of the
+// methods, which are given either by their full name or by their name alone
+func (f AuditFilter) Matches(record AuditRecord) bool {
+	if !f.Since.IsZero() && record.Start.Before(f.Since) {
+		return false
+	}
+
+	if !f.Until.IsZero() && record.Start.After(f.Until) {
+		return false
+	}
+
+	if len(f.Methods) == 0 {
+		return true
+	}
+
+	name := record.Method[strings.LastIndex(record.Method, "/")+1:]
+	for _, method := range f.Methods {
+		if strings.EqualFold(method, record.Method) || strings.EqualFold(method, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ReadAuditLog returns the records of the audit log which match the filter. The calls which have
+// finished are only returned once, by their finished record in place of their started one.
+func ReadAuditLog(r io.Reader, filter AuditFilter) ([]AuditRecord, error) {
+	var records []AuditRecord
+	started := map[string]int{}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+
+		var record AuditRecord
+		err := json.Unmarshal(scanner.Bytes(), &record)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse line %d of the audit log: %w", line, err)
+		}
+
+		if !filter.Matches(record) {
+			continue
+		}
+
+		if i, ok := started[record.ID]; ok && record.Event == AuditEventFinished {
+			records[i] = record
+			delete(started, record.ID)
+			continue
+		}
+
+		if record.Event == AuditEventStarted {
+			started[record.ID] = len(records)
+		}
+		records = append(records, record)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("could not read the audit log: %w", err)
+	}
+
+	return records, nil
+}
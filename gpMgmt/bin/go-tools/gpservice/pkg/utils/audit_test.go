@@ -0,0 +1,225 @@
+package utils_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/greenplum-db/gpdb/gpservice/idl"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+)
+
+func TestSummarizeRequest(t *testing.T) {
+	t.Run("redacts the secrets in the request", func(t *testing.T) {
+		req := &idl.MakeClusterRequest{
+			ClusterParams: &idl.ClusterParams{
+				SuPassword:        "changeme",
+				Encoding:          "UTF-8",
+				CoordinatorConfig: map[string]string{"ldap_password": "secret", "max_connections": "100"},
+			},
+		}
+
+		summary := string(utils.SummarizeRequest(req))
+		expected := `{"clusterParams":{"CoordinatorConfig":{"ldap_password":"REDACTED","max_connections":"100"},"encoding":"UTF-8","suPassword":"REDACTED"}}`
+		if summary != expected {
+			t.Fatalf("got %s, want %s", summary, expected)
+		}
+
+		if req.ClusterParams.SuPassword != "changeme" {
+			t.Fatalf("expected the request to be left untouched, got %s", req.ClusterParams.SuPassword)
+		}
+	})
+
+	t.Run("leaves out the contents of files", func(t *testing.T) {
+		req := &idl.PushFileRequest{Hostnames: []string{"sdw1"}, Path: "/tmp/file", Mode: 0600, Contents: []byte("contents")}
+
+		summary := string(utils.SummarizeRequest(req))
+		expected := `{"hostnames":["sdw1"],"path":"/tmp/file","mode":384}`
+		if summary != expected {
+			t.Fatalf("got %s, want %s", summary, expected)
+		}
+	})
+
+	t.Run("returns no summary for empty requests", func(t *testing.T) {
+		summary := utils.SummarizeRequest(&idl.StopHubRequest{})
+		if summary != nil {
+			t.Fatalf("got %s, want no summary", summary)
+		}
+	})
+}
+
+func TestAuditLogger(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	utils.System.GetHostName = func() (string, error) {
+		return "cdw", nil
+	}
+	defer utils.ResetSystemFunctions()
+
+	t.Run("records the caller, request and outcome of unary calls", func(t *testing.T) {
+		var out bytes.Buffer
+		auditLog := utils.NewAuditLogger(&out, "hub")
+		authorizer := utils.NewAuthorizer(&utils.AuthConfig{Enabled: true, Users: map[string]utils.Role{"alice": utils.RoleAdmin, "bob": utils.RoleReadOnly}}, nil)
+		interceptor := chainUnary(auditLog.UnaryInterceptor(), authorizer.UnaryInterceptor())
+
+		handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, nil
+		}
+		failingHandler := func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, errors.New("error")
+		}
+
+		req := &idl.CleanInitClusterRequest{}
+		interceptor(certificateContext("alice"), req, &grpc.UnaryServerInfo{FullMethod: "/idl.Hub/CleanInitCluster"}, handler)
+		interceptor(certificateContext("alice"), req, &grpc.UnaryServerInfo{FullMethod: "/idl.Hub/StopAgents"}, failingHandler)
+		interceptor(certificateContext("bob"), req, &grpc.UnaryServerInfo{FullMethod: "/idl.Hub/CleanInitCluster"}, handler)
+		interceptor(context.Background(), req, &grpc.UnaryServerInfo{FullMethod: "/grpc.health.v1.Health/Check"}, handler)
+
+		if lines := strings.Count(out.String(), "\n"); lines != 6 {
+			t.Fatalf("got %d lines, want a started and a finished record for each call", lines)
+		}
+
+		records, err := utils.ReadAuditLog(&out, utils.AuditFilter{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(records) != 3 {
+			t.Fatalf("got %d records, want 3 without the health check", len(records))
+		}
+
+		expected := []struct{ caller, method, outcome, code, err string }{
+			{"alice", "/idl.Hub/CleanInitCluster", utils.AuditOutcomeSuccess, "OK", ""},
+			{"alice", "/idl.Hub/StopAgents", utils.AuditOutcomeFailure, "Unknown", "error"},
+			{"bob", "/idl.Hub/CleanInitCluster", utils.AuditOutcomeDenied, "PermissionDenied", "bob is not allowed to call /idl.Hub/CleanInitCluster, which requires the admin role"},
+		}
+		for i, e := range expected {
+			r := records[i]
+			if r.Caller != e.caller || r.Method != e.method || r.Outcome != e.outcome || r.Code != e.code || r.Error != e.err {
+				t.Fatalf("got %+v, want %+v", r, e)
+			}
+
+			if r.Host != "cdw" || r.Service != "hub" || r.AuthMethod != "certificate" || r.Event != utils.AuditEventFinished || r.End.Before(r.Start) {
+				t.Fatalf("got %+v, want the host, service and times to be recorded", r)
+			}
+		}
+	})
+
+	t.Run("records the first request of streaming calls", func(t *testing.T) {
+		var out bytes.Buffer
+		auditLog := utils.NewAuditLogger(&out, "agent")
+
+		stream := &recvServerStream{
+			mockServerStream: mockServerStream{ctx: certificateContext("cdw")},
+			request:          &idl.MakeClusterRequest{ClusterParams: &idl.ClusterParams{SuPassword: "changeme"}},
+		}
+		expected := `{"clusterParams":{"suPassword":"REDACTED"}}`
+		handler := func(srv interface{}, stream grpc.ServerStream) error {
+			req := &idl.MakeClusterRequest{}
+			err := stream.RecvMsg(req)
+			if err != nil {
+				return err
+			}
+
+			var started utils.AuditRecord
+			err = json.Unmarshal(out.Bytes(), &started)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if started.Event != utils.AuditEventStarted || started.Caller != "cdw" || started.End != nil || string(started.Request) != expected {
+				t.Fatalf("got %+v, want the call to be recorded as started with the request %s", started, expected)
+			}
+
+			return status.Error(codes.Internal, "failed")
+		}
+
+		err := auditLog.StreamInterceptor()(nil, stream, &grpc.StreamServerInfo{FullMethod: "/idl.Hub/MakeCluster"}, handler)
+		if status.Code(err) != codes.Internal {
+			t.Fatalf("got %v, want the error of the handler", err)
+		}
+
+		records, err := utils.ReadAuditLog(&out, utils.AuditFilter{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(records) != 1 {
+			t.Fatalf("got %d records, want the started record to be replaced by the finished one", len(records))
+		}
+
+		record := records[0]
+		if record.Event != utils.AuditEventFinished || record.Caller != "cdw" || record.Outcome != utils.AuditOutcomeFailure || string(record.Request) != expected {
+			t.Fatalf("got %+v, want the request %s to be recorded", record, expected)
+		}
+	})
+}
+
+func TestReadAuditLog(t *testing.T) {
+	start := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	var log strings.Builder
+	for i, method := range []string{"/idl.Hub/CleanInitCluster", "/idl.Agent/RemoveDirectory", "/idl.Hub/CleanInitCluster"} {
+		line, _ := json.Marshal(utils.AuditRecord{Start: start.Add(time.Duration(i) * time.Hour), Method: method})
+		log.Write(append(line, '\n'))
+	}
+
+	cases := []struct {
+		name     string
+		filter   utils.AuditFilter
+		expected int
+	}{
+		{"all the records", utils.AuditFilter{}, 3},
+		{"by method name", utils.AuditFilter{Methods: []string{"cleaninitcluster"}}, 2},
+		{"by full method name", utils.AuditFilter{Methods: []string{"/idl.Agent/RemoveDirectory"}}, 1},
+		{"by time range", utils.AuditFilter{Since: start.Add(30 * time.Minute), Until: start.Add(90 * time.Minute)}, 1},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			records, err := utils.ReadAuditLog(strings.NewReader(log.String()), tc.filter)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(records) != tc.expected {
+				t.Fatalf("got %d records, want %d", len(records), tc.expected)
+			}
+		})
+	}
+
+	t.Run("errors out on an invalid line", func(t *testing.T) {
+		_, err := utils.ReadAuditLog(strings.NewReader(log.String()+"invalid\n"), utils.AuditFilter{})
+		expected := "could not parse line 4 of the audit log"
+		if err == nil || !strings.HasPrefix(err.Error(), expected) {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+}
+
+func chainUnary(outer, inner grpc.UnaryServerInterceptor) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		return outer(ctx, req, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return inner(ctx, req, info, handler)
+		})
+	}
+}
+
+// recvServerStream receives the given request
+type recvServerStream struct {
+	mockServerStream
+	request *idl.MakeClusterRequest
+}
+
+func (s *recvServerStream) RecvMsg(m interface{}) error {
+	*m.(*idl.MakeClusterRequest) = *s.request
+	return nil
+}
@@ -0,0 +1,346 @@
+package utils
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// AuthTokenEnv is the environment variable holding the bearer token sent to the hub, if any
+const AuthTokenEnv = "GPSERVICE_AUTH_TOKEN"
+
+type Role string
+
+const (
+	RoleReadOnly Role = "read-only"
+	RoleAdmin    Role = "admin"
+)
+
+func ParseRole(role string) (Role, error) {
+	switch Role(role) {
+	case RoleReadOnly, RoleAdmin:
+		return Role(role), nil
+	}
+
+	return "", fmt.Errorf("unsupported role %q, supported roles are %s and %s", role, RoleReadOnly, RoleAdmin)
+}
+
+// Allows reports whether the role is allowed to call methods which require the given role
+func (r Role) Allows(required Role) bool {
+	return r == RoleAdmin || r == required
+}
+
+// readOnlyMethods only report the state of the services and the cluster, and can be called
+// by any authenticated caller. All the other methods require the admin role.
+var readOnlyMethods = []string{
+	"/idl.Hub/StatusAgents",
+	"/idl.Hub/ReportAgentHealth",
+	"/idl.Hub/GetAllHostNames",
+	"/idl.Hub/ClusterStatus",
+	"/idl.Hub/ListOperations",
+	"/idl.Hub/AttachOperation",
+	"/idl.Agent/Status",
+	"/idl.Agent/GetSegmentStatus",
+	"/idl.Agent/GetInterfaceAddrs",
+	"/idl.Agent/GetHostName",
+}
+
+// publicMethods can be called without authentication, so that the services can be health checked
+var publicMethods = []string{
+	"/grpc.health.v1.Health/Check",
+	"/grpc.health.v1.Health/Watch",
+}
+
+// RequiredRole returns the role needed to call the given gRPC method
+func RequiredRole(fullMethod string) Role {
+	if slices.Contains(readOnlyMethods, fullMethod) {
+		return RoleReadOnly
+	}
+
+	return RoleAdmin
+}
+
+/*
+AuthConfig configures the authentication and authorization of callers of the hub and
+agents. Callers are identified by the common name of their client certificate, or by
+a bearer token when one is sent. The hosts of the configuration are always admins,
+since the hub and the agents call each other using the certificates of their host.
+*/
+type AuthConfig struct {
+	Enabled bool `json:"enabled"`
+
+	// Users maps the common names of client certificates to their roles
+	Users map[string]Role `json:"users,omitempty"`
+
+	// Tokens are the bearer tokens which are accepted, of which only a hash is stored
+	Tokens []AuthToken `json:"tokens,omitempty"`
+}
+
+type AuthToken struct {
+	Name string `json:"name"`
+	Hash string `json:"sha256"`
+	Role Role   `json:"role"`
+}
+
+// Validate checks that the callers can be authenticated with the given transport credentials,
+// as neither client certificates nor bearer tokens can be used without TLS
+func (conf *AuthConfig) Validate(creds Credentials) error {
+	if conf == nil || !conf.Enabled {
+		return nil
+	}
+
+	if len(CredentialPaths(creds)) == 0 {
+		return fmt.Errorf("authentication requires TLS to be enabled")
+	}
+
+	return nil
+}
+
+// tokenCredentials are the credentials which can be used without a client certificate, by the
+// callers authenticated by a bearer token
+type tokenCredentials interface {
+	LoadTokenServerCredentials() (credentials.TransportCredentials, error)
+	LoadTokenClientCredentials() (credentials.TransportCredentials, error)
+}
+
+/*
+ServerCredentials returns the function loading the server credentials of the services. When
+tokens are accepted, callers are not required to present a client certificate. They would
+otherwise need the certificate of a host, which is always an admin, so that the role of their
+token would restrict nothing.
+*/
+func (conf *AuthConfig) ServerCredentials(creds Credentials) func() (credentials.TransportCredentials, error) {
+	if tokenCreds, ok := creds.(tokenCredentials); ok && conf != nil && conf.Enabled && len(conf.Tokens) > 0 {
+		return tokenCreds.LoadTokenServerCredentials
+	}
+
+	return creds.LoadServerCredentials
+}
+
+// LoadCallerCredentials returns the client credentials of a caller. A caller sending a bearer
+// token does not present a client certificate, so that it is only identified by the token.
+func LoadCallerCredentials(creds Credentials, withToken bool) (credentials.TransportCredentials, error) {
+	if tokenCreds, ok := creds.(tokenCredentials); ok && withToken {
+		return tokenCreds.LoadTokenClientCredentials()
+	}
+
+	return creds.LoadClientCredentials()
+}
+
+// NewAuthToken returns a random bearer token, and the entry to store for it in the configuration
+func NewAuthToken(name string, role Role) (string, AuthToken, error) {
+	data := make([]byte, 32)
+	_, err := rand.Read(data)
+	if err != nil {
+		return "", AuthToken{}, fmt.Errorf("could not generate token: %w", err)
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(data)
+	return token, AuthToken{Name: name, Hash: hashToken(token), Role: role}, nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// Identity is the authenticated caller of a gRPC method
+type Identity struct {
+	Name string
+	Role Role
+
+	// Method is how the caller was authenticated, either by certificate or token
+	Method string
+}
+
+type identityKey struct{}
+
+// IdentityFromContext returns the caller authenticated by the auth interceptors, if any
+func IdentityFromContext(ctx context.Context) (*Identity, bool) {
+	identity, ok := ctx.Value(identityKey{}).(*Identity)
+	return identity, ok
+}
+
+// Authenticator identifies the caller of a gRPC method. It returns no identity when the
+// caller does not present the credentials it checks, so that the next one can be tried.
+type Authenticator interface {
+	Authenticate(ctx context.Context) (*Identity, error)
+}
+
+// TokenAuthenticator identifies callers by the bearer token in the authorization metadata
+type TokenAuthenticator struct {
+	Tokens []AuthToken
+}
+
+func (a TokenAuthenticator) Authenticate(ctx context.Context) (*Identity, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	token, found := strings.CutPrefix(values[0], "Bearer ")
+	if !found {
+		return nil, fmt.Errorf("unsupported authorization scheme")
+	}
+
+	hash := hashToken(token)
+	for _, t := range a.Tokens {
+		if subtle.ConstantTimeCompare([]byte(hash), []byte(t.Hash)) == 1 {
+			return &Identity{Name: t.Name, Role: t.Role, Method: "token"}, nil
+		}
+	}
+
+	return nil, fmt.Errorf("invalid token")
+}
+
+// CertificateAuthenticator identifies callers by the common name of their verified client certificate
+type CertificateAuthenticator struct {
+	Users map[string]Role
+
+	// ServiceIdentities are given the admin role, as the services use the certificates of their host
+	ServiceIdentities []string
+}
+
+func (a CertificateAuthenticator) Authenticate(ctx context.Context) (*Identity, error) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil, nil
+	}
+
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return nil, nil
+	}
+
+	name := tlsInfo.State.VerifiedChains[0][0].Subject.CommonName
+	if slices.Contains(a.ServiceIdentities, name) {
+		return &Identity{Name: name, Role: RoleAdmin, Method: "certificate"}, nil
+	}
+
+	role, ok := a.Users[name]
+	if !ok {
+		return nil, fmt.Errorf("certificate %q is not granted a role", name)
+	}
+
+	return &Identity{Name: name, Role: role, Method: "certificate"}, nil
+}
+
+// Authorizer authenticates the callers of the gRPC methods and checks they have the role the method requires
+type Authorizer struct {
+	Authenticators []Authenticator
+}
+
+// NewAuthorizer returns the authorizer for the configuration, which lets all callers through
+// when authentication is not enabled
+func NewAuthorizer(conf *AuthConfig, serviceIdentities []string) *Authorizer {
+	if conf == nil || !conf.Enabled {
+		return &Authorizer{}
+	}
+
+	return &Authorizer{Authenticators: []Authenticator{
+		TokenAuthenticator{Tokens: conf.Tokens},
+		CertificateAuthenticator{Users: conf.Users, ServiceIdentities: serviceIdentities},
+	}}
+}
+
+// authorize returns the context with the identity of the caller, or a gRPC error when the
+// caller could not be authenticated or is not allowed to call the method
+func (a *Authorizer) authorize(ctx context.Context, fullMethod string) (context.Context, error) {
+	if len(a.Authenticators) == 0 || slices.Contains(publicMethods, fullMethod) {
+		return ctx, nil
+	}
+
+	var identity *Identity
+	for _, authenticator := range a.Authenticators {
+		var err error
+		identity, err = authenticator.Authenticate(ctx)
+		if err != nil {
+			gplog.Warn("Rejected call to %s: %v", fullMethod, err)
+			return ctx, status.Errorf(codes.Unauthenticated, "could not authenticate the caller: %v", err)
+		}
+
+		if identity != nil {
+			break
+		}
+	}
+
+	if identity == nil {
+		gplog.Warn("Rejected call to %s: no credentials", fullMethod)
+		return ctx, status.Errorf(codes.Unauthenticated, "could not authenticate the caller: no client certificate or token provided")
+	}
+
+	recordCaller(ctx, identity)
+
+	required := RequiredRole(fullMethod)
+	if !identity.Role.Allows(required) {
+		gplog.Warn("Denied call to %s by %s with role %s", fullMethod, identity.Name, identity.Role)
+		return ctx, status.Errorf(codes.PermissionDenied, "%s is not allowed to call %s, which requires the %s role", identity.Name, fullMethod, required)
+	}
+
+	return context.WithValue(ctx, identityKey{}, identity), nil
+}
+
+func (a *Authorizer) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := a.authorize(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+func (a *Authorizer) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := a.authorize(stream.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &authenticatedStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+// authenticatedStream carries the identity of the caller in the context of the stream
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
+
+// TokenCredentials send a bearer token with each call, which are only sent over TLS
+type TokenCredentials string
+
+func (t TokenCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + string(t)}, nil
+}
+
+func (t TokenCredentials) RequireTransportSecurity() bool {
+	return true
+}
+
+// TokenFromEnv returns the credentials for the bearer token set in the environment, if any
+func TokenFromEnv() (TokenCredentials, bool) {
+	token := strings.TrimSpace(os.Getenv(AuthTokenEnv))
+	return TokenCredentials(token), token != ""
+}
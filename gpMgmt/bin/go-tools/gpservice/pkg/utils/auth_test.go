@@ -0,0 +1,161 @@
+package utils_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+)
+
+func TestAuthorizer(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	token, entry, err := utils.NewAuthToken("monitoring", utils.RoleReadOnly)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	authorizer := utils.NewAuthorizer(&utils.AuthConfig{
+		Enabled: true,
+		Users:   map[string]utils.Role{"alice": utils.RoleAdmin, "bob": utils.RoleReadOnly},
+		Tokens:  []utils.AuthToken{entry},
+	}, []string{"cdw", "sdw1"})
+	interceptor := authorizer.UnaryInterceptor()
+
+	cases := []struct {
+		name     string
+		ctx      context.Context
+		method   string
+		expected codes.Code
+		caller   string
+	}{
+		{"admin certificate calls an admin method", certificateContext("alice"), "/idl.Hub/MakeCluster", codes.OK, "alice"},
+		{"read-only certificate calls a read-only method", certificateContext("bob"), "/idl.Hub/ClusterStatus", codes.OK, "bob"},
+		{"read-only certificate calls an admin method", certificateContext("bob"), "/idl.Hub/CleanInitCluster", codes.PermissionDenied, ""},
+		{"host certificate calls an admin method", certificateContext("sdw1"), "/idl.Agent/MakeSegment", codes.OK, "sdw1"},
+		{"unknown certificate", certificateContext("mallory"), "/idl.Hub/ClusterStatus", codes.Unauthenticated, ""},
+		{"no credentials", context.Background(), "/idl.Hub/ClusterStatus", codes.Unauthenticated, ""},
+		{"token takes precedence over the certificate", tokenContext(certificateContext("alice"), token), "/idl.Hub/Stop", codes.PermissionDenied, ""},
+		{"token calls a read-only method", tokenContext(context.Background(), token), "/idl.Hub/StatusAgents", codes.OK, "monitoring"},
+		{"invalid token", tokenContext(context.Background(), "invalid"), "/idl.Hub/StatusAgents", codes.Unauthenticated, ""},
+		{"health check without credentials", context.Background(), "/grpc.health.v1.Health/Check", codes.OK, ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var caller string
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				if identity, ok := utils.IdentityFromContext(ctx); ok {
+					caller = identity.Name
+				}
+				return "reply", nil
+			}
+
+			_, err := interceptor(tc.ctx, "request", &grpc.UnaryServerInfo{FullMethod: tc.method}, handler)
+			if status.Code(err) != tc.expected {
+				t.Fatalf("got %v, want code %s", err, tc.expected)
+			}
+
+			if caller != tc.caller {
+				t.Fatalf("got caller %q, want %q", caller, tc.caller)
+			}
+		})
+	}
+
+	t.Run("stream interceptor passes the identity to the handler", func(t *testing.T) {
+		var caller string
+		handler := func(srv interface{}, stream grpc.ServerStream) error {
+			identity, _ := utils.IdentityFromContext(stream.Context())
+			caller = identity.Name
+			return nil
+		}
+
+		stream := &mockServerStream{ctx: certificateContext("alice")}
+		err := authorizer.StreamInterceptor()(nil, stream, &grpc.StreamServerInfo{FullMethod: "/idl.Hub/MakeCluster"}, handler)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if caller != "alice" {
+			t.Fatalf("got caller %q, want alice", caller)
+		}
+
+		stream = &mockServerStream{ctx: certificateContext("bob")}
+		err = authorizer.StreamInterceptor()(nil, stream, &grpc.StreamServerInfo{FullMethod: "/idl.Hub/MakeCluster"}, handler)
+		expected := "bob is not allowed to call /idl.Hub/MakeCluster, which requires the admin role"
+		if status.Code(err) != codes.PermissionDenied || status.Convert(err).Message() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+
+	t.Run("lets all callers through when authentication is disabled", func(t *testing.T) {
+		interceptor := utils.NewAuthorizer(&utils.AuthConfig{Enabled: false}, nil).UnaryInterceptor()
+
+		_, err := interceptor(context.Background(), "request", &grpc.UnaryServerInfo{FullMethod: "/idl.Hub/Stop"}, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, nil
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+}
+
+func TestAuthConfigValidate(t *testing.T) {
+	auth := &utils.AuthConfig{Enabled: true}
+
+	err := auth.Validate(&utils.GpCredentials{TlsEnabled: false})
+	expected := "authentication requires TLS to be enabled"
+	if err == nil || err.Error() != expected {
+		t.Fatalf("got %v, want %s", err, expected)
+	}
+
+	err = auth.Validate(&utils.GpCredentials{TlsEnabled: true, CACertPath: "/certs/ca-cert.pem"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestParseRole(t *testing.T) {
+	role, err := utils.ParseRole("admin")
+	if err != nil || role != utils.RoleAdmin {
+		t.Fatalf("got %s, %v, want %s", role, err, utils.RoleAdmin)
+	}
+
+	_, err = utils.ParseRole("owner")
+	expected := `unsupported role "owner", supported roles are read-only and admin`
+	if err == nil || err.Error() != expected {
+		t.Fatalf("got %v, want %s", err, expected)
+	}
+}
+
+// certificateContext returns the context of a call by a client with a verified certificate of the given common name
+func certificateContext(commonName string) context.Context {
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: commonName}}
+	return peer.NewContext(context.Background(), &peer.Peer{
+		AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}}},
+	})
+}
+
+func tokenContext(ctx context.Context, token string) context.Context {
+	return metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", "Bearer "+token))
+}
+
+type mockServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *mockServerStream) Context() context.Context {
+	return s.ctx
+}
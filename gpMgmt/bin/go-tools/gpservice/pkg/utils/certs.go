@@ -0,0 +1,197 @@
+package utils
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"time"
+)
+
+const (
+	DefaultCAValidity          = 10 * 365 * 24 * time.Hour
+	DefaultCertificateValidity = 365 * 24 * time.Hour
+)
+
+// CertificateAuthority issues the server and client certificates used for mutual TLS between the services
+type CertificateAuthority struct {
+	Cert *x509.Certificate
+	Key  crypto.Signer
+}
+
+// NewCertificateAuthority creates a self-signed CA with a new private key
+func NewCertificateAuthority(commonName string, validity time.Duration) (*CertificateAuthority, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("could not generate CA key: %w", err)
+	}
+
+	template, err := certificateTemplate(commonName, validity)
+	if err != nil {
+		return nil, err
+	}
+	template.IsCA = true
+	template.BasicConstraintsValid = true
+	template.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		return nil, fmt.Errorf("could not create CA certificate: %w", err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse CA certificate: %w", err)
+	}
+
+	return &CertificateAuthority{Cert: cert, Key: key}, nil
+}
+
+// LoadCertificateAuthority reads the CA certificate and private key from the given PEM files
+func LoadCertificateAuthority(certPath, keyPath string) (*CertificateAuthority, error) {
+	certPEM, err := System.ReadFile(certPath)
+	if err != nil {
+		return nil, fmt.Errorf("could not read CA certificate: %w", err)
+	}
+
+	cert, err := ParseCertificatePEM(certPEM)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse CA certificate %s: %w", certPath, err)
+	}
+
+	keyPEM, err := System.ReadFile(keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("could not read CA key: %w", err)
+	}
+
+	block, _ := pem.Decode(keyPEM)
+	if block == nil {
+		return nil, fmt.Errorf("could not parse CA key %s: no PEM data found", keyPath)
+	}
+
+	key, err := parsePrivateKey(block)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse CA key %s: %w", keyPath, err)
+	}
+
+	return &CertificateAuthority{Cert: cert, Key: key}, nil
+}
+
+// CertPEM returns the CA certificate in PEM format
+func (ca *CertificateAuthority) CertPEM() []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.Cert.Raw})
+}
+
+// KeyPEM returns the CA private key in PEM format
+func (ca *CertificateAuthority) KeyPEM() ([]byte, error) {
+	return encodeKeyPEM(ca.Key)
+}
+
+// IssueServerCertificate returns a certificate and private key in PEM format, which are
+// valid for the given host as well as for connections to the local host
+func (ca *CertificateAuthority) IssueServerCertificate(hostname string, validity time.Duration) ([]byte, []byte, error) {
+	template, err := certificateTemplate(hostname, validity)
+	if err != nil {
+		return nil, nil, err
+	}
+	template.KeyUsage = x509.KeyUsageDigitalSignature
+	template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+	template.DNSNames = []string{hostname, "localhost"}
+	template.IPAddresses = []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback}
+
+	return ca.issue(template)
+}
+
+// IssueClientCertificate returns a certificate and private key in PEM format, which identify the caller
+func (ca *CertificateAuthority) IssueClientCertificate(commonName string, validity time.Duration) ([]byte, []byte, error) {
+	template, err := certificateTemplate(commonName, validity)
+	if err != nil {
+		return nil, nil, err
+	}
+	template.KeyUsage = x509.KeyUsageDigitalSignature
+	template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+
+	return ca.issue(template)
+}
+
+func (ca *CertificateAuthority) issue(template *x509.Certificate) ([]byte, []byte, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not generate key for %s: %w", template.Subject.CommonName, err)
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.Cert, key.Public(), ca.Key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not create certificate for %s: %w", template.Subject.CommonName, err)
+	}
+
+	keyPEM, err := encodeKeyPEM(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), keyPEM, nil
+}
+
+// ParseCertificatePEM parses the first certificate in the PEM data
+func ParseCertificatePEM(data []byte) (*x509.Certificate, error) {
+	for block, rest := pem.Decode(data); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type == "CERTIFICATE" {
+			return x509.ParseCertificate(block.Bytes)
+		}
+	}
+
+	return nil, fmt.Errorf("no certificate found in PEM data")
+}
+
+func certificateTemplate(commonName string, validity time.Duration) (*x509.Certificate, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, fmt.Errorf("could not generate certificate serial number: %w", err)
+	}
+
+	now := time.Now()
+	return &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{Organization: []string{"Greenplum"}, CommonName: commonName},
+		NotBefore:    now.Add(-5 * time.Minute),
+		NotAfter:     now.Add(validity),
+	}, nil
+}
+
+// parsePrivateKey parses the key in the PKCS #8, PKCS #1 or SEC 1 formats written by openssl
+func parsePrivateKey(block *pem.Block) (crypto.Signer, error) {
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		return x509.ParseECPrivateKey(block.Bytes)
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported key type %T", key)
+	}
+
+	return signer, nil
+}
+
+func encodeKeyPEM(key crypto.Signer) ([]byte, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("could not encode private key: %w", err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), nil
+}
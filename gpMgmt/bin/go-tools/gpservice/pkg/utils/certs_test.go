@@ -0,0 +1,92 @@
+package utils_test
+
+import (
+	"crypto/x509"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+)
+
+func TestCertificateAuthority(t *testing.T) {
+	ca, err := utils.NewCertificateAuthority("gpservice CA", time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.Cert)
+
+	t.Run("issues server certificates valid for the host and localhost", func(t *testing.T) {
+		certPEM, _, err := ca.IssueServerCertificate("sdw1", time.Hour)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		cert, err := utils.ParseCertificatePEM(certPEM)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		for _, name := range []string{"sdw1", "localhost", "127.0.0.1"} {
+			_, err = cert.Verify(x509.VerifyOptions{DNSName: name, Roots: roots, KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}})
+			if err != nil {
+				t.Fatalf("expected the certificate to be valid for %s: %v", name, err)
+			}
+		}
+
+		_, err = cert.Verify(x509.VerifyOptions{DNSName: "sdw2", Roots: roots})
+		if err == nil {
+			t.Fatalf("expected the certificate to not be valid for sdw2")
+		}
+	})
+
+	t.Run("issues client certificates", func(t *testing.T) {
+		certPEM, _, err := ca.IssueClientCertificate("sdw1", time.Hour)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		cert, err := utils.ParseCertificatePEM(certPEM)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		_, err = cert.Verify(x509.VerifyOptions{Roots: roots, KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("loads the CA written to disk", func(t *testing.T) {
+		dir := t.TempDir()
+		keyPEM, err := ca.KeyPEM()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		writeTestFile(t, filepath.Join(dir, "ca-cert.pem"), string(ca.CertPEM()))
+		writeTestFile(t, filepath.Join(dir, "ca-key.pem"), string(keyPEM))
+
+		loaded, err := utils.LoadCertificateAuthority(filepath.Join(dir, "ca-cert.pem"), filepath.Join(dir, "ca-key.pem"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !loaded.Cert.Equal(ca.Cert) {
+			t.Fatalf("got a different CA certificate")
+		}
+	})
+
+	t.Run("errors out when the key is not valid", func(t *testing.T) {
+		dir := t.TempDir()
+		writeTestFile(t, filepath.Join(dir, "ca-cert.pem"), string(ca.CertPEM()))
+		writeTestFile(t, filepath.Join(dir, "ca-key.pem"), "not a key")
+
+		_, err := utils.LoadCertificateAuthority(filepath.Join(dir, "ca-cert.pem"), filepath.Join(dir, "ca-key.pem"))
+		expected := "could not parse CA key " + filepath.Join(dir, "ca-key.pem") + ": no PEM data found"
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+}
@@ -12,6 +12,8 @@ import (
 	"reflect"
 
 	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type CommandBuilder interface {
@@ -41,9 +43,21 @@ func NewGpSourcedCommandContext(ctx context.Context, cmdBuilder CommandBuilder,
 	return System.ExecCommandContext(ctx, cmd.Path, cmd.Args[1:]...)
 }
 
-func runCommand(cmd *exec.Cmd, filename ...string) (*bytes.Buffer, error) {
+/*
+runCommand executes the command, recording it as a span when it is run on behalf of a traced
+request, so that the time spent in initdb, pg_ctl or pg_basebackup shows up in the trace of
+the gpctl command which caused it.
+*/
+func runCommand(ctx context.Context, cmd *exec.Cmd, filename ...string) (out *bytes.Buffer, err error) {
 	var outfile *os.File
-	var err error
+
+	if trace.SpanContextFromContext(ctx).IsValid() {
+		var span trace.Span
+		_, span = StartSpan(ctx, filepath.Base(cmd.Path), attribute.String("command", cmd.String()))
+		defer func() {
+			EndSpan(span, err)
+		}()
+	}
 
 	if len(filename) > 0 {
 		if len(filename) != 1 {
@@ -80,24 +94,24 @@ func runCommand(cmd *exec.Cmd, filename ...string) (*bytes.Buffer, error) {
 
 // RunGpCommandAndRedirectOutput executes the command and redirects the stdout and stderr to the given filename
 func RunGpCommandAndRedirectOutput(ctx context.Context, cmdBuilder CommandBuilder, gpHome string, filename string) (*bytes.Buffer, error) {
-	return runCommand(NewGpCommandContext(ctx, cmdBuilder, gpHome), filename)
+	return runCommand(ctx, NewGpCommandContext(ctx, cmdBuilder, gpHome), filename)
 }
 
 // RunGpCommand executes the given command
 func RunGpCommand(cmdBuilder CommandBuilder, gpHome string) (*bytes.Buffer, error) {
-	out, err := runCommand(NewGpCommand(cmdBuilder, gpHome))
+	out, err := runCommand(context.Background(), NewGpCommand(cmdBuilder, gpHome))
 	return out, err
 }
 
 // RunGpCommand executes the given command with a context
 func RunGpCommandContext(ctx context.Context, cmdBuilder CommandBuilder, gpHome string) (*bytes.Buffer, error) {
-	out, err := runCommand(NewGpCommandContext(ctx, cmdBuilder, gpHome))
+	out, err := runCommand(ctx, NewGpCommandContext(ctx, cmdBuilder, gpHome))
 	return out, err
 }
 
 // RunGpSourcedCommand sources the greenplum_path.sh before executing the given command
 func RunGpSourcedCommand(cmdBuilder CommandBuilder, gpHome string) (*bytes.Buffer, error) {
-	out, err := runCommand(NewGpSourcedCommand(cmdBuilder, gpHome))
+	out, err := runCommand(context.Background(), NewGpSourcedCommand(cmdBuilder, gpHome))
 	return out, err
 }
 
@@ -0,0 +1,151 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"google.golang.org/grpc/credentials"
+)
+
+// CredentialsWatchInterval is how often the certificate files are checked for changes
+var CredentialsWatchInterval = 30 * time.Second
+
+/*
+ReloadableCredentials are transport credentials which can be loaded again while the
+server using them is running, so that rotated certificates take effect without a
+restart. Connections which are already established are not affected, and new
+connections are accepted using the reloaded credentials. When reloading fails, the
+previous credentials are kept.
+*/
+type ReloadableCredentials struct {
+	load func() (credentials.TransportCredentials, error)
+
+	mutex   sync.RWMutex
+	current credentials.TransportCredentials
+}
+
+func NewReloadableCredentials(load func() (credentials.TransportCredentials, error)) (*ReloadableCredentials, error) {
+	current, err := load()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReloadableCredentials{load: load, current: current}, nil
+}
+
+// Reload loads the credentials again, keeping the previous ones if they could not be loaded
+func (r *ReloadableCredentials) Reload() error {
+	current, err := r.load()
+	if err != nil {
+		return err
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.current = current
+
+	return nil
+}
+
+/*
+Watch reloads the credentials whenever any of the given files is modified, checking them
+every CredentialsWatchInterval until done is closed. Since a certificate and its key are
+usually not replaced at the same instant, a failed reload is retried on the next check.
+*/
+func (r *ReloadableCredentials) Watch(paths []string, done <-chan struct{}) {
+	if len(paths) == 0 {
+		return
+	}
+
+	ticker := time.NewTicker(CredentialsWatchInterval)
+	defer ticker.Stop()
+
+	previous := fileStates(paths)
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+		}
+
+		current := fileStates(paths)
+		if current == previous {
+			continue
+		}
+
+		err := r.Reload()
+		if err != nil {
+			gplog.Warn("Could not reload the TLS credentials after the certificate files changed: %v", err)
+			continue
+		}
+
+		previous = current
+		gplog.Info("Reloaded the TLS credentials after the certificate files changed")
+	}
+}
+
+func (r *ReloadableCredentials) get() credentials.TransportCredentials {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	return r.current
+}
+
+func (r *ReloadableCredentials) ClientHandshake(ctx context.Context, authority string, conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return r.get().ClientHandshake(ctx, authority, conn)
+}
+
+func (r *ReloadableCredentials) ServerHandshake(conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	return r.get().ServerHandshake(conn)
+}
+
+func (r *ReloadableCredentials) Info() credentials.ProtocolInfo {
+	return r.get().Info()
+}
+
+// Clone returns the same credentials, so that the clone is reloaded along with them
+func (r *ReloadableCredentials) Clone() credentials.TransportCredentials {
+	return r
+}
+
+func (r *ReloadableCredentials) OverrideServerName(name string) error {
+	return r.get().OverrideServerName(name) // nolint
+}
+
+// CredentialPaths returns the files the credentials are loaded from, if they are loaded from files
+func CredentialPaths(creds Credentials) []string {
+	switch c := creds.(type) {
+	case *GpCredentials:
+		if c.TlsEnabled {
+			return c.Paths()
+		}
+	case GpCredentials:
+		if c.TlsEnabled {
+			return c.Paths()
+		}
+	}
+
+	return nil
+}
+
+// fileStates returns a summary of the modification times and sizes of the files, which
+// changes whenever any of the files is replaced or written to
+func fileStates(paths []string) string {
+	var state string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			state += path + ":missing;"
+			continue
+		}
+
+		state += fmt.Sprintf("%s:%d:%d;", path, info.ModTime().UnixNano(), info.Size())
+	}
+
+	return state
+}
@@ -0,0 +1,135 @@
+package utils_test
+
+import (
+	"crypto/tls"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+)
+
+func TestReloadableCredentials(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	t.Run("replaces the credentials on reload and keeps them when reloading fails", func(t *testing.T) {
+		loaded := []credentials.TransportCredentials{insecure.NewCredentials(), credentials.NewTLS(&tls.Config{})}
+		var loadErr error
+		load := func() (credentials.TransportCredentials, error) {
+			if loadErr != nil {
+				return nil, loadErr
+			}
+			creds := loaded[0]
+			loaded = loaded[1:]
+			return creds, nil
+		}
+
+		creds, err := utils.NewReloadableCredentials(load)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if creds.Info().SecurityProtocol != "insecure" {
+			t.Fatalf("got %s, want insecure", creds.Info().SecurityProtocol)
+		}
+
+		err = creds.Reload()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if creds.Info().SecurityProtocol != "tls" {
+			t.Fatalf("got %s, want tls", creds.Info().SecurityProtocol)
+		}
+
+		loadErr = errors.New("error")
+		err = creds.Reload()
+		if !errors.Is(err, loadErr) {
+			t.Fatalf("got %v, want %v", err, loadErr)
+		}
+		if creds.Info().SecurityProtocol != "tls" {
+			t.Fatalf("got %s, want the previous credentials to be kept", creds.Info().SecurityProtocol)
+		}
+
+		if creds.Clone() != creds {
+			t.Fatalf("expected the clone to be reloaded along with the credentials")
+		}
+	})
+
+	t.Run("errors out when the credentials could not be loaded", func(t *testing.T) {
+		expected := errors.New("error")
+		_, err := utils.NewReloadableCredentials(func() (credentials.TransportCredentials, error) {
+			return nil, expected
+		})
+		if !errors.Is(err, expected) {
+			t.Fatalf("got %v, want %v", err, expected)
+		}
+	})
+
+	t.Run("reloads the credentials when the files change", func(t *testing.T) {
+		defer func(interval time.Duration) { utils.CredentialsWatchInterval = interval }(utils.CredentialsWatchInterval)
+		utils.CredentialsWatchInterval = 10 * time.Millisecond
+
+		path := filepath.Join(t.TempDir(), "server-cert.pem")
+		err := os.WriteFile(path, []byte("old"), 0644)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var loads atomic.Int32
+		creds, err := utils.NewReloadableCredentials(func() (credentials.TransportCredentials, error) {
+			loads.Add(1)
+			return insecure.NewCredentials(), nil
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		done := make(chan struct{})
+		defer close(done)
+		go creds.Watch([]string{path}, done)
+
+		time.Sleep(50 * time.Millisecond)
+		if loads.Load() != 1 {
+			t.Fatalf("got %d loads, want the credentials to not be reloaded while the files are unchanged", loads.Load())
+		}
+
+		err = os.WriteFile(path, []byte("new certificate"), 0644)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		deadline := time.Now().Add(5 * time.Second)
+		for loads.Load() < 2 {
+			if time.Now().After(deadline) {
+				t.Fatalf("expected the credentials to be reloaded after the file changed")
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	})
+}
+
+func TestCredentialPaths(t *testing.T) {
+	creds := &utils.GpCredentials{
+		CACertPath:     "/certs/ca-cert.pem",
+		ServerCertPath: "/certs/server-cert.pem",
+		ServerKeyPath:  "/certs/server-key.pem",
+		TlsEnabled:     true,
+	}
+
+	expected := []string{"/certs/ca-cert.pem", "/certs/server-cert.pem", "/certs/server-key.pem"}
+	if paths := utils.CredentialPaths(creds); !reflect.DeepEqual(paths, expected) {
+		t.Fatalf("got %v, want %v", paths, expected)
+	}
+
+	creds.TlsEnabled = false
+	if paths := utils.CredentialPaths(creds); paths != nil {
+		t.Fatalf("got %v, want no paths when TLS is disabled", paths)
+	}
+}
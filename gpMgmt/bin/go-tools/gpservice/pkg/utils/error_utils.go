@@ -30,6 +30,16 @@ func (h HelpErr) Help() {
 	fmt.Println(h.helpText)
 }
 
+// grpcError is a gRPC error reduced to its message, which keeps its code for ErrorCode
+type grpcError struct {
+	code    codes.Code
+	message string
+}
+
+func (e grpcError) Error() string {
+	return e.message
+}
+
 // FormatGrpcError formats the given error according to gRPC conventions.
 // If the error is nil, it returns nil. If the error is a gRPC error, it extracts
 // the error message and returns a new error with the extracted message. Otherwise,
@@ -41,8 +51,7 @@ func FormatGrpcError(err error) error {
 
 	grpcErr, ok := status.FromError(err)
 	if ok {
-		errorDescription := grpcErr.Message()
-		return fmt.Errorf(errorDescription)
+		return grpcError{code: grpcErr.Code(), message: grpcErr.Message()}
 	}
 
 	return err
@@ -0,0 +1,128 @@
+package utils
+
+import (
+	"fmt"
+	"hash/crc32"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FileChunkSize is the size of the chunks in which files are streamed between the hub and the agents
+const FileChunkSize = 64 * 1024
+
+var crc32Table = crc32.MakeTable(crc32.Castagnoli)
+
+// ChunkChecksum returns the checksum sent along with a chunk of a streamed file
+func ChunkChecksum(data []byte) uint32 {
+	return crc32.Checksum(data, crc32Table)
+}
+
+// IsPathAllowed reports whether the path is an absolute path to one of the given files or within
+// one of the given directories. Symbolic links are resolved first, so that a link cannot be used
+// to reach a file outside of them.
+func IsPathAllowed(path string, allowedFiles, allowedDirs []string) bool {
+	if !filepath.IsAbs(path) {
+		return false
+	}
+
+	path = resolvePath(path)
+	for _, file := range allowedFiles {
+		if file != "" && resolvePath(file) == path {
+			return true
+		}
+	}
+
+	for _, dir := range allowedDirs {
+		if dir == "" {
+			continue
+		}
+
+		rel, err := filepath.Rel(resolvePath(dir), path)
+		if err == nil && rel != "." && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsPrivateKeyPath reports whether the path, once its symbolic links are resolved, names a private
+// key as written by gpservice certs, which are never transferred between the hub and the agents
+func IsPrivateKeyPath(path string) bool {
+	return strings.HasSuffix(resolvePath(path), "-key.pem")
+}
+
+// resolvePath resolves the symbolic links in the path. A file which does not exist yet has the
+// links of its directory resolved, as it is created there when written.
+func resolvePath(path string) string {
+	path = filepath.Clean(path)
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		return resolved
+	}
+
+	if dir, err := filepath.EvalSymlinks(filepath.Dir(path)); err == nil {
+		return filepath.Join(dir, filepath.Base(path))
+	}
+
+	return path
+}
+
+/*
+AtomicFileWriter writes a file to a temporary file in the same directory, which
+is renamed to the destination once committed. Readers of the destination never
+see a partially written file, and the destination is left untouched on failure.
+*/
+type AtomicFileWriter struct {
+	path string
+	mode os.FileMode
+	file *os.File
+}
+
+func NewAtomicFileWriter(path string, mode os.FileMode) (*AtomicFileWriter, error) {
+	file, err := os.CreateTemp(filepath.Dir(path), fmt.Sprintf(".%s.*.tmp", filepath.Base(path)))
+	if err != nil {
+		return nil, fmt.Errorf("could not create temporary file for %s: %w", path, err)
+	}
+
+	return &AtomicFileWriter{path: path, mode: mode, file: file}, nil
+}
+
+func (w *AtomicFileWriter) Write(data []byte) (int, error) {
+	return w.file.Write(data)
+}
+
+// SetMode sets the mode of the file, for when it is not known until the contents are written
+func (w *AtomicFileWriter) SetMode(mode os.FileMode) {
+	w.mode = mode
+}
+
+// Commit syncs the written contents to disk and renames the file to its destination
+func (w *AtomicFileWriter) Commit() error {
+	err := w.file.Chmod(w.mode)
+	if err == nil {
+		err = w.file.Sync()
+	}
+
+	closeErr := w.file.Close()
+	if err == nil {
+		err = closeErr
+	}
+
+	if err == nil {
+		err = os.Rename(w.file.Name(), w.path)
+	}
+
+	if err != nil {
+		os.Remove(w.file.Name())
+		return fmt.Errorf("could not write %s: %w", w.path, err)
+	}
+
+	return nil
+}
+
+// Abort discards the written contents, leaving the destination untouched
+func (w *AtomicFileWriter) Abort() {
+	w.file.Close()
+	os.Remove(w.file.Name())
+}
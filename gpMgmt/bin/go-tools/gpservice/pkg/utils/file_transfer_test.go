@@ -0,0 +1,173 @@
+package utils_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+)
+
+func TestIsPathAllowed(t *testing.T) {
+	allowedFiles := []string{"/usr/local/gpservice.conf", ""}
+	allowedDirs := []string{"/etc/gpservice", "", "/var/log/gpservice/"}
+
+	cases := map[string]bool{
+		"/etc/gpservice/gpservice.conf":      true,
+		"/var/log/gpservice/gpservice.log":   true,
+		"/etc/gpservice/certs/server.crt":    true,
+		"/etc/gpservice":                     false,
+		"/etc/gpservice/../passwd":           false,
+		"/etc/gpservice-other/file":          false,
+		"etc/gpservice/gpservice.conf":       false,
+		"/var/log/gpservice/../../shadow":    false,
+		"/etc/gpservice/./certs/../ca.crt":   true,
+		"/usr/local/gpservice.conf":          true,
+		"/usr/local/bin/gpservice":           false,
+		"/usr/local/../local/gpservice.conf": true,
+	}
+
+	for path, expected := range cases {
+		result := utils.IsPathAllowed(path, allowedFiles, allowedDirs)
+		if result != expected {
+			t.Fatalf("got %t for %s, want %t", result, path, expected)
+		}
+	}
+
+	t.Run("resolves the symbolic links of the path", func(t *testing.T) {
+		allowedDir := t.TempDir()
+		outsideDir := t.TempDir()
+		writeTestFile(t, filepath.Join(outsideDir, "ca-key.pem"), "key")
+
+		err := os.Symlink(filepath.Join(outsideDir, "ca-key.pem"), filepath.Join(allowedDir, "file-link"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		err = os.Symlink(outsideDir, filepath.Join(allowedDir, "dir-link"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		for _, path := range []string{filepath.Join(allowedDir, "file-link"), filepath.Join(allowedDir, "dir-link", "new-file")} {
+			if utils.IsPathAllowed(path, nil, []string{allowedDir}) {
+				t.Fatalf("got true for %s, want false", path)
+			}
+		}
+
+		path := filepath.Join(allowedDir, "new-file")
+		if !utils.IsPathAllowed(path, nil, []string{allowedDir}) {
+			t.Fatalf("got false for %s, want true", path)
+		}
+	})
+}
+
+func TestIsPrivateKeyPath(t *testing.T) {
+	cases := map[string]bool{
+		"/etc/gpservice/server-key.pem":    true,
+		"/etc/gpservice/client-key.pem":    true,
+		"/etc/gpservice/server-cert.pem":   false,
+		"/var/log/gpservice/gpservice.log": false,
+	}
+
+	for path, expected := range cases {
+		result := utils.IsPrivateKeyPath(path)
+		if result != expected {
+			t.Fatalf("got %t for %s, want %t", result, path, expected)
+		}
+	}
+
+	t.Run("resolves the symbolic links of the path", func(t *testing.T) {
+		dir := t.TempDir()
+		writeTestFile(t, filepath.Join(dir, "ca-key.pem"), "key")
+
+		err := os.Symlink(filepath.Join(dir, "ca-key.pem"), filepath.Join(dir, "gpservice.log"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !utils.IsPrivateKeyPath(filepath.Join(dir, "gpservice.log")) {
+			t.Fatalf("got false for the link, want true")
+		}
+	})
+}
+
+func TestAtomicFileWriter(t *testing.T) {
+	t.Run("writes the file with the given mode once committed", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "gpservice.conf")
+		writeTestFile(t, path, "old")
+
+		writer, err := utils.NewAtomicFileWriter(path, 0640)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		_, err = writer.Write([]byte("new"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		assertFileContents(t, path, "old")
+
+		err = writer.Commit()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		assertFileContents(t, path, "new")
+
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if info.Mode().Perm() != 0640 {
+			t.Fatalf("got mode %o, want 640", info.Mode().Perm())
+		}
+	})
+
+	t.Run("leaves the destination untouched when aborted", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "gpservice.conf")
+		writeTestFile(t, path, "old")
+
+		writer, err := utils.NewAtomicFileWriter(path, 0640)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		_, err = writer.Write([]byte("new"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		writer.Abort()
+
+		assertFileContents(t, path, "old")
+
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(entries) != 1 {
+			t.Fatalf("expected the temporary file to be removed, got %v", entries)
+		}
+	})
+
+	t.Run("errors out when the directory does not exist", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "missing", "gpservice.conf")
+
+		_, err := utils.NewAtomicFileWriter(path, 0640)
+		if err == nil {
+			t.Fatalf("expected an error")
+		}
+	})
+}
+
+func assertFileContents(t *testing.T, path, expected string) {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(data) != expected {
+		t.Fatalf("got %q, want %q", data, expected)
+	}
+}
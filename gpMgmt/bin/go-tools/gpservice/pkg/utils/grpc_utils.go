@@ -45,6 +45,13 @@ func CheckGRPCServerHealth(conn *grpc.ClientConn) error {
 	return err
 }
 
+// IsGRPCServerServing checks once whether the gRPC server reports that it is serving, without
+// retrying or waiting for the connection to become ready
+func IsGRPCServerServing(ctx context.Context, conn grpc.ClientConnInterface) bool {
+	resp, err := newHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	return err == nil && resp.GetStatus() == healthpb.HealthCheckResponse_SERVING
+}
+
 func SetNewHealthClient(mock healthpb.HealthClient) {
 	newHealthClient = func(cc grpc.ClientConnInterface) healthpb.HealthClient {
 		return mock
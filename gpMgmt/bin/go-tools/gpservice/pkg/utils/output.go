@@ -0,0 +1,269 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	OutputFormatText   = "text"
+	OutputFormatJson   = "json"
+	OutputFormatNdjson = "ndjson"
+	OutputFormatYaml   = "yaml"
+)
+
+// OutputSchemaVersion is the version of the events and results written by the json, ndjson and
+// yaml output formats. It is only changed when a field is removed or changes meaning.
+const OutputSchemaVersion = 1
+
+const (
+	EventTypeLog       = "log"
+	EventTypeStdout    = "stdout"
+	EventTypeProgress  = "progress"
+	EventTypeOperation = "operation"
+	EventTypeResult    = "result"
+)
+
+const (
+	ResultSucceeded = "succeeded"
+	ResultFailed    = "failed"
+)
+
+// errorCodes are the stable codes of the errors reported in the result of a command
+var errorCodes = map[codes.Code]string{
+	codes.Canceled:           "canceled",
+	codes.Unknown:            "unknown",
+	codes.InvalidArgument:    "invalid_argument",
+	codes.DeadlineExceeded:   "deadline_exceeded",
+	codes.NotFound:           "not_found",
+	codes.AlreadyExists:      "already_exists",
+	codes.PermissionDenied:   "permission_denied",
+	codes.ResourceExhausted:  "resource_exhausted",
+	codes.FailedPrecondition: "failed_precondition",
+	codes.Aborted:            "aborted",
+	codes.OutOfRange:         "out_of_range",
+	codes.Unimplemented:      "unimplemented",
+	codes.Internal:           "internal",
+	codes.Unavailable:        "unavailable",
+	codes.DataLoss:           "data_loss",
+	codes.Unauthenticated:    "unauthenticated",
+}
+
+type ProgressEvent struct {
+	Label   string `json:"label"`
+	Current int32  `json:"current"`
+	Total   int32  `json:"total"`
+}
+
+// OutputEvent is a message of a command, such as a log line streamed from the hub
+type OutputEvent struct {
+	Type        string         `json:"type"`
+	Time        time.Time      `json:"time"`
+	Level       string         `json:"level,omitempty"`
+	Message     string         `json:"message,omitempty"`
+	Progress    *ProgressEvent `json:"progress,omitempty"`
+	OperationID string         `json:"operation_id,omitempty"`
+}
+
+// OutputAction is the action taken by a command when it failed or was interrupted, such as
+// rolling back its changes, along with the reason it was chosen
+type OutputAction struct {
+	Trigger string `json:"trigger"`
+	Action  string `json:"action"`
+	Reason  string `json:"reason"`
+}
+
+type ResultError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// OutputResult is the last object written by a command, which tells whether it succeeded
+type OutputResult struct {
+	Type    string         `json:"type"`
+	Version int            `json:"version"`
+	Command string         `json:"command"`
+	Status  string         `json:"status"`
+	Time    time.Time      `json:"time"`
+	Data    interface{}    `json:"data,omitempty"`
+	Error   *ResultError   `json:"error,omitempty"`
+	Actions []OutputAction `json:"actions,omitempty"`
+	Events  []OutputEvent  `json:"events,omitempty"`
+}
+
+/*
+Output writes the messages and the result of a command for automation. With the ndjson format
+each event is written on its own line as it happens, followed by the result. With the json
+and yaml formats the events are kept and written along with the result as a single object.
+Nothing is written with the text format, where the command displays its output as usual.
+*/
+type Output struct {
+	mutex   sync.Mutex
+	writer  io.Writer
+	format  string
+	events  []OutputEvent
+	actions []OutputAction
+	data    interface{}
+}
+
+func NewOutput(writer io.Writer, format string) *Output {
+	return &Output{
+		writer: writer,
+		format: format,
+	}
+}
+
+func ValidateOutputFormat(format string) error {
+	switch format {
+	case OutputFormatText, OutputFormatJson, OutputFormatNdjson, OutputFormatYaml:
+		return nil
+	}
+
+	return fmt.Errorf("invalid value %q for --output, valid values are %s, %s, %s and %s", format, OutputFormatText, OutputFormatJson, OutputFormatNdjson, OutputFormatYaml)
+}
+
+// Structured returns true if the output is meant for automation rather than for a terminal
+func (o *Output) Structured() bool {
+	return o.format == OutputFormatJson || o.format == OutputFormatNdjson || o.format == OutputFormatYaml
+}
+
+func (o *Output) Event(event OutputEvent) error {
+	if !o.Structured() {
+		return nil
+	}
+
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	if o.format == OutputFormatJson || o.format == OutputFormatYaml {
+		o.events = append(o.events, event)
+		return nil
+	}
+
+	return o.write(event)
+}
+
+// SetData sets the data reported in the result of the command, such as the statuses of the services
+func (o *Output) SetData(data interface{}) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	o.data = data
+}
+
+// Action records an action taken by the command, which is reported in its result
+func (o *Output) Action(action OutputAction) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	o.actions = append(o.actions, action)
+}
+
+func (o *Output) Data() interface{} {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	return o.data
+}
+
+// Result writes the result of the command, which failed if err is not nil
+func (o *Output) Result(command string, err error) error {
+	if !o.Structured() {
+		return nil
+	}
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	result := OutputResult{
+		Type:    EventTypeResult,
+		Version: OutputSchemaVersion,
+		Command: command,
+		Status:  ResultSucceeded,
+		Time:    time.Now(),
+		Data:    o.data,
+		Actions: o.actions,
+		Events:  o.events,
+	}
+	if err != nil {
+		result.Status = ResultFailed
+		result.Error = &ResultError{Code: ErrorCode(err), Message: err.Error()}
+	}
+
+	return o.write(result)
+}
+
+// write writes the object on a single line, or as a yaml document, with the mutex held
+func (o *Output) write(object interface{}) error {
+	out, err := json.Marshal(object)
+	if err != nil {
+		return err
+	}
+
+	if o.format == OutputFormatYaml {
+		out, err = jsonToYaml(out)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = fmt.Fprintln(o.writer, strings.TrimSuffix(string(out), "\n"))
+
+	return err
+}
+
+// jsonToYaml converts a json document to yaml, keeping the names and the order of its fields
+func jsonToYaml(document []byte) ([]byte, error) {
+	var node yaml.Node
+	err := yaml.Unmarshal(document, &node)
+	if err != nil {
+		return nil, err
+	}
+
+	// yaml is a superset of json, so the document is parsed in the flow style of json
+	setBlockStyle(&node)
+
+	return yaml.Marshal(&node)
+}
+
+func setBlockStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		setBlockStyle(child)
+	}
+}
+
+// ErrorCode returns the stable code of the error, based on the gRPC status it originates from
+func ErrorCode(err error) string {
+	var grpcErr grpcError
+	if errors.As(err, &grpcErr) {
+		return errorCodes[grpcErr.code]
+	}
+
+	if grpcStatus, ok := status.FromError(err); ok {
+		return errorCodes[grpcStatus.Code()]
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return errorCodes[codes.Canceled]
+	case errors.Is(err, context.DeadlineExceeded):
+		return errorCodes[codes.DeadlineExceeded]
+	}
+
+	return errorCodes[codes.Unknown]
+}
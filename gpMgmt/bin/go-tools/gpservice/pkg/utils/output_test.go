@@ -0,0 +1,176 @@
+package utils_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gopkg.in/yaml.v3"
+
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+)
+
+func TestOutput(t *testing.T) {
+	events := []utils.OutputEvent{
+		{Type: utils.EventTypeLog, Level: "info", Message: "creating segments"},
+		{Type: utils.EventTypeProgress, Progress: &utils.ProgressEvent{Label: "Initializing segments:", Current: 1, Total: 2}},
+	}
+
+	t.Run("writes each event and the result on its own line with the ndjson format", func(t *testing.T) {
+		var buf bytes.Buffer
+		output := utils.NewOutput(&buf, utils.OutputFormatNdjson)
+
+		for _, event := range events {
+			err := output.Event(event)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+
+		err := output.Result("gpctl init", utils.FormatGrpcError(status.Error(codes.FailedPrecondition, "operation is running")))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		if len(lines) != 3 {
+			t.Fatalf("got %d lines, want 3: %s", len(lines), buf.String())
+		}
+
+		var event utils.OutputEvent
+		err = json.Unmarshal([]byte(lines[1]), &event)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if event.Type != utils.EventTypeProgress || *event.Progress != *events[1].Progress || event.Time.IsZero() {
+			t.Fatalf("got %+v, want %+v", event, events[1])
+		}
+
+		var result utils.OutputResult
+		err = json.Unmarshal([]byte(lines[2]), &result)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := utils.ResultError{Code: "failed_precondition", Message: "operation is running"}
+		if result.Type != utils.EventTypeResult || result.Version != utils.OutputSchemaVersion || result.Command != "gpctl init" ||
+			result.Status != utils.ResultFailed || result.Error == nil || *result.Error != expected || len(result.Events) != 0 {
+			t.Fatalf("got %+v, want a failed result with %+v", result, expected)
+		}
+	})
+
+	t.Run("writes the events along with the result with the json format", func(t *testing.T) {
+		var buf bytes.Buffer
+		output := utils.NewOutput(&buf, utils.OutputFormatJson)
+
+		for _, event := range events {
+			output.Event(event) // nolint
+		}
+		output.SetData([]string{"sdw1", "sdw2"})
+		output.Action(utils.OutputAction{Trigger: "failure", Action: "rollback", Reason: "--on-failure is rollback"})
+
+		err := output.Result("gpservice status", nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var result map[string]interface{}
+		err = json.Unmarshal(buf.Bytes(), &result)
+		if err != nil {
+			t.Fatalf("got %s, want a single json object: %v", buf.String(), err)
+		}
+
+		if result["status"] != utils.ResultSucceeded || result["error"] != nil {
+			t.Fatalf("got %v, want the command to succeed", result)
+		}
+
+		if len(result["events"].([]interface{})) != 2 || len(result["data"].([]interface{})) != 2 || len(result["actions"].([]interface{})) != 1 {
+			t.Fatalf("got %v, want the events, the data and the actions", result)
+		}
+	})
+
+	t.Run("writes the events along with the result as a yaml document with the yaml format", func(t *testing.T) {
+		var buf bytes.Buffer
+		output := utils.NewOutput(&buf, utils.OutputFormatYaml)
+
+		for _, event := range events {
+			output.Event(event) // nolint
+		}
+		output.SetData([]map[string]string{{"preferred_role": "p", "port": "7000"}})
+
+		err := output.Result("gpctl status", nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var result map[string]interface{}
+		err = yaml.Unmarshal(buf.Bytes(), &result)
+		if err != nil {
+			t.Fatalf("got %s, want a single yaml document: %v", buf.String(), err)
+		}
+
+		if result["status"] != utils.ResultSucceeded || len(result["events"].([]interface{})) != 2 {
+			t.Fatalf("got %v, want the command to succeed along with the events", result)
+		}
+
+		for _, expected := range []string{"type: result\n", "command: gpctl status\n", "    - port: \"7000\"\n", "      preferred_role: p\n"} {
+			if !strings.Contains(buf.String(), expected) {
+				t.Fatalf("got %s, want it to contain %q", buf.String(), expected)
+			}
+		}
+	})
+
+	t.Run("writes nothing with the text format", func(t *testing.T) {
+		var buf bytes.Buffer
+		output := utils.NewOutput(&buf, utils.OutputFormatText)
+
+		output.Event(events[0])          // nolint
+		output.Result("gpctl init", nil) // nolint
+
+		if buf.Len() != 0 {
+			t.Fatalf("got %s, want no output", buf.String())
+		}
+	})
+}
+
+func TestValidateOutputFormat(t *testing.T) {
+	for _, format := range []string{utils.OutputFormatText, utils.OutputFormatJson, utils.OutputFormatNdjson, utils.OutputFormatYaml} {
+		err := utils.ValidateOutputFormat(format)
+		if err != nil {
+			t.Fatalf("unexpected error for %s: %v", format, err)
+		}
+	}
+
+	expected := `invalid value "table" for --output, valid values are text, json, ndjson and yaml`
+	err := utils.ValidateOutputFormat("table")
+	if err == nil || err.Error() != expected {
+		t.Fatalf("got %v, want %s", err, expected)
+	}
+}
+
+func TestErrorCode(t *testing.T) {
+	cases := []struct {
+		err      error
+		expected string
+	}{
+		{status.Error(codes.NotFound, "not found"), "not_found"},
+		{utils.FormatGrpcError(status.Error(codes.PermissionDenied, "denied")), "permission_denied"},
+		{fmt.Errorf("failed: %w", utils.FormatGrpcError(status.Error(codes.Unavailable, "down"))), "unavailable"},
+		{context.Canceled, "canceled"},
+		{errors.New("error"), "unknown"},
+	}
+
+	for _, tc := range cases {
+		code := utils.ErrorCode(tc.err)
+		if code != tc.expected {
+			t.Fatalf("got %s for %v, want %s", code, tc.err, tc.expected)
+		}
+	}
+}
@@ -0,0 +1,310 @@
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+const (
+	DefaultSSHPort        = 22
+	DefaultSSHParallelism = 64
+	DefaultSSHTimeout     = 30 * time.Second
+)
+
+var (
+	Remote RemoteExecutor = NewSSHExecutor()
+)
+
+func ResetRemoteExecutor() {
+	Remote = NewSSHExecutor()
+}
+
+// RemoteExecutor runs commands and copies files on a set of remote hosts
+type RemoteExecutor interface {
+	Run(hostnames []string, command string) RemoteResults
+	CopyFile(hostnames []string, source, destination string) RemoteResults
+}
+
+// RemoteResult holds the outcome of running a command on a single host. Err is set when
+// the command could not be run at all, for example when not able to connect to the host.
+type RemoteResult struct {
+	Hostname string
+	ExitCode int
+	Stdout   string
+	Stderr   string
+	Err      error
+}
+
+// Error returns an error if the command could not be run or exited with a non-zero status
+func (r *RemoteResult) Error() error {
+	if r.Err != nil {
+		return fmt.Errorf("host %s: %w", r.Hostname, r.Err)
+	}
+
+	if r.ExitCode != 0 {
+		return fmt.Errorf("host %s: exit status %d: %s", r.Hostname, r.ExitCode, strings.TrimSpace(r.Stderr))
+	}
+
+	return nil
+}
+
+// RemoteResults holds the per host outcome of a remote command, in the order of the given hosts
+type RemoteResults []*RemoteResult
+
+// Err returns the errors of all the hosts on which the command failed joined together
+func (results RemoteResults) Err() error {
+	var err error
+	for _, result := range results {
+		err = errors.Join(err, result.Error())
+	}
+
+	return err
+}
+
+/*
+SSHExecutor runs commands on remote hosts over SSH, fanning out to at most
+Parallelism hosts at a time. Host keys are verified against the known hosts
+file, and the user is authenticated using the keys from the SSH agent if one
+is running, followed by the given private key files.
+*/
+type SSHExecutor struct {
+	User           string
+	Port           int
+	KeyFiles       []string
+	KnownHostsFile string
+	Parallelism    int
+	Timeout        time.Duration
+}
+
+// NewSSHExecutor returns an executor using the default SSH configuration of the current user
+func NewSSHExecutor() *SSHExecutor {
+	executor := &SSHExecutor{
+		Port:        DefaultSSHPort,
+		Parallelism: DefaultSSHParallelism,
+		Timeout:     DefaultSSHTimeout,
+	}
+
+	user, err := System.CurrentUser()
+	if err != nil {
+		return executor
+	}
+
+	sshDir := filepath.Join(user.HomeDir, ".ssh")
+	executor.User = user.Username
+	executor.KnownHostsFile = filepath.Join(sshDir, "known_hosts")
+	for _, key := range []string{"id_rsa", "id_ecdsa", "id_ed25519"} {
+		executor.KeyFiles = append(executor.KeyFiles, filepath.Join(sshDir, key))
+	}
+
+	return executor
+}
+
+func (e *SSHExecutor) Run(hostnames []string, command string) RemoteResults {
+	return e.fanOut(hostnames, func(client *ssh.Client, result *RemoteResult) {
+		e.runSession(client, command, nil, result)
+	})
+}
+
+// CopyFile copies the local source file to the destination path on the hosts, keeping its permissions.
+// The contents are written to a private temporary file next to the destination, which then replaces
+// the destination, so that the destination is never seen partially written or with other permissions.
+func (e *SSHExecutor) CopyFile(hostnames []string, source, destination string) RemoteResults {
+	contents, err := System.ReadFile(source)
+	if err == nil {
+		var info os.FileInfo
+		info, err = System.Stat(source)
+		if err == nil {
+			command := fmt.Sprintf(`tmp=$(umask 077 && mktemp %s) && cat > "$tmp" && chmod %o "$tmp" && mv -f "$tmp" %s || { rm -f "$tmp"; exit 1; }`,
+				ShellQuote(destination+".XXXXXX"), info.Mode().Perm(), ShellQuote(destination))
+
+			return e.fanOut(hostnames, func(client *ssh.Client, result *RemoteResult) {
+				e.runSession(client, command, contents, result)
+			})
+		}
+	}
+
+	results := make(RemoteResults, len(hostnames))
+	for i, host := range hostnames {
+		results[i] = &RemoteResult{Hostname: host, Err: fmt.Errorf("could not read %s: %w", source, err)}
+	}
+
+	return results
+}
+
+func (e *SSHExecutor) fanOut(hostnames []string, run func(client *ssh.Client, result *RemoteResult)) RemoteResults {
+	results := make(RemoteResults, len(hostnames))
+	for i, host := range hostnames {
+		results[i] = &RemoteResult{Hostname: host}
+	}
+
+	config, closeAgent, err := e.clientConfig()
+	if err != nil {
+		for _, result := range results {
+			result.Err = err
+		}
+
+		return results
+	}
+	defer closeAgent()
+
+	parallelism := e.Parallelism
+	if parallelism <= 0 {
+		parallelism = DefaultSSHParallelism
+	}
+
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, parallelism)
+	for _, result := range results {
+		wg.Add(1)
+		semaphore <- struct{}{}
+
+		go func(result *RemoteResult) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			address := net.JoinHostPort(result.Hostname, strconv.Itoa(e.Port))
+			hostConfig := *config
+			hostConfig.HostKeyAlgorithms = knownHostKeyAlgorithms(config.HostKeyCallback, address)
+
+			client, err := ssh.Dial("tcp", address, &hostConfig)
+			if err != nil {
+				result.Err = fmt.Errorf("could not connect: %w", err)
+				return
+			}
+			defer client.Close()
+
+			run(client, result)
+		}(result)
+	}
+	wg.Wait()
+
+	return results
+}
+
+func (e *SSHExecutor) runSession(client *ssh.Client, command string, stdin []byte, result *RemoteResult) {
+	session, err := client.NewSession()
+	if err != nil {
+		result.Err = fmt.Errorf("could not create session: %w", err)
+		return
+	}
+	defer session.Close()
+
+	var stdout, stderr bytes.Buffer
+	session.Stdout = &stdout
+	session.Stderr = &stderr
+	if stdin != nil {
+		session.Stdin = bytes.NewReader(stdin)
+	}
+
+	gplog.Verbose("Executing command on host %s: %s", result.Hostname, command)
+	err = session.Run(command)
+	result.Stdout = stdout.String()
+	result.Stderr = stderr.String()
+
+	var exitErr *ssh.ExitError
+	if errors.As(err, &exitErr) {
+		result.ExitCode = exitErr.ExitStatus()
+	} else if err != nil {
+		result.Err = err
+	}
+}
+
+// clientConfig builds the client configuration along with a function
+// to close the connection to the SSH agent once done
+func (e *SSHExecutor) clientConfig() (*ssh.ClientConfig, func(), error) {
+	hostKeyCallback, err := knownhosts.New(e.KnownHostsFile)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not read known hosts file %s: %w", e.KnownHostsFile, err)
+	}
+
+	closeAgent := func() {}
+	var signers []ssh.Signer
+	if socket := os.Getenv("SSH_AUTH_SOCK"); socket != "" {
+		conn, err := net.Dial("unix", socket)
+		if err != nil {
+			gplog.Verbose("could not connect to the SSH agent: %v", err)
+		} else {
+			closeAgent = func() { conn.Close() }
+			agentSigners, err := agent.NewClient(conn).Signers()
+			if err != nil {
+				gplog.Verbose("could not get the keys from the SSH agent: %v", err)
+			}
+			signers = append(signers, agentSigners...)
+		}
+	}
+
+	for _, keyFile := range e.KeyFiles {
+		contents, err := System.ReadFile(keyFile)
+		if err != nil {
+			continue
+		}
+
+		signer, err := ssh.ParsePrivateKey(contents)
+		if err != nil {
+			gplog.Verbose("skipping SSH key %s: %v", keyFile, err)
+			continue
+		}
+		signers = append(signers, signer)
+	}
+
+	if len(signers) == 0 {
+		closeAgent()
+		return nil, nil, errors.New("no SSH keys found to authenticate with, add a key to the SSH agent or create one in ~/.ssh")
+	}
+
+	config := &ssh.ClientConfig{
+		User:            e.User,
+		Auth:            []ssh.AuthMethod{ssh.PublicKeys(signers...)},
+		HostKeyCallback: hostKeyCallback,
+		Timeout:         e.Timeout,
+	}
+
+	return config, closeAgent, nil
+}
+
+// knownHostKeyAlgorithms returns the algorithms of the keys which are known for the given address,
+// so that the host presents a key which can be verified instead of its preferred one
+func knownHostKeyAlgorithms(callback ssh.HostKeyCallback, address string) []string {
+	placeholder := &net.TCPAddr{IP: net.IPv4zero}
+	err := callback(address, placeholder, placeholderKey{})
+
+	var keyErr *knownhosts.KeyError
+	if !errors.As(err, &keyErr) {
+		return nil
+	}
+
+	var algorithms []string
+	for _, known := range keyErr.Want {
+		if known.Key.Type() == ssh.KeyAlgoRSA {
+			algorithms = append(algorithms, ssh.KeyAlgoRSASHA512, ssh.KeyAlgoRSASHA256)
+		}
+		algorithms = append(algorithms, known.Key.Type())
+	}
+
+	return algorithms
+}
+
+// placeholderKey never matches a known host key, and is used to look up the known keys of a host
+type placeholderKey struct{}
+
+func (placeholderKey) Type() string                        { return "placeholder" }
+func (placeholderKey) Marshal() []byte                     { return []byte("placeholder") }
+func (placeholderKey) Verify([]byte, *ssh.Signature) error { return errors.New("placeholder key") }
+
+// ShellQuote quotes the given string so that it is passed as a single word to the shell
+func ShellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
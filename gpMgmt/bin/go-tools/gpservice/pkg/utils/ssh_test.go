@@ -0,0 +1,335 @@
+package utils_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+)
+
+func TestSSHExecutor(t *testing.T) {
+	testhelper.SetupTestLogger()
+	t.Setenv("SSH_AUTH_SOCK", "")
+
+	t.Run("runs the command on all the hosts and returns the per host results", func(t *testing.T) {
+		server := newTestSSHServer(t)
+		executor := server.executor(t)
+
+		results := executor.Run([]string{"127.0.0.1", "127.0.0.1"}, "hostname")
+		if err := results.Err(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expected := utils.RemoteResults{
+			{Hostname: "127.0.0.1", Stdout: "sdw1\n"},
+			{Hostname: "127.0.0.1", Stdout: "sdw1\n"},
+		}
+		if !reflect.DeepEqual(results, expected) {
+			t.Fatalf("got %+v, want %+v", results, expected)
+		}
+	})
+
+	t.Run("returns the exit code and stderr when the command fails", func(t *testing.T) {
+		server := newTestSSHServer(t)
+		executor := server.executor(t)
+
+		results := executor.Run([]string{"127.0.0.1"}, "fail")
+		expected := utils.RemoteResults{
+			{Hostname: "127.0.0.1", ExitCode: 3, Stderr: "command failed\n"},
+		}
+		if !reflect.DeepEqual(results, expected) {
+			t.Fatalf("got %+v, want %+v", results, expected)
+		}
+
+		expectedErr := "host 127.0.0.1: exit status 3: command failed"
+		if err := results.Err(); err == nil || err.Error() != expectedErr {
+			t.Fatalf("got %v, want %s", err, expectedErr)
+		}
+	})
+
+	t.Run("runs on at most the given number of hosts at a time", func(t *testing.T) {
+		server := newTestSSHServer(t)
+		executor := server.executor(t)
+		executor.Parallelism = 2
+
+		hosts := []string{"127.0.0.1", "127.0.0.1", "127.0.0.1", "127.0.0.1", "127.0.0.1"}
+		results := executor.Run(hosts, "hostname")
+		if err := results.Err(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(results) != len(hosts) {
+			t.Fatalf("got %d results, want %d", len(results), len(hosts))
+		}
+
+		if server.maxSessions > 2 {
+			t.Fatalf("got %d concurrent sessions, want at most 2", server.maxSessions)
+		}
+	})
+
+	t.Run("copies the file to the hosts", func(t *testing.T) {
+		server := newTestSSHServer(t)
+		executor := server.executor(t)
+
+		source := filepath.Join(t.TempDir(), "gpservice.conf")
+		err := os.WriteFile(source, []byte("contents"), 0640)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		results := executor.CopyFile([]string{"127.0.0.1"}, source, "/tmp/it's.conf")
+		if err := results.Err(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		expectedCommand := `tmp=$(umask 077 && mktemp '/tmp/it'\''s.conf.XXXXXX') && cat > "$tmp" && chmod 640 "$tmp" && mv -f "$tmp" '/tmp/it'\''s.conf' || { rm -f "$tmp"; exit 1; }`
+		if server.command != expectedCommand {
+			t.Fatalf("got %s, want %s", server.command, expectedCommand)
+		}
+
+		if server.stdin != "contents" {
+			t.Fatalf("got %s, want contents", server.stdin)
+		}
+	})
+
+	t.Run("errors out when the host key is not known", func(t *testing.T) {
+		server := newTestSSHServer(t)
+		executor := server.executor(t)
+
+		otherKey, _, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		publicKey, err := ssh.NewPublicKey(otherKey)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		writeTestFile(t, executor.KnownHostsFile, knownhosts.Line([]string{knownhosts.Normalize(server.address)}, publicKey)+"\n")
+
+		results := executor.Run([]string{"127.0.0.1"}, "hostname")
+		expectedErr := "host 127.0.0.1: could not connect: ssh: handshake failed: knownhosts: key mismatch"
+		if err := results.Err(); err == nil || err.Error() != expectedErr {
+			t.Fatalf("got %v, want %s", err, expectedErr)
+		}
+	})
+
+	t.Run("errors out when there are no keys to authenticate with", func(t *testing.T) {
+		server := newTestSSHServer(t)
+		executor := server.executor(t)
+		executor.KeyFiles = []string{filepath.Join(t.TempDir(), "id_rsa")}
+
+		results := executor.Run([]string{"127.0.0.1"}, "hostname")
+		expectedErr := "host 127.0.0.1: no SSH keys found to authenticate with"
+		if err := results.Err(); err == nil || !strings.HasPrefix(err.Error(), expectedErr) {
+			t.Fatalf("got %v, want %s", err, expectedErr)
+		}
+	})
+}
+
+func TestShellQuote(t *testing.T) {
+	cases := map[string]string{
+		"/tmp/file":      `'/tmp/file'`,
+		"/tmp/it's":      `'/tmp/it'\''s'`,
+		"/tmp/$(whoami)": `'/tmp/$(whoami)'`,
+	}
+
+	for input, expected := range cases {
+		result := utils.ShellQuote(input)
+		if result != expected {
+			t.Fatalf("got %s, want %s", result, expected)
+		}
+	}
+}
+
+/*
+testSSHServer is a minimal SSH server which accepts the generated client key and
+runs the following commands: "hostname" prints sdw1, "fail" exits with status 3,
+and any other command is recorded along with its standard input.
+*/
+type testSSHServer struct {
+	address   string
+	hostKey   ssh.Signer
+	clientKey ed25519.PrivateKey
+
+	mutex       sync.Mutex
+	sessions    int
+	maxSessions int
+	command     string
+	stdin       string
+}
+
+func newTestSSHServer(t *testing.T) *testSSHServer {
+	t.Helper()
+
+	_, hostKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	hostSigner, err := ssh.NewSignerFromKey(hostKey)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	clientPublicKey, clientKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	authorizedKey, err := ssh.NewPublicKey(clientPublicKey)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	config := &ssh.ServerConfig{
+		PublicKeyCallback: func(conn ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if string(key.Marshal()) != string(authorizedKey.Marshal()) {
+				return nil, io.EOF
+			}
+			return nil, nil
+		},
+	}
+	config.AddHostKey(hostSigner)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	server := &testSSHServer{
+		address:   listener.Addr().String(),
+		hostKey:   hostSigner,
+		clientKey: clientKey,
+	}
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go server.serve(conn, config)
+		}
+	}()
+
+	return server
+}
+
+// executor returns an executor which trusts the server and authenticates with the client key
+func (s *testSSHServer) executor(t *testing.T) *utils.SSHExecutor {
+	t.Helper()
+
+	dir := t.TempDir()
+
+	block, err := ssh.MarshalPrivateKey(s.clientKey, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	keyFile := filepath.Join(dir, "id_ed25519")
+	writeTestFile(t, keyFile, string(pem.EncodeToMemory(block)))
+
+	knownHostsFile := filepath.Join(dir, "known_hosts")
+	writeTestFile(t, knownHostsFile, knownhosts.Line([]string{knownhosts.Normalize(s.address)}, s.hostKey.PublicKey())+"\n")
+
+	_, port, _ := net.SplitHostPort(s.address)
+	portNum, _ := strconv.Atoi(port)
+
+	return &utils.SSHExecutor{
+		User:           "gpadmin",
+		Port:           portNum,
+		KeyFiles:       []string{keyFile},
+		KnownHostsFile: knownHostsFile,
+		Parallelism:    utils.DefaultSSHParallelism,
+		Timeout:        utils.DefaultSSHTimeout,
+	}
+}
+
+func (s *testSSHServer) serve(conn net.Conn, config *ssh.ServerConfig) {
+	_, channels, requests, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		return
+	}
+	go ssh.DiscardRequests(requests)
+
+	for newChannel := range channels {
+		if newChannel.ChannelType() != "session" {
+			newChannel.Reject(ssh.UnknownChannelType, "unknown channel type")
+			continue
+		}
+
+		channel, requests, err := newChannel.Accept()
+		if err != nil {
+			return
+		}
+
+		go func() {
+			for req := range requests {
+				if req.Type != "exec" {
+					req.Reply(false, nil)
+					continue
+				}
+
+				var payload struct{ Command string }
+				ssh.Unmarshal(req.Payload, &payload)
+				req.Reply(true, nil)
+
+				status := s.exec(channel, payload.Command)
+				channel.SendRequest("exit-status", false, ssh.Marshal(struct{ Status uint32 }{status}))
+				channel.Close()
+			}
+		}()
+	}
+}
+
+func (s *testSSHServer) exec(channel ssh.Channel, command string) uint32 {
+	s.mutex.Lock()
+	s.sessions++
+	s.maxSessions = max(s.maxSessions, s.sessions)
+	s.mutex.Unlock()
+
+	defer func() {
+		s.mutex.Lock()
+		s.sessions--
+		s.mutex.Unlock()
+	}()
+
+	switch command {
+	case "hostname":
+		channel.Write([]byte("sdw1\n"))
+		return 0
+	case "fail":
+		channel.Stderr().Write([]byte("command failed\n"))
+		return 3
+	}
+
+	stdin, _ := io.ReadAll(channel)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.command = command
+	s.stdin = string(stdin)
+
+	return 0
+}
+
+func writeTestFile(t *testing.T, path, contents string) {
+	t.Helper()
+
+	err := os.WriteFile(path, []byte(contents), 0600)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -3,63 +3,274 @@ package utils
 import (
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/pem"
 	"fmt"
 	"os"
+	"slices"
 
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 )
 
+// DefaultMinTLSVersion is the minimum TLS version used when none is configured
+const DefaultMinTLSVersion = "1.2"
+
+var tlsVersions = map[string]uint16{
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
 type Credentials interface {
 	LoadServerCredentials() (credentials.TransportCredentials, error)
 	LoadClientCredentials() (credentials.TransportCredentials, error)
 }
 
+/*
+GpCredentials configures mutual TLS between gpctl, the hub and the agents. The server
+certificate is presented by the hub and agents to their callers, and the client
+certificate by the callers. Both sides verify the certificate of the other against
+the CA, and the callers also verify that the server certificate is valid for the host
+they connect to. The client certificate defaults to the server certificate, so that
+configurations written before they could be set separately keep working.
+*/
 type GpCredentials struct {
 	CACertPath     string `json:"caCert"`
 	ServerCertPath string `json:"serverCert"`
 	ServerKeyPath  string `json:"serverKey"`
+	ClientCertPath string `json:"clientCert,omitempty"`
+	ClientKeyPath  string `json:"clientKey,omitempty"`
+	CRLPath        string `json:"crl,omitempty"`
+	MinTLSVersion  string `json:"minTlsVersion,omitempty"`
 	TlsEnabled     bool   `json:"tlsEnabled"`
 }
 
 func (c GpCredentials) LoadServerCredentials() (credentials.TransportCredentials, error) {
+	return c.loadServerCredentials(tls.RequireAndVerifyClientCert)
+}
+
+// LoadTokenServerCredentials are the server credentials of services which also accept bearer
+// tokens, where callers may not present a client certificate. One which is presented is still
+// verified against the CA.
+func (c GpCredentials) LoadTokenServerCredentials() (credentials.TransportCredentials, error) {
+	return c.loadServerCredentials(tls.VerifyClientCertIfGiven)
+}
+
+func (c GpCredentials) loadServerCredentials(clientAuth tls.ClientAuthType) (credentials.TransportCredentials, error) {
 	if c.TlsEnabled {
-		serverCert, err := tls.LoadX509KeyPair(c.ServerCertPath, c.ServerKeyPath)
+		config, err := c.serverTLSConfig(clientAuth)
 		if err != nil {
-			return nil, fmt.Errorf("could not load server credentials: %w", err)
+			return nil, err
 		}
 
-		config := &tls.Config{
-			Certificates: []tls.Certificate{serverCert},
-			ClientAuth:   tls.RequireAnyClientCert,
-		}
 		return credentials.NewTLS(config), nil
 	}
 	return insecure.NewCredentials(), nil
 }
 
+// ServerTLSConfig is the configuration of the servers run by the services besides the gRPC one,
+// such as the metrics server, which also require a client certificate issued by the CA
+func (c GpCredentials) ServerTLSConfig() (*tls.Config, error) {
+	return c.serverTLSConfig(tls.RequireAndVerifyClientCert)
+}
+
+func (c GpCredentials) serverTLSConfig(clientAuth tls.ClientAuthType) (*tls.Config, error) {
+	serverCert, err := tls.LoadX509KeyPair(c.ServerCertPath, c.ServerKeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("could not load server credentials: %w", err)
+	}
+
+	config, err := c.tlsConfig(serverCert)
+	if err != nil {
+		return nil, fmt.Errorf("could not load server credentials: %w", err)
+	}
+
+	config.ClientAuth = clientAuth
+	config.ClientCAs = config.RootCAs
+	config.RootCAs = nil
+
+	return config, nil
+}
+
+// LoadClientCredentials leaves the server name unset, so that it is taken from the address
+// dialled and the server certificate is verified to be valid for that host
 func (c GpCredentials) LoadClientCredentials() (credentials.TransportCredentials, error) {
 	if c.TlsEnabled {
-		caCert, err := os.ReadFile(c.CACertPath)
-		if err != nil {
-			return nil, fmt.Errorf("error while loading server certificate: %v", err)
+		certPath, keyPath := c.ClientCertPath, c.ClientKeyPath
+		if certPath == "" {
+			certPath, keyPath = c.ServerCertPath, c.ServerKeyPath
 		}
-		certPool := x509.NewCertPool()
-		if !certPool.AppendCertsFromPEM(caCert) {
-			return nil, fmt.Errorf("failed to add server CA's certificate")
+
+		clientCert, err := tls.LoadX509KeyPair(certPath, keyPath)
+		if err != nil {
+			return nil, fmt.Errorf("could not load client credentials: %w", err)
 		}
 
-		clientCert, err := tls.LoadX509KeyPair(c.ServerCertPath, c.ServerKeyPath)
+		config, err := c.tlsConfig(clientCert)
 		if err != nil {
-			return nil, fmt.Errorf("error while loading server certificate: %v", err)
+			return nil, fmt.Errorf("could not load client credentials: %w", err)
 		}
 
-		config := &tls.Config{
-			Certificates: []tls.Certificate{clientCert},
-			RootCAs:      certPool,
+		return credentials.NewTLS(config), nil
+	}
+	return insecure.NewCredentials(), nil
+}
+
+// LoadTokenClientCredentials are the client credentials of callers authenticated by a bearer
+// token, which verify the server certificate but do not present a client certificate
+func (c GpCredentials) LoadTokenClientCredentials() (credentials.TransportCredentials, error) {
+	if c.TlsEnabled {
+		config, err := c.tlsConfig()
+		if err != nil {
+			return nil, fmt.Errorf("could not load client credentials: %w", err)
 		}
+
 		return credentials.NewTLS(config), nil
 	}
 	return insecure.NewCredentials(), nil
+}
+
+// Paths returns the paths of the certificate, key and CRL files which are configured
+func (c GpCredentials) Paths() []string {
+	var paths []string
+	for _, path := range []string{c.CACertPath, c.ServerCertPath, c.ServerKeyPath, c.ClientCertPath, c.ClientKeyPath, c.CRLPath} {
+		if path != "" {
+			paths = append(paths, path)
+		}
+	}
+
+	return paths
+}
+
+// CertPaths returns the paths of the certificate and CRL files which are configured, leaving out the keys
+func (c GpCredentials) CertPaths() []string {
+	var paths []string
+	for _, path := range []string{c.CACertPath, c.ServerCertPath, c.ClientCertPath, c.CRLPath} {
+		if path != "" {
+			paths = append(paths, path)
+		}
+	}
+
+	return paths
+}
+
+// tlsConfig returns the configuration common to the server and the client, with the CA
+// certificates as the root CAs
+func (c GpCredentials) tlsConfig(certs ...tls.Certificate) (*tls.Config, error) {
+	minVersion, err := ParseTLSVersion(c.MinTLSVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	caCerts, err := loadCACerts(c.CACertPath)
+	if err != nil {
+		return nil, err
+	}
 
+	certPool := x509.NewCertPool()
+	for _, caCert := range caCerts {
+		certPool.AddCert(caCert)
+	}
+
+	config := &tls.Config{
+		Certificates: certs,
+		RootCAs:      certPool,
+		MinVersion:   minVersion,
+	}
+
+	if c.CRLPath != "" {
+		revoked, err := loadRevokedSerials(c.CRLPath, caCerts)
+		if err != nil {
+			return nil, err
+		}
+		config.VerifyPeerCertificate = verifyNotRevoked(revoked)
+	}
+
+	return config, nil
+}
+
+// ParseTLSVersion returns the TLS version for the given value, which defaults to DefaultMinTLSVersion
+func ParseTLSVersion(version string) (uint16, error) {
+	if version == "" {
+		version = DefaultMinTLSVersion
+	}
+
+	value, ok := tlsVersions[version]
+	if !ok {
+		return 0, fmt.Errorf("unsupported TLS version %q, supported versions are 1.2 and 1.3", version)
+	}
+
+	return value, nil
+}
+
+func loadCACerts(path string) ([]*x509.Certificate, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read CA certificate: %w", err)
+	}
+
+	var caCerts []*x509.Certificate
+	for block, rest := pem.Decode(contents); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		caCert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse CA certificate %s: %w", path, err)
+		}
+		caCerts = append(caCerts, caCert)
+	}
+
+	if len(caCerts) == 0 {
+		return nil, fmt.Errorf("failed to add server CA's certificate")
+	}
+
+	return caCerts, nil
+}
+
+// loadRevokedSerials returns the serial numbers revoked by the CRL, which must be issued by one of the CAs
+func loadRevokedSerials(path string, caCerts []*x509.Certificate) ([]string, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read CRL: %w", err)
+	}
+
+	if block, _ := pem.Decode(contents); block != nil {
+		contents = block.Bytes
+	}
+
+	crl, err := x509.ParseRevocationList(contents)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse CRL %s: %w", path, err)
+	}
+
+	signed := slices.ContainsFunc(caCerts, func(caCert *x509.Certificate) bool {
+		return crl.CheckSignatureFrom(caCert) == nil
+	})
+	if !signed {
+		return nil, fmt.Errorf("CRL %s is not signed by the CA", path)
+	}
+
+	var revoked []string
+	for _, entry := range crl.RevokedCertificateEntries {
+		revoked = append(revoked, entry.SerialNumber.String())
+	}
+
+	return revoked, nil
+}
+
+// verifyNotRevoked rejects a peer whose certificate chain contains a revoked certificate.
+// It is called after the chain has been verified against the CA.
+func verifyNotRevoked(revoked []string) func([][]byte, [][]*x509.Certificate) error {
+	return func(rawCerts [][]byte, verifiedChains [][]*x509.Certificate) error {
+		for _, chain := range verifiedChains {
+			for _, cert := range chain {
+				if slices.Contains(revoked, cert.SerialNumber.String()) {
+					return fmt.Errorf("certificate %q with serial %s has been revoked", cert.Subject.CommonName, cert.SerialNumber)
+				}
+			}
+		}
+
+		return nil
+	}
 }
@@ -1,9 +1,25 @@
 package utils_test
 
 import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
+
+	"google.golang.org/grpc/credentials"
 
 	"github.com/greenplum-db/gpdb/gpservice/constants"
 	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
@@ -82,7 +98,7 @@ func TestLoadClientCredentials(t *testing.T) {
 		if err == nil {
 			t.Fatalf("expected TLS error, did not receive one")
 		}
-		if err.Error() != "failed to add server CA's certificate" {
+		if err.Error() != "could not load client credentials: failed to add server CA's certificate" {
 			t.Errorf("expected TLS error, got %v", err)
 		}
 	})
@@ -92,3 +108,264 @@ func TestLoadClientCredentials(t *testing.T) {
 		t.Fatalf("Cannot remove test certificates: %v", err)
 	}
 }
+
+func TestMutualTLS(t *testing.T) {
+	out, err := exec.Command(constants.ShellPath, "-c", "../../generate_test_tls_certificates.sh `hostname`").CombinedOutput()
+	if err != nil {
+		t.Fatalf("Cannot generate test certificates: %v, stderror:%v", err, string(out))
+	}
+	defer os.RemoveAll("./certificates")
+
+	creds := utils.GpCredentials{
+		CACertPath:     "./certificates/ca-cert.pem",
+		ServerCertPath: "./certificates/server-cert.pem",
+		ServerKeyPath:  "./certificates/server-key.pem",
+		ClientCertPath: "./certificates/client-cert.pem",
+		ClientKeyPath:  "./certificates/client-key.pem",
+		TlsEnabled:     true,
+	}
+
+	t.Run("accepts a client certificate signed by the CA", func(t *testing.T) {
+		serverErr, clientErr := tlsHandshake(t, creds, creds, "localhost")
+		if serverErr != nil || clientErr != nil {
+			t.Fatalf("unexpected errors: server %v, client %v", serverErr, clientErr)
+		}
+	})
+
+	t.Run("uses the server certificate as the client certificate when not configured", func(t *testing.T) {
+		clientCreds := creds
+		clientCreds.ClientCertPath = ""
+		clientCreds.ClientKeyPath = ""
+
+		serverErr, clientErr := tlsHandshake(t, creds, clientCreds, "localhost")
+		if serverErr != nil || clientErr != nil {
+			t.Fatalf("unexpected errors: server %v, client %v", serverErr, clientErr)
+		}
+	})
+
+	t.Run("rejects a client certificate not signed by the CA", func(t *testing.T) {
+		clientCreds := creds
+		clientCreds.ClientCertPath, clientCreds.ClientKeyPath = writeSelfSignedCertificate(t)
+
+		// the client only presents a certificate issued by one of the CAs accepted by the server
+		serverErr, _ := tlsHandshake(t, creds, clientCreds, "localhost")
+		expected := "tls: client didn't provide a certificate"
+		if serverErr == nil || serverErr.Error() != expected {
+			t.Fatalf("got %v, want %s", serverErr, expected)
+		}
+	})
+
+	t.Run("rejects a server certificate which is not valid for the host", func(t *testing.T) {
+		_, clientErr := tlsHandshake(t, creds, creds, "sdw-unknown")
+		if clientErr == nil || !strings.Contains(clientErr.Error(), "certificate is valid for") {
+			t.Fatalf("got %v, want the certificate to be invalid for the host", clientErr)
+		}
+	})
+
+	t.Run("rejects a client certificate revoked by the CRL", func(t *testing.T) {
+		serverCreds := creds
+		serverCreds.CRLPath = writeRevocationList(t, "./certificates/client-cert.pem")
+
+		serverErr, _ := tlsHandshake(t, serverCreds, creds, "localhost")
+		if serverErr == nil || !strings.Contains(serverErr.Error(), `certificate "gpadmin" with serial`) {
+			t.Fatalf("got %v, want the client certificate to be revoked", serverErr)
+		}
+	})
+
+	t.Run("lets a caller without a client certificate through when tokens are accepted", func(t *testing.T) {
+		server, err := creds.LoadTokenServerCredentials()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		client, err := creds.LoadTokenClientCredentials()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		serverErr, clientErr := handshake(t, server, client, "localhost")
+		if serverErr != nil || clientErr != nil {
+			t.Fatalf("unexpected errors: server %v, client %v", serverErr, clientErr)
+		}
+	})
+
+	t.Run("still verifies the client certificates presented when tokens are accepted", func(t *testing.T) {
+		serverCreds := creds
+		serverCreds.CRLPath = writeRevocationList(t, "./certificates/client-cert.pem")
+
+		server, err := serverCreds.LoadTokenServerCredentials()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		client, err := creds.LoadClientCredentials()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		serverErr, _ := handshake(t, server, client, "localhost")
+		if serverErr == nil || !strings.Contains(serverErr.Error(), `certificate "gpadmin" with serial`) {
+			t.Fatalf("got %v, want the client certificate to be revoked", serverErr)
+		}
+	})
+
+	t.Run("requires a client certificate when tokens are not accepted", func(t *testing.T) {
+		server, err := creds.LoadServerCredentials()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		client, err := creds.LoadTokenClientCredentials()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		serverErr, _ := handshake(t, server, client, "localhost")
+		expected := "tls: client didn't provide a certificate"
+		if serverErr == nil || serverErr.Error() != expected {
+			t.Fatalf("got %v, want %s", serverErr, expected)
+		}
+	})
+
+	t.Run("errors out when the minimum TLS version is not supported", func(t *testing.T) {
+		serverCreds := creds
+		serverCreds.MinTLSVersion = "1.1"
+
+		_, err := serverCreds.LoadServerCredentials()
+		expected := `could not load server credentials: unsupported TLS version "1.1", supported versions are 1.2 and 1.3`
+		if err == nil || err.Error() != expected {
+			t.Fatalf("got %v, want %s", err, expected)
+		}
+	})
+}
+
+// tlsHandshake performs a handshake between the server and client credentials over a loopback connection
+func tlsHandshake(t *testing.T, serverCreds, clientCreds utils.GpCredentials, authority string) (serverErr, clientErr error) {
+	t.Helper()
+
+	server, err := serverCreds.LoadServerCredentials()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	client, err := clientCreds.LoadClientCredentials()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return handshake(t, server, client, authority)
+}
+
+// handshake performs a handshake between the server and client transport credentials over a loopback connection
+func handshake(t *testing.T, server, client credentials.TransportCredentials, authority string) (serverErr, clientErr error) {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer listener.Close()
+
+	done := make(chan error, 1)
+	go func() {
+		serverConn, err := listener.Accept()
+		if err != nil {
+			done <- err
+			return
+		}
+		defer serverConn.Close()
+
+		conn, _, err := server.ServerHandshake(serverConn)
+		if err == nil {
+			// wait for the client to finish, as it only sends its certificate after the handshake in TLS 1.3
+			_, err = conn.Read(make([]byte, 1))
+		}
+		done <- err
+	}()
+
+	clientConn, err := net.Dial("tcp", listener.Addr().String())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer clientConn.Close()
+
+	conn, _, clientErr := client.ClientHandshake(context.Background(), authority, clientConn)
+	if clientErr == nil {
+		conn.Write([]byte("x")) // nolint
+	} else {
+		clientConn.Close()
+	}
+
+	return <-done, clientErr
+}
+
+func writeSelfSignedCertificate(t *testing.T) (certPath, keyPath string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "intruder"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	keyDer, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	dir := t.TempDir()
+	certPath = filepath.Join(dir, "cert.pem")
+	keyPath = filepath.Join(dir, "key.pem")
+	writeTestFile(t, certPath, string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})))
+	writeTestFile(t, keyPath, string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDer})))
+
+	return certPath, keyPath
+}
+
+// writeRevocationList writes a CRL signed by the test CA which revokes the given certificate
+func writeRevocationList(t *testing.T, revokedCertPath string) string {
+	t.Helper()
+
+	caPair, err := tls.LoadX509KeyPair("./certificates/ca-cert.pem", "./certificates/ca-key.pem")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	caCert, err := x509.ParseCertificate(caPair.Certificate[0])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	revokedPair, err := tls.LoadX509KeyPair(revokedCertPath, "./certificates/client-key.pem")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	revokedCert, err := x509.ParseCertificate(revokedPair.Certificate[0])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	template := &x509.RevocationList{
+		Number:     big.NewInt(1),
+		ThisUpdate: time.Now().Add(-time.Hour),
+		NextUpdate: time.Now().Add(time.Hour),
+		RevokedCertificateEntries: []x509.RevocationListEntry{
+			{SerialNumber: revokedCert.SerialNumber, RevocationTime: time.Now()},
+		},
+	}
+	der, err := x509.CreateRevocationList(rand.Reader, template, caCert, caPair.PrivateKey.(crypto.Signer))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "crl.pem")
+	writeTestFile(t, path, string(pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: der})))
+
+	return path
+}
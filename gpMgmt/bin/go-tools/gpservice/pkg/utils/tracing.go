@@ -0,0 +1,171 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/greenplum-db/gp-common-go-libs/gplog"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	TraceExporterOTLP = "otlp"
+	TraceExporterFile = "file"
+)
+
+// tracerName is the instrumentation scope of the spans of gpctl and the services
+const tracerName = "github.com/greenplum-db/gpdb/gpservice"
+
+// TraceFile returns the name of the file the spans of the service are written to by the file
+// exporter, by default in the log directory of each host. Each service has its own file, as the
+// hub and the agent share the log directory of their host.
+func TraceFile(service string) string {
+	return service + "_traces.json"
+}
+
+/*
+TracingConfig configures the export of the traces of gpctl, the hub and the agents. The
+trace context of gpctl is propagated to the hub and from the hub to the agents, so that a
+single trace shows the calls made to each host and the commands run on them.
+*/
+type TracingConfig struct {
+	// Exporter is either otlp, to send the spans to an OpenTelemetry collector, or file
+	Exporter string `json:"exporter"`
+
+	// Endpoint is the host:port of the OTLP gRPC collector, which is taken from the standard
+	// OTEL_EXPORTER_OTLP_ENDPOINT environment variable when not set
+	Endpoint string `json:"endpoint,omitempty"`
+	Insecure bool   `json:"insecure,omitempty"`
+
+	// File is the file the file exporter appends the spans to, which defaults to the TraceFile
+	// of each service in the log directory
+	File string `json:"file,omitempty"`
+
+	// SampleRatio is the fraction of the traces which are recorded, where 0 records none of them.
+	// The services record the traces started by gpctl whenever gpctl does.
+	SampleRatio float64 `json:"sampleRatio"`
+}
+
+func (conf *TracingConfig) Validate() error {
+	if conf == nil {
+		return nil
+	}
+
+	switch conf.Exporter {
+	case TraceExporterOTLP, TraceExporterFile:
+	default:
+		return fmt.Errorf("unsupported trace exporter %q, supported exporters are %s and %s", conf.Exporter, TraceExporterOTLP, TraceExporterFile)
+	}
+
+	if conf.SampleRatio < 0 || conf.SampleRatio > 1 {
+		return fmt.Errorf("invalid sample ratio %g, expected a value between 0 and 1", conf.SampleRatio)
+	}
+
+	return nil
+}
+
+/*
+SetupTracing installs the tracer provider which exports the spans of the given service as
+configured, and returns the function to call on exit to flush the spans which are not yet
+exported. Nothing is recorded when tracing is not configured.
+*/
+func SetupTracing(conf *TracingConfig, service, logDir string) (func(), error) {
+	if conf == nil {
+		return func() {}, nil
+	}
+
+	err := conf.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	var exporter sdktrace.SpanExporter
+	var file io.Closer
+	switch conf.Exporter {
+	case TraceExporterOTLP:
+		var opts []otlptracegrpc.Option
+		if conf.Endpoint != "" {
+			opts = append(opts, otlptracegrpc.WithEndpoint(conf.Endpoint))
+		}
+		if conf.Insecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+
+		exporter, err = otlptracegrpc.New(context.Background(), opts...)
+		if err != nil {
+			return nil, fmt.Errorf("could not create the OTLP trace exporter: %w", err)
+		}
+
+	case TraceExporterFile:
+		path := conf.File
+		if path == "" {
+			path = filepath.Join(logDir, TraceFile(service))
+		}
+
+		f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+		if err != nil {
+			return nil, fmt.Errorf("could not open trace file %s: %w", path, err)
+		}
+		file = f
+
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(f))
+		if err != nil {
+			f.Close()
+			return nil, fmt.Errorf("could not create the file trace exporter: %w", err)
+		}
+	}
+
+	host, _ := System.GetHostName()
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(conf.SampleRatio))),
+		sdktrace.WithResource(resource.NewSchemaless(
+			attribute.String("service.name", service),
+			attribute.String("host.name", host),
+		)),
+	)
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		err := provider.Shutdown(ctx)
+		if err != nil {
+			gplog.Warn("Could not export the traces: %v", err)
+		}
+
+		if file != nil {
+			file.Close()
+		}
+	}, nil
+}
+
+// StartSpan starts a span as a child of the span in the context, if any
+func StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// EndSpan ends the span, marking it as failed with the error, if any
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
@@ -0,0 +1,170 @@
+package utils_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/greenplum-db/gp-common-go-libs/testhelper"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/greenplum-db/gpdb/gpservice/pkg/postgres"
+	"github.com/greenplum-db/gpdb/gpservice/pkg/utils"
+	"github.com/greenplum-db/gpdb/gpservice/testutils/exectest"
+)
+
+func TestSetupTracing(t *testing.T) {
+	testhelper.SetupTestLogger()
+	defer otel.SetTracerProvider(noop.NewTracerProvider())
+
+	t.Run("does nothing when the tracing is not configured", func(t *testing.T) {
+		logDir := t.TempDir()
+
+		shutdown, err := utils.SetupTracing(nil, "gpservice-hub", logDir)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		shutdown()
+
+		if _, err := os.Stat(filepath.Join(logDir, utils.TraceFile("gpservice-hub"))); !os.IsNotExist(err) {
+			t.Fatalf("expected no trace file to be created, got %v", err)
+		}
+	})
+
+	t.Run("writes the spans to the trace file of the service in the log directory", func(t *testing.T) {
+		logDir := t.TempDir()
+
+		shutdown, err := utils.SetupTracing(&utils.TracingConfig{Exporter: utils.TraceExporterFile, SampleRatio: 1}, "gpservice-hub", logDir)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		_, span := utils.StartSpan(context.Background(), "init step coordinator created")
+		span.End()
+		shutdown()
+
+		if _, err := os.Stat(filepath.Join(logDir, utils.TraceFile("gpservice-agent"))); !os.IsNotExist(err) {
+			t.Fatalf("expected the trace file of the agent to not be written, got %v", err)
+		}
+
+		contents, err := os.ReadFile(filepath.Join(logDir, "gpservice-hub_traces.json"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		for _, expected := range []string{`"Name":"init step coordinator created"`, `"Value":"gpservice-hub"`} {
+			if !strings.Contains(string(contents), expected) {
+				t.Fatalf("got %s, want it to contain %s", contents, expected)
+			}
+		}
+	})
+
+	t.Run("records no traces when the sample ratio is 0", func(t *testing.T) {
+		logDir := t.TempDir()
+
+		shutdown, err := utils.SetupTracing(&utils.TracingConfig{Exporter: utils.TraceExporterFile, SampleRatio: 0}, "gpctl", logDir)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		_, span := utils.StartSpan(context.Background(), "gpctl init")
+		span.End()
+		shutdown()
+
+		contents, err := os.ReadFile(filepath.Join(logDir, utils.TraceFile("gpctl")))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(contents) != 0 {
+			t.Fatalf("got %s, want no spans to be recorded", contents)
+		}
+	})
+
+	t.Run("errors out when the trace file cannot be opened", func(t *testing.T) {
+		conf := &utils.TracingConfig{Exporter: utils.TraceExporterFile, File: filepath.Join(t.TempDir(), "missing", "traces.json")}
+
+		_, err := utils.SetupTracing(conf, "gpservice-hub", "")
+		if err == nil || !strings.HasPrefix(err.Error(), "could not open trace file") {
+			t.Fatalf("got %v, want an error opening the trace file", err)
+		}
+	})
+
+	cases := []struct {
+		name     string
+		conf     utils.TracingConfig
+		expected string
+	}{
+		{"errors out when the exporter is not supported", utils.TracingConfig{Exporter: "jaeger"}, `unsupported trace exporter "jaeger", supported exporters are otlp and file`},
+		{"errors out when the sample ratio is invalid", utils.TracingConfig{Exporter: utils.TraceExporterOTLP, SampleRatio: 1.5}, "invalid sample ratio 1.5, expected a value between 0 and 1"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := utils.SetupTracing(&tc.conf, "gpservice-hub", t.TempDir())
+			if err == nil || err.Error() != tc.expected {
+				t.Fatalf("got %v, want %s", err, tc.expected)
+			}
+		})
+	}
+}
+
+func TestCommandSpans(t *testing.T) {
+	testhelper.SetupTestLogger()
+
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(noop.NewTracerProvider())
+
+	cmd := &postgres.Initdb{PgData: "pgdata"}
+
+	t.Run("does not record the commands which are not run on behalf of a traced request", func(t *testing.T) {
+		utils.System.ExecCommandContext = exectest.NewCommandContext(exectest.Success)
+		defer utils.ResetSystemFunctions()
+
+		_, err := utils.RunGpCommandContext(context.Background(), cmd, "gpHome")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if spans := recorder.Ended(); len(spans) != 0 {
+			t.Fatalf("got %d spans, want none", len(spans))
+		}
+	})
+
+	t.Run("records the commands under the span of the request", func(t *testing.T) {
+		utils.System.ExecCommandContext = exectest.NewCommandContext(exectest.Failure)
+		defer utils.ResetSystemFunctions()
+
+		ctx, parent := utils.StartSpan(context.Background(), "MakeSegment")
+		_, err := utils.RunGpCommandContext(ctx, cmd, "gpHome")
+		if err == nil {
+			t.Fatalf("expected error")
+		}
+		parent.End()
+
+		spans := recorder.Ended()
+		if len(spans) != 2 {
+			t.Fatalf("got %d spans, want the command and the request spans", len(spans))
+		}
+
+		span := spans[0]
+		if span.Parent().SpanID() != parent.SpanContext().SpanID() {
+			t.Fatalf("got parent %s, want %s", span.Parent().SpanID(), parent.SpanContext().SpanID())
+		}
+
+		if span.Status().Code != codes.Error {
+			t.Fatalf("got status %v, want %v", span.Status().Code, codes.Error)
+		}
+
+		if len(span.Attributes()) != 1 || span.Attributes()[0].Key != "command" {
+			t.Fatalf("got attributes %v, want the command", span.Attributes())
+		}
+	})
+}
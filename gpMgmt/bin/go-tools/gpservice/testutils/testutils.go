@@ -14,6 +14,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/greenplum-db/gpdb/gpservice/constants"
@@ -114,6 +115,60 @@ func (p *MockPlatform) SetServiceFileContent(content string) {
 	p.ServiceFileContent = content
 }
 
+/*
+MockRemoteExecutor records the commands run and the files copied on the remote hosts.
+The commands succeed on all the hosts with an empty output unless Result is set, in
+which case it is called to get the result for each host.
+*/
+type MockRemoteExecutor struct {
+	Commands []string
+	Copies   []string
+	Result   func(hostname, command string) *utils.RemoteResult
+
+	mutex sync.Mutex
+}
+
+func (e *MockRemoteExecutor) Run(hostnames []string, command string) utils.RemoteResults {
+	e.mutex.Lock()
+	e.Commands = append(e.Commands, command)
+	e.mutex.Unlock()
+
+	return e.results(hostnames, command)
+}
+
+func (e *MockRemoteExecutor) CopyFile(hostnames []string, source, destination string) utils.RemoteResults {
+	e.mutex.Lock()
+	e.Copies = append(e.Copies, destination)
+	e.mutex.Unlock()
+
+	return e.results(hostnames, fmt.Sprintf("copy %s %s", source, destination))
+}
+
+func (e *MockRemoteExecutor) results(hostnames []string, command string) utils.RemoteResults {
+	var results utils.RemoteResults
+	for _, host := range hostnames {
+		result := &utils.RemoteResult{Hostname: host}
+		if e.Result != nil {
+			result = e.Result(host, command)
+			result.Hostname = host
+		}
+		results = append(results, result)
+	}
+
+	return results
+}
+
+// SetMockRemoteExecutor replaces the remote executor with a mock which is reset once the test completes
+func SetMockRemoteExecutor(t *testing.T) *MockRemoteExecutor {
+	t.Helper()
+
+	executor := &MockRemoteExecutor{}
+	utils.Remote = executor
+	t.Cleanup(utils.ResetRemoteExecutor)
+
+	return executor
+}
+
 type MockCredentials struct {
 	TlsConnection credentials.TransportCredentials
 	Err           error